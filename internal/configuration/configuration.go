@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/spf13/viper"
@@ -18,8 +19,50 @@ const (
 	DefaultLogLevel = "info"
 	// DefaultProjectStorageLocalPath default local storage path
 	DefaultProjectStorageLocalPath = "storage/projects"
+	// DefaultProjectStorageGitCachePath default path where the git repositories are mirrored
+	DefaultProjectStorageGitCachePath = "storage/git"
+	// DefaultProjectStorageS3Region default region of the S3 storage
+	DefaultProjectStorageS3Region = "us-east-1"
+	// DefaultProjectStorageOCITimeout default time limit of a request to the OCI registries, including the transfer of the blob
+	DefaultProjectStorageOCITimeout = 10 * time.Minute
+	// DefaultProjectStorageS3Timeout default time limit of a request to the S3 storage, including the transfer of the object
+	DefaultProjectStorageS3Timeout = 10 * time.Minute
 	// DefaultProjectRepositoryLocalPath default local repository path
 	DefaultProjectRepositoryLocalPath = "repository/projects"
+	// DefaultTaskRepositoryLocalPath default local task repository path
+	DefaultTaskRepositoryLocalPath = "repository/tasks"
+	// DefaultTaskRepositoryType default task repository type
+	DefaultTaskRepositoryType = TaskRepositoryTypeMemory
+	// DefaultTaskExecutionTimeout default task execution timeout. Zero means no timeout
+	DefaultTaskExecutionTimeout = 0 * time.Second
+	// DefaultTaskMaxExecutionTimeout default maximum task execution timeout. Zero means no maximum
+	DefaultTaskMaxExecutionTimeout = 0 * time.Second
+	// DefaultTaskMaxOutputSize default maximum size of the output kept for each task, 10 MiB
+	DefaultTaskMaxOutputSize = 10 * 1024 * 1024
+	// DefaultTaskRetentionInterval default time between two runs of the task janitor
+	DefaultTaskRetentionInterval = 1 * time.Hour
+	// DefaultProjectLimitsMaxCompressionRatio default maximum compression ratio of the uploaded projects
+	DefaultProjectLimitsMaxCompressionRatio = 100
+	// DefaultProjectLimitsMaxEntries default maximum number of entries of the uploaded projects
+	DefaultProjectLimitsMaxEntries = 50000
+	// DefaultProjectLimitsMaxFileSize default maximum uncompressed size of each file of the uploaded projects, 100 MiB
+	DefaultProjectLimitsMaxFileSize = 100 * 1024 * 1024
+	// DefaultProjectLimitsMaxUncompressedSize default maximum uncompressed size of the uploaded projects, 1 GiB
+	DefaultProjectLimitsMaxUncompressedSize = 1024 * 1024 * 1024
+	// DefaultProjectLimitsMaxUploadSize default maximum size of the uploaded projects, 100 MiB
+	DefaultProjectLimitsMaxUploadSize = 100 * 1024 * 1024
+	// DefaultProjectTrashGracePeriod default time a deleted project is kept in the trash before it is purged
+	DefaultProjectTrashGracePeriod = 7 * 24 * time.Hour
+	// DefaultProjectTrashPurgeInterval default time between two purges of the deleted projects
+	DefaultProjectTrashPurgeInterval = 1 * time.Hour
+	// DefaultProjectUploadsLocalPath default path where the resumable uploads are staged
+	DefaultProjectUploadsLocalPath = "storage/uploads"
+	// DefaultProjectUploadsExpiration default time a resumable upload is kept without receiving a chunk
+	DefaultProjectUploadsExpiration = 24 * time.Hour
+	// DefaultProjectUploadsPurgeInterval default time between two purges of the expired resumable uploads
+	DefaultProjectUploadsPurgeInterval = 1 * time.Hour
+	// DefaultProjectUploadsStorage default storage where the chunks of the resumable uploads are staged
+	DefaultProjectUploadsStorage = ProjectUploadsStorageLocal
 
 	// ServerKey key for server configuration
 	ServerKey = "server"
@@ -39,6 +82,93 @@ const (
 	ProjectStorageTypeKey = "type"
 	// ProjectStorageLocalPathKey key for project storage local path configuration
 	ProjectStorageLocalPathKey = "local_path"
+	// ProjectStorageGitKey key for project git storage configuration
+	ProjectStorageGitKey = "git"
+	// ProjectStorageGitCachePathKey key for project git storage cache path configuration
+	ProjectStorageGitCachePathKey = "cache_path"
+	// ProjectStorageGitCredentialHostsKey key for project git storage credential hosts configuration
+	ProjectStorageGitCredentialHostsKey = "credential_hosts"
+	// ProjectStorageGitSSHKeyPathKey key for project git storage SSH key path configuration
+	ProjectStorageGitSSHKeyPathKey = "ssh_key_path"
+	// ProjectStorageGitTokenKey key for project git storage token configuration
+	ProjectStorageGitTokenKey = "token"
+	// ProjectStorageGitUsernameKey key for project git storage username configuration
+	ProjectStorageGitUsernameKey = "username"
+	// ProjectStorageOCIKey key for project OCI registry storage configuration
+	ProjectStorageOCIKey = "oci"
+	// ProjectStorageOCIAuthHostsKey key for project OCI registry storage authorization hosts configuration
+	ProjectStorageOCIAuthHostsKey = "auth_hosts"
+	// ProjectStorageOCIPasswordKey key for project OCI registry storage password configuration
+	ProjectStorageOCIPasswordKey = "password"
+	// ProjectStorageOCIPlainHTTPKey key for project OCI registry storage plain HTTP configuration
+	ProjectStorageOCIPlainHTTPKey = "plain_http"
+	// ProjectStorageOCIRegistryKey key for project OCI registry storage registry configuration
+	ProjectStorageOCIRegistryKey = "registry"
+	// ProjectStorageOCITimeoutKey key for project OCI registry storage request timeout configuration
+	ProjectStorageOCITimeoutKey = "timeout"
+	// ProjectStorageOCIUsernameKey key for project OCI registry storage username configuration
+	ProjectStorageOCIUsernameKey = "username"
+	// ProjectStorageS3Key key for project S3 storage configuration
+	ProjectStorageS3Key = "s3"
+	// ProjectStorageS3AccessKeyIDKey key for project S3 storage access key ID configuration
+	ProjectStorageS3AccessKeyIDKey = "access_key_id"
+	// ProjectStorageS3BucketKey key for project S3 storage bucket configuration
+	ProjectStorageS3BucketKey = "bucket"
+	// ProjectStorageS3EndpointKey key for project S3 storage endpoint configuration
+	ProjectStorageS3EndpointKey = "endpoint"
+	// ProjectStorageS3PathStyleKey key for project S3 storage path-style addressing configuration
+	ProjectStorageS3PathStyleKey = "path_style"
+	// ProjectStorageS3PrefixKey key for project S3 storage prefix configuration
+	ProjectStorageS3PrefixKey = "prefix"
+	// ProjectStorageS3RegionKey key for project S3 storage region configuration
+	ProjectStorageS3RegionKey = "region"
+	// ProjectStorageS3SecretAccessKeyKey key for project S3 storage secret access key configuration
+	ProjectStorageS3SecretAccessKeyKey = "secret_access_key"
+	// ProjectStorageS3SessionTokenKey key for project S3 storage session token configuration
+	ProjectStorageS3SessionTokenKey = "session_token"
+	// ProjectStorageS3TimeoutKey key for project S3 storage request timeout configuration
+	ProjectStorageS3TimeoutKey = "timeout"
+
+	// ProjectImportPathsKey key for project import paths configuration
+	ProjectImportPathsKey = "import_paths"
+
+	// ProjectLimitsKey key for project archive limits configuration
+	ProjectLimitsKey = "limits"
+	// ProjectLimitsMaxCompressionRatioKey key for project archive maximum compression ratio configuration
+	ProjectLimitsMaxCompressionRatioKey = "max_compression_ratio"
+	// ProjectLimitsMaxEntriesKey key for project archive maximum number of entries configuration
+	ProjectLimitsMaxEntriesKey = "max_entries"
+	// ProjectLimitsMaxFileSizeKey key for project archive maximum file size configuration
+	ProjectLimitsMaxFileSizeKey = "max_file_size"
+	// ProjectLimitsMaxUncompressedSizeKey key for project archive maximum uncompressed size configuration
+	ProjectLimitsMaxUncompressedSizeKey = "max_uncompressed_size"
+	// ProjectLimitsMaxUploadSizeKey key for project maximum upload size configuration
+	ProjectLimitsMaxUploadSizeKey = "max_upload_size"
+
+	// ProjectTrustPolicyKey key for project trust policy configuration
+	ProjectTrustPolicyKey = "trust_policy"
+	// ProjectTrustPolicyPublicKeysKey key for project trust policy public keys configuration
+	ProjectTrustPolicyPublicKeysKey = "public_keys"
+	// ProjectTrustPolicyRequireSignatureKey key for project trust policy required signatures configuration
+	ProjectTrustPolicyRequireSignatureKey = "require_signature"
+
+	// ProjectTrashKey key for project trash configuration
+	ProjectTrashKey = "trash"
+	// ProjectTrashGracePeriodKey key for project trash grace period configuration
+	ProjectTrashGracePeriodKey = "grace_period"
+	// ProjectTrashPurgeIntervalKey key for project trash purge interval configuration
+	ProjectTrashPurgeIntervalKey = "purge_interval"
+
+	// ProjectUploadsKey key for project resumable uploads configuration
+	ProjectUploadsKey = "uploads"
+	// ProjectUploadsExpirationKey key for project resumable uploads expiration configuration
+	ProjectUploadsExpirationKey = "expiration"
+	// ProjectUploadsLocalPathKey key for project resumable uploads local path configuration
+	ProjectUploadsLocalPathKey = "local_path"
+	// ProjectUploadsPurgeIntervalKey key for project resumable uploads purge interval configuration
+	ProjectUploadsPurgeIntervalKey = "purge_interval"
+	// ProjectUploadsStorageKey key for project resumable uploads storage configuration
+	ProjectUploadsStorageKey = "storage"
 
 	// ProjectRepositoryKey key for project repository configuration
 	ProjectRepositoryKey = "repository"
@@ -46,6 +176,52 @@ const (
 	ProjectRepositoryTypeKey = "type"
 	// ProjectRepositoryLocalPathKey key for project repository local path configuration
 	ProjectRepositoryLocalPathKey = "local_path"
+
+	// TaskKey key for task configuration
+	TaskKey = "task"
+	// TaskDefaultExecutionTimeoutKey key for task default execution timeout configuration
+	TaskDefaultExecutionTimeoutKey = "default_execution_timeout"
+	// TaskMaxExecutionTimeoutKey key for task maximum execution timeout configuration
+	TaskMaxExecutionTimeoutKey = "max_execution_timeout"
+	// TaskMaxOutputSizeKey key for task maximum output size configuration
+	TaskMaxOutputSizeKey = "max_output_size"
+
+	// TaskRepositoryKey key for task repository configuration
+	TaskRepositoryKey = "repository"
+	// TaskRepositoryTypeKey key for task repository type configuration
+	TaskRepositoryTypeKey = "type"
+	// TaskRepositoryLocalPathKey key for task repository local path configuration
+	TaskRepositoryLocalPathKey = "local_path"
+
+	// TaskRetentionKey key for task retention configuration
+	TaskRetentionKey = "retention"
+	// TaskRetentionIntervalKey key for task retention interval configuration
+	TaskRetentionIntervalKey = "interval"
+	// TaskRetentionMaxAgeKey key for task retention maximum age configuration
+	TaskRetentionMaxAgeKey = "max_age"
+	// TaskRetentionMaxCountPerProjectKey key for task retention maximum count per project configuration
+	TaskRetentionMaxCountPerProjectKey = "max_count_per_project"
+	// TaskRetentionMaxCountPerStatusKey key for task retention maximum count per status configuration
+	TaskRetentionMaxCountPerStatusKey = "max_count_per_status"
+
+	// ProjectUploadsStorageLocal identifies the uploads storage that stages the chunks in the uploads local path
+	ProjectUploadsStorageLocal = "local"
+	// ProjectUploadsStorageS3 identifies the uploads storage that stages the chunks in the S3 storage
+	ProjectUploadsStorageS3 = "s3"
+
+	// TaskRepositoryTypeLocal identifies the task repository that persists the tasks in the local filesystem
+	TaskRepositoryTypeLocal = "local"
+	// TaskRepositoryTypeMemory identifies the task repository that keeps the tasks in memory
+	TaskRepositoryTypeMemory = "memory"
+)
+
+var (
+	// ErrTaskDefaultExecutionTimeoutExceedsMaximum represents an error when the default execution timeout is greater than the maximum execution timeout
+	ErrTaskDefaultExecutionTimeoutExceedsMaximum = fmt.Errorf("task default execution timeout exceeds the maximum execution timeout")
+	// ErrTaskExecutionTimeoutNotInSeconds represents an error when an execution timeout is not a whole number of seconds, since the tasks set their execution timeout in seconds
+	ErrTaskExecutionTimeoutNotInSeconds = fmt.Errorf("task execution timeout must be a whole number of seconds")
+	// ErrProjectTrustPolicyWithoutPublicKeys represents an error when the trust policy requires signatures but does not allow any public key
+	ErrProjectTrustPolicyWithoutPublicKeys = fmt.Errorf("project trust policy requires signatures but does not allow any public key")
 )
 
 // Configuration represents the configuration
@@ -63,22 +239,164 @@ type ServerConfiguration struct {
 	LogLevel string `mapstructure:"log_level" validate:"required,oneof=debug info warn error"`
 	// Project represents the project configuration
 	Project ProjectConfiguration `mapstructure:"project"`
+	// Task represents the task configuration
+	Task TaskConfiguration `mapstructure:"task"`
+}
+
+// TaskConfiguration represents the task configuration
+type TaskConfiguration struct {
+	// DefaultExecutionTimeout represents the execution timeout applied to the tasks that do not define one. Zero means no timeout
+	DefaultExecutionTimeout time.Duration `mapstructure:"default_execution_timeout" validate:"gte=0"`
+	// MaxExecutionTimeout represents the maximum execution timeout a task can request. Zero means no maximum
+	MaxExecutionTimeout time.Duration `mapstructure:"max_execution_timeout" validate:"gte=0"`
+	// MaxOutputSize represents the maximum size, in bytes, of the output kept for each task. The output exceeding it is discarded. Zero means no maximum
+	MaxOutputSize int64 `mapstructure:"max_output_size" validate:"gte=0"`
+	// TaskRepositoryConfiguration represents the task repository configuration
+	TaskRepositoryConfiguration TaskRepositoryConfiguration `mapstructure:"repository"`
+	// TaskRetentionConfiguration represents the task retention configuration
+	TaskRetentionConfiguration TaskRetentionConfiguration `mapstructure:"retention"`
+}
+
+// TaskRetentionConfiguration represents the task retention configuration. A zero value on any of the limits means that limit is not applied
+type TaskRetentionConfiguration struct {
+	// Interval represents the time between two runs of the task janitor
+	Interval time.Duration `mapstructure:"interval" validate:"gt=0"`
+	// MaxAge represents the maximum time a finished task is kept
+	MaxAge time.Duration `mapstructure:"max_age" validate:"gte=0"`
+	// MaxCountPerProject represents the maximum number of finished tasks kept for each project
+	MaxCountPerProject int `mapstructure:"max_count_per_project" validate:"gte=0"`
+	// MaxCountPerStatus represents the maximum number of finished tasks kept for each status
+	MaxCountPerStatus int `mapstructure:"max_count_per_status" validate:"gte=0"`
+}
+
+// TaskRepositoryConfiguration represents the task repository configuration
+type TaskRepositoryConfiguration struct {
+	// LocalRepositoryPath represents the local repository path
+	LocalRepositoryPath string `mapstructure:"local_path" validate:"required_if=Type local"`
+	// Type represents the type of repository (e.g., memory, local)
+	Type string `mapstructure:"type" validate:"required,oneof=local memory"`
 }
 
 // ProjectConfiguration represents the project configuration
 type ProjectConfiguration struct {
-	ProjectStorageConfiguration    ProjectStorageConfiguration    `mapstructure:"storage"`
-	ProjectRepositoryConfiguration ProjectRepositoryConfiguration `mapstructure:"repository"`
+	// ImportPaths represents the directories and archives whose projects are imported when the server starts
+	ImportPaths                     []string                        `mapstructure:"import_paths"`
+	ProjectLimitsConfiguration      ProjectLimitsConfiguration      `mapstructure:"limits"`
+	ProjectStorageConfiguration     ProjectStorageConfiguration     `mapstructure:"storage"`
+	ProjectRepositoryConfiguration  ProjectRepositoryConfiguration  `mapstructure:"repository"`
+	ProjectTrashConfiguration       ProjectTrashConfiguration       `mapstructure:"trash"`
+	ProjectTrustPolicyConfiguration ProjectTrustPolicyConfiguration `mapstructure:"trust_policy"`
+	ProjectUploadsConfiguration     ProjectUploadsConfiguration     `mapstructure:"uploads"`
+}
+
+// ProjectLimitsConfiguration represents the limits applied to the uploaded source code of the projects. A zero value on any of the limits means that limit is not applied
+type ProjectLimitsConfiguration struct {
+	// MaxCompressionRatio represents the maximum ratio between the uncompressed size of the content and the size of the uploaded file
+	MaxCompressionRatio int64 `mapstructure:"max_compression_ratio" validate:"gte=0"`
+	// MaxEntries represents the maximum number of entries of the uploaded file
+	MaxEntries int `mapstructure:"max_entries" validate:"gte=0"`
+	// MaxFileSize represents the maximum uncompressed size, in bytes, of each file
+	MaxFileSize int64 `mapstructure:"max_file_size" validate:"gte=0"`
+	// MaxUncompressedSize represents the maximum uncompressed size, in bytes, of the whole content
+	MaxUncompressedSize int64 `mapstructure:"max_uncompressed_size" validate:"gte=0"`
+	// MaxUploadSize represents the maximum size, in bytes, of the uploaded file
+	MaxUploadSize int64 `mapstructure:"max_upload_size" validate:"gte=0"`
+}
+
+// ProjectTrashConfiguration represents the configuration of the trash where the deleted projects are kept until they are purged
+type ProjectTrashConfiguration struct {
+	// GracePeriod represents the time a deleted project can be restored before it is purged
+	GracePeriod time.Duration `mapstructure:"grace_period" validate:"gt=0"`
+	// PurgeInterval represents the time between two purges of the deleted projects
+	PurgeInterval time.Duration `mapstructure:"purge_interval" validate:"gt=0"`
+}
+
+// ProjectTrustPolicyConfiguration represents the trust policy used to verify the signatures of the projects before they are executed
+type ProjectTrustPolicyConfiguration struct {
+	// PublicKeys represents the paths of the PEM encoded public keys allowed to sign the projects
+	PublicKeys []string `mapstructure:"public_keys"`
+	// RequireSignature represents whether the unsigned projects are refused
+	RequireSignature bool `mapstructure:"require_signature"`
+}
+
+// ProjectUploadsConfiguration represents the configuration of the resumable uploads of the source code of the projects
+type ProjectUploadsConfiguration struct {
+	// Expiration represents the time an upload is kept without receiving a chunk before it is purged
+	Expiration time.Duration `mapstructure:"expiration" validate:"gt=0"`
+	// LocalPath represents the path where the uploads are staged until they are finalized into a project
+	LocalPath string `mapstructure:"local_path" validate:"required"`
+	// PurgeInterval represents the time between two purges of the expired uploads
+	PurgeInterval time.Duration `mapstructure:"purge_interval" validate:"gt=0"`
+	// Storage represents where the chunks of the uploads are staged. The uploads records are always kept in the local path, while the chunks are staged either in the local path or in the S3 storage
+	Storage string `mapstructure:"storage" validate:"required,oneof=local s3"`
 }
 
 // ProjectStorageConfiguration represents the project storage configuration
 type ProjectStorageConfiguration struct {
+	// Git represents the configuration of the projects stored in git repositories
+	Git ProjectStorageGitConfiguration `mapstructure:"git"`
 	// LocalStoragePath represents the local storage path
 	LocalStoragePath string `mapstructure:"local_path" validate:"required_if=Type local"`
+	// OCI represents the configuration of the projects stored in OCI registries
+	OCI ProjectStorageOCIConfiguration `mapstructure:"oci"`
+	// S3 represents the configuration of the projects stored in an S3-compatible object storage
+	S3 ProjectStorageS3Configuration `mapstructure:"s3"`
 	// Type represents the type of storage (e.g., memory, local, http, registry, etc.)
 	Type string `mapstructure:"type" validate:"required,oneof=local memory"`
 }
 
+// ProjectStorageGitConfiguration represents the configuration of the projects stored in git repositories
+type ProjectStorageGitConfiguration struct {
+	// CachePath represents the path where the git repositories are mirrored
+	CachePath string `mapstructure:"cache_path" validate:"required"`
+	// CredentialHosts represents the hosts, optionally followed by a port, of the git repositories the SSH key and the token are sent to
+	CredentialHosts []string `mapstructure:"credential_hosts"`
+	// SSHKeyPath represents the path of the private key used to access the git repositories over SSH
+	SSHKeyPath string `mapstructure:"ssh_key_path"`
+	// Token represents the token used to access the git repositories over HTTP
+	Token string `mapstructure:"token"`
+	// Username represents the username sent along with the token
+	Username string `mapstructure:"username"`
+}
+
+// ProjectStorageOCIConfiguration represents the configuration of the projects stored in OCI registries
+type ProjectStorageOCIConfiguration struct {
+	// AuthHosts represents the hosts, other than the registry, of the authorization services allowed to receive the credentials
+	AuthHosts []string `mapstructure:"auth_hosts"`
+	// Password represents the password or the token used to authenticate to the registries
+	Password string `mapstructure:"password"`
+	// PlainHTTP represents whether the registries are reached over HTTP instead of HTTPS
+	PlainHTTP bool `mapstructure:"plain_http"`
+	// Registry represents the host, optionally followed by a port, of the registry the credentials are sent to
+	Registry string `mapstructure:"registry"`
+	// Timeout represents the time limit of a request to the registries, including the transfer of the blob
+	Timeout time.Duration `mapstructure:"timeout" validate:"gt=0"`
+	// Username represents the user used to authenticate to the registries. The requests are anonymous when it is not provided
+	Username string `mapstructure:"username"`
+}
+
+// ProjectStorageS3Configuration represents the configuration of the projects stored in an S3-compatible object storage. The S3 storage is enabled when the bucket is provided
+type ProjectStorageS3Configuration struct {
+	// AccessKeyID represents the access key used to sign the requests to the object storage
+	AccessKeyID string `mapstructure:"access_key_id"`
+	// Bucket represents the bucket where the projects are stored
+	Bucket string `mapstructure:"bucket"`
+	// Endpoint represents the URL of the object storage. The AWS endpoint of the region is used when it is not provided
+	Endpoint string `mapstructure:"endpoint" validate:"omitempty,url"`
+	// PathStyle represents whether the bucket is addressed on the request path instead of on the host name
+	PathStyle bool `mapstructure:"path_style"`
+	// Prefix represents the prefix of the keys of the projects in the bucket
+	Prefix string `mapstructure:"prefix"`
+	// Region represents the region of the object storage
+	Region string `mapstructure:"region" validate:"required"`
+	// SecretAccessKey represents the secret key used to sign the requests to the object storage
+	SecretAccessKey string `mapstructure:"secret_access_key"`
+	// SessionToken represents the token of temporary credentials
+	SessionToken string `mapstructure:"session_token"`
+	// Timeout represents the time limit of a request to the object storage, including the transfer of the object
+	Timeout time.Duration `mapstructure:"timeout" validate:"gt=0"`
+}
+
 // ProjectRepositoryConfiguration represents the project repository configuration
 type ProjectRepositoryConfiguration struct {
 	// LocalRepositoryPath represents the local repository path
@@ -96,18 +414,86 @@ func LoadConfig() (*Configuration, error) {
 
 	v.BindEnv(strings.Join([]string{ServerKey, HTTPListenAddressKey}, "."))
 	v.BindEnv(strings.Join([]string{ServerKey, LogLevelKey}, "."))
+	v.BindEnv(strings.Join([]string{ServerKey, ProjectKey, ProjectImportPathsKey}, "."))
+	v.BindEnv(strings.Join([]string{ServerKey, ProjectKey, ProjectLimitsKey, ProjectLimitsMaxCompressionRatioKey}, "."))
+	v.BindEnv(strings.Join([]string{ServerKey, ProjectKey, ProjectLimitsKey, ProjectLimitsMaxEntriesKey}, "."))
+	v.BindEnv(strings.Join([]string{ServerKey, ProjectKey, ProjectLimitsKey, ProjectLimitsMaxFileSizeKey}, "."))
+	v.BindEnv(strings.Join([]string{ServerKey, ProjectKey, ProjectLimitsKey, ProjectLimitsMaxUncompressedSizeKey}, "."))
+	v.BindEnv(strings.Join([]string{ServerKey, ProjectKey, ProjectLimitsKey, ProjectLimitsMaxUploadSizeKey}, "."))
 	v.BindEnv(strings.Join([]string{ServerKey, ProjectKey, ProjectRepositoryKey, ProjectRepositoryLocalPathKey}, "."))
 	v.BindEnv(strings.Join([]string{ServerKey, ProjectKey, ProjectRepositoryKey, ProjectRepositoryTypeKey}, "."))
+	v.BindEnv(strings.Join([]string{ServerKey, ProjectKey, ProjectStorageKey, ProjectStorageGitKey, ProjectStorageGitCachePathKey}, "."))
+	v.BindEnv(strings.Join([]string{ServerKey, ProjectKey, ProjectStorageKey, ProjectStorageGitKey, ProjectStorageGitCredentialHostsKey}, "."))
+	v.BindEnv(strings.Join([]string{ServerKey, ProjectKey, ProjectStorageKey, ProjectStorageGitKey, ProjectStorageGitSSHKeyPathKey}, "."))
+	v.BindEnv(strings.Join([]string{ServerKey, ProjectKey, ProjectStorageKey, ProjectStorageGitKey, ProjectStorageGitTokenKey}, "."))
+	v.BindEnv(strings.Join([]string{ServerKey, ProjectKey, ProjectStorageKey, ProjectStorageGitKey, ProjectStorageGitUsernameKey}, "."))
 	v.BindEnv(strings.Join([]string{ServerKey, ProjectKey, ProjectStorageKey, ProjectStorageLocalPathKey}, "."))
+	v.BindEnv(strings.Join([]string{ServerKey, ProjectKey, ProjectStorageKey, ProjectStorageOCIKey, ProjectStorageOCIAuthHostsKey}, "."))
+	v.BindEnv(strings.Join([]string{ServerKey, ProjectKey, ProjectStorageKey, ProjectStorageOCIKey, ProjectStorageOCIPasswordKey}, "."))
+	v.BindEnv(strings.Join([]string{ServerKey, ProjectKey, ProjectStorageKey, ProjectStorageOCIKey, ProjectStorageOCIPlainHTTPKey}, "."))
+	v.BindEnv(strings.Join([]string{ServerKey, ProjectKey, ProjectStorageKey, ProjectStorageOCIKey, ProjectStorageOCIRegistryKey}, "."))
+	v.BindEnv(strings.Join([]string{ServerKey, ProjectKey, ProjectStorageKey, ProjectStorageOCIKey, ProjectStorageOCITimeoutKey}, "."))
+	v.BindEnv(strings.Join([]string{ServerKey, ProjectKey, ProjectStorageKey, ProjectStorageOCIKey, ProjectStorageOCIUsernameKey}, "."))
+	v.BindEnv(strings.Join([]string{ServerKey, ProjectKey, ProjectStorageKey, ProjectStorageS3Key, ProjectStorageS3AccessKeyIDKey}, "."))
+	v.BindEnv(strings.Join([]string{ServerKey, ProjectKey, ProjectStorageKey, ProjectStorageS3Key, ProjectStorageS3BucketKey}, "."))
+	v.BindEnv(strings.Join([]string{ServerKey, ProjectKey, ProjectStorageKey, ProjectStorageS3Key, ProjectStorageS3EndpointKey}, "."))
+	v.BindEnv(strings.Join([]string{ServerKey, ProjectKey, ProjectStorageKey, ProjectStorageS3Key, ProjectStorageS3PathStyleKey}, "."))
+	v.BindEnv(strings.Join([]string{ServerKey, ProjectKey, ProjectStorageKey, ProjectStorageS3Key, ProjectStorageS3PrefixKey}, "."))
+	v.BindEnv(strings.Join([]string{ServerKey, ProjectKey, ProjectStorageKey, ProjectStorageS3Key, ProjectStorageS3RegionKey}, "."))
+	v.BindEnv(strings.Join([]string{ServerKey, ProjectKey, ProjectStorageKey, ProjectStorageS3Key, ProjectStorageS3SecretAccessKeyKey}, "."))
+	v.BindEnv(strings.Join([]string{ServerKey, ProjectKey, ProjectStorageKey, ProjectStorageS3Key, ProjectStorageS3SessionTokenKey}, "."))
+	v.BindEnv(strings.Join([]string{ServerKey, ProjectKey, ProjectStorageKey, ProjectStorageS3Key, ProjectStorageS3TimeoutKey}, "."))
 	v.BindEnv(strings.Join([]string{ServerKey, ProjectKey, ProjectStorageKey, ProjectStorageTypeKey}, "."))
+	v.BindEnv(strings.Join([]string{ServerKey, ProjectKey, ProjectTrashKey, ProjectTrashGracePeriodKey}, "."))
+	v.BindEnv(strings.Join([]string{ServerKey, ProjectKey, ProjectTrashKey, ProjectTrashPurgeIntervalKey}, "."))
+	v.BindEnv(strings.Join([]string{ServerKey, ProjectKey, ProjectTrustPolicyKey, ProjectTrustPolicyPublicKeysKey}, "."))
+	v.BindEnv(strings.Join([]string{ServerKey, ProjectKey, ProjectTrustPolicyKey, ProjectTrustPolicyRequireSignatureKey}, "."))
+	v.BindEnv(strings.Join([]string{ServerKey, ProjectKey, ProjectUploadsKey, ProjectUploadsExpirationKey}, "."))
+	v.BindEnv(strings.Join([]string{ServerKey, ProjectKey, ProjectUploadsKey, ProjectUploadsLocalPathKey}, "."))
+	v.BindEnv(strings.Join([]string{ServerKey, ProjectKey, ProjectUploadsKey, ProjectUploadsPurgeIntervalKey}, "."))
+	v.BindEnv(strings.Join([]string{ServerKey, ProjectKey, ProjectUploadsKey, ProjectUploadsStorageKey}, "."))
+	v.BindEnv(strings.Join([]string{ServerKey, TaskKey, TaskDefaultExecutionTimeoutKey}, "."))
+	v.BindEnv(strings.Join([]string{ServerKey, TaskKey, TaskMaxExecutionTimeoutKey}, "."))
+	v.BindEnv(strings.Join([]string{ServerKey, TaskKey, TaskMaxOutputSizeKey}, "."))
+	v.BindEnv(strings.Join([]string{ServerKey, TaskKey, TaskRepositoryKey, TaskRepositoryLocalPathKey}, "."))
+	v.BindEnv(strings.Join([]string{ServerKey, TaskKey, TaskRepositoryKey, TaskRepositoryTypeKey}, "."))
+	v.BindEnv(strings.Join([]string{ServerKey, TaskKey, TaskRetentionKey, TaskRetentionIntervalKey}, "."))
+	v.BindEnv(strings.Join([]string{ServerKey, TaskKey, TaskRetentionKey, TaskRetentionMaxAgeKey}, "."))
+	v.BindEnv(strings.Join([]string{ServerKey, TaskKey, TaskRetentionKey, TaskRetentionMaxCountPerProjectKey}, "."))
+	v.BindEnv(strings.Join([]string{ServerKey, TaskKey, TaskRetentionKey, TaskRetentionMaxCountPerStatusKey}, "."))
 	v.BindEnv(strings.Join([]string{ServerKey, WorkerPoolSizeKey}, "."))
 
 	v.SetDefault(strings.Join([]string{ServerKey, HTTPListenAddressKey}, "."), DefaultHTTPListenAddress)
 	v.SetDefault(strings.Join([]string{ServerKey, LogLevelKey}, "."), DefaultLogLevel)
+	v.SetDefault(strings.Join([]string{ServerKey, ProjectKey, ProjectLimitsKey, ProjectLimitsMaxCompressionRatioKey}, "."), DefaultProjectLimitsMaxCompressionRatio)
+	v.SetDefault(strings.Join([]string{ServerKey, ProjectKey, ProjectLimitsKey, ProjectLimitsMaxEntriesKey}, "."), DefaultProjectLimitsMaxEntries)
+	v.SetDefault(strings.Join([]string{ServerKey, ProjectKey, ProjectLimitsKey, ProjectLimitsMaxFileSizeKey}, "."), DefaultProjectLimitsMaxFileSize)
+	v.SetDefault(strings.Join([]string{ServerKey, ProjectKey, ProjectLimitsKey, ProjectLimitsMaxUncompressedSizeKey}, "."), DefaultProjectLimitsMaxUncompressedSize)
+	v.SetDefault(strings.Join([]string{ServerKey, ProjectKey, ProjectLimitsKey, ProjectLimitsMaxUploadSizeKey}, "."), DefaultProjectLimitsMaxUploadSize)
 	v.SetDefault(strings.Join([]string{ServerKey, ProjectKey, ProjectRepositoryKey, ProjectRepositoryLocalPathKey}, "."), DefaultProjectRepositoryLocalPath)
 	v.SetDefault(strings.Join([]string{ServerKey, ProjectKey, ProjectRepositoryKey, ProjectRepositoryTypeKey}, "."), "local")
+	v.SetDefault(strings.Join([]string{ServerKey, ProjectKey, ProjectStorageKey, ProjectStorageGitKey, ProjectStorageGitCachePathKey}, "."), DefaultProjectStorageGitCachePath)
 	v.SetDefault(strings.Join([]string{ServerKey, ProjectKey, ProjectStorageKey, ProjectStorageLocalPathKey}, "."), DefaultProjectStorageLocalPath)
+	v.SetDefault(strings.Join([]string{ServerKey, ProjectKey, ProjectStorageKey, ProjectStorageOCIKey, ProjectStorageOCITimeoutKey}, "."), DefaultProjectStorageOCITimeout)
+	v.SetDefault(strings.Join([]string{ServerKey, ProjectKey, ProjectStorageKey, ProjectStorageS3Key, ProjectStorageS3RegionKey}, "."), DefaultProjectStorageS3Region)
+	v.SetDefault(strings.Join([]string{ServerKey, ProjectKey, ProjectStorageKey, ProjectStorageS3Key, ProjectStorageS3TimeoutKey}, "."), DefaultProjectStorageS3Timeout)
 	v.SetDefault(strings.Join([]string{ServerKey, ProjectKey, ProjectStorageKey, ProjectStorageTypeKey}, "."), "local")
+	v.SetDefault(strings.Join([]string{ServerKey, ProjectKey, ProjectTrashKey, ProjectTrashGracePeriodKey}, "."), DefaultProjectTrashGracePeriod)
+	v.SetDefault(strings.Join([]string{ServerKey, ProjectKey, ProjectTrashKey, ProjectTrashPurgeIntervalKey}, "."), DefaultProjectTrashPurgeInterval)
+	v.SetDefault(strings.Join([]string{ServerKey, ProjectKey, ProjectTrustPolicyKey, ProjectTrustPolicyRequireSignatureKey}, "."), false)
+	v.SetDefault(strings.Join([]string{ServerKey, ProjectKey, ProjectUploadsKey, ProjectUploadsExpirationKey}, "."), DefaultProjectUploadsExpiration)
+	v.SetDefault(strings.Join([]string{ServerKey, ProjectKey, ProjectUploadsKey, ProjectUploadsLocalPathKey}, "."), DefaultProjectUploadsLocalPath)
+	v.SetDefault(strings.Join([]string{ServerKey, ProjectKey, ProjectUploadsKey, ProjectUploadsPurgeIntervalKey}, "."), DefaultProjectUploadsPurgeInterval)
+	v.SetDefault(strings.Join([]string{ServerKey, ProjectKey, ProjectUploadsKey, ProjectUploadsStorageKey}, "."), DefaultProjectUploadsStorage)
+	v.SetDefault(strings.Join([]string{ServerKey, TaskKey, TaskDefaultExecutionTimeoutKey}, "."), DefaultTaskExecutionTimeout)
+	v.SetDefault(strings.Join([]string{ServerKey, TaskKey, TaskMaxExecutionTimeoutKey}, "."), DefaultTaskMaxExecutionTimeout)
+	v.SetDefault(strings.Join([]string{ServerKey, TaskKey, TaskMaxOutputSizeKey}, "."), DefaultTaskMaxOutputSize)
+	v.SetDefault(strings.Join([]string{ServerKey, TaskKey, TaskRepositoryKey, TaskRepositoryLocalPathKey}, "."), DefaultTaskRepositoryLocalPath)
+	v.SetDefault(strings.Join([]string{ServerKey, TaskKey, TaskRepositoryKey, TaskRepositoryTypeKey}, "."), DefaultTaskRepositoryType)
+	v.SetDefault(strings.Join([]string{ServerKey, TaskKey, TaskRetentionKey, TaskRetentionIntervalKey}, "."), DefaultTaskRetentionInterval)
+	v.SetDefault(strings.Join([]string{ServerKey, TaskKey, TaskRetentionKey, TaskRetentionMaxAgeKey}, "."), 0*time.Second)
+	v.SetDefault(strings.Join([]string{ServerKey, TaskKey, TaskRetentionKey, TaskRetentionMaxCountPerProjectKey}, "."), 0)
+	v.SetDefault(strings.Join([]string{ServerKey, TaskKey, TaskRetentionKey, TaskRetentionMaxCountPerStatusKey}, "."), 0)
 	v.SetDefault(strings.Join([]string{ServerKey, WorkerPoolSizeKey}, "."), DefaultWorkerPoolSize)
 
 	replacer := strings.NewReplacer(".", "_")
@@ -146,7 +532,28 @@ func (c *Configuration) Validate() error {
 		return err
 	}
 
-	return validate.Struct(c)
+	err = validate.Struct(c)
+	if err != nil {
+		return err
+	}
+
+	task := c.Server.Task
+	for _, timeout := range []time.Duration{task.DefaultExecutionTimeout, task.MaxExecutionTimeout} {
+		if timeout%time.Second != 0 {
+			return fmt.Errorf("%w: %s", ErrTaskExecutionTimeoutNotInSeconds, timeout)
+		}
+	}
+
+	if task.MaxExecutionTimeout > 0 && task.DefaultExecutionTimeout > task.MaxExecutionTimeout {
+		return fmt.Errorf("%w: %s > %s", ErrTaskDefaultExecutionTimeoutExceedsMaximum, task.DefaultExecutionTimeout, task.MaxExecutionTimeout)
+	}
+
+	trustPolicy := c.Server.Project.ProjectTrustPolicyConfiguration
+	if trustPolicy.RequireSignature && len(trustPolicy.PublicKeys) == 0 {
+		return ErrProjectTrustPolicyWithoutPublicKeys
+	}
+
+	return nil
 }
 
 func listenAddrValidation(fl validator.FieldLevel) bool {
@@ -135,6 +135,13 @@ type AnsiblePlaybookParameters struct {
 
 	// BecomeUser is ansble-playbook's become user
 	BecomeUser string `json:"become_user,omitempty"`
+
+	// Parameters defined by Ransidble, which are not part of the ansible-playbook's command line.
+
+	// ExecutionTimeout is the maximum time, in seconds, the task can be running. When it is exceeded, the execution is stopped and the task status is set to TIMEOUT
+	ExecutionTimeout int `json:"execution_timeout,omitempty" validate:"gte=0"`
+	// StructuredResult runs the playbook using the JSON stdout callback to provide the structured result of the execution in the task
+	StructuredResult bool `json:"structured_result,omitempty" validate:"boolean"`
 }
 
 // AnsiblePlaybookRequirements represents an entity containing the parameters to install roles and collections dependencies
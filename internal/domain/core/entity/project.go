@@ -1,8 +1,13 @@
 package entity
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"net/url"
+	"regexp"
 	"strings"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 )
@@ -10,40 +15,162 @@ import (
 const (
 	// ProjectTypeLocal represents a local project
 	ProjectTypeLocal = "local"
+	// ProjectTypeGit represents a project stored in a git repository
+	ProjectTypeGit = "git"
+	// ProjectTypeS3 represents a project stored in an S3-compatible object storage
+	ProjectTypeS3 = "s3"
+	// ProjectTypeOCI represents a project stored as an artifact in an OCI distribution registry
+	ProjectTypeOCI = "oci"
 	// ProjectFormatPlain represents project in plain format
 	ProjectFormatPlain = "plain"
 	// ProjectFormatTarGz represents a project in tar.gz format
 	ProjectFormatTarGz = "targz"
+	// ProjectFormatTar represents a project in uncompressed tar format
+	ProjectFormatTar = "tar"
+	// ProjectFormatTarZst represents a project in tar.zst format
+	ProjectFormatTarZst = "tarzst"
+	// ProjectFormatTarXz represents a project in tar.xz format
+	ProjectFormatTarXz = "tarxz"
+	// ProjectFormatZip represents a project in zip format
+	ProjectFormatZip = "zip"
+	// ProjectFormatOCI represents a project packed as an OCI image-layout tarball
+	ProjectFormatOCI = "oci"
+	// ProjectFormatAuto represents the format of an uploaded project that must be detected from its content. It is never stored, since it is resolved to the detected format when the project is created
+	ProjectFormatAuto = "auto"
 
 	// ExtensionTarGz represents the tar.gz extension. It is not lead with a dot
 	ExtensionTarGz = "tar.gz"
+	// ExtensionTar represents the tar extension. It is not lead with a dot
+	ExtensionTar = "tar"
+	// ExtensionTarZst represents the tar.zst extension. It is not lead with a dot
+	ExtensionTarZst = "tar.zst"
+	// ExtensionTarXz represents the tar.xz extension. It is not lead with a dot
+	ExtensionTarXz = "tar.xz"
+	// ExtensionZip represents the zip extension. It is not lead with a dot
+	ExtensionZip = "zip"
+	// ExtensionOCI represents the OCI image-layout tarball extension. It is not lead with a dot
+	ExtensionOCI = "oci.tar"
 
-	// FallbackVersion represents the fallback version for a project if the version is not provided
-	FallbackVersion = "latest"
+	// ProjectFormatDetectionHeaderSize represents the number of bytes from the beginning of a project source code required to detect its format
+	ProjectFormatDetectionHeaderSize = 512
+
+	// FallbackVersion represents the fallback version for a project if the version is not provided. It must be a concrete version, distinct from the alias of the most recent version, so the first version of a project can still be pinned once newer versions are added
+	FallbackVersion = "v1"
+	// LatestVersion represents the alias that resolves to the most recent version of a project
+	LatestVersion = "latest"
 )
 
 var (
+	// ErrProjectRevisionMismatch represents the error when the revision of a project does not match the revision expected by a conditional update
+	ErrProjectRevisionMismatch = errors.New("project revision mismatch")
+
+	// projectVersionPattern represents the characters allowed in a project version. The version is used to identify the version records and the stored source code, so it must not contain path separators
+	projectVersionPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._+-]{0,127}$`)
+
 	// projectFomatToExtension represents the project format to extension mapping
 	projectFomatToExtension = map[string]string{
-		ProjectFormatPlain: "",
-		ProjectFormatTarGz: ExtensionTarGz,
+		ProjectFormatPlain:  "",
+		ProjectFormatTarGz:  ExtensionTarGz,
+		ProjectFormatTar:    ExtensionTar,
+		ProjectFormatTarZst: ExtensionTarZst,
+		ProjectFormatTarXz:  ExtensionTarXz,
+		ProjectFormatZip:    ExtensionZip,
+		ProjectFormatOCI:    ExtensionOCI,
+	}
+
+	// projectFormatMagicNumbers represents the magic numbers that identify the compressed project formats
+	projectFormatMagicNumbers = []struct {
+		format string
+		magic  []byte
+	}{
+		{format: ProjectFormatTarGz, magic: []byte{0x1f, 0x8b}},
+		{format: ProjectFormatTarZst, magic: []byte{0x28, 0xb5, 0x2f, 0xfd}},
+		{format: ProjectFormatTarXz, magic: []byte{0xfd, '7', 'z', 'X', 'Z', 0x00}},
+		{format: ProjectFormatZip, magic: []byte{'P', 'K', 0x03, 0x04}},
+		{format: ProjectFormatZip, magic: []byte{'P', 'K', 0x05, 0x06}},
 	}
+
+	// tarMagicNumberOffset represents the offset of the magic number in a tar header
+	tarMagicNumberOffset = 257
+	// tarMagicNumber represents the magic number of the POSIX and GNU tar formats
+	tarMagicNumber = []byte("ustar")
 )
 
-// Project entity represents a project
+// Project entity represents a project. Each version of a project is represented by its own Project entity, being the project itself its most recent version
 type Project struct {
-	// Format represents the project format. This field is required and must be one of the following values: plain, targz
-	Format string `json:"format" validate:"required,oneof=plain targz"`
+	// Contents represents the Ansible content discovered in the project tree when the project is created. It is not set when the contents cannot be discovered, such as for the projects stored in a git repository
+	Contents *ProjectContents `json:"contents,omitempty"`
+	// CreatedAt represents the time when the project version is created
+	CreatedAt string `json:"created_at,omitempty"`
+	// DeletedAt represents the time when the project is deleted. It is only set on the deleted projects, which are kept in the trash until they are purged or restored
+	DeletedAt string `json:"deleted_at,omitempty"`
+	// Digest represents the sha256 digest of the stored source code, computed when the source code is uploaded. It is verified before the project is unpacked
+	Digest string `json:"digest,omitempty"`
+	// Format represents the project format. This field is required and must be one of the following values: plain, targz, tar, tarzst, tarxz, zip, oci
+	Format string `json:"format" validate:"required,oneof=plain targz tar tarzst tarxz zip oci"`
+	// Manifest represents the ransidble.yaml manifest shipped with the project, which is parsed and validated when the project is created
+	Manifest *ProjectManifest `json:"manifest,omitempty"`
 	// Name represents the project name. This field is required
 	Name string `json:"name" validate:"required"`
+	// OCI represents the OCI artifact of the project. This field is required when the project format is oci
+	OCI *ProjectOCISource `json:"oci,omitempty" validate:"required_if=Format oci"`
 	// Reference represents the project source. This field is required
 	Reference string `json:"reference" validate:"required"`
-	// Storage represents the project type. This field is required and must be one of the following values: local
-	Storage string `json:"storage" validate:"required,oneof=local"`
+	// Revision represents the revision of the stored project record, which changes every time the record is written. It is set by the repository and it is not persisted as part of the project
+	Revision string `json:"-"`
+	// Signature represents the base64 encoded detached signature of the uploaded source code. It is verified against the trust policy before the project is unpacked
+	Signature string `json:"signature,omitempty"`
+	// Git represents the git repository where the project is stored. This field is required when the project storage is git
+	Git *ProjectGitSource `json:"git,omitempty" validate:"required_if=Storage git"`
+	// Storage represents the project type. This field is required and must be one of the following values: local, git, s3, oci
+	Storage string `json:"storage" validate:"required,oneof=local git s3 oci"`
 	// Version represents the project version. This field is required
 	Version string `json:"version,omitempty" validate:"required"`
 }
 
+// ProjectGitSource represents the location of a project stored in a git repository
+type ProjectGitSource struct {
+	// Ref represents the branch, tag or commit to fetch. The default branch of the repository is fetched when it is not provided
+	Ref string `json:"ref,omitempty" validate:"omitempty,startsnotwith=-"`
+	// Subdirectory represents the directory of the repository where the project is located. The repository root is used when it is not provided
+	Subdirectory string `json:"subdirectory,omitempty" validate:"omitempty,startsnotwith=/"`
+	// URL represents the git repository URL
+	URL string `json:"url" validate:"required,startsnotwith=-"`
+}
+
+// projectGitSchemes represents the transports allowed to fetch the projects stored in git repositories. The local transports are not allowed, so the repositories of the server can not be registered as projects
+var projectGitSchemes = map[string]struct{}{
+	"git":   {},
+	"http":  {},
+	"https": {},
+	"ssh":   {},
+}
+
+// NewProjectGitSource creates a new project git source instance
+func NewProjectGitSource(url, ref, subdirectory string) *ProjectGitSource {
+	return &ProjectGitSource{
+		Ref:          ref,
+		Subdirectory: subdirectory,
+		URL:          url,
+	}
+}
+
+// ProjectOCISource represents the OCI artifact of a project, either fetched from a registry or uploaded as an OCI image-layout tarball
+type ProjectOCISource struct {
+	// Digest represents the digest of the artifact manifest. The project is pinned to it, so the artifact content can not change after the project is created
+	Digest string `json:"digest" validate:"required"`
+	// Reference represents the artifact reference in the registry. It is empty when the artifact is uploaded
+	Reference string `json:"reference,omitempty" validate:"omitempty,startsnotwith=-"`
+}
+
+// NewProjectOCISource creates a new project OCI source instance
+func NewProjectOCISource(reference, digest string) *ProjectOCISource {
+	return &ProjectOCISource{
+		Digest:    digest,
+		Reference: reference,
+	}
+}
+
 // NewProject creates a new project instance
 func NewProject(name, version, reference, format, storage string) *Project {
 
@@ -71,10 +198,78 @@ func (p *Project) ProjectSourceCodeExtension() (string, error) {
 	return ext, nil
 }
 
+// IsPurgeable returns true when the deleted project has been kept in the trash for the grace period at the given time. A deleted project whose deletion time can not be parsed is purgeable, since it would never be purged otherwise
+func (p *Project) IsPurgeable(now time.Time, gracePeriod time.Duration) bool {
+	deletedAt, err := time.Parse(time.RFC3339, p.DeletedAt)
+	if err != nil {
+		return true
+	}
+
+	return !now.Before(deletedAt.Add(gracePeriod))
+}
+
 // Validate validates the project entity
 func (p *Project) Validate() error {
 	validate := validator.New()
-	return validate.Struct(p)
+	err := validate.Struct(p)
+	if err != nil {
+		return err
+	}
+
+	if p.Git != nil {
+		_, _, err = ParseProjectGitURL(p.Git.URL)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ParseProjectGitURL returns the scheme and the host of a git repository URL. The URL is either a URL using the git, http, https or ssh scheme, or an scp-like address such as git@example.com:project.git, whose scheme is ssh. The local paths, the file URLs and the remote helpers are rejected
+func ParseProjectGitURL(repositoryURL string) (string, string, error) {
+
+	if repositoryURL == "" || strings.HasPrefix(repositoryURL, "-") {
+		return "", "", fmt.Errorf("invalid git repository URL: %s", repositoryURL)
+	}
+
+	// the remote helpers are addressed as <transport>::<address>
+	helper := strings.Index(repositoryURL, "::")
+	scheme := strings.Index(repositoryURL, "://")
+	if helper >= 0 && (scheme < 0 || helper < scheme) {
+		return "", "", fmt.Errorf("invalid git repository URL: %s", repositoryURL)
+	}
+
+	if strings.Contains(repositoryURL, "://") {
+		parsed, err := url.Parse(repositoryURL)
+		if err != nil {
+			return "", "", fmt.Errorf("invalid git repository URL: %s", repositoryURL)
+		}
+
+		scheme := strings.ToLower(parsed.Scheme)
+		if _, allowed := projectGitSchemes[scheme]; !allowed || parsed.Hostname() == "" {
+			return "", "", fmt.Errorf("invalid git repository URL: %s", repositoryURL)
+		}
+
+		return scheme, parsed.Host, nil
+	}
+
+	// git takes an address as a local path when a slash is found before the first colon
+	colon := strings.Index(repositoryURL, ":")
+	if colon <= 0 || strings.Contains(repositoryURL[:colon], "/") {
+		return "", "", fmt.Errorf("invalid git repository URL: %s", repositoryURL)
+	}
+
+	host := repositoryURL[:colon]
+	if at := strings.LastIndex(host, "@"); at >= 0 {
+		host = host[at+1:]
+	}
+
+	if host == "" {
+		return "", "", fmt.Errorf("invalid git repository URL: %s", repositoryURL)
+	}
+
+	return "ssh", host, nil
 }
 
 // GetExtensionFromFormat returns the project source code extension from the project format
@@ -96,7 +291,7 @@ func GetExtensionFromFormat(format string) (string, error) {
 // ValidateProjectFormat validates the project format
 func ValidateProjectFormat(format string) error {
 	validate := validator.New()
-	err := validate.Var(format, "required,oneof=plain targz")
+	err := validate.Var(format, "required,oneof=plain targz tar tarzst tarxz zip oci")
 	if err != nil {
 		return fmt.Errorf("invalid format: %s", format)
 	}
@@ -104,10 +299,27 @@ func ValidateProjectFormat(format string) error {
 	return nil
 }
 
+// DetectProjectFormat returns the format of a packed project source code, sniffing the magic number located on its first bytes. The header should contain, at least, the first ProjectFormatDetectionHeaderSize bytes of the source code, or the whole source code when it is shorter
+func DetectProjectFormat(header []byte) (string, error) {
+
+	for _, candidate := range projectFormatMagicNumbers {
+		if bytes.HasPrefix(header, candidate.magic) {
+			return candidate.format, nil
+		}
+	}
+
+	if len(header) >= tarMagicNumberOffset+len(tarMagicNumber) &&
+		bytes.Equal(header[tarMagicNumberOffset:tarMagicNumberOffset+len(tarMagicNumber)], tarMagicNumber) {
+		return ProjectFormatTar, nil
+	}
+
+	return "", fmt.Errorf("unable to detect the project format")
+}
+
 // ValidateProjectStorage validates the project storage
 func ValidateProjectStorage(storage string) error {
 	validate := validator.New()
-	err := validate.Var(storage, "required,oneof=local")
+	err := validate.Var(storage, "required,oneof=local git s3 oci")
 
 	if err != nil {
 		return fmt.Errorf("invalid storage type: %s", storage)
@@ -116,6 +328,19 @@ func ValidateProjectStorage(storage string) error {
 	return nil
 }
 
+// ValidateProjectVersion validates the project version
+func ValidateProjectVersion(version string) error {
+	if version == LatestVersion {
+		return fmt.Errorf("reserved version: %s", version)
+	}
+
+	if !projectVersionPattern.MatchString(version) {
+		return fmt.Errorf("invalid version: %s", version)
+	}
+
+	return nil
+}
+
 // ValidateProjectFileExtension validates the project file extension
 func ValidateProjectFileExtension(file string) error {
 	has := strings.HasSuffix(file, ExtensionTarGz)
@@ -0,0 +1,126 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrProjectUploadSizeExceeded is returned when the uploaded source code of a project exceeds the maximum upload size
+	ErrProjectUploadSizeExceeded = errors.New("project upload size exceeded")
+	// ErrProjectArchiveLimitExceeded is returned when the content of a packed source code exceeds any of the archive limits
+	ErrProjectArchiveLimitExceeded = errors.New("project archive limit exceeded")
+)
+
+// ProjectArchiveLimits describes the limits applied to the packed source code of the projects, which protect the server against decompression bombs. A zero value on any of its limits means that limit is not applied
+type ProjectArchiveLimits struct {
+	// MaxCompressionRatio is the maximum ratio between the uncompressed size of the content and the size of the packed source code
+	MaxCompressionRatio int64
+	// MaxEntries is the maximum number of entries of the packed source code
+	MaxEntries int
+	// MaxFileSize is the maximum uncompressed size of each file
+	MaxFileSize int64
+	// MaxUncompressedSize is the maximum uncompressed size of the whole content
+	MaxUncompressedSize int64
+	// MaxUploadSize is the maximum size of the uploaded source code
+	MaxUploadSize int64
+}
+
+// ProjectArchiveUsage accounts the content of a packed source code while it is inspected or extracted, to check it against the archive limits
+type ProjectArchiveUsage struct {
+	// CompressedSize is the size of the packed source code
+	CompressedSize int64
+	// Entries is the number of entries accounted
+	Entries int
+	// UncompressedSize is the uncompressed size of the files accounted
+	UncompressedSize int64
+
+	limits *ProjectArchiveLimits
+}
+
+// IsEnabled returns true when any limit is set
+func (l *ProjectArchiveLimits) IsEnabled() bool {
+	if l == nil {
+		return false
+	}
+
+	return l.MaxCompressionRatio > 0 || l.MaxEntries > 0 || l.MaxFileSize > 0 || l.MaxUncompressedSize > 0 || l.MaxUploadSize > 0
+}
+
+// CheckUploadSize returns an error when the size of the uploaded source code exceeds the maximum upload size
+func (l *ProjectArchiveLimits) CheckUploadSize(size int64) error {
+	if l == nil || l.MaxUploadSize <= 0 || size <= l.MaxUploadSize {
+		return nil
+	}
+
+	return fmt.Errorf("%w: the size of %d bytes exceeds the maximum of %d bytes", ErrProjectUploadSizeExceeded, size, l.MaxUploadSize)
+}
+
+// NewUsage returns the usage to account the content of a packed source code of the given size. It returns nil when no limit is set, and a nil usage accounts nothing
+func (l *ProjectArchiveLimits) NewUsage(compressedSize int64) *ProjectArchiveUsage {
+	if !l.IsEnabled() {
+		return nil
+	}
+
+	return &ProjectArchiveUsage{
+		CompressedSize: compressedSize,
+		limits:         l,
+	}
+}
+
+// AddEntry accounts an entry of the packed source code, along with its uncompressed size, and returns an error when any archive limit is exceeded. The size is accounted before the entry is extracted, so the limits are enforced before filling the disk
+func (u *ProjectArchiveUsage) AddEntry(name string, size int64) error {
+	if u == nil {
+		return nil
+	}
+
+	u.Entries++
+	u.UncompressedSize += size
+
+	if u.limits.MaxEntries > 0 && u.Entries > u.limits.MaxEntries {
+		return fmt.Errorf("%w: the number of entries exceeds the maximum of %d", ErrProjectArchiveLimitExceeded, u.limits.MaxEntries)
+	}
+
+	if u.limits.MaxFileSize > 0 && size > u.limits.MaxFileSize {
+		return fmt.Errorf("%w: the size of %s, %d bytes, exceeds the maximum file size of %d bytes", ErrProjectArchiveLimitExceeded, name, size, u.limits.MaxFileSize)
+	}
+
+	if u.limits.MaxUncompressedSize > 0 && u.UncompressedSize > u.limits.MaxUncompressedSize {
+		return fmt.Errorf("%w: the uncompressed size exceeds the maximum of %d bytes", ErrProjectArchiveLimitExceeded, u.limits.MaxUncompressedSize)
+	}
+
+	if u.limits.MaxCompressionRatio > 0 && u.CompressedSize > 0 && u.UncompressedSize > u.CompressedSize*u.limits.MaxCompressionRatio {
+		return fmt.Errorf("%w: the compression ratio exceeds the maximum of %d", ErrProjectArchiveLimitExceeded, u.limits.MaxCompressionRatio)
+	}
+
+	return nil
+}
+
+// RemainingUncompressedSize returns the uncompressed size that can still be accounted without exceeding the maximum uncompressed size nor the maximum compression ratio, and false when neither limit is set
+func (u *ProjectArchiveUsage) RemainingUncompressedSize() (int64, bool) {
+	var remaining int64
+	limited := false
+
+	if u == nil {
+		return 0, false
+	}
+
+	if u.limits.MaxUncompressedSize > 0 {
+		remaining = u.limits.MaxUncompressedSize - u.UncompressedSize
+		limited = true
+	}
+
+	if u.limits.MaxCompressionRatio > 0 && u.CompressedSize > 0 {
+		ratioRemaining := u.CompressedSize*u.limits.MaxCompressionRatio - u.UncompressedSize
+		if !limited || ratioRemaining < remaining {
+			remaining = ratioRemaining
+		}
+		limited = true
+	}
+
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	return remaining, limited
+}
@@ -0,0 +1,184 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectArchiveLimitsIsEnabled(t *testing.T) {
+	tests := []struct {
+		desc     string
+		limits   *ProjectArchiveLimits
+		expected bool
+	}{
+		{desc: "Testing nil project archive limits are not enabled", limits: nil, expected: false},
+		{desc: "Testing project archive limits without limits are not enabled", limits: &ProjectArchiveLimits{}, expected: false},
+		{desc: "Testing project archive limits with a maximum compression ratio are enabled", limits: &ProjectArchiveLimits{MaxCompressionRatio: 100}, expected: true},
+		{desc: "Testing project archive limits with a maximum number of entries are enabled", limits: &ProjectArchiveLimits{MaxEntries: 10}, expected: true},
+		{desc: "Testing project archive limits with a maximum file size are enabled", limits: &ProjectArchiveLimits{MaxFileSize: 10}, expected: true},
+		{desc: "Testing project archive limits with a maximum uncompressed size are enabled", limits: &ProjectArchiveLimits{MaxUncompressedSize: 10}, expected: true},
+		{desc: "Testing project archive limits with a maximum upload size are enabled", limits: &ProjectArchiveLimits{MaxUploadSize: 10}, expected: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			assert.Equal(t, test.expected, test.limits.IsEnabled())
+		})
+	}
+}
+
+func TestProjectArchiveLimitsCheckUploadSize(t *testing.T) {
+	tests := []struct {
+		desc   string
+		limits *ProjectArchiveLimits
+		size   int64
+		err    error
+	}{
+		{desc: "Testing checking the upload size without limits", limits: nil, size: 1000},
+		{desc: "Testing checking an upload size below the maximum", limits: &ProjectArchiveLimits{MaxUploadSize: 1000}, size: 1000},
+		{desc: "Testing error checking an upload size above the maximum", limits: &ProjectArchiveLimits{MaxUploadSize: 1000}, size: 1001, err: ErrProjectUploadSizeExceeded},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			err := test.limits.CheckUploadSize(test.size)
+			if test.err != nil {
+				assert.ErrorIs(t, err, test.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestProjectArchiveUsageAddEntry(t *testing.T) {
+	type entry struct {
+		name string
+		size int64
+	}
+
+	tests := []struct {
+		desc           string
+		limits         *ProjectArchiveLimits
+		compressedSize int64
+		entries        []entry
+		err            error
+	}{
+		{
+			desc:           "Testing accounting entries without limits",
+			limits:         &ProjectArchiveLimits{},
+			compressedSize: 10,
+			entries:        []entry{{"site.yml", 1000}, {"inventory.yml", 1000}},
+		},
+		{
+			desc:           "Testing accounting entries within the limits",
+			limits:         &ProjectArchiveLimits{MaxCompressionRatio: 10, MaxEntries: 2, MaxFileSize: 100, MaxUncompressedSize: 200},
+			compressedSize: 20,
+			entries:        []entry{{"site.yml", 100}, {"inventory.yml", 100}},
+		},
+		{
+			desc:           "Testing error accounting more entries than the maximum",
+			limits:         &ProjectArchiveLimits{MaxEntries: 1},
+			compressedSize: 20,
+			entries:        []entry{{"site.yml", 0}, {"inventory.yml", 0}},
+			err:            ErrProjectArchiveLimitExceeded,
+		},
+		{
+			desc:           "Testing error accounting a file larger than the maximum file size",
+			limits:         &ProjectArchiveLimits{MaxFileSize: 100},
+			compressedSize: 20,
+			entries:        []entry{{"site.yml", 101}},
+			err:            ErrProjectArchiveLimitExceeded,
+		},
+		{
+			desc:           "Testing error accounting files larger than the maximum uncompressed size",
+			limits:         &ProjectArchiveLimits{MaxUncompressedSize: 150},
+			compressedSize: 20,
+			entries:        []entry{{"site.yml", 100}, {"inventory.yml", 100}},
+			err:            ErrProjectArchiveLimitExceeded,
+		},
+		{
+			desc:           "Testing error accounting files exceeding the maximum compression ratio",
+			limits:         &ProjectArchiveLimits{MaxCompressionRatio: 10},
+			compressedSize: 20,
+			entries:        []entry{{"site.yml", 100}, {"inventory.yml", 101}},
+			err:            ErrProjectArchiveLimitExceeded,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			var err error
+			usage := test.limits.NewUsage(test.compressedSize)
+			for _, entry := range test.entries {
+				err = usage.AddEntry(entry.name, entry.size)
+				if err != nil {
+					break
+				}
+			}
+
+			if test.err != nil {
+				assert.ErrorIs(t, err, test.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestProjectArchiveUsageRemainingUncompressedSize(t *testing.T) {
+	tests := []struct {
+		desc              string
+		usage             *ProjectArchiveUsage
+		expectedRemaining int64
+		expectedLimited   bool
+	}{
+		{
+			desc:  "Testing the remaining uncompressed size of a nil usage",
+			usage: nil,
+		},
+		{
+			desc:  "Testing the remaining uncompressed size without size limits",
+			usage: (&ProjectArchiveLimits{MaxEntries: 10}).NewUsage(20),
+		},
+		{
+			desc:              "Testing the remaining uncompressed size bounded by the maximum uncompressed size",
+			usage:             (&ProjectArchiveLimits{MaxCompressionRatio: 100, MaxUncompressedSize: 150}).NewUsage(20),
+			expectedRemaining: 150,
+			expectedLimited:   true,
+		},
+		{
+			desc:              "Testing the remaining uncompressed size bounded by the maximum compression ratio",
+			usage:             (&ProjectArchiveLimits{MaxCompressionRatio: 5, MaxUncompressedSize: 150}).NewUsage(20),
+			expectedRemaining: 100,
+			expectedLimited:   true,
+		},
+		{
+			desc:              "Testing the remaining uncompressed size of a usage that reached the limits",
+			usage:             &ProjectArchiveUsage{CompressedSize: 20, UncompressedSize: 200, limits: &ProjectArchiveLimits{MaxUncompressedSize: 150}},
+			expectedRemaining: 0,
+			expectedLimited:   true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			remaining, limited := test.usage.RemainingUncompressedSize()
+			assert.Equal(t, test.expectedRemaining, remaining)
+			assert.Equal(t, test.expectedLimited, limited)
+		})
+	}
+}
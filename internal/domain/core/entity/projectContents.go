@@ -0,0 +1,74 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+)
+
+var (
+	// ErrProjectPlaybookNotFound is returned when a playbook is not a file of the project tree
+	ErrProjectPlaybookNotFound = errors.New("playbook not found in project")
+	// ErrProjectInventoryNotFound is returned when an inventory is neither a file nor a directory of the project tree
+	ErrProjectInventoryNotFound = errors.New("inventory not found in project")
+)
+
+// ProjectContents represents the Ansible content discovered in the project tree when the project is created. The paths are relative to the root of the project tree and sorted
+type ProjectContents struct {
+	// AnsibleConfigs represents the ansible.cfg files
+	AnsibleConfigs []string `json:"ansible_configs,omitempty"`
+	// Files represents every file of the project tree, including the files whose content is not inspected. It is used to check that the playbooks and inventories referenced by a task exist, and it is nil for the projects whose contents were discovered before the files were listed
+	Files []string `json:"files"`
+	// Inventories represents the inventory files and directories
+	Inventories []string `json:"inventories,omitempty"`
+	// Manifest represents the content of the ransidble.yaml manifest found at the root of the project tree, which is parsed when the project is created
+	Manifest []byte `json:"-"`
+	// Playbooks represents the YAML files holding a list of plays
+	Playbooks []string `json:"playbooks,omitempty"`
+	// Requirements represents the requirements.yml files, listing the roles and collections the project depends on
+	Requirements []string `json:"requirements,omitempty"`
+	// Roles represents the role directories
+	Roles []string `json:"roles,omitempty"`
+}
+
+// CheckPlaybooks returns an error when any of the playbooks is not a file of the project tree. The playbooks are not checked against the playbooks discovered in the project, since the discovery is heuristic, and they are not checked at all when the files of the project are not listed
+func (c *ProjectContents) CheckPlaybooks(playbooks []string) error {
+	if c == nil || c.Files == nil {
+		return nil
+	}
+
+	missing := []string{}
+	for _, playbook := range playbooks {
+		if path.IsAbs(playbook) {
+			continue
+		}
+
+		if !slices.Contains(c.Files, path.Clean(playbook)) {
+			missing = append(missing, playbook)
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: %s", ErrProjectPlaybookNotFound, strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
+// CheckInventory returns an error when the inventory is neither a file nor a directory of the project tree. The inline host lists and the absolute paths are not checked, as well as the inventories of the projects whose files are not listed
+func (c *ProjectContents) CheckInventory(inventory string) error {
+	if c == nil || c.Files == nil || inventory == "" || strings.Contains(inventory, ",") || path.IsAbs(inventory) {
+		return nil
+	}
+
+	cleanInventory := path.Clean(inventory)
+	for _, file := range c.Files {
+		if cleanInventory == file || strings.HasPrefix(file, cleanInventory+"/") {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %s", ErrProjectInventoryNotFound, inventory)
+}
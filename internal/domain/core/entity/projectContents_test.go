@@ -0,0 +1,82 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectContentsCheckPlaybooks(t *testing.T) {
+	contents := &ProjectContents{
+		Files:     []string{".playbooks/hidden.yml", "hosts.ini", "playbooks/deploy.yml", "site.yml"},
+		Playbooks: []string{"playbooks/deploy.yml", "site.yml"},
+	}
+
+	tests := []struct {
+		desc      string
+		contents  *ProjectContents
+		playbooks []string
+		err       error
+	}{
+		{desc: "Testing checking the playbooks of a project", contents: contents, playbooks: []string{"site.yml", "./playbooks/deploy.yml"}},
+		{desc: "Testing checking a playbook of a project that is not discovered as a playbook", contents: contents, playbooks: []string{".playbooks/hidden.yml"}},
+		{desc: "Testing checking the playbooks of a project whose contents are not discovered", contents: nil, playbooks: []string{"unknown.yml"}},
+		{desc: "Testing checking the playbooks of a project whose files are not listed", contents: &ProjectContents{Playbooks: []string{"site.yml"}}, playbooks: []string{"unknown.yml"}},
+		{desc: "Testing checking an absolute playbook path, which is not checked", contents: contents, playbooks: []string{"/opt/playbooks/site.yml"}},
+		{desc: "Testing error checking a playbook that is not in the project", contents: contents, playbooks: []string{"site.yml", "unknown.yml"}, err: ErrProjectPlaybookNotFound},
+		{desc: "Testing error checking a playbook that is a directory of the project", contents: contents, playbooks: []string{"playbooks"}, err: ErrProjectPlaybookNotFound},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			err := test.contents.CheckPlaybooks(test.playbooks)
+			if test.err != nil {
+				assert.ErrorIs(t, err, test.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestProjectContentsCheckInventory(t *testing.T) {
+	contents := &ProjectContents{
+		Files:       []string{"aws_ec2.yml", "hosts.ini", "inventories/production/hosts.yml", "production.ini"},
+		Inventories: []string{"hosts.ini", "inventories/production"},
+	}
+
+	tests := []struct {
+		desc      string
+		contents  *ProjectContents
+		inventory string
+		err       error
+	}{
+		{desc: "Testing checking an inventory file of a project", contents: contents, inventory: "hosts.ini"},
+		{desc: "Testing checking an inventory directory of a project", contents: contents, inventory: "inventories/production/"},
+		{desc: "Testing checking a file within an inventory directory of a project", contents: contents, inventory: "inventories/production/hosts.yml"},
+		{desc: "Testing checking an inventory file of a project that is not discovered as an inventory", contents: contents, inventory: "production.ini"},
+		{desc: "Testing checking an inventory plugin configuration of a project", contents: contents, inventory: "aws_ec2.yml"},
+		{desc: "Testing checking an inline host list, which is not checked", contents: contents, inventory: "127.0.0.1,"},
+		{desc: "Testing checking the inventory of a project whose contents are not discovered", contents: nil, inventory: "unknown.ini"},
+		{desc: "Testing checking the inventory of a project whose files are not listed", contents: &ProjectContents{Inventories: []string{"hosts.ini"}}, inventory: "unknown.ini"},
+		{desc: "Testing error checking an inventory that is not in the project", contents: contents, inventory: "inventories/staging", err: ErrProjectInventoryNotFound},
+		{desc: "Testing error checking an inventory sharing the prefix of an inventory directory", contents: contents, inventory: "inventories/production-eu", err: ErrProjectInventoryNotFound},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			err := test.contents.CheckInventory(test.inventory)
+			if test.err != nil {
+				assert.ErrorIs(t, err, test.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
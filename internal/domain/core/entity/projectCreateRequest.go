@@ -0,0 +1,30 @@
+package entity
+
+const (
+	// CreateProjectModeProject creates a new project. It is the mode of the requests that do not set any mode
+	CreateProjectModeProject = "project"
+	// CreateProjectModeVersion creates a new version of an existing project, which becomes the most recent version of the project
+	CreateProjectModeVersion = "version"
+	// CreateProjectModeReplace replaces the source code of the most recent version of an existing project
+	CreateProjectModeReplace = "replace"
+)
+
+// CreateProjectRequest represents a request to create a project, to create a new version of an existing project, or to replace the source code of the most recent version of an existing project. The source code is provided apart from the request, since each source has its own entry point. The digest, format, signature and storage only apply to the uploaded source code
+type CreateProjectRequest struct {
+	// Digest represents the expected digest of the source code. The digest is not checked when it is empty
+	Digest string
+	// Format represents the format of the source code
+	Format string
+	// Mode represents how the project is stored along with the existing projects, which must be one of the following values: project, version, replace. A new project is created when it is empty
+	Mode string
+	// ProjectID represents the project ID
+	ProjectID string
+	// Revision represents the revision the replaced project must match. It is only used when the project is replaced, and the revision is not checked when it is empty
+	Revision string
+	// Signature represents the signature of the source code, which is stored along with the project
+	Signature string
+	// Storage represents the storage of the source code
+	Storage string
+	// Version represents the project version
+	Version string
+}
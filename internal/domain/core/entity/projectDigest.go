@@ -0,0 +1,72 @@
+package entity
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"regexp"
+)
+
+// ProjectDigestAlgorithm represents the algorithm used to compute the digest of the project source code
+const ProjectDigestAlgorithm = "sha256"
+
+var (
+	// ErrInvalidProjectDigest is returned when a project digest is not a sha256 digest
+	ErrInvalidProjectDigest = errors.New("invalid project digest")
+	// ErrProjectDigestMismatch is returned when the digest of the project source code does not match the expected one
+	ErrProjectDigestMismatch = errors.New("project digest mismatch")
+
+	// projectDigestPattern represents a sha256 digest, prefixed by its algorithm
+	projectDigestPattern = regexp.MustCompile(`^sha256:[a-f0-9]{64}$`)
+)
+
+// ProjectDigester computes the digest of the project source code while it is read
+type ProjectDigester struct {
+	hash   hash.Hash
+	reader io.Reader
+}
+
+// NewProjectDigester returns a reader that computes the digest of the content read from the given reader
+func NewProjectDigester(reader io.Reader) *ProjectDigester {
+	h := sha256.New()
+
+	return &ProjectDigester{
+		hash:   h,
+		reader: io.TeeReader(reader, h),
+	}
+}
+
+// Read reads from the underlying reader, accounting the content read in the digest
+func (d *ProjectDigester) Read(p []byte) (int, error) {
+	return d.reader.Read(p)
+}
+
+// Digest returns the digest of the content read so far, prefixed by its algorithm
+func (d *ProjectDigester) Digest() string {
+	return fmt.Sprintf("%s:%s", ProjectDigestAlgorithm, hex.EncodeToString(d.hash.Sum(nil)))
+}
+
+// ValidateProjectDigest returns an error when the digest is not a sha256 digest prefixed by its algorithm
+func ValidateProjectDigest(digest string) error {
+	if !projectDigestPattern.MatchString(digest) {
+		return fmt.Errorf("%w: %s", ErrInvalidProjectDigest, digest)
+	}
+
+	return nil
+}
+
+// VerifyDigest returns an error when the given digest does not match the digest of the project. The projects without digest, such as the ones stored in a git repository, are not verified
+func (p *Project) VerifyDigest(digest string) error {
+	if p.Digest == "" {
+		return nil
+	}
+
+	if p.Digest != digest {
+		return fmt.Errorf("%w: expected %s, got %s", ErrProjectDigestMismatch, p.Digest, digest)
+	}
+
+	return nil
+}
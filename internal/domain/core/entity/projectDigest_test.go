@@ -0,0 +1,75 @@
+package entity
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectDigesterDigest(t *testing.T) {
+	t.Log("Testing computing the digest of the content read through a project digester")
+
+	digester := NewProjectDigester(strings.NewReader("content for testing"))
+	content, err := io.ReadAll(digester)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "content for testing", string(content))
+	assert.Equal(t, "sha256:dfb84009b9e13d54974a044dd35e6b03bb9b53763f2a5cdebebf94ee55f7c000", digester.Digest())
+}
+
+func TestValidateProjectDigest(t *testing.T) {
+	tests := []struct {
+		desc   string
+		digest string
+		err    error
+	}{
+		{desc: "Testing validating a sha256 project digest", digest: "sha256:" + strings.Repeat("a", 64)},
+		{desc: "Testing error validating a project digest without algorithm", digest: strings.Repeat("a", 64), err: ErrInvalidProjectDigest},
+		{desc: "Testing error validating a project digest using another algorithm", digest: "sha512:" + strings.Repeat("a", 64), err: ErrInvalidProjectDigest},
+		{desc: "Testing error validating a truncated project digest", digest: "sha256:aaaa", err: ErrInvalidProjectDigest},
+		{desc: "Testing error validating an uppercase project digest", digest: "sha256:" + strings.Repeat("A", 64), err: ErrInvalidProjectDigest},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			err := ValidateProjectDigest(test.digest)
+			if test.err != nil {
+				assert.ErrorIs(t, err, test.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestProjectVerifyDigest(t *testing.T) {
+	tests := []struct {
+		desc    string
+		project *Project
+		digest  string
+		err     error
+	}{
+		{desc: "Testing verifying a project digest", project: &Project{Digest: "sha256:abc"}, digest: "sha256:abc"},
+		{desc: "Testing verifying a project without digest", project: &Project{}, digest: "sha256:abc"},
+		{desc: "Testing error verifying a project digest that does not match", project: &Project{Digest: "sha256:abc"}, digest: "sha256:def", err: ErrProjectDigestMismatch},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			err := test.project.VerifyDigest(test.digest)
+			if test.err != nil {
+				assert.ErrorIs(t, err, test.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
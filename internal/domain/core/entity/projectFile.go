@@ -0,0 +1,62 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+)
+
+const (
+	// ProjectFileTypeFile represents a regular file of the project tree
+	ProjectFileTypeFile = "file"
+	// ProjectFileTypeDirectory represents a directory of the project tree
+	ProjectFileTypeDirectory = "directory"
+	// ProjectFileTypeSymlink represents a symbolic link of the project tree
+	ProjectFileTypeSymlink = "symlink"
+)
+
+var (
+	// ErrInvalidProjectFilePath is returned when the path of a project file is empty, absolute or points outside the project tree
+	ErrInvalidProjectFilePath = errors.New("invalid project file path")
+	// ErrProjectFileNotFound is returned when the project tree does not hold a regular file at the given path
+	ErrProjectFileNotFound = errors.New("project file not found")
+	// ErrProjectContentNotArchived is returned when the source code of a project is not stored as a single file, such as the projects stored in a git repository or as a directory
+	ErrProjectContentNotArchived = errors.New("project source code is not stored as an archive")
+)
+
+// ProjectFile represents an entry of the project tree, once the source code is unpacked
+type ProjectFile struct {
+	// Path represents the path of the entry, relative to the root of the project tree and using slashes as separator
+	Path string `json:"path"`
+	// Size represents the size in bytes of the entry. It is only set for the regular files
+	Size int64 `json:"size"`
+	// Type represents the type of the entry. It is one of the following values: file, directory, symlink
+	Type string `json:"type"`
+}
+
+// ProjectContent represents the stream of either the stored source code of a project or one of the files of its tree. The reader must be closed once it is consumed
+type ProjectContent struct {
+	// Name represents the file name of the content
+	Name string
+	// Reader represents the stream of the content
+	Reader io.ReadCloser
+	// Size represents the size in bytes of the content
+	Size int64
+}
+
+// CleanProjectFilePath returns the path of a project file relative to the root of the project tree. It returns an error when the path is empty, absolute or points outside the project tree
+func CleanProjectFilePath(filePath string) (string, error) {
+
+	if filePath == "" || strings.ContainsRune(filePath, 0) || path.IsAbs(filePath) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidProjectFilePath, filePath)
+	}
+
+	cleanPath := path.Clean(filePath)
+	if cleanPath == "." || cleanPath == ".." || strings.HasPrefix(cleanPath, "../") {
+		return "", fmt.Errorf("%w: %q", ErrInvalidProjectFilePath, filePath)
+	}
+
+	return cleanPath, nil
+}
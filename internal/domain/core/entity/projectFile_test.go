@@ -0,0 +1,39 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCleanProjectFilePath(t *testing.T) {
+	tests := []struct {
+		desc     string
+		path     string
+		expected string
+		err      error
+	}{
+		{desc: "Testing cleaning the path of a project file", path: "playbooks/site.yml", expected: "playbooks/site.yml"},
+		{desc: "Testing cleaning the path of a project file with redundant elements", path: "playbooks/./roles/../site.yml", expected: "playbooks/site.yml"},
+		{desc: "Testing error cleaning an empty project file path", path: "", err: ErrInvalidProjectFilePath},
+		{desc: "Testing error cleaning an absolute project file path", path: "/etc/passwd", err: ErrInvalidProjectFilePath},
+		{desc: "Testing error cleaning a project file path pointing outside the project tree", path: "playbooks/../../etc/passwd", err: ErrInvalidProjectFilePath},
+		{desc: "Testing error cleaning a project file path pointing to the root of the project tree", path: "playbooks/..", err: ErrInvalidProjectFilePath},
+		{desc: "Testing error cleaning a project file path holding a null character", path: "site.yml\x00", err: ErrInvalidProjectFilePath},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			path, err := CleanProjectFilePath(test.path)
+			if test.err != nil {
+				assert.ErrorIs(t, err, test.err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expected, path)
+			}
+		})
+	}
+}
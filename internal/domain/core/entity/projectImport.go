@@ -0,0 +1,66 @@
+package entity
+
+const (
+	// ProjectImportStatusAdded represents a project, or a project version, registered by the import
+	ProjectImportStatusAdded = "added"
+	// ProjectImportStatusFailed represents a project that could not be imported
+	ProjectImportStatusFailed = "failed"
+	// ProjectImportStatusSkipped represents a project already registered with the same source code
+	ProjectImportStatusSkipped = "skipped"
+)
+
+// ProjectImportSource represents a project found in an import path, either a directory holding the project tree or a project archive. The project name and version are taken from the directory or archive name, which follows the <name>@<version> convention, being the version optional
+type ProjectImportSource struct {
+	// Format represents the format of the source code read from the import source. The project trees are packed as tar.gz
+	Format string
+	// Name represents the project name
+	Name string
+	// Path represents the path of the directory or archive
+	Path string
+	// Version represents the project version. It is empty when the name does not hold a version
+	Version string
+}
+
+// ProjectImportResult represents the outcome of importing a project
+type ProjectImportResult struct {
+	// Name represents the project name
+	Name string `json:"name,omitempty"`
+	// Path represents the path of the directory or archive the project is imported from
+	Path string `json:"path"`
+	// Reason represents why the project is skipped or failed
+	Reason string `json:"reason,omitempty"`
+	// Status represents the import status, which must be one of the following values: added, skipped, failed
+	Status string `json:"status"`
+	// Version represents the project version
+	Version string `json:"version,omitempty"`
+}
+
+// ProjectImportReport represents the outcome of importing the projects found in a set of import paths
+type ProjectImportReport struct {
+	// Results represents the outcome of each project, in the order they are imported
+	Results []*ProjectImportResult `json:"results"`
+}
+
+// NewProjectImportReport creates an empty ProjectImportReport
+func NewProjectImportReport() *ProjectImportReport {
+	return &ProjectImportReport{
+		Results: []*ProjectImportResult{},
+	}
+}
+
+// Add appends the outcome of a project to the report
+func (r *ProjectImportReport) Add(result *ProjectImportResult) {
+	r.Results = append(r.Results, result)
+}
+
+// Count returns the number of projects of the report having the given status
+func (r *ProjectImportReport) Count(status string) int {
+	count := 0
+	for _, result := range r.Results {
+		if result.Status == status {
+			count++
+		}
+	}
+
+	return count
+}
@@ -0,0 +1,45 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectImportReportCount(t *testing.T) {
+	report := NewProjectImportReport()
+	report.Add(&ProjectImportResult{Name: "project-1", Path: "projects/project-1", Status: ProjectImportStatusAdded})
+	report.Add(&ProjectImportResult{Name: "project-2", Path: "projects/project-2.tar.gz", Status: ProjectImportStatusAdded})
+	report.Add(&ProjectImportResult{Name: "project-3", Path: "projects/project-3", Status: ProjectImportStatusSkipped})
+
+	tests := []struct {
+		desc     string
+		status   string
+		expected int
+	}{
+		{
+			desc:     "Testing counting the added projects of an import report",
+			status:   ProjectImportStatusAdded,
+			expected: 2,
+		},
+		{
+			desc:     "Testing counting the skipped projects of an import report",
+			status:   ProjectImportStatusSkipped,
+			expected: 1,
+		},
+		{
+			desc:     "Testing counting the failed projects of an import report",
+			status:   ProjectImportStatusFailed,
+			expected: 0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			assert.Equal(t, test.expected, report.Count(test.status))
+		})
+	}
+}
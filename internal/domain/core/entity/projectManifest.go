@@ -0,0 +1,292 @@
+package entity
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// ProjectManifestFileName is the name of the manifest file, located at the root of the project tree
+	ProjectManifestFileName = "ransidble.yaml"
+	// ProjectManifestAlternativeFileName is the alternative name of the manifest file, located at the root of the project tree
+	ProjectManifestAlternativeFileName = "ransidble.yml"
+)
+
+var (
+	// ErrInvalidProjectManifest is returned when the project manifest cannot be parsed or it is not consistent with the project contents
+	ErrInvalidProjectManifest = errors.New("invalid project manifest")
+	// ErrProjectPlaybookNotAllowed is returned when a playbook is not one of the playbooks declared in the project manifest
+	ErrProjectPlaybookNotAllowed = errors.New("playbook not allowed by project manifest")
+	// ErrProjectVariableNotAllowed is returned when an extra variable is not one of the variables allowed in the project manifest
+	ErrProjectVariableNotAllowed = errors.New("extra variable not allowed by project manifest")
+	// ErrProjectVariableRequired is returned when an extra variable required in the project manifest is not provided
+	ErrProjectVariableRequired = errors.New("extra variable required by project manifest")
+)
+
+// ProjectManifest represents the ransidble.yaml manifest shipped with a project, declaring how the tasks of the project are executed
+type ProjectManifest struct {
+	// Defaults represents the parameters applied to the tasks that do not define them
+	Defaults *ProjectManifestDefaults `json:"defaults,omitempty" yaml:"defaults"`
+	// Playbooks represents the playbooks that may be executed. Any playbook of the project may be executed when it is empty
+	Playbooks []string `json:"playbooks,omitempty" yaml:"playbooks"`
+	// Requirements represents the roles and collections installed before the tasks are executed, unless the tasks define their own requirements
+	Requirements *ProjectManifestRequirements `json:"requirements,omitempty" yaml:"requirements"`
+	// Variables represents the constraints on the extra variables of the tasks
+	Variables *ProjectManifestVariables `json:"variables,omitempty" yaml:"variables"`
+}
+
+// ProjectManifestDefaults represents the default parameters of the tasks declared in a project manifest. The boolean flags are not part of the defaults, since an omitted flag cannot be told apart from a disabled one
+type ProjectManifestDefaults struct {
+	// BecomeMethod represents the default privilege escalation method
+	BecomeMethod string `json:"become_method,omitempty" yaml:"become_method"`
+	// BecomeUser represents the default user to become
+	BecomeUser string `json:"become_user,omitempty" yaml:"become_user"`
+	// Connection represents the default connection type
+	Connection string `json:"connection,omitempty" yaml:"connection"`
+	// ExtraVars represents the default extra variables, which are merged with the extra variables of the tasks
+	ExtraVars map[string]interface{} `json:"extra_vars,omitempty" yaml:"extra_vars"`
+	// ExtraVarsFile represents the default files to load extra variables from
+	ExtraVarsFile []string `json:"extra_vars_file,omitempty" yaml:"extra_vars_file"`
+	// Forks represents the default number of parallel processes
+	Forks int `json:"forks,omitempty" yaml:"forks"`
+	// Inventory represents the default inventory
+	Inventory string `json:"inventory,omitempty" yaml:"inventory"`
+	// Limit represents the default hosts pattern
+	Limit string `json:"limit,omitempty" yaml:"limit"`
+	// SkipTags represents the default tags to skip
+	SkipTags string `json:"skip_tags,omitempty" yaml:"skip_tags"`
+	// Tags represents the default tags to run
+	Tags string `json:"tags,omitempty" yaml:"tags"`
+	// Timeout represents the default connection timeout
+	Timeout int `json:"timeout,omitempty" yaml:"timeout"`
+	// User represents the default user to connect to the hosts
+	User string `json:"user,omitempty" yaml:"user"`
+}
+
+// ProjectManifestRequirements represents the galaxy requirements declared in a project manifest
+type ProjectManifestRequirements struct {
+	// Collections represents the collections to install
+	Collections []string `json:"collections,omitempty" yaml:"collections"`
+	// CollectionsFile represents the requirements file listing the collections to install
+	CollectionsFile string `json:"collections_file,omitempty" yaml:"collections_file"`
+	// RoleFile represents the requirements file listing the roles to install
+	RoleFile string `json:"role_file,omitempty" yaml:"role_file"`
+	// Roles represents the roles to install
+	Roles []string `json:"roles,omitempty" yaml:"roles"`
+}
+
+// ProjectManifestVariables represents the constraints on the extra variables declared in a project manifest
+type ProjectManifestVariables struct {
+	// Allowed represents the extra variables the tasks may provide, along with the required ones. Any extra variable is allowed when it is empty
+	Allowed []string `json:"allowed,omitempty" yaml:"allowed"`
+	// Required represents the extra variables the tasks must provide, either by themselves or through the default extra variables
+	Required []string `json:"required,omitempty" yaml:"required"`
+}
+
+// ParseProjectManifest parses the content of a project manifest. The unknown attributes are rejected, and an empty manifest declares no constraints
+func ParseProjectManifest(data []byte) (*ProjectManifest, error) {
+	manifest := &ProjectManifest{}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	err := decoder.Decode(manifest)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidProjectManifest, err)
+	}
+
+	for i, playbook := range manifest.Playbooks {
+		if playbook != "" {
+			manifest.Playbooks[i] = path.Clean(playbook)
+		}
+	}
+
+	return manifest, nil
+}
+
+// Validate validates the project manifest against the contents of the project. The declared playbooks and the default inventory must be part of the project contents, which are not checked when they are not discovered, and the default extra variables must be allowed
+func (m *ProjectManifest) Validate(contents *ProjectContents) error {
+
+	for _, playbook := range m.Playbooks {
+		if playbook == "" || path.IsAbs(playbook) {
+			return fmt.Errorf("%w: playbooks must be relative paths of the project tree: %q", ErrInvalidProjectManifest, playbook)
+		}
+	}
+
+	err := contents.CheckPlaybooks(m.Playbooks)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidProjectManifest, err)
+	}
+
+	if m.Defaults != nil {
+		if m.Defaults.Forks < 0 || m.Defaults.Timeout < 0 {
+			return fmt.Errorf("%w: default forks and timeout must not be negative", ErrInvalidProjectManifest)
+		}
+
+		err = contents.CheckInventory(m.Defaults.Inventory)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidProjectManifest, err)
+		}
+	}
+
+	if m.Variables != nil {
+		for _, variable := range append(append([]string{}, m.Variables.Allowed...), m.Variables.Required...) {
+			if strings.TrimSpace(variable) == "" {
+				return fmt.Errorf("%w: variable names must not be empty", ErrInvalidProjectManifest)
+			}
+		}
+
+		if m.Defaults != nil {
+			err = m.checkVariablesAllowed(m.Defaults.ExtraVars)
+			if err != nil {
+				return fmt.Errorf("%w: %w", ErrInvalidProjectManifest, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Apply sets the defaults of the project manifest to the parameters that are not defined. The default extra variables are merged with the extra variables of the parameters, which take precedence
+func (m *ProjectManifest) Apply(parameters *AnsiblePlaybookParameters) {
+	if m == nil || parameters == nil {
+		return
+	}
+
+	if m.Defaults != nil {
+		defaults := m.Defaults
+
+		if parameters.BecomeMethod == "" {
+			parameters.BecomeMethod = defaults.BecomeMethod
+		}
+		if parameters.BecomeUser == "" {
+			parameters.BecomeUser = defaults.BecomeUser
+		}
+		if parameters.Connection == "" {
+			parameters.Connection = defaults.Connection
+		}
+		if len(parameters.ExtraVarsFile) == 0 {
+			parameters.ExtraVarsFile = defaults.ExtraVarsFile
+		}
+		if parameters.Forks == 0 {
+			parameters.Forks = defaults.Forks
+		}
+		if parameters.Inventory == "" {
+			parameters.Inventory = defaults.Inventory
+		}
+		if parameters.Limit == "" {
+			parameters.Limit = defaults.Limit
+		}
+		if parameters.SkipTags == "" {
+			parameters.SkipTags = defaults.SkipTags
+		}
+		if parameters.Tags == "" {
+			parameters.Tags = defaults.Tags
+		}
+		if parameters.Timeout == 0 {
+			parameters.Timeout = defaults.Timeout
+		}
+		if parameters.User == "" {
+			parameters.User = defaults.User
+		}
+
+		if len(defaults.ExtraVars) > 0 {
+			extraVars := make(map[string]interface{}, len(defaults.ExtraVars)+len(parameters.ExtraVars))
+			for name, value := range defaults.ExtraVars {
+				extraVars[name] = value
+			}
+			for name, value := range parameters.ExtraVars {
+				extraVars[name] = value
+			}
+			parameters.ExtraVars = extraVars
+		}
+	}
+
+	if m.Requirements != nil && parameters.Requirements == nil {
+		requirements := &AnsiblePlaybookRequirements{}
+
+		if len(m.Requirements.Roles) > 0 || m.Requirements.RoleFile != "" {
+			requirements.Roles = &AnsiblePlaybookRoleRequirements{
+				Roles:    m.Requirements.Roles,
+				RoleFile: m.Requirements.RoleFile,
+			}
+		}
+
+		if len(m.Requirements.Collections) > 0 || m.Requirements.CollectionsFile != "" {
+			requirements.Collections = &AnsiblePlaybookCollectionRequirements{
+				Collections:      m.Requirements.Collections,
+				RequirementsFile: m.Requirements.CollectionsFile,
+			}
+		}
+
+		if requirements.Roles != nil || requirements.Collections != nil {
+			parameters.Requirements = requirements
+		}
+	}
+}
+
+// Check returns an error when the parameters do not fulfil the constraints of the project manifest. The playbooks must be declared in the manifest, the required extra variables must be provided and the extra variables must be allowed
+func (m *ProjectManifest) Check(parameters *AnsiblePlaybookParameters) error {
+	if m == nil || parameters == nil {
+		return nil
+	}
+
+	if len(m.Playbooks) > 0 {
+		notAllowed := []string{}
+		for _, playbook := range parameters.Playbooks {
+			if !slices.Contains(m.Playbooks, path.Clean(playbook)) {
+				notAllowed = append(notAllowed, playbook)
+			}
+		}
+
+		if len(notAllowed) > 0 {
+			return fmt.Errorf("%w: %s", ErrProjectPlaybookNotAllowed, strings.Join(notAllowed, ", "))
+		}
+	}
+
+	if m.Variables == nil {
+		return nil
+	}
+
+	missing := []string{}
+	for _, variable := range m.Variables.Required {
+		if _, exists := parameters.ExtraVars[variable]; !exists {
+			missing = append(missing, variable)
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: %s", ErrProjectVariableRequired, strings.Join(missing, ", "))
+	}
+
+	return m.checkVariablesAllowed(parameters.ExtraVars)
+}
+
+// checkVariablesAllowed returns an error when any of the extra variables is neither allowed nor required by the project manifest. Any extra variable is allowed when the manifest does not declare the allowed variables
+func (m *ProjectManifest) checkVariablesAllowed(extraVars map[string]interface{}) error {
+	if m.Variables == nil || len(m.Variables.Allowed) == 0 {
+		return nil
+	}
+
+	notAllowed := []string{}
+	for variable := range extraVars {
+		if !slices.Contains(m.Variables.Allowed, variable) && !slices.Contains(m.Variables.Required, variable) {
+			notAllowed = append(notAllowed, variable)
+		}
+	}
+
+	if len(notAllowed) > 0 {
+		sort.Strings(notAllowed)
+		return fmt.Errorf("%w: %s", ErrProjectVariableNotAllowed, strings.Join(notAllowed, ", "))
+	}
+
+	return nil
+}
@@ -0,0 +1,307 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseProjectManifest(t *testing.T) {
+	tests := []struct {
+		desc     string
+		data     string
+		expected *ProjectManifest
+		err      error
+	}{
+		{
+			desc: "Testing parsing a project manifest",
+			data: `
+playbooks:
+  - site.yml
+  - ./playbooks/deploy.yml
+defaults:
+  inventory: inventory
+  forks: 10
+  tags: deploy
+  extra_vars:
+    environment: staging
+variables:
+  allowed: [version]
+  required: [environment]
+requirements:
+  role_file: roles/requirements.yml
+  collections: [community.general]
+`,
+			expected: &ProjectManifest{
+				Defaults: &ProjectManifestDefaults{
+					ExtraVars: map[string]interface{}{"environment": "staging"},
+					Forks:     10,
+					Inventory: "inventory",
+					Tags:      "deploy",
+				},
+				Playbooks: []string{"site.yml", "playbooks/deploy.yml"},
+				Requirements: &ProjectManifestRequirements{
+					Collections: []string{"community.general"},
+					RoleFile:    "roles/requirements.yml",
+				},
+				Variables: &ProjectManifestVariables{
+					Allowed:  []string{"version"},
+					Required: []string{"environment"},
+				},
+			},
+		},
+		{
+			desc:     "Testing parsing an empty project manifest",
+			data:     "",
+			expected: &ProjectManifest{},
+		},
+		{
+			desc: "Testing error parsing a project manifest having an unknown attribute",
+			data: "playbook: site.yml",
+			err:  ErrInvalidProjectManifest,
+		},
+		{
+			desc: "Testing error parsing a project manifest that is not valid YAML",
+			data: "playbooks: [site.yml",
+			err:  ErrInvalidProjectManifest,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			manifest, err := ParseProjectManifest([]byte(test.data))
+			if test.err != nil {
+				assert.ErrorIs(t, err, test.err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expected, manifest)
+			}
+		})
+	}
+}
+
+func TestProjectManifestValidate(t *testing.T) {
+	contents := &ProjectContents{
+		Files:       []string{"inventory/hosts.yml", "site.yml"},
+		Inventories: []string{"inventory"},
+		Playbooks:   []string{"site.yml"},
+	}
+
+	tests := []struct {
+		desc     string
+		manifest *ProjectManifest
+		contents *ProjectContents
+		err      error
+	}{
+		{
+			desc: "Testing validating a project manifest",
+			manifest: &ProjectManifest{
+				Defaults:  &ProjectManifestDefaults{Inventory: "inventory/hosts.yml", ExtraVars: map[string]interface{}{"environment": "staging"}},
+				Playbooks: []string{"site.yml"},
+				Variables: &ProjectManifestVariables{Required: []string{"environment"}},
+			},
+			contents: contents,
+		},
+		{
+			desc:     "Testing validating a project manifest of a project whose contents are not discovered",
+			manifest: &ProjectManifest{Playbooks: []string{"unknown.yml"}},
+		},
+		{
+			desc:     "Testing error validating a project manifest declaring a playbook that is not in the project",
+			manifest: &ProjectManifest{Playbooks: []string{"unknown.yml"}},
+			contents: contents,
+			err:      ErrProjectPlaybookNotFound,
+		},
+		{
+			desc:     "Testing error validating a project manifest declaring an absolute playbook path",
+			manifest: &ProjectManifest{Playbooks: []string{"/site.yml"}},
+			contents: contents,
+			err:      ErrInvalidProjectManifest,
+		},
+		{
+			desc:     "Testing error validating a project manifest having a default inventory that is not in the project",
+			manifest: &ProjectManifest{Defaults: &ProjectManifestDefaults{Inventory: "hosts"}},
+			contents: contents,
+			err:      ErrProjectInventoryNotFound,
+		},
+		{
+			desc: "Testing error validating a project manifest having a default extra variable that is not allowed",
+			manifest: &ProjectManifest{
+				Defaults:  &ProjectManifestDefaults{ExtraVars: map[string]interface{}{"debug": true}},
+				Variables: &ProjectManifestVariables{Allowed: []string{"version"}},
+			},
+			contents: contents,
+			err:      ErrProjectVariableNotAllowed,
+		},
+		{
+			desc:     "Testing error validating a project manifest having an empty variable name",
+			manifest: &ProjectManifest{Variables: &ProjectManifestVariables{Required: []string{" "}}},
+			contents: contents,
+			err:      ErrInvalidProjectManifest,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			err := test.manifest.Validate(test.contents)
+			if test.err != nil {
+				assert.ErrorIs(t, err, test.err)
+				assert.ErrorIs(t, err, ErrInvalidProjectManifest)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestProjectManifestApply(t *testing.T) {
+	manifest := &ProjectManifest{
+		Defaults: &ProjectManifestDefaults{
+			ExtraVars: map[string]interface{}{"environment": "staging", "version": "1.0.0"},
+			Forks:     10,
+			Inventory: "inventory",
+			Tags:      "deploy",
+		},
+		Requirements: &ProjectManifestRequirements{
+			RoleFile: "roles/requirements.yml",
+		},
+	}
+
+	tests := []struct {
+		desc       string
+		manifest   *ProjectManifest
+		parameters *AnsiblePlaybookParameters
+		expected   *AnsiblePlaybookParameters
+	}{
+		{
+			desc:     "Testing applying the defaults of a project manifest",
+			manifest: manifest,
+			parameters: &AnsiblePlaybookParameters{
+				Playbooks: []string{"site.yml"},
+				ExtraVars: map[string]interface{}{"version": "2.0.0"},
+				Tags:      "upgrade",
+			},
+			expected: &AnsiblePlaybookParameters{
+				Playbooks: []string{"site.yml"},
+				ExtraVars: map[string]interface{}{"environment": "staging", "version": "2.0.0"},
+				Forks:     10,
+				Inventory: "inventory",
+				Requirements: &AnsiblePlaybookRequirements{
+					Roles: &AnsiblePlaybookRoleRequirements{RoleFile: "roles/requirements.yml"},
+				},
+				Tags: "upgrade",
+			},
+		},
+		{
+			desc:     "Testing applying the defaults of a project manifest to parameters defining their own requirements",
+			manifest: manifest,
+			parameters: &AnsiblePlaybookParameters{
+				Playbooks:    []string{"site.yml"},
+				Inventory:    "hosts.ini",
+				Requirements: &AnsiblePlaybookRequirements{},
+			},
+			expected: &AnsiblePlaybookParameters{
+				Playbooks:    []string{"site.yml"},
+				ExtraVars:    map[string]interface{}{"environment": "staging", "version": "1.0.0"},
+				Forks:        10,
+				Inventory:    "hosts.ini",
+				Requirements: &AnsiblePlaybookRequirements{},
+				Tags:         "deploy",
+			},
+		},
+		{
+			desc:       "Testing applying a nil project manifest",
+			manifest:   nil,
+			parameters: &AnsiblePlaybookParameters{Playbooks: []string{"site.yml"}},
+			expected:   &AnsiblePlaybookParameters{Playbooks: []string{"site.yml"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			test.manifest.Apply(test.parameters)
+			assert.Equal(t, test.expected, test.parameters)
+		})
+	}
+}
+
+func TestProjectManifestCheck(t *testing.T) {
+	manifest := &ProjectManifest{
+		Playbooks: []string{"site.yml"},
+		Variables: &ProjectManifestVariables{
+			Allowed:  []string{"version"},
+			Required: []string{"environment"},
+		},
+	}
+
+	tests := []struct {
+		desc       string
+		manifest   *ProjectManifest
+		parameters *AnsiblePlaybookParameters
+		err        error
+	}{
+		{
+			desc:     "Testing checking the parameters against a project manifest",
+			manifest: manifest,
+			parameters: &AnsiblePlaybookParameters{
+				Playbooks: []string{"./site.yml"},
+				ExtraVars: map[string]interface{}{"environment": "staging", "version": "1.0.0"},
+			},
+		},
+		{
+			desc:       "Testing checking the parameters against a nil project manifest",
+			manifest:   nil,
+			parameters: &AnsiblePlaybookParameters{Playbooks: []string{"unknown.yml"}},
+		},
+		{
+			desc:     "Testing error checking a playbook that is not declared in the project manifest",
+			manifest: manifest,
+			parameters: &AnsiblePlaybookParameters{
+				Playbooks: []string{"unknown.yml"},
+				ExtraVars: map[string]interface{}{"environment": "staging"},
+			},
+			err: ErrProjectPlaybookNotAllowed,
+		},
+		{
+			desc:     "Testing error checking the parameters missing a required extra variable",
+			manifest: manifest,
+			parameters: &AnsiblePlaybookParameters{
+				Playbooks: []string{"site.yml"},
+				ExtraVars: map[string]interface{}{"version": "1.0.0"},
+			},
+			err: ErrProjectVariableRequired,
+		},
+		{
+			desc:     "Testing error checking the parameters having an extra variable that is not allowed",
+			manifest: manifest,
+			parameters: &AnsiblePlaybookParameters{
+				Playbooks: []string{"site.yml"},
+				ExtraVars: map[string]interface{}{"environment": "staging", "debug": true},
+			},
+			err: ErrProjectVariableNotAllowed,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			err := test.manifest.Check(test.parameters)
+			if test.err != nil {
+				assert.ErrorIs(t, err, test.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
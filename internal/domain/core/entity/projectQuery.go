@@ -0,0 +1,162 @@
+package entity
+
+import (
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	// DefaultProjectQueryLimit is the number of projects returned in a page when the limit is not set
+	DefaultProjectQueryLimit = 20
+	// MaxProjectQueryLimit is the maximum number of projects returned in a page
+	MaxProjectQueryLimit = 100
+)
+
+var (
+	// ErrInvalidProjectQueryCursor represents an error when the cursor of a project query can not be decoded
+	ErrInvalidProjectQueryCursor = fmt.Errorf("invalid project query cursor")
+)
+
+// ProjectQuery describes the criteria to filter and paginate a list of projects. The projects are sorted by name. Project repositories receive it to look up the projects, and those that can not translate it into a native query use the Apply method
+type ProjectQuery struct {
+	// Cursor is the opaque position, returned on the previous page, from which the next page starts
+	Cursor string
+	// Format filters the projects by format
+	Format string `validate:"omitempty,oneof=plain targz tar tarzst tarxz zip oci"`
+	// Limit is the maximum number of projects returned in a page
+	Limit int `validate:"gte=0,lte=100"`
+	// NamePrefix filters the projects whose name starts with the given prefix
+	NamePrefix string
+	// Storage filters the projects by storage
+	Storage string `validate:"omitempty,oneof=local git s3 oci"`
+	// Version filters the projects by version. The projects without version are considered to have the fallback version
+	Version string
+}
+
+// ProjectPage represents a page of projects returned by a project query
+type ProjectPage struct {
+	// NextCursor is the cursor to request the next page. It is empty when there are no more projects
+	NextCursor string
+	// Projects is the list of projects in the page
+	Projects []*Project
+	// Total is the number of projects that fulfill the query filters, regardless of the pagination
+	Total int
+}
+
+// NewProjectQuery creates a new project query
+func NewProjectQuery() *ProjectQuery {
+	return &ProjectQuery{
+		Limit: DefaultProjectQueryLimit,
+	}
+}
+
+// Validate validates the project query
+func (q *ProjectQuery) Validate() error {
+	validate := validator.New()
+	err := validate.Struct(q)
+	if err != nil {
+		return err
+	}
+
+	if q.Cursor != "" {
+		_, err = q.decodeCursor()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Match returns true when the project fulfills the query filters
+func (q *ProjectQuery) Match(project *Project) bool {
+	if project == nil {
+		return false
+	}
+
+	if q.Format != "" && project.Format != q.Format {
+		return false
+	}
+
+	if q.Storage != "" && project.Storage != q.Storage {
+		return false
+	}
+
+	if q.NamePrefix != "" && !strings.HasPrefix(project.Name, q.NamePrefix) {
+		return false
+	}
+
+	if q.Version != "" {
+		version := project.Version
+		if version == "" {
+			version = FallbackVersion
+		}
+		if version != q.Version {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Apply filters, sorts and paginates the given projects according to the query
+func (q *ProjectQuery) Apply(projects []*Project) (*ProjectPage, error) {
+	var cursor string
+	var err error
+
+	if q.Cursor != "" {
+		cursor, err = q.decodeCursor()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	matched := make([]*Project, 0, len(projects))
+	for _, project := range projects {
+		if q.Match(project) {
+			matched = append(matched, project)
+		}
+	}
+
+	sort.SliceStable(matched, func(i, j int) bool {
+		return matched[i].Name < matched[j].Name
+	})
+
+	page := &ProjectPage{
+		Projects: matched,
+		Total:    len(matched),
+	}
+
+	if cursor != "" {
+		start := sort.Search(len(matched), func(i int) bool {
+			return matched[i].Name > cursor
+		})
+		page.Projects = matched[start:]
+	}
+
+	limit := q.Limit
+	if limit <= 0 {
+		limit = DefaultProjectQueryLimit
+	}
+
+	if len(page.Projects) > limit {
+		page.Projects = page.Projects[:limit]
+		page.NextCursor = base64.RawURLEncoding.EncodeToString([]byte(page.Projects[limit-1].Name))
+	}
+
+	return page, nil
+}
+
+// decodeCursor decodes the query cursor into the name of the last project of the previous page
+func (q *ProjectQuery) decodeCursor() (string, error) {
+	name, err := base64.RawURLEncoding.DecodeString(q.Cursor)
+	if err != nil || len(name) == 0 {
+		return "", ErrInvalidProjectQueryCursor
+	}
+
+	return string(name), nil
+}
@@ -0,0 +1,173 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testingProjectQueryProjects() []*Project {
+	return []*Project{
+		{Name: "web-server", Format: ProjectFormatTarGz, Storage: ProjectTypeLocal, Version: "v1.0.0"},
+		{Name: "database", Format: ProjectFormatPlain, Storage: ProjectTypeLocal},
+		{Name: "web-proxy", Format: ProjectFormatPlain, Storage: ProjectTypeLocal, Version: "v2.0.0"},
+		{Name: "monitoring", Format: ProjectFormatTarGz, Storage: ProjectTypeLocal, Version: "v1.0.0"},
+	}
+}
+
+func projectNames(projects []*Project) []string {
+	names := []string{}
+	for _, project := range projects {
+		names = append(names, project.Name)
+	}
+	return names
+}
+
+func TestNewProjectQuery(t *testing.T) {
+	t.Log("Testing project query creation")
+	t.Parallel()
+
+	query := NewProjectQuery()
+
+	assert.Equal(t, DefaultProjectQueryLimit, query.Limit)
+	assert.NoError(t, query.Validate())
+}
+
+func TestProjectQueryValidate(t *testing.T) {
+	tests := []struct {
+		desc    string
+		query   *ProjectQuery
+		wantErr bool
+	}{
+		{
+			desc:    "Validating a project query",
+			query:   &ProjectQuery{Format: ProjectFormatTarGz, Storage: ProjectTypeLocal, Limit: 10, NamePrefix: "web", Version: "v1.0.0"},
+			wantErr: false,
+		},
+		{
+			desc:    "Validating a project query with an invalid format",
+			query:   &ProjectQuery{Format: "rar"},
+			wantErr: true,
+		},
+		{
+			desc:    "Validating a project query with an invalid storage",
+			query:   &ProjectQuery{Storage: "ftp"},
+			wantErr: true,
+		},
+		{
+			desc:    "Validating a project query with a limit greater than the maximum",
+			query:   &ProjectQuery{Limit: MaxProjectQueryLimit + 1},
+			wantErr: true,
+		},
+		{
+			desc:    "Validating a project query with an invalid cursor",
+			query:   &ProjectQuery{Cursor: "%%%"},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			err := test.query.Validate()
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestProjectQueryApply(t *testing.T) {
+	tests := []struct {
+		desc               string
+		query              *ProjectQuery
+		expectedNames      []string
+		expectedTotal      int
+		expectedNextCursor bool
+	}{
+		{
+			desc:          "Testing applying a project query sorting the projects by name",
+			query:         NewProjectQuery(),
+			expectedNames: []string{"database", "monitoring", "web-proxy", "web-server"},
+			expectedTotal: 4,
+		},
+		{
+			desc:          "Testing applying a project query filtering by name prefix",
+			query:         &ProjectQuery{NamePrefix: "web-"},
+			expectedNames: []string{"web-proxy", "web-server"},
+			expectedTotal: 2,
+		},
+		{
+			desc:          "Testing applying a project query filtering by format and version",
+			query:         &ProjectQuery{Format: ProjectFormatTarGz, Version: "v1.0.0"},
+			expectedNames: []string{"monitoring", "web-server"},
+			expectedTotal: 2,
+		},
+		{
+			desc:          "Testing applying a project query filtering by the fallback version",
+			query:         &ProjectQuery{Version: FallbackVersion},
+			expectedNames: []string{"database"},
+			expectedTotal: 1,
+		},
+		{
+			desc:          "Testing applying a project query filtering by storage",
+			query:         &ProjectQuery{Storage: "other"},
+			expectedNames: []string{},
+			expectedTotal: 0,
+		},
+		{
+			desc:               "Testing applying a project query with a limit",
+			query:              &ProjectQuery{Limit: 3},
+			expectedNames:      []string{"database", "monitoring", "web-proxy"},
+			expectedTotal:      4,
+			expectedNextCursor: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			page, err := test.query.Apply(testingProjectQueryProjects())
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedNames, projectNames(page.Projects))
+			assert.Equal(t, test.expectedTotal, page.Total)
+			assert.Equal(t, test.expectedNextCursor, page.NextCursor != "")
+		})
+	}
+}
+
+func TestProjectQueryApplyPagination(t *testing.T) {
+	t.Log("Testing paginating the projects using the cursor")
+	t.Parallel()
+
+	projects := testingProjectQueryProjects()
+	query := &ProjectQuery{Limit: 3}
+
+	names := []string{}
+	for {
+		page, err := query.Apply(projects)
+		assert.NoError(t, err)
+		assert.Equal(t, 4, page.Total)
+		names = append(names, projectNames(page.Projects)...)
+		if page.NextCursor == "" {
+			break
+		}
+		query.Cursor = page.NextCursor
+	}
+
+	assert.Equal(t, []string{"database", "monitoring", "web-proxy", "web-server"}, names)
+}
+
+func TestProjectQueryApplyInvalidCursor(t *testing.T) {
+	t.Log("Testing applying a project query with an invalid cursor")
+	t.Parallel()
+
+	_, err := (&ProjectQuery{Cursor: "%%%"}).Apply(testingProjectQueryProjects())
+	assert.Equal(t, ErrInvalidProjectQueryCursor, err)
+}
@@ -0,0 +1,261 @@
+package entity
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"strings"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+const (
+	// minisignAlgorithmHashed identifies the minisign signatures of the BLAKE2b-512 digest of the content, which is the default minisign signature
+	minisignAlgorithmHashed = "ED"
+	// minisignAlgorithmLegacy identifies the legacy minisign signatures of the content itself
+	minisignAlgorithmLegacy = "Ed"
+	// minisignKeyIDSize is the size of the minisign key id
+	minisignKeyIDSize = 8
+	// minisignTrustedCommentPrefix is the prefix of the trusted comment line of a minisign signature
+	minisignTrustedCommentPrefix = "trusted comment: "
+	// minisignUntrustedCommentPrefix is the prefix of the untrusted comment line of the minisign signatures and public keys
+	minisignUntrustedCommentPrefix = "untrusted comment:"
+)
+
+var (
+	// ErrInvalidProjectSignature is returned when a project signature is not base64 encoded, or it is not a valid minisign signature
+	ErrInvalidProjectSignature = errors.New("invalid project signature")
+	// ErrInvalidProjectPublicKey is returned when a public key of the trust policy is neither a PEM encoded ECDSA or Ed25519 public key nor a minisign public key
+	ErrInvalidProjectPublicKey = errors.New("invalid project public key")
+	// ErrProjectSignatureRequired is returned when the trust policy requires signatures and the project is not signed
+	ErrProjectSignatureRequired = errors.New("project signature required")
+	// ErrProjectSignatureNotTrusted is returned when the project signature is not verified by any public key of the trust policy
+	ErrProjectSignatureNotTrusted = errors.New("project signature not trusted")
+)
+
+// ProjectTrustPolicy represents the public keys allowed to sign the source code of the projects, and whether the projects must be signed to be executed
+type ProjectTrustPolicy struct {
+	// PublicKeys represents the public keys allowed to sign the source code of the projects
+	PublicKeys []crypto.PublicKey
+	// RequireSignature represents whether the unsigned projects are refused
+	RequireSignature bool
+}
+
+// NewProjectTrustPolicy returns a trust policy allowing the given public keys
+func NewProjectTrustPolicy(requireSignature bool, publicKeys ...crypto.PublicKey) *ProjectTrustPolicy {
+	return &ProjectTrustPolicy{
+		PublicKeys:       publicKeys,
+		RequireSignature: requireSignature,
+	}
+}
+
+// MinisignPublicKey represents a minisign public key, which only verifies the signatures made with the secret key of the same key id
+type MinisignPublicKey struct {
+	// KeyID represents the id of the key pair
+	KeyID []byte
+	// PublicKey represents the Ed25519 public key
+	PublicKey ed25519.PublicKey
+}
+
+// ParseProjectPublicKey returns the public key of either a PEM encoded PKIX public key or a minisign public key. Only ECDSA and Ed25519 PEM encoded public keys are accepted
+func ParseProjectPublicKey(data []byte) (crypto.PublicKey, error) {
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		publicKey, err := parseMinisignPublicKey(data)
+		if err != nil {
+			return nil, fmt.Errorf("%w: neither PEM data nor a minisign public key found", ErrInvalidProjectPublicKey)
+		}
+		return publicKey, nil
+	}
+
+	publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidProjectPublicKey, err)
+	}
+
+	switch publicKey.(type) {
+	case *ecdsa.PublicKey, ed25519.PublicKey:
+		return publicKey, nil
+	default:
+		return nil, fmt.Errorf("%w: unsupported key type %T", ErrInvalidProjectPublicKey, publicKey)
+	}
+}
+
+// ValidateProjectSignature returns an error when the signature is not base64 encoded, or when it is an invalid minisign signature
+func ValidateProjectSignature(signature string) error {
+
+	decodedSignature, err := decodeProjectSignature(signature)
+	if err != nil {
+		return err
+	}
+
+	_, err = parseMinisignSignature(decodedSignature)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// IsEnforced returns whether the trust policy verifies the projects. A nil trust policy does not verify any project
+func (p *ProjectTrustPolicy) IsEnforced() bool {
+	return p != nil && (p.RequireSignature || len(p.PublicKeys) > 0)
+}
+
+// Verify returns an error when the signature of the content is not verified by any public key of the trust policy. The ECDSA signatures are verified against the sha256 digest of the content, as cosign signs the blobs, and the minisign signatures are verified against the BLAKE2b-512 digest of the content by the Ed25519 and minisign public keys. The content is streamed through the digest, so it is never held in memory
+func (p *ProjectTrustPolicy) Verify(signature string, content io.Reader) error {
+
+	if signature == "" {
+		if p.RequireSignature {
+			return ErrProjectSignatureRequired
+		}
+		return nil
+	}
+
+	decodedSignature, err := decodeProjectSignature(signature)
+	if err != nil {
+		return err
+	}
+
+	minisignSignature, err := parseMinisignSignature(decodedSignature)
+	if err != nil {
+		return err
+	}
+
+	var hasher hash.Hash
+	if minisignSignature != nil {
+		// the error is only returned when the key is longer than 64 bytes
+		hasher, _ = blake2b.New512(nil)
+	} else {
+		hasher = sha256.New()
+	}
+
+	_, err = io.Copy(hasher, content)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrProjectSignatureNotTrusted, err)
+	}
+	digest := hasher.Sum(nil)
+
+	for _, publicKey := range p.PublicKeys {
+		switch key := publicKey.(type) {
+		case *ecdsa.PublicKey:
+			if minisignSignature == nil && ecdsa.VerifyASN1(key, digest, decodedSignature) {
+				return nil
+			}
+		case ed25519.PublicKey:
+			if minisignSignature != nil && minisignSignature.verify(key, digest) {
+				return nil
+			}
+		case *MinisignPublicKey:
+			if minisignSignature != nil && bytes.Equal(key.KeyID, minisignSignature.keyID) && minisignSignature.verify(key.PublicKey, digest) {
+				return nil
+			}
+		}
+	}
+
+	return ErrProjectSignatureNotTrusted
+}
+
+// decodeProjectSignature returns the decoded base64 signature
+func decodeProjectSignature(signature string) ([]byte, error) {
+
+	if signature == "" {
+		return nil, fmt.Errorf("%w: empty signature", ErrInvalidProjectSignature)
+	}
+
+	decodedSignature, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidProjectSignature, err)
+	}
+
+	return decodedSignature, nil
+}
+
+// minisignSignature represents a signature produced by minisign, made of the signature of the content digest and the global signature, which signs the trusted comment along with the signature
+type minisignSignature struct {
+	globalSignature []byte
+	keyID           []byte
+	signature       []byte
+	trustedComment  string
+}
+
+// parseMinisignSignature returns the minisign signature held by the decoded signature, or nil when the decoded signature is not a minisign signature. Only the default minisign signatures, made of the BLAKE2b-512 digest of the content, are accepted, since the legacy ones require the whole content in memory
+func parseMinisignSignature(data []byte) (*minisignSignature, error) {
+
+	if !bytes.HasPrefix(data, []byte(minisignUntrustedCommentPrefix)) {
+		return nil, nil
+	}
+
+	lines := strings.Split(strings.TrimSpace(strings.ReplaceAll(string(data), "\r\n", "\n")), "\n")
+	if len(lines) != 4 || !strings.HasPrefix(lines[2], minisignTrustedCommentPrefix) {
+		return nil, fmt.Errorf("%w: malformed minisign signature", ErrInvalidProjectSignature)
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(lines[1])
+	if err != nil || len(signature) != 2+minisignKeyIDSize+ed25519.SignatureSize {
+		return nil, fmt.Errorf("%w: malformed minisign signature", ErrInvalidProjectSignature)
+	}
+
+	switch string(signature[:2]) {
+	case minisignAlgorithmHashed:
+	case minisignAlgorithmLegacy:
+		return nil, fmt.Errorf("%w: legacy minisign signatures are not supported, sign without the legacy mode", ErrInvalidProjectSignature)
+	default:
+		return nil, fmt.Errorf("%w: unsupported minisign signature algorithm", ErrInvalidProjectSignature)
+	}
+
+	globalSignature, err := base64.StdEncoding.DecodeString(lines[3])
+	if err != nil || len(globalSignature) != ed25519.SignatureSize {
+		return nil, fmt.Errorf("%w: malformed minisign global signature", ErrInvalidProjectSignature)
+	}
+
+	return &minisignSignature{
+		globalSignature: globalSignature,
+		keyID:           signature[2 : 2+minisignKeyIDSize],
+		signature:       signature[2+minisignKeyIDSize:],
+		trustedComment:  strings.TrimPrefix(lines[2], minisignTrustedCommentPrefix),
+	}, nil
+}
+
+// verify returns whether the public key verifies both the signature of the content digest and the global signature
+func (s *minisignSignature) verify(publicKey ed25519.PublicKey, digest []byte) bool {
+
+	if !ed25519.Verify(publicKey, digest, s.signature) {
+		return false
+	}
+
+	return ed25519.Verify(publicKey, append(append([]byte{}, s.signature...), s.trustedComment...), s.globalSignature)
+}
+
+// parseMinisignPublicKey returns the public key of a minisign public key file, or of its base64 encoded key alone
+func parseMinisignPublicKey(data []byte) (*MinisignPublicKey, error) {
+
+	lines := strings.Split(strings.TrimSpace(strings.ReplaceAll(string(data), "\r\n", "\n")), "\n")
+	if len(lines) == 2 && strings.HasPrefix(lines[0], minisignUntrustedCommentPrefix) {
+		lines = lines[1:]
+	}
+
+	if len(lines) != 1 {
+		return nil, fmt.Errorf("%w: malformed minisign public key", ErrInvalidProjectPublicKey)
+	}
+
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(lines[0]))
+	if err != nil || len(key) != 2+minisignKeyIDSize+ed25519.PublicKeySize || string(key[:2]) != minisignAlgorithmLegacy {
+		return nil, fmt.Errorf("%w: malformed minisign public key", ErrInvalidProjectPublicKey)
+	}
+
+	return &MinisignPublicKey{
+		KeyID:     key[2 : 2+minisignKeyIDSize],
+		PublicKey: ed25519.PublicKey(key[2+minisignKeyIDSize:]),
+	}, nil
+}
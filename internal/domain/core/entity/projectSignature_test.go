@@ -0,0 +1,245 @@
+package entity
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/pem"
+	"strings"
+	"testing"
+
+	"github.com/apenella/ransidble/test/signing"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/blake2b"
+)
+
+func TestParseProjectPublicKey(t *testing.T) {
+
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	ed25519PublicKey, _, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		desc string
+		data []byte
+		err  error
+	}{
+		{desc: "Testing parsing an ECDSA project public key", data: signing.EncodePublicKey(t, &ecdsaKey.PublicKey)},
+		{desc: "Testing parsing an Ed25519 project public key", data: signing.EncodePublicKey(t, ed25519PublicKey)},
+		{desc: "Testing parsing a minisign project public key", data: signing.EncodeMinisignPublicKey(ed25519PublicKey, []byte("keyid-01"))},
+		{desc: "Testing parsing a minisign project public key without comment", data: []byte(strings.Split(string(signing.EncodeMinisignPublicKey(ed25519PublicKey, []byte("keyid-01"))), "\n")[1])},
+		{desc: "Testing error parsing a malformed minisign project public key", data: []byte("untrusted comment: minisign public key\nRWQ="), err: ErrInvalidProjectPublicKey},
+		{desc: "Testing error parsing an RSA project public key", data: signing.EncodePublicKey(t, &rsaKey.PublicKey), err: ErrInvalidProjectPublicKey},
+		{desc: "Testing error parsing a project public key without PEM data", data: []byte("not a public key"), err: ErrInvalidProjectPublicKey},
+		{desc: "Testing error parsing a project public key with invalid PEM content", data: pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: []byte("invalid")}), err: ErrInvalidProjectPublicKey},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			publicKey, err := ParseProjectPublicKey(test.data)
+			if test.err != nil {
+				assert.ErrorIs(t, err, test.err)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, publicKey)
+			}
+		})
+	}
+}
+
+func TestValidateProjectSignature(t *testing.T) {
+
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		desc      string
+		signature string
+		err       error
+	}{
+		{desc: "Testing validating a base64 project signature", signature: base64.StdEncoding.EncodeToString([]byte("signature"))},
+		{desc: "Testing validating a minisign project signature", signature: encodeMinisignSignature(privateKey, []byte("keyid-01"), minisignAlgorithmHashed, "content", "timestamp:1")},
+		{desc: "Testing error validating a legacy minisign project signature", signature: encodeMinisignSignature(privateKey, []byte("keyid-01"), minisignAlgorithmLegacy, "content", "timestamp:1"), err: ErrInvalidProjectSignature},
+		{desc: "Testing error validating a malformed minisign project signature", signature: base64.StdEncoding.EncodeToString([]byte("untrusted comment: signature\nRWQ=")), err: ErrInvalidProjectSignature},
+		{desc: "Testing error validating an empty project signature", signature: "", err: ErrInvalidProjectSignature},
+		{desc: "Testing error validating a project signature that is not base64 encoded", signature: "not base64!", err: ErrInvalidProjectSignature},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			err := ValidateProjectSignature(test.signature)
+			if test.err != nil {
+				assert.ErrorIs(t, err, test.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestProjectTrustPolicyIsEnforced(t *testing.T) {
+	tests := []struct {
+		desc     string
+		policy   *ProjectTrustPolicy
+		expected bool
+	}{
+		{desc: "Testing nil project trust policy is not enforced", policy: nil, expected: false},
+		{desc: "Testing project trust policy without keys nor required signatures is not enforced", policy: NewProjectTrustPolicy(false), expected: false},
+		{desc: "Testing project trust policy requiring signatures is enforced", policy: NewProjectTrustPolicy(true), expected: true},
+		{desc: "Testing project trust policy with public keys is enforced", policy: NewProjectTrustPolicy(false, ed25519.PublicKey{}), expected: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			assert.Equal(t, test.expected, test.policy.IsEnforced())
+		})
+	}
+}
+
+func TestProjectTrustPolicyVerify(t *testing.T) {
+
+	content := "content for testing"
+	digest := sha256.Sum256([]byte(content))
+
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	ecdsaSignature, err := ecdsa.SignASN1(rand.Reader, ecdsaKey, digest[:])
+	assert.NoError(t, err)
+
+	ed25519PublicKey, ed25519PrivateKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	ed25519Signature := ed25519.Sign(ed25519PrivateKey, []byte(content))
+	minisignSignature := encodeMinisignSignature(ed25519PrivateKey, []byte("keyid-01"), minisignAlgorithmHashed, content, "timestamp:1")
+	minisignPublicKey, err := ParseProjectPublicKey(signing.EncodeMinisignPublicKey(ed25519PublicKey, []byte("keyid-01")))
+	assert.NoError(t, err)
+	otherMinisignPublicKey, err := ParseProjectPublicKey(signing.EncodeMinisignPublicKey(ed25519PublicKey, []byte("keyid-02")))
+	assert.NoError(t, err)
+
+	untrustedPublicKey, _, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		desc      string
+		policy    *ProjectTrustPolicy
+		signature string
+		err       error
+	}{
+		{
+			desc:      "Testing verifying a project signed with an ECDSA key",
+			policy:    NewProjectTrustPolicy(true, &ecdsaKey.PublicKey),
+			signature: base64.StdEncoding.EncodeToString(ecdsaSignature),
+		},
+		{
+			desc:      "Testing verifying a project signed with minisign against an Ed25519 key",
+			policy:    NewProjectTrustPolicy(true, untrustedPublicKey, ed25519PublicKey),
+			signature: minisignSignature,
+		},
+		{
+			desc:      "Testing verifying a project signed with minisign against a minisign key",
+			policy:    NewProjectTrustPolicy(true, otherMinisignPublicKey, minisignPublicKey),
+			signature: minisignSignature,
+		},
+		{
+			desc:      "Testing error verifying a project signed with minisign against a minisign key of another key id",
+			policy:    NewProjectTrustPolicy(true, otherMinisignPublicKey),
+			signature: minisignSignature,
+			err:       ErrProjectSignatureNotTrusted,
+		},
+		{
+			desc:      "Testing error verifying a project signed with minisign when the trusted comment is tampered",
+			policy:    NewProjectTrustPolicy(true, ed25519PublicKey),
+			signature: tamperMinisignTrustedComment(t, minisignSignature),
+			err:       ErrProjectSignatureNotTrusted,
+		},
+		{
+			desc:      "Testing error verifying a project signed with minisign when the content is tampered",
+			policy:    NewProjectTrustPolicy(true, ed25519PublicKey),
+			signature: encodeMinisignSignature(ed25519PrivateKey, []byte("keyid-01"), minisignAlgorithmHashed, "other content", "timestamp:1"),
+			err:       ErrProjectSignatureNotTrusted,
+		},
+		{
+			desc:      "Testing error verifying a project with a raw Ed25519 signature of its content",
+			policy:    NewProjectTrustPolicy(true, ed25519PublicKey),
+			signature: base64.StdEncoding.EncodeToString(ed25519Signature),
+			err:       ErrProjectSignatureNotTrusted,
+		},
+		{
+			desc:   "Testing verifying an unsigned project when signatures are not required",
+			policy: NewProjectTrustPolicy(false, ed25519PublicKey),
+		},
+		{
+			desc:   "Testing error verifying an unsigned project when signatures are required",
+			policy: NewProjectTrustPolicy(true, ed25519PublicKey),
+			err:    ErrProjectSignatureRequired,
+		},
+		{
+			desc:      "Testing error verifying a project signed with an untrusted key",
+			policy:    NewProjectTrustPolicy(false, untrustedPublicKey, &ecdsaKey.PublicKey),
+			signature: minisignSignature,
+			err:       ErrProjectSignatureNotTrusted,
+		},
+		{
+			desc:      "Testing error verifying a project with a signature that is not base64 encoded",
+			policy:    NewProjectTrustPolicy(false, ed25519PublicKey),
+			signature: "not base64!",
+			err:       ErrInvalidProjectSignature,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			err := test.policy.Verify(test.signature, strings.NewReader(content))
+			if test.err != nil {
+				assert.ErrorIs(t, err, test.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+// encodeMinisignSignature returns the base64 encoded minisign signature file of the content, as produced by minisign
+func encodeMinisignSignature(privateKey ed25519.PrivateKey, keyID []byte, algorithm string, content string, trustedComment string) string {
+	message := []byte(content)
+	if algorithm == minisignAlgorithmHashed {
+		digest := blake2b.Sum512(message)
+		message = digest[:]
+	}
+
+	signature := ed25519.Sign(privateKey, message)
+	globalSignature := ed25519.Sign(privateKey, append(append([]byte{}, signature...), trustedComment...))
+
+	file := "untrusted comment: signature from minisign secret key\n" +
+		base64.StdEncoding.EncodeToString(append(append([]byte(algorithm), keyID...), signature...)) + "\n" +
+		minisignTrustedCommentPrefix + trustedComment + "\n" +
+		base64.StdEncoding.EncodeToString(globalSignature) + "\n"
+
+	return base64.StdEncoding.EncodeToString([]byte(file))
+}
+
+// tamperMinisignTrustedComment returns the base64 encoded minisign signature with its trusted comment changed
+func tamperMinisignTrustedComment(t *testing.T, signature string) string {
+	file, err := base64.StdEncoding.DecodeString(signature)
+	assert.NoError(t, err)
+
+	return base64.StdEncoding.EncodeToString([]byte(strings.Replace(string(file), "timestamp:1", "timestamp:2", 1)))
+}
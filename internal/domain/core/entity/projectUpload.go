@@ -0,0 +1,63 @@
+package entity
+
+import (
+	"time"
+)
+
+// ProjectUpload represents an upload session where the source code of a project is received in chunks. The chunks are appended at the offset of the upload, so an interrupted upload is resumed from the bytes already received. Once all the bytes are received, the upload is finalized into a project or a project version
+type ProjectUpload struct {
+	// CreatedAt represents when the upload was opened
+	CreatedAt string `json:"created_at"`
+	// ExpiresAt represents when the upload expires. The upload is removed when it is not completed before then
+	ExpiresAt string `json:"expires_at"`
+	// ID represents the upload identifier
+	ID string `json:"id"`
+	// Length represents the size, in bytes, of the source code to upload
+	Length int64 `json:"length"`
+	// Offset represents the number of bytes already received
+	Offset int64 `json:"offset"`
+	// UpdatedAt represents when the last chunk was received
+	UpdatedAt string `json:"updated_at"`
+}
+
+// NewProjectUpload creates a new ProjectUpload, which expires after the expiration time unless a chunk is received
+func NewProjectUpload(id string, length int64, now time.Time, expiration time.Duration) *ProjectUpload {
+	upload := &ProjectUpload{
+		CreatedAt: now.Format(time.RFC3339),
+		ID:        id,
+		Length:    length,
+	}
+	upload.Touch(now, expiration)
+
+	return upload
+}
+
+// Touch sets the upload as updated at the given time, and extends its expiration
+func (u *ProjectUpload) Touch(now time.Time, expiration time.Duration) {
+	u.UpdatedAt = now.Format(time.RFC3339)
+	u.ExpiresAt = now.Add(expiration).Format(time.RFC3339)
+}
+
+// IsComplete returns true when all the bytes of the source code have been received
+func (u *ProjectUpload) IsComplete() bool {
+	return u.Offset >= u.Length
+}
+
+// IsExpired returns true when the upload has expired at the given time. An upload whose expiration can not be parsed is expired, since it would never be removed otherwise
+func (u *ProjectUpload) IsExpired(now time.Time) bool {
+	expiresAt, err := time.Parse(time.RFC3339, u.ExpiresAt)
+	if err != nil {
+		return true
+	}
+
+	return !now.Before(expiresAt)
+}
+
+// Remaining returns the number of bytes still to be received
+func (u *ProjectUpload) Remaining() int64 {
+	if u.IsComplete() {
+		return 0
+	}
+
+	return u.Length - u.Offset
+}
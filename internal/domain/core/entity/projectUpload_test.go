@@ -0,0 +1,86 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewProjectUpload(t *testing.T) {
+	t.Log("Testing creating a new ProjectUpload expiring after the expiration time")
+	t.Parallel()
+
+	now := time.Date(2026, 1, 5, 10, 0, 0, 0, time.UTC)
+
+	upload := NewProjectUpload("upload-1", 1024, now, time.Hour)
+
+	assert.Equal(t, &ProjectUpload{
+		CreatedAt: "2026-01-05T10:00:00Z",
+		ExpiresAt: "2026-01-05T11:00:00Z",
+		ID:        "upload-1",
+		Length:    1024,
+		UpdatedAt: "2026-01-05T10:00:00Z",
+	}, upload)
+}
+
+func TestProjectUploadTouch(t *testing.T) {
+	t.Log("Testing touching a ProjectUpload extends its expiration")
+	t.Parallel()
+
+	created := time.Date(2026, 1, 5, 10, 0, 0, 0, time.UTC)
+	upload := NewProjectUpload("upload-1", 1024, created, time.Hour)
+
+	upload.Touch(created.Add(30*time.Minute), time.Hour)
+
+	assert.Equal(t, "2026-01-05T10:00:00Z", upload.CreatedAt)
+	assert.Equal(t, "2026-01-05T10:30:00Z", upload.UpdatedAt)
+	assert.Equal(t, "2026-01-05T11:30:00Z", upload.ExpiresAt)
+}
+
+func TestProjectUploadIsComplete(t *testing.T) {
+	tests := []struct {
+		desc      string
+		upload    *ProjectUpload
+		expected  bool
+		remaining int64
+	}{
+		{desc: "Testing a ProjectUpload without received bytes is not complete", upload: &ProjectUpload{Length: 1024}, expected: false, remaining: 1024},
+		{desc: "Testing a partially received ProjectUpload is not complete", upload: &ProjectUpload{Length: 1024, Offset: 512}, expected: false, remaining: 512},
+		{desc: "Testing a fully received ProjectUpload is complete", upload: &ProjectUpload{Length: 1024, Offset: 1024}, expected: true, remaining: 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			assert.Equal(t, test.expected, test.upload.IsComplete())
+			assert.Equal(t, test.remaining, test.upload.Remaining())
+		})
+	}
+}
+
+func TestProjectUploadIsExpired(t *testing.T) {
+	now := time.Date(2026, 1, 5, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		desc     string
+		upload   *ProjectUpload
+		expected bool
+	}{
+		{desc: "Testing a ProjectUpload expiring in the future is not expired", upload: &ProjectUpload{ExpiresAt: "2026-01-05T11:00:00Z"}, expected: false},
+		{desc: "Testing a ProjectUpload expiring now is expired", upload: &ProjectUpload{ExpiresAt: "2026-01-05T10:00:00Z"}, expected: true},
+		{desc: "Testing a ProjectUpload expired in the past is expired", upload: &ProjectUpload{ExpiresAt: "2026-01-05T09:00:00Z"}, expected: true},
+		{desc: "Testing a ProjectUpload with an invalid expiration is expired", upload: &ProjectUpload{ExpiresAt: "invalid"}, expected: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			assert.Equal(t, test.expected, test.upload.IsExpired(now))
+		})
+	}
+}
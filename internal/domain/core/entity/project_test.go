@@ -3,6 +3,7 @@ package entity
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -30,13 +31,24 @@ func TestNewProject_FallbackVersion(t *testing.T) {
 	assert.Equal(t, "project", project.Name)
 	assert.Equal(t, "reference", project.Reference)
 	assert.Equal(t, "local", project.Storage)
-	assert.Equal(t, "latest", project.Version)
+	assert.Equal(t, "v1", project.Version)
+}
+
+func TestNewProjectGitSource(t *testing.T) {
+	t.Log("Testing project git source entity creation")
+	t.Parallel()
+
+	source := NewProjectGitSource("https://example.com/project.git", "main", "ansible")
+
+	assert.Equal(t, &ProjectGitSource{URL: "https://example.com/project.git", Ref: "main", Subdirectory: "ansible"}, source)
 }
 
 func TestProjectValidate(t *testing.T) {
 	type fields struct {
 		Format    string
+		Git       *ProjectGitSource
 		Name      string
+		OCI       *ProjectOCISource
 		Reference string
 		Storage   string
 		Version   string
@@ -124,6 +136,100 @@ func TestProjectValidate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			desc: "Validating a project entity stored in a git repository",
+			fields: fields{
+				Format:    "plain",
+				Git:       NewProjectGitSource("https://example.com/project.git", "v1.0.0", "ansible"),
+				Name:      "project",
+				Reference: "https://example.com/project.git",
+				Storage:   "git",
+				Version:   "v1.0.0",
+			},
+			wantErr: false,
+		},
+		{
+			desc: "Validating a project entity stored in a git repository without git source",
+			fields: fields{
+				Format:    "plain",
+				Name:      "project",
+				Reference: "https://example.com/project.git",
+				Storage:   "git",
+				Version:   "v1.0.0",
+			},
+			wantErr: true,
+		},
+		{
+			desc: "Validating a project entity stored in a git repository with an invalid ref",
+			fields: fields{
+				Format:    "plain",
+				Git:       NewProjectGitSource("https://example.com/project.git", "--upload-pack=touch", ""),
+				Name:      "project",
+				Reference: "https://example.com/project.git",
+				Storage:   "git",
+				Version:   "v1.0.0",
+			},
+			wantErr: true,
+		},
+		{
+			desc: "Validating a project entity stored in a git repository with a file URL",
+			fields: fields{
+				Format:    "plain",
+				Git:       NewProjectGitSource("file:///srv/project.git", "", ""),
+				Name:      "project",
+				Reference: "file:///srv/project.git",
+				Storage:   "git",
+				Version:   "v1.0.0",
+			},
+			wantErr: true,
+		},
+		{
+			desc: "Validating a project entity stored in a git repository with a local path",
+			fields: fields{
+				Format:    "plain",
+				Git:       NewProjectGitSource("/srv/project.git", "", ""),
+				Name:      "project",
+				Reference: "/srv/project.git",
+				Storage:   "git",
+				Version:   "v1.0.0",
+			},
+			wantErr: true,
+		},
+		{
+			desc: "Validating a project entity stored in a git repository with a remote helper",
+			fields: fields{
+				Format:    "plain",
+				Git:       NewProjectGitSource("ext::sh -c touch% /tmp/pwned", "", ""),
+				Name:      "project",
+				Reference: "ext::sh -c touch% /tmp/pwned",
+				Storage:   "git",
+				Version:   "v1.0.0",
+			},
+			wantErr: true,
+		},
+		{
+			desc: "Validating a project entity stored in an OCI registry",
+			fields: fields{
+				Format:    "oci",
+				Name:      "project",
+				OCI:       NewProjectOCISource("registry.example.com/project:v1.0.0", "sha256:digest"),
+				Reference: "project.oci.tar",
+				Storage:   "oci",
+				Version:   "v1.0.0",
+			},
+			wantErr: false,
+		},
+		{
+			desc: "Validating a project entity in oci format without OCI source",
+			fields: fields{
+				Format:    "oci",
+				Name:      "project",
+				Reference: "project.oci.tar",
+				Storage:   "local",
+				Version:   "v1.0.0",
+			},
+			wantErr: true,
+		},
 		{
 			desc: "Validating a project entity with empty version",
 			fields: fields{
@@ -143,7 +249,9 @@ func TestProjectValidate(t *testing.T) {
 
 			p := &Project{
 				Format:    test.fields.Format,
+				Git:       test.fields.Git,
 				Name:      test.fields.Name,
+				OCI:       test.fields.OCI,
 				Reference: test.fields.Reference,
 				Storage:   test.fields.Storage,
 				Version:   test.fields.Version,
@@ -162,6 +270,30 @@ func TestProjectValidate(t *testing.T) {
 	}
 }
 
+func TestProjectIsPurgeable(t *testing.T) {
+	now := time.Date(2026, 1, 5, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		desc     string
+		project  *Project
+		expected bool
+	}{
+		{desc: "Testing a Project deleted within the grace period is not purgeable", project: &Project{DeletedAt: "2026-01-05T09:30:00Z"}, expected: false},
+		{desc: "Testing a Project deleted exactly the grace period ago is purgeable", project: &Project{DeletedAt: "2026-01-05T09:00:00Z"}, expected: true},
+		{desc: "Testing a Project deleted before the grace period is purgeable", project: &Project{DeletedAt: "2026-01-04T10:00:00Z"}, expected: true},
+		{desc: "Testing a Project with an invalid deletion time is purgeable", project: &Project{DeletedAt: "invalid"}, expected: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			assert.Equal(t, test.expected, test.project.IsPurgeable(now, time.Hour))
+		})
+	}
+}
+
 func TestProjectSourceCodeExtension(t *testing.T) {
 	type fields struct {
 		Format    string
@@ -200,6 +332,18 @@ func TestProjectSourceCodeExtension(t *testing.T) {
 			expected: "",
 			err:      nil,
 		},
+		{
+			desc: "Testing get source code extension when format is oci",
+			fields: fields{
+				Format:    ProjectFormatOCI,
+				Name:      "project",
+				Reference: "reference",
+				Storage:   "oci",
+				Version:   "v1.0.0",
+			},
+			expected: ExtensionOCI,
+			err:      nil,
+		},
 		{
 			desc: "Testing get source code extension with invalid format",
 			fields: fields{
@@ -254,6 +398,26 @@ func TestValidateProjectFormat(t *testing.T) {
 			format: "targz",
 			err:    nil,
 		},
+		{
+			desc:   "Testing validate project format with zip format",
+			format: "zip",
+			err:    nil,
+		},
+		{
+			desc:   "Testing validate project format with tarzst format",
+			format: "tarzst",
+			err:    nil,
+		},
+		{
+			desc:   "Testing validate project format with oci format",
+			format: "oci",
+			err:    nil,
+		},
+		{
+			desc:   "Testing validate project format with auto format",
+			format: "auto",
+			err:    fmt.Errorf("invalid format: auto"),
+		},
 		{
 			desc:   "Testing validate project format with invalid format",
 			format: "invalid-format",
@@ -288,6 +452,21 @@ func TestValidateProjectStorage(t *testing.T) {
 			storage: "local",
 			err:     nil,
 		},
+		{
+			desc:    "Testing validate project storage with git storage",
+			storage: "git",
+			err:     nil,
+		},
+		{
+			desc:    "Testing validate project storage with s3 storage",
+			storage: "s3",
+			err:     nil,
+		},
+		{
+			desc:    "Testing validate project storage with oci storage",
+			storage: "oci",
+			err:     nil,
+		},
 		{
 			desc:    "Testing validate project storage with invalid storage",
 			storage: "invalid-storage",
@@ -311,6 +490,60 @@ func TestValidateProjectStorage(t *testing.T) {
 	}
 }
 
+func TestValidateProjectVersion(t *testing.T) {
+	tests := []struct {
+		desc    string
+		version string
+		err     error
+	}{
+		{
+			desc:    "Testing validate project version with a semantic version",
+			version: "v1.2.3-rc.1+build.5",
+			err:     nil,
+		},
+		{
+			desc:    "Testing validate project version with the fallback version",
+			version: FallbackVersion,
+			err:     nil,
+		},
+		{
+			desc:    "Testing validate project version with the latest version alias",
+			version: LatestVersion,
+			err:     fmt.Errorf("reserved version: latest"),
+		},
+		{
+			desc:    "Testing validate project version with an empty version",
+			version: "",
+			err:     fmt.Errorf("invalid version: "),
+		},
+		{
+			desc:    "Testing validate project version with a path separator",
+			version: "../v1",
+			err:     fmt.Errorf("invalid version: ../v1"),
+		},
+		{
+			desc:    "Testing validate project version with a whitespace",
+			version: "v1 beta",
+			err:     fmt.Errorf("invalid version: v1 beta"),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+			t.Parallel()
+
+			err := ValidateProjectVersion(test.version)
+
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Nil(t, test.err, "an expected error not received")
+				assert.Nil(t, err, "got an unexpected error")
+			}
+		})
+	}
+}
+
 func TestValidateProjectFileExtension(t *testing.T) {
 	tests := []struct {
 		desc string
@@ -364,6 +597,30 @@ func TestGetExtensionFromFormat(t *testing.T) {
 			expected: "tar.gz",
 			err:      nil,
 		},
+		{
+			desc:     "Testing get extension from format with tar format",
+			format:   "tar",
+			expected: "tar",
+			err:      nil,
+		},
+		{
+			desc:     "Testing get extension from format with tarzst format",
+			format:   "tarzst",
+			expected: "tar.zst",
+			err:      nil,
+		},
+		{
+			desc:     "Testing get extension from format with tarxz format",
+			format:   "tarxz",
+			expected: "tar.xz",
+			err:      nil,
+		},
+		{
+			desc:     "Testing get extension from format with zip format",
+			format:   "zip",
+			expected: "zip",
+			err:      nil,
+		},
 		{
 			desc:     "Testing get extension from format with invalid format",
 			format:   "invalid-format",
@@ -388,3 +645,110 @@ func TestGetExtensionFromFormat(t *testing.T) {
 		})
 	}
 }
+
+func TestDetectProjectFormat(t *testing.T) {
+
+	tarHeader := make([]byte, ProjectFormatDetectionHeaderSize)
+	copy(tarHeader[257:], "ustar")
+
+	tests := []struct {
+		desc     string
+		header   []byte
+		expected string
+		err      error
+	}{
+		{
+			desc:     "Testing detect project format with tar.gz magic number",
+			header:   []byte{0x1f, 0x8b, 0x08, 0x00},
+			expected: ProjectFormatTarGz,
+		},
+		{
+			desc:     "Testing detect project format with tar.zst magic number",
+			header:   []byte{0x28, 0xb5, 0x2f, 0xfd, 0x04},
+			expected: ProjectFormatTarZst,
+		},
+		{
+			desc:     "Testing detect project format with tar.xz magic number",
+			header:   []byte{0xfd, '7', 'z', 'X', 'Z', 0x00, 0x00},
+			expected: ProjectFormatTarXz,
+		},
+		{
+			desc:     "Testing detect project format with zip magic number",
+			header:   []byte{'P', 'K', 0x03, 0x04, 0x14},
+			expected: ProjectFormatZip,
+		},
+		{
+			desc:     "Testing detect project format with empty zip magic number",
+			header:   []byte{'P', 'K', 0x05, 0x06},
+			expected: ProjectFormatZip,
+		},
+		{
+			desc:     "Testing detect project format with tar magic number",
+			header:   tarHeader,
+			expected: ProjectFormatTar,
+		},
+		{
+			desc:   "Testing error detecting project format with unknown magic number",
+			header: []byte("- hosts: all"),
+			err:    fmt.Errorf("unable to detect the project format"),
+		},
+		{
+			desc: "Testing error detecting project format with an empty header",
+			err:  fmt.Errorf("unable to detect the project format"),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+			t.Parallel()
+
+			got, err := DetectProjectFormat(test.header)
+
+			if test.err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expected, got)
+			}
+		})
+	}
+}
+
+func TestParseProjectGitURL(t *testing.T) {
+	tests := []struct {
+		desc   string
+		url    string
+		scheme string
+		host   string
+		err    bool
+	}{
+		{desc: "Parsing an https git repository URL", url: "https://example.com/project.git", scheme: "https", host: "example.com"},
+		{desc: "Parsing an https git repository URL with a port", url: "https://example.com:8443/project.git", scheme: "https", host: "example.com:8443"},
+		{desc: "Parsing an https git repository URL with an IPv6 host", url: "https://[::1]:8443/project.git", scheme: "https", host: "[::1]:8443"},
+		{desc: "Parsing an ssh git repository URL", url: "ssh://git@example.com/project.git", scheme: "ssh", host: "example.com"},
+		{desc: "Parsing an scp-like git repository address", url: "git@example.com:project.git", scheme: "ssh", host: "example.com"},
+		{desc: "Parsing a file git repository URL", url: "file:///srv/project.git", err: true},
+		{desc: "Parsing a local git repository path", url: "/srv/project.git", err: true},
+		{desc: "Parsing a relative git repository path", url: "./project:name.git", err: true},
+		{desc: "Parsing a git repository URL using a remote helper", url: "ext::sh -c touch", err: true},
+		{desc: "Parsing a git repository URL using a remote helper over a URL", url: "fd::https://example.com/project.git", err: true},
+		{desc: "Parsing a git repository URL starting with a dash", url: "--upload-pack=touch", err: true},
+		{desc: "Parsing an empty git repository URL", url: "", err: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			scheme, host, err := ParseProjectGitURL(test.url)
+			if test.err {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.scheme, scheme)
+			assert.Equal(t, test.host, host)
+		})
+	}
+}
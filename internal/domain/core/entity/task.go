@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"encoding/json"
 	"sync"
 	"time"
 
@@ -10,6 +11,8 @@ import (
 const (
 	// ACCEPTED status when the task is accepted to be executed
 	ACCEPTED = "ACCEPTED"
+	// CANCELLED status when the task is cancelled before it finishes
+	CANCELLED = "CANCELLED"
 	// FAILED status when the task is failed
 	FAILED = "FAILED"
 	// PENDING status when the task is pending. This status is used when the task is not yet accepted to be executed
@@ -18,6 +21,8 @@ const (
 	RUNNING = "RUNNING"
 	// SUCCESS status when the task is successfully executed
 	SUCCESS = "SUCCESS"
+	// TIMEOUT status when the task execution exceeds its execution timeout
+	TIMEOUT = "TIMEOUT"
 
 	// AnsiblePlaybookCommand identifies the task as an Ansible playbook task
 	AnsiblePlaybookCommand = "ansible-playbook"
@@ -41,12 +46,21 @@ type Task struct {
 	Parameters interface{} `json:"parameters" validate:"required"`
 	// ProjectID represents the project ID. This field is required when the command is ansible-playbook
 	ProjectID string `json:"project_id" validate:"required_if=Command ansible-playbook"`
-	// Status represents the task status. This field is required and must be one of the following values: ACCEPTED, FAILED, PENDING, RUNNING, SUCCESS
-	Status string `json:"status" validate:"required,oneof=ACCEPTED FAILED PENDING RUNNING SUCCESS"`
+	// ProjectVersion represents the project version pinned by the task. The most recent version of the project is resolved and pinned when the task is created if it is empty or latest
+	ProjectVersion string `json:"project_version,omitempty"`
+	// Result represents the structured result of the task execution. It is only set when the task is executed asking for it
+	Result *TaskResult `json:"result,omitempty"`
+	// Status represents the task status. This field is required and must be one of the following values: ACCEPTED, CANCELLED, FAILED, PENDING, RUNNING, SUCCESS, TIMEOUT
+	Status string `json:"status" validate:"required,oneof=ACCEPTED CANCELLED FAILED PENDING RUNNING SUCCESS TIMEOUT"`
 
+	// cancelFunc stops the execution of the task when it is cancelled
+	cancelFunc  func()
 	statusMutex sync.Mutex
 }
 
+// taskJSON has the same fields as Task without its JSON methods, to encode and decode the task avoiding the recursion
+type taskJSON Task
+
 // NewTask creates a new task
 func NewTask(id string, projectID string, command string, parameters interface{}) *Task {
 	return &Task{
@@ -59,39 +73,199 @@ func NewTask(id string, projectID string, command string, parameters interface{}
 	}
 }
 
-// Accepted sets the task status to ACCEPTED
+// Accepted sets the task status to ACCEPTED. A cancelled task keeps its status
 func (t *Task) Accepted() {
 	t.statusMutex.Lock()
 	defer t.statusMutex.Unlock()
+	if t.Status == CANCELLED {
+		return
+	}
 	t.Status = ACCEPTED
 	t.CreatedAt = time.Now().Format(time.RFC3339)
 }
 
-// Failed sets the task status to FAILED
+// Failed sets the task status to FAILED. A cancelled task keeps its status
 func (t *Task) Failed(errorMsg string) {
 	t.statusMutex.Lock()
 	defer t.statusMutex.Unlock()
+	if t.Status == CANCELLED {
+		return
+	}
 	t.Status = FAILED
 	t.ErrorMessage = errorMsg
 	t.CompletedAt = time.Now().Format(time.RFC3339)
 }
 
-// Success sets the task status to SUCCESS
+// Success sets the task status to SUCCESS. A cancelled task keeps its status
 func (t *Task) Success() {
 	t.statusMutex.Lock()
 	defer t.statusMutex.Unlock()
+	if t.Status == CANCELLED {
+		return
+	}
 	t.Status = SUCCESS
 	t.CompletedAt = time.Now().Format(time.RFC3339)
 }
 
-// Running sets the task status to RUNNING
+// TimedOut sets the task status to TIMEOUT. A cancelled task keeps its status
+func (t *Task) TimedOut(errorMsg string) {
+	t.statusMutex.Lock()
+	defer t.statusMutex.Unlock()
+	if t.Status == CANCELLED {
+		return
+	}
+	t.Status = TIMEOUT
+	t.ErrorMessage = errorMsg
+	t.CompletedAt = time.Now().Format(time.RFC3339)
+}
+
+// Running sets the task status to RUNNING. A cancelled task keeps its status
 func (t *Task) Running() {
 	t.statusMutex.Lock()
 	defer t.statusMutex.Unlock()
+	if t.Status == CANCELLED {
+		return
+	}
 	t.Status = RUNNING
 	t.ExecutedAt = time.Now().Format(time.RFC3339)
 }
 
+// Cancelled sets the task status to CANCELLED and stops its execution when it is running. It returns false when the task is already finished
+func (t *Task) Cancelled() bool {
+	t.statusMutex.Lock()
+	defer t.statusMutex.Unlock()
+	if t.Status == SUCCESS || t.Status == FAILED || t.Status == CANCELLED || t.Status == TIMEOUT {
+		return false
+	}
+	t.Status = CANCELLED
+	t.CompletedAt = time.Now().Format(time.RFC3339)
+	if t.cancelFunc != nil {
+		t.cancelFunc()
+	}
+	return true
+}
+
+// SetCancelFunc sets the function that stops the execution of the task. The function is called straight away when the task is already cancelled
+func (t *Task) SetCancelFunc(cancel func()) {
+	t.statusMutex.Lock()
+	defer t.statusMutex.Unlock()
+	t.cancelFunc = cancel
+	if t.Status == CANCELLED && cancel != nil {
+		cancel()
+	}
+}
+
+// IsCancelled returns true when the task is cancelled
+func (t *Task) IsCancelled() bool {
+	return t.GetStatus() == CANCELLED
+}
+
+// SetResult sets the structured result of the task execution
+func (t *Task) SetResult(result *TaskResult) {
+	t.statusMutex.Lock()
+	defer t.statusMutex.Unlock()
+	t.Result = result
+}
+
+// GetStatus returns the current status of the task
+func (t *Task) GetStatus() string {
+	t.statusMutex.Lock()
+	defer t.statusMutex.Unlock()
+	return t.Status
+}
+
+// GetCreatedAt returns the time when the task is created
+func (t *Task) GetCreatedAt() string {
+	t.statusMutex.Lock()
+	defer t.statusMutex.Unlock()
+	return t.CreatedAt
+}
+
+// GetExecutedAt returns the time when the task is executed
+func (t *Task) GetExecutedAt() string {
+	t.statusMutex.Lock()
+	defer t.statusMutex.Unlock()
+	return t.ExecutedAt
+}
+
+// GetCompletedAt returns the time when the task is completed
+func (t *Task) GetCompletedAt() string {
+	t.statusMutex.Lock()
+	defer t.statusMutex.Unlock()
+	return t.CompletedAt
+}
+
+// Snapshot returns a copy of the task taken holding the status lock, since the task status and times can change while the task is running
+func (t *Task) Snapshot() *Task {
+	t.statusMutex.Lock()
+	defer t.statusMutex.Unlock()
+	return &Task{
+		Command:        t.Command,
+		CompletedAt:    t.CompletedAt,
+		CreatedAt:      t.CreatedAt,
+		ErrorMessage:   t.ErrorMessage,
+		ExecutedAt:     t.ExecutedAt,
+		ID:             t.ID,
+		Parameters:     t.Parameters,
+		ProjectID:      t.ProjectID,
+		ProjectVersion: t.ProjectVersion,
+		Result:         t.Result,
+		Status:         t.Status,
+	}
+}
+
+// IsFinished returns true when the task has reached a final status
+func (t *Task) IsFinished() bool {
+	status := t.GetStatus()
+	return status == SUCCESS || status == FAILED || status == CANCELLED || status == TIMEOUT
+}
+
+// MarshalJSON encodes the task holding the status lock, since the task status can change while the task is running
+func (t *Task) MarshalJSON() ([]byte, error) {
+	t.statusMutex.Lock()
+	defer t.statusMutex.Unlock()
+	return json.Marshal((*taskJSON)(t))
+}
+
+// UnmarshalJSON decodes the task. The parameters are decoded into the parameters type of the task command
+func (t *Task) UnmarshalJSON(data []byte) error {
+	decoded := &struct {
+		*taskJSON
+		Parameters json.RawMessage `json:"parameters"`
+	}{
+		taskJSON: (*taskJSON)(t),
+	}
+
+	err := json.Unmarshal(data, decoded)
+	if err != nil {
+		return err
+	}
+
+	t.Parameters = nil
+	if len(decoded.Parameters) == 0 || string(decoded.Parameters) == "null" {
+		return nil
+	}
+
+	switch t.Command {
+	case AnsiblePlaybookCommand:
+		parameters := &AnsiblePlaybookParameters{}
+		err = json.Unmarshal(decoded.Parameters, parameters)
+		if err != nil {
+			return err
+		}
+		t.Parameters = parameters
+	default:
+		var parameters interface{}
+		err = json.Unmarshal(decoded.Parameters, &parameters)
+		if err != nil {
+			return err
+		}
+		t.Parameters = parameters
+	}
+
+	return nil
+}
+
 // Validate validates the task entity
 func (t *Task) Validate() error {
 	validate := validator.New()
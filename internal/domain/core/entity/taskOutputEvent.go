@@ -0,0 +1,38 @@
+package entity
+
+const (
+	// TaskOutputEventTypeOutput identifies an event that contains a line of the task output
+	TaskOutputEventTypeOutput = "output"
+	// TaskOutputEventTypeStatus identifies an event that notifies the status of the task
+	TaskOutputEventTypeStatus = "status"
+)
+
+// TaskOutputEvent represents an event produced while streaming the output of a task
+type TaskOutputEvent struct {
+	// Data is the line of the task output, without the line break. It is only set on output events
+	Data string
+	// Offset is the position in bytes of the task output where the data following this event starts. It can be used to resume the stream
+	Offset int64
+	// Status is the status of the task. It is only set on status events
+	Status string
+	// Type is the type of the event
+	Type string
+}
+
+// NewTaskOutputLineEvent creates an event with a line of the task output
+func NewTaskOutputLineEvent(data string, offset int64) *TaskOutputEvent {
+	return &TaskOutputEvent{
+		Data:   data,
+		Offset: offset,
+		Type:   TaskOutputEventTypeOutput,
+	}
+}
+
+// NewTaskOutputStatusEvent creates an event with the status of the task
+func NewTaskOutputStatusEvent(status string, offset int64) *TaskOutputEvent {
+	return &TaskOutputEvent{
+		Offset: offset,
+		Status: status,
+		Type:   TaskOutputEventTypeStatus,
+	}
+}
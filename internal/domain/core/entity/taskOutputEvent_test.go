@@ -0,0 +1,31 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTaskOutputLineEvent(t *testing.T) {
+	t.Log("Testing task output line event creation")
+	t.Parallel()
+
+	event := NewTaskOutputLineEvent("PLAY [all] ***", 15)
+
+	assert.Equal(t, "PLAY [all] ***", event.Data)
+	assert.Equal(t, int64(15), event.Offset)
+	assert.Empty(t, event.Status)
+	assert.Equal(t, TaskOutputEventTypeOutput, event.Type)
+}
+
+func TestNewTaskOutputStatusEvent(t *testing.T) {
+	t.Log("Testing task output status event creation")
+	t.Parallel()
+
+	event := NewTaskOutputStatusEvent(SUCCESS, 15)
+
+	assert.Empty(t, event.Data)
+	assert.Equal(t, int64(15), event.Offset)
+	assert.Equal(t, SUCCESS, event.Status)
+	assert.Equal(t, TaskOutputEventTypeStatus, event.Type)
+}
@@ -0,0 +1,291 @@
+package entity
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	// TaskSortByCreatedAt sorts the tasks by the time they were created
+	TaskSortByCreatedAt = "created_at"
+	// TaskSortByExecutedAt sorts the tasks by the time they started running
+	TaskSortByExecutedAt = "executed_at"
+	// TaskSortByCompletedAt sorts the tasks by the time they were completed
+	TaskSortByCompletedAt = "completed_at"
+
+	// TaskSortOrderAsc sorts the tasks in ascending order
+	TaskSortOrderAsc = "asc"
+	// TaskSortOrderDesc sorts the tasks in descending order
+	TaskSortOrderDesc = "desc"
+
+	// DefaultTaskQueryLimit is the number of tasks returned in a page when the limit is not set
+	DefaultTaskQueryLimit = 20
+	// MaxTaskQueryLimit is the maximum number of tasks returned in a page
+	MaxTaskQueryLimit = 100
+)
+
+var (
+	// ErrInvalidTaskQueryCursor represents an error when the cursor of a task query can not be decoded
+	ErrInvalidTaskQueryCursor = fmt.Errorf("invalid task query cursor")
+	// ErrTaskQueryCursorMismatch represents an error when the cursor was generated by a query sorted in a different way
+	ErrTaskQueryCursorMismatch = fmt.Errorf("task query cursor does not match the query sorting")
+	// ErrInvalidTaskQueryTimeRange represents an error when the created_at range of a task query is not valid
+	ErrInvalidTaskQueryTimeRange = fmt.Errorf("task query created_after must be before created_before")
+)
+
+// TaskQuery describes the criteria to filter, sort and paginate a list of tasks. Task repositories receive it to look up the tasks, and those that can not translate it into a native query use the Apply method
+type TaskQuery struct {
+	// Command filters the tasks by command
+	Command string `validate:"omitempty,oneof=ansible-playbook"`
+	// CreatedAfter filters the tasks created at or after the given time
+	CreatedAfter time.Time
+	// CreatedBefore filters the tasks created before the given time
+	CreatedBefore time.Time
+	// Cursor is the opaque position, returned on the previous page, from which the next page starts
+	Cursor string
+	// Limit is the maximum number of tasks returned in a page
+	Limit int `validate:"gte=0,lte=100"`
+	// Order is the sort order. It must be one of the following values: asc, desc
+	Order string `validate:"required,oneof=asc desc"`
+	// ProjectID filters the tasks by project
+	ProjectID string
+	// SortBy is the task time used to sort the tasks. It must be one of the following values: created_at, executed_at, completed_at
+	SortBy string `validate:"required,oneof=created_at executed_at completed_at"`
+	// Status filters the tasks by any of the given statuses
+	Status []string `validate:"dive,oneof=ACCEPTED CANCELLED FAILED PENDING RUNNING SUCCESS TIMEOUT"`
+}
+
+// TaskPage represents a page of tasks returned by a task query
+type TaskPage struct {
+	// NextCursor is the cursor to request the next page. It is empty when there are no more tasks
+	NextCursor string
+	// Tasks is the list of tasks in the page
+	Tasks []*Task
+}
+
+// taskQueryCursor is the position of the last task of a page, which is encoded into the cursor
+type taskQueryCursor struct {
+	ID     string `json:"id"`
+	Order  string `json:"order"`
+	SortBy string `json:"sort_by"`
+	Value  string `json:"value"`
+}
+
+// NewTaskQuery creates a new task query sorting the tasks by creation time, from the newest to the oldest
+func NewTaskQuery() *TaskQuery {
+	return &TaskQuery{
+		Limit:  DefaultTaskQueryLimit,
+		Order:  TaskSortOrderDesc,
+		SortBy: TaskSortByCreatedAt,
+	}
+}
+
+// Validate validates the task query
+func (q *TaskQuery) Validate() error {
+	validate := validator.New()
+	err := validate.Struct(q)
+	if err != nil {
+		return err
+	}
+
+	if !q.CreatedAfter.IsZero() && !q.CreatedBefore.IsZero() && !q.CreatedAfter.Before(q.CreatedBefore) {
+		return ErrInvalidTaskQueryTimeRange
+	}
+
+	if q.Cursor != "" {
+		_, err = q.decodeCursor()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Match returns true when the task fulfills the query filters
+func (q *TaskQuery) Match(task *Task) bool {
+	if task == nil {
+		return false
+	}
+
+	if q.ProjectID != "" && task.ProjectID != q.ProjectID {
+		return false
+	}
+
+	if q.Command != "" && task.Command != q.Command {
+		return false
+	}
+
+	if len(q.Status) > 0 {
+		status := task.GetStatus()
+		found := false
+		for _, s := range q.Status {
+			if s == status {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if !q.CreatedAfter.IsZero() || !q.CreatedBefore.IsZero() {
+		createdAt := parseTaskTime(task.GetCreatedAt())
+		if createdAt.IsZero() {
+			return false
+		}
+		if !q.CreatedAfter.IsZero() && createdAt.Before(q.CreatedAfter) {
+			return false
+		}
+		if !q.CreatedBefore.IsZero() && !createdAt.Before(q.CreatedBefore) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Less reports whether the task a is placed before the task b according to the query sorting. Tasks with the same time are sorted by ID to keep a stable order
+func (q *TaskQuery) Less(a, b *Task) bool {
+	return q.compare(q.sortValue(a), a.ID, q.sortValue(b), b.ID) < 0
+}
+
+// Apply filters, sorts and paginates the given tasks according to the query
+func (q *TaskQuery) Apply(tasks []*Task) (*TaskPage, error) {
+	var cursor *taskQueryCursor
+	var err error
+
+	if q.Cursor != "" {
+		cursor, err = q.decodeCursor()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	matched := make([]*Task, 0, len(tasks))
+	for _, task := range tasks {
+		if !q.Match(task) {
+			continue
+		}
+
+		if cursor != nil && q.compare(q.sortValue(task), task.ID, parseTaskTime(cursor.Value), cursor.ID) <= 0 {
+			continue
+		}
+
+		matched = append(matched, task)
+	}
+
+	sort.SliceStable(matched, func(i, j int) bool {
+		return q.Less(matched[i], matched[j])
+	})
+
+	limit := q.Limit
+	if limit <= 0 {
+		limit = DefaultTaskQueryLimit
+	}
+
+	page := &TaskPage{
+		Tasks: matched,
+	}
+
+	if len(matched) > limit {
+		page.Tasks = matched[:limit]
+		page.NextCursor = q.encodeCursor(page.Tasks[limit-1])
+	}
+
+	return page, nil
+}
+
+// sortValue returns the task time used to sort the task. A task without the time is returned as zero time. The time is read holding the task lock, since it changes while the task is running
+func (q *TaskQuery) sortValue(task *Task) time.Time {
+	switch q.SortBy {
+	case TaskSortByExecutedAt:
+		return parseTaskTime(task.GetExecutedAt())
+	case TaskSortByCompletedAt:
+		return parseTaskTime(task.GetCompletedAt())
+	default:
+		return parseTaskTime(task.GetCreatedAt())
+	}
+}
+
+// compare compares two task positions according to the query order
+func (q *TaskQuery) compare(aValue time.Time, aID string, bValue time.Time, bID string) int {
+	result := aValue.Compare(bValue)
+	if result == 0 {
+		result = strings.Compare(aID, bID)
+	}
+
+	if q.Order == TaskSortOrderDesc {
+		return -result
+	}
+
+	return result
+}
+
+// encodeCursor encodes the position of the task into a cursor
+func (q *TaskQuery) encodeCursor(task *Task) string {
+	value := ""
+	sortValue := q.sortValue(task)
+	if !sortValue.IsZero() {
+		value = sortValue.Format(time.RFC3339Nano)
+	}
+
+	// marshalling a struct of strings can not fail
+	data, _ := json.Marshal(&taskQueryCursor{
+		ID:     task.ID,
+		Order:  q.Order,
+		SortBy: q.SortBy,
+		Value:  value,
+	})
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeCursor decodes the query cursor and ensures it was generated by a query with the same sorting
+func (q *TaskQuery) decodeCursor() (*taskQueryCursor, error) {
+	cursor := &taskQueryCursor{}
+
+	data, err := base64.RawURLEncoding.DecodeString(q.Cursor)
+	if err != nil {
+		return nil, ErrInvalidTaskQueryCursor
+	}
+
+	err = json.Unmarshal(data, cursor)
+	if err != nil || cursor.ID == "" {
+		return nil, ErrInvalidTaskQueryCursor
+	}
+
+	if cursor.Value != "" {
+		_, err = time.Parse(time.RFC3339Nano, cursor.Value)
+		if err != nil {
+			return nil, ErrInvalidTaskQueryCursor
+		}
+	}
+
+	if cursor.SortBy != q.SortBy || cursor.Order != q.Order {
+		return nil, ErrTaskQueryCursorMismatch
+	}
+
+	return cursor, nil
+}
+
+// parseTaskTime parses a task time. It returns zero time when the time is not set or it can not be parsed
+func parseTaskTime(value string) time.Time {
+	if value == "" {
+		return time.Time{}
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return t
+}
@@ -0,0 +1,244 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testingTaskQueryTasks() []*Task {
+	return []*Task{
+		{ID: "task-3", ProjectID: "project-2", Command: AnsiblePlaybookCommand, Status: FAILED, CreatedAt: "2026-01-01T10:02:00Z", ExecutedAt: "2026-01-01T10:03:00Z", CompletedAt: "2026-01-01T10:04:00Z"},
+		{ID: "task-1", ProjectID: "project-1", Command: AnsiblePlaybookCommand, Status: SUCCESS, CreatedAt: "2026-01-01T10:00:00Z", ExecutedAt: "2026-01-01T10:05:00Z", CompletedAt: "2026-01-01T10:06:00Z"},
+		{ID: "task-2", ProjectID: "project-1", Command: AnsiblePlaybookCommand, Status: RUNNING, CreatedAt: "2026-01-01T10:01:00Z", ExecutedAt: "2026-01-01T10:01:00Z"},
+		{ID: "task-4", ProjectID: "project-1", Command: AnsiblePlaybookCommand, Status: PENDING, CreatedAt: "2026-01-01T10:01:00Z"},
+	}
+}
+
+func taskIDs(tasks []*Task) []string {
+	ids := []string{}
+	for _, task := range tasks {
+		ids = append(ids, task.ID)
+	}
+	return ids
+}
+
+func TestNewTaskQuery(t *testing.T) {
+	t.Log("Testing task query creation")
+	t.Parallel()
+
+	query := NewTaskQuery()
+
+	assert.Equal(t, DefaultTaskQueryLimit, query.Limit)
+	assert.Equal(t, TaskSortOrderDesc, query.Order)
+	assert.Equal(t, TaskSortByCreatedAt, query.SortBy)
+	assert.NoError(t, query.Validate())
+}
+
+func TestTaskQueryValidate(t *testing.T) {
+	tests := []struct {
+		desc    string
+		query   *TaskQuery
+		wantErr bool
+	}{
+		{
+			desc:    "Validating a task query",
+			query:   &TaskQuery{Order: TaskSortOrderAsc, SortBy: TaskSortByCompletedAt, Status: []string{SUCCESS, FAILED}, Command: AnsiblePlaybookCommand},
+			wantErr: false,
+		},
+		{
+			desc:    "Validating a task query with an invalid sort field",
+			query:   &TaskQuery{Order: TaskSortOrderAsc, SortBy: "id"},
+			wantErr: true,
+		},
+		{
+			desc:    "Validating a task query with an invalid order",
+			query:   &TaskQuery{Order: "up", SortBy: TaskSortByCreatedAt},
+			wantErr: true,
+		},
+		{
+			desc:    "Validating a task query with an invalid status",
+			query:   &TaskQuery{Order: TaskSortOrderAsc, SortBy: TaskSortByCreatedAt, Status: []string{"DONE"}},
+			wantErr: true,
+		},
+		{
+			desc:    "Validating a task query with a limit greater than the maximum",
+			query:   &TaskQuery{Order: TaskSortOrderAsc, SortBy: TaskSortByCreatedAt, Limit: MaxTaskQueryLimit + 1},
+			wantErr: true,
+		},
+		{
+			desc: "Validating a task query with an invalid created_at range",
+			query: &TaskQuery{
+				Order:         TaskSortOrderAsc,
+				SortBy:        TaskSortByCreatedAt,
+				CreatedAfter:  time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC),
+				CreatedBefore: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+			wantErr: true,
+		},
+		{
+			desc:    "Validating a task query with an invalid cursor",
+			query:   &TaskQuery{Order: TaskSortOrderAsc, SortBy: TaskSortByCreatedAt, Cursor: "not a cursor"},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			err := test.query.Validate()
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestTaskQueryApply(t *testing.T) {
+	tests := []struct {
+		desc               string
+		query              *TaskQuery
+		expectedIDs        []string
+		expectedNextCursor bool
+	}{
+		{
+			desc:        "Testing applying a task query sorting by creation time in descending order",
+			query:       NewTaskQuery(),
+			expectedIDs: []string{"task-3", "task-4", "task-2", "task-1"},
+		},
+		{
+			desc:        "Testing applying a task query sorting by execution time in ascending order",
+			query:       &TaskQuery{Order: TaskSortOrderAsc, SortBy: TaskSortByExecutedAt},
+			expectedIDs: []string{"task-4", "task-2", "task-3", "task-1"},
+		},
+		{
+			desc:        "Testing applying a task query filtering by project and status",
+			query:       &TaskQuery{Order: TaskSortOrderAsc, SortBy: TaskSortByCreatedAt, ProjectID: "project-1", Status: []string{SUCCESS, PENDING}},
+			expectedIDs: []string{"task-1", "task-4"},
+		},
+		{
+			desc:        "Testing applying a task query filtering by command",
+			query:       &TaskQuery{Order: TaskSortOrderAsc, SortBy: TaskSortByCreatedAt, Command: "other"},
+			expectedIDs: []string{},
+		},
+		{
+			desc: "Testing applying a task query filtering by created_at range",
+			query: &TaskQuery{
+				Order:         TaskSortOrderAsc,
+				SortBy:        TaskSortByCreatedAt,
+				CreatedAfter:  time.Date(2026, 1, 1, 10, 1, 0, 0, time.UTC),
+				CreatedBefore: time.Date(2026, 1, 1, 10, 2, 0, 0, time.UTC),
+			},
+			expectedIDs: []string{"task-2", "task-4"},
+		},
+		{
+			desc:               "Testing applying a task query with a limit",
+			query:              &TaskQuery{Order: TaskSortOrderAsc, SortBy: TaskSortByCreatedAt, Limit: 2},
+			expectedIDs:        []string{"task-1", "task-2"},
+			expectedNextCursor: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			page, err := test.query.Apply(testingTaskQueryTasks())
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedIDs, taskIDs(page.Tasks))
+			assert.Equal(t, test.expectedNextCursor, page.NextCursor != "")
+		})
+	}
+}
+
+func TestTaskQueryApplyPagination(t *testing.T) {
+	t.Log("Testing paginating the tasks using the cursor")
+	t.Parallel()
+
+	tasks := testingTaskQueryTasks()
+	query := &TaskQuery{Order: TaskSortOrderDesc, SortBy: TaskSortByCreatedAt, Limit: 1}
+
+	ids := []string{}
+	for {
+		page, err := query.Apply(tasks)
+		assert.NoError(t, err)
+		ids = append(ids, taskIDs(page.Tasks)...)
+		if page.NextCursor == "" {
+			break
+		}
+		query.Cursor = page.NextCursor
+	}
+
+	assert.Equal(t, []string{"task-3", "task-4", "task-2", "task-1"}, ids)
+}
+
+func TestTaskQueryApplyCursorErrors(t *testing.T) {
+	tests := []struct {
+		desc  string
+		query func() *TaskQuery
+		err   error
+	}{
+		{
+			desc: "Testing applying a task query with an invalid cursor",
+			query: func() *TaskQuery {
+				return &TaskQuery{Order: TaskSortOrderAsc, SortBy: TaskSortByCreatedAt, Cursor: "%%%"}
+			},
+			err: ErrInvalidTaskQueryCursor,
+		},
+		{
+			desc: "Testing applying a task query with a cursor generated by a query sorted in a different way",
+			query: func() *TaskQuery {
+				query := &TaskQuery{Order: TaskSortOrderAsc, SortBy: TaskSortByCreatedAt, Limit: 1}
+				page, _ := query.Apply(testingTaskQueryTasks())
+				return &TaskQuery{Order: TaskSortOrderDesc, SortBy: TaskSortByCreatedAt, Cursor: page.NextCursor}
+			},
+			err: ErrTaskQueryCursorMismatch,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			_, err := test.query().Apply(testingTaskQueryTasks())
+			assert.Equal(t, test.err, err)
+		})
+	}
+}
+
+func TestTaskQueryApplyWhileTaskIsExecuted(t *testing.T) {
+	t.Log("Testing applying a task query while one of the tasks is executed does not race with the task status changes. It is meaningful when the tests are run with the race detector")
+	t.Parallel()
+
+	tasks := testingTaskQueryTasks()
+	executed := NewTask("task-5", "project-1", AnsiblePlaybookCommand, nil)
+	tasks = append(tasks, executed)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		executed.Accepted()
+		executed.Running()
+		executed.Success()
+	}()
+
+	for _, sortBy := range []string{TaskSortByCreatedAt, TaskSortByExecutedAt, TaskSortByCompletedAt} {
+		query := &TaskQuery{
+			Order:        TaskSortOrderAsc,
+			SortBy:       sortBy,
+			Status:       []string{PENDING, ACCEPTED, RUNNING, SUCCESS},
+			CreatedAfter: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		}
+		_, err := query.Apply(tasks)
+		assert.NoError(t, err)
+	}
+
+	<-done
+}
@@ -0,0 +1,51 @@
+package entity
+
+// TaskResult represents the structured result of a task execution
+type TaskResult struct {
+	// FailedTasks is the list of tasks that failed or whose host was unreachable
+	FailedTasks []*TaskResultFailedTask `json:"failed_tasks,omitempty"`
+	// Hosts is the play recap of each host
+	Hosts map[string]*TaskResultHostRecap `json:"hosts"`
+}
+
+// TaskResultHostRecap represents the play recap of a host
+type TaskResultHostRecap struct {
+	// Changed is the number of tasks that made changes on the host
+	Changed int `json:"changed"`
+	// Failed is the number of tasks that failed on the host
+	Failed int `json:"failed"`
+	// Ignored is the number of tasks that failed on the host and whose errors were ignored
+	Ignored int `json:"ignored"`
+	// Ok is the number of tasks successfully executed on the host
+	Ok int `json:"ok"`
+	// Rescued is the number of tasks that failed on the host and were rescued
+	Rescued int `json:"rescued"`
+	// Skipped is the number of tasks skipped on the host
+	Skipped int `json:"skipped"`
+	// Unreachable is the number of tasks that could not reach the host
+	Unreachable int `json:"unreachable"`
+}
+
+// TaskResultFailedTask represents a task that failed on a host
+type TaskResultFailedTask struct {
+	// Host is the host where the task failed
+	Host string `json:"host"`
+	// Message is the message returned by the module
+	Message string `json:"message,omitempty"`
+	// Module is the module executed by the task
+	Module string `json:"module,omitempty"`
+	// Play is the name of the play which the task belongs to
+	Play string `json:"play,omitempty"`
+	// Task is the name of the task
+	Task string `json:"task"`
+	// Unreachable is true when the task failed because the host was unreachable
+	Unreachable bool `json:"unreachable,omitempty"`
+}
+
+// NewTaskResult creates a new task result
+func NewTaskResult() *TaskResult {
+	return &TaskResult{
+		FailedTasks: []*TaskResultFailedTask{},
+		Hosts:       map[string]*TaskResultHostRecap{},
+	}
+}
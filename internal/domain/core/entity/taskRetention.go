@@ -0,0 +1,98 @@
+package entity
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// TaskRetentionPolicy describes how long the finished tasks are kept. A zero value on any of its limits means that limit is not applied
+type TaskRetentionPolicy struct {
+	// MaxAge is the maximum time a task is kept since it finished
+	MaxAge time.Duration
+	// MaxCountPerProject is the maximum number of finished tasks kept for each project
+	MaxCountPerProject int
+	// MaxCountPerStatus is the maximum number of finished tasks kept for each status
+	MaxCountPerStatus int
+}
+
+// TaskJanitorStats represents the statistics of the last run of the task janitor
+type TaskJanitorStats struct {
+	// CompletedAt is the time when the last run completed
+	CompletedAt time.Time
+	// Errors is the number of tasks or outputs that could not be purged on the last run
+	Errors int
+	// PurgedOutputs is the number of task outputs purged on the last run
+	PurgedOutputs int
+	// PurgedTasks is the number of tasks purged on the last run
+	PurgedTasks int
+	// Runs is the number of times the janitor has run
+	Runs int
+	// StartedAt is the time when the last run started
+	StartedAt time.Time
+}
+
+// IsEnabled returns true when the policy sets any limit
+func (p *TaskRetentionPolicy) IsEnabled() bool {
+	if p == nil {
+		return false
+	}
+
+	return p.MaxAge > 0 || p.MaxCountPerProject > 0 || p.MaxCountPerStatus > 0
+}
+
+// Expired returns the tasks that exceed the retention policy at the given time. Only the finished tasks can expire, and the most recently finished ones are kept first when a count limit is exceeded
+func (p *TaskRetentionPolicy) Expired(tasks []*Task, now time.Time) []*Task {
+	expired := []*Task{}
+
+	if !p.IsEnabled() {
+		return expired
+	}
+
+	finished := make([]*Task, 0, len(tasks))
+	for _, task := range tasks {
+		if task != nil && task.IsFinished() {
+			finished = append(finished, task)
+		}
+	}
+
+	// the tasks are sorted from the most recently finished to the oldest one
+	sort.SliceStable(finished, func(i, j int) bool {
+		result := finishedAt(finished[i]).Compare(finishedAt(finished[j]))
+		if result == 0 {
+			result = strings.Compare(finished[i].ID, finished[j].ID)
+		}
+		return result > 0
+	})
+
+	keptPerProject := map[string]int{}
+	keptPerStatus := map[string]int{}
+
+	for _, task := range finished {
+		status := task.GetStatus()
+
+		switch {
+		case p.MaxAge > 0 && now.Sub(finishedAt(task)) > p.MaxAge:
+			expired = append(expired, task)
+		case p.MaxCountPerProject > 0 && keptPerProject[task.ProjectID] >= p.MaxCountPerProject:
+			expired = append(expired, task)
+		case p.MaxCountPerStatus > 0 && keptPerStatus[status] >= p.MaxCountPerStatus:
+			expired = append(expired, task)
+		default:
+			keptPerProject[task.ProjectID]++
+			keptPerStatus[status]++
+		}
+	}
+
+	return expired
+}
+
+// finishedAt returns the time when the task finished. The creation time is used when the task has no completion time. The times are read holding the task lock, since the worker sets them when the task finishes
+func finishedAt(task *Task) time.Time {
+	completedAt := parseTaskTime(task.GetCompletedAt())
+	if completedAt.IsZero() {
+		return parseTaskTime(task.GetCreatedAt())
+	}
+
+	return completedAt
+}
@@ -0,0 +1,112 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testingTaskRetentionTasks() []*Task {
+	return []*Task{
+		{ID: "task-1", ProjectID: "project-1", Status: SUCCESS, CreatedAt: "2026-01-01T09:00:00Z", CompletedAt: "2026-01-01T10:00:00Z"},
+		{ID: "task-2", ProjectID: "project-1", Status: FAILED, CreatedAt: "2026-01-02T09:00:00Z", CompletedAt: "2026-01-02T10:00:00Z"},
+		{ID: "task-3", ProjectID: "project-1", Status: SUCCESS, CreatedAt: "2026-01-03T09:00:00Z", CompletedAt: "2026-01-03T10:00:00Z"},
+		{ID: "task-4", ProjectID: "project-2", Status: SUCCESS, CreatedAt: "2026-01-04T09:00:00Z", CompletedAt: "2026-01-04T10:00:00Z"},
+		{ID: "task-5", ProjectID: "project-1", Status: RUNNING, CreatedAt: "2026-01-01T08:00:00Z", ExecutedAt: "2026-01-01T08:00:00Z"},
+		{ID: "task-6", ProjectID: "project-2", Status: CANCELLED, CreatedAt: "2026-01-01T08:00:00Z"},
+	}
+}
+
+func TestTaskRetentionPolicyIsEnabled(t *testing.T) {
+	tests := []struct {
+		desc     string
+		policy   *TaskRetentionPolicy
+		expected bool
+	}{
+		{desc: "Testing a nil task retention policy is not enabled", policy: nil, expected: false},
+		{desc: "Testing a task retention policy without limits is not enabled", policy: &TaskRetentionPolicy{}, expected: false},
+		{desc: "Testing a task retention policy with a maximum age is enabled", policy: &TaskRetentionPolicy{MaxAge: time.Hour}, expected: true},
+		{desc: "Testing a task retention policy with a maximum count per project is enabled", policy: &TaskRetentionPolicy{MaxCountPerProject: 1}, expected: true},
+		{desc: "Testing a task retention policy with a maximum count per status is enabled", policy: &TaskRetentionPolicy{MaxCountPerStatus: 1}, expected: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			assert.Equal(t, test.expected, test.policy.IsEnabled())
+		})
+	}
+}
+
+func TestTaskRetentionPolicyExpired(t *testing.T) {
+	now := time.Date(2026, 1, 5, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		desc        string
+		policy      *TaskRetentionPolicy
+		expectedIDs []string
+	}{
+		{
+			desc:        "Testing a task retention policy without limits does not expire tasks",
+			policy:      &TaskRetentionPolicy{},
+			expectedIDs: []string{},
+		},
+		{
+			desc:        "Testing a task retention policy expiring the tasks by age",
+			policy:      &TaskRetentionPolicy{MaxAge: 60 * time.Hour},
+			expectedIDs: []string{"task-2", "task-1", "task-6"},
+		},
+		{
+			desc:        "Testing a task retention policy expiring the tasks by count per project",
+			policy:      &TaskRetentionPolicy{MaxCountPerProject: 1},
+			expectedIDs: []string{"task-2", "task-1", "task-6"},
+		},
+		{
+			desc:        "Testing a task retention policy expiring the tasks by count per status",
+			policy:      &TaskRetentionPolicy{MaxCountPerStatus: 2},
+			expectedIDs: []string{"task-1"},
+		},
+		{
+			desc:        "Testing a task retention policy combining its limits",
+			policy:      &TaskRetentionPolicy{MaxAge: 96 * time.Hour, MaxCountPerProject: 2, MaxCountPerStatus: 1},
+			expectedIDs: []string{"task-3", "task-1", "task-6"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			expired := test.policy.Expired(testingTaskRetentionTasks(), now)
+			assert.Equal(t, test.expectedIDs, taskIDs(expired))
+		})
+	}
+}
+
+func TestTaskRetentionPolicyExpiredWhileTaskIsExecuted(t *testing.T) {
+	t.Log("Testing a task retention policy expiring the tasks while one of them finishes does not race with the task status changes. It is meaningful when the tests are run with the race detector")
+	t.Parallel()
+
+	tasks := testingTaskRetentionTasks()
+	executed := NewTask("task-7", "project-1", AnsiblePlaybookCommand, nil)
+	tasks = append(tasks, executed)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		executed.Running()
+		executed.Success()
+	}()
+
+	policy := &TaskRetentionPolicy{MaxAge: time.Hour, MaxCountPerProject: 1, MaxCountPerStatus: 1}
+	for i := 0; i < 10; i++ {
+		expired := policy.Expired(tasks, time.Date(2026, 1, 5, 10, 0, 0, 0, time.UTC))
+		assert.NotEmpty(t, expired)
+	}
+
+	<-done
+}
@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -182,3 +183,183 @@ func TestSuccess(t *testing.T) {
 
 	assert.Equal(t, SUCCESS, task.Status)
 }
+
+func TestTimedOut(t *testing.T) {
+	t.Log("Testing task entity timed out method")
+
+	task := NewTask("id", "project-id", "command", map[string]interface{}{})
+	task.TimedOut("execution timeout exceeded")
+
+	assert.Equal(t, TIMEOUT, task.Status)
+	assert.Equal(t, "execution timeout exceeded", task.ErrorMessage)
+	assert.NotEmpty(t, task.CompletedAt)
+}
+
+func TestCancelled(t *testing.T) {
+	tests := []struct {
+		desc            string
+		status          string
+		expected        bool
+		expectedStatus  string
+		expectedCancel  bool
+		cancelFuncIsSet bool
+	}{
+		{desc: "Testing cancelling a pending task", status: PENDING, expected: true, expectedStatus: CANCELLED},
+		{desc: "Testing cancelling a running task stops its execution", status: RUNNING, expected: true, expectedStatus: CANCELLED, cancelFuncIsSet: true, expectedCancel: true},
+		{desc: "Testing cancelling a successful task", status: SUCCESS, expected: false, expectedStatus: SUCCESS, cancelFuncIsSet: true},
+		{desc: "Testing cancelling a failed task", status: FAILED, expected: false, expectedStatus: FAILED},
+		{desc: "Testing cancelling a cancelled task", status: CANCELLED, expected: false, expectedStatus: CANCELLED},
+		{desc: "Testing cancelling a timed out task", status: TIMEOUT, expected: false, expectedStatus: TIMEOUT},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			cancelled := false
+			task := &Task{Status: test.status}
+			if test.cancelFuncIsSet {
+				task.cancelFunc = func() { cancelled = true }
+			}
+
+			assert.Equal(t, test.expected, task.Cancelled())
+			assert.Equal(t, test.expectedStatus, task.Status)
+			assert.Equal(t, test.expectedCancel, cancelled)
+		})
+	}
+}
+
+func TestCancelledTaskKeepsItsStatus(t *testing.T) {
+	t.Log("Testing a cancelled task keeps its status when its execution finishes")
+
+	task := NewTask("id", "project-id", "command", map[string]interface{}{})
+	task.Cancelled()
+	task.Accepted()
+	task.Running()
+	task.Failed("error message")
+	task.TimedOut("error message")
+	task.Success()
+
+	assert.Equal(t, CANCELLED, task.Status)
+	assert.Empty(t, task.ErrorMessage)
+}
+
+func TestSetCancelFunc(t *testing.T) {
+	t.Log("Testing task entity set cancel func method calls the function when the task is already cancelled")
+
+	cancelled := false
+	task := NewTask("id", "project-id", "command", map[string]interface{}{})
+	task.Cancelled()
+	task.SetCancelFunc(func() { cancelled = true })
+
+	assert.True(t, cancelled)
+	assert.True(t, task.IsCancelled())
+}
+
+func TestSetResult(t *testing.T) {
+	t.Log("Testing task entity set result method")
+
+	result := NewTaskResult()
+	result.Hosts["127.0.0.1"] = &TaskResultHostRecap{Ok: 2}
+
+	task := NewTask("id", "project-id", "command", map[string]interface{}{})
+	task.SetResult(result)
+
+	assert.Equal(t, result, task.Result)
+}
+
+func TestGetStatus(t *testing.T) {
+	t.Log("Testing task entity get status method")
+
+	task := NewTask("id", "project-id", "command", map[string]interface{}{})
+	task.Running()
+
+	assert.Equal(t, RUNNING, task.GetStatus())
+}
+
+func TestIsFinished(t *testing.T) {
+	tests := []struct {
+		desc     string
+		status   string
+		expected bool
+	}{
+		{desc: "Testing a task with status PENDING is not finished", status: PENDING, expected: false},
+		{desc: "Testing a task with status ACCEPTED is not finished", status: ACCEPTED, expected: false},
+		{desc: "Testing a task with status RUNNING is not finished", status: RUNNING, expected: false},
+		{desc: "Testing a task with status SUCCESS is finished", status: SUCCESS, expected: true},
+		{desc: "Testing a task with status FAILED is finished", status: FAILED, expected: true},
+		{desc: "Testing a task with status CANCELLED is finished", status: CANCELLED, expected: true},
+		{desc: "Testing a task with status TIMEOUT is finished", status: TIMEOUT, expected: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			task := &Task{Status: test.status}
+			assert.Equal(t, test.expected, task.IsFinished())
+		})
+	}
+}
+
+func TestTaskJSONEncoding(t *testing.T) {
+	tests := []struct {
+		desc string
+		task *Task
+	}{
+		{
+			desc: "Testing encoding and decoding an ansible-playbook task",
+			task: &Task{
+				Command:   AnsiblePlaybookCommand,
+				CreatedAt: "2026-01-01T10:00:00Z",
+				ID:        "id",
+				Parameters: &AnsiblePlaybookParameters{
+					Playbooks: []string{"site.yml"},
+					Inventory: "127.0.0.1,",
+				},
+				ProjectID: "project-id",
+				Status:    FAILED,
+			},
+		},
+		{
+			desc: "Testing encoding and decoding a task without parameters",
+			task: &Task{
+				Command: "command",
+				ID:      "id",
+				Status:  PENDING,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			data, err := json.Marshal(test.task)
+			assert.NoError(t, err)
+
+			task := &Task{}
+			err = json.Unmarshal(data, task)
+			assert.NoError(t, err)
+			assert.Equal(t, test.task, task)
+		})
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	t.Log("Testing task entity snapshot method")
+
+	task := NewTask("id", "project-id", "command", map[string]interface{}{})
+	task.Running()
+
+	snapshot := task.Snapshot()
+	task.Success()
+
+	assert.Equal(t, RUNNING, snapshot.Status)
+	assert.Equal(t, "", snapshot.CompletedAt)
+	assert.Equal(t, task.ID, snapshot.ID)
+	assert.Equal(t, task.ExecutedAt, snapshot.ExecutedAt)
+}
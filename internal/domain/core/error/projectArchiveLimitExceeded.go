@@ -0,0 +1,16 @@
+package error
+
+// ProjectArchiveLimitExceededError is an error type for the packed source code of a project whose content exceeds any of the archive limits, such as a decompression bomb
+type ProjectArchiveLimitExceededError struct {
+	Err error
+}
+
+// NewProjectArchiveLimitExceededError creates a new ProjectArchiveLimitExceededError
+func NewProjectArchiveLimitExceededError(err error) *ProjectArchiveLimitExceededError {
+	return &ProjectArchiveLimitExceededError{Err: err}
+}
+
+// Error returns the error message
+func (e *ProjectArchiveLimitExceededError) Error() string {
+	return e.Err.Error()
+}
@@ -0,0 +1,30 @@
+package error
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectArchiveLimitExceededError(t *testing.T) {
+	tests := []struct {
+		desc     string
+		err      error
+		expected string
+	}{
+		{
+			desc:     "Testing project archive limit exceeded error",
+			err:      NewProjectArchiveLimitExceededError(fmt.Errorf("project archive limit exceeded")),
+			expected: "project archive limit exceeded",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+			assert.Equal(t, test.expected, test.err.Error())
+		})
+	}
+}
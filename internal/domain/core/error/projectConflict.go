@@ -0,0 +1,16 @@
+package error
+
+// ProjectConflictError is an error type for a project whose state does not allow the request, such as a project deleted while it has tasks that are not finished
+type ProjectConflictError struct {
+	Err error
+}
+
+// NewProjectConflictError creates a new ProjectConflictError
+func NewProjectConflictError(err error) *ProjectConflictError {
+	return &ProjectConflictError{Err: err}
+}
+
+// Error returns the error message
+func (e *ProjectConflictError) Error() string {
+	return e.Err.Error()
+}
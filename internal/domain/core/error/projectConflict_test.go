@@ -0,0 +1,30 @@
+package error
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectConflictError(t *testing.T) {
+	tests := []struct {
+		desc     string
+		err      error
+		expected string
+	}{
+		{
+			desc:     "Testing project conflict error",
+			err:      NewProjectConflictError(fmt.Errorf("project has active tasks")),
+			expected: "project has active tasks",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+			assert.Equal(t, test.expected, test.err.Error())
+		})
+	}
+}
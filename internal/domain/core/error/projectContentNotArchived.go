@@ -0,0 +1,16 @@
+package error
+
+// ProjectContentNotArchivedError is an error type for a project whose source code is not stored as an archive
+type ProjectContentNotArchivedError struct {
+	Err error
+}
+
+// NewProjectContentNotArchivedError creates a new ProjectContentNotArchivedError
+func NewProjectContentNotArchivedError(err error) *ProjectContentNotArchivedError {
+	return &ProjectContentNotArchivedError{Err: err}
+}
+
+// Error returns the error message
+func (e *ProjectContentNotArchivedError) Error() string {
+	return e.Err.Error()
+}
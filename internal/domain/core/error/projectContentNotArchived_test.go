@@ -0,0 +1,30 @@
+package error
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectContentNotArchivedError(t *testing.T) {
+	tests := []struct {
+		desc     string
+		err      error
+		expected string
+	}{
+		{
+			desc:     "Testing project content not archived error",
+			err:      NewProjectContentNotArchivedError(fmt.Errorf("project source code is not stored as an archive")),
+			expected: "project source code is not stored as an archive",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+			assert.Equal(t, test.expected, test.err.Error())
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package error
+
+// ProjectFileNotFoundError is an error type for a project file not found in the project tree
+type ProjectFileNotFoundError struct {
+	Err error
+}
+
+// NewProjectFileNotFoundError creates a new ProjectFileNotFoundError
+func NewProjectFileNotFoundError(err error) *ProjectFileNotFoundError {
+	return &ProjectFileNotFoundError{Err: err}
+}
+
+// Error returns the error message
+func (e *ProjectFileNotFoundError) Error() string {
+	return e.Err.Error()
+}
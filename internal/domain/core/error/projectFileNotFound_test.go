@@ -0,0 +1,30 @@
+package error
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectFileNotFoundError(t *testing.T) {
+	tests := []struct {
+		desc     string
+		err      error
+		expected string
+	}{
+		{
+			desc:     "Testing project file not found error",
+			err:      NewProjectFileNotFoundError(fmt.Errorf("project file not found")),
+			expected: "project file not found",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+			assert.Equal(t, test.expected, test.err.Error())
+		})
+	}
+}
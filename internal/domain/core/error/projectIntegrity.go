@@ -0,0 +1,16 @@
+package error
+
+// ProjectIntegrityError is an error type for the source code of a project whose digest does not match the expected one
+type ProjectIntegrityError struct {
+	Err error
+}
+
+// NewProjectIntegrityError creates a new ProjectIntegrityError
+func NewProjectIntegrityError(err error) *ProjectIntegrityError {
+	return &ProjectIntegrityError{Err: err}
+}
+
+// Error returns the error message
+func (e *ProjectIntegrityError) Error() string {
+	return e.Err.Error()
+}
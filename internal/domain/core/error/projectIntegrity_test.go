@@ -0,0 +1,30 @@
+package error
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectIntegrityError(t *testing.T) {
+	tests := []struct {
+		desc     string
+		err      error
+		expected string
+	}{
+		{
+			desc:     "Testing project integrity error",
+			err:      NewProjectIntegrityError(fmt.Errorf("project digest mismatch")),
+			expected: "project digest mismatch",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+			assert.Equal(t, test.expected, test.err.Error())
+		})
+	}
+}
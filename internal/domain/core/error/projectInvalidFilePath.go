@@ -0,0 +1,16 @@
+package error
+
+// ProjectInvalidFilePathError is an error type for an invalid project file path
+type ProjectInvalidFilePathError struct {
+	Err error
+}
+
+// NewProjectInvalidFilePathError creates a new ProjectInvalidFilePathError
+func NewProjectInvalidFilePathError(err error) *ProjectInvalidFilePathError {
+	return &ProjectInvalidFilePathError{Err: err}
+}
+
+// Error returns the error message
+func (e *ProjectInvalidFilePathError) Error() string {
+	return e.Err.Error()
+}
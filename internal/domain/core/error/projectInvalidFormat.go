@@ -0,0 +1,16 @@
+package error
+
+// ProjectInvalidFormatError is an error type for a project format that is not valid or cannot be detected
+type ProjectInvalidFormatError struct {
+	Err error
+}
+
+// NewProjectInvalidFormatError creates a new ProjectInvalidFormatError
+func NewProjectInvalidFormatError(err error) *ProjectInvalidFormatError {
+	return &ProjectInvalidFormatError{Err: err}
+}
+
+// Error returns the error message
+func (e *ProjectInvalidFormatError) Error() string {
+	return e.Err.Error()
+}
@@ -0,0 +1,16 @@
+package error
+
+// ProjectInvalidManifestError is an error type for the manifest of a project that cannot be parsed or is not consistent with the project contents
+type ProjectInvalidManifestError struct {
+	Err error
+}
+
+// NewProjectInvalidManifestError creates a new ProjectInvalidManifestError
+func NewProjectInvalidManifestError(err error) *ProjectInvalidManifestError {
+	return &ProjectInvalidManifestError{Err: err}
+}
+
+// Error returns the error message
+func (e *ProjectInvalidManifestError) Error() string {
+	return e.Err.Error()
+}
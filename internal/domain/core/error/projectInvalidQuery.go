@@ -0,0 +1,16 @@
+package error
+
+// ProjectInvalidQueryError is an error type for a project query that is not valid
+type ProjectInvalidQueryError struct {
+	Err error
+}
+
+// NewProjectInvalidQueryError creates a new ProjectInvalidQueryError
+func NewProjectInvalidQueryError(err error) *ProjectInvalidQueryError {
+	return &ProjectInvalidQueryError{Err: err}
+}
+
+// Error returns the error message
+func (e *ProjectInvalidQueryError) Error() string {
+	return e.Err.Error()
+}
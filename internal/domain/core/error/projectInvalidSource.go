@@ -0,0 +1,16 @@
+package error
+
+// ProjectInvalidSourceError is an error type for a project source, such as an OCI artifact reference, that is not valid or cannot be resolved
+type ProjectInvalidSourceError struct {
+	Err error
+}
+
+// NewProjectInvalidSourceError creates a new ProjectInvalidSourceError
+func NewProjectInvalidSourceError(err error) *ProjectInvalidSourceError {
+	return &ProjectInvalidSourceError{Err: err}
+}
+
+// Error returns the error message
+func (e *ProjectInvalidSourceError) Error() string {
+	return e.Err.Error()
+}
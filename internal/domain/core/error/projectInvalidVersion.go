@@ -0,0 +1,16 @@
+package error
+
+// ProjectInvalidVersionError is an error type for a project version that is not valid
+type ProjectInvalidVersionError struct {
+	Err error
+}
+
+// NewProjectInvalidVersionError creates a new ProjectInvalidVersionError
+func NewProjectInvalidVersionError(err error) *ProjectInvalidVersionError {
+	return &ProjectInvalidVersionError{Err: err}
+}
+
+// Error returns the error message
+func (e *ProjectInvalidVersionError) Error() string {
+	return e.Err.Error()
+}
@@ -0,0 +1,16 @@
+package error
+
+// ProjectLastVersionError is an error type for removing the only version of a project
+type ProjectLastVersionError struct {
+	Err error
+}
+
+// NewProjectLastVersionError creates a new ProjectLastVersionError
+func NewProjectLastVersionError(err error) *ProjectLastVersionError {
+	return &ProjectLastVersionError{Err: err}
+}
+
+// Error returns the error message
+func (e *ProjectLastVersionError) Error() string {
+	return e.Err.Error()
+}
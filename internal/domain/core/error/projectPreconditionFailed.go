@@ -0,0 +1,16 @@
+package error
+
+// ProjectPreconditionFailedError is an error type for a conditional request on a project whose revision does not match the expected one, since the project has been modified after the client read it
+type ProjectPreconditionFailedError struct {
+	Err error
+}
+
+// NewProjectPreconditionFailedError creates a new ProjectPreconditionFailedError
+func NewProjectPreconditionFailedError(err error) *ProjectPreconditionFailedError {
+	return &ProjectPreconditionFailedError{Err: err}
+}
+
+// Error returns the error message
+func (e *ProjectPreconditionFailedError) Error() string {
+	return e.Err.Error()
+}
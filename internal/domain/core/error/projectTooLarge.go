@@ -0,0 +1,16 @@
+package error
+
+// ProjectTooLargeError is an error type for the uploaded source code of a project that exceeds the maximum upload size
+type ProjectTooLargeError struct {
+	Err error
+}
+
+// NewProjectTooLargeError creates a new ProjectTooLargeError
+func NewProjectTooLargeError(err error) *ProjectTooLargeError {
+	return &ProjectTooLargeError{Err: err}
+}
+
+// Error returns the error message
+func (e *ProjectTooLargeError) Error() string {
+	return e.Err.Error()
+}
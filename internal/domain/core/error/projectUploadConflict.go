@@ -0,0 +1,16 @@
+package error
+
+// ProjectUploadConflictError is an error type for a project upload whose state does not allow the request, such as a chunk sent at an offset other than the upload offset or an incomplete upload being finalized
+type ProjectUploadConflictError struct {
+	Err error
+}
+
+// NewProjectUploadConflictError creates a new ProjectUploadConflictError
+func NewProjectUploadConflictError(err error) *ProjectUploadConflictError {
+	return &ProjectUploadConflictError{Err: err}
+}
+
+// Error returns the error message
+func (e *ProjectUploadConflictError) Error() string {
+	return e.Err.Error()
+}
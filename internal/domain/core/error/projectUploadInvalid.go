@@ -0,0 +1,16 @@
+package error
+
+// ProjectUploadInvalidError is an error type for invalid project upload parameters
+type ProjectUploadInvalidError struct {
+	Err error
+}
+
+// NewProjectUploadInvalidError creates a new ProjectUploadInvalidError
+func NewProjectUploadInvalidError(err error) *ProjectUploadInvalidError {
+	return &ProjectUploadInvalidError{Err: err}
+}
+
+// Error returns the error message
+func (e *ProjectUploadInvalidError) Error() string {
+	return e.Err.Error()
+}
@@ -0,0 +1,16 @@
+package error
+
+// ProjectUploadNotFoundError is an error type for project upload not found, either because it never existed or because it expired
+type ProjectUploadNotFoundError struct {
+	Err error
+}
+
+// NewProjectUploadNotFoundError creates a new ProjectUploadNotFoundError
+func NewProjectUploadNotFoundError(err error) *ProjectUploadNotFoundError {
+	return &ProjectUploadNotFoundError{Err: err}
+}
+
+// Error returns the error message
+func (e *ProjectUploadNotFoundError) Error() string {
+	return e.Err.Error()
+}
@@ -0,0 +1,16 @@
+package error
+
+// TaskInvalidParametersError is an error type for a task whose parameters are not valid
+type TaskInvalidParametersError struct {
+	Err error
+}
+
+// NewTaskInvalidParametersError creates a new TaskInvalidParametersError
+func NewTaskInvalidParametersError(err error) *TaskInvalidParametersError {
+	return &TaskInvalidParametersError{Err: err}
+}
+
+// Error returns the error message
+func (e *TaskInvalidParametersError) Error() string {
+	return e.Err.Error()
+}
@@ -0,0 +1,16 @@
+package error
+
+// TaskInvalidQueryError is an error type for a task query that is not valid
+type TaskInvalidQueryError struct {
+	Err error
+}
+
+// NewTaskInvalidQueryError creates a new TaskInvalidQueryError
+func NewTaskInvalidQueryError(err error) *TaskInvalidQueryError {
+	return &TaskInvalidQueryError{Err: err}
+}
+
+// Error returns the error message
+func (e *TaskInvalidQueryError) Error() string {
+	return e.Err.Error()
+}
@@ -0,0 +1,16 @@
+package error
+
+// TaskNotCancellableError is an error type for a task that can not be cancelled because it is already finished
+type TaskNotCancellableError struct {
+	Err error
+}
+
+// NewTaskNotCancellableError creates a new TaskNotCancellableError
+func NewTaskNotCancellableError(err error) *TaskNotCancellableError {
+	return &TaskNotCancellableError{Err: err}
+}
+
+// Error returns the error message
+func (e *TaskNotCancellableError) Error() string {
+	return e.Err.Error()
+}
@@ -53,6 +53,8 @@ func (m *AnsiblePlaybookParametersMapper) ToAnsiblePlaybookParametersEntity(para
 		Become:            parameters.Become,
 		BecomeMethod:      parameters.BecomeMethod,
 		BecomeUser:        parameters.BecomeUser,
+		ExecutionTimeout:  parameters.ExecutionTimeout,
+		StructuredResult:  parameters.StructuredResult,
 	}
 }
 
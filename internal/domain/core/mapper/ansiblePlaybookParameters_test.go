@@ -59,6 +59,8 @@ func TestToAnsiblePlaybookParametersEntity(t *testing.T) {
 				Become:            true,
 				BecomeMethod:      "become-method",
 				BecomeUser:        "become-user",
+				ExecutionTimeout:  60,
+				StructuredResult:  true,
 			},
 			expected: &entity.AnsiblePlaybookParameters{
 				Playbooks: []string{"playbook1", "playbook2"},
@@ -100,6 +102,8 @@ func TestToAnsiblePlaybookParametersEntity(t *testing.T) {
 				Become:            true,
 				BecomeMethod:      "become-method",
 				BecomeUser:        "become-user",
+				ExecutionTimeout:  60,
+				StructuredResult:  true,
 			},
 		},
 		{
@@ -2,6 +2,7 @@ package mapper
 
 import (
 	"github.com/apenella/ransidble/internal/domain/core/entity"
+	"github.com/apenella/ransidble/internal/domain/core/model/request"
 	"github.com/apenella/ransidble/internal/domain/core/model/response"
 )
 
@@ -20,10 +21,187 @@ func (m *ProjectMapper) ToProjectResponse(project *entity.Project) *response.Pro
 		return &response.ProjectResponse{}
 	}
 
-	return &response.ProjectResponse{
+	projectResponse := &response.ProjectResponse{
+		CreatedAt: project.CreatedAt,
+		Digest:    project.Digest,
 		Format:    project.Format,
 		Name:      project.Name,
 		Reference: project.Reference,
+		Signature: project.Signature,
 		Storage:   project.Storage,
+		Version:   project.Version,
 	}
+
+	if project.Contents != nil {
+		projectResponse.Contents = &response.ProjectContentsResponse{
+			AnsibleConfigs: project.Contents.AnsibleConfigs,
+			Inventories:    project.Contents.Inventories,
+			Playbooks:      project.Contents.Playbooks,
+			Requirements:   project.Contents.Requirements,
+			Roles:          project.Contents.Roles,
+		}
+	}
+
+	if project.Manifest != nil {
+		projectResponse.Manifest = m.toProjectManifestResponse(project.Manifest)
+	}
+
+	if project.Git != nil {
+		projectResponse.Git = &response.ProjectGitResponse{
+			Ref:          project.Git.Ref,
+			Subdirectory: project.Git.Subdirectory,
+			URL:          project.Git.URL,
+		}
+	}
+
+	if project.OCI != nil {
+		projectResponse.OCI = &response.ProjectOCIResponse{
+			Digest:    project.OCI.Digest,
+			Reference: project.OCI.Reference,
+		}
+	}
+
+	return projectResponse
+}
+
+// toProjectManifestResponse maps a project manifest to a project manifest response
+func (m *ProjectMapper) toProjectManifestResponse(manifest *entity.ProjectManifest) *response.ProjectManifestResponse {
+	manifestResponse := &response.ProjectManifestResponse{
+		Playbooks: manifest.Playbooks,
+	}
+
+	if manifest.Defaults != nil {
+		manifestResponse.Defaults = &response.ProjectManifestDefaultsResponse{
+			BecomeMethod:  manifest.Defaults.BecomeMethod,
+			BecomeUser:    manifest.Defaults.BecomeUser,
+			Connection:    manifest.Defaults.Connection,
+			ExtraVars:     manifest.Defaults.ExtraVars,
+			ExtraVarsFile: manifest.Defaults.ExtraVarsFile,
+			Forks:         manifest.Defaults.Forks,
+			Inventory:     manifest.Defaults.Inventory,
+			Limit:         manifest.Defaults.Limit,
+			SkipTags:      manifest.Defaults.SkipTags,
+			Tags:          manifest.Defaults.Tags,
+			Timeout:       manifest.Defaults.Timeout,
+			User:          manifest.Defaults.User,
+		}
+	}
+
+	if manifest.Requirements != nil {
+		manifestResponse.Requirements = &response.ProjectManifestRequirementsResponse{
+			Collections:     manifest.Requirements.Collections,
+			CollectionsFile: manifest.Requirements.CollectionsFile,
+			RoleFile:        manifest.Requirements.RoleFile,
+			Roles:           manifest.Requirements.Roles,
+		}
+	}
+
+	if manifest.Variables != nil {
+		manifestResponse.Variables = &response.ProjectManifestVariablesResponse{
+			Allowed:  manifest.Variables.Allowed,
+			Required: manifest.Variables.Required,
+		}
+	}
+
+	return manifestResponse
+}
+
+// ToProjectVersionsResponse maps the versions of a project, sorted from the oldest to the most recent, to a project versions response
+func (m *ProjectMapper) ToProjectVersionsResponse(versions []*entity.Project) *response.ProjectVersionsResponse {
+
+	versionsResponse := &response.ProjectVersionsResponse{
+		Versions: make([]*response.ProjectResponse, 0, len(versions)),
+	}
+
+	for _, version := range versions {
+		versionsResponse.Versions = append(versionsResponse.Versions, m.ToProjectResponse(version))
+	}
+
+	if len(versions) > 0 {
+		versionsResponse.Latest = versions[len(versions)-1].Version
+	}
+
+	return versionsResponse
+}
+
+// ToProjectFilesResponse maps the entries of the project tree to a project files response
+func (m *ProjectMapper) ToProjectFilesResponse(files []*entity.ProjectFile) *response.ProjectFilesResponse {
+
+	filesResponse := &response.ProjectFilesResponse{
+		Files: make([]*response.ProjectFileResponse, 0, len(files)),
+	}
+
+	for _, file := range files {
+		filesResponse.Files = append(filesResponse.Files, &response.ProjectFileResponse{
+			Path: file.Path,
+			Size: file.Size,
+			Type: file.Type,
+		})
+	}
+
+	return filesResponse
+}
+
+// ToProjectUploadResponse maps a project upload entity to a project upload response
+func (m *ProjectMapper) ToProjectUploadResponse(upload *entity.ProjectUpload) *response.ProjectUploadResponse {
+
+	if upload == nil {
+		return &response.ProjectUploadResponse{}
+	}
+
+	return &response.ProjectUploadResponse{
+		CreatedAt: upload.CreatedAt,
+		ExpiresAt: upload.ExpiresAt,
+		ID:        upload.ID,
+		Length:    upload.Length,
+		Offset:    upload.Offset,
+		UpdatedAt: upload.UpdatedAt,
+	}
+}
+
+// ToProjectGitSourceEntity maps the git parameters of a project request to a project git source entity
+func (m *ProjectMapper) ToProjectGitSourceEntity(parameters *request.ProjectGitParameters) *entity.ProjectGitSource {
+
+	if parameters == nil {
+		return nil
+	}
+
+	return entity.NewProjectGitSource(parameters.URL, parameters.Ref, parameters.Subdirectory)
+}
+
+// ToProjectOCISourceEntity maps the OCI parameters of a project request to a project OCI source entity. The digest is resolved when the project is created
+func (m *ProjectMapper) ToProjectOCISourceEntity(parameters *request.ProjectOCIParameters) *entity.ProjectOCISource {
+
+	if parameters == nil {
+		return nil
+	}
+
+	return entity.NewProjectOCISource(parameters.Reference, "")
+}
+
+// ToProjectFieldsResponse maps a project entity to a response that only contains the given fields. The project name is always included
+func (m *ProjectMapper) ToProjectFieldsResponse(project *entity.Project, fields []string) map[string]interface{} {
+
+	projectResponse := m.ToProjectResponse(project)
+
+	values := map[string]interface{}{
+		request.ProjectFieldFormat:    projectResponse.Format,
+		request.ProjectFieldName:      projectResponse.Name,
+		request.ProjectFieldReference: projectResponse.Reference,
+		request.ProjectFieldStorage:   projectResponse.Storage,
+		request.ProjectFieldVersion:   projectResponse.Version,
+	}
+
+	fieldsResponse := map[string]interface{}{
+		request.ProjectFieldName: projectResponse.Name,
+	}
+
+	for _, field := range fields {
+		value, ok := values[field]
+		if ok {
+			fieldsResponse[field] = value
+		}
+	}
+
+	return fieldsResponse
 }
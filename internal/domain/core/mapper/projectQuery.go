@@ -0,0 +1,35 @@
+package mapper
+
+import (
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	"github.com/apenella/ransidble/internal/domain/core/model/request"
+)
+
+// ProjectQueryMapper is responsible for mapping project query parameters to a project query entity
+type ProjectQueryMapper struct{}
+
+// NewProjectQueryMapper creates a new project query mapper
+func NewProjectQueryMapper() *ProjectQueryMapper {
+	return &ProjectQueryMapper{}
+}
+
+// ToProjectQueryEntity maps the project query parameters to a project query entity. The parameters that are not provided keep the default query values
+func (m *ProjectQueryMapper) ToProjectQueryEntity(parameters *request.ProjectQueryParameters) *entity.ProjectQuery {
+	query := entity.NewProjectQuery()
+
+	if parameters == nil {
+		return query
+	}
+
+	query.Cursor = parameters.Cursor
+	query.Format = parameters.Format
+	query.NamePrefix = parameters.NamePrefix
+	query.Storage = parameters.Storage
+	query.Version = parameters.Version
+
+	if parameters.Limit > 0 {
+		query.Limit = parameters.Limit
+	}
+
+	return query
+}
@@ -0,0 +1,56 @@
+package mapper
+
+import (
+	"testing"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	"github.com/apenella/ransidble/internal/domain/core/model/request"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToProjectQueryEntity(t *testing.T) {
+	tests := []struct {
+		desc       string
+		parameters *request.ProjectQueryParameters
+		mapper     *ProjectQueryMapper
+		expected   *entity.ProjectQuery
+	}{
+		{
+			desc: "Testing project query parameters mapping",
+			parameters: &request.ProjectQueryParameters{
+				Cursor:     "cursor",
+				Fields:     []string{"name"},
+				Format:     "targz",
+				Limit:      10,
+				NamePrefix: "web",
+				Storage:    "local",
+				Version:    "v1.0.0",
+			},
+			expected: &entity.ProjectQuery{
+				Cursor:     "cursor",
+				Format:     "targz",
+				Limit:      10,
+				NamePrefix: "web",
+				Storage:    "local",
+				Version:    "v1.0.0",
+			},
+			mapper: NewProjectQueryMapper(),
+		},
+		{
+			desc:       "Testing project query parameters mapping with nil parameters",
+			parameters: nil,
+			expected:   entity.NewProjectQuery(),
+			mapper:     NewProjectQueryMapper(),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+			t.Parallel()
+
+			res := test.mapper.ToProjectQueryEntity(test.parameters)
+			assert.Equal(t, test.expected, res)
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/apenella/ransidble/internal/domain/core/entity"
+	"github.com/apenella/ransidble/internal/domain/core/model/request"
 	"github.com/apenella/ransidble/internal/domain/core/model/response"
 	"github.com/stretchr/testify/assert"
 )
@@ -19,16 +20,133 @@ func TestToProjectResponse(t *testing.T) {
 		{
 			desc: "Testing project mapping",
 			project: &entity.Project{
+				CreatedAt: "project-created-at",
+				Digest:    "sha256:project-digest",
 				Format:    "project-format",
 				Name:      "project-name",
 				Reference: "project-reference",
+				Signature: "project-signature",
 				Storage:   "project-storage",
+				Version:   "project-version",
 			},
 			expected: &response.ProjectResponse{
+				CreatedAt: "project-created-at",
+				Digest:    "sha256:project-digest",
 				Format:    "project-format",
 				Name:      "project-name",
 				Reference: "project-reference",
+				Signature: "project-signature",
 				Storage:   "project-storage",
+				Version:   "project-version",
+			},
+			mapper: NewProjectMapper(),
+		},
+		{
+			desc: "Testing project stored in a git repository mapping",
+			project: &entity.Project{
+				Format:    "plain",
+				Git:       entity.NewProjectGitSource("https://example.com/project.git", "main", "ansible"),
+				Name:      "project-name",
+				Reference: "https://example.com/project.git",
+				Storage:   "git",
+				Version:   "project-version",
+			},
+			expected: &response.ProjectResponse{
+				Format: "plain",
+				Git: &response.ProjectGitResponse{
+					Ref:          "main",
+					Subdirectory: "ansible",
+					URL:          "https://example.com/project.git",
+				},
+				Name:      "project-name",
+				Reference: "https://example.com/project.git",
+				Storage:   "git",
+				Version:   "project-version",
+			},
+			mapper: NewProjectMapper(),
+		},
+		{
+			desc: "Testing project stored in an OCI registry mapping",
+			project: &entity.Project{
+				Format:    "oci",
+				Name:      "project-name",
+				OCI:       entity.NewProjectOCISource("registry.example.com/project:v1", "sha256:digest"),
+				Reference: "project-name.oci.tar",
+				Storage:   "oci",
+				Version:   "project-version",
+			},
+			expected: &response.ProjectResponse{
+				Format: "oci",
+				Name:   "project-name",
+				OCI: &response.ProjectOCIResponse{
+					Digest:    "sha256:digest",
+					Reference: "registry.example.com/project:v1",
+				},
+				Reference: "project-name.oci.tar",
+				Storage:   "oci",
+				Version:   "project-version",
+			},
+			mapper: NewProjectMapper(),
+		},
+		{
+			desc: "Testing project with discovered contents mapping",
+			project: &entity.Project{
+				Contents: &entity.ProjectContents{
+					AnsibleConfigs: []string{"ansible.cfg"},
+					Inventories:    []string{"inventory"},
+					Playbooks:      []string{"site.yml"},
+					Requirements:   []string{"requirements.yml"},
+					Roles:          []string{"roles/nginx"},
+				},
+				Format:    "targz",
+				Name:      "project-name",
+				Reference: "project-name.tar.gz",
+				Storage:   "local",
+				Version:   "project-version",
+			},
+			expected: &response.ProjectResponse{
+				Contents: &response.ProjectContentsResponse{
+					AnsibleConfigs: []string{"ansible.cfg"},
+					Inventories:    []string{"inventory"},
+					Playbooks:      []string{"site.yml"},
+					Requirements:   []string{"requirements.yml"},
+					Roles:          []string{"roles/nginx"},
+				},
+				Format:    "targz",
+				Name:      "project-name",
+				Reference: "project-name.tar.gz",
+				Storage:   "local",
+				Version:   "project-version",
+			},
+			mapper: NewProjectMapper(),
+		},
+		{
+			desc: "Testing project with manifest mapping",
+			project: &entity.Project{
+				Format: "targz",
+				Manifest: &entity.ProjectManifest{
+					Defaults:     &entity.ProjectManifestDefaults{Inventory: "inventory", Forks: 10},
+					Playbooks:    []string{"site.yml"},
+					Requirements: &entity.ProjectManifestRequirements{RoleFile: "roles/requirements.yml"},
+					Variables:    &entity.ProjectManifestVariables{Required: []string{"environment"}},
+				},
+				Name:      "project-name",
+				Reference: "project-name.tar.gz",
+				Storage:   "local",
+				Version:   "project-version",
+			},
+			expected: &response.ProjectResponse{
+				Format: "targz",
+				Manifest: &response.ProjectManifestResponse{
+					Defaults:     &response.ProjectManifestDefaultsResponse{Inventory: "inventory", Forks: 10},
+					Playbooks:    []string{"site.yml"},
+					Requirements: &response.ProjectManifestRequirementsResponse{RoleFile: "roles/requirements.yml"},
+					Variables:    &response.ProjectManifestVariablesResponse{Required: []string{"environment"}},
+				},
+				Name:      "project-name",
+				Reference: "project-name.tar.gz",
+				Storage:   "local",
+				Version:   "project-version",
 			},
 			mapper: NewProjectMapper(),
 		},
@@ -57,3 +175,248 @@ func TestToProjectResponse(t *testing.T) {
 		})
 	}
 }
+
+// TestToProjectFieldsResponse maps a project entity to a response containing the given fields
+func TestToProjectFieldsResponse(t *testing.T) {
+	tests := []struct {
+		desc     string
+		project  *entity.Project
+		fields   []string
+		mapper   *ProjectMapper
+		expected map[string]interface{}
+	}{
+		{
+			desc: "Testing project fields mapping",
+			project: &entity.Project{
+				Format:    "project-format",
+				Name:      "project-name",
+				Reference: "project-reference",
+				Storage:   "project-storage",
+				Version:   "project-version",
+			},
+			fields: []string{"version", "format", "unknown"},
+			expected: map[string]interface{}{
+				"format":  "project-format",
+				"name":    "project-name",
+				"version": "project-version",
+			},
+			mapper: NewProjectMapper(),
+		},
+		{
+			desc:    "Testing project fields mapping without fields",
+			project: &entity.Project{Name: "project-name"},
+			fields:  nil,
+			expected: map[string]interface{}{
+				"name": "project-name",
+			},
+			mapper: NewProjectMapper(),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+			t.Parallel()
+
+			res := test.mapper.ToProjectFieldsResponse(test.project, test.fields)
+			assert.Equal(t, test.expected, res)
+		})
+	}
+}
+
+// TestToProjectGitSourceEntity maps the git parameters of a project request to a project git source entity
+func TestToProjectGitSourceEntity(t *testing.T) {
+	tests := []struct {
+		desc       string
+		parameters *request.ProjectGitParameters
+		mapper     *ProjectMapper
+		expected   *entity.ProjectGitSource
+	}{
+		{
+			desc:       "Testing project git parameters mapping",
+			parameters: &request.ProjectGitParameters{URL: "https://example.com/project.git", Ref: "main", Subdirectory: "ansible"},
+			mapper:     NewProjectMapper(),
+			expected:   entity.NewProjectGitSource("https://example.com/project.git", "main", "ansible"),
+		},
+		{
+			desc:       "Testing nil project git parameters mapping",
+			parameters: nil,
+			mapper:     NewProjectMapper(),
+			expected:   nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+			t.Parallel()
+
+			res := test.mapper.ToProjectGitSourceEntity(test.parameters)
+			assert.Equal(t, test.expected, res)
+		})
+	}
+}
+
+// TestToProjectOCISourceEntity maps the OCI parameters of a project request to a project OCI source entity
+func TestToProjectOCISourceEntity(t *testing.T) {
+	tests := []struct {
+		desc       string
+		parameters *request.ProjectOCIParameters
+		mapper     *ProjectMapper
+		expected   *entity.ProjectOCISource
+	}{
+		{
+			desc:       "Testing project OCI parameters mapping",
+			parameters: &request.ProjectOCIParameters{Reference: "registry.example.com/project:v1.0.0"},
+			mapper:     NewProjectMapper(),
+			expected:   entity.NewProjectOCISource("registry.example.com/project:v1.0.0", ""),
+		},
+		{
+			desc:       "Testing nil project OCI parameters mapping",
+			parameters: nil,
+			mapper:     NewProjectMapper(),
+			expected:   nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+			t.Parallel()
+
+			res := test.mapper.ToProjectOCISourceEntity(test.parameters)
+			assert.Equal(t, test.expected, res)
+		})
+	}
+}
+
+func TestToProjectVersionsResponse(t *testing.T) {
+	tests := []struct {
+		desc     string
+		versions []*entity.Project
+		mapper   *ProjectMapper
+		expected *response.ProjectVersionsResponse
+	}{
+		{
+			desc: "Testing project versions mapping",
+			versions: []*entity.Project{
+				{Name: "project-name", Reference: "project-name.tar.gz", Version: "v1"},
+				{Name: "project-name", Reference: "project-name@v2.tar.gz", Version: "v2"},
+			},
+			mapper: NewProjectMapper(),
+			expected: &response.ProjectVersionsResponse{
+				Latest: "v2",
+				Versions: []*response.ProjectResponse{
+					{Name: "project-name", Reference: "project-name.tar.gz", Version: "v1"},
+					{Name: "project-name", Reference: "project-name@v2.tar.gz", Version: "v2"},
+				},
+			},
+		},
+		{
+			desc:     "Testing empty project versions mapping",
+			versions: nil,
+			mapper:   NewProjectMapper(),
+			expected: &response.ProjectVersionsResponse{
+				Versions: []*response.ProjectResponse{},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+			t.Parallel()
+
+			res := test.mapper.ToProjectVersionsResponse(test.versions)
+			assert.Equal(t, test.expected, res)
+		})
+	}
+}
+
+func TestToProjectFilesResponse(t *testing.T) {
+	tests := []struct {
+		desc     string
+		files    []*entity.ProjectFile
+		mapper   *ProjectMapper
+		expected *response.ProjectFilesResponse
+	}{
+		{
+			desc: "Testing project files mapping",
+			files: []*entity.ProjectFile{
+				{Path: "inventory", Type: entity.ProjectFileTypeDirectory},
+				{Path: "inventory/hosts.yml", Size: 7, Type: entity.ProjectFileTypeFile},
+			},
+			mapper: NewProjectMapper(),
+			expected: &response.ProjectFilesResponse{
+				Files: []*response.ProjectFileResponse{
+					{Path: "inventory", Type: "directory"},
+					{Path: "inventory/hosts.yml", Size: 7, Type: "file"},
+				},
+			},
+		},
+		{
+			desc:   "Testing empty project files mapping",
+			files:  nil,
+			mapper: NewProjectMapper(),
+			expected: &response.ProjectFilesResponse{
+				Files: []*response.ProjectFileResponse{},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+			t.Parallel()
+
+			res := test.mapper.ToProjectFilesResponse(test.files)
+			assert.Equal(t, test.expected, res)
+		})
+	}
+}
+
+func TestToProjectUploadResponse(t *testing.T) {
+	tests := []struct {
+		desc     string
+		upload   *entity.ProjectUpload
+		mapper   *ProjectMapper
+		expected *response.ProjectUploadResponse
+	}{
+		{
+			desc: "Testing project upload mapping",
+			upload: &entity.ProjectUpload{
+				CreatedAt: "2026-01-05T10:00:00Z",
+				ExpiresAt: "2026-01-06T10:30:00Z",
+				ID:        "upload-1",
+				Length:    1024,
+				Offset:    512,
+				UpdatedAt: "2026-01-05T10:30:00Z",
+			},
+			mapper: NewProjectMapper(),
+			expected: &response.ProjectUploadResponse{
+				CreatedAt: "2026-01-05T10:00:00Z",
+				ExpiresAt: "2026-01-06T10:30:00Z",
+				ID:        "upload-1",
+				Length:    1024,
+				Offset:    512,
+				UpdatedAt: "2026-01-05T10:30:00Z",
+			},
+		},
+		{
+			desc:     "Testing nil project upload mapping",
+			upload:   nil,
+			mapper:   NewProjectMapper(),
+			expected: &response.ProjectUploadResponse{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+			t.Parallel()
+
+			res := test.mapper.ToProjectUploadResponse(test.upload)
+			assert.Equal(t, test.expected, res)
+		})
+	}
+}
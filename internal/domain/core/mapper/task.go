@@ -1,6 +1,8 @@
 package mapper
 
 import (
+	"time"
+
 	"github.com/apenella/ransidble/internal/domain/core/entity"
 	"github.com/apenella/ransidble/internal/domain/core/model/response"
 )
@@ -20,15 +22,124 @@ func (m *TaskMapper) ToTaskResponse(task *entity.Task) *response.TaskResponse {
 		return &response.TaskResponse{}
 	}
 
+	// the task status and times change while the task is running
+	task = task.Snapshot()
+
 	return &response.TaskResponse{
-		Command:      task.Command,
-		CompletedAt:  task.CompletedAt,
-		CreatedAt:    task.CreatedAt,
-		ErrorMessage: task.ErrorMessage,
-		ExecutedAt:   task.ExecutedAt,
-		ID:           task.ID,
-		Parameters:   task.Parameters,
-		ProjectID:    task.ProjectID,
-		Status:       task.Status,
+		Command:        task.Command,
+		CompletedAt:    task.CompletedAt,
+		CreatedAt:      task.CreatedAt,
+		ErrorMessage:   task.ErrorMessage,
+		ExecutedAt:     task.ExecutedAt,
+		ID:             task.ID,
+		Parameters:     task.Parameters,
+		ProjectID:      task.ProjectID,
+		ProjectVersion: task.ProjectVersion,
+		Result:         m.ToTaskResultResponse(task.Result),
+		Status:         task.Status,
+	}
+}
+
+// ToTaskListResponse maps a page of tasks to a task list response
+func (m *TaskMapper) ToTaskListResponse(page *entity.TaskPage) *response.TaskListResponse {
+
+	listResponse := &response.TaskListResponse{
+		Tasks: make([]*response.TaskResponse, 0),
+	}
+
+	if page == nil {
+		return listResponse
+	}
+
+	listResponse.NextCursor = page.NextCursor
+	for _, task := range page.Tasks {
+		listResponse.Tasks = append(listResponse.Tasks, m.ToTaskResponse(task))
+	}
+
+	return listResponse
+}
+
+// ToTaskJanitorStatsResponse maps the statistics of the task janitor to a response. The times are omitted when the janitor has not run yet
+func (m *TaskMapper) ToTaskJanitorStatsResponse(stats *entity.TaskJanitorStats) *response.TaskJanitorStatsResponse {
+
+	if stats == nil {
+		return &response.TaskJanitorStatsResponse{}
+	}
+
+	statsResponse := &response.TaskJanitorStatsResponse{
+		Errors:        stats.Errors,
+		PurgedOutputs: stats.PurgedOutputs,
+		PurgedTasks:   stats.PurgedTasks,
+		Runs:          stats.Runs,
+	}
+
+	if !stats.StartedAt.IsZero() {
+		statsResponse.StartedAt = stats.StartedAt.Format(time.RFC3339)
+	}
+
+	if !stats.CompletedAt.IsZero() {
+		statsResponse.CompletedAt = stats.CompletedAt.Format(time.RFC3339)
+	}
+
+	return statsResponse
+}
+
+// ToTaskResultResponse maps a task result entity to a task result response. It returns nil when the task has no result
+func (m *TaskMapper) ToTaskResultResponse(result *entity.TaskResult) *response.TaskResultResponse {
+
+	if result == nil {
+		return nil
+	}
+
+	resultResponse := &response.TaskResultResponse{
+		Hosts: make(map[string]*response.TaskResultHostRecapResponse, len(result.Hosts)),
+	}
+
+	for host, recap := range result.Hosts {
+		if recap == nil {
+			continue
+		}
+
+		resultResponse.Hosts[host] = &response.TaskResultHostRecapResponse{
+			Changed:     recap.Changed,
+			Failed:      recap.Failed,
+			Ignored:     recap.Ignored,
+			Ok:          recap.Ok,
+			Rescued:     recap.Rescued,
+			Skipped:     recap.Skipped,
+			Unreachable: recap.Unreachable,
+		}
+	}
+
+	for _, failedTask := range result.FailedTasks {
+		if failedTask == nil {
+			continue
+		}
+
+		resultResponse.FailedTasks = append(resultResponse.FailedTasks, &response.TaskResultFailedTaskResponse{
+			Host:        failedTask.Host,
+			Message:     failedTask.Message,
+			Module:      failedTask.Module,
+			Play:        failedTask.Play,
+			Task:        failedTask.Task,
+			Unreachable: failedTask.Unreachable,
+		})
+	}
+
+	return resultResponse
+}
+
+// ToTaskOutputEventResponse maps a task output event entity to a task output event response
+func (m *TaskMapper) ToTaskOutputEventResponse(event *entity.TaskOutputEvent) *response.TaskOutputEventResponse {
+
+	if event == nil {
+		return &response.TaskOutputEventResponse{}
+	}
+
+	return &response.TaskOutputEventResponse{
+		Data:   event.Data,
+		Offset: event.Offset,
+		Status: event.Status,
+		Type:   event.Type,
 	}
 }
@@ -0,0 +1,78 @@
+package mapper
+
+import (
+	"strings"
+	"time"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	"github.com/apenella/ransidble/internal/domain/core/model/request"
+)
+
+// TaskQueryMapper is responsible for mapping task query parameters to a task query entity
+type TaskQueryMapper struct{}
+
+// NewTaskQueryMapper creates a new task query mapper
+func NewTaskQueryMapper() *TaskQueryMapper {
+	return &TaskQueryMapper{}
+}
+
+// ToTaskQueryEntity maps the task query parameters to a task query entity. The parameters that are not provided keep the default query values
+func (m *TaskQueryMapper) ToTaskQueryEntity(parameters *request.TaskQueryParameters) *entity.TaskQuery {
+	query := entity.NewTaskQuery()
+
+	if parameters == nil {
+		return query
+	}
+
+	query.Command = parameters.Command
+	query.CreatedAfter = m.toTime(parameters.CreatedAfter)
+	query.CreatedBefore = m.toTime(parameters.CreatedBefore)
+	query.Cursor = parameters.Cursor
+	query.ProjectID = parameters.ProjectID
+	query.Status = m.toStatus(parameters.Status)
+
+	if parameters.Limit > 0 {
+		query.Limit = parameters.Limit
+	}
+
+	if parameters.Order != "" {
+		query.Order = parameters.Order
+	}
+
+	if parameters.SortBy != "" {
+		query.SortBy = parameters.SortBy
+	}
+
+	return query
+}
+
+// toTime parses an RFC3339 time. It returns zero time when the value is empty or invalid
+func (m *TaskQueryMapper) toTime(value string) time.Time {
+	if value == "" {
+		return time.Time{}
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return t
+}
+
+// toStatus splits the comma-separated statuses and converts them to upper case
+func (m *TaskQueryMapper) toStatus(values []string) []string {
+	var status []string
+
+	for _, value := range values {
+		for _, s := range strings.Split(value, ",") {
+			s = strings.ToUpper(strings.TrimSpace(s))
+			if s == "" {
+				continue
+			}
+			status = append(status, s)
+		}
+	}
+
+	return status
+}
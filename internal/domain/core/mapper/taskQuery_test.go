@@ -0,0 +1,68 @@
+package mapper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	"github.com/apenella/ransidble/internal/domain/core/model/request"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToTaskQueryEntity(t *testing.T) {
+	tests := []struct {
+		desc       string
+		parameters *request.TaskQueryParameters
+		mapper     *TaskQueryMapper
+		expected   *entity.TaskQuery
+	}{
+		{
+			desc: "Testing task query parameters mapping",
+			parameters: &request.TaskQueryParameters{
+				Command:       "ansible-playbook",
+				CreatedAfter:  "2026-01-01T00:00:00Z",
+				CreatedBefore: "2026-01-02T00:00:00Z",
+				Cursor:        "cursor",
+				Limit:         10,
+				Order:         "asc",
+				ProjectID:     "project-1",
+				SortBy:        "executed_at",
+				Status:        []string{"success,failed", "RUNNING"},
+			},
+			mapper: NewTaskQueryMapper(),
+			expected: &entity.TaskQuery{
+				Command:       "ansible-playbook",
+				CreatedAfter:  time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+				CreatedBefore: time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC),
+				Cursor:        "cursor",
+				Limit:         10,
+				Order:         "asc",
+				ProjectID:     "project-1",
+				SortBy:        "executed_at",
+				Status:        []string{"SUCCESS", "FAILED", "RUNNING"},
+			},
+		},
+		{
+			desc:       "Testing task query parameters mapping with empty parameters",
+			parameters: &request.TaskQueryParameters{},
+			mapper:     NewTaskQueryMapper(),
+			expected:   entity.NewTaskQuery(),
+		},
+		{
+			desc:       "Testing task query parameters mapping with nil parameters",
+			parameters: nil,
+			mapper:     NewTaskQueryMapper(),
+			expected:   entity.NewTaskQuery(),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			query := test.mapper.ToTaskQueryEntity(test.parameters)
+			assert.Equal(t, test.expected, query)
+		})
+	}
+}
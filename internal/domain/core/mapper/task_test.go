@@ -2,6 +2,7 @@ package mapper
 
 import (
 	"testing"
+	"time"
 
 	"github.com/apenella/ransidble/internal/domain/core/entity"
 	"github.com/apenella/ransidble/internal/domain/core/model/response"
@@ -66,3 +67,245 @@ func TestToTaskResponse(t *testing.T) {
 		})
 	}
 }
+
+// TestToTaskListResponse maps a page of tasks to a task list response
+func TestToTaskListResponse(t *testing.T) {
+	tests := []struct {
+		desc     string
+		page     *entity.TaskPage
+		mapper   *TaskMapper
+		expected *response.TaskListResponse
+	}{
+		{
+			desc: "Testing task list mapping",
+			page: &entity.TaskPage{
+				NextCursor: "cursor",
+				Tasks: []*entity.Task{
+					{ID: "task-1", Command: "ansible-playbook", ProjectID: "project-1", Status: "SUCCESS"},
+				},
+			},
+			expected: &response.TaskListResponse{
+				NextCursor: "cursor",
+				Tasks: []*response.TaskResponse{
+					{ID: "task-1", Command: "ansible-playbook", ProjectID: "project-1", Status: "SUCCESS"},
+				},
+			},
+			mapper: NewTaskMapper(),
+		},
+		{
+			desc: "Testing task list mapping with nil page",
+			page: nil,
+			expected: &response.TaskListResponse{
+				Tasks: []*response.TaskResponse{},
+			},
+			mapper: NewTaskMapper(),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+			t.Parallel()
+
+			res := test.mapper.ToTaskListResponse(test.page)
+			assert.Equal(t, test.expected, res)
+		})
+	}
+}
+
+// TestToTaskListResponseWhileTaskIsExecuted maps a page of tasks while one of them is executed
+func TestToTaskListResponseWhileTaskIsExecuted(t *testing.T) {
+	t.Log("Testing task list mapping while one of the tasks is executed does not race with the task status changes. It is meaningful when the tests are run with the race detector")
+	t.Parallel()
+
+	task := entity.NewTask("task-1", "project-1", entity.AnsiblePlaybookCommand, nil)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		task.Running()
+		task.Failed("testing error")
+	}()
+
+	res := NewTaskMapper().ToTaskListResponse(&entity.TaskPage{Tasks: []*entity.Task{task}})
+	assert.Len(t, res.Tasks, 1)
+
+	<-done
+}
+
+// TestToTaskResultResponse maps a task result entity to a task result response
+func TestToTaskResultResponse(t *testing.T) {
+	tests := []struct {
+		desc     string
+		result   *entity.TaskResult
+		mapper   *TaskMapper
+		expected *response.TaskResultResponse
+	}{
+		{
+			desc: "Testing task result mapping",
+			result: &entity.TaskResult{
+				FailedTasks: []*entity.TaskResultFailedTask{
+					{
+						Host:        "host",
+						Message:     "message",
+						Module:      "module",
+						Play:        "play",
+						Task:        "task",
+						Unreachable: true,
+					},
+				},
+				Hosts: map[string]*entity.TaskResultHostRecap{
+					"host": {
+						Changed:     1,
+						Failed:      2,
+						Ignored:     3,
+						Ok:          4,
+						Rescued:     5,
+						Skipped:     6,
+						Unreachable: 7,
+					},
+				},
+			},
+			expected: &response.TaskResultResponse{
+				FailedTasks: []*response.TaskResultFailedTaskResponse{
+					{
+						Host:        "host",
+						Message:     "message",
+						Module:      "module",
+						Play:        "play",
+						Task:        "task",
+						Unreachable: true,
+					},
+				},
+				Hosts: map[string]*response.TaskResultHostRecapResponse{
+					"host": {
+						Changed:     1,
+						Failed:      2,
+						Ignored:     3,
+						Ok:          4,
+						Rescued:     5,
+						Skipped:     6,
+						Unreachable: 7,
+					},
+				},
+			},
+			mapper: NewTaskMapper(),
+		},
+		{
+			desc:     "Testing task result mapping with nil result",
+			result:   nil,
+			expected: nil,
+			mapper:   NewTaskMapper(),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+			t.Parallel()
+
+			res := test.mapper.ToTaskResultResponse(test.result)
+			assert.Equal(t, test.expected, res)
+		})
+	}
+}
+
+// TestToTaskOutputEventResponse maps a task output event entity to a task output event response
+func TestToTaskOutputEventResponse(t *testing.T) {
+	tests := []struct {
+		desc     string
+		event    *entity.TaskOutputEvent
+		mapper   *TaskMapper
+		expected *response.TaskOutputEventResponse
+	}{
+		{
+			desc:  "Testing task output line event mapping",
+			event: entity.NewTaskOutputLineEvent("PLAY [all] ***", 15),
+			expected: &response.TaskOutputEventResponse{
+				Data:   "PLAY [all] ***",
+				Offset: 15,
+				Type:   entity.TaskOutputEventTypeOutput,
+			},
+			mapper: NewTaskMapper(),
+		},
+		{
+			desc:  "Testing task output status event mapping",
+			event: entity.NewTaskOutputStatusEvent(entity.SUCCESS, 15),
+			expected: &response.TaskOutputEventResponse{
+				Offset: 15,
+				Status: entity.SUCCESS,
+				Type:   entity.TaskOutputEventTypeStatus,
+			},
+			mapper: NewTaskMapper(),
+		},
+		{
+			desc:     "Testing task output event mapping with nil event",
+			event:    nil,
+			expected: &response.TaskOutputEventResponse{},
+			mapper:   NewTaskMapper(),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			result := test.mapper.ToTaskOutputEventResponse(test.event)
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
+
+// TestToTaskJanitorStatsResponse maps the task janitor statistics to a response
+func TestToTaskJanitorStatsResponse(t *testing.T) {
+	tests := []struct {
+		desc     string
+		stats    *entity.TaskJanitorStats
+		mapper   *TaskMapper
+		expected *response.TaskJanitorStatsResponse
+	}{
+		{
+			desc: "Testing task janitor statistics mapping",
+			stats: &entity.TaskJanitorStats{
+				CompletedAt:   time.Date(2026, 1, 1, 10, 0, 5, 0, time.UTC),
+				Errors:        1,
+				PurgedOutputs: 2,
+				PurgedTasks:   3,
+				Runs:          4,
+				StartedAt:     time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC),
+			},
+			mapper: NewTaskMapper(),
+			expected: &response.TaskJanitorStatsResponse{
+				CompletedAt:   "2026-01-01T10:00:05Z",
+				Errors:        1,
+				PurgedOutputs: 2,
+				PurgedTasks:   3,
+				Runs:          4,
+				StartedAt:     "2026-01-01T10:00:00Z",
+			},
+		},
+		{
+			desc:     "Testing task janitor statistics mapping when the janitor has not run",
+			stats:    &entity.TaskJanitorStats{},
+			mapper:   NewTaskMapper(),
+			expected: &response.TaskJanitorStatsResponse{},
+		},
+		{
+			desc:     "Testing nil task janitor statistics mapping",
+			stats:    nil,
+			mapper:   NewTaskMapper(),
+			expected: &response.TaskJanitorStatsResponse{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+			t.Parallel()
+
+			res := test.mapper.ToTaskJanitorStatsResponse(test.stats)
+			assert.Equal(t, test.expected, res)
+		})
+	}
+}
@@ -34,8 +34,8 @@ type AnsiblePlaybookParameters struct {
 	// Forks specify number of parallel processes to use (default=50)
 	Forks int `json:"forks,omitempty" validate:"gte=0"`
 
-	// Inventory specify inventory host path
-	Inventory string `json:"inventory,omitempty" validate:"required"`
+	// Inventory specify inventory host path. It is required unless the project manifest defines a default inventory, which is checked when the task is created
+	Inventory string `json:"inventory,omitempty"`
 
 	// Limit is selected hosts additional pattern
 	Limit string `json:"limit,omitempty"`
@@ -136,6 +136,15 @@ type AnsiblePlaybookParameters struct {
 
 	// BecomeUser is ansble-playbook's become user
 	BecomeUser string `json:"become_user,omitempty"`
+
+	// Parameters defined by Ransidble, which are not part of the ansible-playbook's command line.
+
+	// ExecutionTimeout is the maximum time, in seconds, the task can be running. When it is exceeded, the execution is stopped and the task status is set to TIMEOUT
+	ExecutionTimeout int `json:"execution_timeout,omitempty" validate:"gte=0"`
+	// ProjectVersion is the version of the project to run the playbook against. The most recent version of the project is used when it is not provided or when it is latest
+	ProjectVersion string `json:"project_version,omitempty"`
+	// StructuredResult runs the playbook using the JSON stdout callback to provide the structured result of the execution in the task
+	StructuredResult bool `json:"structured_result,omitempty" validate:"boolean"`
 }
 
 // AnsiblePlaybookRequirements represent the requirements to be used on ansible-playbook execution
@@ -72,7 +72,7 @@ func TestRequsetAnsiblePlaybookParametersValidate(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			desc: "Validating a AnsiblePlaybookParameters with empty inventory",
+			desc: "Validating a AnsiblePlaybookParameters with empty inventory, which may be defined by the project manifest",
 			fields: fields{
 				Playbooks:     []string{"playbook.yml"},
 				Check:         false,
@@ -89,7 +89,7 @@ func TestRequsetAnsiblePlaybookParametersValidate(t *testing.T) {
 				Timeout:       30,
 				Become:        false,
 			},
-			wantErr: true,
+			wantErr: false,
 		},
 		{
 			desc: "Testing validate a AnsiblePlaybookParameters with forks less than 1",
@@ -1,23 +1,81 @@
 package request
 
-import "github.com/go-playground/validator/v10"
+import (
+	"fmt"
+
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	// projectStorageGit represents the storage of the projects located in a git repository
+	projectStorageGit = "git"
+	// projectStorageS3 represents the storage of the projects located in an S3-compatible object storage
+	projectStorageS3 = "s3"
+	// projectStorageOCI represents the storage of the projects located in an OCI distribution registry
+	projectStorageOCI = "oci"
+	// projectFormatPlain represents the format of the projects that are not packed
+	projectFormatPlain = "plain"
+	// projectFormatOCI represents the format of the projects packed as OCI artifacts
+	projectFormatOCI = "oci"
+)
 
 // ProjectParameters represents a request describing a project
 type ProjectParameters struct {
-	// Format represents the project format
-	Format string `json:"format" validate:"required,oneof=targz plain"`
+	// Format represents the project format. The auto format detects the format of the uploaded project from its content
+	Format string `json:"format" validate:"required,oneof=targz tar tarzst tarxz zip oci plain auto"`
+	// Git represents the git repository where the project is located. It is required when the storage is git, and not allowed otherwise
+	Git *ProjectGitParameters `json:"git,omitempty" validate:"required_if=Storage git,excluded_unless=Storage git"`
+	// OCI represents the OCI artifact where the project is located. It is required when the storage is oci, and not allowed otherwise
+	OCI *ProjectOCIParameters `json:"oci,omitempty" validate:"required_if=Storage oci,excluded_unless=Storage oci"`
 	// Name represents the project name
 	// Name string `json:"name" validate:"required"`
 	// // Source represents the project source
 	// Reference string `json:"reference" validate:"required"`
 	// Storage represents the project type
-	Storage string `json:"storage" validate:"required,oneof=local"`
+	Storage string `json:"storage" validate:"required,oneof=local git s3 oci"`
+	// Upload represents the resumable upload holding the project source code. When it is provided, the source code is taken from the upload instead of the request. It is not allowed when the storage is git or oci
+	Upload string `json:"upload,omitempty" validate:"excluded_if=Storage git,excluded_if=Storage oci"`
 	// Version represents the project version. This is an optional field, if not provided, the FallbackVersion will be used.
 	Version string `json:"version,omitempty"`
 }
 
+// ProjectGitParameters represents a request describing the git repository where a project is located
+type ProjectGitParameters struct {
+	// Ref represents the branch, tag or commit to fetch. If not provided, the default branch of the repository is fetched
+	Ref string `json:"ref,omitempty" validate:"omitempty,startsnotwith=-"`
+	// Subdirectory represents the directory of the repository where the project is located. If not provided, the repository root is used
+	Subdirectory string `json:"subdirectory,omitempty" validate:"omitempty,startsnotwith=/"`
+	// URL represents the git repository URL
+	URL string `json:"url" validate:"required,startsnotwith=-"`
+}
+
+// ProjectOCIParameters represents a request describing the OCI artifact where a project is located
+type ProjectOCIParameters struct {
+	// Reference represents the artifact reference, such as registry.example.com/team/project:v1.0.0. The artifact is pinned to the digest its reference resolves to when the project is created
+	Reference string `json:"reference" validate:"required,startsnotwith=-"`
+}
+
 // Validate validates the request
 func (p *ProjectParameters) Validate() error {
 	validate := validator.New()
-	return validate.Struct(p)
+	err := validate.Struct(p)
+	if err != nil {
+		return err
+	}
+
+	if p.Storage == projectStorageGit && p.Format != projectFormatPlain {
+		return fmt.Errorf("format %s not supported by %s storage", p.Format, p.Storage)
+	}
+
+	// the projects are stored as a single object, so they must be packed
+	if p.Storage == projectStorageS3 && p.Format == projectFormatPlain {
+		return fmt.Errorf("format %s not supported by %s storage", p.Format, p.Storage)
+	}
+
+	// the artifacts fetched from a registry are written as OCI image-layout tarballs
+	if p.Storage == projectStorageOCI && p.Format != projectFormatOCI {
+		return fmt.Errorf("format %s not supported by %s storage", p.Format, p.Storage)
+	}
+
+	return nil
 }
@@ -9,7 +9,10 @@ import (
 func TestProjectParametersValidate(t *testing.T) {
 	type fields struct {
 		Format  string
+		Git     *ProjectGitParameters
+		OCI     *ProjectOCIParameters
 		Storage string
+		Upload  string
 		Version string
 	}
 	test := []struct {
@@ -25,6 +28,30 @@ func TestProjectParametersValidate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			desc: "Validating a ProjectParameters with zip format",
+			fields: fields{
+				Format:  "zip",
+				Storage: "local",
+			},
+			wantErr: false,
+		},
+		{
+			desc: "Validating a ProjectParameters with auto format",
+			fields: fields{
+				Format:  "auto",
+				Storage: "local",
+			},
+			wantErr: false,
+		},
+		{
+			desc: "Validating a ProjectParameters stored in an S3 bucket with tarzst format",
+			fields: fields{
+				Format:  "tarzst",
+				Storage: "s3",
+			},
+			wantErr: false,
+		},
 		{
 			desc: "Validating a ProjectParameters with empty format",
 			fields: fields{
@@ -64,12 +91,156 @@ func TestProjectParametersValidate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			desc: "Validating a ProjectParameters stored in a git repository",
+			fields: fields{
+				Format:  "plain",
+				Git:     &ProjectGitParameters{URL: "https://example.com/project.git", Ref: "main", Subdirectory: "ansible"},
+				Storage: "git",
+			},
+			wantErr: false,
+		},
+		{
+			desc: "Validating a ProjectParameters stored in a git repository without git parameters",
+			fields: fields{
+				Format:  "plain",
+				Storage: "git",
+			},
+			wantErr: true,
+		},
+		{
+			desc: "Validating a ProjectParameters stored in a git repository without url",
+			fields: fields{
+				Format:  "plain",
+				Git:     &ProjectGitParameters{Ref: "main"},
+				Storage: "git",
+			},
+			wantErr: true,
+		},
+		{
+			desc: "Validating a ProjectParameters stored in a git repository with targz format",
+			fields: fields{
+				Format:  "targz",
+				Git:     &ProjectGitParameters{URL: "https://example.com/project.git"},
+				Storage: "git",
+			},
+			wantErr: true,
+		},
+		{
+			desc: "Validating a ProjectParameters stored in an S3 bucket",
+			fields: fields{
+				Format:  "targz",
+				Storage: "s3",
+			},
+			wantErr: false,
+		},
+		{
+			desc: "Validating a ProjectParameters stored in an S3 bucket with plain format",
+			fields: fields{
+				Format:  "plain",
+				Storage: "s3",
+			},
+			wantErr: true,
+		},
+		{
+			desc: "Validating a ProjectParameters stored in local storage with git parameters",
+			fields: fields{
+				Format:  "targz",
+				Git:     &ProjectGitParameters{URL: "https://example.com/project.git"},
+				Storage: "local",
+			},
+			wantErr: true,
+		},
+		{
+			desc: "Validating a ProjectParameters stored in an OCI registry",
+			fields: fields{
+				Format:  "oci",
+				OCI:     &ProjectOCIParameters{Reference: "registry.example.com/project:v1.0.0"},
+				Storage: "oci",
+			},
+			wantErr: false,
+		},
+		{
+			desc: "Validating a ProjectParameters stored in an OCI registry without oci parameters",
+			fields: fields{
+				Format:  "oci",
+				Storage: "oci",
+			},
+			wantErr: true,
+		},
+		{
+			desc: "Validating a ProjectParameters stored in an OCI registry without reference",
+			fields: fields{
+				Format:  "oci",
+				OCI:     &ProjectOCIParameters{},
+				Storage: "oci",
+			},
+			wantErr: true,
+		},
+		{
+			desc: "Validating a ProjectParameters stored in an OCI registry with targz format",
+			fields: fields{
+				Format:  "targz",
+				OCI:     &ProjectOCIParameters{Reference: "registry.example.com/project:v1.0.0"},
+				Storage: "oci",
+			},
+			wantErr: true,
+		},
+		{
+			desc: "Validating a ProjectParameters uploaded in oci format",
+			fields: fields{
+				Format:  "oci",
+				Storage: "local",
+			},
+			wantErr: false,
+		},
+		{
+			desc: "Validating a ProjectParameters stored in local storage with oci parameters",
+			fields: fields{
+				Format:  "oci",
+				OCI:     &ProjectOCIParameters{Reference: "registry.example.com/project:v1.0.0"},
+				Storage: "local",
+			},
+			wantErr: true,
+		},
+		{
+			desc: "Validating a ProjectParameters whose source code is taken from an upload",
+			fields: fields{
+				Format:  "targz",
+				Storage: "local",
+				Upload:  "b5a3c1f2-7d4e-4f6a-9c8b-1e2d3f4a5b6c",
+			},
+			wantErr: false,
+		},
+		{
+			desc: "Validating a ProjectParameters stored in a git repository with an upload",
+			fields: fields{
+				Format:  "plain",
+				Git:     &ProjectGitParameters{URL: "https://example.com/project.git"},
+				Storage: "git",
+				Upload:  "b5a3c1f2-7d4e-4f6a-9c8b-1e2d3f4a5b6c",
+			},
+			wantErr: true,
+		},
+		{
+			desc: "Validating a ProjectParameters stored in an OCI registry with an upload",
+			fields: fields{
+				Format:  "oci",
+				OCI:     &ProjectOCIParameters{Reference: "registry.example.com/project:v1.0.0"},
+				Storage: "oci",
+				Upload:  "b5a3c1f2-7d4e-4f6a-9c8b-1e2d3f4a5b6c",
+			},
+			wantErr: true,
+		},
 	}
 	for _, test := range test {
 		t.Run(test.desc, func(t *testing.T) {
 			p := &ProjectParameters{
 				Format:  test.fields.Format,
+				Git:     test.fields.Git,
+				OCI:     test.fields.OCI,
 				Storage: test.fields.Storage,
+				Upload:  test.fields.Upload,
 				Version: test.fields.Version,
 			}
 
@@ -0,0 +1,74 @@
+package request
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	// ProjectFieldFormat identifies the project format field
+	ProjectFieldFormat = "format"
+	// ProjectFieldName identifies the project name field
+	ProjectFieldName = "name"
+	// ProjectFieldReference identifies the project reference field
+	ProjectFieldReference = "reference"
+	// ProjectFieldStorage identifies the project storage field
+	ProjectFieldStorage = "storage"
+	// ProjectFieldVersion identifies the project version field
+	ProjectFieldVersion = "version"
+)
+
+// ProjectQueryParameters represents a request to list the projects
+type ProjectQueryParameters struct {
+	// Cursor is the position, returned on the previous page, from which the next page starts
+	Cursor string `query:"cursor"`
+	// Fields is the list of project fields included in the response. Several fields can be provided repeating the parameter or as a comma-separated list
+	Fields []string `query:"fields"`
+	// Format filters the projects by format
+	Format string `query:"format" validate:"omitempty,oneof=plain targz tar tarzst tarxz zip oci"`
+	// Limit is the maximum number of projects returned in a page
+	Limit int `query:"limit" validate:"gte=0,lte=100"`
+	// NamePrefix filters the projects whose name starts with the given prefix
+	NamePrefix string `query:"name_prefix"`
+	// Storage filters the projects by storage
+	Storage string `query:"storage" validate:"omitempty,oneof=local git s3 oci"`
+	// Version filters the projects by version
+	Version string `query:"version"`
+}
+
+// Validate validates the request
+func (p *ProjectQueryParameters) Validate() error {
+	validate := validator.New()
+	err := validate.Struct(p)
+	if err != nil {
+		return err
+	}
+
+	for _, field := range p.FieldList() {
+		err = validate.Var(field, "oneof=format name reference storage version")
+		if err != nil {
+			return fmt.Errorf("invalid project field: %s", field)
+		}
+	}
+
+	return nil
+}
+
+// FieldList returns the list of project fields included in the response, splitting the comma-separated values
+func (p *ProjectQueryParameters) FieldList() []string {
+	var fields []string
+
+	for _, value := range p.Fields {
+		for _, field := range strings.Split(value, ",") {
+			field = strings.TrimSpace(field)
+			if field == "" {
+				continue
+			}
+			fields = append(fields, field)
+		}
+	}
+
+	return fields
+}
@@ -0,0 +1,86 @@
+package request
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectQueryParametersValidate(t *testing.T) {
+	tests := []struct {
+		desc       string
+		parameters *ProjectQueryParameters
+		wantErr    bool
+	}{
+		{
+			desc:       "Validating an empty ProjectQueryParameters",
+			parameters: &ProjectQueryParameters{},
+			wantErr:    false,
+		},
+		{
+			desc: "Validating a ProjectQueryParameters",
+			parameters: &ProjectQueryParameters{
+				Fields:     []string{"name,version"},
+				Format:     "targz",
+				Limit:      10,
+				NamePrefix: "web",
+				Storage:    "local",
+				Version:    "v1.0.0",
+			},
+			wantErr: false,
+		},
+		{
+			desc: "Validating a ProjectQueryParameters with an invalid format",
+			parameters: &ProjectQueryParameters{
+				Format: "rar",
+			},
+			wantErr: true,
+		},
+		{
+			desc: "Validating a ProjectQueryParameters with an invalid storage",
+			parameters: &ProjectQueryParameters{
+				Storage: "ftp",
+			},
+			wantErr: true,
+		},
+		{
+			desc: "Validating a ProjectQueryParameters with an invalid field",
+			parameters: &ProjectQueryParameters{
+				Fields: []string{"name,hash"},
+			},
+			wantErr: true,
+		},
+		{
+			desc: "Validating a ProjectQueryParameters with an invalid limit",
+			parameters: &ProjectQueryParameters{
+				Limit: 101,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			err := test.parameters.Validate()
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestProjectQueryParametersFieldList(t *testing.T) {
+	t.Log("Testing the list of project fields included in the response")
+	t.Parallel()
+
+	parameters := &ProjectQueryParameters{
+		Fields: []string{"name, version", "format", ""},
+	}
+
+	assert.Equal(t, []string{"name", "version", "format"}, parameters.FieldList())
+}
@@ -0,0 +1,17 @@
+package request
+
+import (
+	"github.com/go-playground/validator/v10"
+)
+
+// ProjectUploadParameters represents a request to open a resumable upload of a project source code
+type ProjectUploadParameters struct {
+	// Length represents the size in bytes of the project source code to upload
+	Length int64 `json:"length" validate:"required,gt=0"`
+}
+
+// Validate validates the request
+func (p *ProjectUploadParameters) Validate() error {
+	validate := validator.New()
+	return validate.Struct(p)
+}
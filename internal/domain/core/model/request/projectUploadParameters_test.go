@@ -0,0 +1,48 @@
+package request
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectUploadParametersValidate(t *testing.T) {
+	tests := []struct {
+		desc    string
+		length  int64
+		wantErr bool
+	}{
+		{
+			desc:    "Validating a ProjectUploadParameters",
+			length:  1024,
+			wantErr: false,
+		},
+		{
+			desc:    "Validating a ProjectUploadParameters without length",
+			wantErr: true,
+		},
+		{
+			desc:    "Validating a ProjectUploadParameters with a negative length",
+			length:  -1,
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+			t.Parallel()
+
+			p := &ProjectUploadParameters{
+				Length: test.length,
+			}
+
+			err := p.Validate()
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package request
+
+import "github.com/go-playground/validator/v10"
+
+// TaskQueryParameters represents a request to list the tasks
+type TaskQueryParameters struct {
+	// Command filters the tasks by command
+	Command string `query:"command" validate:"omitempty,oneof=ansible-playbook"`
+	// CreatedAfter filters the tasks created at or after the given time. The time must be in RFC3339 format
+	CreatedAfter string `query:"created_after" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	// CreatedBefore filters the tasks created before the given time. The time must be in RFC3339 format
+	CreatedBefore string `query:"created_before" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	// Cursor is the position, returned on the previous page, from which the next page starts
+	Cursor string `query:"cursor"`
+	// Limit is the maximum number of tasks returned in a page
+	Limit int `query:"limit" validate:"gte=0,lte=100"`
+	// Order is the sort order. It must be one of the following values: asc, desc
+	Order string `query:"order" validate:"omitempty,oneof=asc desc"`
+	// ProjectID filters the tasks by project
+	ProjectID string `query:"project_id"`
+	// SortBy is the task time used to sort the tasks. It must be one of the following values: created_at, executed_at, completed_at
+	SortBy string `query:"sort_by" validate:"omitempty,oneof=created_at executed_at completed_at"`
+	// Status filters the tasks by any of the given statuses. Several statuses can be provided repeating the parameter or as a comma-separated list
+	Status []string `query:"status"`
+}
+
+// Validate validates the request
+func (p *TaskQueryParameters) Validate() error {
+	validate := validator.New()
+	return validate.Struct(p)
+}
@@ -0,0 +1,77 @@
+package request
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaskQueryParametersValidate(t *testing.T) {
+	tests := []struct {
+		desc       string
+		parameters *TaskQueryParameters
+		wantErr    bool
+	}{
+		{
+			desc:       "Validating an empty TaskQueryParameters",
+			parameters: &TaskQueryParameters{},
+			wantErr:    false,
+		},
+		{
+			desc: "Validating a TaskQueryParameters",
+			parameters: &TaskQueryParameters{
+				Command:       "ansible-playbook",
+				CreatedAfter:  "2026-01-01T00:00:00Z",
+				CreatedBefore: "2026-01-02T00:00:00+02:00",
+				Limit:         10,
+				Order:         "asc",
+				ProjectID:     "project-1",
+				SortBy:        "completed_at",
+				Status:        []string{"SUCCESS"},
+			},
+			wantErr: false,
+		},
+		{
+			desc: "Validating a TaskQueryParameters with an invalid created_after time",
+			parameters: &TaskQueryParameters{
+				CreatedAfter: "2026-01-01",
+			},
+			wantErr: true,
+		},
+		{
+			desc: "Validating a TaskQueryParameters with an invalid limit",
+			parameters: &TaskQueryParameters{
+				Limit: 101,
+			},
+			wantErr: true,
+		},
+		{
+			desc: "Validating a TaskQueryParameters with an invalid order",
+			parameters: &TaskQueryParameters{
+				Order: "random",
+			},
+			wantErr: true,
+		},
+		{
+			desc: "Validating a TaskQueryParameters with an invalid sort field",
+			parameters: &TaskQueryParameters{
+				SortBy: "id",
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			err := test.parameters.Validate()
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -2,12 +2,144 @@ package response
 
 // ProjectResponse represents a response describing a project
 type ProjectResponse struct {
+	// Contents represents the Ansible content discovered in the project when it is created
+	Contents *ProjectContentsResponse `json:"contents,omitempty"`
+	// CreatedAt represents the time when the project version is created
+	CreatedAt string `json:"created_at,omitempty"`
+	// Digest represents the digest of the uploaded project source code
+	Digest string `json:"digest,omitempty"`
 	// Format represents the project format
 	Format string `json:"format" validate:"required"`
+	// Git represents the git repository where the project is stored
+	Git *ProjectGitResponse `json:"git,omitempty"`
+	// Manifest represents the manifest shipped with the project
+	Manifest *ProjectManifestResponse `json:"manifest,omitempty"`
 	// Name represents the project name
 	Name string `json:"name" validate:"required"`
+	// OCI represents the OCI artifact of the project
+	OCI *ProjectOCIResponse `json:"oci,omitempty"`
 	// Source represents the project source
 	Reference string `json:"reference" validate:"required"`
+	// Signature represents the detached signature of the uploaded project source code
+	Signature string `json:"signature,omitempty"`
 	// Storage represents the project type
 	Storage string `json:"storage" validate:"required"`
+	// Version represents the project version
+	Version string `json:"version,omitempty"`
+}
+
+// ProjectVersionsResponse represents a response describing the versions of a project
+type ProjectVersionsResponse struct {
+	// Latest represents the most recent version of the project, which is the one resolved by the latest alias
+	Latest string `json:"latest"`
+	// Versions represents the project versions sorted from the oldest to the most recent
+	Versions []*ProjectResponse `json:"versions"`
+}
+
+// ProjectFilesResponse represents a response describing the project tree of a project
+type ProjectFilesResponse struct {
+	// Files represents the entries of the project tree sorted by their path
+	Files []*ProjectFileResponse `json:"files"`
+}
+
+// ProjectFileResponse represents a response describing an entry of the project tree
+type ProjectFileResponse struct {
+	// Path represents the path of the entry relative to the root of the project tree
+	Path string `json:"path"`
+	// Size represents the size in bytes of the entry
+	Size int64 `json:"size"`
+	// Type represents the type of the entry
+	Type string `json:"type"`
+}
+
+// ProjectContentsResponse represents a response describing the Ansible content discovered in a project. The paths are relative to the root of the project tree
+type ProjectContentsResponse struct {
+	// AnsibleConfigs represents the ansible.cfg files
+	AnsibleConfigs []string `json:"ansible_configs,omitempty"`
+	// Inventories represents the inventory files and directories
+	Inventories []string `json:"inventories,omitempty"`
+	// Playbooks represents the YAML files holding a list of plays
+	Playbooks []string `json:"playbooks,omitempty"`
+	// Requirements represents the requirements.yml files
+	Requirements []string `json:"requirements,omitempty"`
+	// Roles represents the role directories
+	Roles []string `json:"roles,omitempty"`
+}
+
+// ProjectManifestResponse represents a response describing the manifest shipped with a project
+type ProjectManifestResponse struct {
+	// Defaults represents the parameters applied to the tasks that do not define them
+	Defaults *ProjectManifestDefaultsResponse `json:"defaults,omitempty"`
+	// Playbooks represents the playbooks that may be executed
+	Playbooks []string `json:"playbooks,omitempty"`
+	// Requirements represents the roles and collections installed before the tasks are executed
+	Requirements *ProjectManifestRequirementsResponse `json:"requirements,omitempty"`
+	// Variables represents the constraints on the extra variables of the tasks
+	Variables *ProjectManifestVariablesResponse `json:"variables,omitempty"`
+}
+
+// ProjectManifestDefaultsResponse represents a response describing the default parameters of the tasks declared in a project manifest
+type ProjectManifestDefaultsResponse struct {
+	// BecomeMethod represents the default privilege escalation method
+	BecomeMethod string `json:"become_method,omitempty"`
+	// BecomeUser represents the default user to become
+	BecomeUser string `json:"become_user,omitempty"`
+	// Connection represents the default connection type
+	Connection string `json:"connection,omitempty"`
+	// ExtraVars represents the default extra variables
+	ExtraVars map[string]interface{} `json:"extra_vars,omitempty"`
+	// ExtraVarsFile represents the default files to load extra variables from
+	ExtraVarsFile []string `json:"extra_vars_file,omitempty"`
+	// Forks represents the default number of parallel processes
+	Forks int `json:"forks,omitempty"`
+	// Inventory represents the default inventory
+	Inventory string `json:"inventory,omitempty"`
+	// Limit represents the default hosts pattern
+	Limit string `json:"limit,omitempty"`
+	// SkipTags represents the default tags to skip
+	SkipTags string `json:"skip_tags,omitempty"`
+	// Tags represents the default tags to run
+	Tags string `json:"tags,omitempty"`
+	// Timeout represents the default connection timeout
+	Timeout int `json:"timeout,omitempty"`
+	// User represents the default user to connect to the hosts
+	User string `json:"user,omitempty"`
+}
+
+// ProjectManifestRequirementsResponse represents a response describing the galaxy requirements declared in a project manifest
+type ProjectManifestRequirementsResponse struct {
+	// Collections represents the collections to install
+	Collections []string `json:"collections,omitempty"`
+	// CollectionsFile represents the requirements file listing the collections to install
+	CollectionsFile string `json:"collections_file,omitempty"`
+	// RoleFile represents the requirements file listing the roles to install
+	RoleFile string `json:"role_file,omitempty"`
+	// Roles represents the roles to install
+	Roles []string `json:"roles,omitempty"`
+}
+
+// ProjectManifestVariablesResponse represents a response describing the constraints on the extra variables declared in a project manifest
+type ProjectManifestVariablesResponse struct {
+	// Allowed represents the extra variables the tasks may provide, along with the required ones
+	Allowed []string `json:"allowed,omitempty"`
+	// Required represents the extra variables the tasks must provide
+	Required []string `json:"required,omitempty"`
+}
+
+// ProjectGitResponse represents a response describing the git repository where a project is stored
+type ProjectGitResponse struct {
+	// Ref represents the branch, tag or commit fetched
+	Ref string `json:"ref,omitempty"`
+	// Subdirectory represents the directory of the repository where the project is located
+	Subdirectory string `json:"subdirectory,omitempty"`
+	// URL represents the git repository URL
+	URL string `json:"url"`
+}
+
+// ProjectOCIResponse represents a response describing the OCI artifact of a project
+type ProjectOCIResponse struct {
+	// Digest represents the digest of the artifact manifest the project is pinned to
+	Digest string `json:"digest"`
+	// Reference represents the artifact reference in the registry
+	Reference string `json:"reference,omitempty"`
 }
@@ -0,0 +1,17 @@
+package response
+
+// ProjectUploadResponse represents a response describing a resumable upload of a project source code
+type ProjectUploadResponse struct {
+	// CreatedAt represents the time when the upload is opened
+	CreatedAt string `json:"created_at,omitempty"`
+	// ExpiresAt represents the time when the upload expires unless it receives another chunk
+	ExpiresAt string `json:"expires_at,omitempty"`
+	// ID represents the upload identifier
+	ID string `json:"id"`
+	// Length represents the size in bytes of the project source code to upload
+	Length int64 `json:"length"`
+	// Offset represents the number of bytes received
+	Offset int64 `json:"offset"`
+	// UpdatedAt represents the time when the upload received its last chunk
+	UpdatedAt string `json:"updated_at,omitempty"`
+}
@@ -0,0 +1,17 @@
+package response
+
+// TaskJanitorStatsResponse represents a response describing the last run of the task janitor
+type TaskJanitorStatsResponse struct {
+	// CompletedAt represents the time when the last run completed
+	CompletedAt string `json:"completed_at,omitempty"`
+	// Errors represents the number of tasks or outputs that could not be purged on the last run
+	Errors int `json:"errors"`
+	// PurgedOutputs represents the number of task outputs purged on the last run
+	PurgedOutputs int `json:"purged_outputs"`
+	// PurgedTasks represents the number of tasks purged on the last run
+	PurgedTasks int `json:"purged_tasks"`
+	// Runs represents the number of times the janitor has run
+	Runs int `json:"runs"`
+	// StartedAt represents the time when the last run started
+	StartedAt string `json:"started_at,omitempty"`
+}
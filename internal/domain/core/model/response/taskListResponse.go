@@ -0,0 +1,9 @@
+package response
+
+// TaskListResponse represents a response describing a page of tasks
+type TaskListResponse struct {
+	// NextCursor is the cursor to request the next page. It is empty when there are no more tasks
+	NextCursor string `json:"next_cursor,omitempty"`
+	// Tasks represents the list of tasks in the page
+	Tasks []*TaskResponse `json:"tasks"`
+}
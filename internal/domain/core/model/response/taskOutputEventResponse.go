@@ -0,0 +1,13 @@
+package response
+
+// TaskOutputEventResponse represents an event sent while streaming the output of a task
+type TaskOutputEventResponse struct {
+	// Data represents a line of the task output
+	Data string `json:"data,omitempty"`
+	// Offset represents the position in bytes of the task output where the data following the event starts
+	Offset int64 `json:"offset"`
+	// Status represents the status of the task
+	Status string `json:"status,omitempty"`
+	// Type represents the type of the event
+	Type string `json:"type" validate:"required"`
+}
@@ -18,6 +18,10 @@ type TaskResponse struct {
 	Parameters interface{} `json:"parameters" validate:"required"`
 	// Project represents the project
 	ProjectID string `json:"project_id" validate:"required"`
+	// ProjectVersion represents the project version pinned by the task
+	ProjectVersion string `json:"project_version,omitempty"`
+	// Result represents the structured result of the task execution
+	Result *TaskResultResponse `json:"result,omitempty"`
 	// Status represents the status of the task
 	Status string `json:"status" validate:"required"`
 }
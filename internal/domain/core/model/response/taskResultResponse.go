@@ -0,0 +1,43 @@
+package response
+
+// TaskResultResponse represents the structured result of a task execution
+type TaskResultResponse struct {
+	// FailedTasks represents the tasks that failed or whose host was unreachable
+	FailedTasks []*TaskResultFailedTaskResponse `json:"failed_tasks,omitempty"`
+	// Hosts represents the play recap of each host
+	Hosts map[string]*TaskResultHostRecapResponse `json:"hosts"`
+}
+
+// TaskResultHostRecapResponse represents the play recap of a host
+type TaskResultHostRecapResponse struct {
+	// Changed represents the number of tasks that made changes on the host
+	Changed int `json:"changed"`
+	// Failed represents the number of tasks that failed on the host
+	Failed int `json:"failed"`
+	// Ignored represents the number of tasks that failed on the host and whose errors were ignored
+	Ignored int `json:"ignored"`
+	// Ok represents the number of tasks successfully executed on the host
+	Ok int `json:"ok"`
+	// Rescued represents the number of tasks that failed on the host and were rescued
+	Rescued int `json:"rescued"`
+	// Skipped represents the number of tasks skipped on the host
+	Skipped int `json:"skipped"`
+	// Unreachable represents the number of tasks that could not reach the host
+	Unreachable int `json:"unreachable"`
+}
+
+// TaskResultFailedTaskResponse represents a task that failed on a host
+type TaskResultFailedTaskResponse struct {
+	// Host represents the host where the task failed
+	Host string `json:"host"`
+	// Message represents the message returned by the module
+	Message string `json:"message,omitempty"`
+	// Module represents the module executed by the task
+	Module string `json:"module,omitempty"`
+	// Play represents the name of the play which the task belongs to
+	Play string `json:"play,omitempty"`
+	// Task represents the name of the task
+	Task string `json:"task"`
+	// Unreachable represents whether the task failed because the host was unreachable
+	Unreachable bool `json:"unreachable,omitempty"`
+}
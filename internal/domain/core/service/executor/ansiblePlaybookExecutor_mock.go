@@ -2,6 +2,7 @@ package executor
 
 import (
 	"context"
+	"io"
 
 	"github.com/apenella/ransidble/internal/domain/core/entity"
 	"github.com/stretchr/testify/mock"
@@ -18,7 +19,12 @@ func NewMockAnsiblePlaybookExecutor() *MockAnsiblePlaybookExecutor {
 }
 
 // Run runs the mock ansible playbook
-func (m *MockAnsiblePlaybookExecutor) Run(ctx context.Context, workingDir string, parameters *entity.AnsiblePlaybookParameters) error {
-	args := m.Called(ctx, workingDir, parameters)
-	return args.Error(0)
+func (m *MockAnsiblePlaybookExecutor) Run(ctx context.Context, workingDir string, parameters *entity.AnsiblePlaybookParameters, output io.Writer) (*entity.TaskResult, error) {
+	args := m.Called(ctx, workingDir, parameters, output)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entity.TaskResult), args.Error(1)
 }
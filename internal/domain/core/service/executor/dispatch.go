@@ -18,6 +18,10 @@ const (
 var (
 	// ErrDispatcherStartingWorker represents an error when starting a worker
 	ErrDispatcherStartingWorker = "error starting worker"
+	// ErrTaskNotProvided represents an error when the task is not provided
+	ErrTaskNotProvided = fmt.Errorf("task not provided")
+	// ErrTaskAlreadyFinished represents an error when cancelling a task that is already finished
+	ErrTaskAlreadyFinished = fmt.Errorf("task already finished")
 )
 
 // Dispatch represents a dispatcher to run tasks
@@ -28,8 +32,18 @@ type Dispatch struct {
 	onceStart sync.Once
 	// onceStop is the sync.Once to stop the dispatcher
 	onceStop sync.Once
-	// queue is the queue of tasks to be executed
-	queue chan *entity.Task
+	// pending holds the tasks waiting for a worker, in the order they were queued
+	pending []*entity.Task
+	// pendingCh notifies the dispatcher that a task has been queued
+	pendingCh chan struct{}
+	// pendingMutex is the mutex to guard the pending tasks
+	pendingMutex sync.Mutex
+	// slots limits the number of pending tasks. Each pending task takes a slot, which is released once the task reaches a worker or it is cancelled
+	slots chan struct{}
+	// taskOutputRepository is the repository where the tasks output is stored
+	taskOutputRepository repository.TaskOutputRepository
+	// taskRepository is the repository where the workers persist the task status changes
+	taskRepository repository.TaskRepository
 	// stopCh is the channel to stop the dispatcher
 	stopCh chan struct{}
 	// workerPool is the pool of workers
@@ -47,6 +61,7 @@ func NewDispatch(
 	workers int,
 	workspaceBuilder service.WorkspaceBuilder,
 	ansiblePlaybookExecutor AnsiblePlaybookExecutor,
+	taskOutputRepository repository.TaskOutputRepository,
 	logger repository.Logger,
 ) *Dispatch {
 
@@ -57,25 +72,35 @@ func NewDispatch(
 	return &Dispatch{
 		ansiblePlaybookExecutor: ansiblePlaybookExecutor,
 		logger:                  logger,
-		queue:                   make(chan *entity.Task, workers),
+		pending:                 []*entity.Task{},
+		pendingCh:               make(chan struct{}, 1),
+		slots:                   make(chan struct{}, workers),
 		stopCh:                  make(chan struct{}),
+		taskOutputRepository:    taskOutputRepository,
 		workerPool:              make(chan chan *entity.Task, workers),
 		workers:                 make([]*Worker, 0, workers),
 		workspaceBuilder:        workspaceBuilder,
 	}
 }
 
+// WithTaskRepository sets the repository where the workers persist the status changes of the tasks they run. It must be set before starting the dispatcher
+func (d *Dispatch) WithTaskRepository(taskRepository repository.TaskRepository) *Dispatch {
+	d.taskRepository = taskRepository
+	return d
+}
+
 // Start starts the dispatcher
 func (d *Dispatch) Start(ctx context.Context) (err error) {
 
 	d.onceStart.Do(func() {
 
-		for i := 0; i < cap(d.queue); i++ {
+		for i := 0; i < cap(d.slots); i++ {
 			worker := NewWorker(
 				d.workerPool,
 				d.workspaceBuilder,
 				d.ansiblePlaybookExecutor,
-				d.logger)
+				d.taskOutputRepository,
+				d.logger).WithTaskRepository(d.taskRepository)
 			d.workers = append(d.workers, worker)
 			workerStartErr := worker.Start(ctx)
 
@@ -90,13 +115,21 @@ func (d *Dispatch) Start(ctx context.Context) (err error) {
 			}
 		}
 
-		// main loop of the dispatcher must receive tasks from the queue. Then achieve the worker channel from the worker pool and send the task to the worker channel
+		// main loop of the dispatcher must be notified of the pending tasks. Then, for each pending task, achieve the worker channel from the worker pool and send the next pending task to the worker channel
 		go func() {
 			for {
 				select {
-				case task := <-d.queue:
-					workerChannel := <-d.workerPool
-					workerChannel <- task
+				case <-d.pendingCh:
+					for d.hasPending() {
+						workerChannel := <-d.workerPool
+						task := d.nextPending()
+						// the pending tasks may have been cancelled while waiting for the worker, so the worker is given back to the pool
+						if task == nil {
+							d.workerPool <- workerChannel
+							break
+						}
+						workerChannel <- task
+					}
 				case <-ctx.Done():
 					d.Stop()
 				case <-d.stopCh:
@@ -111,7 +144,7 @@ func (d *Dispatch) Start(ctx context.Context) (err error) {
 						"component": "Dispatch.Start",
 						"package":   "github.com/apenella/ransidble/internal/domain/core/service/task",
 					})
-					close(d.queue)
+					close(d.slots)
 					return
 				}
 			}
@@ -133,8 +166,92 @@ func (d *Dispatch) Stop() {
 	})
 }
 
-// Execute executes a task
+// Execute executes a task. The task is queued until a worker is available, and it blocks while all the pending slots are taken
 func (d *Dispatch) Execute(task *entity.Task) error {
-	d.queue <- task
+	d.slots <- struct{}{}
+
+	d.pendingMutex.Lock()
+	d.pending = append(d.pending, task)
+	d.pendingMutex.Unlock()
+
+	select {
+	case d.pendingCh <- struct{}{}:
+	default:
+	}
+
 	return nil
 }
+
+// Cancel cancels a task. A queued task is removed from the pending tasks and its slot is released, while a running task has its execution context cancelled
+func (d *Dispatch) Cancel(task *entity.Task) error {
+	if task == nil {
+		d.logger.Error(ErrTaskNotProvided.Error(), map[string]interface{}{
+			"component": "Dispatch.Cancel",
+			"package":   "github.com/apenella/ransidble/internal/domain/core/service/task",
+		})
+		return ErrTaskNotProvided
+	}
+
+	if !task.Cancelled() {
+		d.logger.Error(ErrTaskAlreadyFinished.Error(), map[string]interface{}{
+			"component": "Dispatch.Cancel",
+			"package":   "github.com/apenella/ransidble/internal/domain/core/service/task",
+			"task_id":   task.ID,
+		})
+		return ErrTaskAlreadyFinished
+	}
+
+	d.removePending(task)
+
+	d.logger.Info(fmt.Sprintf("Task %s cancelled", task.ID), map[string]interface{}{
+		"component": "Dispatch.Cancel",
+		"package":   "github.com/apenella/ransidble/internal/domain/core/service/task",
+		"task_id":   task.ID,
+	})
+
+	return nil
+}
+
+// hasPending returns whether there are tasks waiting for a worker
+func (d *Dispatch) hasPending() bool {
+	d.pendingMutex.Lock()
+	defer d.pendingMutex.Unlock()
+
+	return len(d.pending) > 0
+}
+
+// nextPending removes the first pending task and releases its slot. It returns nil when there are no pending tasks
+func (d *Dispatch) nextPending() *entity.Task {
+	d.pendingMutex.Lock()
+	defer d.pendingMutex.Unlock()
+
+	if len(d.pending) == 0 {
+		return nil
+	}
+
+	task := d.pending[0]
+	d.pending = d.pending[1:]
+	<-d.slots
+
+	return task
+}
+
+// removePending removes a task from the pending tasks and releases its slot. A task that is not pending, such as a running task, is ignored
+func (d *Dispatch) removePending(task *entity.Task) {
+	d.pendingMutex.Lock()
+	defer d.pendingMutex.Unlock()
+
+	for i, pendingTask := range d.pending {
+		if pendingTask == task {
+			d.pending = append(d.pending[:i], d.pending[i+1:]...)
+			<-d.slots
+
+			d.logger.Debug(fmt.Sprintf("Task %s removed from the pending tasks because it is cancelled", task.ID), map[string]interface{}{
+				"component": "Dispatch.removePending",
+				"package":   "github.com/apenella/ransidble/internal/domain/core/service/task",
+				"task_id":   task.ID,
+			})
+			return
+		}
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/apenella/ransidble/internal/domain/ports/repository"
 	"github.com/apenella/ransidble/internal/infrastructure/logger"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestDispatchTaskExecution(t *testing.T) {
@@ -20,12 +21,12 @@ func TestDispatchTaskExecution(t *testing.T) {
 
 		// arrange workspace mocks for testing the dispatcher
 		mockWorkspace := &repository.MockWorkspace{}
-		mockWorkspace.On("Prepare").Return(nil)
+		mockWorkspace.On("Prepare", mock.Anything).Return(nil)
 		mockWorkspace.On("GetWorkingDir").Return("/tmp", nil)
 		mockWorkspace.On("Cleanup").Return(nil)
 		// arrange ansible playbook executor mocks for testing the dispatcher
 		ansiblePlaybookExecutor := NewMockAnsiblePlaybookExecutor()
-		ansiblePlaybookExecutor.On("Run", context.TODO(), "/tmp", &entity.AnsiblePlaybookParameters{}).Return(nil)
+		ansiblePlaybookExecutor.On("Run", mock.Anything, "/tmp", &entity.AnsiblePlaybookParameters{}, NewTaskOutputWriter("task-id", nil)).Return(nil, nil)
 
 		workspaceBuilder := &repository.MockBuilder{
 			Workspace: mockWorkspace,
@@ -35,6 +36,7 @@ func TestDispatchTaskExecution(t *testing.T) {
 			1,
 			workspaceBuilder,
 			ansiblePlaybookExecutor,
+			nil,
 			logger.NewFakeLogger(),
 		)
 
@@ -96,6 +98,7 @@ func TestDispatchTaskExecutionContextCancelled(t *testing.T) {
 			1,
 			workspaceBuilder,
 			ansiblePlaybookExecutor,
+			nil,
 			logger.NewFakeLogger(),
 		)
 
@@ -118,3 +121,168 @@ func TestDispatchTaskExecutionContextCancelled(t *testing.T) {
 	})
 
 }
+
+func TestDispatchCancel(t *testing.T) {
+	tests := []struct {
+		desc           string
+		task           *entity.Task
+		expectedStatus string
+		err            error
+	}{
+		{
+			desc: "Testing cancelling a pending task",
+			task: &entity.Task{
+				ID:     "task-id",
+				Status: entity.PENDING,
+			},
+			expectedStatus: entity.CANCELLED,
+		},
+		{
+			desc: "Testing cancelling a running task",
+			task: &entity.Task{
+				ID:     "task-id",
+				Status: entity.RUNNING,
+			},
+			expectedStatus: entity.CANCELLED,
+		},
+		{
+			desc: "Testing error cancelling a finished task",
+			task: &entity.Task{
+				ID:     "task-id",
+				Status: entity.SUCCESS,
+			},
+			expectedStatus: entity.SUCCESS,
+			err:            ErrTaskAlreadyFinished,
+		},
+		{
+			desc: "Testing error cancelling a task when the task is not provided",
+			task: nil,
+			err:  ErrTaskNotProvided,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			dispatch := NewDispatch(1, nil, nil, nil, logger.NewFakeLogger())
+
+			err := dispatch.Cancel(test.task)
+			if err != nil {
+				assert.Equal(t, test.err, err)
+				if test.task != nil {
+					assert.Equal(t, test.expectedStatus, test.task.Status)
+				}
+				return
+			}
+
+			assert.Equal(t, test.expectedStatus, test.task.Status)
+		})
+	}
+}
+
+func TestDispatchDiscardsCancelledTasks(t *testing.T) {
+
+	t.Run("Testing the execution dispatcher discards the tasks cancelled while they are queued", func(t *testing.T) {
+		t.Parallel()
+		t.Log("Testing the execution dispatcher discards the tasks cancelled while they are queued")
+
+		mockWorkspace := &repository.MockWorkspace{}
+		ansiblePlaybookExecutor := NewMockAnsiblePlaybookExecutor()
+		workspaceBuilder := &repository.MockBuilder{
+			Workspace: mockWorkspace,
+		}
+
+		dispatch := NewDispatch(
+			1,
+			workspaceBuilder,
+			ansiblePlaybookExecutor,
+			nil,
+			logger.NewFakeLogger(),
+		)
+
+		task := &entity.Task{
+			ID:         "task-id",
+			Status:     "PENDING",
+			Parameters: &entity.AnsiblePlaybookParameters{},
+			Command:    "ansible-playbook",
+			ProjectID:  "project-id",
+		}
+
+		// the task is queued before starting the dispatcher to ensure it is cancelled before reaching a worker
+		err := dispatch.Execute(task)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		err = dispatch.Cancel(task)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		err = dispatch.Start(context.TODO())
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		// Wait for the task to be processed
+		time.Sleep(500 * time.Millisecond)
+
+		assert.Equal(t, entity.CANCELLED, task.GetStatus())
+		mockWorkspace.AssertExpectations(t)
+		ansiblePlaybookExecutor.AssertExpectations(t)
+
+		dispatch.Stop()
+	})
+}
+
+func TestDispatchCancelReleasesQueuedTaskSlot(t *testing.T) {
+
+	t.Run("Testing cancelling a task queued in a full queue releases its slot", func(t *testing.T) {
+		t.Parallel()
+		t.Log("Testing cancelling a task queued in a full queue releases its slot")
+
+		// the dispatcher is not started, so the queued tasks never reach a worker
+		dispatch := NewDispatch(1, nil, nil, nil, logger.NewFakeLogger())
+
+		queuedTask := &entity.Task{
+			ID:     "queued-task-id",
+			Status: entity.PENDING,
+		}
+		waitingTask := &entity.Task{
+			ID:     "waiting-task-id",
+			Status: entity.PENDING,
+		}
+
+		err := dispatch.Execute(queuedTask)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		// the queue is full, so executing another task blocks until a slot is released
+		executed := make(chan error)
+		go func() {
+			executed <- dispatch.Execute(waitingTask)
+		}()
+
+		select {
+		case <-executed:
+			t.Fatal("task executed while the queue is full")
+		case <-time.After(100 * time.Millisecond):
+		}
+
+		err = dispatch.Cancel(queuedTask)
+		assert.NoError(t, err)
+
+		select {
+		case err = <-executed:
+			assert.NoError(t, err)
+		case <-time.After(time.Second):
+			t.Fatal("slot of the cancelled task not released")
+		}
+
+		assert.Equal(t, entity.CANCELLED, queuedTask.GetStatus())
+		assert.Equal(t, []*entity.Task{waitingTask}, dispatch.pending)
+	})
+}
@@ -6,11 +6,12 @@ package executor
 
 import (
 	"context"
+	"io"
 
 	"github.com/apenella/ransidble/internal/domain/core/entity"
 )
 
 // AnsiblePlaybookExecutor represents the interface for the ansible playbook executor
 type AnsiblePlaybookExecutor interface {
-	Run(ctx context.Context, workingDir string, parameters *entity.AnsiblePlaybookParameters) error
+	Run(ctx context.Context, workingDir string, parameters *entity.AnsiblePlaybookParameters, output io.Writer) (*entity.TaskResult, error)
 }
@@ -0,0 +1,40 @@
+package executor
+
+import (
+	"github.com/apenella/ransidble/internal/domain/ports/repository"
+)
+
+// TaskOutputWriter is an io.Writer that appends the data written to it to the output of a task
+type TaskOutputWriter struct {
+	// repository is the repository where the task output is stored
+	repository repository.TaskOutputRepository
+	// taskID is the id of the task which the output belongs to
+	taskID string
+}
+
+// NewTaskOutputWriter creates a new TaskOutputWriter
+func NewTaskOutputWriter(taskID string, repository repository.TaskOutputRepository) *TaskOutputWriter {
+	return &TaskOutputWriter{
+		repository: repository,
+		taskID:     taskID,
+	}
+}
+
+// Write appends p to the task output. When there is no repository defined, the data is discarded
+func (w *TaskOutputWriter) Write(p []byte) (int, error) {
+
+	if w.repository == nil {
+		return len(p), nil
+	}
+
+	// the repository may keep a reference to the data, so it must receive a copy of p because the caller can reuse it
+	data := make([]byte, len(p))
+	copy(data, p)
+
+	err := w.repository.Append(w.taskID, data)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
@@ -0,0 +1,71 @@
+package executor
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/apenella/ransidble/internal/domain/ports/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaskOutputWriterWrite(t *testing.T) {
+	tests := []struct {
+		desc            string
+		writer          *TaskOutputWriter
+		data            []byte
+		expectedN       int
+		err             error
+		arrangeTestFunc func(w *TaskOutputWriter)
+		assertTestFunc  func(t *testing.T, w *TaskOutputWriter)
+	}{
+		{
+			desc:      "Testing writing the output of a task when the repository is not defined",
+			writer:    NewTaskOutputWriter("task-id", nil),
+			data:      []byte("output"),
+			expectedN: 6,
+		},
+		{
+			desc:      "Testing writing the output of a task into the repository",
+			writer:    NewTaskOutputWriter("task-id", repository.NewMockTaskOutputRepository()),
+			data:      []byte("output"),
+			expectedN: 6,
+			arrangeTestFunc: func(w *TaskOutputWriter) {
+				w.repository.(*repository.MockTaskOutputRepository).On("Append", "task-id", []byte("output")).Return(nil)
+			},
+			assertTestFunc: func(t *testing.T, w *TaskOutputWriter) {
+				w.repository.(*repository.MockTaskOutputRepository).AssertExpectations(t)
+			},
+		},
+		{
+			desc:   "Testing error writing the output of a task when the repository returns an error",
+			writer: NewTaskOutputWriter("task-id", repository.NewMockTaskOutputRepository()),
+			data:   []byte("output"),
+			err:    errors.New("error appending output"),
+			arrangeTestFunc: func(w *TaskOutputWriter) {
+				w.repository.(*repository.MockTaskOutputRepository).On("Append", "task-id", []byte("output")).Return(errors.New("error appending output"))
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			if test.arrangeTestFunc != nil {
+				test.arrangeTestFunc(test.writer)
+			}
+
+			n, err := test.writer.Write(test.data)
+			if err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.Equal(t, test.expectedN, n)
+			}
+
+			if test.assertTestFunc != nil {
+				test.assertTestFunc(t, test.writer)
+			}
+		})
+	}
+}
@@ -2,8 +2,10 @@ package executor
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/apenella/ransidble/internal/domain/core/entity"
 	"github.com/apenella/ransidble/internal/domain/ports/repository"
@@ -21,6 +23,10 @@ var (
 	ErrAnsiblePlaybookTaskInvalidParameters = fmt.Errorf("task has invalid parameters")
 	// ErrPreparingWorkspace represents an error when preparing the workspace
 	ErrPreparingWorkspace = fmt.Errorf("error preparing workspace")
+	// ErrVerifyingProjectIntegrity represents an error when the source code of the project does not match its digest
+	ErrVerifyingProjectIntegrity = fmt.Errorf("project integrity verification failed")
+	// ErrVerifyingProjectSignature represents an error when the source code of the project is not verified against the trust policy
+	ErrVerifyingProjectSignature = fmt.Errorf("project signature verification failed")
 	// ErrGettingWorkingDir represents an error when getting the working directory
 	ErrGettingWorkingDir = fmt.Errorf("error getting working directory")
 	// ErrAnsiblePlaybookExecutorDefined represents an error when the ansible playbook executor is not found
@@ -29,6 +35,10 @@ var (
 	ErrUnknownCommandType = fmt.Errorf("unknown command type")
 	// ErrAnsiblePlaybookTaskFailed represents an error when the ansible playbook task failed
 	ErrAnsiblePlaybookTaskFailed = fmt.Errorf("ansible playbook task failed")
+	// ErrPersistingTask represents an error when the task status can not be persisted
+	ErrPersistingTask = fmt.Errorf("error persisting task")
+	// ErrAnsiblePlaybookTaskTimeout represents an error when the ansible playbook task exceeds its execution timeout
+	ErrAnsiblePlaybookTaskTimeout = fmt.Errorf("ansible playbook task exceeded its execution timeout")
 )
 
 // Worker represents a worker to run tasks
@@ -43,6 +53,10 @@ type Worker struct {
 	onceStop sync.Once
 	// stopCh is the channel to stop the worker
 	stopCh chan struct{}
+	// taskOutputRepository is the repository where the tasks output is stored
+	taskOutputRepository repository.TaskOutputRepository
+	// taskRepository is the repository where the task status changes are persisted. It is optional
+	taskRepository repository.TaskRepository
 	// taskChan is the channel to receive tasks
 	taskChan chan *entity.Task
 	// workerPool is the pool of workers to synchronize to the dispatcher
@@ -63,6 +77,7 @@ func NewWorker(
 	workerPool chan chan *entity.Task,
 	workspaceBuilder service.WorkspaceBuilder,
 	ansiblePlaybookExecutor AnsiblePlaybookExecutor,
+	taskOutputRepository repository.TaskOutputRepository,
 	logger repository.Logger,
 ) *Worker {
 
@@ -73,12 +88,19 @@ func NewWorker(
 		id:                      id, // set random alphanumeric
 		logger:                  logger,
 		stopCh:                  make(chan struct{}),
+		taskOutputRepository:    taskOutputRepository,
 		taskChan:                make(chan *entity.Task),
 		workerPool:              workerPool,
 		workspaceBuilder:        workspaceBuilder,
 	}
 }
 
+// WithTaskRepository sets the repository where the worker persists the status changes of the tasks it runs
+func (w *Worker) WithTaskRepository(taskRepository repository.TaskRepository) *Worker {
+	w.taskRepository = taskRepository
+	return w
+}
+
 // Start starts the worker
 func (w *Worker) Start(ctx context.Context) (err error) {
 
@@ -136,9 +158,27 @@ func (w *Worker) handleTask(ctx context.Context, task *entity.Task) error {
 	var workspace service.Workspacer
 	var err error
 
+	if task.IsCancelled() {
+		w.logger.Debug(fmt.Sprintf(WorkerTaskMessagePrefix, w.id, task.ID, "Task discarded because it is cancelled"), map[string]interface{}{
+			"component": "Worker.handleTask",
+			"package":   "github.com/apenella/ransidble/internal/domain/core/service/task",
+			"task_id":   task.ID,
+			"worker_id": w.id,
+		})
+		return nil
+	}
+
+	// the task context is cancelled when the task is cancelled, which stops the commands executed by the task
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	task.SetCancelFunc(cancel)
+
 	task.Accepted()
+	w.persistTask(task)
+	// the final status of the task is persisted once the task is handled
+	defer w.persistTask(task)
 
-	workspace, err = w.createWorkspace(task)
+	workspace, err = w.createWorkspace(ctx, task)
 	if err != nil {
 		errMsg := fmt.Sprintf("%s: %s", ErrPreparingWorkspace, err.Error())
 		task.Failed(errMsg)
@@ -170,7 +210,7 @@ func (w *Worker) handleTask(ctx context.Context, task *entity.Task) error {
 
 	switch task.Command {
 	case entity.AnsiblePlaybookCommand:
-		_, ok := task.Parameters.(*entity.AnsiblePlaybookParameters)
+		parameters, ok := task.Parameters.(*entity.AnsiblePlaybookParameters)
 		if !ok {
 			errorMsg := ErrAnsiblePlaybookTaskInvalidParameters.Error()
 			task.Failed(errorMsg)
@@ -184,8 +224,43 @@ func (w *Worker) handleTask(ctx context.Context, task *entity.Task) error {
 			return fmt.Errorf("%s", errorMsg)
 		}
 
+		runCtx := ctx
+		if parameters.ExecutionTimeout > 0 {
+			var cancelTimeout context.CancelFunc
+			runCtx, cancelTimeout = context.WithTimeout(ctx, time.Duration(parameters.ExecutionTimeout)*time.Second)
+			defer cancelTimeout()
+		}
+
 		task.Running()
-		err = w.handleAnsiblePlaybookTask(ctx, task, workingDir)
+		w.persistTask(task)
+		err = w.handleAnsiblePlaybookTask(runCtx, task, workingDir)
+		if task.IsCancelled() {
+			w.logger.Info(fmt.Sprintf(WorkerTaskMessagePrefix, w.id, task.ID, "Task cancelled"), map[string]interface{}{
+				"component": "Worker.handleTask",
+				"package":   "github.com/apenella/ransidble/internal/infrastructure/executor",
+				"task_id":   task.ID,
+				"worker_id": w.id,
+			})
+
+			return nil
+		}
+
+		if errors.Is(runCtx.Err(), context.DeadlineExceeded) {
+			errorMsg := fmt.Sprintf("%s of %ds", ErrAnsiblePlaybookTaskTimeout, parameters.ExecutionTimeout)
+			if err != nil {
+				errorMsg = fmt.Sprintf("%s: %s", errorMsg, err.Error())
+			}
+			task.TimedOut(errorMsg)
+			w.logger.Error(errorMsg, map[string]interface{}{
+				"component": "Worker.handleTask",
+				"package":   "github.com/apenella/ransidble/internal/infrastructure/executor",
+				"task_id":   task.ID,
+				"worker_id": w.id,
+			})
+
+			return fmt.Errorf("%s", errorMsg)
+		}
+
 		if err != nil {
 			errorMsg := fmt.Sprintf("%s: %s", ErrAnsiblePlaybookTaskFailed, err.Error())
 			task.Failed(errorMsg)
@@ -224,15 +299,40 @@ func (w *Worker) handleTask(ctx context.Context, task *entity.Task) error {
 	return nil
 }
 
-// createWorkspace creates a workspace for a task
-func (w *Worker) createWorkspace(task *entity.Task) (service.Workspacer, error) {
+// persistTask persists the task status when the worker has a task repository. A task that can not be persisted keeps running
+func (w *Worker) persistTask(task *entity.Task) {
+	if w.taskRepository == nil {
+		return
+	}
+
+	err := w.taskRepository.Update(task.ID, task)
+	if err != nil {
+		w.logger.Error(fmt.Sprintf(WorkerTaskMessagePrefix, w.id, task.ID, fmt.Sprintf("%s: %s", ErrPersistingTask, err.Error())), map[string]interface{}{
+			"component": "Worker.persistTask",
+			"package":   "github.com/apenella/ransidble/internal/infrastructure/executor",
+			"task_id":   task.ID,
+			"worker_id": w.id,
+		})
+	}
+}
+
+// createWorkspace creates a workspace for a task. The workspace is prepared using the task context, so preparing it is stopped when the task is cancelled
+func (w *Worker) createWorkspace(ctx context.Context, task *entity.Task) (service.Workspacer, error) {
 
 	// the wsp and err are defined in the return statement to be able to handle the error in the defer function
 	wsp := w.workspaceBuilder.WithTask(task).Build()
 
-	err := wsp.Prepare()
+	err := wsp.Prepare(ctx)
 	if err != nil {
 		errMssg := fmt.Sprintf("%s: %s", ErrPreparingWorkspace, err.Error())
+		// the integrity errors are reported on their own, since the stored source code has been corrupted or tampered with
+		if errors.Is(err, entity.ErrProjectDigestMismatch) {
+			errMssg = fmt.Sprintf("%s: %s", ErrVerifyingProjectIntegrity, err.Error())
+		}
+		// the projects refused by the trust policy are reported on their own, since they are not allowed to be executed
+		if errors.Is(err, entity.ErrProjectSignatureRequired) || errors.Is(err, entity.ErrProjectSignatureNotTrusted) || errors.Is(err, entity.ErrInvalidProjectSignature) {
+			errMssg = fmt.Sprintf("%s: %s", ErrVerifyingProjectSignature, err.Error())
+		}
 		w.logger.Error(errMssg, map[string]interface{}{
 			"component": "Worker.createWorkspace",
 			"package":   "github.com/apenella/ransidble/internal/infrastructure/executor",
@@ -268,8 +368,15 @@ func (w *Worker) handleAnsiblePlaybookTask(ctx context.Context, task *entity.Tas
 		"worker_id": w.id,
 	})
 
+	output := NewTaskOutputWriter(task.ID, w.taskOutputRepository)
+
 	// ansibleplaybook := executor.NewAnsiblePlaybook()
-	errRunAnsiblePlaybook := w.ansiblePlaybookExecutor.Run(ctx, workingDir, task.Parameters.(*entity.AnsiblePlaybookParameters))
+	result, errRunAnsiblePlaybook := w.ansiblePlaybookExecutor.Run(ctx, workingDir, task.Parameters.(*entity.AnsiblePlaybookParameters), output)
+	// the result is set even when the execution fails, since it describes which hosts and tasks failed
+	if result != nil {
+		task.SetResult(result)
+	}
+
 	if errRunAnsiblePlaybook != nil {
 		errorMsg := errRunAnsiblePlaybook.Error()
 		w.logger.Error(errorMsg, map[string]interface{}{
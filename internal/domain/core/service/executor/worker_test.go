@@ -13,6 +13,7 @@ import (
 	"github.com/apenella/ransidble/internal/infrastructure/logger"
 	"github.com/go-errors/errors"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestWorkerGenerateID(t *testing.T) {
@@ -60,6 +61,7 @@ func TestCreateWorkspace(t *testing.T) {
 					logger.NewFakeLogger(),
 				),
 				nil,
+				nil,
 			),
 			task: &entity.Task{
 				ID:         "task-id",
@@ -80,7 +82,7 @@ func TestCreateWorkspace(t *testing.T) {
 					return fmt.Errorf("Workspace builder must have expectations")
 				}
 
-				w.workspaceBuilder.(*repository.MockBuilder).Workspace.On("Prepare").Return(nil)
+				w.workspaceBuilder.(*repository.MockBuilder).Workspace.On("Prepare", mock.Anything).Return(nil)
 
 				return nil
 			},
@@ -96,6 +98,7 @@ func TestCreateWorkspace(t *testing.T) {
 				executor.NewAnsiblePlaybook(
 					logger.NewFakeLogger(),
 				),
+				nil,
 				logger.NewFakeLogger(),
 			),
 			task: &entity.Task{
@@ -117,12 +120,88 @@ func TestCreateWorkspace(t *testing.T) {
 					return fmt.Errorf("Workspace builder must have expectations")
 				}
 
-				w.workspaceBuilder.(*repository.MockBuilder).Workspace.On("Prepare").Return(fmt.Errorf("Error preparing workspace"))
+				w.workspaceBuilder.(*repository.MockBuilder).Workspace.On("Prepare", mock.Anything).Return(fmt.Errorf("Error preparing workspace"))
 
 				return nil
 			},
 			err: fmt.Errorf("%s: %s", ErrPreparingWorkspace, "Error preparing workspace"),
 		},
+		{
+			desc: "Testing error creating a workspace for a task whose project does not match its digest",
+			worker: NewWorker(
+				make(chan chan *entity.Task),
+				&repository.MockBuilder{
+					Workspace: &repository.MockWorkspace{},
+				},
+				executor.NewAnsiblePlaybook(
+					logger.NewFakeLogger(),
+				),
+				nil,
+				logger.NewFakeLogger(),
+			),
+			task: &entity.Task{
+				ID:         "task-id",
+				Status:     "ACCEPTED",
+				Parameters: map[string]interface{}{},
+				Command:    "ansible-playbook",
+				ProjectID:  "project-id",
+			},
+			arrange: func(t *testing.T, w *Worker) error {
+				// The arrange function is used to mock the workspace builder to return an integrity error when preparing the workspace
+
+				if w.workspaceBuilder == nil {
+					return fmt.Errorf("Workspace builder must not be nil")
+				}
+
+				_, ok := w.workspaceBuilder.(*repository.MockBuilder)
+				if !ok {
+					return fmt.Errorf("Workspace builder must have expectations")
+				}
+
+				w.workspaceBuilder.(*repository.MockBuilder).Workspace.On("Prepare", mock.Anything).Return(fmt.Errorf("error fetching project: %w", entity.ErrProjectDigestMismatch))
+
+				return nil
+			},
+			err: fmt.Errorf("%s: %s", ErrVerifyingProjectIntegrity, "error fetching project: project digest mismatch"),
+		},
+		{
+			desc: "Testing error creating a workspace for a task whose project signature is not trusted",
+			worker: NewWorker(
+				make(chan chan *entity.Task),
+				&repository.MockBuilder{
+					Workspace: &repository.MockWorkspace{},
+				},
+				executor.NewAnsiblePlaybook(
+					logger.NewFakeLogger(),
+				),
+				nil,
+				logger.NewFakeLogger(),
+			),
+			task: &entity.Task{
+				ID:         "task-id",
+				Status:     "ACCEPTED",
+				Parameters: map[string]interface{}{},
+				Command:    "ansible-playbook",
+				ProjectID:  "project-id",
+			},
+			arrange: func(t *testing.T, w *Worker) error {
+				// The arrange function is used to mock the workspace builder to return a signature error when preparing the workspace
+
+				if w.workspaceBuilder == nil {
+					return fmt.Errorf("Workspace builder must not be nil")
+				}
+
+				_, ok := w.workspaceBuilder.(*repository.MockBuilder)
+				if !ok {
+					return fmt.Errorf("Workspace builder must have expectations")
+				}
+
+				w.workspaceBuilder.(*repository.MockBuilder).Workspace.On("Prepare", mock.Anything).Return(fmt.Errorf("error verifying project signature: %w", entity.ErrProjectSignatureNotTrusted))
+
+				return nil
+			},
+			err: fmt.Errorf("%s: %s", ErrVerifyingProjectSignature, "error verifying project signature: project signature not trusted"),
+		},
 	}
 
 	for _, test := range tests {
@@ -137,7 +216,7 @@ func TestCreateWorkspace(t *testing.T) {
 				}
 			}
 
-			wsp, err := test.worker.createWorkspace(test.task)
+			wsp, err := test.worker.createWorkspace(context.Background(), test.task)
 			if err != nil {
 				assert.Equal(t, test.err, err, "Error must be the expected")
 			} else {
@@ -155,6 +234,7 @@ func TestHandleAnsiblePlaybookTask(t *testing.T) {
 		worker     *Worker
 		task       *entity.Task
 		workingDir string
+		result     *entity.TaskResult
 		err        error
 		arrange    func(*testing.T, *Worker) error
 	}{
@@ -166,6 +246,7 @@ func TestHandleAnsiblePlaybookTask(t *testing.T) {
 					Workspace: &repository.MockWorkspace{},
 				},
 				NewMockAnsiblePlaybookExecutor(),
+				nil,
 				logger.NewFakeLogger(),
 			),
 			task: &entity.Task{
@@ -188,7 +269,7 @@ func TestHandleAnsiblePlaybookTask(t *testing.T) {
 					return fmt.Errorf("Ansible playbook executor must have expectations")
 				}
 
-				w.ansiblePlaybookExecutor.(*MockAnsiblePlaybookExecutor).On("Run", context.TODO(), "/tmp", &entity.AnsiblePlaybookParameters{}).Return(nil)
+				w.ansiblePlaybookExecutor.(*MockAnsiblePlaybookExecutor).On("Run", context.TODO(), "/tmp", &entity.AnsiblePlaybookParameters{}, NewTaskOutputWriter("task-id", nil)).Return(nil, nil)
 
 				return nil
 			},
@@ -201,6 +282,7 @@ func TestHandleAnsiblePlaybookTask(t *testing.T) {
 					Workspace: &repository.MockWorkspace{},
 				},
 				nil,
+				nil,
 				logger.NewFakeLogger(),
 			),
 			task: &entity.Task{
@@ -221,6 +303,7 @@ func TestHandleAnsiblePlaybookTask(t *testing.T) {
 					Workspace: &repository.MockWorkspace{},
 				},
 				NewMockAnsiblePlaybookExecutor(),
+				nil,
 				logger.NewFakeLogger(),
 			),
 			task: &entity.Task{
@@ -243,12 +326,61 @@ func TestHandleAnsiblePlaybookTask(t *testing.T) {
 					return fmt.Errorf("Ansible playbook executor must have expectations")
 				}
 
-				w.ansiblePlaybookExecutor.(*MockAnsiblePlaybookExecutor).On("Run", context.TODO(), "/tmp", &entity.AnsiblePlaybookParameters{}).Return(fmt.Errorf("error running ansible playbook"))
+				w.ansiblePlaybookExecutor.(*MockAnsiblePlaybookExecutor).On("Run", context.TODO(), "/tmp", &entity.AnsiblePlaybookParameters{}, NewTaskOutputWriter("task-id", nil)).Return(nil, fmt.Errorf("error running ansible playbook"))
 
 				return nil
 			},
 			err: fmt.Errorf("error running ansible playbook"),
 		},
+		{
+			desc: "Testing error handling an ansible-playbook task setting the structured result when ansible playbook executor returns an error",
+			worker: NewWorker(
+				make(chan chan *entity.Task),
+				&repository.MockBuilder{
+					Workspace: &repository.MockWorkspace{},
+				},
+				NewMockAnsiblePlaybookExecutor(),
+				nil,
+				logger.NewFakeLogger(),
+			),
+			task: &entity.Task{
+				ID:         "task-id",
+				Status:     "ACCEPTED",
+				Parameters: &entity.AnsiblePlaybookParameters{StructuredResult: true},
+				Command:    "ansible-playbook",
+				ProjectID:  "project-id",
+			},
+			workingDir: "/tmp",
+			arrange: func(t *testing.T, w *Worker) error {
+				// The arrange function is used to mock the ansible playbook executor to return the structured result along with an error
+
+				if w.ansiblePlaybookExecutor == nil {
+					return fmt.Errorf("Ansible playbook executor must not be nil")
+				}
+
+				_, ok := w.ansiblePlaybookExecutor.(*MockAnsiblePlaybookExecutor)
+				if !ok {
+					return fmt.Errorf("Ansible playbook executor must have expectations")
+				}
+
+				w.ansiblePlaybookExecutor.(*MockAnsiblePlaybookExecutor).On("Run", context.TODO(), "/tmp", &entity.AnsiblePlaybookParameters{StructuredResult: true}, NewTaskOutputWriter("task-id", nil)).Return(
+					&entity.TaskResult{
+						Hosts: map[string]*entity.TaskResultHostRecap{
+							"127.0.0.1": {Failed: 1},
+						},
+					},
+					fmt.Errorf("error running ansible playbook"),
+				)
+
+				return nil
+			},
+			result: &entity.TaskResult{
+				Hosts: map[string]*entity.TaskResultHostRecap{
+					"127.0.0.1": {Failed: 1},
+				},
+			},
+			err: fmt.Errorf("error running ansible playbook"),
+		},
 	}
 
 	for _, test := range tests {
@@ -269,6 +401,7 @@ func TestHandleAnsiblePlaybookTask(t *testing.T) {
 			} else {
 				test.worker.workspaceBuilder.(*repository.MockBuilder).Workspace.AssertExpectations(t)
 			}
+			assert.Equal(t, test.result, test.task.Result, "Result must be the expected")
 		})
 	}
 }
@@ -291,6 +424,7 @@ func TestHandleTask(t *testing.T) {
 					Workspace: &repository.MockWorkspace{},
 				},
 				NewMockAnsiblePlaybookExecutor(),
+				nil,
 				logger.NewFakeLogger(),
 			),
 			task: &entity.Task{
@@ -312,7 +446,7 @@ func TestHandleTask(t *testing.T) {
 					return fmt.Errorf("Workspace builder must have expectations")
 				}
 
-				w.workspaceBuilder.(*repository.MockBuilder).Workspace.On("Prepare").Return(fmt.Errorf("error preparing workspace"))
+				w.workspaceBuilder.(*repository.MockBuilder).Workspace.On("Prepare", mock.Anything).Return(fmt.Errorf("error preparing workspace"))
 
 				return nil
 			},
@@ -333,6 +467,7 @@ func TestHandleTask(t *testing.T) {
 					Workspace: &repository.MockWorkspace{},
 				},
 				NewMockAnsiblePlaybookExecutor(),
+				nil,
 				logger.NewFakeLogger(),
 			),
 			task: &entity.Task{
@@ -354,7 +489,7 @@ func TestHandleTask(t *testing.T) {
 					return fmt.Errorf("Workspace builder must have expectations")
 				}
 
-				w.workspaceBuilder.(*repository.MockBuilder).Workspace.On("Prepare").Return(nil)
+				w.workspaceBuilder.(*repository.MockBuilder).Workspace.On("Prepare", mock.Anything).Return(nil)
 
 				w.workspaceBuilder.(*repository.MockBuilder).Workspace.On("GetWorkingDir").Return("", fmt.Errorf("error getting working directory"))
 
@@ -379,6 +514,7 @@ func TestHandleTask(t *testing.T) {
 					Workspace: &repository.MockWorkspace{},
 				},
 				NewMockAnsiblePlaybookExecutor(),
+				nil,
 				logger.NewFakeLogger(),
 			),
 			task: &entity.Task{
@@ -400,7 +536,7 @@ func TestHandleTask(t *testing.T) {
 					return fmt.Errorf("Workspace builder must have expectations")
 				}
 
-				w.workspaceBuilder.(*repository.MockBuilder).Workspace.On("Prepare").Return(nil)
+				w.workspaceBuilder.(*repository.MockBuilder).Workspace.On("Prepare", mock.Anything).Return(nil)
 
 				w.workspaceBuilder.(*repository.MockBuilder).Workspace.On("GetWorkingDir").Return("/tmp", nil)
 
@@ -425,6 +561,7 @@ func TestHandleTask(t *testing.T) {
 					Workspace: &repository.MockWorkspace{},
 				},
 				NewMockAnsiblePlaybookExecutor(),
+				nil,
 				logger.NewFakeLogger(),
 			),
 			task: &entity.Task{
@@ -446,7 +583,7 @@ func TestHandleTask(t *testing.T) {
 					return fmt.Errorf("Workspace builder must have expectations")
 				}
 
-				w.workspaceBuilder.(*repository.MockBuilder).Workspace.On("Prepare").Return(nil)
+				w.workspaceBuilder.(*repository.MockBuilder).Workspace.On("Prepare", mock.Anything).Return(nil)
 
 				w.workspaceBuilder.(*repository.MockBuilder).Workspace.On("GetWorkingDir").Return("/tmp", nil)
 
@@ -471,6 +608,7 @@ func TestHandleTask(t *testing.T) {
 					Workspace: &repository.MockWorkspace{},
 				},
 				NewMockAnsiblePlaybookExecutor(),
+				nil,
 				logger.NewFakeLogger(),
 			),
 			task: &entity.Task{
@@ -492,7 +630,7 @@ func TestHandleTask(t *testing.T) {
 					return fmt.Errorf("Workspace builder must have expectations")
 				}
 
-				w.workspaceBuilder.(*repository.MockBuilder).Workspace.On("Prepare").Return(nil)
+				w.workspaceBuilder.(*repository.MockBuilder).Workspace.On("Prepare", mock.Anything).Return(nil)
 
 				w.workspaceBuilder.(*repository.MockBuilder).Workspace.On("GetWorkingDir").Return("/tmp", nil)
 
@@ -507,7 +645,7 @@ func TestHandleTask(t *testing.T) {
 					return fmt.Errorf("Ansible playbook executor must have expectations")
 				}
 
-				w.ansiblePlaybookExecutor.(*MockAnsiblePlaybookExecutor).On("Run", context.TODO(), "/tmp", &entity.AnsiblePlaybookParameters{}).Return(fmt.Errorf("error running ansible playbook"))
+				w.ansiblePlaybookExecutor.(*MockAnsiblePlaybookExecutor).On("Run", mock.Anything, "/tmp", &entity.AnsiblePlaybookParameters{}, NewTaskOutputWriter("task-id", nil)).Return(nil, fmt.Errorf("error running ansible playbook"))
 
 				return nil
 			},
@@ -528,6 +666,7 @@ func TestHandleTask(t *testing.T) {
 					Workspace: &repository.MockWorkspace{},
 				},
 				NewMockAnsiblePlaybookExecutor(),
+				nil,
 				logger.NewFakeLogger(),
 			),
 			task: &entity.Task{
@@ -549,7 +688,7 @@ func TestHandleTask(t *testing.T) {
 					return fmt.Errorf("Workspace builder must have expectations")
 				}
 
-				w.workspaceBuilder.(*repository.MockBuilder).Workspace.On("Prepare").Return(nil)
+				w.workspaceBuilder.(*repository.MockBuilder).Workspace.On("Prepare", mock.Anything).Return(nil)
 
 				w.workspaceBuilder.(*repository.MockBuilder).Workspace.On("GetWorkingDir").Return("/tmp", nil)
 
@@ -564,7 +703,7 @@ func TestHandleTask(t *testing.T) {
 					return fmt.Errorf("Ansible playbook executor must have expectations")
 				}
 
-				w.ansiblePlaybookExecutor.(*MockAnsiblePlaybookExecutor).On("Run", context.TODO(), "/tmp", &entity.AnsiblePlaybookParameters{}).Return(nil)
+				w.ansiblePlaybookExecutor.(*MockAnsiblePlaybookExecutor).On("Run", mock.Anything, "/tmp", &entity.AnsiblePlaybookParameters{}, NewTaskOutputWriter("task-id", nil)).Return(nil, nil)
 
 				return nil
 			},
@@ -616,6 +755,7 @@ func TestWorkerStop(t *testing.T) {
 					Workspace: &repository.MockWorkspace{},
 				},
 				NewMockAnsiblePlaybookExecutor(),
+				nil,
 				logger.NewFakeLogger(),
 			),
 		},
@@ -662,6 +802,7 @@ func TestWorkerStart(t *testing.T) {
 					Workspace: &repository.MockWorkspace{},
 				},
 				NewMockAnsiblePlaybookExecutor(),
+				nil,
 				logger.NewFakeLogger(),
 			),
 			arrangeFunc: func(t *testing.T, w *Worker) error {
@@ -675,7 +816,7 @@ func TestWorkerStart(t *testing.T) {
 					return fmt.Errorf("Workspace builder must have expectations")
 				}
 
-				w.workspaceBuilder.(*repository.MockBuilder).Workspace.On("Prepare").Return(nil)
+				w.workspaceBuilder.(*repository.MockBuilder).Workspace.On("Prepare", mock.Anything).Return(nil)
 
 				w.workspaceBuilder.(*repository.MockBuilder).Workspace.On("GetWorkingDir").Return("/tmp", nil)
 
@@ -690,7 +831,7 @@ func TestWorkerStart(t *testing.T) {
 					return fmt.Errorf("Ansible playbook executor must have expectations")
 				}
 
-				w.ansiblePlaybookExecutor.(*MockAnsiblePlaybookExecutor).On("Run", context.TODO(), "/tmp", &entity.AnsiblePlaybookParameters{}).Return(nil)
+				w.ansiblePlaybookExecutor.(*MockAnsiblePlaybookExecutor).On("Run", mock.Anything, "/tmp", &entity.AnsiblePlaybookParameters{}, NewTaskOutputWriter("task-id", nil)).Return(nil, nil)
 
 				return nil
 			},
@@ -771,3 +912,148 @@ func TestWorkerStart(t *testing.T) {
 		})
 	}
 }
+
+func TestHandleTaskCancelled(t *testing.T) {
+
+	t.Run("Testing handling a task that is cancelled before reaching the worker", func(t *testing.T) {
+		t.Parallel()
+		t.Log("Testing handling a task that is cancelled before reaching the worker")
+
+		workspace := &repository.MockWorkspace{}
+		ansiblePlaybookExecutor := NewMockAnsiblePlaybookExecutor()
+		worker := NewWorker(
+			make(chan chan *entity.Task),
+			&repository.MockBuilder{
+				Workspace: workspace,
+			},
+			ansiblePlaybookExecutor,
+			nil,
+			logger.NewFakeLogger(),
+		)
+
+		task := entity.NewTask("task-id", "project-id", entity.AnsiblePlaybookCommand, &entity.AnsiblePlaybookParameters{})
+		task.Cancelled()
+
+		err := worker.handleTask(context.TODO(), task)
+		assert.NoError(t, err)
+		assert.Equal(t, entity.CANCELLED, task.Status)
+		// neither the workspace nor the ansible playbook executor are used
+		workspace.AssertExpectations(t)
+		ansiblePlaybookExecutor.AssertExpectations(t)
+	})
+
+	t.Run("Testing handling a task that is cancelled while running", func(t *testing.T) {
+		t.Parallel()
+		t.Log("Testing handling a task that is cancelled while running")
+
+		task := entity.NewTask("task-id", "project-id", entity.AnsiblePlaybookCommand, &entity.AnsiblePlaybookParameters{})
+
+		workspace := &repository.MockWorkspace{}
+		workspace.On("Prepare", mock.Anything).Return(nil)
+		workspace.On("GetWorkingDir").Return("/tmp", nil)
+		workspace.On("Cleanup").Return(nil)
+
+		var runCtx context.Context
+		ansiblePlaybookExecutor := NewMockAnsiblePlaybookExecutor()
+		ansiblePlaybookExecutor.On("Run", mock.Anything, "/tmp", &entity.AnsiblePlaybookParameters{}, NewTaskOutputWriter("task-id", nil)).Run(func(args mock.Arguments) {
+			runCtx = args.Get(0).(context.Context)
+			task.Cancelled()
+		}).Return(nil, fmt.Errorf("error running ansible playbook"))
+
+		worker := NewWorker(
+			make(chan chan *entity.Task),
+			&repository.MockBuilder{
+				Workspace: workspace,
+			},
+			ansiblePlaybookExecutor,
+			nil,
+			logger.NewFakeLogger(),
+		)
+
+		err := worker.handleTask(context.TODO(), task)
+		assert.NoError(t, err)
+		assert.Equal(t, entity.CANCELLED, task.Status)
+		assert.Empty(t, task.ErrorMessage)
+		assert.ErrorIs(t, runCtx.Err(), context.Canceled)
+		// the workspace is cleaned up even when the task is cancelled
+		workspace.AssertExpectations(t)
+		ansiblePlaybookExecutor.AssertExpectations(t)
+	})
+}
+
+func TestHandleTaskTimeout(t *testing.T) {
+	t.Parallel()
+	t.Log("Testing handling a task that exceeds its execution timeout")
+
+	parameters := &entity.AnsiblePlaybookParameters{
+		ExecutionTimeout: 1,
+	}
+	task := entity.NewTask("task-id", "project-id", entity.AnsiblePlaybookCommand, parameters)
+
+	workspace := &repository.MockWorkspace{}
+	workspace.On("Prepare", mock.Anything).Return(nil)
+	workspace.On("GetWorkingDir").Return("/tmp", nil)
+	workspace.On("Cleanup").Return(nil)
+
+	ansiblePlaybookExecutor := NewMockAnsiblePlaybookExecutor()
+	ansiblePlaybookExecutor.On("Run", mock.Anything, "/tmp", parameters, NewTaskOutputWriter("task-id", nil)).Run(func(args mock.Arguments) {
+		ctx := args.Get(0).(context.Context)
+		<-ctx.Done()
+	}).Return(nil, context.DeadlineExceeded)
+
+	worker := NewWorker(
+		make(chan chan *entity.Task),
+		&repository.MockBuilder{
+			Workspace: workspace,
+		},
+		ansiblePlaybookExecutor,
+		nil,
+		logger.NewFakeLogger(),
+	)
+
+	err := worker.handleTask(context.TODO(), task)
+	assert.Error(t, err)
+	assert.Equal(t, entity.TIMEOUT, task.Status)
+	assert.Contains(t, task.ErrorMessage, ErrAnsiblePlaybookTaskTimeout.Error())
+	assert.Contains(t, task.ErrorMessage, context.DeadlineExceeded.Error())
+	assert.NotEmpty(t, task.CompletedAt)
+	workspace.AssertExpectations(t)
+	ansiblePlaybookExecutor.AssertExpectations(t)
+}
+
+func TestHandleTaskPersistsTaskStatus(t *testing.T) {
+	t.Parallel()
+	t.Log("Testing handling a task persisting its status changes in the task repository")
+
+	parameters := &entity.AnsiblePlaybookParameters{}
+	task := entity.NewTask("task-id", "project-id", entity.AnsiblePlaybookCommand, parameters)
+
+	workspace := &repository.MockWorkspace{}
+	workspace.On("Prepare", mock.Anything).Return(nil)
+	workspace.On("GetWorkingDir").Return("/tmp", nil)
+	workspace.On("Cleanup").Return(nil)
+
+	ansiblePlaybookExecutor := NewMockAnsiblePlaybookExecutor()
+	ansiblePlaybookExecutor.On("Run", mock.Anything, "/tmp", parameters, NewTaskOutputWriter("task-id", nil)).Return(nil, nil)
+
+	persistedStatus := []string{}
+	taskRepository := repository.NewMockTaskRepository()
+	taskRepository.On("Update", "task-id", task).Run(func(args mock.Arguments) {
+		persistedStatus = append(persistedStatus, args.Get(1).(*entity.Task).GetStatus())
+	}).Return(nil)
+
+	worker := NewWorker(
+		make(chan chan *entity.Task),
+		&repository.MockBuilder{
+			Workspace: workspace,
+		},
+		ansiblePlaybookExecutor,
+		nil,
+		logger.NewFakeLogger(),
+	).WithTaskRepository(taskRepository)
+
+	err := worker.handleTask(context.TODO(), task)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{entity.ACCEPTED, entity.RUNNING, entity.SUCCESS}, persistedStatus)
+	taskRepository.AssertExpectations(t)
+}
@@ -1,6 +1,8 @@
 package project
 
 import (
+	"bufio"
+	"errors"
 	"fmt"
 	"io"
 
@@ -8,13 +10,19 @@ import (
 	domainerror "github.com/apenella/ransidble/internal/domain/core/error"
 	"github.com/apenella/ransidble/internal/domain/ports/repository"
 	"github.com/apenella/ransidble/internal/domain/ports/service"
+	"github.com/google/uuid"
 )
 
 // CreateProjectService represents the service to create a project
 type CreateProjectService struct {
-	repository repository.ProjectRepository
-	storage    repository.SourceCodeStorageFactory
-	logger     repository.Logger
+	repository       repository.ProjectRepository
+	storage          repository.SourceCodeStorageFactory
+	ociResolver      repository.SourceCodeOCIResolver
+	archiveInspector repository.SourceCodeArchiveInspector
+	archiveLimits    *entity.ProjectArchiveLimits
+	discoverer       repository.SourceCodeDiscoverer
+	uploads          service.ProjectUploadServicer
+	logger           repository.Logger
 }
 
 // Ensure CreateProjectService implements the CreateProjectServicer interface
@@ -29,140 +37,284 @@ func NewCreateProjectService(repository repository.ProjectRepository, storage re
 	}
 }
 
-// Create creates a project and returns an error if something goes wrong
-// func (s *CreateProjectService) Create(format string, storage string, file *multipart.FileHeader) error {
-func (s *CreateProjectService) Create(format string, storage string, projectID string, projectVersion string, projectContentReader io.Reader) error {
+// WithOCIResolver sets the component that resolves the manifest digest of the OCI artifacts, which is required to create projects in oci format
+func (s *CreateProjectService) WithOCIResolver(resolver repository.SourceCodeOCIResolver) *CreateProjectService {
+	s.ociResolver = resolver
+	return s
+}
+
+// WithArchiveLimits sets the limits applied to the uploaded source code of the projects
+func (s *CreateProjectService) WithArchiveLimits(limits *entity.ProjectArchiveLimits) *CreateProjectService {
+	s.archiveLimits = limits
+	return s
+}
+
+// WithArchiveInspector sets the component that checks the uploaded source code against the archive limits, which is required when any archive limit is set
+func (s *CreateProjectService) WithArchiveInspector(inspector repository.SourceCodeArchiveInspector) *CreateProjectService {
+	s.archiveInspector = inspector
+	return s
+}
+
+// WithDiscoverer sets the component that discovers the Ansible content of the source code when a project is created. The content is not discovered when it is not set
+func (s *CreateProjectService) WithDiscoverer(discoverer repository.SourceCodeDiscoverer) *CreateProjectService {
+	s.discoverer = discoverer
+	return s
+}
+
+// WithUploadService sets the service managing the uploads where the source code is received in chunks, which is required to create projects from an upload
+func (s *CreateProjectService) WithUploadService(uploads service.ProjectUploadServicer) *CreateProjectService {
+	s.uploads = uploads
+	return s
+}
+
+// Create creates a project, creates a new version of an existing project, or replaces the source code of the most recent version of an existing project, depending on the mode of the request, and returns the revision of the stored project. When the expected digest is provided, the digest of the source code must match it. The signature is stored along with the project, and verified before the project is executed. The replaced source code is removed once the project points to the new source code, so the tasks that already fetched the project keep running with the source code they fetched
+func (s *CreateProjectService) Create(request *entity.CreateProjectRequest, projectContentReader io.Reader) (string, error) {
+	project, err := s.create("CreateProjectService.Create", request, projectContentReader)
+	if err != nil {
+		return "", err
+	}
+
+	return project.Revision, nil
+}
+
+// create stores the project source code and the project, either as a new project, as a new version of an existing project, or replacing the most recent version of an existing project. It returns the stored project
+func (s *CreateProjectService) create(component string, request *entity.CreateProjectRequest, projectContentReader io.Reader) (*entity.Project, error) {
+	var current *entity.Project
 	var err error
 	var extension string
+	var reference string
+
+	err = s.validateRequest(component, request)
+	if err != nil {
+		return nil, err
+	}
+
+	expectedDigest := request.Digest
+	format := request.Format
+	mode := request.Mode
+	projectID := request.ProjectID
+	projectVersion := request.Version
+	revision := request.Revision
+	signature := request.Signature
+	storage := request.Storage
 
 	if format == "" {
 		s.logger.Error(ErrProjectFormatNotProvided, map[string]interface{}{
-			"component": "CreateProjectService.Create",
+			"component": component,
 			"package":   "github.com/apenella/ransidble/internal/domain/core/service/project",
 		})
-		return fmt.Errorf(ErrProjectFormatNotProvided)
+		return nil, fmt.Errorf(ErrProjectFormatNotProvided)
 	}
 
 	if storage == "" {
 		s.logger.Error(ErrProjectStorageNotProvided, map[string]interface{}{
-			"component": "CreateProjectService.Create",
+			"component": component,
 			"package":   "github.com/apenella/ransidble/internal/domain/core/service/project",
 		})
-		return fmt.Errorf(ErrProjectStorageNotProvided)
+		return nil, fmt.Errorf(ErrProjectStorageNotProvided)
 	}
 
 	if projectContentReader == nil {
 		s.logger.Error(ErrProjectContentReaderNotProvided, map[string]interface{}{
-			"component": "CreateProjectService.Create",
+			"component": component,
 			"package":   "github.com/apenella/ransidble/internal/domain/core/service/project",
 		})
-		return fmt.Errorf(ErrProjectContentReaderNotProvided)
+		return nil, fmt.Errorf(ErrProjectContentReaderNotProvided)
 	}
 
 	if projectID == "" {
 		s.logger.Error(fmt.Sprintf(ErrProjectIDNotProvided), map[string]interface{}{
-			"component": "CreateProjectService.Create",
+			"component": component,
 			"package":   "github.com/apenella/ransidble/internal/domain/core/service/project",
 		})
-		return domainerror.NewProjectIDNotProvidedError(
+		return nil, domainerror.NewProjectIDNotProvidedError(
 			fmt.Errorf(ErrProjectIDNotProvided),
 		)
 	}
 
+	err = s.validateVersion(component, projectID, projectVersion, mode)
+	if err != nil {
+		return nil, err
+	}
+
+	if expectedDigest != "" {
+		err = entity.ValidateProjectDigest(expectedDigest)
+		if err != nil {
+			s.logger.Error(fmt.Sprintf("%s: %s", ErrInvalidProjectDigest, err.Error()), map[string]interface{}{
+				"component":       component,
+				"package":         "github.com/apenella/ransidble/internal/domain/core/service/project",
+				"project_id":      projectID,
+				"project_version": projectVersion,
+			})
+			return nil, fmt.Errorf("%s: %s", ErrInvalidProjectDigest, err.Error())
+		}
+	}
+
+	if signature != "" {
+		err = entity.ValidateProjectSignature(signature)
+		if err != nil {
+			s.logger.Error(fmt.Sprintf("%s: %s", ErrInvalidProjectSignature, err.Error()), map[string]interface{}{
+				"component":       component,
+				"package":         "github.com/apenella/ransidble/internal/domain/core/service/project",
+				"project_id":      projectID,
+				"project_version": projectVersion,
+			})
+			return nil, fmt.Errorf("%s: %s", ErrInvalidProjectSignature, err.Error())
+		}
+	}
+
+	// the format of the uploaded source code is detected from its content when it is not explicitly provided
+	if format == entity.ProjectFormatAuto {
+		format, projectContentReader, err = s.detectFormat(component, projectID, projectVersion, projectContentReader)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if s.storage == nil {
 		s.logger.Error(ErrStorageHandlerNotInitialized, map[string]interface{}{
-			"component":       "CreateProjectService.Create",
+			"component":       component,
 			"package":         "github.com/apenella/ransidble/internal/domain/core/service/project",
 			"project_id":      projectID,
 			"project_version": projectVersion,
 		})
-		return fmt.Errorf(ErrStorageHandlerNotInitialized)
+		return nil, fmt.Errorf(ErrStorageHandlerNotInitialized)
 	}
 
 	if s.repository == nil {
 		s.logger.Error(ErrProjectRepositoryNotInitialized, map[string]interface{}{
-			"component":       "CreateProjectService.Create",
+			"component":       component,
 			"package":         "github.com/apenella/ransidble/internal/domain/core/service/project",
 			"project_id":      projectID,
 			"project_version": projectVersion,
 		})
-		return fmt.Errorf(ErrProjectRepositoryNotInitialized)
+		return nil, fmt.Errorf(ErrProjectRepositoryNotInitialized)
 	}
 
 	err = entity.ValidateProjectFormat(format)
 	if err != nil {
 		s.logger.Error(fmt.Sprintf("%s: %s", ErrProjectFormatNotSupported, err.Error()), map[string]interface{}{
-			"component":       "CreateProjectService.Create",
+			"component":       component,
 			"format":          format,
 			"package":         "github.com/apenella/ransidble/internal/domain/core/service/project",
 			"project_id":      projectID,
 			"project_version": projectVersion,
 			"storage":         storage,
 		})
-		return fmt.Errorf("%s: %s", ErrProjectFormatNotSupported, err.Error())
+		return nil, fmt.Errorf("%s: %s", ErrProjectFormatNotSupported, err.Error())
 	}
 
 	err = entity.ValidateProjectStorage(storage)
 	if err != nil {
 		s.logger.Error(fmt.Sprintf("%s: %s", ErrProjectStorageNotSupported, err.Error()), map[string]interface{}{
-			"component":       "CreateProjectService.Create",
+			"component":       component,
 			"package":         "github.com/apenella/ransidble/internal/domain/core/service/project",
 			"project_id":      projectID,
 			"project_version": projectVersion,
 			"storage":         storage,
 		})
-		return fmt.Errorf("%s: %s", ErrProjectStorageNotSupported, err.Error())
+		return nil, fmt.Errorf("%s: %s", ErrProjectStorageNotSupported, err.Error())
 	}
 
-	extension, err = entity.GetExtensionFromFormat(format)
-	if err != nil {
-		s.logger.Error(fmt.Sprintf("%s: %s", ErrProjectFormatNotSupported, err.Error()), map[string]interface{}{
-			"component":       "CreateProjectService.Create",
-			"format":          format,
+	// the source code of the projects stored in a git repository is not uploaded, but fetched from the repository
+	if storage == entity.ProjectTypeGit {
+		s.logger.Error(fmt.Sprintf("%s: %s", ErrProjectStorageNotSupported, "git projects must be created from their repository"), map[string]interface{}{
+			"component":       component,
 			"package":         "github.com/apenella/ransidble/internal/domain/core/service/project",
 			"project_id":      projectID,
 			"project_version": projectVersion,
 			"storage":         storage,
 		})
-		return fmt.Errorf("%s: %s", ErrProjectFormatNotSupported, err.Error())
+		return nil, fmt.Errorf("%s: %s", ErrProjectStorageNotSupported, "git projects must be created from their repository")
 	}
 
-	// name := extractProjectName(filename)
-	findProject, _ := s.repository.Find(projectID)
-	if findProject != nil {
-		s.logger.Error(fmt.Sprintf(ErrProjectAlreadyExists), map[string]interface{}{
-			"component":       "CreateProjectService.Create",
+	// the source code of the projects stored in an OCI registry is not uploaded, but fetched from the registry
+	if storage == entity.ProjectTypeOCI {
+		s.logger.Error(fmt.Sprintf("%s: %s", ErrProjectStorageNotSupported, "oci projects must be created from their registry"), map[string]interface{}{
+			"component":       component,
 			"package":         "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"project_id":      projectID,
+			"project_version": projectVersion,
+			"storage":         storage,
+		})
+		return nil, fmt.Errorf("%s: %s", ErrProjectStorageNotSupported, "oci projects must be created from their registry")
+	}
+
+	err = s.inspectContent(component, format, projectID, projectVersion, projectContentReader)
+	if err != nil {
+		return nil, err
+	}
+
+	extension, err = entity.GetExtensionFromFormat(format)
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("%s: %s", ErrProjectFormatNotSupported, err.Error()), map[string]interface{}{
+			"component":       component,
 			"format":          format,
+			"package":         "github.com/apenella/ransidble/internal/domain/core/service/project",
 			"project_id":      projectID,
 			"project_version": projectVersion,
 			"storage":         storage,
 		})
-		return domainerror.NewProjectAlreadyExistsError(
-			fmt.Errorf(ErrProjectAlreadyExists),
-		)
+		return nil, fmt.Errorf("%s: %s", ErrProjectFormatNotSupported, err.Error())
+	}
+
+	switch mode {
+	case entity.CreateProjectModeVersion:
+		err = s.checkNewVersion(component, projectID, projectVersion)
+	case entity.CreateProjectModeReplace:
+		current, err = s.checkReplacedProject(component, projectID, projectVersion, revision)
+	default:
+		err = s.checkNewProject(component, projectID, projectVersion)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// the replaced project keeps its version
+	if current != nil {
+		projectVersion = current.Version
 	}
 
 	storer := s.storage.Get(storage)
 	if storer == nil {
 		s.logger.Error(ErrStorageHandlerNotFound, map[string]interface{}{
-			"component":       "CreateProjectService.Create",
+			"component":       component,
 			"format":          format,
 			"package":         "github.com/apenella/ransidble/internal/domain/core/service/project",
 			"project_id":      projectID,
 			"project_version": projectVersion,
 			"storage":         storage,
 		})
-		return fmt.Errorf(ErrStorageHandlerNotFound)
+		return nil, fmt.Errorf(ErrStorageHandlerNotFound)
 	}
 
-	reference := fmt.Sprintf("%s.%s", projectID, extension)
+	// each version has its own source code, so the version is part of the reference of the versions created after the project. The source code is stored before the project record, so every reference is unique: a request that loses the race to store the record only removes its own source code, and the replaced source code is kept until the project is replaced
+	switch mode {
+	case entity.CreateProjectModeVersion, entity.CreateProjectModeReplace:
+		reference = fmt.Sprintf("%s@%s.%s.%s", projectID, projectVersion, uuid.New().String(), extension)
+	default:
+		reference = fmt.Sprintf("%s.%s.%s", projectID, uuid.New().String(), extension)
+	}
 
 	project := entity.NewProject(projectID, projectVersion, reference, format, storage)
-	err = s.repository.SafeStore(projectID, project)
+	project.Signature = signature
+
+	// the uploaded OCI image layouts are pinned to the digest of their manifest
+	if format == entity.ProjectFormatOCI {
+		var digest string
+
+		digest, err = s.resolveLayout(component, projectID, projectVersion, projectContentReader)
+		if err != nil {
+			return nil, err
+		}
+		project.OCI = entity.NewProjectOCISource("", digest)
+	}
+
+	// the source code is stored before the project, since its digest is computed while it is stored
+	err = storer.Store(project, projectContentReader)
 	if err != nil {
 		s.logger.Error(fmt.Sprintf("%s: %s", ErrStoringProject, err.Error()), map[string]interface{}{
-			"component":       "CreateProjectService.Create",
+			"component":       component,
 			"format":          format,
 			"package":         "github.com/apenella/ransidble/internal/domain/core/service/project",
 			"project_id":      projectID,
@@ -170,13 +322,49 @@ func (s *CreateProjectService) Create(format string, storage string, projectID s
 			"reference":       reference,
 			"storage":         storage,
 		})
-		return fmt.Errorf("%s: %s", ErrStoringProject, err.Error())
+		return nil, fmt.Errorf("%s: %s", ErrStoringProject, err.Error())
 	}
 
-	err = storer.Store(project, projectContentReader)
+	if expectedDigest != "" && project.Digest != expectedDigest {
+		s.logger.Error(fmt.Sprintf("%s: expected %s, got %s", ErrProjectDigestMismatch, expectedDigest, project.Digest), map[string]interface{}{
+			"component":       component,
+			"format":          format,
+			"package":         "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"project_id":      projectID,
+			"project_version": projectVersion,
+			"reference":       reference,
+			"storage":         storage,
+		})
+		s.removeSourceCode(component, storer, project)
+		return nil, domainerror.NewProjectIntegrityError(
+			fmt.Errorf("%s: expected %s, got %s", ErrProjectDigestMismatch, expectedDigest, project.Digest),
+		)
+	}
+
+	err = s.discoverContents(component, project)
+	if err != nil {
+		s.removeSourceCode(component, storer, project)
+		return nil, err
+	}
+
+	err = s.storeProject(projectID, project, mode, revision)
+	if err != nil && errors.Is(err, entity.ErrProjectRevisionMismatch) {
+		s.logger.Error(fmt.Sprintf("%s: %s", ErrProjectRevisionMismatch, err.Error()), map[string]interface{}{
+			"component":       component,
+			"package":         "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"project_id":      projectID,
+			"project_version": projectVersion,
+			"reference":       reference,
+			"revision":        revision,
+		})
+		s.removeSourceCode(component, storer, project)
+		return nil, domainerror.NewProjectPreconditionFailedError(
+			fmt.Errorf("%s", ErrProjectRevisionMismatch),
+		)
+	}
 	if err != nil {
 		s.logger.Error(fmt.Sprintf("%s: %s", ErrStoringProject, err.Error()), map[string]interface{}{
-			"component":       "CreateProjectService.Create",
+			"component":       component,
 			"format":          format,
 			"package":         "github.com/apenella/ransidble/internal/domain/core/service/project",
 			"project_id":      projectID,
@@ -184,13 +372,22 @@ func (s *CreateProjectService) Create(format string, storage string, projectID s
 			"reference":       reference,
 			"storage":         storage,
 		})
-		return fmt.Errorf("%s: %s", ErrStoringProject, err.Error())
+		s.removeSourceCode(component, storer, project)
+		return nil, fmt.Errorf("%s: %s", ErrStoringProject, err.Error())
+	}
 
+	if current != nil {
+		s.removeReplacedSourceCode(component, current)
 	}
 
-	s.logger.Info("Project created", map[string]interface{}{
-		"component":       "CreateProjectService.Create",
+	message := "Project created"
+	if mode == entity.CreateProjectModeReplace {
+		message = "Project replaced"
+	}
+	s.logger.Info(message, map[string]interface{}{
+		"component":       component,
 		"package":         "github.com/apenella/ransidble/internal/domain/core/service/project",
+		"digest":          project.Digest,
 		"format":          format,
 		"project_id":      projectID,
 		"project_version": projectVersion,
@@ -198,5 +395,752 @@ func (s *CreateProjectService) Create(format string, storage string, projectID s
 		"storage":         storage,
 	})
 
+	return project, nil
+}
+
+// removeReplacedSourceCode removes the source code of a project that has been replaced, which may be located in a different storage than the replacing source code. The project is already replaced whether the source code is removed or not, so the errors are only logged
+func (s *CreateProjectService) removeReplacedSourceCode(component string, project *entity.Project) {
+
+	storer := s.storage.Get(project.Storage)
+	if storer == nil {
+		s.logger.Error(fmt.Sprintf("%s: %s", ErrRemovingProjectSourceCode, ErrStorageHandlerNotFound), map[string]interface{}{
+			"component":       component,
+			"package":         "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"project_id":      project.Name,
+			"project_version": project.Version,
+			"reference":       project.Reference,
+			"storage":         project.Storage,
+		})
+		return
+	}
+
+	s.removeSourceCode(component, storer, project)
+}
+
+// removeSourceCode removes the stored source code of a project that could not be created, or that has been replaced. The project is not created whether the source code is removed or not, so the error is only logged
+func (s *CreateProjectService) removeSourceCode(component string, storer repository.SourceCodeStorer, project *entity.Project) {
+
+	err := storer.Delete(project)
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("%s: %s", ErrRemovingProjectSourceCode, err.Error()), map[string]interface{}{
+			"component":       component,
+			"package":         "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"project_id":      project.Name,
+			"project_version": project.Version,
+			"reference":       project.Reference,
+		})
+	}
+}
+
+// detectFormat detects the format of the project source code sniffing its first bytes. It returns the detected format along with a reader that still provides the whole source code. The seekable readers are rewound instead of buffered, so they can still be read again afterwards
+func (s *CreateProjectService) detectFormat(component string, projectID string, projectVersion string, projectContentReader io.Reader) (string, io.Reader, error) {
+	var header []byte
+	var err error
+
+	reader := projectContentReader
+	seeker, seekable := projectContentReader.(io.ReadSeeker)
+	if seekable {
+		header, err = readHeader(seeker)
+	} else {
+		bufferedReader := bufio.NewReaderSize(projectContentReader, entity.ProjectFormatDetectionHeaderSize)
+		header, err = bufferedReader.Peek(entity.ProjectFormatDetectionHeaderSize)
+		reader = bufferedReader
+	}
+	if err != nil && !errors.Is(err, io.EOF) {
+		s.logger.Error(fmt.Sprintf("%s: %s", ErrReadingProjectContent, err.Error()), map[string]interface{}{
+			"component":       component,
+			"package":         "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"project_id":      projectID,
+			"project_version": projectVersion,
+		})
+		return "", nil, fmt.Errorf("%s: %s", ErrReadingProjectContent, err.Error())
+	}
+
+	format, err := entity.DetectProjectFormat(header)
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("%s: %s", ErrProjectFormatNotDetected, err.Error()), map[string]interface{}{
+			"component":       component,
+			"package":         "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"project_id":      projectID,
+			"project_version": projectVersion,
+		})
+		return "", nil, domainerror.NewProjectInvalidFormatError(
+			fmt.Errorf("%s: %s", ErrProjectFormatNotDetected, err.Error()),
+		)
+	}
+
+	return format, reader, nil
+}
+
+// readHeader reads the first bytes of a seekable source code and rewinds it
+func readHeader(seeker io.ReadSeeker) ([]byte, error) {
+
+	header := make([]byte, entity.ProjectFormatDetectionHeaderSize)
+	n, err := io.ReadFull(seeker, header)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, err
+	}
+
+	_, err = seeker.Seek(0, io.SeekStart)
+	if err != nil {
+		return nil, err
+	}
+
+	return header[:n], nil
+}
+
+// inspectContent checks the uploaded source code against the archive limits. The content is inspected before it is stored, so the reader must be seekable to be rewound afterwards
+func (s *CreateProjectService) inspectContent(component string, format string, projectID string, projectVersion string, projectContentReader io.Reader) error {
+
+	if !s.archiveLimits.IsEnabled() {
+		return nil
+	}
+
+	if s.archiveInspector == nil {
+		s.logger.Error(ErrArchiveInspectorNotInitialized, map[string]interface{}{
+			"component":       component,
+			"package":         "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"project_id":      projectID,
+			"project_version": projectVersion,
+		})
+		return fmt.Errorf(ErrArchiveInspectorNotInitialized)
+	}
+
+	content, ok := projectContentReader.(interface {
+		io.ReadSeeker
+		io.ReaderAt
+	})
+	if !ok {
+		s.logger.Error(ErrProjectContentNotSeekable, map[string]interface{}{
+			"component":       component,
+			"package":         "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"project_id":      projectID,
+			"project_version": projectVersion,
+		})
+		return fmt.Errorf(ErrProjectContentNotSeekable)
+	}
+
+	size, err := content.Seek(0, io.SeekEnd)
+	if err == nil {
+		_, err = content.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("%s: %s", ErrReadingProjectContent, err.Error()), map[string]interface{}{
+			"component":       component,
+			"package":         "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"project_id":      projectID,
+			"project_version": projectVersion,
+		})
+		return fmt.Errorf("%s: %s", ErrReadingProjectContent, err.Error())
+	}
+
+	err = s.archiveInspector.Inspect(format, content, size, s.archiveLimits)
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("%s: %s", ErrInspectingProjectContent, err.Error()), map[string]interface{}{
+			"component":       component,
+			"format":          format,
+			"package":         "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"project_id":      projectID,
+			"project_version": projectVersion,
+			"size":            size,
+		})
+
+		switch {
+		case errors.Is(err, entity.ErrProjectUploadSizeExceeded):
+			return domainerror.NewProjectTooLargeError(
+				fmt.Errorf("%s: %s", ErrInspectingProjectContent, err.Error()),
+			)
+		case errors.Is(err, entity.ErrProjectArchiveLimitExceeded):
+			return domainerror.NewProjectArchiveLimitExceededError(
+				fmt.Errorf("%s: %s", ErrInspectingProjectContent, err.Error()),
+			)
+		default:
+			return domainerror.NewProjectInvalidFormatError(
+				fmt.Errorf("%s: %s", ErrInspectingProjectContent, err.Error()),
+			)
+		}
+	}
+
 	return nil
 }
+
+// resolveLayout returns the manifest digest of an uploaded OCI image layout. The layout is read to resolve the digest, so the reader is rewound afterwards to store the whole source code
+func (s *CreateProjectService) resolveLayout(component string, projectID string, projectVersion string, projectContentReader io.Reader) (string, error) {
+
+	if s.ociResolver == nil {
+		s.logger.Error(ErrOCIResolverNotInitialized, map[string]interface{}{
+			"component":       component,
+			"package":         "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"project_id":      projectID,
+			"project_version": projectVersion,
+		})
+		return "", fmt.Errorf(ErrOCIResolverNotInitialized)
+	}
+
+	seeker, ok := projectContentReader.(io.ReadSeeker)
+	if !ok {
+		s.logger.Error(ErrProjectContentNotSeekable, map[string]interface{}{
+			"component":       component,
+			"package":         "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"project_id":      projectID,
+			"project_version": projectVersion,
+		})
+		return "", fmt.Errorf(ErrProjectContentNotSeekable)
+	}
+
+	digest, err := s.ociResolver.ResolveLayout(seeker)
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("%s: %s", ErrInvalidProjectOCILayout, err.Error()), map[string]interface{}{
+			"component":       component,
+			"package":         "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"project_id":      projectID,
+			"project_version": projectVersion,
+		})
+		return "", domainerror.NewProjectInvalidFormatError(
+			fmt.Errorf("%s: %s", ErrInvalidProjectOCILayout, err.Error()),
+		)
+	}
+
+	_, err = seeker.Seek(0, io.SeekStart)
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("%s: %s", ErrReadingProjectContent, err.Error()), map[string]interface{}{
+			"component":       component,
+			"package":         "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"project_id":      projectID,
+			"project_version": projectVersion,
+		})
+		return "", fmt.Errorf("%s: %s", ErrReadingProjectContent, err.Error())
+	}
+
+	return digest, nil
+}
+
+// CreateFromOCI creates a project, or a new version of an existing project, stored as an artifact in an OCI registry, depending on the mode of the request, and returns the revision of the stored project. The project is pinned to the manifest digest its reference resolves to, and the artifact is fetched from the registry when a task is executed
+func (s *CreateProjectService) CreateFromOCI(request *entity.CreateProjectRequest, source *entity.ProjectOCISource) (string, error) {
+	project, err := s.createFromOCI("CreateProjectService.CreateFromOCI", request, source)
+	if err != nil {
+		return "", err
+	}
+
+	return project.Revision, nil
+}
+
+// createFromOCI stores a project stored in an OCI registry, either as a new project or as a new version of an existing project
+func (s *CreateProjectService) createFromOCI(component string, request *entity.CreateProjectRequest, source *entity.ProjectOCISource) (*entity.Project, error) {
+	var err error
+
+	err = s.validateRequest(component, request)
+	if err != nil {
+		return nil, err
+	}
+
+	mode := request.Mode
+	projectID := request.ProjectID
+	projectVersion := request.Version
+
+	// the source code of the projects stored in an OCI registry is not uploaded, so there is no source code to replace
+	if mode == entity.CreateProjectModeReplace {
+		s.logger.Error(ErrProjectSourceCodeNotReplaceable, map[string]interface{}{
+			"component":  component,
+			"package":    "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"project_id": projectID,
+		})
+		return nil, domainerror.NewProjectInvalidSourceError(
+			fmt.Errorf(ErrProjectSourceCodeNotReplaceable),
+		)
+	}
+
+	if projectID == "" {
+		s.logger.Error(ErrProjectIDNotProvided, map[string]interface{}{
+			"component": component,
+			"package":   "github.com/apenella/ransidble/internal/domain/core/service/project",
+		})
+		return nil, domainerror.NewProjectIDNotProvidedError(
+			fmt.Errorf(ErrProjectIDNotProvided),
+		)
+	}
+
+	err = s.validateVersion(component, projectID, projectVersion, mode)
+	if err != nil {
+		return nil, err
+	}
+
+	if source == nil || source.Reference == "" {
+		s.logger.Error(ErrProjectOCISourceNotProvided, map[string]interface{}{
+			"component":  component,
+			"package":    "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"project_id": projectID,
+		})
+		return nil, fmt.Errorf(ErrProjectOCISourceNotProvided)
+	}
+
+	if s.repository == nil {
+		s.logger.Error(ErrProjectRepositoryNotInitialized, map[string]interface{}{
+			"component":       component,
+			"package":         "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"project_id":      projectID,
+			"project_version": projectVersion,
+		})
+		return nil, fmt.Errorf(ErrProjectRepositoryNotInitialized)
+	}
+
+	if s.ociResolver == nil {
+		s.logger.Error(ErrOCIResolverNotInitialized, map[string]interface{}{
+			"component":       component,
+			"package":         "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"project_id":      projectID,
+			"project_version": projectVersion,
+		})
+		return nil, fmt.Errorf(ErrOCIResolverNotInitialized)
+	}
+
+	if mode == entity.CreateProjectModeVersion {
+		err = s.checkNewVersion(component, projectID, projectVersion)
+	} else {
+		err = s.checkNewProject(component, projectID, projectVersion)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	digest, err := s.ociResolver.ResolveReference(source.Reference)
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("%s: %s", ErrResolvingProjectOCIReference, err.Error()), map[string]interface{}{
+			"component":       component,
+			"package":         "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"project_id":      projectID,
+			"project_version": projectVersion,
+			"reference":       source.Reference,
+		})
+		return nil, domainerror.NewProjectInvalidSourceError(
+			fmt.Errorf("%s: %s", ErrResolvingProjectOCIReference, err.Error()),
+		)
+	}
+
+	// the fetched artifact is written as an OCI image-layout tarball named after the reference, as the uploaded ones
+	reference := fmt.Sprintf("%s.%s", projectID, entity.ExtensionOCI)
+	if mode == entity.CreateProjectModeVersion {
+		reference = fmt.Sprintf("%s@%s.%s", projectID, projectVersion, entity.ExtensionOCI)
+	}
+
+	project := entity.NewProject(projectID, projectVersion, reference, entity.ProjectFormatOCI, entity.ProjectTypeOCI)
+	project.OCI = entity.NewProjectOCISource(source.Reference, digest)
+
+	err = project.Validate()
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("%s: %s", ErrInvalidProjectOCISource, err.Error()), map[string]interface{}{
+			"component":       component,
+			"package":         "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"project_id":      projectID,
+			"project_version": projectVersion,
+			"reference":       source.Reference,
+		})
+		return nil, domainerror.NewProjectInvalidSourceError(
+			fmt.Errorf("%s: %s", ErrInvalidProjectOCISource, err.Error()),
+		)
+	}
+
+	err = s.discoverContents(component, project)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.storeProject(projectID, project, mode, "")
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("%s: %s", ErrStoringProject, err.Error()), map[string]interface{}{
+			"component":       component,
+			"package":         "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"project_id":      projectID,
+			"project_version": projectVersion,
+			"reference":       source.Reference,
+		})
+		return nil, fmt.Errorf("%s: %s", ErrStoringProject, err.Error())
+	}
+
+	s.logger.Info("Project created", map[string]interface{}{
+		"component":       component,
+		"digest":          digest,
+		"package":         "github.com/apenella/ransidble/internal/domain/core/service/project",
+		"project_id":      projectID,
+		"project_version": projectVersion,
+		"reference":       source.Reference,
+		"storage":         entity.ProjectTypeOCI,
+	})
+
+	return project, nil
+}
+
+// CreateFromGit creates a project, or a new version of an existing project, stored in a git repository, depending on the mode of the request, and returns the revision of the stored project. The project source code is fetched from the repository when a task is executed, so the project is stored in plain format
+func (s *CreateProjectService) CreateFromGit(request *entity.CreateProjectRequest, source *entity.ProjectGitSource) (string, error) {
+	project, err := s.createFromGit("CreateProjectService.CreateFromGit", request, source)
+	if err != nil {
+		return "", err
+	}
+
+	return project.Revision, nil
+}
+
+// createFromGit stores a project stored in a git repository, either as a new project or as a new version of an existing project
+func (s *CreateProjectService) createFromGit(component string, request *entity.CreateProjectRequest, source *entity.ProjectGitSource) (*entity.Project, error) {
+	var err error
+
+	err = s.validateRequest(component, request)
+	if err != nil {
+		return nil, err
+	}
+
+	mode := request.Mode
+	projectID := request.ProjectID
+	projectVersion := request.Version
+
+	// the source code of the projects stored in a git repository is not uploaded, so there is no source code to replace
+	if mode == entity.CreateProjectModeReplace {
+		s.logger.Error(ErrProjectSourceCodeNotReplaceable, map[string]interface{}{
+			"component":  component,
+			"package":    "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"project_id": projectID,
+		})
+		return nil, domainerror.NewProjectInvalidSourceError(
+			fmt.Errorf(ErrProjectSourceCodeNotReplaceable),
+		)
+	}
+
+	if projectID == "" {
+		s.logger.Error(ErrProjectIDNotProvided, map[string]interface{}{
+			"component": component,
+			"package":   "github.com/apenella/ransidble/internal/domain/core/service/project",
+		})
+		return nil, domainerror.NewProjectIDNotProvidedError(
+			fmt.Errorf(ErrProjectIDNotProvided),
+		)
+	}
+
+	err = s.validateVersion(component, projectID, projectVersion, mode)
+	if err != nil {
+		return nil, err
+	}
+
+	if source == nil {
+		s.logger.Error(ErrProjectGitSourceNotProvided, map[string]interface{}{
+			"component":  component,
+			"package":    "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"project_id": projectID,
+		})
+		return nil, fmt.Errorf(ErrProjectGitSourceNotProvided)
+	}
+
+	if s.repository == nil {
+		s.logger.Error(ErrProjectRepositoryNotInitialized, map[string]interface{}{
+			"component":       component,
+			"package":         "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"project_id":      projectID,
+			"project_version": projectVersion,
+		})
+		return nil, fmt.Errorf(ErrProjectRepositoryNotInitialized)
+	}
+
+	project := entity.NewProject(projectID, projectVersion, source.URL, entity.ProjectFormatPlain, entity.ProjectTypeGit)
+	project.Git = source
+
+	err = project.Validate()
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("%s: %s", ErrInvalidProjectGitSource, err.Error()), map[string]interface{}{
+			"component":       component,
+			"package":         "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"project_id":      projectID,
+			"project_version": projectVersion,
+			"url":             source.URL,
+		})
+		return nil, domainerror.NewProjectInvalidSourceError(
+			fmt.Errorf("%s: %s", ErrInvalidProjectGitSource, err.Error()),
+		)
+	}
+
+	if mode == entity.CreateProjectModeVersion {
+		err = s.checkNewVersion(component, projectID, projectVersion)
+	} else {
+		err = s.checkNewProject(component, projectID, projectVersion)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.storeProject(projectID, project, mode, "")
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("%s: %s", ErrStoringProject, err.Error()), map[string]interface{}{
+			"component":       component,
+			"package":         "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"project_id":      projectID,
+			"project_version": projectVersion,
+			"url":             source.URL,
+		})
+		return nil, fmt.Errorf("%s: %s", ErrStoringProject, err.Error())
+	}
+
+	s.logger.Info("Project created", map[string]interface{}{
+		"component":       component,
+		"package":         "github.com/apenella/ransidble/internal/domain/core/service/project",
+		"project_id":      projectID,
+		"project_version": projectVersion,
+		"ref":             source.Ref,
+		"storage":         entity.ProjectTypeGit,
+		"subdirectory":    source.Subdirectory,
+		"url":             source.URL,
+	})
+
+	return project, nil
+}
+
+// CreateFromUpload creates a project, creates a new version of an existing project, or replaces the source code of the most recent version of an existing project, depending on the mode of the request, from the source code received by an upload. It returns the revision of the stored project. The upload must be complete, and it is removed once the project is stored
+func (s *CreateProjectService) CreateFromUpload(request *entity.CreateProjectRequest, uploadID string) (string, error) {
+	project, err := s.createFromUpload("CreateProjectService.CreateFromUpload", request, uploadID)
+	if err != nil {
+		return "", err
+	}
+
+	return project.Revision, nil
+}
+
+// createFromUpload creates a project, a new version of an existing project, or replaces the most recent version of an existing project, from the source code staged by an upload. The staged source code goes through the same pipeline as the source code uploaded at once
+func (s *CreateProjectService) createFromUpload(component string, request *entity.CreateProjectRequest, uploadID string) (*entity.Project, error) {
+
+	err := s.validateRequest(component, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.uploads == nil {
+		s.logger.Error(ErrProjectUploadServiceNotInitialized, map[string]interface{}{
+			"component":       component,
+			"package":         "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"project_id":      request.ProjectID,
+			"project_version": request.Version,
+		})
+		return nil, fmt.Errorf(ErrProjectUploadServiceNotInitialized)
+	}
+
+	var project *entity.Project
+
+	// the upload service keeps the upload reserved while the project is created, so no chunk is appended and the upload is not removed meanwhile
+	err = s.uploads.FinalizeUpload(uploadID, func(content io.ReadSeeker) error {
+		var err error
+		project, err = s.create(component, request, content)
+		return err
+	})
+	// the errors are already logged by the upload service and while the project is created
+	if err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
+
+// validateRequest validates that the request to create a project is provided and that its mode is known
+func (s *CreateProjectService) validateRequest(component string, request *entity.CreateProjectRequest) error {
+	if request == nil {
+		s.logger.Error(ErrCreateProjectRequestNotProvided, map[string]interface{}{
+			"component": component,
+			"package":   "github.com/apenella/ransidble/internal/domain/core/service/project",
+		})
+		return fmt.Errorf(ErrCreateProjectRequestNotProvided)
+	}
+
+	switch request.Mode {
+	case "", entity.CreateProjectModeProject, entity.CreateProjectModeVersion, entity.CreateProjectModeReplace:
+		return nil
+	default:
+		s.logger.Error(fmt.Sprintf("%s: %s", ErrInvalidCreateProjectMode, request.Mode), map[string]interface{}{
+			"component":  component,
+			"package":    "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"project_id": request.ProjectID,
+		})
+		return fmt.Errorf("%s: %s", ErrInvalidCreateProjectMode, request.Mode)
+	}
+}
+
+// validateVersion validates the version of the project to create. The version is required when a new version is created, and it cannot be the alias of the most recent version unless the most recent version is replaced
+func (s *CreateProjectService) validateVersion(component string, projectID string, projectVersion string, mode string) error {
+	var err error
+
+	switch {
+	case mode == entity.CreateProjectModeVersion && projectVersion == "":
+		err = fmt.Errorf(ErrProjectVersionNotProvided)
+	case mode == entity.CreateProjectModeReplace && projectVersion == entity.LatestVersion:
+		err = nil
+	case projectVersion == entity.LatestVersion:
+		err = fmt.Errorf("%s: %s", ErrProjectVersionReserved, projectVersion)
+	case projectVersion != "":
+		err = entity.ValidateProjectVersion(projectVersion)
+	}
+
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("%s: %s", ErrInvalidProjectVersion, err.Error()), map[string]interface{}{
+			"component":       component,
+			"package":         "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"project_id":      projectID,
+			"project_version": projectVersion,
+		})
+		return domainerror.NewProjectInvalidVersionError(
+			fmt.Errorf("%s: %s", ErrInvalidProjectVersion, err.Error()),
+		)
+	}
+
+	return nil
+}
+
+// checkNewProject ensures that the project to create does not exist
+func (s *CreateProjectService) checkNewProject(component string, projectID string, projectVersion string) error {
+	findProject, _ := s.repository.Find(projectID)
+	if findProject != nil {
+		s.logger.Error(ErrProjectAlreadyExists, map[string]interface{}{
+			"component":       component,
+			"package":         "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"project_id":      projectID,
+			"project_version": projectVersion,
+		})
+		return domainerror.NewProjectAlreadyExistsError(
+			fmt.Errorf(ErrProjectAlreadyExists),
+		)
+	}
+
+	return nil
+}
+
+// checkNewVersion ensures that the project of the version to create exists and the version does not
+func (s *CreateProjectService) checkNewVersion(component string, projectID string, projectVersion string) error {
+	findProject, err := s.repository.Find(projectID)
+	if findProject == nil {
+		msg := ErrFindingProject
+		if err != nil {
+			msg = fmt.Sprintf("%s: %s", ErrFindingProject, err.Error())
+		}
+		s.logger.Error(msg, map[string]interface{}{
+			"component":       component,
+			"package":         "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"project_id":      projectID,
+			"project_version": projectVersion,
+		})
+		return domainerror.NewProjectNotFoundError(
+			fmt.Errorf("%s", msg),
+		)
+	}
+
+	findVersion, _ := s.repository.FindVersion(projectID, projectVersion)
+	if findVersion != nil {
+		s.logger.Error(ErrProjectVersionAlreadyExists, map[string]interface{}{
+			"component":       component,
+			"package":         "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"project_id":      projectID,
+			"project_version": projectVersion,
+		})
+		return domainerror.NewProjectAlreadyExistsError(
+			fmt.Errorf(ErrProjectVersionAlreadyExists),
+		)
+	}
+
+	return nil
+}
+
+// checkReplacedProject ensures that the project to replace exists, that the version to replace is its most recent version, and that its revision matches the expected revision when it is provided. It returns the project to replace
+func (s *CreateProjectService) checkReplacedProject(component string, projectID string, projectVersion string, revision string) (*entity.Project, error) {
+	findProject, err := s.repository.Find(projectID)
+	if findProject == nil {
+		msg := ErrFindingProject
+		if err != nil {
+			msg = fmt.Sprintf("%s: %s", ErrFindingProject, err.Error())
+		}
+		s.logger.Error(msg, map[string]interface{}{
+			"component":       component,
+			"package":         "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"project_id":      projectID,
+			"project_version": projectVersion,
+		})
+		return nil, domainerror.NewProjectNotFoundError(
+			fmt.Errorf("%s", msg),
+		)
+	}
+
+	if projectVersion != "" && projectVersion != entity.LatestVersion && projectVersion != findProject.Version {
+		s.logger.Error(fmt.Sprintf("%s: %s", ErrProjectVersionNotReplaceable, findProject.Version), map[string]interface{}{
+			"component":       component,
+			"package":         "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"project_id":      projectID,
+			"project_version": projectVersion,
+		})
+		return nil, domainerror.NewProjectInvalidVersionError(
+			fmt.Errorf("%s: %s", ErrProjectVersionNotReplaceable, findProject.Version),
+		)
+	}
+
+	if revision != "" && revision != findProject.Revision {
+		s.logger.Error(ErrProjectRevisionMismatch, map[string]interface{}{
+			"component":       component,
+			"package":         "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"project_id":      projectID,
+			"project_version": projectVersion,
+			"revision":        revision,
+		})
+		return nil, domainerror.NewProjectPreconditionFailedError(
+			fmt.Errorf("%s", ErrProjectRevisionMismatch),
+		)
+	}
+
+	return findProject, nil
+}
+
+// discoverContents sets the Ansible content discovered in the source code of the project, along with its manifest. The project is created whether its content is discovered or not, so the discovery error is only logged, but it is not created when its manifest is not valid
+func (s *CreateProjectService) discoverContents(component string, project *entity.Project) error {
+	if s.discoverer == nil {
+		return nil
+	}
+
+	contents, err := s.discoverer.Discover(project)
+	if err != nil {
+		s.logger.Warn(fmt.Sprintf("%s: %s", ErrDiscoveringProjectContents, err.Error()), map[string]interface{}{
+			"component":       component,
+			"package":         "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"project_id":      project.Name,
+			"project_version": project.Version,
+			"reference":       project.Reference,
+		})
+		return nil
+	}
+
+	if contents.Manifest != nil {
+		manifest, err := entity.ParseProjectManifest(contents.Manifest)
+		if err == nil {
+			err = manifest.Validate(contents)
+		}
+		if err != nil {
+			s.logger.Error(fmt.Sprintf("%s: %s", ErrValidatingProjectManifest, err.Error()), map[string]interface{}{
+				"component":       component,
+				"package":         "github.com/apenella/ransidble/internal/domain/core/service/project",
+				"project_id":      project.Name,
+				"project_version": project.Version,
+				"reference":       project.Reference,
+			})
+			return domainerror.NewProjectInvalidManifestError(
+				fmt.Errorf("%s: %s", ErrValidatingProjectManifest, err.Error()),
+			)
+		}
+
+		project.Manifest = manifest
+		contents.Manifest = nil
+	}
+
+	project.Contents = contents
+
+	return nil
+}
+
+// storeProject stores the project in the repository, either as a new project, as a new version of an existing project, or replacing the most recent version of an existing project
+func (s *CreateProjectService) storeProject(projectID string, project *entity.Project, mode string, revision string) error {
+	switch mode {
+	case entity.CreateProjectModeVersion:
+		return s.repository.SafeStoreVersion(projectID, project)
+	case entity.CreateProjectModeReplace:
+		return s.repository.SafeReplace(projectID, project, revision)
+	default:
+		return s.repository.SafeStore(projectID, project)
+	}
+}
@@ -1,8 +1,10 @@
 package project
 
 import (
+	"errors"
 	"fmt"
 	"io"
+	"regexp"
 	"strings"
 	"testing"
 
@@ -11,8 +13,26 @@ import (
 	"github.com/apenella/ransidble/internal/domain/ports/repository"
 	"github.com/apenella/ransidble/internal/infrastructure/logger"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
+// storedSourceCodeID matches the unique identifier that is part of the reference of a stored source code
+var storedSourceCodeID = regexp.MustCompile(`\.[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}\.`)
+
+// storedProject matches the expected project, whose source code is stored with a unique reference. The expected reference does not include the unique identifier
+func storedProject(expected *entity.Project) interface{} {
+	return mock.MatchedBy(func(project *entity.Project) bool {
+		if project == nil || !storedSourceCodeID.MatchString(project.Reference) {
+			return false
+		}
+
+		actual := *project
+		actual.Reference = storedSourceCodeID.ReplaceAllString(project.Reference, ".")
+
+		return assert.ObjectsAreEqual(expected, &actual)
+	})
+}
+
 func TestCreateProjectService_Create(t *testing.T) {
 
 	fileReader := io.NopCloser(strings.NewReader("content for testing"))
@@ -22,11 +42,13 @@ func TestCreateProjectService_Create(t *testing.T) {
 		assertFunc           func(*testing.T, *CreateProjectService) bool
 		desc                 string
 		err                  error
+		expectedDigest       string
 		format               string
 		projectContentReader io.Reader
 		projectID            string
 		projectVersion       string
 		service              *CreateProjectService
+		signature            string
 		storage              string
 	}{
 		{
@@ -56,24 +78,24 @@ func TestCreateProjectService_Create(t *testing.T) {
 				service.repository.(*repository.MockProjectRepository).On(
 					"SafeStore",
 					"project-id",
-					&entity.Project{
+					storedProject(&entity.Project{
 						Name:      "project-id",
 						Version:   "v1.0.0",
 						Format:    "targz",
 						Storage:   "local",
 						Reference: "project-id.tar.gz",
-					},
+					}),
 				).Return(nil)
 
 				projectSourceCodeStorer.On(
 					"Store",
-					&entity.Project{
+					storedProject(&entity.Project{
 						Name:      "project-id",
 						Version:   "v1.0.0",
 						Format:    "targz",
 						Storage:   "local",
 						Reference: "project-id.tar.gz",
-					},
+					}),
 					fileReader,
 				).Return(nil)
 			},
@@ -81,6 +103,37 @@ func TestCreateProjectService_Create(t *testing.T) {
 				return service.repository.(*repository.MockProjectRepository).AssertExpectations(t)
 			},
 		},
+		{
+			desc:                 "Testing an error creating a project on the CreateProjectService when another request stores the project first, removing only its own source code",
+			format:               "targz",
+			storage:              "local",
+			projectID:            "project-id",
+			projectVersion:       "v1.0.0",
+			projectContentReader: fileReader,
+			err:                  fmt.Errorf("%s: %s", ErrStoringProject, "error project already exists"),
+			service: NewCreateProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			),
+			arrangeFunc: func(t *testing.T, service *CreateProjectService) {
+				projectSourceCodeStorer := repository.NewMockProjectSourceCodeStorer()
+				project := storedProject(&entity.Project{
+					Name:      "project-id",
+					Version:   "v1.0.0",
+					Format:    "targz",
+					Storage:   "local",
+					Reference: "project-id.tar.gz",
+				})
+
+				service.repository.(*repository.MockProjectRepository).On("Find", "project-id").Return(nil, nil)
+				service.storage.(*repository.MockProjectSourceCodeStorageFactory).On("Get", "local").Return(projectSourceCodeStorer)
+				service.repository.(*repository.MockProjectRepository).On("SafeStore", "project-id", project).Return(fmt.Errorf("error project already exists"))
+				projectSourceCodeStorer.On("Store", project, fileReader).Return(nil)
+				// the source code is removed using its unique reference, so the source code stored by the other request is kept
+				projectSourceCodeStorer.On("Delete", project).Return(nil)
+			},
+		},
 		{
 			desc:                 "Testing create a project on the CreateProjectService without providing a version",
 			format:               "targz",
@@ -108,24 +161,24 @@ func TestCreateProjectService_Create(t *testing.T) {
 				service.repository.(*repository.MockProjectRepository).On(
 					"SafeStore",
 					"project-id",
-					&entity.Project{
+					storedProject(&entity.Project{
 						Name:      "project-id",
-						Version:   "latest",
+						Version:   "v1",
 						Format:    "targz",
 						Storage:   "local",
 						Reference: "project-id.tar.gz",
-					},
+					}),
 				).Return(nil)
 
 				projectSourceCodeStorer.On(
 					"Store",
-					&entity.Project{
+					storedProject(&entity.Project{
 						Name:      "project-id",
-						Version:   "latest",
+						Version:   "v1",
 						Format:    "targz",
 						Storage:   "local",
 						Reference: "project-id.tar.gz",
-					},
+					}),
 					fileReader,
 				).Return(nil)
 			},
@@ -280,6 +333,23 @@ func TestCreateProjectService_Create(t *testing.T) {
 			),
 			arrangeFunc: func(t *testing.T, service *CreateProjectService) {},
 		},
+		{
+			desc:                 "Testing an error creating a project on the CreateProjectService service when storage is git",
+			format:               "plain",
+			storage:              "git",
+			projectContentReader: fileReader,
+			projectID:            "project-id",
+			err: fmt.Errorf(
+				"%s: %s",
+				ErrProjectStorageNotSupported, "git projects must be created from their repository",
+			),
+			service: NewCreateProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			),
+			arrangeFunc: func(t *testing.T, service *CreateProjectService) {},
+		},
 		{
 			desc:                 "Testing an error creating a project on the CreateProjectService service when storage handler is not found",
 			format:               "plain",
@@ -329,14 +399,36 @@ func TestCreateProjectService_Create(t *testing.T) {
 				service.repository.(*repository.MockProjectRepository).On(
 					"SafeStore",
 					"project-id",
-					&entity.Project{
+					storedProject(&entity.Project{
 						Format:    "targz",
 						Name:      "project-id",
 						Reference: "project-id.tar.gz",
 						Storage:   "local",
-						Version:   "latest",
-					},
+						Version:   "v1",
+					}),
 				).Return(fmt.Errorf("storing project fails"))
+
+				projectSourceCodeStorer.On(
+					"Store",
+					storedProject(&entity.Project{
+						Format:    "targz",
+						Name:      "project-id",
+						Reference: "project-id.tar.gz",
+						Storage:   "local",
+						Version:   "v1",
+					}),
+					fileReader,
+				).Return(nil)
+				projectSourceCodeStorer.On(
+					"Delete",
+					storedProject(&entity.Project{
+						Format:    "targz",
+						Name:      "project-id",
+						Reference: "project-id.tar.gz",
+						Storage:   "local",
+						Version:   "v1",
+					}),
+				).Return(nil)
 			},
 		},
 		{
@@ -362,53 +454,1560 @@ func TestCreateProjectService_Create(t *testing.T) {
 					"Get",
 					"local",
 				).Return(projectSourceCodeStorer)
-				service.repository.(*repository.MockProjectRepository).On(
-					"SafeStore",
-					"project-id",
-					&entity.Project{
+
+				projectSourceCodeStorer.On(
+					"Store",
+					storedProject(&entity.Project{
 						Format:    "targz",
 						Name:      "project-id",
 						Reference: "project-id.tar.gz",
 						Storage:   "local",
-						Version:   "latest",
-					},
-				).Return(nil)
+						Version:   "v1",
+					}),
+					fileReader,
+				).Return(fmt.Errorf("storing project fails"))
+			},
+		},
+		{
+			desc:                 "Testing create a project on the CreateProjectService providing the expected digest of its source code",
+			format:               "targz",
+			storage:              "local",
+			projectContentReader: fileReader,
+			projectID:            "project-id",
+			expectedDigest:       "sha256:dfb84009b9e13d54974a044dd35e6b03bb9b53763f2a5cdebebf94ee55f7c000",
+			service: NewCreateProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			),
+			arrangeFunc: func(t *testing.T, service *CreateProjectService) {
+				projectSourceCodeStorer := repository.NewMockProjectSourceCodeStorer()
 
+				service.repository.(*repository.MockProjectRepository).On(
+					"Find",
+					"project-id",
+				).Return(nil, nil)
+				service.storage.(*repository.MockProjectSourceCodeStorageFactory).On(
+					"Get",
+					"local",
+				).Return(projectSourceCodeStorer)
 				projectSourceCodeStorer.On(
 					"Store",
-					&entity.Project{
+					mock.AnythingOfType("*entity.Project"),
+					fileReader,
+				).Run(func(args mock.Arguments) {
+					args.Get(0).(*entity.Project).Digest = "sha256:dfb84009b9e13d54974a044dd35e6b03bb9b53763f2a5cdebebf94ee55f7c000"
+				}).Return(nil)
+				service.repository.(*repository.MockProjectRepository).On(
+					"SafeStore",
+					"project-id",
+					storedProject(&entity.Project{
+						Digest:    "sha256:dfb84009b9e13d54974a044dd35e6b03bb9b53763f2a5cdebebf94ee55f7c000",
 						Format:    "targz",
 						Name:      "project-id",
 						Reference: "project-id.tar.gz",
 						Storage:   "local",
-						Version:   "latest",
-					},
-					fileReader,
-				).Return(fmt.Errorf("storing project fails"))
+						Version:   "v1",
+					}),
+				).Return(nil)
 			},
 		},
-	}
+		{
+			desc:                 "Testing error creating a project on the CreateProjectService when the digest of its source code does not match the expected one",
+			format:               "targz",
+			storage:              "local",
+			projectContentReader: fileReader,
+			projectID:            "project-id",
+			expectedDigest:       "sha256:0000000000000000000000000000000000000000000000000000000000000000",
+			err: domainerror.NewProjectIntegrityError(
+				fmt.Errorf("%s: expected %s, got %s", ErrProjectDigestMismatch, "sha256:0000000000000000000000000000000000000000000000000000000000000000", "sha256:dfb84009b9e13d54974a044dd35e6b03bb9b53763f2a5cdebebf94ee55f7c000"),
+			),
+			service: NewCreateProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			),
+			arrangeFunc: func(t *testing.T, service *CreateProjectService) {
+				projectSourceCodeStorer := repository.NewMockProjectSourceCodeStorer()
 
-	for _, test := range tests {
-		t.Run(test.desc, func(t *testing.T) {
-			t.Parallel()
-			t.Log(test.desc)
+				service.repository.(*repository.MockProjectRepository).On(
+					"Find",
+					"project-id",
+				).Return(nil, nil)
+				service.storage.(*repository.MockProjectSourceCodeStorageFactory).On(
+					"Get",
+					"local",
+				).Return(projectSourceCodeStorer)
+				projectSourceCodeStorer.On(
+					"Store",
+					mock.AnythingOfType("*entity.Project"),
+					fileReader,
+				).Run(func(args mock.Arguments) {
+					args.Get(0).(*entity.Project).Digest = "sha256:dfb84009b9e13d54974a044dd35e6b03bb9b53763f2a5cdebebf94ee55f7c000"
+				}).Return(nil)
+				projectSourceCodeStorer.On(
+					"Delete",
+					mock.AnythingOfType("*entity.Project"),
+				).Return(nil)
+			},
+			assertFunc: func(t *testing.T, service *CreateProjectService) bool {
+				return service.repository.(*repository.MockProjectRepository).AssertNotCalled(t, "SafeStore", mock.Anything, mock.Anything)
+			},
+		},
+		{
+			desc:                 "Testing error creating a project on the CreateProjectService when the expected digest is not valid",
+			format:               "targz",
+			storage:              "local",
+			projectContentReader: fileReader,
+			projectID:            "project-id",
+			expectedDigest:       "md5:invalid",
+			err:                  fmt.Errorf("%s: %s: %s", ErrInvalidProjectDigest, entity.ErrInvalidProjectDigest, "md5:invalid"),
+			service: NewCreateProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			),
+		},
+		{
+			desc:                 "Testing create a signed project on the CreateProjectService",
+			format:               "targz",
+			storage:              "local",
+			projectContentReader: fileReader,
+			projectID:            "project-id",
+			signature:            "c2lnbmF0dXJl",
+			service: NewCreateProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			),
+			arrangeFunc: func(t *testing.T, service *CreateProjectService) {
+				projectSourceCodeStorer := repository.NewMockProjectSourceCodeStorer()
+				project := storedProject(&entity.Project{
+					Format:    "targz",
+					Name:      "project-id",
+					Reference: "project-id.tar.gz",
+					Signature: "c2lnbmF0dXJl",
+					Storage:   "local",
+					Version:   "v1",
+				})
 
-			if test.arrangeFunc != nil {
-				test.arrangeFunc(t, test.service)
-			}
+				service.repository.(*repository.MockProjectRepository).On(
+					"Find",
+					"project-id",
+				).Return(nil, nil)
+				service.storage.(*repository.MockProjectSourceCodeStorageFactory).On(
+					"Get",
+					"local",
+				).Return(projectSourceCodeStorer)
+				projectSourceCodeStorer.On(
+					"Store",
+					project,
+					fileReader,
+				).Return(nil)
+				service.repository.(*repository.MockProjectRepository).On(
+					"SafeStore",
+					"project-id",
+					project,
+				).Return(nil)
+			},
+		},
+		{
+			desc:                 "Testing error creating a project on the CreateProjectService when the signature is not valid",
+			format:               "targz",
+			storage:              "local",
+			projectContentReader: fileReader,
+			projectID:            "project-id",
+			signature:            "not base64!",
+			err:                  fmt.Errorf("%s: %s: %s", ErrInvalidProjectSignature, entity.ErrInvalidProjectSignature, "illegal base64 data at input byte 3"),
+			service: NewCreateProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			),
+		},
+		{
+			desc:                 "Testing create a project on the CreateProjectService detecting the project format from its content",
+			format:               "auto",
+			storage:              "local",
+			projectID:            "project-id",
+			projectVersion:       "v1.0.0",
+			projectContentReader: strings.NewReader("PK\x03\x04zip content for testing"),
+			err:                  nil,
+			service: NewCreateProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			),
+			arrangeFunc: func(t *testing.T, service *CreateProjectService) {
+				projectSourceCodeStorer := repository.NewMockProjectSourceCodeStorer()
+				project := storedProject(&entity.Project{
+					Name:      "project-id",
+					Version:   "v1.0.0",
+					Format:    "zip",
+					Storage:   "local",
+					Reference: "project-id.zip",
+				})
 
-			err := test.service.Create(test.format, test.storage, test.projectID, test.projectVersion, test.projectContentReader)
-			if err != nil && test.err != nil {
-				assert.Equal(t, test.err, err)
-			} else {
-				assert.Nil(t, err, "unexpected error received")
-				assert.Nil(t, test.err, "no error received when an error was expected")
+				service.repository.(*repository.MockProjectRepository).On("Find", "project-id").Return(nil, nil)
+				service.storage.(*repository.MockProjectSourceCodeStorageFactory).On("Get", "local").Return(projectSourceCodeStorer)
+				service.repository.(*repository.MockProjectRepository).On("SafeStore", "project-id", project).Return(nil)
+				projectSourceCodeStorer.On("Store", project, mock.MatchedBy(func(r io.Reader) bool {
+					content, err := io.ReadAll(r)
+					return err == nil && string(content) == "PK\x03\x04zip content for testing"
+				})).Return(nil)
+			},
+			assertFunc: func(t *testing.T, service *CreateProjectService) bool {
+				return service.repository.(*repository.MockProjectRepository).AssertExpectations(t)
+			},
+		},
+		{
+			desc:                 "Testing error creating a project on the CreateProjectService when the project format cannot be detected from its content",
+			format:               "auto",
+			storage:              "local",
+			projectID:            "project-id",
+			projectVersion:       "v1.0.0",
+			projectContentReader: strings.NewReader("- hosts: all"),
+			err: domainerror.NewProjectInvalidFormatError(
+				fmt.Errorf("%s: %s", ErrProjectFormatNotDetected, "unable to detect the project format"),
+			),
+			service: NewCreateProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			),
+		},
+		{
+			desc:                 "Testing create a project on the CreateProjectService uploading an OCI image layout",
+			format:               "oci",
+			storage:              "local",
+			projectID:            "project-id",
+			projectVersion:       "v1.0.0",
+			projectContentReader: strings.NewReader("oci layout for testing"),
+			err:                  nil,
+			service: NewCreateProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			).WithOCIResolver(repository.NewMockProjectSourceCodeOCIResolver()),
+			arrangeFunc: func(t *testing.T, service *CreateProjectService) {
+				projectSourceCodeStorer := repository.NewMockProjectSourceCodeStorer()
+				project := storedProject(&entity.Project{
+					Name:      "project-id",
+					Version:   "v1.0.0",
+					Format:    "oci",
+					OCI:       entity.NewProjectOCISource("", "sha256:digest"),
+					Storage:   "local",
+					Reference: "project-id.oci.tar",
+				})
 
-				if test.assertFunc != nil {
-					assert.True(t, test.assertFunc(t, test.service))
-				}
-			}
-		})
-	}
+				service.ociResolver.(*repository.MockProjectSourceCodeOCIResolver).On("ResolveLayout", mock.MatchedBy(func(r io.Reader) bool {
+					// the resolver reads the layout, so the service must rewind it before storing it
+					_, err := io.ReadAll(r)
+					return err == nil
+				})).Return("sha256:digest", nil)
+				service.repository.(*repository.MockProjectRepository).On("Find", "project-id").Return(nil, nil)
+				service.storage.(*repository.MockProjectSourceCodeStorageFactory).On("Get", "local").Return(projectSourceCodeStorer)
+				service.repository.(*repository.MockProjectRepository).On("SafeStore", "project-id", project).Return(nil)
+				projectSourceCodeStorer.On("Store", project, mock.MatchedBy(func(r io.Reader) bool {
+					content, err := io.ReadAll(r)
+					return err == nil && string(content) == "oci layout for testing"
+				})).Return(nil)
+			},
+			assertFunc: func(t *testing.T, service *CreateProjectService) bool {
+				return service.repository.(*repository.MockProjectRepository).AssertExpectations(t)
+			},
+		},
+		{
+			desc:                 "Testing error creating a project on the CreateProjectService uploading an invalid OCI image layout",
+			format:               "oci",
+			storage:              "local",
+			projectID:            "project-id",
+			projectVersion:       "v1.0.0",
+			projectContentReader: strings.NewReader("content for testing"),
+			err: domainerror.NewProjectInvalidFormatError(
+				fmt.Errorf("%s: %s", ErrInvalidProjectOCILayout, "invalid OCI image layout"),
+			),
+			service: NewCreateProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			).WithOCIResolver(repository.NewMockProjectSourceCodeOCIResolver()),
+			arrangeFunc: func(t *testing.T, service *CreateProjectService) {
+				service.ociResolver.(*repository.MockProjectSourceCodeOCIResolver).On("ResolveLayout", mock.Anything).Return("", fmt.Errorf("invalid OCI image layout"))
+				service.repository.(*repository.MockProjectRepository).On("Find", "project-id").Return(nil, nil)
+				service.storage.(*repository.MockProjectSourceCodeStorageFactory).On("Get", "local").Return(repository.NewMockProjectSourceCodeStorer())
+			},
+		},
+		{
+			desc:                 "Testing error creating a project on the CreateProjectService uploading an OCI image layout when the OCI resolver is not initialized",
+			format:               "oci",
+			storage:              "local",
+			projectID:            "project-id",
+			projectVersion:       "v1.0.0",
+			projectContentReader: strings.NewReader("oci layout for testing"),
+			err:                  fmt.Errorf(ErrOCIResolverNotInitialized),
+			service: NewCreateProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			),
+			arrangeFunc: func(t *testing.T, service *CreateProjectService) {
+				service.repository.(*repository.MockProjectRepository).On("Find", "project-id").Return(nil, nil)
+				service.storage.(*repository.MockProjectSourceCodeStorageFactory).On("Get", "local").Return(repository.NewMockProjectSourceCodeStorer())
+			},
+		},
+		{
+			desc:                 "Testing error creating a project on the CreateProjectService uploading its source code to an OCI registry storage",
+			format:               "oci",
+			storage:              "oci",
+			projectID:            "project-id",
+			projectVersion:       "v1.0.0",
+			projectContentReader: strings.NewReader("oci layout for testing"),
+			err:                  fmt.Errorf("%s: %s", ErrProjectStorageNotSupported, "oci projects must be created from their registry"),
+			service: NewCreateProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			),
+		},
+		{
+			desc:                 "Testing create a project on the CreateProjectService within the archive limits",
+			format:               "targz",
+			storage:              "local",
+			projectID:            "project-id",
+			projectVersion:       "v1.0.0",
+			projectContentReader: strings.NewReader("content for testing"),
+			err:                  nil,
+			service: NewCreateProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			).WithArchiveLimits(&entity.ProjectArchiveLimits{MaxUploadSize: 100}).WithArchiveInspector(repository.NewMockProjectSourceCodeArchiveInspector()),
+			arrangeFunc: func(t *testing.T, service *CreateProjectService) {
+				projectSourceCodeStorer := repository.NewMockProjectSourceCodeStorer()
+				project := storedProject(&entity.Project{
+					Name:      "project-id",
+					Version:   "v1.0.0",
+					Format:    "targz",
+					Storage:   "local",
+					Reference: "project-id.tar.gz",
+				})
+
+				service.archiveInspector.(*repository.MockProjectSourceCodeArchiveInspector).On("Inspect", "targz", mock.Anything, int64(19), service.archiveLimits).Return(nil)
+				service.repository.(*repository.MockProjectRepository).On("Find", "project-id").Return(nil, nil)
+				service.storage.(*repository.MockProjectSourceCodeStorageFactory).On("Get", "local").Return(projectSourceCodeStorer)
+				service.repository.(*repository.MockProjectRepository).On("SafeStore", "project-id", project).Return(nil)
+				projectSourceCodeStorer.On("Store", project, mock.MatchedBy(func(r io.Reader) bool {
+					// the inspected content must be rewound before storing it
+					content, err := io.ReadAll(r)
+					return err == nil && string(content) == "content for testing"
+				})).Return(nil)
+			},
+			assertFunc: func(t *testing.T, service *CreateProjectService) bool {
+				return service.archiveInspector.(*repository.MockProjectSourceCodeArchiveInspector).AssertExpectations(t)
+			},
+		},
+		{
+			desc:                 "Testing error creating a project on the CreateProjectService when the upload size exceeds the maximum",
+			format:               "targz",
+			storage:              "local",
+			projectID:            "project-id",
+			projectVersion:       "v1.0.0",
+			projectContentReader: strings.NewReader("content for testing"),
+			err: domainerror.NewProjectTooLargeError(
+				fmt.Errorf("%s: %s", ErrInspectingProjectContent, "project upload size exceeded: the size of 19 bytes exceeds the maximum of 10 bytes"),
+			),
+			service: NewCreateProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			).WithArchiveLimits(&entity.ProjectArchiveLimits{MaxUploadSize: 10}).WithArchiveInspector(repository.NewMockProjectSourceCodeArchiveInspector()),
+			arrangeFunc: func(t *testing.T, service *CreateProjectService) {
+				service.archiveInspector.(*repository.MockProjectSourceCodeArchiveInspector).On("Inspect", "targz", mock.Anything, int64(19), service.archiveLimits).Return(
+					fmt.Errorf("%w: the size of 19 bytes exceeds the maximum of 10 bytes", entity.ErrProjectUploadSizeExceeded),
+				)
+			},
+		},
+		{
+			desc:                 "Testing error creating a project on the CreateProjectService when the content exceeds the archive limits",
+			format:               "targz",
+			storage:              "local",
+			projectID:            "project-id",
+			projectVersion:       "v1.0.0",
+			projectContentReader: strings.NewReader("content for testing"),
+			err: domainerror.NewProjectArchiveLimitExceededError(
+				fmt.Errorf("%s: %s", ErrInspectingProjectContent, "project archive limit exceeded: the number of entries exceeds the maximum of 1"),
+			),
+			service: NewCreateProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			).WithArchiveLimits(&entity.ProjectArchiveLimits{MaxEntries: 1}).WithArchiveInspector(repository.NewMockProjectSourceCodeArchiveInspector()),
+			arrangeFunc: func(t *testing.T, service *CreateProjectService) {
+				service.archiveInspector.(*repository.MockProjectSourceCodeArchiveInspector).On("Inspect", "targz", mock.Anything, int64(19), service.archiveLimits).Return(
+					fmt.Errorf("%w: the number of entries exceeds the maximum of 1", entity.ErrProjectArchiveLimitExceeded),
+				)
+			},
+		},
+		{
+			desc:                 "Testing error creating a project on the CreateProjectService when the content cannot be inspected",
+			format:               "targz",
+			storage:              "local",
+			projectID:            "project-id",
+			projectVersion:       "v1.0.0",
+			projectContentReader: strings.NewReader("content for testing"),
+			err: domainerror.NewProjectInvalidFormatError(
+				fmt.Errorf("%s: %s", ErrInspectingProjectContent, "gzip: invalid header"),
+			),
+			service: NewCreateProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			).WithArchiveLimits(&entity.ProjectArchiveLimits{MaxEntries: 1}).WithArchiveInspector(repository.NewMockProjectSourceCodeArchiveInspector()),
+			arrangeFunc: func(t *testing.T, service *CreateProjectService) {
+				service.archiveInspector.(*repository.MockProjectSourceCodeArchiveInspector).On("Inspect", "targz", mock.Anything, int64(19), service.archiveLimits).Return(
+					fmt.Errorf("gzip: invalid header"),
+				)
+			},
+		},
+		{
+			desc:                 "Testing error creating a project on the CreateProjectService with archive limits when the archive inspector is not initialized",
+			format:               "targz",
+			storage:              "local",
+			projectID:            "project-id",
+			projectVersion:       "v1.0.0",
+			projectContentReader: strings.NewReader("content for testing"),
+			err:                  fmt.Errorf(ErrArchiveInspectorNotInitialized),
+			service: NewCreateProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			).WithArchiveLimits(&entity.ProjectArchiveLimits{MaxEntries: 1}),
+		},
+		{
+			desc:                 "Testing error creating a project on the CreateProjectService with archive limits when the content cannot be rewound",
+			format:               "targz",
+			storage:              "local",
+			projectID:            "project-id",
+			projectVersion:       "v1.0.0",
+			projectContentReader: fileReader,
+			err:                  fmt.Errorf(ErrProjectContentNotSeekable),
+			service: NewCreateProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			).WithArchiveLimits(&entity.ProjectArchiveLimits{MaxEntries: 1}).WithArchiveInspector(repository.NewMockProjectSourceCodeArchiveInspector()),
+		},
+		{
+			desc:                 "Testing create a project on the CreateProjectService discovering its contents",
+			format:               "targz",
+			storage:              "local",
+			projectID:            "project-id",
+			projectVersion:       "v1.0.0",
+			projectContentReader: fileReader,
+			service: NewCreateProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			).WithDiscoverer(repository.NewMockProjectSourceCodeDiscoverer()),
+			arrangeFunc: func(t *testing.T, service *CreateProjectService) {
+				projectSourceCodeStorer := repository.NewMockProjectSourceCodeStorer()
+				contents := &entity.ProjectContents{
+					Inventories: []string{"inventory"},
+					Playbooks:   []string{"site.yml"},
+				}
+
+				service.repository.(*repository.MockProjectRepository).On("Find", "project-id").Return(nil, nil)
+				service.storage.(*repository.MockProjectSourceCodeStorageFactory).On("Get", "local").Return(projectSourceCodeStorer)
+				projectSourceCodeStorer.On("Store", mock.Anything, fileReader).Return(nil)
+				service.discoverer.(*repository.MockProjectSourceCodeDiscoverer).On("Discover", mock.Anything).Return(contents, nil)
+				service.repository.(*repository.MockProjectRepository).On(
+					"SafeStore",
+					"project-id",
+					storedProject(&entity.Project{
+						Name:      "project-id",
+						Version:   "v1.0.0",
+						Format:    "targz",
+						Storage:   "local",
+						Reference: "project-id.tar.gz",
+						Contents:  contents,
+					}),
+				).Return(nil)
+			},
+			assertFunc: func(t *testing.T, service *CreateProjectService) bool {
+				return service.repository.(*repository.MockProjectRepository).AssertExpectations(t) &&
+					service.discoverer.(*repository.MockProjectSourceCodeDiscoverer).AssertExpectations(t)
+			},
+		},
+		{
+			desc:                 "Testing create a project on the CreateProjectService holding a manifest",
+			format:               "targz",
+			storage:              "local",
+			projectID:            "project-id",
+			projectVersion:       "v1.0.0",
+			projectContentReader: fileReader,
+			service: NewCreateProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			).WithDiscoverer(repository.NewMockProjectSourceCodeDiscoverer()),
+			arrangeFunc: func(t *testing.T, service *CreateProjectService) {
+				projectSourceCodeStorer := repository.NewMockProjectSourceCodeStorer()
+
+				service.repository.(*repository.MockProjectRepository).On("Find", "project-id").Return(nil, nil)
+				service.storage.(*repository.MockProjectSourceCodeStorageFactory).On("Get", "local").Return(projectSourceCodeStorer)
+				projectSourceCodeStorer.On("Store", mock.Anything, fileReader).Return(nil)
+				service.discoverer.(*repository.MockProjectSourceCodeDiscoverer).On("Discover", mock.Anything).Return(&entity.ProjectContents{
+					Files:       []string{"inventory/hosts.yml", "site.yml"},
+					Manifest:    []byte("playbooks: [site.yml]\ndefaults:\n  inventory: inventory"),
+					Inventories: []string{"inventory"},
+					Playbooks:   []string{"site.yml"},
+				}, nil)
+				service.repository.(*repository.MockProjectRepository).On(
+					"SafeStore",
+					"project-id",
+					storedProject(&entity.Project{
+						Name:      "project-id",
+						Version:   "v1.0.0",
+						Format:    "targz",
+						Storage:   "local",
+						Reference: "project-id.tar.gz",
+						Contents: &entity.ProjectContents{
+							Files:       []string{"inventory/hosts.yml", "site.yml"},
+							Inventories: []string{"inventory"},
+							Playbooks:   []string{"site.yml"},
+						},
+						Manifest: &entity.ProjectManifest{
+							Defaults:  &entity.ProjectManifestDefaults{Inventory: "inventory"},
+							Playbooks: []string{"site.yml"},
+						},
+					}),
+				).Return(nil)
+			},
+			assertFunc: func(t *testing.T, service *CreateProjectService) bool {
+				return service.repository.(*repository.MockProjectRepository).AssertExpectations(t)
+			},
+		},
+		{
+			desc:                 "Testing an error creating a project on the CreateProjectService holding a manifest that declares a playbook that is not in the project",
+			format:               "targz",
+			storage:              "local",
+			projectID:            "project-id",
+			projectVersion:       "v1.0.0",
+			projectContentReader: fileReader,
+			err: domainerror.NewProjectInvalidManifestError(
+				fmt.Errorf("%s: %s", ErrValidatingProjectManifest, fmt.Errorf("%w: %w", entity.ErrInvalidProjectManifest, fmt.Errorf("%w: %s", entity.ErrProjectPlaybookNotFound, "deploy.yml")).Error()),
+			),
+			service: NewCreateProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			).WithDiscoverer(repository.NewMockProjectSourceCodeDiscoverer()),
+			arrangeFunc: func(t *testing.T, service *CreateProjectService) {
+				projectSourceCodeStorer := repository.NewMockProjectSourceCodeStorer()
+
+				service.repository.(*repository.MockProjectRepository).On("Find", "project-id").Return(nil, nil)
+				service.storage.(*repository.MockProjectSourceCodeStorageFactory).On("Get", "local").Return(projectSourceCodeStorer)
+				projectSourceCodeStorer.On("Store", mock.Anything, fileReader).Return(nil)
+				projectSourceCodeStorer.On("Delete", mock.Anything).Return(nil)
+				service.discoverer.(*repository.MockProjectSourceCodeDiscoverer).On("Discover", mock.Anything).Return(&entity.ProjectContents{
+					Files:     []string{"site.yml"},
+					Manifest:  []byte("playbooks: [deploy.yml]"),
+					Playbooks: []string{"site.yml"},
+				}, nil)
+			},
+		},
+		{
+			desc:                 "Testing create a project on the CreateProjectService when its contents cannot be discovered",
+			format:               "targz",
+			storage:              "local",
+			projectID:            "project-id",
+			projectVersion:       "v1.0.0",
+			projectContentReader: fileReader,
+			service: NewCreateProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			).WithDiscoverer(repository.NewMockProjectSourceCodeDiscoverer()),
+			arrangeFunc: func(t *testing.T, service *CreateProjectService) {
+				projectSourceCodeStorer := repository.NewMockProjectSourceCodeStorer()
+
+				service.repository.(*repository.MockProjectRepository).On("Find", "project-id").Return(nil, nil)
+				service.storage.(*repository.MockProjectSourceCodeStorageFactory).On("Get", "local").Return(projectSourceCodeStorer)
+				projectSourceCodeStorer.On("Store", mock.Anything, fileReader).Return(nil)
+				service.discoverer.(*repository.MockProjectSourceCodeDiscoverer).On("Discover", mock.Anything).Return(nil, errors.New("error unpacking source code"))
+				service.repository.(*repository.MockProjectRepository).On(
+					"SafeStore",
+					"project-id",
+					storedProject(&entity.Project{
+						Name:      "project-id",
+						Version:   "v1.0.0",
+						Format:    "targz",
+						Storage:   "local",
+						Reference: "project-id.tar.gz",
+					}),
+				).Return(nil)
+			},
+			assertFunc: func(t *testing.T, service *CreateProjectService) bool {
+				return service.repository.(*repository.MockProjectRepository).AssertExpectations(t)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			if test.arrangeFunc != nil {
+				test.arrangeFunc(t, test.service)
+			}
+
+			_, err := test.service.Create(&entity.CreateProjectRequest{
+				Digest:    test.expectedDigest,
+				Format:    test.format,
+				Mode:      entity.CreateProjectModeProject,
+				ProjectID: test.projectID,
+				Signature: test.signature,
+				Storage:   test.storage,
+				Version:   test.projectVersion,
+			}, test.projectContentReader)
+			if err != nil && test.err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.Nil(t, err, "unexpected error received")
+				assert.Nil(t, test.err, "no error received when an error was expected")
+
+				if test.assertFunc != nil {
+					assert.True(t, test.assertFunc(t, test.service))
+				}
+			}
+		})
+	}
+}
+
+func TestCreateProjectService_CreateInvalidRequest(t *testing.T) {
+	tests := []struct {
+		desc    string
+		service *CreateProjectService
+		request *entity.CreateProjectRequest
+		err     error
+	}{
+		{
+			desc: "Testing an error creating a project on the CreateProjectService when the request is not provided",
+			service: NewCreateProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			),
+			request: nil,
+			err:     fmt.Errorf(ErrCreateProjectRequestNotProvided),
+		},
+		{
+			desc: "Testing an error creating a project on the CreateProjectService when the mode of the request is not valid",
+			service: NewCreateProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			),
+			request: &entity.CreateProjectRequest{
+				Format:    "targz",
+				Mode:      "unknown",
+				ProjectID: "project-id",
+				Storage:   "local",
+			},
+			err: fmt.Errorf("%s: %s", ErrInvalidCreateProjectMode, "unknown"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			_, err := test.service.Create(test.request, strings.NewReader("content for testing"))
+			assert.Equal(t, test.err, err)
+
+			_, err = test.service.CreateFromUpload(test.request, "upload-id")
+			assert.Equal(t, test.err, err)
+		})
+	}
+}
+
+func TestCreateProjectService_CreateFromGit(t *testing.T) {
+
+	tests := []struct {
+		arrangeFunc    func(*testing.T, *CreateProjectService)
+		desc           string
+		err            error
+		mode           string
+		projectID      string
+		projectVersion string
+		service        *CreateProjectService
+		source         *entity.ProjectGitSource
+	}{
+		{
+			desc:           "Testing create a project stored in a git repository on the CreateProjectService",
+			projectID:      "project-id",
+			projectVersion: "v1.0.0",
+			source:         entity.NewProjectGitSource("https://example.com/project.git", "main", "ansible"),
+			service: NewCreateProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			),
+			arrangeFunc: func(t *testing.T, service *CreateProjectService) {
+				service.repository.(*repository.MockProjectRepository).On(
+					"Find",
+					"project-id",
+				).Return(nil, nil)
+				service.repository.(*repository.MockProjectRepository).On(
+					"SafeStore",
+					"project-id",
+					&entity.Project{
+						Format:    "plain",
+						Git:       entity.NewProjectGitSource("https://example.com/project.git", "main", "ansible"),
+						Name:      "project-id",
+						Reference: "https://example.com/project.git",
+						Storage:   "git",
+						Version:   "v1.0.0",
+					},
+				).Return(nil)
+			},
+		},
+		{
+			desc:      "Testing an error creating a project stored in a git repository on the CreateProjectService when the project id is not provided",
+			projectID: "",
+			source:    entity.NewProjectGitSource("https://example.com/project.git", "", ""),
+			err: domainerror.NewProjectIDNotProvidedError(
+				fmt.Errorf(ErrProjectIDNotProvided),
+			),
+			service: NewCreateProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			),
+		},
+		{
+			desc:      "Testing an error replacing a project stored in a git repository on the CreateProjectService",
+			mode:      entity.CreateProjectModeReplace,
+			projectID: "project-id",
+			source:    entity.NewProjectGitSource("https://example.com/project.git", "", ""),
+			err: domainerror.NewProjectInvalidSourceError(
+				fmt.Errorf(ErrProjectSourceCodeNotReplaceable),
+			),
+			service: NewCreateProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			),
+		},
+		{
+			desc:      "Testing an error creating a project stored in a git repository on the CreateProjectService when the git repository is not provided",
+			projectID: "project-id",
+			err:       fmt.Errorf(ErrProjectGitSourceNotProvided),
+			service: NewCreateProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			),
+		},
+		{
+			desc:      "Testing an error creating a project stored in a git repository on the CreateProjectService when the project repository is not initialized",
+			projectID: "project-id",
+			source:    entity.NewProjectGitSource("https://example.com/project.git", "", ""),
+			err:       fmt.Errorf(ErrProjectRepositoryNotInitialized),
+			service: NewCreateProjectService(
+				nil,
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			),
+		},
+		{
+			desc:      "Testing an error creating a project stored in a git repository on the CreateProjectService when the project already exists",
+			projectID: "project-id",
+			source:    entity.NewProjectGitSource("https://example.com/project.git", "", ""),
+			err: domainerror.NewProjectAlreadyExistsError(
+				fmt.Errorf(ErrProjectAlreadyExists),
+			),
+			service: NewCreateProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			),
+			arrangeFunc: func(t *testing.T, service *CreateProjectService) {
+				service.repository.(*repository.MockProjectRepository).On(
+					"Find",
+					"project-id",
+				).Return(&entity.Project{Name: "project-id"}, nil)
+			},
+		},
+		{
+			desc:      "Testing an error creating a project stored in a git repository on the CreateProjectService when storing the project fails",
+			projectID: "project-id",
+			source:    entity.NewProjectGitSource("https://example.com/project.git", "", ""),
+			err:       fmt.Errorf("%s: %s", ErrStoringProject, "testing error"),
+			service: NewCreateProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			),
+			arrangeFunc: func(t *testing.T, service *CreateProjectService) {
+				service.repository.(*repository.MockProjectRepository).On(
+					"Find",
+					"project-id",
+				).Return(nil, nil)
+				service.repository.(*repository.MockProjectRepository).On(
+					"SafeStore",
+					"project-id",
+					&entity.Project{
+						Format:    "plain",
+						Git:       entity.NewProjectGitSource("https://example.com/project.git", "", ""),
+						Name:      "project-id",
+						Reference: "https://example.com/project.git",
+						Storage:   "git",
+						Version:   "v1",
+					},
+				).Return(fmt.Errorf("testing error"))
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			if test.arrangeFunc != nil {
+				test.arrangeFunc(t, test.service)
+			}
+
+			_, err := test.service.CreateFromGit(&entity.CreateProjectRequest{
+				Mode:      test.mode,
+				ProjectID: test.projectID,
+				Version:   test.projectVersion,
+			}, test.source)
+			if test.err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.NoError(t, err)
+				test.service.repository.(*repository.MockProjectRepository).AssertExpectations(t)
+			}
+		})
+	}
+}
+
+func TestCreateProjectService_CreateFromOCI(t *testing.T) {
+
+	tests := []struct {
+		arrangeFunc    func(*testing.T, *CreateProjectService)
+		desc           string
+		err            error
+		mode           string
+		projectID      string
+		projectVersion string
+		service        *CreateProjectService
+		source         *entity.ProjectOCISource
+	}{
+		{
+			desc:           "Testing create a project stored in an OCI registry on the CreateProjectService",
+			projectID:      "project-id",
+			projectVersion: "v1.0.0",
+			source:         entity.NewProjectOCISource("registry.example.com/project:v1.0.0", ""),
+			service: NewCreateProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			).WithOCIResolver(repository.NewMockProjectSourceCodeOCIResolver()),
+			arrangeFunc: func(t *testing.T, service *CreateProjectService) {
+				service.repository.(*repository.MockProjectRepository).On("Find", "project-id").Return(nil, nil)
+				service.ociResolver.(*repository.MockProjectSourceCodeOCIResolver).On("ResolveReference", "registry.example.com/project:v1.0.0").Return("sha256:digest", nil)
+				service.repository.(*repository.MockProjectRepository).On(
+					"SafeStore",
+					"project-id",
+					&entity.Project{
+						Format:    "oci",
+						Name:      "project-id",
+						OCI:       entity.NewProjectOCISource("registry.example.com/project:v1.0.0", "sha256:digest"),
+						Reference: "project-id.oci.tar",
+						Storage:   "oci",
+						Version:   "v1.0.0",
+					},
+				).Return(nil)
+			},
+		},
+		{
+			desc:           "Testing create a new version of a project stored in an OCI registry on the CreateProjectService",
+			mode:           entity.CreateProjectModeVersion,
+			projectID:      "project-id",
+			projectVersion: "v2.0.0",
+			source:         entity.NewProjectOCISource("registry.example.com/project:v2.0.0", ""),
+			service: NewCreateProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			).WithOCIResolver(repository.NewMockProjectSourceCodeOCIResolver()),
+			arrangeFunc: func(t *testing.T, service *CreateProjectService) {
+				service.repository.(*repository.MockProjectRepository).On("Find", "project-id").Return(&entity.Project{Name: "project-id"}, nil)
+				service.repository.(*repository.MockProjectRepository).On("FindVersion", "project-id", "v2.0.0").Return(nil, nil)
+				service.ociResolver.(*repository.MockProjectSourceCodeOCIResolver).On("ResolveReference", "registry.example.com/project:v2.0.0").Return("sha256:digest", nil)
+				service.repository.(*repository.MockProjectRepository).On(
+					"SafeStoreVersion",
+					"project-id",
+					&entity.Project{
+						Format:    "oci",
+						Name:      "project-id",
+						OCI:       entity.NewProjectOCISource("registry.example.com/project:v2.0.0", "sha256:digest"),
+						Reference: "project-id@v2.0.0.oci.tar",
+						Storage:   "oci",
+						Version:   "v2.0.0",
+					},
+				).Return(nil)
+			},
+		},
+		{
+			desc:      "Testing an error replacing a project stored in an OCI registry on the CreateProjectService",
+			mode:      entity.CreateProjectModeReplace,
+			projectID: "project-id",
+			source:    entity.NewProjectOCISource("registry.example.com/project:v1.0.0", ""),
+			err: domainerror.NewProjectInvalidSourceError(
+				fmt.Errorf(ErrProjectSourceCodeNotReplaceable),
+			),
+			service: NewCreateProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			),
+		},
+		{
+			desc:      "Testing an error creating a project stored in an OCI registry on the CreateProjectService when the artifact is not provided",
+			projectID: "project-id",
+			err:       fmt.Errorf(ErrProjectOCISourceNotProvided),
+			service: NewCreateProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			).WithOCIResolver(repository.NewMockProjectSourceCodeOCIResolver()),
+		},
+		{
+			desc:      "Testing an error creating a project stored in an OCI registry on the CreateProjectService when the OCI resolver is not initialized",
+			projectID: "project-id",
+			source:    entity.NewProjectOCISource("registry.example.com/project:v1.0.0", ""),
+			err:       fmt.Errorf(ErrOCIResolverNotInitialized),
+			service: NewCreateProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			),
+		},
+		{
+			desc:      "Testing an error creating a project stored in an OCI registry on the CreateProjectService when the reference cannot be resolved",
+			projectID: "project-id",
+			source:    entity.NewProjectOCISource("registry.example.com/project:unknown", ""),
+			err: domainerror.NewProjectInvalidSourceError(
+				fmt.Errorf("%s: %s", ErrResolvingProjectOCIReference, "manifest not found"),
+			),
+			service: NewCreateProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			).WithOCIResolver(repository.NewMockProjectSourceCodeOCIResolver()),
+			arrangeFunc: func(t *testing.T, service *CreateProjectService) {
+				service.repository.(*repository.MockProjectRepository).On("Find", "project-id").Return(nil, nil)
+				service.ociResolver.(*repository.MockProjectSourceCodeOCIResolver).On("ResolveReference", "registry.example.com/project:unknown").Return("", fmt.Errorf("manifest not found"))
+			},
+		},
+		{
+			desc:      "Testing an error creating a project stored in an OCI registry on the CreateProjectService when the project already exists",
+			projectID: "project-id",
+			source:    entity.NewProjectOCISource("registry.example.com/project:v1.0.0", ""),
+			err: domainerror.NewProjectAlreadyExistsError(
+				fmt.Errorf(ErrProjectAlreadyExists),
+			),
+			service: NewCreateProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			).WithOCIResolver(repository.NewMockProjectSourceCodeOCIResolver()),
+			arrangeFunc: func(t *testing.T, service *CreateProjectService) {
+				service.repository.(*repository.MockProjectRepository).On("Find", "project-id").Return(&entity.Project{Name: "project-id"}, nil)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			if test.arrangeFunc != nil {
+				test.arrangeFunc(t, test.service)
+			}
+
+			_, err := test.service.CreateFromOCI(&entity.CreateProjectRequest{
+				Mode:      test.mode,
+				ProjectID: test.projectID,
+				Version:   test.projectVersion,
+			}, test.source)
+			if test.err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.NoError(t, err)
+				test.service.repository.(*repository.MockProjectRepository).AssertExpectations(t)
+			}
+		})
+	}
+}
+
+func TestCreateProjectService_CreateVersion(t *testing.T) {
+
+	fileReader := io.NopCloser(strings.NewReader("content for testing"))
+
+	tests := []struct {
+		arrangeFunc    func(*testing.T, *CreateProjectService)
+		desc           string
+		err            error
+		format         string
+		projectID      string
+		projectVersion string
+		service        *CreateProjectService
+		storage        string
+	}{
+		{
+			desc:           "Testing create a project version on the CreateProjectService",
+			format:         "targz",
+			storage:        "local",
+			projectID:      "project-id",
+			projectVersion: "v2.0.0",
+			service: NewCreateProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			),
+			arrangeFunc: func(t *testing.T, service *CreateProjectService) {
+				projectSourceCodeStorer := repository.NewMockProjectSourceCodeStorer()
+				project := storedProject(entity.NewProject("project-id", "v2.0.0", "project-id@v2.0.0.tar.gz", "targz", "local"))
+
+				service.repository.(*repository.MockProjectRepository).On("Find", "project-id").Return(entity.NewProject("project-id", "v1.0.0", "project-id.tar.gz", "targz", "local"), nil)
+				service.repository.(*repository.MockProjectRepository).On("FindVersion", "project-id", "v2.0.0").Return(nil, fmt.Errorf("version not found"))
+				service.storage.(*repository.MockProjectSourceCodeStorageFactory).On("Get", "local").Return(projectSourceCodeStorer)
+				service.repository.(*repository.MockProjectRepository).On("SafeStoreVersion", "project-id", project).Return(nil)
+				projectSourceCodeStorer.On("Store", project, fileReader).Return(nil)
+			},
+		},
+		{
+			desc:           "Testing an error creating a project version on the CreateProjectService when the version is not provided",
+			format:         "targz",
+			storage:        "local",
+			projectID:      "project-id",
+			projectVersion: "",
+			service: NewCreateProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			),
+			err: domainerror.NewProjectInvalidVersionError(
+				fmt.Errorf("%s: %s", ErrInvalidProjectVersion, ErrProjectVersionNotProvided),
+			),
+		},
+		{
+			desc:           "Testing an error creating a project version on the CreateProjectService when the version is the latest alias",
+			format:         "targz",
+			storage:        "local",
+			projectID:      "project-id",
+			projectVersion: entity.LatestVersion,
+			service: NewCreateProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			),
+			err: domainerror.NewProjectInvalidVersionError(
+				fmt.Errorf("%s: %s: %s", ErrInvalidProjectVersion, ErrProjectVersionReserved, entity.LatestVersion),
+			),
+		},
+		{
+			desc:           "Testing an error creating a project version on the CreateProjectService when the version is not valid",
+			format:         "targz",
+			storage:        "local",
+			projectID:      "project-id",
+			projectVersion: "../v2",
+			service: NewCreateProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			),
+			err: domainerror.NewProjectInvalidVersionError(
+				fmt.Errorf("%s: %s", ErrInvalidProjectVersion, entity.ValidateProjectVersion("../v2").Error()),
+			),
+		},
+		{
+			desc:           "Testing an error creating a project version on the CreateProjectService when the project does not exist",
+			format:         "targz",
+			storage:        "local",
+			projectID:      "project-id",
+			projectVersion: "v2.0.0",
+			service: NewCreateProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			),
+			arrangeFunc: func(t *testing.T, service *CreateProjectService) {
+				service.repository.(*repository.MockProjectRepository).On("Find", "project-id").Return(nil, fmt.Errorf("record not found"))
+			},
+			err: domainerror.NewProjectNotFoundError(
+				fmt.Errorf("%s: %s", ErrFindingProject, "record not found"),
+			),
+		},
+		{
+			desc:           "Testing an error creating a project version on the CreateProjectService when the version already exists",
+			format:         "targz",
+			storage:        "local",
+			projectID:      "project-id",
+			projectVersion: "v1.0.0",
+			service: NewCreateProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			),
+			arrangeFunc: func(t *testing.T, service *CreateProjectService) {
+				project := entity.NewProject("project-id", "v1.0.0", "project-id.tar.gz", "targz", "local")
+				service.repository.(*repository.MockProjectRepository).On("Find", "project-id").Return(project, nil)
+				service.repository.(*repository.MockProjectRepository).On("FindVersion", "project-id", "v1.0.0").Return(project, nil)
+			},
+			err: domainerror.NewProjectAlreadyExistsError(
+				fmt.Errorf(ErrProjectVersionAlreadyExists),
+			),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			if test.arrangeFunc != nil {
+				test.arrangeFunc(t, test.service)
+			}
+
+			_, err := test.service.Create(&entity.CreateProjectRequest{
+				Format:    test.format,
+				Mode:      entity.CreateProjectModeVersion,
+				ProjectID: test.projectID,
+				Storage:   test.storage,
+				Version:   test.projectVersion,
+			}, fileReader)
+			if test.err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.NoError(t, err)
+				test.service.repository.(*repository.MockProjectRepository).AssertExpectations(t)
+			}
+		})
+	}
+}
+
+func TestCreateProjectService_CreateVersionFromGit(t *testing.T) {
+	t.Log("Testing create a version of a project stored in a git repository on the CreateProjectService")
+
+	service := NewCreateProjectService(
+		repository.NewMockProjectRepository(),
+		repository.NewMockProjectSourceCodeStorageFactory(),
+		logger.NewFakeLogger(),
+	)
+
+	project := entity.NewProject("project-id", "v2.0.0", "https://example.com/project.git", entity.ProjectFormatPlain, entity.ProjectTypeGit)
+	project.Git = entity.NewProjectGitSource("https://example.com/project.git", "v2.0.0", "")
+
+	service.repository.(*repository.MockProjectRepository).On("Find", "project-id").Return(entity.NewProject("project-id", "v1.0.0", "https://example.com/project.git", entity.ProjectFormatPlain, entity.ProjectTypeGit), nil)
+	service.repository.(*repository.MockProjectRepository).On("FindVersion", "project-id", "v2.0.0").Return(nil, fmt.Errorf("version not found"))
+	service.repository.(*repository.MockProjectRepository).On("SafeStoreVersion", "project-id", project).Return(nil)
+
+	_, err := service.CreateFromGit(&entity.CreateProjectRequest{
+		Mode:      entity.CreateProjectModeVersion,
+		ProjectID: "project-id",
+		Version:   "v2.0.0",
+	}, entity.NewProjectGitSource("https://example.com/project.git", "v2.0.0", ""))
+	assert.NoError(t, err)
+	service.repository.(*repository.MockProjectRepository).AssertExpectations(t)
+}
+
+func TestCreateProjectService_Replace(t *testing.T) {
+
+	fileReader := io.NopCloser(strings.NewReader("content for testing"))
+
+	// replacingProject matches the project replacing the most recent version of the project, whose source code is stored with a unique reference
+	replacingProject := mock.MatchedBy(func(project *entity.Project) bool {
+		return project.Name == "project-id" &&
+			project.Version == "v1.0.0" &&
+			strings.HasPrefix(project.Reference, "project-id@v1.0.0.") &&
+			strings.HasSuffix(project.Reference, ".tar.gz")
+	})
+
+	tests := []struct {
+		arrangeFunc    func(*testing.T, *CreateProjectService)
+		desc           string
+		err            error
+		expected       string
+		projectVersion string
+		revision       string
+		service        *CreateProjectService
+	}{
+		{
+			desc:     "Testing replace a project on the CreateProjectService removing the replaced source code",
+			revision: "revision-1",
+			expected: "revision-2",
+			service: NewCreateProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			),
+			arrangeFunc: func(t *testing.T, service *CreateProjectService) {
+				projectSourceCodeStorer := repository.NewMockProjectSourceCodeStorer()
+				current := entity.NewProject("project-id", "v1.0.0", "project-id.tar.gz", "targz", "local")
+				current.Revision = "revision-1"
+
+				service.repository.(*repository.MockProjectRepository).On("Find", "project-id").Return(current, nil)
+				service.storage.(*repository.MockProjectSourceCodeStorageFactory).On("Get", "local").Return(projectSourceCodeStorer)
+				projectSourceCodeStorer.On("Store", replacingProject, fileReader).Return(nil)
+				service.repository.(*repository.MockProjectRepository).On("SafeReplace", "project-id", replacingProject, "revision-1").Run(func(args mock.Arguments) {
+					args.Get(1).(*entity.Project).Revision = "revision-2"
+				}).Return(nil)
+				projectSourceCodeStorer.On("Delete", current).Return(nil)
+			},
+		},
+		{
+			desc:           "Testing replace a project on the CreateProjectService providing its most recent version and no revision",
+			projectVersion: "v1.0.0",
+			expected:       "revision-2",
+			service: NewCreateProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			),
+			arrangeFunc: func(t *testing.T, service *CreateProjectService) {
+				projectSourceCodeStorer := repository.NewMockProjectSourceCodeStorer()
+				current := entity.NewProject("project-id", "v1.0.0", "project-id.tar.gz", "targz", "local")
+				current.Revision = "revision-1"
+
+				service.repository.(*repository.MockProjectRepository).On("Find", "project-id").Return(current, nil)
+				service.storage.(*repository.MockProjectSourceCodeStorageFactory).On("Get", "local").Return(projectSourceCodeStorer)
+				projectSourceCodeStorer.On("Store", replacingProject, fileReader).Return(nil)
+				service.repository.(*repository.MockProjectRepository).On("SafeReplace", "project-id", replacingProject, "").Run(func(args mock.Arguments) {
+					args.Get(1).(*entity.Project).Revision = "revision-2"
+				}).Return(nil)
+				projectSourceCodeStorer.On("Delete", current).Return(nil)
+			},
+		},
+		{
+			desc: "Testing an error replacing a project on the CreateProjectService when the project does not exist",
+			service: NewCreateProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			),
+			arrangeFunc: func(t *testing.T, service *CreateProjectService) {
+				service.repository.(*repository.MockProjectRepository).On("Find", "project-id").Return(nil, fmt.Errorf("record not found"))
+			},
+			err: domainerror.NewProjectNotFoundError(
+				fmt.Errorf("%s: %s", ErrFindingProject, "record not found"),
+			),
+		},
+		{
+			desc:           "Testing an error replacing a project on the CreateProjectService when the version is not the most recent one",
+			projectVersion: "v0.1.0",
+			service: NewCreateProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			),
+			arrangeFunc: func(t *testing.T, service *CreateProjectService) {
+				service.repository.(*repository.MockProjectRepository).On("Find", "project-id").Return(entity.NewProject("project-id", "v1.0.0", "project-id.tar.gz", "targz", "local"), nil)
+			},
+			err: domainerror.NewProjectInvalidVersionError(
+				fmt.Errorf("%s: %s", ErrProjectVersionNotReplaceable, "v1.0.0"),
+			),
+		},
+		{
+			desc:     "Testing an error replacing a project on the CreateProjectService when its revision does not match",
+			revision: "revision-0",
+			service: NewCreateProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			),
+			arrangeFunc: func(t *testing.T, service *CreateProjectService) {
+				current := entity.NewProject("project-id", "v1.0.0", "project-id.tar.gz", "targz", "local")
+				current.Revision = "revision-1"
+				service.repository.(*repository.MockProjectRepository).On("Find", "project-id").Return(current, nil)
+			},
+			err: domainerror.NewProjectPreconditionFailedError(
+				fmt.Errorf("%s", ErrProjectRevisionMismatch),
+			),
+		},
+		{
+			desc:     "Testing an error replacing a project on the CreateProjectService when it is modified while its source code is stored",
+			revision: "revision-1",
+			service: NewCreateProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			),
+			arrangeFunc: func(t *testing.T, service *CreateProjectService) {
+				projectSourceCodeStorer := repository.NewMockProjectSourceCodeStorer()
+				current := entity.NewProject("project-id", "v1.0.0", "project-id.tar.gz", "targz", "local")
+				current.Revision = "revision-1"
+
+				service.repository.(*repository.MockProjectRepository).On("Find", "project-id").Return(current, nil)
+				service.storage.(*repository.MockProjectSourceCodeStorageFactory).On("Get", "local").Return(projectSourceCodeStorer)
+				projectSourceCodeStorer.On("Store", replacingProject, fileReader).Return(nil)
+				service.repository.(*repository.MockProjectRepository).On("SafeReplace", "project-id", replacingProject, "revision-1").Return(fmt.Errorf("error replacing project: %w", entity.ErrProjectRevisionMismatch))
+				// the replacing source code is removed, while the replaced one is kept
+				projectSourceCodeStorer.On("Delete", replacingProject).Return(nil)
+			},
+			err: domainerror.NewProjectPreconditionFailedError(
+				fmt.Errorf("%s", ErrProjectRevisionMismatch),
+			),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			if test.arrangeFunc != nil {
+				test.arrangeFunc(t, test.service)
+			}
+
+			revision, err := test.service.Create(&entity.CreateProjectRequest{
+				Format:    "targz",
+				Mode:      entity.CreateProjectModeReplace,
+				ProjectID: "project-id",
+				Revision:  test.revision,
+				Storage:   "local",
+				Version:   test.projectVersion,
+			}, fileReader)
+			if test.err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expected, revision)
+				test.service.repository.(*repository.MockProjectRepository).AssertExpectations(t)
+			}
+		})
+	}
+}
+
+// mockUploadRepository returns the mock repository where the upload service of the CreateProjectService stages the uploads
+func mockUploadRepository(service *CreateProjectService) *repository.MockProjectUploadRepository {
+	return service.uploads.(*ProjectUploadService).repository.(*repository.MockProjectUploadRepository)
+}
+
+func TestCreateProjectService_CreateFromUpload(t *testing.T) {
+
+	uploadContent := newImportSourceContent("content for testing")
+	completeUpload := &entity.ProjectUpload{ID: "upload-id", Length: 19, Offset: 19, ExpiresAt: "2100-01-01T00:00:00Z"}
+
+	tests := []struct {
+		arrangeFunc    func(*testing.T, *CreateProjectService)
+		desc           string
+		err            error
+		projectID      string
+		projectVersion string
+		service        *CreateProjectService
+		uploadID       string
+	}{
+		{
+			desc:           "Testing create a project from an upload on the CreateProjectService",
+			projectID:      "project-id",
+			projectVersion: "v1.0.0",
+			uploadID:       "upload-id",
+			service: NewCreateProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			).WithUploadService(NewProjectUploadService(repository.NewMockProjectUploadRepository(), 0, 0, logger.NewFakeLogger())),
+			arrangeFunc: func(t *testing.T, service *CreateProjectService) {
+				projectSourceCodeStorer := repository.NewMockProjectSourceCodeStorer()
+				project := storedProject(&entity.Project{
+					Name:      "project-id",
+					Version:   "v1.0.0",
+					Format:    "targz",
+					Storage:   "local",
+					Reference: "project-id.tar.gz",
+				})
+
+				mockUploadRepository(service).On("Find", "upload-id").Return(completeUpload, nil)
+				mockUploadRepository(service).On("Open", "upload-id").Return(uploadContent, nil)
+				mockUploadRepository(service).On("Remove", "upload-id").Return(nil)
+				service.repository.(*repository.MockProjectRepository).On("Find", "project-id").Return(nil, nil)
+				service.storage.(*repository.MockProjectSourceCodeStorageFactory).On("Get", "local").Return(projectSourceCodeStorer)
+				service.repository.(*repository.MockProjectRepository).On("SafeStore", "project-id", project).Return(nil)
+				projectSourceCodeStorer.On("Store", project, uploadContent).Return(nil)
+			},
+		},
+		{
+			desc:      "Testing an error creating a project from an upload on the CreateProjectService when the upload service is not initialized",
+			projectID: "project-id",
+			uploadID:  "upload-id",
+			err:       fmt.Errorf(ErrProjectUploadServiceNotInitialized),
+			service: NewCreateProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			),
+		},
+		{
+			desc:      "Testing an error creating a project from an upload on the CreateProjectService when the upload is not found",
+			projectID: "project-id",
+			uploadID:  "upload-id",
+			err: domainerror.NewProjectUploadNotFoundError(
+				fmt.Errorf("%s: %s", ErrFindingProjectUpload, "testing error"),
+			),
+			service: NewCreateProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			).WithUploadService(NewProjectUploadService(repository.NewMockProjectUploadRepository(), 0, 0, logger.NewFakeLogger())),
+			arrangeFunc: func(t *testing.T, service *CreateProjectService) {
+				mockUploadRepository(service).On("Find", "upload-id").Return(nil, errors.New("testing error"))
+			},
+		},
+		{
+			desc:      "Testing an error creating a project from an upload on the CreateProjectService when the upload has expired",
+			projectID: "project-id",
+			uploadID:  "upload-id",
+			err: domainerror.NewProjectUploadNotFoundError(
+				fmt.Errorf("%s: %s", ErrFindingProjectUpload, ErrProjectUploadExpired),
+			),
+			service: NewCreateProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			).WithUploadService(NewProjectUploadService(repository.NewMockProjectUploadRepository(), 0, 0, logger.NewFakeLogger())),
+			arrangeFunc: func(t *testing.T, service *CreateProjectService) {
+				mockUploadRepository(service).On("Find", "upload-id").Return(&entity.ProjectUpload{ID: "upload-id", Length: 19, Offset: 19, ExpiresAt: "2000-01-01T00:00:00Z"}, nil)
+			},
+		},
+		{
+			desc:      "Testing an error creating a project from an upload on the CreateProjectService when the upload is receiving a chunk",
+			projectID: "project-id",
+			uploadID:  "upload-id",
+			err: domainerror.NewProjectUploadConflictError(
+				fmt.Errorf(ErrProjectUploadBusy),
+			),
+			service: NewCreateProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			).WithUploadService(NewProjectUploadService(repository.NewMockProjectUploadRepository(), 0, 0, logger.NewFakeLogger())),
+			arrangeFunc: func(t *testing.T, service *CreateProjectService) {
+				service.uploads.(*ProjectUploadService).lock("upload-id")
+			},
+		},
+		{
+			desc:      "Testing an error creating a project from an upload on the CreateProjectService when the upload is not complete",
+			projectID: "project-id",
+			uploadID:  "upload-id",
+			err: domainerror.NewProjectUploadConflictError(
+				fmt.Errorf("%s: %d of %d bytes received", ErrProjectUploadIncomplete, 10, 19),
+			),
+			service: NewCreateProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			).WithUploadService(NewProjectUploadService(repository.NewMockProjectUploadRepository(), 0, 0, logger.NewFakeLogger())),
+			arrangeFunc: func(t *testing.T, service *CreateProjectService) {
+				mockUploadRepository(service).On("Find", "upload-id").Return(&entity.ProjectUpload{ID: "upload-id", Length: 19, Offset: 10, ExpiresAt: "2100-01-01T00:00:00Z"}, nil)
+			},
+		},
+		{
+			desc:      "Testing an error creating a project from an upload on the CreateProjectService keeps the upload when the project can not be created",
+			projectID: "project-id",
+			uploadID:  "upload-id",
+			err: domainerror.NewProjectAlreadyExistsError(
+				fmt.Errorf(ErrProjectAlreadyExists),
+			),
+			service: NewCreateProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			).WithUploadService(NewProjectUploadService(repository.NewMockProjectUploadRepository(), 0, 0, logger.NewFakeLogger())),
+			arrangeFunc: func(t *testing.T, service *CreateProjectService) {
+				mockUploadRepository(service).On("Find", "upload-id").Return(completeUpload, nil)
+				mockUploadRepository(service).On("Open", "upload-id").Return(newImportSourceContent("content for testing"), nil)
+				service.repository.(*repository.MockProjectRepository).On("Find", "project-id").Return(&entity.Project{Name: "project-id"}, nil)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			if test.arrangeFunc != nil {
+				test.arrangeFunc(t, test.service)
+			}
+
+			_, err := test.service.CreateFromUpload(&entity.CreateProjectRequest{
+				Format:    "targz",
+				Mode:      entity.CreateProjectModeProject,
+				ProjectID: test.projectID,
+				Storage:   "local",
+				Version:   test.projectVersion,
+			}, test.uploadID)
+			if test.err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.NoError(t, err)
+				test.service.repository.(*repository.MockProjectRepository).AssertExpectations(t)
+			}
+
+			if test.service.uploads != nil {
+				mockUploadRepository(test.service).AssertExpectations(t)
+			}
+		})
+	}
+}
+
+func TestCreateProjectService_ReplaceFromUpload(t *testing.T) {
+	t.Log("Testing replace a project from an upload on the CreateProjectService removing the upload")
+
+	uploadContent := newImportSourceContent("content for testing")
+	service := NewCreateProjectService(
+		repository.NewMockProjectRepository(),
+		repository.NewMockProjectSourceCodeStorageFactory(),
+		logger.NewFakeLogger(),
+	).WithUploadService(NewProjectUploadService(repository.NewMockProjectUploadRepository(), 0, 0, logger.NewFakeLogger()))
+
+	projectSourceCodeStorer := repository.NewMockProjectSourceCodeStorer()
+	current := entity.NewProject("project-id", "v1.0.0", "project-id.tar.gz", "targz", "local")
+	current.Revision = "revision-1"
+	replacingProject := mock.MatchedBy(func(project *entity.Project) bool {
+		return project.Version == "v1.0.0" && strings.HasPrefix(project.Reference, "project-id@v1.0.0.")
+	})
+
+	mockUploadRepository(service).On("Find", "upload-id").Return(&entity.ProjectUpload{ID: "upload-id", Length: 19, Offset: 19, ExpiresAt: "2100-01-01T00:00:00Z"}, nil)
+	mockUploadRepository(service).On("Open", "upload-id").Return(uploadContent, nil)
+	mockUploadRepository(service).On("Remove", "upload-id").Return(nil)
+	service.repository.(*repository.MockProjectRepository).On("Find", "project-id").Return(current, nil)
+	service.storage.(*repository.MockProjectSourceCodeStorageFactory).On("Get", "local").Return(projectSourceCodeStorer)
+	projectSourceCodeStorer.On("Store", replacingProject, uploadContent).Return(nil)
+	service.repository.(*repository.MockProjectRepository).On("SafeReplace", "project-id", replacingProject, "revision-1").Run(func(args mock.Arguments) {
+		args.Get(1).(*entity.Project).Revision = "revision-2"
+	}).Return(nil)
+	projectSourceCodeStorer.On("Delete", current).Return(nil)
+
+	revision, err := service.CreateFromUpload(&entity.CreateProjectRequest{
+		Format:    "targz",
+		Mode:      entity.CreateProjectModeReplace,
+		ProjectID: "project-id",
+		Revision:  "revision-1",
+		Storage:   "local",
+	}, "upload-id")
+	assert.NoError(t, err)
+	assert.Equal(t, "revision-2", revision)
+	mockUploadRepository(service).AssertExpectations(t)
+	projectSourceCodeStorer.AssertExpectations(t)
 }
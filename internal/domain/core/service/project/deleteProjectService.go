@@ -1,7 +1,12 @@
 package project
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/apenella/ransidble/internal/domain/core/entity"
 	domainerror "github.com/apenella/ransidble/internal/domain/core/error"
@@ -9,11 +14,27 @@ import (
 	"github.com/apenella/ransidble/internal/domain/ports/service"
 )
 
-// DeleteProjectService is a service that handles the deletion of projects
+const (
+	// DefaultProjectTrashGracePeriod represents the default time a deleted project is kept in the trash before it is purged
+	DefaultProjectTrashGracePeriod = 7 * 24 * time.Hour
+	// DefaultProjectTrashPurgeInterval represents the default time between two purges of the deleted projects
+	DefaultProjectTrashPurgeInterval = 1 * time.Hour
+)
+
+// DeleteProjectService is a service that handles the deletion of projects. The deleted projects are moved to the trash, where they can be restored until the grace period is over and they are periodically purged
 type DeleteProjectService struct {
-	repository repository.ProjectRepository
-	storage    repository.SourceCodeStorageFactory
-	logger     repository.Logger
+	canceller      service.CancelTaskServicer
+	gracePeriod    time.Duration
+	interval       time.Duration
+	logger         repository.Logger
+	mutex          sync.Mutex
+	now            func() time.Time
+	onceStart      sync.Once
+	onceStop       sync.Once
+	repository     repository.ProjectRepository
+	stopCh         chan struct{}
+	storage        repository.SourceCodeStorageFactory
+	taskRepository repository.TaskRepository
 }
 
 // Ensure DeleteProjectService implements the DeleteProjectServicer interface
@@ -22,18 +43,46 @@ var _ service.DeleteProjectServicer = (*DeleteProjectService)(nil)
 // NewDeleteProjectService creates a new instance of DeleteProjectService
 func NewDeleteProjectService(repository repository.ProjectRepository, storage repository.SourceCodeStorageFactory, logger repository.Logger) *DeleteProjectService {
 	return &DeleteProjectService{
-		repository: repository,
-		storage:    storage,
-		logger:     logger,
+		gracePeriod: DefaultProjectTrashGracePeriod,
+		interval:    DefaultProjectTrashPurgeInterval,
+		logger:      logger,
+		now:         time.Now,
+		repository:  repository,
+		stopCh:      make(chan struct{}),
+		storage:     storage,
 	}
 }
 
-// Delete deletes a project by its id
-func (s *DeleteProjectService) Delete(projectID string) error {
+// WithTaskRepository sets the repository used to find the tasks of the project to delete. The tasks are not checked when it is not set
+func (s *DeleteProjectService) WithTaskRepository(taskRepository repository.TaskRepository) *DeleteProjectService {
+	s.taskRepository = taskRepository
+	return s
+}
+
+// WithTaskCanceller sets the service to cancel the tasks that are not finished when a project is forcibly deleted
+func (s *DeleteProjectService) WithTaskCanceller(canceller service.CancelTaskServicer) *DeleteProjectService {
+	s.canceller = canceller
+	return s
+}
+
+// WithTrashPolicy sets the time the deleted projects are kept in the trash before they are purged, and the time between two purges. The defaults are kept for the values that are not greater than zero
+func (s *DeleteProjectService) WithTrashPolicy(gracePeriod time.Duration, interval time.Duration) *DeleteProjectService {
+	if gracePeriod > 0 {
+		s.gracePeriod = gracePeriod
+	}
+
+	if interval > 0 {
+		s.interval = interval
+	}
+
+	return s
+}
+
+// Delete moves a project and all its versions to the trash. A project having tasks that are not finished is not deleted, unless force is set, which cancels those tasks before the project is deleted. A project previously deleted with the same id is purged from the trash
+func (s *DeleteProjectService) Delete(projectID string, force bool) error {
 
-	var project *entity.Project
 	var err error
-	var storer repository.SourceCodeStorer
+	var storers []repository.SourceCodeStorer
 
 	if s.repository == nil {
 		s.logger.Error(ErrProjectRepositoryNotInitialized, map[string]interface{}{
@@ -63,9 +112,9 @@ func (s *DeleteProjectService) Delete(projectID string) error {
 		)
 	}
 
-	project, err = s.repository.Find(projectID)
+	project, err := s.repository.Find(projectID)
 	if err != nil {
-		s.logger.Error("%s: %s", ErrFindingProject, err.Error(), map[string]interface{}{
+		s.logger.Error(fmt.Sprintf("%s: %s", ErrFindingProject, err.Error()), map[string]interface{}{
 			"component":  "DeleteProjectService.DeleteProject",
 			"package":    "github.com/apenella/ransidble/internal/domain/core/service/project",
 			"project_id": projectID,
@@ -75,43 +124,520 @@ func (s *DeleteProjectService) Delete(projectID string) error {
 		)
 	}
 
-	storer = s.storage.Get(project.Storage)
-	if storer == nil {
-		s.logger.Error(ErrStorageHandlerNotFound, map[string]interface{}{
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	err = s.releaseTasks(projectID, force)
+	if err != nil {
+		return err
+	}
+
+	// each version has its own source code, which may be located in a different storage
+	versions, err := s.repository.FindVersions(projectID)
+	if err != nil || len(versions) == 0 {
+		versions = []*entity.Project{project}
+	}
+
+	storers, err = s.storers("DeleteProjectService.DeleteProject", projectID, versions)
+	if err != nil {
+		return err
+	}
+
+	// the trash keeps a single deleted project for each id, so a project previously deleted with the same id is purged
+	_, err = s.repository.FindTrashedVersions(projectID)
+	if err == nil {
+		err = s.purge("DeleteProjectService.DeleteProject", projectID)
+		if err != nil {
+			return fmt.Errorf("%s: %w", ErrDeletingProject, err)
+		}
+	}
+
+	err = s.repository.Trash(projectID, s.now().UTC().Format(time.RFC3339))
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("%s: %s", ErrDeletingProject, err.Error()), map[string]interface{}{
 			"component":  "DeleteProjectService.DeleteProject",
 			"package":    "github.com/apenella/ransidble/internal/domain/core/service/project",
 			"project_id": projectID,
-			"storage":    project.Storage,
 		})
-		return fmt.Errorf(ErrStorageHandlerNotFound)
+		return fmt.Errorf("%s: %w", ErrDeletingProject, err)
+	}
+
+	for i, version := range versions {
+		err = storers[i].Trash(version)
+		if err != nil {
+			s.logger.Error(fmt.Sprintf("%s: %s", ErrDeletingProject, err.Error()), map[string]interface{}{
+				"component":       "DeleteProjectService.DeleteProject",
+				"package":         "github.com/apenella/ransidble/internal/domain/core/service/project",
+				"project_id":      projectID,
+				"project_version": version.Version,
+				"storage":         version.Storage,
+			})
+			return fmt.Errorf("%s: %w", ErrDeletingProject, err)
+		}
+	}
+
+	s.logger.Info("Project moved to the trash", map[string]interface{}{
+		"component":  "DeleteProjectService.DeleteProject",
+		"package":    "github.com/apenella/ransidble/internal/domain/core/service/project",
+		"project_id": projectID,
+	})
+
+	return nil
+}
+
+// Restore moves a deleted project and all its versions from the trash back to the projects. A deleted project can not be restored while another project with the same id exists
+func (s *DeleteProjectService) Restore(projectID string) error {
+
+	if s.repository == nil {
+		s.logger.Error(ErrProjectRepositoryNotInitialized, map[string]interface{}{
+			"component":  "DeleteProjectService.Restore",
+			"package":    "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"project_id": projectID,
+		})
+		return fmt.Errorf(ErrProjectRepositoryNotInitialized)
+	}
+
+	if s.storage == nil {
+		s.logger.Error(ErrProjectStorageNotProvided, map[string]interface{}{
+			"component":  "DeleteProjectService.Restore",
+			"package":    "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"project_id": projectID,
+		})
+		return fmt.Errorf(ErrProjectStorageNotProvided)
+	}
+
+	if projectID == "" {
+		s.logger.Error(ErrProjectIDNotProvided, map[string]interface{}{
+			"component": "DeleteProjectService.Restore",
+			"package":   "github.com/apenella/ransidble/internal/domain/core/service/project",
+		})
+		return domainerror.NewProjectNotProvidedError(
+			fmt.Errorf(ErrProjectIDNotProvided),
+		)
 	}
 
-	err = s.repository.Delete(projectID)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	versions, err := s.repository.FindTrashedVersions(projectID)
 	if err != nil {
-		s.logger.Error("%s: %s", ErrDeletingProject, err.Error(), map[string]interface{}{
-			"component":  "DeleteProjectService.DeleteProject",
+		s.logger.Error(fmt.Sprintf("%s: %s", ErrFindingDeletedProject, err.Error()), map[string]interface{}{
+			"component":  "DeleteProjectService.Restore",
 			"package":    "github.com/apenella/ransidble/internal/domain/core/service/project",
 			"project_id": projectID,
 		})
-		return fmt.Errorf("%s: %w", ErrDeletingProject, err)
+		return domainerror.NewProjectNotFoundError(
+			fmt.Errorf("%s: %w", ErrFindingDeletedProject, err),
+		)
 	}
 
-	err = storer.Delete(project)
+	_, err = s.repository.Find(projectID)
+	if err == nil {
+		s.logger.Error(fmt.Sprintf("%s: %s", ErrRestoringProject, ErrProjectAlreadyExists), map[string]interface{}{
+			"component":  "DeleteProjectService.Restore",
+			"package":    "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"project_id": projectID,
+		})
+		return domainerror.NewProjectAlreadyExistsError(
+			fmt.Errorf("%s: %s", ErrRestoringProject, ErrProjectAlreadyExists),
+		)
+	}
+
+	storers, err := s.storers("DeleteProjectService.Restore", projectID, versions)
+	if err != nil {
+		return err
+	}
+
+	// the source code is restored before the project, so the restored project is only found once its source code is available
+	for i, version := range versions {
+		err = storers[i].Restore(version)
+		if err != nil {
+			s.logger.Error(fmt.Sprintf("%s: %s", ErrRestoringProject, err.Error()), map[string]interface{}{
+				"component":       "DeleteProjectService.Restore",
+				"package":         "github.com/apenella/ransidble/internal/domain/core/service/project",
+				"project_id":      projectID,
+				"project_version": version.Version,
+				"storage":         version.Storage,
+			})
+			return fmt.Errorf("%s: %w", ErrRestoringProject, err)
+		}
+	}
+
+	err = s.repository.Restore(projectID)
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("%s: %s", ErrRestoringProject, err.Error()), map[string]interface{}{
+			"component":  "DeleteProjectService.Restore",
+			"package":    "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"project_id": projectID,
+		})
+		return fmt.Errorf("%s: %w", ErrRestoringProject, err)
+	}
+
+	s.logger.Info("Project restored from the trash", map[string]interface{}{
+		"component":  "DeleteProjectService.Restore",
+		"package":    "github.com/apenella/ransidble/internal/domain/core/service/project",
+		"project_id": projectID,
+	})
+
+	return nil
+}
+
+// Start purges the deleted projects whose grace period is over once when the service starts and then on every interval, until the context is done or the service is stopped
+func (s *DeleteProjectService) Start(ctx context.Context) {
+	s.onceStart.Do(func() {
+		go func() {
+			// the projects whose grace period ended while the server was down are purged without waiting for the first interval
+			_, _ = s.Purge()
+
+			ticker := time.NewTicker(s.interval)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-ticker.C:
+					// the errors are already logged
+					_, _ = s.Purge()
+				case <-ctx.Done():
+					return
+				case <-s.stopCh:
+					return
+				}
+			}
+		}()
+	})
+}
+
+// Stop stops purging the deleted projects
+func (s *DeleteProjectService) Stop() {
+	s.onceStop.Do(func() {
+		close(s.stopCh)
+	})
+}
+
+// Purge removes the deleted projects whose grace period is over, along with their source code, and returns the number of projects removed. A project that can not be removed does not stop the purge
+func (s *DeleteProjectService) Purge() (int, error) {
+
+	if s.repository == nil {
+		s.logger.Error(ErrProjectRepositoryNotInitialized, map[string]interface{}{
+			"component": "DeleteProjectService.Purge",
+			"package":   "github.com/apenella/ransidble/internal/domain/core/service/project",
+		})
+		return 0, fmt.Errorf(ErrProjectRepositoryNotInitialized)
+	}
+
+	if s.storage == nil {
+		s.logger.Error(ErrProjectStorageNotProvided, map[string]interface{}{
+			"component": "DeleteProjectService.Purge",
+			"package":   "github.com/apenella/ransidble/internal/domain/core/service/project",
+		})
+		return 0, fmt.Errorf(ErrProjectStorageNotProvided)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	projects, err := s.repository.FindAllTrashed()
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("%s: %s", ErrFindingDeletedProjects, err.Error()), map[string]interface{}{
+			"component": "DeleteProjectService.Purge",
+			"package":   "github.com/apenella/ransidble/internal/domain/core/service/project",
+		})
+		return 0, fmt.Errorf("%s: %s", ErrFindingDeletedProjects, err.Error())
+	}
+
+	now := s.now()
+	purged := 0
+	for _, project := range projects {
+		if !project.IsPurgeable(now, s.gracePeriod) {
+			continue
+		}
+
+		err = s.purge("DeleteProjectService.Purge", project.Name)
+		if err != nil {
+			continue
+		}
+		purged++
+	}
+
+	s.logger.Info("Deleted projects purged", map[string]interface{}{
+		"component": "DeleteProjectService.Purge",
+		"package":   "github.com/apenella/ransidble/internal/domain/core/service/project",
+		"purged":    purged,
+	})
+
+	return purged, nil
+}
+
+// purge removes a deleted project from the trash. The source code is removed before the project, so a project whose source code can not be removed is purged again later
+func (s *DeleteProjectService) purge(component string, projectID string) error {
+
+	versions, err := s.repository.FindTrashedVersions(projectID)
 	if err != nil {
-		s.logger.Error("%s: %s", ErrDeletingProject, err.Error(), map[string]interface{}{
+		s.logger.Error(fmt.Sprintf("%s: %s", ErrPurgingProject, err.Error()), map[string]interface{}{
+			"component":  component,
+			"package":    "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"project_id": projectID,
+		})
+		return fmt.Errorf("%s: %w", ErrPurgingProject, err)
+	}
+
+	storers, err := s.storers(component, projectID, versions)
+	if err != nil {
+		return fmt.Errorf("%s: %w", ErrPurgingProject, err)
+	}
+
+	for i, version := range versions {
+		err = storers[i].Purge(version)
+		if err != nil {
+			s.logger.Error(fmt.Sprintf("%s: %s", ErrPurgingProject, err.Error()), map[string]interface{}{
+				"component":       component,
+				"package":         "github.com/apenella/ransidble/internal/domain/core/service/project",
+				"project_id":      projectID,
+				"project_version": version.Version,
+				"storage":         version.Storage,
+			})
+			return fmt.Errorf("%s: %w", ErrPurgingProject, err)
+		}
+	}
+
+	err = s.repository.Purge(projectID)
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("%s: %s", ErrPurgingProject, err.Error()), map[string]interface{}{
+			"component":  component,
+			"package":    "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"project_id": projectID,
+		})
+		return fmt.Errorf("%s: %w", ErrPurgingProject, err)
+	}
+
+	return nil
+}
+
+// releaseTasks checks that the project to delete has no tasks that are not finished. When force is set, those tasks are cancelled instead
+func (s *DeleteProjectService) releaseTasks(projectID string, force bool) error {
+
+	if s.taskRepository == nil {
+		return nil
+	}
+
+	tasks, err := s.taskRepository.FindAll()
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("%s: %s", ErrFindingProjectTasks, err.Error()), map[string]interface{}{
 			"component":  "DeleteProjectService.DeleteProject",
 			"package":    "github.com/apenella/ransidble/internal/domain/core/service/project",
 			"project_id": projectID,
-			"storage":    project.Storage,
 		})
-		return fmt.Errorf("%s: %w", ErrDeletingProject, err)
+		return fmt.Errorf("%s: %w", ErrFindingProjectTasks, err)
+	}
+
+	active := []string{}
+	for _, task := range tasks {
+		if task.ProjectID == projectID && !task.IsFinished() {
+			active = append(active, task.ID)
+		}
+	}
+
+	if len(active) == 0 {
+		return nil
+	}
+
+	if !force {
+		s.logger.Error(ErrProjectHasActiveTasks, map[string]interface{}{
+			"component":  "DeleteProjectService.DeleteProject",
+			"package":    "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"project_id": projectID,
+			"tasks":      active,
+		})
+		return domainerror.NewProjectConflictError(
+			fmt.Errorf("%s: %s", ErrProjectHasActiveTasks, strings.Join(active, ", ")),
+		)
+	}
+
+	if s.canceller == nil {
+		s.logger.Error(ErrTaskCancellerNotInitialized, map[string]interface{}{
+			"component":  "DeleteProjectService.DeleteProject",
+			"package":    "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"project_id": projectID,
+		})
+		return fmt.Errorf(ErrTaskCancellerNotInitialized)
+	}
+
+	for _, id := range active {
+		_, err = s.canceller.CancelTask(id)
+
+		// the task could finish before it is cancelled
+		var taskNotCancellable *domainerror.TaskNotCancellableError
+		if err != nil && !errors.As(err, &taskNotCancellable) {
+			s.logger.Error(fmt.Sprintf("%s: %s", ErrCancellingProjectTask, err.Error()), map[string]interface{}{
+				"component":  "DeleteProjectService.DeleteProject",
+				"package":    "github.com/apenella/ransidble/internal/domain/core/service/project",
+				"project_id": projectID,
+				"task_id":    id,
+			})
+			return fmt.Errorf("%s %s: %w", ErrCancellingProjectTask, id, err)
+		}
 	}
 
 	return nil
 }
 
-// DeleteVersion deletes a project version by its id and version
+// storers returns the storer of each project version, since each version has its own source code, which may be located in a different storage
+func (s *DeleteProjectService) storers(component string, projectID string, versions []*entity.Project) ([]repository.SourceCodeStorer, error) {
+
+	storers := make([]repository.SourceCodeStorer, 0, len(versions))
+	for _, version := range versions {
+		storer := s.storage.Get(version.Storage)
+		if storer == nil {
+			s.logger.Error(ErrStorageHandlerNotFound, map[string]interface{}{
+				"component":       component,
+				"package":         "github.com/apenella/ransidble/internal/domain/core/service/project",
+				"project_id":      projectID,
+				"project_version": version.Version,
+				"storage":         version.Storage,
+			})
+			return nil, fmt.Errorf(ErrStorageHandlerNotFound)
+		}
+		storers = append(storers, storer)
+	}
+
+	return storers, nil
+}
+
+// DeleteVersion deletes a project version by its id and version. The only version of a project cannot be deleted, the project must be deleted instead
 func (s *DeleteProjectService) DeleteVersion(projectID string, version string) error {
-	// TODO: implement the logic to delete a project version. This will require changes in the repository and storage layers to support versioning.
-	return fmt.Errorf("DeleteVersion method not implemented yet")
+
+	var project *entity.Project
+	var versions []*entity.Project
+	var err error
+	var storer repository.SourceCodeStorer
+
+	if s.repository == nil {
+		s.logger.Error(ErrProjectRepositoryNotInitialized, map[string]interface{}{
+			"component":       "DeleteProjectService.DeleteVersion",
+			"package":         "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"project_id":      projectID,
+			"project_version": version,
+		})
+		return fmt.Errorf(ErrProjectRepositoryNotInitialized)
+	}
+
+	if s.storage == nil {
+		s.logger.Error(ErrProjectStorageNotProvided, map[string]interface{}{
+			"component":       "DeleteProjectService.DeleteVersion",
+			"package":         "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"project_id":      projectID,
+			"project_version": version,
+		})
+		return fmt.Errorf(ErrProjectStorageNotProvided)
+	}
+
+	if projectID == "" {
+		s.logger.Error(ErrProjectIDNotProvided, map[string]interface{}{
+			"component": "DeleteProjectService.DeleteVersion",
+			"package":   "github.com/apenella/ransidble/internal/domain/core/service/project",
+		})
+		return domainerror.NewProjectNotProvidedError(
+			fmt.Errorf(ErrProjectIDNotProvided),
+		)
+	}
+
+	if version == "" {
+		s.logger.Error(ErrProjectVersionNotProvided, map[string]interface{}{
+			"component":  "DeleteProjectService.DeleteVersion",
+			"package":    "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"project_id": projectID,
+		})
+		return domainerror.NewProjectInvalidVersionError(
+			fmt.Errorf(ErrProjectVersionNotProvided),
+		)
+	}
+
+	// the alias of the most recent version is not accepted, the version to delete must be explicit
+	err = entity.ValidateProjectVersion(version)
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("%s: %s", ErrInvalidProjectVersion, err.Error()), map[string]interface{}{
+			"component":       "DeleteProjectService.DeleteVersion",
+			"package":         "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"project_id":      projectID,
+			"project_version": version,
+		})
+		return domainerror.NewProjectInvalidVersionError(
+			fmt.Errorf("%s: %s", ErrInvalidProjectVersion, err.Error()),
+		)
+	}
+
+	project, err = s.repository.FindVersion(projectID, version)
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("%s: %s", ErrProjectVersionNotFound, err.Error()), map[string]interface{}{
+			"component":       "DeleteProjectService.DeleteVersion",
+			"package":         "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"project_id":      projectID,
+			"project_version": version,
+		})
+		return domainerror.NewProjectNotFoundError(
+			fmt.Errorf("%s: %w", ErrProjectVersionNotFound, err),
+		)
+	}
+
+	versions, err = s.repository.FindVersions(projectID)
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("%s: %s", ErrFindingProject, err.Error()), map[string]interface{}{
+			"component":       "DeleteProjectService.DeleteVersion",
+			"package":         "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"project_id":      projectID,
+			"project_version": version,
+		})
+		return domainerror.NewProjectNotFoundError(
+			fmt.Errorf("%s: %w", ErrFindingProject, err),
+		)
+	}
+
+	if len(versions) <= 1 {
+		s.logger.Error(ErrRemovingLastProjectVersion, map[string]interface{}{
+			"component":       "DeleteProjectService.DeleteVersion",
+			"package":         "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"project_id":      projectID,
+			"project_version": version,
+		})
+		return domainerror.NewProjectLastVersionError(
+			fmt.Errorf(ErrRemovingLastProjectVersion),
+		)
+	}
+
+	storer = s.storage.Get(project.Storage)
+	if storer == nil {
+		s.logger.Error(ErrStorageHandlerNotFound, map[string]interface{}{
+			"component":       "DeleteProjectService.DeleteVersion",
+			"package":         "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"project_id":      projectID,
+			"project_version": version,
+			"storage":         project.Storage,
+		})
+		return fmt.Errorf(ErrStorageHandlerNotFound)
+	}
+
+	err = s.repository.DeleteVersion(projectID, version)
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("%s: %s", ErrDeletingProject, err.Error()), map[string]interface{}{
+			"component":       "DeleteProjectService.DeleteVersion",
+			"package":         "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"project_id":      projectID,
+			"project_version": version,
+		})
+		return fmt.Errorf("%s: %w", ErrDeletingProject, err)
+	}
+
+	err = storer.Delete(project)
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("%s: %s", ErrDeletingProject, err.Error()), map[string]interface{}{
+			"component":       "DeleteProjectService.DeleteVersion",
+			"package":         "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"project_id":      projectID,
+			"project_version": version,
+			"storage":         project.Storage,
+		})
+		return fmt.Errorf("%s: %w", ErrDeletingProject, err)
+	}
+
+	return nil
 }
@@ -1,22 +1,33 @@
 package project
 
 import (
+	"context"
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/apenella/ransidble/internal/domain/core/entity"
 	domainerror "github.com/apenella/ransidble/internal/domain/core/error"
 	"github.com/apenella/ransidble/internal/domain/ports/repository"
+	"github.com/apenella/ransidble/internal/domain/ports/service"
 	"github.com/apenella/ransidble/internal/infrastructure/logger"
+	"github.com/apenella/ransidble/internal/infrastructure/persistence/project/repository/local"
+	"github.com/apenella/ransidble/internal/infrastructure/persistence/project/store"
+	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestDeleteProjectService_Delete(t *testing.T) {
 
+	deletedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
 	tests := []struct {
 		desc        string
 		service     *DeleteProjectService
 		projectID   string
+		force       bool
 		arrangeFunc func(*testing.T, *DeleteProjectService)
 		assertFunc  func(*testing.T, *DeleteProjectService) bool
 		err         error
@@ -89,6 +100,25 @@ func TestDeleteProjectService_Delete(t *testing.T) {
 					},
 					nil,
 				)
+				service.repository.(*repository.MockProjectRepository).On(
+					"FindTrashedVersions",
+					"test-id",
+				).Return(
+					nil,
+					fmt.Errorf("project not found"),
+				)
+				service.repository.(*repository.MockProjectRepository).On(
+					"FindVersions",
+					"test-id",
+				).Return(
+					[]*entity.Project{
+						{
+							Name:    "test-id",
+							Storage: "local",
+						},
+					},
+					nil,
+				)
 
 				service.storage.(*repository.MockProjectSourceCodeStorageFactory).On(
 					"Get",
@@ -118,6 +148,18 @@ func TestDeleteProjectService_Delete(t *testing.T) {
 					},
 					nil,
 				)
+				service.repository.(*repository.MockProjectRepository).On(
+					"FindVersions",
+					"test-id",
+				).Return(
+					[]*entity.Project{
+						{
+							Name:    "test-id",
+							Storage: "local",
+						},
+					},
+					nil,
+				)
 
 				service.storage.(*repository.MockProjectSourceCodeStorageFactory).On(
 					"Get",
@@ -128,13 +170,23 @@ func TestDeleteProjectService_Delete(t *testing.T) {
 				)
 
 				service.repository.(*repository.MockProjectRepository).On(
-					"Delete",
+					"FindTrashedVersions",
+					"test-id",
+				).Return(
+					nil,
+					fmt.Errorf("project not found"),
+				)
+
+				service.now = func() time.Time { return deletedAt }
+				service.repository.(*repository.MockProjectRepository).On(
+					"Trash",
 					"test-id",
+					"2026-01-02T03:04:05Z",
 				).Return(
-					fmt.Errorf("error deleting project"),
+					fmt.Errorf("error moving project to the trash"),
 				)
 			},
-			err: fmt.Errorf("%s: %w", ErrDeletingProject, fmt.Errorf("error deleting project")),
+			err: fmt.Errorf("%s: %w", ErrDeletingProject, fmt.Errorf("error moving project to the trash")),
 		},
 		{
 			desc: "Testing an error deleting a project on the DeleteProjectService service when there is an error deleting the project source code from the storage",
@@ -157,6 +209,18 @@ func TestDeleteProjectService_Delete(t *testing.T) {
 					},
 					nil,
 				)
+				service.repository.(*repository.MockProjectRepository).On(
+					"FindVersions",
+					"test-id",
+				).Return(
+					[]*entity.Project{
+						{
+							Name:    "test-id",
+							Storage: "local",
+						},
+					},
+					nil,
+				)
 
 				service.storage.(*repository.MockProjectSourceCodeStorageFactory).On(
 					"Get",
@@ -167,26 +231,289 @@ func TestDeleteProjectService_Delete(t *testing.T) {
 				)
 
 				service.repository.(*repository.MockProjectRepository).On(
-					"Delete",
+					"FindTrashedVersions",
 					"test-id",
+				).Return(
+					nil,
+					fmt.Errorf("project not found"),
+				)
+
+				service.now = func() time.Time { return deletedAt }
+				service.repository.(*repository.MockProjectRepository).On(
+					"Trash",
+					"test-id",
+					"2026-01-02T03:04:05Z",
 				).Return(
 					nil,
 				)
 
 				projectSourceCodeStorer.On(
-					"Delete",
+					"Trash",
 					&entity.Project{
 						Name:    "test-id",
 						Storage: "local",
 					},
 				).Return(
-					fmt.Errorf("error deleting project source code"),
+					fmt.Errorf("error moving project source code to the trash"),
 				)
 			},
-			err: fmt.Errorf("%s: %w", ErrDeletingProject, fmt.Errorf("error deleting project source code")),
+			err: fmt.Errorf("%s: %w", ErrDeletingProject, fmt.Errorf("error moving project source code to the trash")),
 		},
 		{
-			desc: "Testing successfully deleting a project on the DeleteProjectService service",
+			desc: "Testing an error deleting a project on the DeleteProjectService service when the project has tasks that are not finished",
+			service: NewDeleteProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			).WithTaskRepository(repository.NewMockTaskRepository()),
+			projectID: "test-id",
+			arrangeFunc: func(t *testing.T, service *DeleteProjectService) {
+				service.repository.(*repository.MockProjectRepository).On(
+					"Find",
+					"test-id",
+				).Return(
+					&entity.Project{
+						Name:    "test-id",
+						Storage: "local",
+					},
+					nil,
+				)
+				service.repository.(*repository.MockProjectRepository).On(
+					"FindTrashedVersions",
+					"test-id",
+				).Return(
+					nil,
+					fmt.Errorf("project not found"),
+				)
+				service.taskRepository.(*repository.MockTaskRepository).On(
+					"FindAll",
+				).Return(
+					[]*entity.Task{
+						{ID: "task-running", ProjectID: "test-id", Status: entity.RUNNING},
+						{ID: "task-success", ProjectID: "test-id", Status: entity.SUCCESS},
+						{ID: "task-pending", ProjectID: "test-id", Status: entity.PENDING},
+						{ID: "task-other", ProjectID: "other-id", Status: entity.RUNNING},
+					},
+					nil,
+				)
+			},
+			err: domainerror.NewProjectConflictError(
+				fmt.Errorf("%s: %s", ErrProjectHasActiveTasks, "task-running, task-pending"),
+			),
+		},
+		{
+			desc: "Testing an error deleting a project on the DeleteProjectService service when the tasks of the project cannot be found",
+			service: NewDeleteProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			).WithTaskRepository(repository.NewMockTaskRepository()),
+			projectID: "test-id",
+			arrangeFunc: func(t *testing.T, service *DeleteProjectService) {
+				service.repository.(*repository.MockProjectRepository).On(
+					"Find",
+					"test-id",
+				).Return(
+					&entity.Project{
+						Name:    "test-id",
+						Storage: "local",
+					},
+					nil,
+				)
+				service.repository.(*repository.MockProjectRepository).On(
+					"FindTrashedVersions",
+					"test-id",
+				).Return(
+					nil,
+					fmt.Errorf("project not found"),
+				)
+				service.taskRepository.(*repository.MockTaskRepository).On(
+					"FindAll",
+				).Return(
+					nil,
+					fmt.Errorf("error finding tasks"),
+				)
+			},
+			err: fmt.Errorf("%s: %w", ErrFindingProjectTasks, fmt.Errorf("error finding tasks")),
+		},
+		{
+			desc: "Testing an error forcibly deleting a project on the DeleteProjectService service when the task cancellation service is not initialized",
+			service: NewDeleteProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			).WithTaskRepository(repository.NewMockTaskRepository()),
+			projectID: "test-id",
+			force:     true,
+			arrangeFunc: func(t *testing.T, service *DeleteProjectService) {
+				service.repository.(*repository.MockProjectRepository).On(
+					"Find",
+					"test-id",
+				).Return(
+					&entity.Project{
+						Name:    "test-id",
+						Storage: "local",
+					},
+					nil,
+				)
+				service.repository.(*repository.MockProjectRepository).On(
+					"FindTrashedVersions",
+					"test-id",
+				).Return(
+					nil,
+					fmt.Errorf("project not found"),
+				)
+				service.taskRepository.(*repository.MockTaskRepository).On(
+					"FindAll",
+				).Return(
+					[]*entity.Task{
+						{ID: "task-running", ProjectID: "test-id", Status: entity.RUNNING},
+					},
+					nil,
+				)
+			},
+			err: fmt.Errorf(ErrTaskCancellerNotInitialized),
+		},
+		{
+			desc: "Testing an error forcibly deleting a project on the DeleteProjectService service when a task cannot be cancelled",
+			service: NewDeleteProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			).WithTaskRepository(repository.NewMockTaskRepository()).
+				WithTaskCanceller(service.NewMockCancelTaskService()),
+			projectID: "test-id",
+			force:     true,
+			arrangeFunc: func(t *testing.T, s *DeleteProjectService) {
+				s.repository.(*repository.MockProjectRepository).On(
+					"Find",
+					"test-id",
+				).Return(
+					&entity.Project{
+						Name:    "test-id",
+						Storage: "local",
+					},
+					nil,
+				)
+				s.repository.(*repository.MockProjectRepository).On(
+					"FindTrashedVersions",
+					"test-id",
+				).Return(
+					nil,
+					fmt.Errorf("project not found"),
+				)
+				s.taskRepository.(*repository.MockTaskRepository).On(
+					"FindAll",
+				).Return(
+					[]*entity.Task{
+						{ID: "task-running", ProjectID: "test-id", Status: entity.RUNNING},
+					},
+					nil,
+				)
+				s.canceller.(*service.MockCancelTaskService).On(
+					"CancelTask",
+					"task-running",
+				).Return(
+					nil,
+					fmt.Errorf("error cancelling task"),
+				)
+			},
+			err: fmt.Errorf("%s %s: %w", ErrCancellingProjectTask, "task-running", fmt.Errorf("error cancelling task")),
+		},
+		{
+			desc: "Testing successfully forcibly deleting a project on the DeleteProjectService service that cancels the tasks that are not finished",
+			service: NewDeleteProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			).WithTaskRepository(repository.NewMockTaskRepository()).
+				WithTaskCanceller(service.NewMockCancelTaskService()),
+			projectID: "test-id",
+			force:     true,
+			arrangeFunc: func(t *testing.T, s *DeleteProjectService) {
+				projectSourceCodeStorer := repository.NewMockProjectSourceCodeStorer()
+
+				s.repository.(*repository.MockProjectRepository).On(
+					"Find",
+					"test-id",
+				).Return(
+					&entity.Project{
+						Name:    "test-id",
+						Storage: "local",
+					},
+					nil,
+				)
+				s.taskRepository.(*repository.MockTaskRepository).On(
+					"FindAll",
+				).Return(
+					[]*entity.Task{
+						{ID: "task-running", ProjectID: "test-id", Status: entity.RUNNING},
+						{ID: "task-accepted", ProjectID: "test-id", Status: entity.ACCEPTED},
+					},
+					nil,
+				)
+				s.canceller.(*service.MockCancelTaskService).On(
+					"CancelTask",
+					"task-running",
+				).Return(
+					&entity.Task{ID: "task-running", ProjectID: "test-id", Status: entity.CANCELLED},
+					nil,
+				)
+				// the task finished before it is cancelled
+				s.canceller.(*service.MockCancelTaskService).On(
+					"CancelTask",
+					"task-accepted",
+				).Return(
+					nil,
+					domainerror.NewTaskNotCancellableError(fmt.Errorf("task already finished")),
+				)
+				s.repository.(*repository.MockProjectRepository).On(
+					"FindVersions",
+					"test-id",
+				).Return(
+					nil,
+					fmt.Errorf("versions not found"),
+				)
+				s.storage.(*repository.MockProjectSourceCodeStorageFactory).On(
+					"Get",
+					"local",
+				).Return(
+					projectSourceCodeStorer,
+				)
+				s.repository.(*repository.MockProjectRepository).On(
+					"FindTrashedVersions",
+					"test-id",
+				).Return(
+					nil,
+					fmt.Errorf("project not found"),
+				)
+
+				s.now = func() time.Time { return deletedAt }
+				s.repository.(*repository.MockProjectRepository).On(
+					"Trash",
+					"test-id",
+					"2026-01-02T03:04:05Z",
+				).Return(
+					nil,
+				)
+				projectSourceCodeStorer.On(
+					"Trash",
+					&entity.Project{
+						Name:    "test-id",
+						Storage: "local",
+					},
+				).Return(
+					nil,
+				)
+			},
+			assertFunc: func(t *testing.T, s *DeleteProjectService) bool {
+				return s.repository.(*repository.MockProjectRepository).AssertExpectations(t) &&
+					s.canceller.(*service.MockCancelTaskService).AssertExpectations(t)
+			},
+			err: nil,
+		},
+		{
+			desc: "Testing successfully deleting a project on the DeleteProjectService service that purges the project previously deleted with the same id",
 			service: NewDeleteProjectService(
 				repository.NewMockProjectRepository(),
 				repository.NewMockProjectSourceCodeStorageFactory(),
@@ -195,6 +522,11 @@ func TestDeleteProjectService_Delete(t *testing.T) {
 			projectID: "test-id",
 			arrangeFunc: func(t *testing.T, service *DeleteProjectService) {
 				projectSourceCodeStorer := repository.NewMockProjectSourceCodeStorer()
+				trashed := &entity.Project{
+					Name:      "test-id",
+					Storage:   "local",
+					DeletedAt: "2026-01-01T00:00:00Z",
+				}
 
 				service.repository.(*repository.MockProjectRepository).On(
 					"Find",
@@ -206,24 +538,49 @@ func TestDeleteProjectService_Delete(t *testing.T) {
 					},
 					nil,
 				)
-
+				service.repository.(*repository.MockProjectRepository).On(
+					"FindVersions",
+					"test-id",
+				).Return(
+					nil,
+					fmt.Errorf("versions not found"),
+				)
 				service.storage.(*repository.MockProjectSourceCodeStorageFactory).On(
 					"Get",
 					"local",
 				).Return(
 					projectSourceCodeStorer,
+				)
+				service.repository.(*repository.MockProjectRepository).On(
+					"FindTrashedVersions",
+					"test-id",
+				).Return(
+					[]*entity.Project{trashed},
+					nil,
+				)
+				projectSourceCodeStorer.On(
+					"Purge",
+					trashed,
+				).Return(
 					nil,
 				)
-
 				service.repository.(*repository.MockProjectRepository).On(
-					"Delete",
+					"Purge",
 					"test-id",
 				).Return(
 					nil,
 				)
 
+				service.now = func() time.Time { return deletedAt }
+				service.repository.(*repository.MockProjectRepository).On(
+					"Trash",
+					"test-id",
+					"2026-01-02T03:04:05Z",
+				).Return(
+					nil,
+				)
 				projectSourceCodeStorer.On(
-					"Delete",
+					"Trash",
 					&entity.Project{
 						Name:    "test-id",
 						Storage: "local",
@@ -233,23 +590,97 @@ func TestDeleteProjectService_Delete(t *testing.T) {
 				)
 			},
 			assertFunc: func(t *testing.T, service *DeleteProjectService) bool {
-				return service.repository.(*repository.MockProjectRepository).AssertExpectations(t) &&
-					service.storage.(*repository.MockProjectSourceCodeStorageFactory).AssertExpectations(t)
+				return service.repository.(*repository.MockProjectRepository).AssertExpectations(t)
 			},
 			err: nil,
 		},
-	}
-
-	for _, test := range tests {
-		t.Run(test.desc, func(t *testing.T) {
-			t.Parallel()
-			t.Log(test.desc)
+		{
+			desc: "Testing successfully deleting a project on the DeleteProjectService service",
+			service: NewDeleteProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			),
+			projectID: "test-id",
+			arrangeFunc: func(t *testing.T, service *DeleteProjectService) {
+				projectSourceCodeStorer := repository.NewMockProjectSourceCodeStorer()
 
-			if test.arrangeFunc != nil {
-				test.arrangeFunc(t, test.service)
-			}
+				service.repository.(*repository.MockProjectRepository).On(
+					"Find",
+					"test-id",
+				).Return(
+					&entity.Project{
+						Name:    "test-id",
+						Storage: "local",
+					},
+					nil,
+				)
+				service.repository.(*repository.MockProjectRepository).On(
+					"FindVersions",
+					"test-id",
+				).Return(
+					[]*entity.Project{
+						{
+							Name:    "test-id",
+							Storage: "local",
+						},
+					},
+					nil,
+				)
+
+				service.storage.(*repository.MockProjectSourceCodeStorageFactory).On(
+					"Get",
+					"local",
+				).Return(
+					projectSourceCodeStorer,
+					nil,
+				)
+
+				service.repository.(*repository.MockProjectRepository).On(
+					"FindTrashedVersions",
+					"test-id",
+				).Return(
+					nil,
+					fmt.Errorf("project not found"),
+				)
+
+				service.now = func() time.Time { return deletedAt }
+				service.repository.(*repository.MockProjectRepository).On(
+					"Trash",
+					"test-id",
+					"2026-01-02T03:04:05Z",
+				).Return(
+					nil,
+				)
+
+				projectSourceCodeStorer.On(
+					"Trash",
+					&entity.Project{
+						Name:    "test-id",
+						Storage: "local",
+					},
+				).Return(
+					nil,
+				)
+			},
+			assertFunc: func(t *testing.T, service *DeleteProjectService) bool {
+				return service.repository.(*repository.MockProjectRepository).AssertExpectations(t) &&
+					service.storage.(*repository.MockProjectSourceCodeStorageFactory).AssertExpectations(t)
+			},
+			err: nil,
+		},
+	}
 
-			err := test.service.Delete(test.projectID)
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			if test.arrangeFunc != nil {
+				test.arrangeFunc(t, test.service)
+			}
+
+			err := test.service.Delete(test.projectID, test.force)
 			if err != nil && test.err != nil {
 				assert.Equal(t, test.err, err)
 			} else {
@@ -266,10 +697,556 @@ func TestDeleteProjectService_Delete(t *testing.T) {
 
 func TestDeleteProjectService_DeleteVersion(t *testing.T) {
 
-	service := &DeleteProjectService{}
+	projectV1 := &entity.Project{Name: "test-id", Storage: "local", Version: "v1"}
+	projectV2 := &entity.Project{Name: "test-id", Storage: "local", Version: "v2"}
+
+	tests := []struct {
+		desc        string
+		service     *DeleteProjectService
+		projectID   string
+		version     string
+		arrangeFunc func(*testing.T, *DeleteProjectService)
+		assertFunc  func(*testing.T, *DeleteProjectService) bool
+		err         error
+	}{
+		{
+			desc:      "Testing an error deleting a project version on the DeleteProjectService service when the project repository is not initialized",
+			service:   NewDeleteProjectService(nil, nil, logger.NewFakeLogger()),
+			projectID: "test-id",
+			version:   "v1",
+			err:       fmt.Errorf(ErrProjectRepositoryNotInitialized),
+		},
+		{
+			desc: "Testing an error deleting a project version on the DeleteProjectService service when the version is not provided",
+			service: NewDeleteProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			),
+			projectID: "test-id",
+			err: domainerror.NewProjectInvalidVersionError(
+				fmt.Errorf(ErrProjectVersionNotProvided),
+			),
+		},
+		{
+			desc: "Testing an error deleting a project version on the DeleteProjectService service when the version is the alias of the most recent version",
+			service: NewDeleteProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			),
+			projectID: "test-id",
+			version:   entity.LatestVersion,
+			err: domainerror.NewProjectInvalidVersionError(
+				fmt.Errorf("%s: %s", ErrInvalidProjectVersion, "reserved version: latest"),
+			),
+		},
+		{
+			desc: "Testing an error deleting a project version on the DeleteProjectService service when the version is not found",
+			service: NewDeleteProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			),
+			projectID: "test-id",
+			version:   "v3",
+			arrangeFunc: func(t *testing.T, service *DeleteProjectService) {
+				service.repository.(*repository.MockProjectRepository).On("FindVersion", "test-id", "v3").Return(nil, fmt.Errorf("version not found"))
+			},
+			err: domainerror.NewProjectNotFoundError(
+				fmt.Errorf("%s: %w", ErrProjectVersionNotFound, fmt.Errorf("version not found")),
+			),
+		},
+		{
+			desc: "Testing an error deleting the only version of a project on the DeleteProjectService service",
+			service: NewDeleteProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			),
+			projectID: "test-id",
+			version:   "v1",
+			arrangeFunc: func(t *testing.T, service *DeleteProjectService) {
+				service.repository.(*repository.MockProjectRepository).On("FindVersion", "test-id", "v1").Return(projectV1, nil)
+				service.repository.(*repository.MockProjectRepository).On("FindVersions", "test-id").Return([]*entity.Project{projectV1}, nil)
+			},
+			err: domainerror.NewProjectLastVersionError(
+				fmt.Errorf(ErrRemovingLastProjectVersion),
+			),
+		},
+		{
+			desc: "Testing an error deleting a project version on the DeleteProjectService service when there is an error deleting the version from the repository",
+			service: NewDeleteProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			),
+			projectID: "test-id",
+			version:   "v1",
+			arrangeFunc: func(t *testing.T, service *DeleteProjectService) {
+				service.repository.(*repository.MockProjectRepository).On("FindVersion", "test-id", "v1").Return(projectV1, nil)
+				service.repository.(*repository.MockProjectRepository).On("FindVersions", "test-id").Return([]*entity.Project{projectV1, projectV2}, nil)
+				service.storage.(*repository.MockProjectSourceCodeStorageFactory).On("Get", "local").Return(repository.NewMockProjectSourceCodeStorer())
+				service.repository.(*repository.MockProjectRepository).On("DeleteVersion", "test-id", "v1").Return(fmt.Errorf("error deleting version"))
+			},
+			err: fmt.Errorf("%s: %w", ErrDeletingProject, fmt.Errorf("error deleting version")),
+		},
+		{
+			desc: "Testing successfully deleting a project version on the DeleteProjectService service",
+			service: NewDeleteProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			),
+			projectID: "test-id",
+			version:   "v1",
+			arrangeFunc: func(t *testing.T, service *DeleteProjectService) {
+				projectSourceCodeStorer := repository.NewMockProjectSourceCodeStorer()
+
+				service.repository.(*repository.MockProjectRepository).On("FindVersion", "test-id", "v1").Return(projectV1, nil)
+				service.repository.(*repository.MockProjectRepository).On("FindVersions", "test-id").Return([]*entity.Project{projectV1, projectV2}, nil)
+				service.storage.(*repository.MockProjectSourceCodeStorageFactory).On("Get", "local").Return(projectSourceCodeStorer)
+				service.repository.(*repository.MockProjectRepository).On("DeleteVersion", "test-id", "v1").Return(nil)
+				projectSourceCodeStorer.On("Delete", projectV1).Return(nil)
+			},
+			assertFunc: func(t *testing.T, service *DeleteProjectService) bool {
+				return service.repository.(*repository.MockProjectRepository).AssertExpectations(t) &&
+					service.storage.(*repository.MockProjectSourceCodeStorageFactory).AssertExpectations(t)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			if test.arrangeFunc != nil {
+				test.arrangeFunc(t, test.service)
+			}
+
+			err := test.service.DeleteVersion(test.projectID, test.version)
+			if test.err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.NoError(t, err)
+
+				if test.assertFunc != nil {
+					assert.True(t, test.assertFunc(t, test.service), "assertion function returned false")
+				}
+			}
+		})
+	}
+}
+
+func TestDeleteProjectService_Restore(t *testing.T) {
+
+	trashed := &entity.Project{Name: "test-id", Storage: "local", DeletedAt: "2026-01-02T03:04:05Z"}
+
+	tests := []struct {
+		desc        string
+		service     *DeleteProjectService
+		projectID   string
+		arrangeFunc func(*testing.T, *DeleteProjectService)
+		assertFunc  func(*testing.T, *DeleteProjectService) bool
+		err         error
+	}{
+		{
+			desc:      "Testing an error restoring a project on the DeleteProjectService service when the project repository is not initialized",
+			service:   NewDeleteProjectService(nil, nil, logger.NewFakeLogger()),
+			projectID: "test-id",
+			err:       fmt.Errorf(ErrProjectRepositoryNotInitialized),
+		},
+		{
+			desc: "Testing an error restoring a project on the DeleteProjectService service when the project id is not provided",
+			service: NewDeleteProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			),
+			projectID: "",
+			err: domainerror.NewProjectNotProvidedError(
+				fmt.Errorf(ErrProjectIDNotProvided),
+			),
+		},
+		{
+			desc: "Testing an error restoring a project on the DeleteProjectService service when the project is not in the trash",
+			service: NewDeleteProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			),
+			projectID: "test-id",
+			arrangeFunc: func(t *testing.T, service *DeleteProjectService) {
+				service.repository.(*repository.MockProjectRepository).On(
+					"FindTrashedVersions",
+					"test-id",
+				).Return(
+					nil,
+					fmt.Errorf("project not found"),
+				)
+			},
+			err: domainerror.NewProjectNotFoundError(
+				fmt.Errorf("%s: %w", ErrFindingDeletedProject, fmt.Errorf("project not found")),
+			),
+		},
+		{
+			desc: "Testing an error restoring a project on the DeleteProjectService service when a project with the same id exists",
+			service: NewDeleteProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			),
+			projectID: "test-id",
+			arrangeFunc: func(t *testing.T, service *DeleteProjectService) {
+				service.repository.(*repository.MockProjectRepository).On(
+					"FindTrashedVersions",
+					"test-id",
+				).Return(
+					[]*entity.Project{trashed},
+					nil,
+				)
+				service.repository.(*repository.MockProjectRepository).On(
+					"Find",
+					"test-id",
+				).Return(
+					&entity.Project{Name: "test-id", Storage: "local"},
+					nil,
+				)
+			},
+			err: domainerror.NewProjectAlreadyExistsError(
+				fmt.Errorf("%s: %s", ErrRestoringProject, ErrProjectAlreadyExists),
+			),
+		},
+		{
+			desc: "Testing an error restoring a project on the DeleteProjectService service when the project source code cannot be restored",
+			service: NewDeleteProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			),
+			projectID: "test-id",
+			arrangeFunc: func(t *testing.T, service *DeleteProjectService) {
+				projectSourceCodeStorer := repository.NewMockProjectSourceCodeStorer()
+
+				service.repository.(*repository.MockProjectRepository).On(
+					"FindTrashedVersions",
+					"test-id",
+				).Return(
+					[]*entity.Project{trashed},
+					nil,
+				)
+				service.repository.(*repository.MockProjectRepository).On(
+					"Find",
+					"test-id",
+				).Return(
+					nil,
+					fmt.Errorf("project not found"),
+				)
+				service.storage.(*repository.MockProjectSourceCodeStorageFactory).On(
+					"Get",
+					"local",
+				).Return(
+					projectSourceCodeStorer,
+				)
+				projectSourceCodeStorer.On(
+					"Restore",
+					trashed,
+				).Return(
+					fmt.Errorf("error restoring project source code"),
+				)
+			},
+			err: fmt.Errorf("%s: %w", ErrRestoringProject, fmt.Errorf("error restoring project source code")),
+		},
+		{
+			desc: "Testing successfully restoring a project on the DeleteProjectService service",
+			service: NewDeleteProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			),
+			projectID: "test-id",
+			arrangeFunc: func(t *testing.T, service *DeleteProjectService) {
+				projectSourceCodeStorer := repository.NewMockProjectSourceCodeStorer()
+
+				service.repository.(*repository.MockProjectRepository).On(
+					"FindTrashedVersions",
+					"test-id",
+				).Return(
+					[]*entity.Project{trashed},
+					nil,
+				)
+				service.repository.(*repository.MockProjectRepository).On(
+					"Find",
+					"test-id",
+				).Return(
+					nil,
+					fmt.Errorf("project not found"),
+				)
+				service.storage.(*repository.MockProjectSourceCodeStorageFactory).On(
+					"Get",
+					"local",
+				).Return(
+					projectSourceCodeStorer,
+				)
+				projectSourceCodeStorer.On(
+					"Restore",
+					trashed,
+				).Return(
+					nil,
+				)
+				service.repository.(*repository.MockProjectRepository).On(
+					"Restore",
+					"test-id",
+				).Return(
+					nil,
+				)
+			},
+			assertFunc: func(t *testing.T, service *DeleteProjectService) bool {
+				return service.repository.(*repository.MockProjectRepository).AssertExpectations(t) &&
+					service.storage.(*repository.MockProjectSourceCodeStorageFactory).AssertExpectations(t)
+			},
+			err: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			if test.arrangeFunc != nil {
+				test.arrangeFunc(t, test.service)
+			}
+
+			err := test.service.Restore(test.projectID)
+			if err != nil && test.err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.Nil(t, err, "expected no error, got %v", err)
+				assert.Nil(t, test.err, "no error received, but expected %v", test.err)
+
+				if test.assertFunc != nil {
+					assert.True(t, test.assertFunc(t, test.service), "assertion function returned false")
+				}
+			}
+		})
+	}
+}
+
+func TestDeleteProjectService_Purge(t *testing.T) {
+
+	now := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+	expired := &entity.Project{Name: "expired", Storage: "local", DeletedAt: "2026-01-01T00:00:00Z"}
+	failing := &entity.Project{Name: "failing", Storage: "local", DeletedAt: "2026-01-01T00:00:00Z"}
+	recent := &entity.Project{Name: "recent", Storage: "local", DeletedAt: "2026-01-09T00:00:00Z"}
+
+	tests := []struct {
+		desc        string
+		service     *DeleteProjectService
+		arrangeFunc func(*testing.T, *DeleteProjectService)
+		assertFunc  func(*testing.T, *DeleteProjectService) bool
+		purged      int
+		err         error
+	}{
+		{
+			desc:    "Testing an error purging the deleted projects on the DeleteProjectService service when the project repository is not initialized",
+			service: NewDeleteProjectService(nil, nil, logger.NewFakeLogger()),
+			err:     fmt.Errorf(ErrProjectRepositoryNotInitialized),
+		},
+		{
+			desc: "Testing an error purging the deleted projects on the DeleteProjectService service when the deleted projects cannot be found",
+			service: NewDeleteProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			),
+			arrangeFunc: func(t *testing.T, service *DeleteProjectService) {
+				service.repository.(*repository.MockProjectRepository).On(
+					"FindAllTrashed",
+				).Return(
+					nil,
+					fmt.Errorf("error reading trash"),
+				)
+			},
+			err: fmt.Errorf("%s: %s", ErrFindingDeletedProjects, "error reading trash"),
+		},
+		{
+			desc: "Testing successfully purging the deleted projects whose grace period is over on the DeleteProjectService service",
+			service: NewDeleteProjectService(
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeStorageFactory(),
+				logger.NewFakeLogger(),
+			).WithTrashPolicy(48*time.Hour, time.Minute),
+			arrangeFunc: func(t *testing.T, service *DeleteProjectService) {
+				projectSourceCodeStorer := repository.NewMockProjectSourceCodeStorer()
+
+				service.now = func() time.Time { return now }
+				service.repository.(*repository.MockProjectRepository).On(
+					"FindAllTrashed",
+				).Return(
+					[]*entity.Project{expired, failing, recent},
+					nil,
+				)
+				service.storage.(*repository.MockProjectSourceCodeStorageFactory).On(
+					"Get",
+					"local",
+				).Return(
+					projectSourceCodeStorer,
+				)
+
+				service.repository.(*repository.MockProjectRepository).On(
+					"FindTrashedVersions",
+					"expired",
+				).Return(
+					[]*entity.Project{expired},
+					nil,
+				)
+				projectSourceCodeStorer.On(
+					"Purge",
+					expired,
+				).Return(
+					nil,
+				)
+				service.repository.(*repository.MockProjectRepository).On(
+					"Purge",
+					"expired",
+				).Return(
+					nil,
+				)
+
+				// a project that cannot be purged does not stop the purge
+				service.repository.(*repository.MockProjectRepository).On(
+					"FindTrashedVersions",
+					"failing",
+				).Return(
+					[]*entity.Project{failing},
+					nil,
+				)
+				projectSourceCodeStorer.On(
+					"Purge",
+					failing,
+				).Return(
+					fmt.Errorf("error purging project source code"),
+				)
+			},
+			assertFunc: func(t *testing.T, service *DeleteProjectService) bool {
+				return service.repository.(*repository.MockProjectRepository).AssertExpectations(t) &&
+					service.repository.(*repository.MockProjectRepository).AssertNotCalled(t, "Purge", "failing") &&
+					service.repository.(*repository.MockProjectRepository).AssertNotCalled(t, "FindTrashedVersions", "recent")
+			},
+			purged: 1,
+			err:    nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			if test.arrangeFunc != nil {
+				test.arrangeFunc(t, test.service)
+			}
+
+			purged, err := test.service.Purge()
+			if err != nil && test.err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.Nil(t, err, "expected no error, got %v", err)
+				assert.Nil(t, test.err, "no error received, but expected %v", test.err)
+				assert.Equal(t, test.purged, purged)
+
+				if test.assertFunc != nil {
+					assert.True(t, test.assertFunc(t, test.service), "assertion function returned false")
+				}
+			}
+		})
+	}
+}
+
+func TestDeleteProjectService_Start(t *testing.T) {
+	t.Log("Testing the DeleteProjectService purges the deleted projects once it is started, without waiting for the first interval")
+	t.Parallel()
+
+	purged := make(chan struct{}, 1)
+	projectRepository := repository.NewMockProjectRepository()
+	projectRepository.On("FindAllTrashed").Run(func(mock.Arguments) {
+		select {
+		case purged <- struct{}{}:
+		default:
+		}
+	}).Return([]*entity.Project{}, nil)
+
+	service := NewDeleteProjectService(
+		projectRepository,
+		repository.NewMockProjectSourceCodeStorageFactory(),
+		logger.NewFakeLogger(),
+	).WithTrashPolicy(48*time.Hour, time.Hour)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	service.Start(ctx)
+	assert.Eventually(t, func() bool {
+		return len(purged) > 0
+	}, time.Second, 10*time.Millisecond)
+	service.Stop()
+}
+
+func TestDeleteProjectService_DeleteRecreatedProject(t *testing.T) {
+	t.Log("Testing deleting a project on the DeleteProjectService service that was created again after being deleted, which purges the project previously deleted with the same id")
+	t.Parallel()
+
+	fs := afero.NewMemMapFs()
+	projectRepository := local.NewDatabaseDriver(fs, "/projects", logger.NewFakeLogger())
+	assert.NoError(t, projectRepository.Initialize())
+
+	localStorage := store.NewLocalStorage(fs, "/storage", logger.NewFakeLogger())
+	assert.NoError(t, localStorage.Initialize())
+	storage := store.NewFactory()
+	storage.Register("local", localStorage)
+
+	createService := NewCreateProjectService(projectRepository, storage, logger.NewFakeLogger())
+	deleteService := NewDeleteProjectService(projectRepository, storage, logger.NewFakeLogger())
+
+	// create -> delete
+	_, err := createService.Create(&entity.CreateProjectRequest{
+		Format:    "targz",
+		ProjectID: "project-id",
+		Storage:   "local",
+		Version:   "v1.0.0",
+	}, strings.NewReader("first content"))
+	assert.NoError(t, err)
+	assert.NoError(t, deleteService.Delete("project-id", false))
+
+	// create -> delete again, while the first project is still in the trash
+	_, err = createService.Create(&entity.CreateProjectRequest{
+		Format:    "targz",
+		ProjectID: "project-id",
+		Storage:   "local",
+		Version:   "v2.0.0",
+	}, strings.NewReader("second content"))
+	assert.NoError(t, err)
+	assert.NoError(t, deleteService.Delete("project-id", false))
+
+	_, err = projectRepository.Find("project-id")
+	assert.Error(t, err)
+
+	// the trash keeps the last deleted project, which can be restored
+	versions, err := projectRepository.FindTrashedVersions("project-id")
+	assert.NoError(t, err)
+	if assert.Len(t, versions, 1) {
+		assert.Equal(t, "v2.0.0", versions[0].Version)
+	}
 
-	err := service.DeleteVersion("test-id", "test-version")
+	assert.NoError(t, deleteService.Restore("project-id"))
 
-	assert.Equal(t, fmt.Errorf("DeleteVersion method not implemented yet"), err, "unexpected error received")
+	project, err := projectRepository.Find("project-id")
+	assert.NoError(t, err)
+	assert.Equal(t, "v2.0.0", project.Version)
 
+	content, err := afero.ReadFile(fs, "/storage/"+project.Reference)
+	assert.NoError(t, err)
+	assert.Equal(t, "second content", string(content))
 }
@@ -1,32 +1,166 @@
 package project
 
 const (
+	// ErrAppendingProjectUpload error message when a chunk cannot be appended to a project upload
+	ErrAppendingProjectUpload = "error appending project upload chunk"
+	// ErrArchiveInspectorNotInitialized error message when the archive inspector is not initialized
+	ErrArchiveInspectorNotInitialized = "project archive inspector not initialized"
+	// ErrCancellingProjectTask error message when a task of a project that is forcibly deleted cannot be cancelled
+	ErrCancellingProjectTask = "error cancelling project task"
+	// ErrCreateProjectRequestNotProvided error message when the request to create a project is not provided
+	ErrCreateProjectRequestNotProvided = "create project request not provided"
+	// ErrCreatingProjectUpload error message when a project upload cannot be created
+	ErrCreatingProjectUpload = "error creating project upload"
 	// ErrDeletingProject error message when deleting project fails
 	ErrDeletingProject = "deleting project fails"
+	// ErrDiscoveringProjectContents error message when the Ansible content of the project source code cannot be discovered
+	ErrDiscoveringProjectContents = "error discovering project contents"
+	// ErrFindingDeletedProject error message when a deleted project is not found in the trash
+	ErrFindingDeletedProject = "error finding deleted project"
+	// ErrFindingDeletedProjects error message when the deleted projects to purge cannot be found
+	ErrFindingDeletedProjects = "error finding deleted projects"
+	// ErrFindingExpiredProjectUploads error message when the project uploads to purge cannot be found
+	ErrFindingExpiredProjectUploads = "error finding expired project uploads"
 	// ErrFindingProject error message when a project is not found
 	ErrFindingProject = "error finding project"
+	// ErrFindingProjectTasks error message when the tasks of a project cannot be found
+	ErrFindingProjectTasks = "error finding project tasks"
+	// ErrFindingProjectUpload error message when a project upload is not found
+	ErrFindingProjectUpload = "error finding project upload"
+	// ErrImportingProject error message when a project found in an import path cannot be imported
+	ErrImportingProject = "error importing project"
+	// ErrInspectingProjectContent error message when the project source code does not pass the archive inspection
+	ErrInspectingProjectContent = "error inspecting project content"
+	// ErrInvalidCreateProjectMode error message when the mode of the request to create a project is not valid
+	ErrInvalidCreateProjectMode = "invalid create project mode"
+	// ErrInvalidProjectDigest error message when the expected digest of the project source code is not valid
+	ErrInvalidProjectDigest = "invalid project digest"
+	// ErrInvalidProjectSignature error message when the signature of the project source code is not valid
+	ErrInvalidProjectSignature = "invalid project signature"
+	// ErrInvalidProjectFilePath error message when the path of a project file is not valid
+	ErrInvalidProjectFilePath = "invalid project file path"
+	// ErrInvalidProjectQuery error message when the project query is not valid
+	ErrInvalidProjectQuery = "invalid project query"
+	// ErrInvalidProjectUploadLength error message when the length of a project upload is not valid
+	ErrInvalidProjectUploadLength = "invalid project upload length"
+	// ErrInvalidProjectUploadOffset error message when the offset of a project upload chunk is not valid
+	ErrInvalidProjectUploadOffset = "invalid project upload offset"
+	// ErrInvalidProjectVersion error message when the project version is not valid
+	ErrInvalidProjectVersion = "invalid project version"
+	// ErrInvalidProjectOCILayout error message when the uploaded OCI image layout of a project is not valid
+	ErrInvalidProjectOCILayout = "invalid project OCI image layout"
+	// ErrInvalidProjectOCISource error message when the OCI artifact of a project is not valid
+	ErrInvalidProjectOCISource = "invalid project OCI artifact"
+	// ErrOCIResolverNotInitialized error message when the OCI artifact resolver is not initialized
+	ErrOCIResolverNotInitialized = "OCI artifact resolver not initialized"
 	// ErrOpeningProjectFile error message when opening project file fails
 	ErrOpeningProjectFile = "opening project file fails"
+	// ErrOpeningProjectImportSource error message when the source code of a project found in an import path cannot be opened
+	ErrOpeningProjectImportSource = "error opening project import source"
+	// ErrOpeningProjectUpload error message when the source code staged by a project upload cannot be opened
+	ErrOpeningProjectUpload = "error opening project upload"
 	// ErrProjectAlreadyExists error message when project already exists
 	ErrProjectAlreadyExists = "project already exists"
 	// ErrProjectContentReaderNotProvided error message when project content reader is not provided
 	ErrProjectContentReaderNotProvided = "project content reader not provided"
+	// ErrProjectContentNotSeekable error message when the project content must be read twice but its reader cannot be rewound
+	ErrProjectContentNotSeekable = "project content reader cannot be rewound"
+	// ErrProjectCreatorNotInitialized error message when the project creation service is not initialized
+	ErrProjectCreatorNotInitialized = "project creation service not initialized"
+	// ErrProjectDigestMismatch error message when the digest of the uploaded source code does not match the expected one
+	ErrProjectDigestMismatch = "project digest mismatch"
 	// ErrProjectFormatNotProvided error message when format is not provided
 	ErrProjectFormatNotProvided = "format not provided"
+	// ErrProjectFormatNotDetected error message when the format of the project source code cannot be detected
+	ErrProjectFormatNotDetected = "project format could not be detected"
 	// ErrProjectFormatNotSupported error message when format is not supported
 	ErrProjectFormatNotSupported = "format not supported"
+	// ErrProjectGitSourceNotProvided error message when the git repository of a project is not provided
+	ErrProjectGitSourceNotProvided = "project git repository not provided"
+	// ErrProjectOCISourceNotProvided error message when the OCI artifact of a project is not provided
+	ErrProjectOCISourceNotProvided = "project OCI artifact not provided"
+	// ErrProjectHasActiveTasks error message when a project is deleted while it has tasks that are not finished
+	ErrProjectHasActiveTasks = "project has tasks that are not finished"
 	// ErrProjectIDNotProvided error message when the project id is not provided
 	ErrProjectIDNotProvided = "project id not provided"
+	// ErrProjectImportConflict error message when an imported project is already registered with a different source code
+	ErrProjectImportConflict = "project already registered with a different source code"
+	// ErrProjectImportScannerNotInitialized error message when the project import scanner is not initialized
+	ErrProjectImportScannerNotInitialized = "project import scanner not initialized"
+	// ErrInvalidProjectGitSource error message when the git repository of a project is not valid
+	ErrInvalidProjectGitSource = "invalid project git repository"
+	// ErrProjectUploadBusy error message when a project upload is still receiving another chunk or being finalized
+	ErrProjectUploadBusy = "project upload is busy receiving another chunk or being finalized"
+	// ErrProjectUploadChunkNotProvided error message when the chunk of a project upload is not provided
+	ErrProjectUploadChunkNotProvided = "project upload chunk not provided"
+	// ErrProjectUploadChunkTooLarge error message when a chunk exceeds the length of the project upload
+	ErrProjectUploadChunkTooLarge = "project upload chunk exceeds the upload length"
+	// ErrProjectUploadExpired error message when a project upload has expired
+	ErrProjectUploadExpired = "project upload expired"
+	// ErrProjectUploadFinalizerNotProvided error message when the function to finalize a project upload is not provided
+	ErrProjectUploadFinalizerNotProvided = "project upload finalizer not provided"
+	// ErrProjectUploadIDNotProvided error message when the project upload id is not provided
+	ErrProjectUploadIDNotProvided = "project upload id not provided"
+	// ErrProjectUploadIncomplete error message when a project upload is finalized before all its bytes are received
+	ErrProjectUploadIncomplete = "project upload incomplete"
+	// ErrProjectUploadOffsetMismatch error message when a chunk is not sent at the offset of the project upload
+	ErrProjectUploadOffsetMismatch = "project upload offset mismatch"
+	// ErrProjectUploadRepositoryNotInitialized error message when the project upload repository is not initialized
+	ErrProjectUploadRepositoryNotInitialized = "project upload repository not initialized"
+	// ErrProjectUploadServiceNotInitialized error message when the project upload service is not initialized
+	ErrProjectUploadServiceNotInitialized = "project upload service not initialized"
+	// ErrPurgingProject error message when a deleted project cannot be purged
+	ErrPurgingProject = "error purging deleted project"
+	// ErrPurgingProjectUpload error message when an expired project upload cannot be purged
+	ErrPurgingProjectUpload = "error purging project upload"
+	// ErrRemovingProjectUpload error message when a project upload cannot be removed
+	ErrRemovingProjectUpload = "error removing project upload"
+	// ErrRestoringProject error message when a deleted project cannot be restored
+	ErrRestoringProject = "error restoring project"
+	// ErrResolvingProjectOCIReference error message when the OCI artifact reference of a project cannot be resolved
+	ErrResolvingProjectOCIReference = "error resolving project OCI artifact reference"
+	// ErrReadingProjectContent error message when the project source code cannot be read
+	ErrReadingProjectContent = "error reading project content"
+	// ErrReadingProjectSourceCode error message when the stored source code of a project cannot be read
+	ErrReadingProjectSourceCode = "error reading project source code"
+	// ErrProjectRevisionMismatch error message when the revision of the project to replace does not match the expected revision
+	ErrProjectRevisionMismatch = "project revision does not match the expected revision"
 	// ErrProjectRepositoryNotInitialized error message when project repository is not initialized
 	ErrProjectRepositoryNotInitialized = "project repository not initialized"
+	// ErrProjectSourceCodeNotReplaceable error message when the source code of a project that is not uploaded is replaced
+	ErrProjectSourceCodeNotReplaceable = "only the uploaded source code of a project can be replaced"
 	// ErrProjectStorageNotProvided error message when storage is not provided
 	ErrProjectStorageNotProvided = "storage not provided"
 	// ErrProjectStorageNotSupported error message when storage is not supported
 	ErrProjectStorageNotSupported = "storage not supported"
+	// ErrProjectVersionAlreadyExists error message when the project version already exists
+	ErrProjectVersionAlreadyExists = "project version already exists"
+	// ErrProjectVersionNotFound error message when the project version is not found
+	ErrProjectVersionNotFound = "project version not found"
+	// ErrProjectVersionNotProvided error message when the project version is not provided
+	ErrProjectVersionNotProvided = "project version not provided"
+	// ErrProjectVersionNotReplaceable error message when the version to replace is not the most recent version of the project
+	ErrProjectVersionNotReplaceable = "only the most recent version of a project can be replaced"
+	// ErrProjectVersionReserved error message when the project version is reserved
+	ErrProjectVersionReserved = "project version is reserved"
+	// ErrRemovingLastProjectVersion error message when removing the only version of a project
+	ErrRemovingLastProjectVersion = "the only version of a project cannot be removed"
+	// ErrRemovingProjectSourceCode error message when the source code of a project that could not be created, or that has been replaced, cannot be removed
+	ErrRemovingProjectSourceCode = "error removing project source code"
+	// ErrScanningProjectImportPath error message when an import path cannot be scanned for projects
+	ErrScanningProjectImportPath = "error scanning project import path"
+	// ErrSourceCodeBrowserNotInitialized error message when the source code browser is not initialized
+	ErrSourceCodeBrowserNotInitialized = "source code browser not initialized"
 	// ErrStorageHandlerNotFound error message when storage handler is not found
 	ErrStorageHandlerNotFound = "storage handler not found"
 	// ErrStorageHandlerNotInitialized error message when storage handler is not initialized
 	ErrStorageHandlerNotInitialized = "storage handler not initialized"
 	// ErrStoringProject error message when storing project fails
 	ErrStoringProject = "storing project fails"
+	// ErrTaskCancellerNotInitialized error message when the task cancellation service is not initialized
+	ErrTaskCancellerNotInitialized = "task cancellation service not initialized"
+	// ErrUpdatingProjectUpload error message when a project upload cannot be updated
+	ErrUpdatingProjectUpload = "error updating project upload"
+	// ErrValidatingProjectManifest error message when the project manifest cannot be parsed or it is not consistent with the project contents
+	ErrValidatingProjectManifest = "error validating project manifest"
 )
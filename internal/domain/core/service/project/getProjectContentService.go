@@ -0,0 +1,164 @@
+package project
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	domainerror "github.com/apenella/ransidble/internal/domain/core/error"
+	"github.com/apenella/ransidble/internal/domain/ports/repository"
+)
+
+// GetProjectContentService is a service to read the source code stored for a project
+type GetProjectContentService struct {
+	browser    repository.SourceCodeBrowser
+	repository repository.ProjectRepository
+	logger     repository.Logger
+}
+
+// NewGetProjectContentService creates a new GetProjectContentService
+func NewGetProjectContentService(repository repository.ProjectRepository, browser repository.SourceCodeBrowser, logger repository.Logger) *GetProjectContentService {
+	return &GetProjectContentService{
+		browser:    browser,
+		repository: repository,
+		logger:     logger,
+	}
+}
+
+// GetProjectContent returns the stored source code of a project version. The most recent version is read when the version is not provided or it is the latest alias
+func (p *GetProjectContentService) GetProjectContent(ctx context.Context, id string, version string) (*entity.ProjectContent, error) {
+
+	project, err := p.findProject("GetProjectContentService.GetProjectContent", id, version)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := p.browser.Content(ctx, project)
+	if err != nil {
+		return nil, p.readingError("GetProjectContentService.GetProjectContent", project, err)
+	}
+
+	return content, nil
+}
+
+// GetProjectFiles returns the entries of the project tree of a project version. The most recent version is read when the version is not provided or it is the latest alias
+func (p *GetProjectContentService) GetProjectFiles(ctx context.Context, id string, version string) ([]*entity.ProjectFile, error) {
+
+	project, err := p.findProject("GetProjectContentService.GetProjectFiles", id, version)
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := p.browser.Files(ctx, project)
+	if err != nil {
+		return nil, p.readingError("GetProjectContentService.GetProjectFiles", project, err)
+	}
+
+	return files, nil
+}
+
+// GetProjectFile returns a file of the project tree of a project version. The most recent version is read when the version is not provided or it is the latest alias
+func (p *GetProjectContentService) GetProjectFile(ctx context.Context, id string, version string, path string) (*entity.ProjectContent, error) {
+
+	_, err := entity.CleanProjectFilePath(path)
+	if err != nil {
+		p.logger.Error(fmt.Sprintf("%s: %s", ErrInvalidProjectFilePath, err.Error()), map[string]interface{}{
+			"component":  "GetProjectContentService.GetProjectFile",
+			"package":    "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"project_id": id,
+		})
+		return nil, domainerror.NewProjectInvalidFilePathError(
+			fmt.Errorf("%s: %w", ErrInvalidProjectFilePath, err),
+		)
+	}
+
+	project, err := p.findProject("GetProjectContentService.GetProjectFile", id, version)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := p.browser.ReadFile(ctx, project, path)
+	if err != nil {
+		return nil, p.readingError("GetProjectContentService.GetProjectFile", project, err)
+	}
+
+	return content, nil
+}
+
+// findProject returns the project version to read, resolving the latest alias to the most recent version of the project
+func (p *GetProjectContentService) findProject(component string, id string, version string) (*entity.Project, error) {
+	var project *entity.Project
+	var err error
+
+	if p.repository == nil {
+		p.logger.Error(ErrProjectRepositoryNotInitialized, map[string]interface{}{
+			"component":  component,
+			"package":    "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"project_id": id,
+		})
+		return nil, fmt.Errorf(ErrProjectRepositoryNotInitialized)
+	}
+
+	if p.browser == nil {
+		p.logger.Error(ErrSourceCodeBrowserNotInitialized, map[string]interface{}{
+			"component":  component,
+			"package":    "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"project_id": id,
+		})
+		return nil, fmt.Errorf(ErrSourceCodeBrowserNotInitialized)
+	}
+
+	if id == "" {
+		p.logger.Error(ErrProjectIDNotProvided, map[string]interface{}{
+			"component": component,
+			"package":   "github.com/apenella/ransidble/internal/domain/core/service/project",
+		})
+		return nil, domainerror.NewProjectNotProvidedError(
+			fmt.Errorf(ErrProjectIDNotProvided),
+		)
+	}
+
+	if version == "" || version == entity.LatestVersion {
+		project, err = p.repository.Find(id)
+	} else {
+		project, err = p.repository.FindVersion(id, version)
+	}
+	if err != nil {
+		p.logger.Error(fmt.Sprintf("%s: %s", ErrFindingProject, err.Error()), map[string]interface{}{
+			"component":       component,
+			"package":         "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"project_id":      id,
+			"project_version": version,
+		})
+		return nil, domainerror.NewProjectNotFoundError(
+			fmt.Errorf("%s: %w", ErrFindingProject, err),
+		)
+	}
+
+	return project, nil
+}
+
+// readingError logs the error returned when the source code of a project cannot be read and returns it as the matching domain error
+func (p *GetProjectContentService) readingError(component string, project *entity.Project, err error) error {
+
+	p.logger.Error(fmt.Sprintf("%s: %s", ErrReadingProjectSourceCode, err.Error()), map[string]interface{}{
+		"component":       component,
+		"package":         "github.com/apenella/ransidble/internal/domain/core/service/project",
+		"project_id":      project.Name,
+		"project_version": project.Version,
+	})
+
+	err = fmt.Errorf("%s: %w", ErrReadingProjectSourceCode, err)
+
+	switch {
+	case errors.Is(err, entity.ErrInvalidProjectFilePath):
+		return domainerror.NewProjectInvalidFilePathError(err)
+	case errors.Is(err, entity.ErrProjectFileNotFound):
+		return domainerror.NewProjectFileNotFoundError(err)
+	case errors.Is(err, entity.ErrProjectContentNotArchived):
+		return domainerror.NewProjectContentNotArchivedError(err)
+	}
+
+	return err
+}
@@ -0,0 +1,257 @@
+package project
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	domainerror "github.com/apenella/ransidble/internal/domain/core/error"
+	"github.com/apenella/ransidble/internal/domain/ports/repository"
+	"github.com/apenella/ransidble/internal/infrastructure/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetProjectContent(t *testing.T) {
+
+	project := &entity.Project{Name: "project-id", Format: "targz", Reference: "project-id.tar.gz", Storage: "local", Version: "v2"}
+	projectVersion := &entity.Project{Name: "project-id", Format: "targz", Reference: "project-id@v1.tar.gz", Storage: "local", Version: "v1"}
+
+	tests := []struct {
+		desc        string
+		id          string
+		version     string
+		err         error
+		expected    string
+		service     *GetProjectContentService
+		arrangeFunc func(*testing.T, *GetProjectContentService)
+	}{
+		{
+			desc:     "Testing getting the content of the most recent version of a project on the GetProjectContentService",
+			id:       "project-id",
+			version:  entity.LatestVersion,
+			expected: "project-id.tar.gz",
+			service:  NewGetProjectContentService(repository.NewMockProjectRepository(), repository.NewMockProjectSourceCodeBrowser(), logger.NewFakeLogger()),
+			arrangeFunc: func(t *testing.T, service *GetProjectContentService) {
+				service.repository.(*repository.MockProjectRepository).On("Find", "project-id").Return(project, nil)
+				service.browser.(*repository.MockProjectSourceCodeBrowser).On("Content", mock.Anything, project).Return(&entity.ProjectContent{
+					Name:   "project-id.tar.gz",
+					Reader: io.NopCloser(strings.NewReader("content")),
+					Size:   7,
+				}, nil)
+			},
+		},
+		{
+			desc:     "Testing getting the content of a version of a project on the GetProjectContentService",
+			id:       "project-id",
+			version:  "v1",
+			expected: "project-id@v1.tar.gz",
+			service:  NewGetProjectContentService(repository.NewMockProjectRepository(), repository.NewMockProjectSourceCodeBrowser(), logger.NewFakeLogger()),
+			arrangeFunc: func(t *testing.T, service *GetProjectContentService) {
+				service.repository.(*repository.MockProjectRepository).On("FindVersion", "project-id", "v1").Return(projectVersion, nil)
+				service.browser.(*repository.MockProjectSourceCodeBrowser).On("Content", mock.Anything, projectVersion).Return(&entity.ProjectContent{
+					Name:   "project-id@v1.tar.gz",
+					Reader: io.NopCloser(strings.NewReader("content")),
+					Size:   7,
+				}, nil)
+			},
+		},
+		{
+			desc:    "Testing error getting the content of a project on the GetProjectContentService having a nil project repository",
+			id:      "project-id",
+			err:     fmt.Errorf(ErrProjectRepositoryNotInitialized),
+			service: NewGetProjectContentService(nil, repository.NewMockProjectSourceCodeBrowser(), logger.NewFakeLogger()),
+		},
+		{
+			desc:    "Testing error getting the content of a project on the GetProjectContentService having a nil source code browser",
+			id:      "project-id",
+			err:     fmt.Errorf(ErrSourceCodeBrowserNotInitialized),
+			service: NewGetProjectContentService(repository.NewMockProjectRepository(), nil, logger.NewFakeLogger()),
+		},
+		{
+			desc: "Testing error getting the content of a project on the GetProjectContentService having an empty project id",
+			err: domainerror.NewProjectNotProvidedError(
+				fmt.Errorf(ErrProjectIDNotProvided),
+			),
+			service: NewGetProjectContentService(repository.NewMockProjectRepository(), repository.NewMockProjectSourceCodeBrowser(), logger.NewFakeLogger()),
+		},
+		{
+			desc:    "Testing error getting the content of a project version that does not exist on the GetProjectContentService",
+			id:      "project-id",
+			version: "v3",
+			err: domainerror.NewProjectNotFoundError(
+				fmt.Errorf("%s: %w", ErrFindingProject, errors.New("version not found")),
+			),
+			service: NewGetProjectContentService(repository.NewMockProjectRepository(), repository.NewMockProjectSourceCodeBrowser(), logger.NewFakeLogger()),
+			arrangeFunc: func(t *testing.T, service *GetProjectContentService) {
+				service.repository.(*repository.MockProjectRepository).On("FindVersion", "project-id", "v3").Return(nil, errors.New("version not found"))
+			},
+		},
+		{
+			desc: "Testing error getting the content of a project that is not stored as an archive on the GetProjectContentService",
+			id:   "project-id",
+			err: domainerror.NewProjectContentNotArchivedError(
+				fmt.Errorf("%s: %w", ErrReadingProjectSourceCode, entity.ErrProjectContentNotArchived),
+			),
+			service: NewGetProjectContentService(repository.NewMockProjectRepository(), repository.NewMockProjectSourceCodeBrowser(), logger.NewFakeLogger()),
+			arrangeFunc: func(t *testing.T, service *GetProjectContentService) {
+				service.repository.(*repository.MockProjectRepository).On("Find", "project-id").Return(project, nil)
+				service.browser.(*repository.MockProjectSourceCodeBrowser).On("Content", mock.Anything, project).Return(nil, entity.ErrProjectContentNotArchived)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			if test.arrangeFunc != nil {
+				test.arrangeFunc(t, test.service)
+			}
+
+			content, err := test.service.GetProjectContent(context.Background(), test.id, test.version)
+			if test.err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expected, content.Name)
+			}
+		})
+	}
+}
+
+func TestGetProjectFiles(t *testing.T) {
+
+	project := &entity.Project{Name: "project-id", Format: "targz", Reference: "project-id.tar.gz", Storage: "local", Version: "v1"}
+	files := []*entity.ProjectFile{
+		{Path: "inventory", Type: entity.ProjectFileTypeDirectory},
+		{Path: "inventory/hosts.yml", Size: 7, Type: entity.ProjectFileTypeFile},
+		{Path: "site.yml", Size: 12, Type: entity.ProjectFileTypeFile},
+	}
+
+	tests := []struct {
+		desc        string
+		id          string
+		err         error
+		expected    []*entity.ProjectFile
+		service     *GetProjectContentService
+		arrangeFunc func(*testing.T, *GetProjectContentService)
+	}{
+		{
+			desc:     "Testing getting the files of a project on the GetProjectContentService",
+			id:       "project-id",
+			expected: files,
+			service:  NewGetProjectContentService(repository.NewMockProjectRepository(), repository.NewMockProjectSourceCodeBrowser(), logger.NewFakeLogger()),
+			arrangeFunc: func(t *testing.T, service *GetProjectContentService) {
+				service.repository.(*repository.MockProjectRepository).On("Find", "project-id").Return(project, nil)
+				service.browser.(*repository.MockProjectSourceCodeBrowser).On("Files", mock.Anything, project).Return(files, nil)
+			},
+		},
+		{
+			desc:    "Testing error getting the files of a project when its source code cannot be unpacked on the GetProjectContentService",
+			id:      "project-id",
+			err:     fmt.Errorf("%s: %w", ErrReadingProjectSourceCode, errors.New("error unpacking source code")),
+			service: NewGetProjectContentService(repository.NewMockProjectRepository(), repository.NewMockProjectSourceCodeBrowser(), logger.NewFakeLogger()),
+			arrangeFunc: func(t *testing.T, service *GetProjectContentService) {
+				service.repository.(*repository.MockProjectRepository).On("Find", "project-id").Return(project, nil)
+				service.browser.(*repository.MockProjectSourceCodeBrowser).On("Files", mock.Anything, project).Return(nil, errors.New("error unpacking source code"))
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			if test.arrangeFunc != nil {
+				test.arrangeFunc(t, test.service)
+			}
+
+			files, err := test.service.GetProjectFiles(context.Background(), test.id, "")
+			if test.err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expected, files)
+			}
+		})
+	}
+}
+
+func TestGetProjectFile(t *testing.T) {
+
+	project := &entity.Project{Name: "project-id", Format: "targz", Reference: "project-id.tar.gz", Storage: "local", Version: "v1"}
+
+	tests := []struct {
+		desc        string
+		id          string
+		path        string
+		err         error
+		expected    string
+		service     *GetProjectContentService
+		arrangeFunc func(*testing.T, *GetProjectContentService)
+	}{
+		{
+			desc:     "Testing getting a file of a project on the GetProjectContentService",
+			id:       "project-id",
+			path:     "site.yml",
+			expected: "site.yml",
+			service:  NewGetProjectContentService(repository.NewMockProjectRepository(), repository.NewMockProjectSourceCodeBrowser(), logger.NewFakeLogger()),
+			arrangeFunc: func(t *testing.T, service *GetProjectContentService) {
+				service.repository.(*repository.MockProjectRepository).On("Find", "project-id").Return(project, nil)
+				service.browser.(*repository.MockProjectSourceCodeBrowser).On("ReadFile", mock.Anything, project, "site.yml").Return(&entity.ProjectContent{
+					Name:   "site.yml",
+					Reader: io.NopCloser(strings.NewReader("- hosts: all")),
+					Size:   12,
+				}, nil)
+			},
+		},
+		{
+			desc: "Testing error getting a file outside the project tree on the GetProjectContentService",
+			id:   "project-id",
+			path: "../site.yml",
+			err: domainerror.NewProjectInvalidFilePathError(
+				fmt.Errorf("%s: %w", ErrInvalidProjectFilePath, fmt.Errorf("%w: %q", entity.ErrInvalidProjectFilePath, "../site.yml")),
+			),
+			service: NewGetProjectContentService(repository.NewMockProjectRepository(), repository.NewMockProjectSourceCodeBrowser(), logger.NewFakeLogger()),
+		},
+		{
+			desc: "Testing error getting a file that is not in the project tree on the GetProjectContentService",
+			id:   "project-id",
+			path: "unknown.yml",
+			err: domainerror.NewProjectFileNotFoundError(
+				fmt.Errorf("%s: %w", ErrReadingProjectSourceCode, entity.ErrProjectFileNotFound),
+			),
+			service: NewGetProjectContentService(repository.NewMockProjectRepository(), repository.NewMockProjectSourceCodeBrowser(), logger.NewFakeLogger()),
+			arrangeFunc: func(t *testing.T, service *GetProjectContentService) {
+				service.repository.(*repository.MockProjectRepository).On("Find", "project-id").Return(project, nil)
+				service.browser.(*repository.MockProjectSourceCodeBrowser).On("ReadFile", mock.Anything, project, "unknown.yml").Return(nil, entity.ErrProjectFileNotFound)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			if test.arrangeFunc != nil {
+				test.arrangeFunc(t, test.service)
+			}
+
+			content, err := test.service.GetProjectFile(context.Background(), test.id, "", test.path)
+			if test.err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expected, content.Name)
+			}
+		})
+	}
+}
@@ -46,7 +46,7 @@ func (p *GetProjectService) GetProject(id string) (*entity.Project, error) {
 
 	project, err := p.repository.Find(id)
 	if err != nil {
-		p.logger.Error("%s: %s", ErrFindingProject, err.Error(), map[string]interface{}{
+		p.logger.Error(fmt.Sprintf("%s: %s", ErrFindingProject, err.Error()), map[string]interface{}{
 			"component":  "GetProjectService.GetProject",
 			"package":    "github.com/apenella/ransidble/internal/domain/core/service/project",
 			"project_id": id,
@@ -59,21 +59,36 @@ func (p *GetProjectService) GetProject(id string) (*entity.Project, error) {
 	return project, nil
 }
 
-// GetProjectsList returns all projects
-func (p *GetProjectService) GetProjectsList() ([]*entity.Project, error) {
+// GetProjectsList returns the page of projects that fulfills the query. When the query is not provided, the first page of the projects is returned
+func (p *GetProjectService) GetProjectsList(query *entity.ProjectQuery) (*entity.ProjectPage, error) {
 
 	if p.repository == nil {
 		p.logger.Error(ErrProjectRepositoryNotInitialized, map[string]interface{}{
-			"component": "GetProjectService.GetProject",
+			"component": "GetProjectService.GetProjectsList",
 			"package":   "github.com/apenella/ransidble/internal/domain/core/service/project",
 		})
 		return nil, fmt.Errorf(ErrProjectRepositoryNotInitialized)
 	}
 
-	projects, err := p.repository.FindAll()
+	if query == nil {
+		query = entity.NewProjectQuery()
+	}
+
+	err := query.Validate()
 	if err != nil {
-		p.logger.Error("%s: %s", ErrFindingProject, err.Error(), map[string]interface{}{
-			"component": "GetProjectService.GetProject",
+		p.logger.Error(fmt.Sprintf("%s: %s", ErrInvalidProjectQuery, err.Error()), map[string]interface{}{
+			"component": "GetProjectService.GetProjectsList",
+			"package":   "github.com/apenella/ransidble/internal/domain/core/service/project",
+		})
+		return nil, domainerror.NewProjectInvalidQueryError(
+			fmt.Errorf("%s: %w", ErrInvalidProjectQuery, err),
+		)
+	}
+
+	page, err := p.repository.Search(query)
+	if err != nil {
+		p.logger.Error(fmt.Sprintf("%s: %s", ErrFindingProject, err.Error()), map[string]interface{}{
+			"component": "GetProjectService.GetProjectsList",
 			"package":   "github.com/apenella/ransidble/internal/domain/core/service/project",
 		})
 		return nil, domainerror.NewProjectNotFoundError(
@@ -81,5 +96,42 @@ func (p *GetProjectService) GetProjectsList() ([]*entity.Project, error) {
 		)
 	}
 
-	return projects, nil
+	return page, nil
+}
+
+// GetProjectVersions returns the versions of a project sorted from the oldest to the most recent
+func (p *GetProjectService) GetProjectVersions(id string) ([]*entity.Project, error) {
+
+	if p.repository == nil {
+		p.logger.Error(ErrProjectRepositoryNotInitialized, map[string]interface{}{
+			"component":  "GetProjectService.GetProjectVersions",
+			"package":    "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"project_id": id,
+		})
+		return nil, fmt.Errorf(ErrProjectRepositoryNotInitialized)
+	}
+
+	if id == "" {
+		p.logger.Error(ErrProjectIDNotProvided, map[string]interface{}{
+			"component": "GetProjectService.GetProjectVersions",
+			"package":   "github.com/apenella/ransidble/internal/domain/core/service/project",
+		})
+		return nil, domainerror.NewProjectNotProvidedError(
+			fmt.Errorf(ErrProjectIDNotProvided),
+		)
+	}
+
+	versions, err := p.repository.FindVersions(id)
+	if err != nil {
+		p.logger.Error(fmt.Sprintf("%s: %s", ErrFindingProject, err.Error()), map[string]interface{}{
+			"component":  "GetProjectService.GetProjectVersions",
+			"package":    "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"project_id": id,
+		})
+		return nil, domainerror.NewProjectNotFoundError(
+			fmt.Errorf("%s: %w", ErrFindingProject, err),
+		)
+	}
+
+	return versions, nil
 }
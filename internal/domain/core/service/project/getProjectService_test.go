@@ -108,39 +108,67 @@ func TestGetProjectsList(t *testing.T) {
 
 	tests := []struct {
 		desc        string
+		query       *entity.ProjectQuery
 		err         error
-		expected    []*entity.Project
+		expected    *entity.ProjectPage
 		service     *GetProjectService
 		arrangeFunc func(*testing.T, *GetProjectService)
 	}{
 		{
-			desc: "Testing getting a project list on the GetProjectService",
-			err:  errors.New(""),
-			expected: []*entity.Project{
-				{
-					Name:      "project-id",
-					Reference: "project-id",
-					Format:    "plain",
-					Storage:   "local",
+			desc:  "Testing getting a project list on the GetProjectService",
+			query: &entity.ProjectQuery{Limit: 1, NamePrefix: "project"},
+			err:   errors.New(""),
+			expected: &entity.ProjectPage{
+				NextCursor: "cursor",
+				Projects: []*entity.Project{
+					{
+						Name:      "project-id",
+						Reference: "project-id",
+						Format:    "plain",
+						Storage:   "local",
+					},
 				},
+				Total: 2,
 			},
 			service: NewGetProjectService(
 				repository.NewMockProjectRepository(),
 				logger.NewFakeLogger(),
 			),
 			arrangeFunc: func(t *testing.T, service *GetProjectService) {
-				service.repository.(*repository.MockProjectRepository).On("FindAll").Return([]*entity.Project{
-					{
-						Name:      "project-id",
-						Reference: "project-id",
-						Format:    "plain",
-						Storage:   "local",
+				service.repository.(*repository.MockProjectRepository).On("Search", &entity.ProjectQuery{Limit: 1, NamePrefix: "project"}).Return(&entity.ProjectPage{
+					NextCursor: "cursor",
+					Projects: []*entity.Project{
+						{
+							Name:      "project-id",
+							Reference: "project-id",
+							Format:    "plain",
+							Storage:   "local",
+						},
 					},
+					Total: 2,
+				}, nil)
+			},
+		},
+		{
+			desc:  "Testing getting a project list on the GetProjectService using the default query when it is not provided",
+			query: nil,
+			err:   errors.New(""),
+			expected: &entity.ProjectPage{
+				Projects: []*entity.Project{},
+			},
+			service: NewGetProjectService(
+				repository.NewMockProjectRepository(),
+				logger.NewFakeLogger(),
+			),
+			arrangeFunc: func(t *testing.T, service *GetProjectService) {
+				service.repository.(*repository.MockProjectRepository).On("Search", entity.NewProjectQuery()).Return(&entity.ProjectPage{
+					Projects: []*entity.Project{},
 				}, nil)
 			},
 		},
 		{
 			desc:     "Testing error getting a project list on the GetProjectService having a nil project repository",
+			query:    entity.NewProjectQuery(),
 			err:      fmt.Errorf(ErrProjectRepositoryNotInitialized),
 			expected: nil,
 			service: NewGetProjectService(
@@ -150,7 +178,21 @@ func TestGetProjectsList(t *testing.T) {
 			arrangeFunc: nil,
 		},
 		{
-			desc: "Testing error getting a project list on the GetProjectService having an error on find project into the repository",
+			desc:  "Testing error getting a project list on the GetProjectService having an invalid query",
+			query: &entity.ProjectQuery{Cursor: "%%%"},
+			err: domainerror.NewProjectInvalidQueryError(
+				fmt.Errorf("%s: %w", ErrInvalidProjectQuery, entity.ErrInvalidProjectQueryCursor),
+			),
+			expected: nil,
+			service: NewGetProjectService(
+				repository.NewMockProjectRepository(),
+				logger.NewFakeLogger(),
+			),
+			arrangeFunc: nil,
+		},
+		{
+			desc:  "Testing error getting a project list on the GetProjectService having an error on find project into the repository",
+			query: entity.NewProjectQuery(),
 			err: domainerror.NewProjectNotFoundError(
 				fmt.Errorf("%s: %s", ErrFindingProject, errors.New("error finding project")),
 			),
@@ -160,7 +202,7 @@ func TestGetProjectsList(t *testing.T) {
 				logger:     logger.NewFakeLogger(),
 			},
 			arrangeFunc: func(t *testing.T, service *GetProjectService) {
-				service.repository.(*repository.MockProjectRepository).On("FindAll").Return(nil, errors.New("error finding project"))
+				service.repository.(*repository.MockProjectRepository).On("Search", entity.NewProjectQuery()).Return(nil, errors.New("error finding project"))
 			},
 		},
 	}
@@ -174,13 +216,86 @@ func TestGetProjectsList(t *testing.T) {
 				test.arrangeFunc(t, test.service)
 			}
 
-			projects, err := test.service.GetProjectsList()
+			page, err := test.service.GetProjectsList(test.query)
 			if err != nil {
 				assert.Equal(t, test.err, err)
 			} else {
-				assert.Equal(t, test.expected, projects)
+				assert.Equal(t, test.expected, page)
 			}
 		})
 	}
 
 }
+
+func TestGetProjectVersions(t *testing.T) {
+
+	versions := []*entity.Project{
+		{Name: "project-id", Reference: "project-id.tar.gz", Format: "targz", Storage: "local", Version: "v1"},
+		{Name: "project-id", Reference: "project-id@v2.tar.gz", Format: "targz", Storage: "local", Version: "v2"},
+	}
+
+	tests := []struct {
+		desc        string
+		id          string
+		err         error
+		expected    []*entity.Project
+		service     *GetProjectService
+		arrangeFunc func(*testing.T, *GetProjectService)
+	}{
+		{
+			desc:     "Testing getting the versions of a project on the GetProjectService",
+			id:       "project-id",
+			expected: versions,
+			service: NewGetProjectService(
+				repository.NewMockProjectRepository(),
+				logger.NewFakeLogger(),
+			),
+			arrangeFunc: func(t *testing.T, service *GetProjectService) {
+				service.repository.(*repository.MockProjectRepository).On("FindVersions", "project-id").Return(versions, nil)
+			},
+		},
+		{
+			desc: "Testing error getting the versions of a project on the GetProjectService when the project id is not provided",
+			err: domainerror.NewProjectNotProvidedError(
+				fmt.Errorf(ErrProjectIDNotProvided),
+			),
+			service: NewGetProjectService(
+				repository.NewMockProjectRepository(),
+				logger.NewFakeLogger(),
+			),
+		},
+		{
+			desc: "Testing error getting the versions of a project on the GetProjectService when the project does not exist",
+			id:   "project-id",
+			err: domainerror.NewProjectNotFoundError(
+				fmt.Errorf("%s: %w", ErrFindingProject, errors.New("record not found")),
+			),
+			service: NewGetProjectService(
+				repository.NewMockProjectRepository(),
+				logger.NewFakeLogger(),
+			),
+			arrangeFunc: func(t *testing.T, service *GetProjectService) {
+				service.repository.(*repository.MockProjectRepository).On("FindVersions", "project-id").Return(nil, errors.New("record not found"))
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			if test.arrangeFunc != nil {
+				test.arrangeFunc(t, test.service)
+			}
+
+			projects, err := test.service.GetProjectVersions(test.id)
+			if test.err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expected, projects)
+			}
+		})
+	}
+}
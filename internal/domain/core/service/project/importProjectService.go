@@ -0,0 +1,196 @@
+package project
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	"github.com/apenella/ransidble/internal/domain/ports/repository"
+	"github.com/apenella/ransidble/internal/domain/ports/service"
+)
+
+// projectImportUnchanged is the reason reported for the projects already registered with the same source code
+const projectImportUnchanged = "project already registered with the same source code"
+
+// ImportProjectService is a service to import the projects found in the filesystem. The projects are created through the project creation service and stored in the local storage. The import is idempotent: the projects already registered with the same source code are skipped, while those registered with a different source code are reported as failed and never overwritten
+type ImportProjectService struct {
+	creator    service.CreateProjectServicer
+	repository repository.ProjectRepository
+	scanner    repository.SourceCodeScanner
+	logger     repository.Logger
+}
+
+// Ensure ImportProjectService implements the ImportProjectServicer interface
+var _ service.ImportProjectServicer = (*ImportProjectService)(nil)
+
+// NewImportProjectService creates a new ImportProjectService
+func NewImportProjectService(creator service.CreateProjectServicer, repository repository.ProjectRepository, scanner repository.SourceCodeScanner, logger repository.Logger) *ImportProjectService {
+	return &ImportProjectService{
+		creator:    creator,
+		repository: repository,
+		scanner:    scanner,
+		logger:     logger,
+	}
+}
+
+// Import imports the projects found in the paths and returns a report of the added, skipped and failed projects. A path that cannot be scanned is reported as failed, and the import goes on with the remaining paths
+func (s *ImportProjectService) Import(paths ...string) (*entity.ProjectImportReport, error) {
+
+	if s.creator == nil {
+		s.logger.Error(ErrProjectCreatorNotInitialized, map[string]interface{}{
+			"component": "ImportProjectService.Import",
+			"package":   "github.com/apenella/ransidble/internal/domain/core/service/project",
+		})
+		return nil, fmt.Errorf(ErrProjectCreatorNotInitialized)
+	}
+
+	if s.repository == nil {
+		s.logger.Error(ErrProjectRepositoryNotInitialized, map[string]interface{}{
+			"component": "ImportProjectService.Import",
+			"package":   "github.com/apenella/ransidble/internal/domain/core/service/project",
+		})
+		return nil, fmt.Errorf(ErrProjectRepositoryNotInitialized)
+	}
+
+	if s.scanner == nil {
+		s.logger.Error(ErrProjectImportScannerNotInitialized, map[string]interface{}{
+			"component": "ImportProjectService.Import",
+			"package":   "github.com/apenella/ransidble/internal/domain/core/service/project",
+		})
+		return nil, fmt.Errorf(ErrProjectImportScannerNotInitialized)
+	}
+
+	report := entity.NewProjectImportReport()
+
+	for _, path := range paths {
+		sources, err := s.scanner.Scan(path)
+		if err != nil {
+			s.logger.Error(fmt.Sprintf("%s: %s", ErrScanningProjectImportPath, err.Error()), map[string]interface{}{
+				"component": "ImportProjectService.Import",
+				"package":   "github.com/apenella/ransidble/internal/domain/core/service/project",
+				"path":      path,
+			})
+			report.Add(&entity.ProjectImportResult{
+				Path:   path,
+				Reason: fmt.Sprintf("%s: %s", ErrScanningProjectImportPath, err.Error()),
+				Status: entity.ProjectImportStatusFailed,
+			})
+			continue
+		}
+
+		for _, source := range sources {
+			report.Add(s.importSource(source))
+		}
+	}
+
+	return report, nil
+}
+
+// importSource registers the project of the import source, unless it is already registered
+func (s *ImportProjectService) importSource(source *entity.ProjectImportSource) *entity.ProjectImportResult {
+
+	result := &entity.ProjectImportResult{
+		Name:    source.Name,
+		Path:    source.Path,
+		Version: source.Version,
+	}
+
+	content, err := s.scanner.Open(source)
+	if err != nil {
+		return s.fail(result, fmt.Sprintf("%s: %s", ErrOpeningProjectImportSource, err.Error()))
+	}
+	defer content.Close()
+
+	digester := entity.NewProjectDigester(content)
+	_, err = io.Copy(io.Discard, digester)
+	if err == nil {
+		_, err = content.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		return s.fail(result, fmt.Sprintf("%s: %s", ErrReadingProjectContent, err.Error()))
+	}
+	digest := digester.Digest()
+
+	registered, _ := s.repository.Find(source.Name)
+	if registered == nil {
+		_, err = s.creator.Create(&entity.CreateProjectRequest{
+			Digest:    digest,
+			Format:    source.Format,
+			Mode:      entity.CreateProjectModeProject,
+			ProjectID: source.Name,
+			Storage:   entity.ProjectTypeLocal,
+			Version:   source.Version,
+		}, content)
+		if err != nil {
+			return s.fail(result, err.Error())
+		}
+		return s.add(result)
+	}
+
+	// the projects imported without a version are created as the fallback version, so they are compared against it rather than against the latest version, which could have been created afterwards
+	version := source.Version
+	if version == "" {
+		version = entity.FallbackVersion
+	}
+
+	registered, _ = s.repository.FindVersion(source.Name, version)
+	if registered == nil {
+		_, err = s.creator.Create(&entity.CreateProjectRequest{
+			Digest:    digest,
+			Format:    source.Format,
+			Mode:      entity.CreateProjectModeVersion,
+			ProjectID: source.Name,
+			Storage:   entity.ProjectTypeLocal,
+			Version:   version,
+		}, content)
+		if err != nil {
+			return s.fail(result, err.Error())
+		}
+		return s.add(result)
+	}
+
+	if registered.Digest != digest {
+		return s.fail(result, ErrProjectImportConflict)
+	}
+
+	result.Reason = projectImportUnchanged
+	result.Status = entity.ProjectImportStatusSkipped
+	s.logger.Info(projectImportUnchanged, map[string]interface{}{
+		"component":       "ImportProjectService.Import",
+		"package":         "github.com/apenella/ransidble/internal/domain/core/service/project",
+		"path":            result.Path,
+		"project_id":      result.Name,
+		"project_version": result.Version,
+	})
+
+	return result
+}
+
+// add sets the result of a registered project
+func (s *ImportProjectService) add(result *entity.ProjectImportResult) *entity.ProjectImportResult {
+	result.Status = entity.ProjectImportStatusAdded
+	s.logger.Info("Project imported", map[string]interface{}{
+		"component":       "ImportProjectService.Import",
+		"package":         "github.com/apenella/ransidble/internal/domain/core/service/project",
+		"path":            result.Path,
+		"project_id":      result.Name,
+		"project_version": result.Version,
+	})
+
+	return result
+}
+
+// fail sets the result of a project that could not be imported
+func (s *ImportProjectService) fail(result *entity.ProjectImportResult, reason string) *entity.ProjectImportResult {
+	result.Reason = reason
+	result.Status = entity.ProjectImportStatusFailed
+	s.logger.Error(fmt.Sprintf("%s: %s", ErrImportingProject, reason), map[string]interface{}{
+		"component":       "ImportProjectService.Import",
+		"package":         "github.com/apenella/ransidble/internal/domain/core/service/project",
+		"path":            result.Path,
+		"project_id":      result.Name,
+		"project_version": result.Version,
+	})
+
+	return result
+}
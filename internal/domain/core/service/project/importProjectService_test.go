@@ -0,0 +1,275 @@
+package project
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	"github.com/apenella/ransidble/internal/domain/ports/repository"
+	"github.com/apenella/ransidble/internal/domain/ports/service"
+	"github.com/apenella/ransidble/internal/infrastructure/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// importSourceContent is the source code read from the import sources of the tests
+type importSourceContent struct {
+	*bytes.Reader
+}
+
+// Close closes the source code
+func (c *importSourceContent) Close() error {
+	return nil
+}
+
+// newImportSourceContent returns the source code of an import source
+func newImportSourceContent(content string) io.ReadSeekCloser {
+	return &importSourceContent{bytes.NewReader([]byte(content))}
+}
+
+func TestImportProjectServiceImport(t *testing.T) {
+	digester := entity.NewProjectDigester(bytes.NewReader([]byte("project content")))
+	_, _ = io.Copy(io.Discard, digester)
+	digest := digester.Digest()
+
+	source := &entity.ProjectImportSource{
+		Format: entity.ProjectFormatTarGz,
+		Name:   "project-1",
+		Path:   "projects/project-1.tar.gz",
+	}
+	versionSource := &entity.ProjectImportSource{
+		Format:  entity.ProjectFormatTarGz,
+		Name:    "project-1",
+		Path:    "projects/project-1@v2.tar.gz",
+		Version: "v2",
+	}
+
+	tests := []struct {
+		desc        string
+		service     *ImportProjectService
+		arrangeFunc func(*testing.T, *ImportProjectService)
+		expected    *entity.ProjectImportReport
+		err         error
+	}{
+		{
+			desc: "Testing importing a project that is not registered on the ImportProjectService",
+			service: NewImportProjectService(
+				service.NewMockCreateProjectService(),
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeScanner(),
+				logger.NewFakeLogger(),
+			),
+			arrangeFunc: func(t *testing.T, s *ImportProjectService) {
+				s.scanner.(*repository.MockProjectSourceCodeScanner).On("Scan", "projects").Return([]*entity.ProjectImportSource{source}, nil)
+				s.scanner.(*repository.MockProjectSourceCodeScanner).On("Open", source).Return(newImportSourceContent("project content"), nil)
+				s.repository.(*repository.MockProjectRepository).On("Find", "project-1").Return(nil, errors.New("record not found"))
+				s.creator.(*service.MockCreateProjectService).On("Create", &entity.CreateProjectRequest{Digest: digest, Format: entity.ProjectFormatTarGz, Mode: entity.CreateProjectModeProject, ProjectID: "project-1", Storage: entity.ProjectTypeLocal}, mock.Anything).Return("", nil)
+			},
+			expected: &entity.ProjectImportReport{
+				Results: []*entity.ProjectImportResult{
+					{Name: "project-1", Path: "projects/project-1.tar.gz", Status: entity.ProjectImportStatusAdded},
+				},
+			},
+		},
+		{
+			desc: "Testing importing a version that is not registered of a registered project on the ImportProjectService",
+			service: NewImportProjectService(
+				service.NewMockCreateProjectService(),
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeScanner(),
+				logger.NewFakeLogger(),
+			),
+			arrangeFunc: func(t *testing.T, s *ImportProjectService) {
+				s.scanner.(*repository.MockProjectSourceCodeScanner).On("Scan", "projects").Return([]*entity.ProjectImportSource{versionSource}, nil)
+				s.scanner.(*repository.MockProjectSourceCodeScanner).On("Open", versionSource).Return(newImportSourceContent("project content"), nil)
+				s.repository.(*repository.MockProjectRepository).On("Find", "project-1").Return(&entity.Project{Name: "project-1", Version: "v1"}, nil)
+				s.repository.(*repository.MockProjectRepository).On("FindVersion", "project-1", "v2").Return(nil, errors.New("project version not found"))
+				s.creator.(*service.MockCreateProjectService).On("Create", &entity.CreateProjectRequest{Digest: digest, Format: entity.ProjectFormatTarGz, Mode: entity.CreateProjectModeVersion, ProjectID: "project-1", Storage: entity.ProjectTypeLocal, Version: "v2"}, mock.Anything).Return("", nil)
+			},
+			expected: &entity.ProjectImportReport{
+				Results: []*entity.ProjectImportResult{
+					{Name: "project-1", Path: "projects/project-1@v2.tar.gz", Status: entity.ProjectImportStatusAdded, Version: "v2"},
+				},
+			},
+		},
+		{
+			desc: "Testing skipping a project registered with the same source code on the ImportProjectService",
+			service: NewImportProjectService(
+				service.NewMockCreateProjectService(),
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeScanner(),
+				logger.NewFakeLogger(),
+			),
+			arrangeFunc: func(t *testing.T, s *ImportProjectService) {
+				s.scanner.(*repository.MockProjectSourceCodeScanner).On("Scan", "projects").Return([]*entity.ProjectImportSource{versionSource}, nil)
+				s.scanner.(*repository.MockProjectSourceCodeScanner).On("Open", versionSource).Return(newImportSourceContent("project content"), nil)
+				s.repository.(*repository.MockProjectRepository).On("Find", "project-1").Return(&entity.Project{Name: "project-1", Version: "v2"}, nil)
+				s.repository.(*repository.MockProjectRepository).On("FindVersion", "project-1", "v2").Return(&entity.Project{Name: "project-1", Version: "v2", Digest: digest}, nil)
+			},
+			expected: &entity.ProjectImportReport{
+				Results: []*entity.ProjectImportResult{
+					{Name: "project-1", Path: "projects/project-1@v2.tar.gz", Reason: projectImportUnchanged, Status: entity.ProjectImportStatusSkipped, Version: "v2"},
+				},
+			},
+		},
+		{
+			desc: "Testing skipping a project imported without a version once a newer version is registered on the ImportProjectService",
+			service: NewImportProjectService(
+				service.NewMockCreateProjectService(),
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeScanner(),
+				logger.NewFakeLogger(),
+			),
+			arrangeFunc: func(t *testing.T, s *ImportProjectService) {
+				s.scanner.(*repository.MockProjectSourceCodeScanner).On("Scan", "projects").Return([]*entity.ProjectImportSource{source}, nil)
+				s.scanner.(*repository.MockProjectSourceCodeScanner).On("Open", source).Return(newImportSourceContent("project content"), nil)
+				s.repository.(*repository.MockProjectRepository).On("Find", "project-1").Return(&entity.Project{Name: "project-1", Version: "v2", Digest: "sha256:0000"}, nil)
+				s.repository.(*repository.MockProjectRepository).On("FindVersion", "project-1", entity.FallbackVersion).Return(&entity.Project{Name: "project-1", Version: "v1", Digest: digest}, nil)
+			},
+			expected: &entity.ProjectImportReport{
+				Results: []*entity.ProjectImportResult{
+					{Name: "project-1", Path: "projects/project-1.tar.gz", Reason: projectImportUnchanged, Status: entity.ProjectImportStatusSkipped},
+				},
+			},
+		},
+		{
+			desc: "Testing importing a project without a version whose fallback version is not registered on the ImportProjectService",
+			service: NewImportProjectService(
+				service.NewMockCreateProjectService(),
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeScanner(),
+				logger.NewFakeLogger(),
+			),
+			arrangeFunc: func(t *testing.T, s *ImportProjectService) {
+				s.scanner.(*repository.MockProjectSourceCodeScanner).On("Scan", "projects").Return([]*entity.ProjectImportSource{source}, nil)
+				s.scanner.(*repository.MockProjectSourceCodeScanner).On("Open", source).Return(newImportSourceContent("project content"), nil)
+				s.repository.(*repository.MockProjectRepository).On("Find", "project-1").Return(&entity.Project{Name: "project-1", Version: "v2"}, nil)
+				s.repository.(*repository.MockProjectRepository).On("FindVersion", "project-1", entity.FallbackVersion).Return(nil, errors.New("project version not found"))
+				s.creator.(*service.MockCreateProjectService).On("Create", &entity.CreateProjectRequest{Digest: digest, Format: entity.ProjectFormatTarGz, Mode: entity.CreateProjectModeVersion, ProjectID: "project-1", Storage: entity.ProjectTypeLocal, Version: entity.FallbackVersion}, mock.Anything).Return("", nil)
+			},
+			expected: &entity.ProjectImportReport{
+				Results: []*entity.ProjectImportResult{
+					{Name: "project-1", Path: "projects/project-1.tar.gz", Status: entity.ProjectImportStatusAdded},
+				},
+			},
+		},
+		{
+			desc: "Testing failing to import a project registered with a different source code on the ImportProjectService",
+			service: NewImportProjectService(
+				service.NewMockCreateProjectService(),
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeScanner(),
+				logger.NewFakeLogger(),
+			),
+			arrangeFunc: func(t *testing.T, s *ImportProjectService) {
+				s.scanner.(*repository.MockProjectSourceCodeScanner).On("Scan", "projects").Return([]*entity.ProjectImportSource{source}, nil)
+				s.scanner.(*repository.MockProjectSourceCodeScanner).On("Open", source).Return(newImportSourceContent("project content"), nil)
+				s.repository.(*repository.MockProjectRepository).On("Find", "project-1").Return(&entity.Project{Name: "project-1", Version: "v1", Digest: "sha256:0000"}, nil)
+				s.repository.(*repository.MockProjectRepository).On("FindVersion", "project-1", entity.FallbackVersion).Return(&entity.Project{Name: "project-1", Version: "v1", Digest: "sha256:0000"}, nil)
+			},
+			expected: &entity.ProjectImportReport{
+				Results: []*entity.ProjectImportResult{
+					{Name: "project-1", Path: "projects/project-1.tar.gz", Reason: ErrProjectImportConflict, Status: entity.ProjectImportStatusFailed},
+				},
+			},
+		},
+		{
+			desc: "Testing failing to import a project that cannot be created on the ImportProjectService",
+			service: NewImportProjectService(
+				service.NewMockCreateProjectService(),
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeScanner(),
+				logger.NewFakeLogger(),
+			),
+			arrangeFunc: func(t *testing.T, s *ImportProjectService) {
+				s.scanner.(*repository.MockProjectSourceCodeScanner).On("Scan", "projects").Return([]*entity.ProjectImportSource{source}, nil)
+				s.scanner.(*repository.MockProjectSourceCodeScanner).On("Open", source).Return(newImportSourceContent("project content"), nil)
+				s.repository.(*repository.MockProjectRepository).On("Find", "project-1").Return(nil, errors.New("record not found"))
+				s.creator.(*service.MockCreateProjectService).On("Create", &entity.CreateProjectRequest{Digest: digest, Format: entity.ProjectFormatTarGz, Mode: entity.CreateProjectModeProject, ProjectID: "project-1", Storage: entity.ProjectTypeLocal}, mock.Anything).Return("", errors.New("error validating project manifest"))
+			},
+			expected: &entity.ProjectImportReport{
+				Results: []*entity.ProjectImportResult{
+					{Name: "project-1", Path: "projects/project-1.tar.gz", Reason: "error validating project manifest", Status: entity.ProjectImportStatusFailed},
+				},
+			},
+		},
+		{
+			desc: "Testing failing to import a project whose source code cannot be opened on the ImportProjectService",
+			service: NewImportProjectService(
+				service.NewMockCreateProjectService(),
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeScanner(),
+				logger.NewFakeLogger(),
+			),
+			arrangeFunc: func(t *testing.T, s *ImportProjectService) {
+				s.scanner.(*repository.MockProjectSourceCodeScanner).On("Scan", "projects").Return([]*entity.ProjectImportSource{source}, nil)
+				s.scanner.(*repository.MockProjectSourceCodeScanner).On("Open", source).Return(nil, errors.New("permission denied"))
+			},
+			expected: &entity.ProjectImportReport{
+				Results: []*entity.ProjectImportResult{
+					{Name: "project-1", Path: "projects/project-1.tar.gz", Reason: fmt.Sprintf("%s: %s", ErrOpeningProjectImportSource, "permission denied"), Status: entity.ProjectImportStatusFailed},
+				},
+			},
+		},
+		{
+			desc: "Testing failing to import the projects of a path that cannot be scanned on the ImportProjectService",
+			service: NewImportProjectService(
+				service.NewMockCreateProjectService(),
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeScanner(),
+				logger.NewFakeLogger(),
+			),
+			arrangeFunc: func(t *testing.T, s *ImportProjectService) {
+				s.scanner.(*repository.MockProjectSourceCodeScanner).On("Scan", "projects").Return(nil, errors.New("no such file or directory"))
+			},
+			expected: &entity.ProjectImportReport{
+				Results: []*entity.ProjectImportResult{
+					{Path: "projects", Reason: fmt.Sprintf("%s: %s", ErrScanningProjectImportPath, "no such file or directory"), Status: entity.ProjectImportStatusFailed},
+				},
+			},
+		},
+		{
+			desc: "Testing error importing projects on the ImportProjectService having a nil project creation service",
+			service: NewImportProjectService(
+				nil,
+				repository.NewMockProjectRepository(),
+				repository.NewMockProjectSourceCodeScanner(),
+				logger.NewFakeLogger(),
+			),
+			err: fmt.Errorf(ErrProjectCreatorNotInitialized),
+		},
+		{
+			desc: "Testing error importing projects on the ImportProjectService having a nil project import scanner",
+			service: NewImportProjectService(
+				service.NewMockCreateProjectService(),
+				repository.NewMockProjectRepository(),
+				nil,
+				logger.NewFakeLogger(),
+			),
+			err: fmt.Errorf(ErrProjectImportScannerNotInitialized),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			if test.arrangeFunc != nil {
+				test.arrangeFunc(t, test.service)
+			}
+
+			report, err := test.service.Import("projects")
+			if test.err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expected, report)
+				test.service.creator.(*service.MockCreateProjectService).AssertExpectations(t)
+			}
+		})
+	}
+}
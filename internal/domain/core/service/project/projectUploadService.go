@@ -0,0 +1,500 @@
+package project
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	domainerror "github.com/apenella/ransidble/internal/domain/core/error"
+	"github.com/apenella/ransidble/internal/domain/ports/repository"
+	"github.com/apenella/ransidble/internal/domain/ports/service"
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultProjectUploadExpiration represents the default time a project upload is kept since it received its last chunk
+	DefaultProjectUploadExpiration = 24 * time.Hour
+	// DefaultProjectUploadPurgeInterval represents the default time between two purges of the expired project uploads
+	DefaultProjectUploadPurgeInterval = 1 * time.Hour
+)
+
+// ProjectUploadService is a service to manage the upload sessions where the source code of the projects is received in chunks. The received chunks are staged in the upload repository until the upload is finalized into a project, and the uploads not completed before they expire are periodically purged
+type ProjectUploadService struct {
+	appending     map[string]struct{}
+	archiveLimits *entity.ProjectArchiveLimits
+	expiration    time.Duration
+	interval      time.Duration
+	logger        repository.Logger
+	mutex         sync.Mutex
+	now           func() time.Time
+	onceStart     sync.Once
+	onceStop      sync.Once
+	repository    repository.ProjectUploadRepository
+	stopCh        chan struct{}
+}
+
+// Ensure ProjectUploadService implements the ProjectUploadServicer interface
+var _ service.ProjectUploadServicer = (*ProjectUploadService)(nil)
+
+// NewProjectUploadService creates a new ProjectUploadService
+func NewProjectUploadService(repository repository.ProjectUploadRepository, expiration time.Duration, interval time.Duration, logger repository.Logger) *ProjectUploadService {
+
+	if expiration <= 0 {
+		expiration = DefaultProjectUploadExpiration
+	}
+
+	if interval <= 0 {
+		interval = DefaultProjectUploadPurgeInterval
+	}
+
+	return &ProjectUploadService{
+		appending:  make(map[string]struct{}),
+		expiration: expiration,
+		interval:   interval,
+		logger:     logger,
+		now:        time.Now,
+		repository: repository,
+		stopCh:     make(chan struct{}),
+	}
+}
+
+// WithArchiveLimits sets the limits applied to the uploaded source code of the projects. The uploads longer than the maximum upload size are refused when they are opened
+func (s *ProjectUploadService) WithArchiveLimits(limits *entity.ProjectArchiveLimits) *ProjectUploadService {
+	s.archiveLimits = limits
+	return s
+}
+
+// CreateUpload opens an upload to receive a source code of the given length, in bytes
+func (s *ProjectUploadService) CreateUpload(length int64) (*entity.ProjectUpload, error) {
+
+	if s.repository == nil {
+		s.logger.Error(ErrProjectUploadRepositoryNotInitialized, map[string]interface{}{
+			"component": "ProjectUploadService.CreateUpload",
+			"package":   "github.com/apenella/ransidble/internal/domain/core/service/project",
+		})
+		return nil, fmt.Errorf(ErrProjectUploadRepositoryNotInitialized)
+	}
+
+	if length <= 0 {
+		s.logger.Error(fmt.Sprintf("%s: %d", ErrInvalidProjectUploadLength, length), map[string]interface{}{
+			"component": "ProjectUploadService.CreateUpload",
+			"package":   "github.com/apenella/ransidble/internal/domain/core/service/project",
+		})
+		return nil, domainerror.NewProjectUploadInvalidError(
+			fmt.Errorf("%s: the length must be greater than 0", ErrInvalidProjectUploadLength),
+		)
+	}
+
+	err := s.archiveLimits.CheckUploadSize(length)
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("%s: %s", ErrInvalidProjectUploadLength, err.Error()), map[string]interface{}{
+			"component": "ProjectUploadService.CreateUpload",
+			"package":   "github.com/apenella/ransidble/internal/domain/core/service/project",
+		})
+		return nil, domainerror.NewProjectTooLargeError(
+			fmt.Errorf("%s: %s", ErrInvalidProjectUploadLength, err.Error()),
+		)
+	}
+
+	upload := entity.NewProjectUpload(uuid.New().String(), length, s.now(), s.expiration)
+
+	err = s.repository.Create(upload)
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("%s: %s", ErrCreatingProjectUpload, err.Error()), map[string]interface{}{
+			"component": "ProjectUploadService.CreateUpload",
+			"package":   "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"upload_id": upload.ID,
+		})
+		return nil, fmt.Errorf("%s: %s", ErrCreatingProjectUpload, err.Error())
+	}
+
+	s.logger.Info("Project upload created", map[string]interface{}{
+		"component":  "ProjectUploadService.CreateUpload",
+		"expires_at": upload.ExpiresAt,
+		"length":     upload.Length,
+		"package":    "github.com/apenella/ransidble/internal/domain/core/service/project",
+		"upload_id":  upload.ID,
+	})
+
+	return upload, nil
+}
+
+// GetUpload returns an upload. The expired uploads are not found, even before they are purged
+func (s *ProjectUploadService) GetUpload(id string) (*entity.ProjectUpload, error) {
+	return s.find("ProjectUploadService.GetUpload", id)
+}
+
+// AppendUpload appends a chunk to an upload and returns the updated upload. The chunk must be sent at the offset of the upload, and the bytes received before the chunk is interrupted are kept, so the client resumes the upload from the new offset. Only one chunk is received at a time on each upload
+func (s *ProjectUploadService) AppendUpload(id string, offset int64, chunk io.Reader) (*entity.ProjectUpload, error) {
+
+	if chunk == nil {
+		s.logger.Error(ErrProjectUploadChunkNotProvided, map[string]interface{}{
+			"component": "ProjectUploadService.AppendUpload",
+			"package":   "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"upload_id": id,
+		})
+		return nil, domainerror.NewProjectUploadInvalidError(
+			fmt.Errorf(ErrProjectUploadChunkNotProvided),
+		)
+	}
+
+	if offset < 0 {
+		s.logger.Error(fmt.Sprintf("%s: %d", ErrInvalidProjectUploadOffset, offset), map[string]interface{}{
+			"component": "ProjectUploadService.AppendUpload",
+			"package":   "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"upload_id": id,
+		})
+		return nil, domainerror.NewProjectUploadInvalidError(
+			fmt.Errorf("%s: the offset must not be negative", ErrInvalidProjectUploadOffset),
+		)
+	}
+
+	if !s.lock(id) {
+		s.logger.Error(ErrProjectUploadBusy, map[string]interface{}{
+			"component": "ProjectUploadService.AppendUpload",
+			"package":   "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"upload_id": id,
+		})
+		return nil, domainerror.NewProjectUploadConflictError(
+			fmt.Errorf(ErrProjectUploadBusy),
+		)
+	}
+	defer s.unlock(id)
+
+	upload, err := s.find("ProjectUploadService.AppendUpload", id)
+	if err != nil {
+		return nil, err
+	}
+
+	if offset != upload.Offset {
+		s.logger.Error(fmt.Sprintf("%s: expected %d, got %d", ErrProjectUploadOffsetMismatch, upload.Offset, offset), map[string]interface{}{
+			"component": "ProjectUploadService.AppendUpload",
+			"package":   "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"upload_id": id,
+		})
+		return nil, domainerror.NewProjectUploadConflictError(
+			fmt.Errorf("%s: expected %d, got %d", ErrProjectUploadOffsetMismatch, upload.Offset, offset),
+		)
+	}
+
+	written, errAppend := s.repository.Append(id, offset, io.LimitReader(chunk, upload.Remaining()))
+
+	// the bytes received before the chunk is interrupted are kept, so the upload is updated even when the chunk is not fully appended
+	if written > 0 {
+		upload.Offset += written
+		upload.Touch(s.now(), s.expiration)
+
+		err = s.repository.Update(upload)
+		if err != nil {
+			s.logger.Error(fmt.Sprintf("%s: %s", ErrUpdatingProjectUpload, err.Error()), map[string]interface{}{
+				"component": "ProjectUploadService.AppendUpload",
+				"package":   "github.com/apenella/ransidble/internal/domain/core/service/project",
+				"upload_id": id,
+			})
+			return nil, fmt.Errorf("%s: %s", ErrUpdatingProjectUpload, err.Error())
+		}
+	}
+
+	if errAppend != nil {
+		s.logger.Error(fmt.Sprintf("%s: %s", ErrAppendingProjectUpload, errAppend.Error()), map[string]interface{}{
+			"component": "ProjectUploadService.AppendUpload",
+			"offset":    upload.Offset,
+			"package":   "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"upload_id": id,
+		})
+		return nil, fmt.Errorf("%s: %s", ErrAppendingProjectUpload, errAppend.Error())
+	}
+
+	// the chunk is only read up to the length of the upload, so any byte left means the chunk exceeds it
+	n, _ := chunk.Read(make([]byte, 1))
+	if n > 0 {
+		s.logger.Error(ErrProjectUploadChunkTooLarge, map[string]interface{}{
+			"component": "ProjectUploadService.AppendUpload",
+			"length":    upload.Length,
+			"package":   "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"upload_id": id,
+		})
+		return nil, domainerror.NewProjectTooLargeError(
+			fmt.Errorf("%s: the upload length is %d bytes", ErrProjectUploadChunkTooLarge, upload.Length),
+		)
+	}
+
+	s.logger.Debug("Project upload chunk appended", map[string]interface{}{
+		"component": "ProjectUploadService.AppendUpload",
+		"length":    upload.Length,
+		"offset":    upload.Offset,
+		"package":   "github.com/apenella/ransidble/internal/domain/core/service/project",
+		"upload_id": id,
+	})
+
+	return upload, nil
+}
+
+// DeleteUpload removes an upload along with its staged source code. An upload receiving a chunk can not be removed
+func (s *ProjectUploadService) DeleteUpload(id string) error {
+
+	if !s.lock(id) {
+		s.logger.Error(ErrProjectUploadBusy, map[string]interface{}{
+			"component": "ProjectUploadService.DeleteUpload",
+			"package":   "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"upload_id": id,
+		})
+		return domainerror.NewProjectUploadConflictError(
+			fmt.Errorf(ErrProjectUploadBusy),
+		)
+	}
+	defer s.unlock(id)
+
+	_, err := s.find("ProjectUploadService.DeleteUpload", id)
+	if err != nil {
+		return err
+	}
+
+	err = s.repository.Remove(id)
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("%s: %s", ErrRemovingProjectUpload, err.Error()), map[string]interface{}{
+			"component": "ProjectUploadService.DeleteUpload",
+			"package":   "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"upload_id": id,
+		})
+		return fmt.Errorf("%s: %s", ErrRemovingProjectUpload, err.Error())
+	}
+
+	s.logger.Info("Project upload removed", map[string]interface{}{
+		"component": "ProjectUploadService.DeleteUpload",
+		"package":   "github.com/apenella/ransidble/internal/domain/core/service/project",
+		"upload_id": id,
+	})
+
+	return nil
+}
+
+// FinalizeUpload hands the staged source code of a complete upload to the finalize function, and removes the upload once the function succeeds. The upload is reserved while it is finalized, so it can not receive a chunk, be removed or be purged meanwhile, and it is kept when the function fails
+func (s *ProjectUploadService) FinalizeUpload(id string, finalize func(content io.ReadSeeker) error) error {
+
+	if finalize == nil {
+		s.logger.Error(ErrProjectUploadFinalizerNotProvided, map[string]interface{}{
+			"component": "ProjectUploadService.FinalizeUpload",
+			"package":   "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"upload_id": id,
+		})
+		return fmt.Errorf(ErrProjectUploadFinalizerNotProvided)
+	}
+
+	if !s.lock(id) {
+		s.logger.Error(ErrProjectUploadBusy, map[string]interface{}{
+			"component": "ProjectUploadService.FinalizeUpload",
+			"package":   "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"upload_id": id,
+		})
+		return domainerror.NewProjectUploadConflictError(
+			fmt.Errorf(ErrProjectUploadBusy),
+		)
+	}
+	defer s.unlock(id)
+
+	upload, err := s.find("ProjectUploadService.FinalizeUpload", id)
+	if err != nil {
+		return err
+	}
+
+	if !upload.IsComplete() {
+		s.logger.Error(fmt.Sprintf("%s: %d of %d bytes received", ErrProjectUploadIncomplete, upload.Offset, upload.Length), map[string]interface{}{
+			"component": "ProjectUploadService.FinalizeUpload",
+			"package":   "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"upload_id": id,
+		})
+		return domainerror.NewProjectUploadConflictError(
+			fmt.Errorf("%s: %d of %d bytes received", ErrProjectUploadIncomplete, upload.Offset, upload.Length),
+		)
+	}
+
+	content, err := s.repository.Open(id)
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("%s: %s", ErrOpeningProjectUpload, err.Error()), map[string]interface{}{
+			"component": "ProjectUploadService.FinalizeUpload",
+			"package":   "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"upload_id": id,
+		})
+		return fmt.Errorf("%s: %s", ErrOpeningProjectUpload, err.Error())
+	}
+	defer content.Close()
+
+	err = finalize(content)
+	if err != nil {
+		return err
+	}
+
+	// the upload is already finalized whether it is removed or not, and the uploads left behind are purged once they expire
+	err = s.repository.Remove(id)
+	if err != nil {
+		s.logger.Warn(fmt.Sprintf("%s: %s", ErrRemovingProjectUpload, err.Error()), map[string]interface{}{
+			"component": "ProjectUploadService.FinalizeUpload",
+			"package":   "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"upload_id": id,
+		})
+		return nil
+	}
+
+	s.logger.Info("Project upload finalized", map[string]interface{}{
+		"component": "ProjectUploadService.FinalizeUpload",
+		"package":   "github.com/apenella/ransidble/internal/domain/core/service/project",
+		"upload_id": id,
+	})
+
+	return nil
+}
+
+// Start purges the expired uploads once when the service starts and then on every interval, until the context is done or the service is stopped
+func (s *ProjectUploadService) Start(ctx context.Context) {
+	s.onceStart.Do(func() {
+		go func() {
+			// the uploads that expired while the server was down are purged without waiting for the first interval
+			_, _ = s.Purge()
+
+			ticker := time.NewTicker(s.interval)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-ticker.C:
+					// the errors are already logged
+					_, _ = s.Purge()
+				case <-ctx.Done():
+					return
+				case <-s.stopCh:
+					return
+				}
+			}
+		}()
+	})
+}
+
+// Stop stops purging the expired uploads
+func (s *ProjectUploadService) Stop() {
+	s.onceStop.Do(func() {
+		close(s.stopCh)
+	})
+}
+
+// Purge removes the expired uploads along with their staged source code, and returns the number of uploads removed. The uploads receiving a chunk are not removed, and an upload that can not be removed does not stop the purge
+func (s *ProjectUploadService) Purge() (int, error) {
+
+	if s.repository == nil {
+		s.logger.Error(ErrProjectUploadRepositoryNotInitialized, map[string]interface{}{
+			"component": "ProjectUploadService.Purge",
+			"package":   "github.com/apenella/ransidble/internal/domain/core/service/project",
+		})
+		return 0, fmt.Errorf(ErrProjectUploadRepositoryNotInitialized)
+	}
+
+	uploads, err := s.repository.FindAll()
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("%s: %s", ErrFindingExpiredProjectUploads, err.Error()), map[string]interface{}{
+			"component": "ProjectUploadService.Purge",
+			"package":   "github.com/apenella/ransidble/internal/domain/core/service/project",
+		})
+		return 0, fmt.Errorf("%s: %s", ErrFindingExpiredProjectUploads, err.Error())
+	}
+
+	now := s.now()
+	purged := 0
+	for _, upload := range uploads {
+		if !upload.IsExpired(now) || !s.lock(upload.ID) {
+			continue
+		}
+
+		err = s.repository.Remove(upload.ID)
+		s.unlock(upload.ID)
+		if err != nil {
+			s.logger.Error(fmt.Sprintf("%s: %s", ErrPurgingProjectUpload, err.Error()), map[string]interface{}{
+				"component": "ProjectUploadService.Purge",
+				"package":   "github.com/apenella/ransidble/internal/domain/core/service/project",
+				"upload_id": upload.ID,
+			})
+			continue
+		}
+		purged++
+	}
+
+	s.logger.Info("Expired project uploads purged", map[string]interface{}{
+		"component": "ProjectUploadService.Purge",
+		"package":   "github.com/apenella/ransidble/internal/domain/core/service/project",
+		"purged":    purged,
+	})
+
+	return purged, nil
+}
+
+// find returns an upload that has not expired
+func (s *ProjectUploadService) find(component string, id string) (*entity.ProjectUpload, error) {
+
+	if s.repository == nil {
+		s.logger.Error(ErrProjectUploadRepositoryNotInitialized, map[string]interface{}{
+			"component": component,
+			"package":   "github.com/apenella/ransidble/internal/domain/core/service/project",
+		})
+		return nil, fmt.Errorf(ErrProjectUploadRepositoryNotInitialized)
+	}
+
+	if id == "" {
+		s.logger.Error(ErrProjectUploadIDNotProvided, map[string]interface{}{
+			"component": component,
+			"package":   "github.com/apenella/ransidble/internal/domain/core/service/project",
+		})
+		return nil, domainerror.NewProjectUploadInvalidError(
+			fmt.Errorf(ErrProjectUploadIDNotProvided),
+		)
+	}
+
+	upload, err := s.repository.Find(id)
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("%s: %s", ErrFindingProjectUpload, err.Error()), map[string]interface{}{
+			"component": component,
+			"package":   "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"upload_id": id,
+		})
+		return nil, domainerror.NewProjectUploadNotFoundError(
+			fmt.Errorf("%s: %s", ErrFindingProjectUpload, err.Error()),
+		)
+	}
+
+	if upload.IsExpired(s.now()) {
+		s.logger.Error(ErrProjectUploadExpired, map[string]interface{}{
+			"component":  component,
+			"expires_at": upload.ExpiresAt,
+			"package":    "github.com/apenella/ransidble/internal/domain/core/service/project",
+			"upload_id":  id,
+		})
+		return nil, domainerror.NewProjectUploadNotFoundError(
+			fmt.Errorf("%s: %s", ErrFindingProjectUpload, ErrProjectUploadExpired),
+		)
+	}
+
+	return upload, nil
+}
+
+// lock reserves an upload to receive a chunk, and returns false when the upload is already reserved
+func (s *ProjectUploadService) lock(id string) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	_, reserved := s.appending[id]
+	if reserved {
+		return false
+	}
+	s.appending[id] = struct{}{}
+
+	return true
+}
+
+// unlock releases an upload reserved to receive a chunk
+func (s *ProjectUploadService) unlock(id string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	delete(s.appending, id)
+}
@@ -0,0 +1,543 @@
+package project
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	domainerror "github.com/apenella/ransidble/internal/domain/core/error"
+	"github.com/apenella/ransidble/internal/domain/ports/repository"
+	"github.com/apenella/ransidble/internal/infrastructure/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func testingProjectUploadNow() time.Time {
+	return time.Date(2026, 1, 5, 10, 0, 0, 0, time.UTC)
+}
+
+// newTestingProjectUploadService returns a ProjectUploadService whose uploads expire after an hour
+func newTestingProjectUploadService() *ProjectUploadService {
+	service := NewProjectUploadService(repository.NewMockProjectUploadRepository(), time.Hour, time.Minute, logger.NewFakeLogger())
+	service.now = testingProjectUploadNow
+
+	return service
+}
+
+// appendChunk returns a function that consumes the chunk passed to the Append method of the upload repository
+func appendChunk(t *testing.T) func(mock.Arguments) {
+	return func(args mock.Arguments) {
+		_, err := io.ReadAll(args.Get(2).(io.Reader))
+		assert.NoError(t, err)
+	}
+}
+
+func TestNewProjectUploadService(t *testing.T) {
+	t.Log("Testing creating a ProjectUploadService applying the default expiration and interval")
+	t.Parallel()
+
+	service := NewProjectUploadService(nil, 0, 0, logger.NewFakeLogger())
+
+	assert.Equal(t, DefaultProjectUploadExpiration, service.expiration)
+	assert.Equal(t, DefaultProjectUploadPurgeInterval, service.interval)
+}
+
+func TestProjectUploadService_CreateUpload(t *testing.T) {
+	tests := []struct {
+		desc        string
+		length      int64
+		service     *ProjectUploadService
+		arrangeFunc func(*testing.T, *ProjectUploadService)
+		err         error
+	}{
+		{
+			desc:    "Testing creating an upload on the ProjectUploadService",
+			length:  1024,
+			service: newTestingProjectUploadService().WithArchiveLimits(&entity.ProjectArchiveLimits{MaxUploadSize: 1024}),
+			arrangeFunc: func(t *testing.T, s *ProjectUploadService) {
+				s.repository.(*repository.MockProjectUploadRepository).On("Create", mock.MatchedBy(func(upload *entity.ProjectUpload) bool {
+					return upload.ID != "" && upload.Length == 1024 && upload.ExpiresAt == "2026-01-05T11:00:00Z"
+				})).Return(nil)
+			},
+		},
+		{
+			desc:    "Testing an error creating an upload on the ProjectUploadService with a length that is not positive",
+			length:  0,
+			service: newTestingProjectUploadService(),
+			err: domainerror.NewProjectUploadInvalidError(
+				fmt.Errorf("%s: the length must be greater than 0", ErrInvalidProjectUploadLength),
+			),
+		},
+		{
+			desc:    "Testing an error creating an upload on the ProjectUploadService with a length exceeding the maximum upload size",
+			length:  2048,
+			service: newTestingProjectUploadService().WithArchiveLimits(&entity.ProjectArchiveLimits{MaxUploadSize: 1024}),
+			err: domainerror.NewProjectTooLargeError(
+				fmt.Errorf("%s: %s: the size of 2048 bytes exceeds the maximum of 1024 bytes", ErrInvalidProjectUploadLength, entity.ErrProjectUploadSizeExceeded),
+			),
+		},
+		{
+			desc:    "Testing an error creating an upload on the ProjectUploadService when the upload can not be stored",
+			length:  1024,
+			service: newTestingProjectUploadService(),
+			arrangeFunc: func(t *testing.T, s *ProjectUploadService) {
+				s.repository.(*repository.MockProjectUploadRepository).On("Create", mock.Anything).Return(errors.New("testing error"))
+			},
+			err: fmt.Errorf("%s: %s", ErrCreatingProjectUpload, "testing error"),
+		},
+		{
+			desc:    "Testing an error creating an upload on the ProjectUploadService when the upload repository is not initialized",
+			length:  1024,
+			service: NewProjectUploadService(nil, time.Hour, time.Minute, logger.NewFakeLogger()),
+			err:     fmt.Errorf(ErrProjectUploadRepositoryNotInitialized),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			if test.arrangeFunc != nil {
+				test.arrangeFunc(t, test.service)
+			}
+
+			upload, err := test.service.CreateUpload(test.length)
+			if test.err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.length, upload.Length)
+				assert.Equal(t, int64(0), upload.Offset)
+				test.service.repository.(*repository.MockProjectUploadRepository).AssertExpectations(t)
+			}
+		})
+	}
+}
+
+func TestProjectUploadService_GetUpload(t *testing.T) {
+	tests := []struct {
+		desc        string
+		id          string
+		service     *ProjectUploadService
+		arrangeFunc func(*testing.T, *ProjectUploadService)
+		expected    *entity.ProjectUpload
+		err         error
+	}{
+		{
+			desc:    "Testing getting an upload on the ProjectUploadService",
+			id:      "upload-id",
+			service: newTestingProjectUploadService(),
+			arrangeFunc: func(t *testing.T, s *ProjectUploadService) {
+				s.repository.(*repository.MockProjectUploadRepository).On("Find", "upload-id").Return(&entity.ProjectUpload{ID: "upload-id", Length: 1024, Offset: 512, ExpiresAt: "2026-01-05T11:00:00Z"}, nil)
+			},
+			expected: &entity.ProjectUpload{ID: "upload-id", Length: 1024, Offset: 512, ExpiresAt: "2026-01-05T11:00:00Z"},
+		},
+		{
+			desc:    "Testing an error getting an upload on the ProjectUploadService when the upload is not found",
+			id:      "upload-id",
+			service: newTestingProjectUploadService(),
+			arrangeFunc: func(t *testing.T, s *ProjectUploadService) {
+				s.repository.(*repository.MockProjectUploadRepository).On("Find", "upload-id").Return(nil, errors.New("testing error"))
+			},
+			err: domainerror.NewProjectUploadNotFoundError(
+				fmt.Errorf("%s: %s", ErrFindingProjectUpload, "testing error"),
+			),
+		},
+		{
+			desc:    "Testing an error getting an upload on the ProjectUploadService when the upload has expired",
+			id:      "upload-id",
+			service: newTestingProjectUploadService(),
+			arrangeFunc: func(t *testing.T, s *ProjectUploadService) {
+				s.repository.(*repository.MockProjectUploadRepository).On("Find", "upload-id").Return(&entity.ProjectUpload{ID: "upload-id", Length: 1024, ExpiresAt: "2026-01-05T09:00:00Z"}, nil)
+			},
+			err: domainerror.NewProjectUploadNotFoundError(
+				fmt.Errorf("%s: %s", ErrFindingProjectUpload, ErrProjectUploadExpired),
+			),
+		},
+		{
+			desc:    "Testing an error getting an upload on the ProjectUploadService when the upload id is not provided",
+			service: newTestingProjectUploadService(),
+			err: domainerror.NewProjectUploadInvalidError(
+				fmt.Errorf(ErrProjectUploadIDNotProvided),
+			),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			if test.arrangeFunc != nil {
+				test.arrangeFunc(t, test.service)
+			}
+
+			upload, err := test.service.GetUpload(test.id)
+			if test.err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expected, upload)
+			}
+		})
+	}
+}
+
+func TestProjectUploadService_AppendUpload(t *testing.T) {
+	tests := []struct {
+		desc        string
+		offset      int64
+		chunk       io.Reader
+		service     *ProjectUploadService
+		arrangeFunc func(*testing.T, *ProjectUploadService)
+		expected    *entity.ProjectUpload
+		err         error
+	}{
+		{
+			desc:    "Testing appending a chunk to an upload on the ProjectUploadService extends its expiration",
+			offset:  4,
+			chunk:   strings.NewReader("5678"),
+			service: newTestingProjectUploadService(),
+			arrangeFunc: func(t *testing.T, s *ProjectUploadService) {
+				s.repository.(*repository.MockProjectUploadRepository).On("Find", "upload-id").Return(&entity.ProjectUpload{ID: "upload-id", Length: 10, Offset: 4, ExpiresAt: "2026-01-05T10:30:00Z"}, nil)
+				s.repository.(*repository.MockProjectUploadRepository).On("Append", "upload-id", int64(4), mock.Anything).Run(appendChunk(t)).Return(int64(4), nil)
+				s.repository.(*repository.MockProjectUploadRepository).On("Update", &entity.ProjectUpload{ID: "upload-id", Length: 10, Offset: 8, ExpiresAt: "2026-01-05T11:00:00Z", UpdatedAt: "2026-01-05T10:00:00Z"}).Return(nil)
+			},
+			expected: &entity.ProjectUpload{ID: "upload-id", Length: 10, Offset: 8, ExpiresAt: "2026-01-05T11:00:00Z", UpdatedAt: "2026-01-05T10:00:00Z"},
+		},
+		{
+			desc:    "Testing an error appending a chunk to an upload on the ProjectUploadService at an offset other than the upload offset",
+			offset:  2,
+			chunk:   strings.NewReader("5678"),
+			service: newTestingProjectUploadService(),
+			arrangeFunc: func(t *testing.T, s *ProjectUploadService) {
+				s.repository.(*repository.MockProjectUploadRepository).On("Find", "upload-id").Return(&entity.ProjectUpload{ID: "upload-id", Length: 10, Offset: 4, ExpiresAt: "2026-01-05T10:30:00Z"}, nil)
+			},
+			err: domainerror.NewProjectUploadConflictError(
+				fmt.Errorf("%s: expected %d, got %d", ErrProjectUploadOffsetMismatch, 4, 2),
+			),
+		},
+		{
+			desc:    "Testing an error appending a chunk exceeding the length of an upload on the ProjectUploadService keeps the bytes within the length",
+			offset:  8,
+			chunk:   strings.NewReader("9012"),
+			service: newTestingProjectUploadService(),
+			arrangeFunc: func(t *testing.T, s *ProjectUploadService) {
+				s.repository.(*repository.MockProjectUploadRepository).On("Find", "upload-id").Return(&entity.ProjectUpload{ID: "upload-id", Length: 10, Offset: 8, ExpiresAt: "2026-01-05T10:30:00Z"}, nil)
+				s.repository.(*repository.MockProjectUploadRepository).On("Append", "upload-id", int64(8), mock.Anything).Run(appendChunk(t)).Return(int64(2), nil)
+				s.repository.(*repository.MockProjectUploadRepository).On("Update", mock.Anything).Return(nil)
+			},
+			err: domainerror.NewProjectTooLargeError(
+				fmt.Errorf("%s: the upload length is %d bytes", ErrProjectUploadChunkTooLarge, 10),
+			),
+		},
+		{
+			desc:    "Testing an error appending an interrupted chunk to an upload on the ProjectUploadService keeps the bytes received",
+			offset:  0,
+			chunk:   strings.NewReader("1234"),
+			service: newTestingProjectUploadService(),
+			arrangeFunc: func(t *testing.T, s *ProjectUploadService) {
+				s.repository.(*repository.MockProjectUploadRepository).On("Find", "upload-id").Return(&entity.ProjectUpload{ID: "upload-id", Length: 10, ExpiresAt: "2026-01-05T10:30:00Z"}, nil)
+				s.repository.(*repository.MockProjectUploadRepository).On("Append", "upload-id", int64(0), mock.Anything).Return(int64(2), errors.New("unexpected EOF"))
+				s.repository.(*repository.MockProjectUploadRepository).On("Update", mock.MatchedBy(func(upload *entity.ProjectUpload) bool {
+					return upload.Offset == 2
+				})).Return(nil)
+			},
+			err: fmt.Errorf("%s: %s", ErrAppendingProjectUpload, "unexpected EOF"),
+		},
+		{
+			desc:    "Testing an error appending a chunk to an upload on the ProjectUploadService with a negative offset",
+			offset:  -1,
+			chunk:   strings.NewReader("1234"),
+			service: newTestingProjectUploadService(),
+			err: domainerror.NewProjectUploadInvalidError(
+				fmt.Errorf("%s: the offset must not be negative", ErrInvalidProjectUploadOffset),
+			),
+		},
+		{
+			desc:    "Testing an error appending a chunk to an upload on the ProjectUploadService while it receives another chunk",
+			offset:  0,
+			chunk:   strings.NewReader("1234"),
+			service: newTestingProjectUploadService(),
+			arrangeFunc: func(t *testing.T, s *ProjectUploadService) {
+				s.lock("upload-id")
+			},
+			err: domainerror.NewProjectUploadConflictError(
+				fmt.Errorf(ErrProjectUploadBusy),
+			),
+		},
+		{
+			desc:    "Testing an error appending a chunk to an upload on the ProjectUploadService without providing the chunk",
+			service: newTestingProjectUploadService(),
+			err: domainerror.NewProjectUploadInvalidError(
+				fmt.Errorf(ErrProjectUploadChunkNotProvided),
+			),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			if test.arrangeFunc != nil {
+				test.arrangeFunc(t, test.service)
+			}
+
+			upload, err := test.service.AppendUpload("upload-id", test.offset, test.chunk)
+			if test.err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expected, upload)
+			}
+			test.service.repository.(*repository.MockProjectUploadRepository).AssertExpectations(t)
+		})
+	}
+}
+
+func TestProjectUploadService_DeleteUpload(t *testing.T) {
+	tests := []struct {
+		desc        string
+		service     *ProjectUploadService
+		arrangeFunc func(*testing.T, *ProjectUploadService)
+		err         error
+	}{
+		{
+			desc:    "Testing deleting an upload on the ProjectUploadService",
+			service: newTestingProjectUploadService(),
+			arrangeFunc: func(t *testing.T, s *ProjectUploadService) {
+				s.repository.(*repository.MockProjectUploadRepository).On("Find", "upload-id").Return(&entity.ProjectUpload{ID: "upload-id", Length: 10, ExpiresAt: "2026-01-05T10:30:00Z"}, nil)
+				s.repository.(*repository.MockProjectUploadRepository).On("Remove", "upload-id").Return(nil)
+			},
+		},
+		{
+			desc:    "Testing an error deleting an upload on the ProjectUploadService when the upload can not be removed",
+			service: newTestingProjectUploadService(),
+			arrangeFunc: func(t *testing.T, s *ProjectUploadService) {
+				s.repository.(*repository.MockProjectUploadRepository).On("Find", "upload-id").Return(&entity.ProjectUpload{ID: "upload-id", Length: 10, ExpiresAt: "2026-01-05T10:30:00Z"}, nil)
+				s.repository.(*repository.MockProjectUploadRepository).On("Remove", "upload-id").Return(errors.New("testing error"))
+			},
+			err: fmt.Errorf("%s: %s", ErrRemovingProjectUpload, "testing error"),
+		},
+		{
+			desc:    "Testing an error deleting an upload on the ProjectUploadService while it receives a chunk",
+			service: newTestingProjectUploadService(),
+			arrangeFunc: func(t *testing.T, s *ProjectUploadService) {
+				s.lock("upload-id")
+			},
+			err: domainerror.NewProjectUploadConflictError(
+				fmt.Errorf(ErrProjectUploadBusy),
+			),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			if test.arrangeFunc != nil {
+				test.arrangeFunc(t, test.service)
+			}
+
+			err := test.service.DeleteUpload("upload-id")
+			if test.err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			test.service.repository.(*repository.MockProjectUploadRepository).AssertExpectations(t)
+		})
+	}
+}
+
+func TestProjectUploadService_FinalizeUpload(t *testing.T) {
+	tests := []struct {
+		desc        string
+		service     *ProjectUploadService
+		arrangeFunc func(*testing.T, *ProjectUploadService)
+		finalizeErr error
+		finalized   bool
+		err         error
+	}{
+		{
+			desc:    "Testing finalizing an upload on the ProjectUploadService",
+			service: newTestingProjectUploadService(),
+			arrangeFunc: func(t *testing.T, s *ProjectUploadService) {
+				s.repository.(*repository.MockProjectUploadRepository).On("Find", "upload-id").Return(&entity.ProjectUpload{ID: "upload-id", Length: 19, Offset: 19, ExpiresAt: "2026-01-05T10:30:00Z"}, nil)
+				s.repository.(*repository.MockProjectUploadRepository).On("Open", "upload-id").Return(newImportSourceContent("content for testing"), nil)
+				s.repository.(*repository.MockProjectUploadRepository).On("Remove", "upload-id").Return(nil)
+			},
+			finalized: true,
+		},
+		{
+			desc:    "Testing finalizing an upload on the ProjectUploadService when the upload can not be removed",
+			service: newTestingProjectUploadService(),
+			arrangeFunc: func(t *testing.T, s *ProjectUploadService) {
+				s.repository.(*repository.MockProjectUploadRepository).On("Find", "upload-id").Return(&entity.ProjectUpload{ID: "upload-id", Length: 19, Offset: 19, ExpiresAt: "2026-01-05T10:30:00Z"}, nil)
+				s.repository.(*repository.MockProjectUploadRepository).On("Open", "upload-id").Return(newImportSourceContent("content for testing"), nil)
+				s.repository.(*repository.MockProjectUploadRepository).On("Remove", "upload-id").Return(errors.New("testing error"))
+			},
+			finalized: true,
+		},
+		{
+			desc:    "Testing an error finalizing an upload on the ProjectUploadService keeps the upload when the finalize function fails",
+			service: newTestingProjectUploadService(),
+			arrangeFunc: func(t *testing.T, s *ProjectUploadService) {
+				s.repository.(*repository.MockProjectUploadRepository).On("Find", "upload-id").Return(&entity.ProjectUpload{ID: "upload-id", Length: 19, Offset: 19, ExpiresAt: "2026-01-05T10:30:00Z"}, nil)
+				s.repository.(*repository.MockProjectUploadRepository).On("Open", "upload-id").Return(newImportSourceContent("content for testing"), nil)
+			},
+			finalizeErr: errors.New("testing error"),
+			finalized:   true,
+			err:         errors.New("testing error"),
+		},
+		{
+			desc:    "Testing an error finalizing an upload on the ProjectUploadService when the upload is not complete",
+			service: newTestingProjectUploadService(),
+			arrangeFunc: func(t *testing.T, s *ProjectUploadService) {
+				s.repository.(*repository.MockProjectUploadRepository).On("Find", "upload-id").Return(&entity.ProjectUpload{ID: "upload-id", Length: 19, Offset: 10, ExpiresAt: "2026-01-05T10:30:00Z"}, nil)
+			},
+			err: domainerror.NewProjectUploadConflictError(
+				fmt.Errorf("%s: %d of %d bytes received", ErrProjectUploadIncomplete, 10, 19),
+			),
+		},
+		{
+			desc:    "Testing an error finalizing an upload on the ProjectUploadService when the upload can not be opened",
+			service: newTestingProjectUploadService(),
+			arrangeFunc: func(t *testing.T, s *ProjectUploadService) {
+				s.repository.(*repository.MockProjectUploadRepository).On("Find", "upload-id").Return(&entity.ProjectUpload{ID: "upload-id", Length: 19, Offset: 19, ExpiresAt: "2026-01-05T10:30:00Z"}, nil)
+				s.repository.(*repository.MockProjectUploadRepository).On("Open", "upload-id").Return(nil, errors.New("testing error"))
+			},
+			err: fmt.Errorf("%s: %s", ErrOpeningProjectUpload, "testing error"),
+		},
+		{
+			desc:    "Testing an error finalizing an upload on the ProjectUploadService while it receives a chunk",
+			service: newTestingProjectUploadService(),
+			arrangeFunc: func(t *testing.T, s *ProjectUploadService) {
+				s.lock("upload-id")
+			},
+			err: domainerror.NewProjectUploadConflictError(
+				fmt.Errorf(ErrProjectUploadBusy),
+			),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			if test.arrangeFunc != nil {
+				test.arrangeFunc(t, test.service)
+			}
+
+			finalized := false
+			err := test.service.FinalizeUpload("upload-id", func(content io.ReadSeeker) error {
+				finalized = true
+
+				// the upload can not receive a chunk while it is finalized
+				_, errAppend := test.service.AppendUpload("upload-id", 19, strings.NewReader("chunk"))
+				assert.Equal(t, domainerror.NewProjectUploadConflictError(fmt.Errorf(ErrProjectUploadBusy)), errAppend)
+
+				return test.finalizeErr
+			})
+			if test.err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.finalized, finalized)
+			test.service.repository.(*repository.MockProjectUploadRepository).AssertExpectations(t)
+		})
+	}
+}
+
+func TestProjectUploadService_Purge(t *testing.T) {
+	tests := []struct {
+		desc        string
+		service     *ProjectUploadService
+		arrangeFunc func(*testing.T, *ProjectUploadService)
+		expected    int
+		err         error
+	}{
+		{
+			desc:    "Testing purging the expired uploads on the ProjectUploadService",
+			service: newTestingProjectUploadService(),
+			arrangeFunc: func(t *testing.T, s *ProjectUploadService) {
+				s.repository.(*repository.MockProjectUploadRepository).On("FindAll").Return([]*entity.ProjectUpload{
+					{ID: "upload-1", ExpiresAt: "2026-01-05T09:00:00Z"},
+					{ID: "upload-2", ExpiresAt: "2026-01-05T11:00:00Z"},
+					{ID: "upload-3", ExpiresAt: "2026-01-04T09:00:00Z"},
+					{ID: "upload-4", ExpiresAt: "2026-01-04T09:00:00Z"},
+					{ID: "upload-5", ExpiresAt: "2026-01-04T09:00:00Z"},
+				}, nil)
+				s.repository.(*repository.MockProjectUploadRepository).On("Remove", "upload-1").Return(nil)
+				s.repository.(*repository.MockProjectUploadRepository).On("Remove", "upload-3").Return(nil)
+				s.repository.(*repository.MockProjectUploadRepository).On("Remove", "upload-4").Return(errors.New("testing error"))
+				// the uploads receiving a chunk are not purged
+				s.lock("upload-5")
+			},
+			expected: 2,
+		},
+		{
+			desc:    "Testing an error purging the expired uploads on the ProjectUploadService when the uploads can not be found",
+			service: newTestingProjectUploadService(),
+			arrangeFunc: func(t *testing.T, s *ProjectUploadService) {
+				s.repository.(*repository.MockProjectUploadRepository).On("FindAll").Return(nil, errors.New("testing error"))
+			},
+			err: fmt.Errorf("%s: %s", ErrFindingExpiredProjectUploads, "testing error"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			if test.arrangeFunc != nil {
+				test.arrangeFunc(t, test.service)
+			}
+
+			purged, err := test.service.Purge()
+			if test.err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expected, purged)
+				test.service.repository.(*repository.MockProjectUploadRepository).AssertExpectations(t)
+			}
+		})
+	}
+}
+
+func TestProjectUploadService_Start(t *testing.T) {
+	t.Log("Testing the ProjectUploadService purges the expired uploads once it is started, without waiting for the first interval")
+	t.Parallel()
+
+	purged := make(chan struct{}, 1)
+	uploadRepository := repository.NewMockProjectUploadRepository()
+	uploadRepository.On("FindAll").Run(func(mock.Arguments) {
+		select {
+		case purged <- struct{}{}:
+		default:
+		}
+	}).Return([]*entity.ProjectUpload{}, nil)
+
+	service := NewProjectUploadService(uploadRepository, time.Hour, time.Hour, logger.NewFakeLogger())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	service.Start(ctx)
+	assert.Eventually(t, func() bool {
+		return len(purged) > 0
+	}, time.Second, 10*time.Millisecond)
+	service.Stop()
+}
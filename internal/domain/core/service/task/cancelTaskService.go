@@ -0,0 +1,121 @@
+package task
+
+import (
+	"fmt"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	domainerror "github.com/apenella/ransidble/internal/domain/core/error"
+	"github.com/apenella/ransidble/internal/domain/ports/repository"
+)
+
+var (
+	// ErrCancellingTask represents an error when cancelling a task
+	ErrCancellingTask = fmt.Errorf("error cancelling task")
+	// ErrPersistingCancelledTask represents an error when the status of a cancelled task can not be persisted
+	ErrPersistingCancelledTask = fmt.Errorf("error persisting cancelled task")
+	// ErrTaskAlreadyFinished represents an error when the task to cancel is already finished
+	ErrTaskAlreadyFinished = fmt.Errorf("task already finished")
+)
+
+// CancelTaskService is a service to cancel a task
+type CancelTaskService struct {
+	executor   repository.Executor
+	repository repository.TaskRepository
+	logger     repository.Logger
+}
+
+// NewCancelTaskService creates a new CancelTaskService
+func NewCancelTaskService(executor repository.Executor, repository repository.TaskRepository, logger repository.Logger) *CancelTaskService {
+	return &CancelTaskService{
+		executor:   executor,
+		repository: repository,
+		logger:     logger,
+	}
+}
+
+// CancelTask cancels a task by its id and returns the cancelled task. A task can be cancelled while it is queued or running
+func (t *CancelTaskService) CancelTask(id string) (*entity.Task, error) {
+
+	if t.executor == nil {
+		t.logger.Error(ErrExecutorNotInitialized.Error(), map[string]interface{}{
+			"component": "CancelTaskService.CancelTask",
+			"package":   "github.com/apenella/ransidble/internal/domain/core/service/task",
+			"task_id":   id,
+		})
+		return nil, ErrExecutorNotInitialized
+	}
+
+	if t.repository == nil {
+		t.logger.Error(ErrRepositoryNotInitialized.Error(), map[string]interface{}{
+			"component": "CancelTaskService.CancelTask",
+			"package":   "github.com/apenella/ransidble/internal/domain/core/service/task",
+			"task_id":   id,
+		})
+		return nil, ErrRepositoryNotInitialized
+	}
+
+	if id == "" {
+		t.logger.Error(ErrTaskIDNotProvided.Error(), map[string]interface{}{
+			"component": "CancelTaskService.CancelTask",
+			"package":   "github.com/apenella/ransidble/internal/domain/core/service/task",
+		})
+
+		return nil, domainerror.NewTaskNotProvidedError(ErrTaskIDNotProvided)
+	}
+
+	task, err := t.repository.Find(id)
+	if err != nil {
+		t.logger.Error(fmt.Sprintf("%s: %s", ErrFindingTask.Error(), err.Error()), map[string]interface{}{
+			"component": "CancelTaskService.CancelTask",
+			"package":   "github.com/apenella/ransidble/internal/domain/core/service/task",
+			"task_id":   id,
+		})
+
+		return nil, domainerror.NewTaskNotFoundError(
+			fmt.Errorf("%s %s: %w", ErrFindingTask.Error(), id, err),
+		)
+	}
+
+	if task.IsFinished() {
+		t.logger.Error(ErrTaskAlreadyFinished.Error(), map[string]interface{}{
+			"component": "CancelTaskService.CancelTask",
+			"package":   "github.com/apenella/ransidble/internal/domain/core/service/task",
+			"status":    task.GetStatus(),
+			"task_id":   id,
+		})
+
+		return nil, domainerror.NewTaskNotCancellableError(
+			fmt.Errorf("%s %s: %w", ErrCancellingTask.Error(), id, ErrTaskAlreadyFinished),
+		)
+	}
+
+	err = t.executor.Cancel(task)
+	if err != nil {
+		t.logger.Error(fmt.Sprintf("%s: %s", ErrCancellingTask.Error(), err.Error()), map[string]interface{}{
+			"component": "CancelTaskService.CancelTask",
+			"package":   "github.com/apenella/ransidble/internal/domain/core/service/task",
+			"task_id":   id,
+		})
+
+		// the task could finish between the status check and the cancellation
+		if task.IsFinished() && !task.IsCancelled() {
+			return nil, domainerror.NewTaskNotCancellableError(
+				fmt.Errorf("%s %s: %w", ErrCancellingTask.Error(), id, ErrTaskAlreadyFinished),
+			)
+		}
+
+		return nil, fmt.Errorf("%s %s: %w", ErrCancellingTask.Error(), id, err)
+	}
+
+	// the task is already cancelled, so an error persisting its status does not fail the cancellation
+	err = t.repository.Update(id, task)
+	if err != nil {
+		t.logger.Error(fmt.Sprintf("%s: %s", ErrPersistingCancelledTask.Error(), err.Error()), map[string]interface{}{
+			"component": "CancelTaskService.CancelTask",
+			"package":   "github.com/apenella/ransidble/internal/domain/core/service/task",
+			"task_id":   id,
+		})
+	}
+
+	return task, nil
+}
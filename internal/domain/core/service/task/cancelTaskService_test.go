@@ -0,0 +1,147 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	domainerror "github.com/apenella/ransidble/internal/domain/core/error"
+	"github.com/apenella/ransidble/internal/domain/ports/repository"
+	"github.com/apenella/ransidble/internal/infrastructure/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCancelTask(t *testing.T) {
+	tests := []struct {
+		desc        string
+		id          string
+		err         error
+		expected    *entity.Task
+		service     *CancelTaskService
+		arrangeFunc func(*testing.T, *CancelTaskService)
+	}{
+		{
+			desc:     "Testing cancelling a task on the CancelTaskService",
+			id:       "task-id",
+			expected: &entity.Task{ID: "task-id", Status: entity.RUNNING},
+			service: NewCancelTaskService(
+				repository.NewMockTaskExecutor(),
+				repository.NewMockTaskRepository(),
+				logger.NewFakeLogger(),
+			),
+			arrangeFunc: func(t *testing.T, service *CancelTaskService) {
+				service.repository.(*repository.MockTaskRepository).On("Find", "task-id").Return(&entity.Task{ID: "task-id", Status: entity.RUNNING}, nil)
+				service.executor.(*repository.MockTaskExecutor).On("Cancel", &entity.Task{ID: "task-id", Status: entity.RUNNING}).Return(nil)
+				service.repository.(*repository.MockTaskRepository).On("Update", "task-id", &entity.Task{ID: "task-id", Status: entity.RUNNING}).Return(nil)
+			},
+		},
+		{
+			desc:     "Testing cancelling a task on the CancelTaskService when the cancelled task can not be persisted",
+			id:       "task-id",
+			expected: &entity.Task{ID: "task-id", Status: entity.RUNNING},
+			service: NewCancelTaskService(
+				repository.NewMockTaskExecutor(),
+				repository.NewMockTaskRepository(),
+				logger.NewFakeLogger(),
+			),
+			arrangeFunc: func(t *testing.T, service *CancelTaskService) {
+				service.repository.(*repository.MockTaskRepository).On("Find", "task-id").Return(&entity.Task{ID: "task-id", Status: entity.RUNNING}, nil)
+				service.executor.(*repository.MockTaskExecutor).On("Cancel", &entity.Task{ID: "task-id", Status: entity.RUNNING}).Return(nil)
+				service.repository.(*repository.MockTaskRepository).On("Update", "task-id", &entity.Task{ID: "task-id", Status: entity.RUNNING}).Return(errors.New("error persisting"))
+			},
+		},
+		{
+			desc: "Testing error cancelling a task on the CancelTaskService having a nil executor",
+			id:   "task-id",
+			err:  ErrExecutorNotInitialized,
+			service: NewCancelTaskService(
+				nil,
+				repository.NewMockTaskRepository(),
+				logger.NewFakeLogger(),
+			),
+		},
+		{
+			desc: "Testing error cancelling a task on the CancelTaskService having a nil task repository",
+			id:   "task-id",
+			err:  ErrRepositoryNotInitialized,
+			service: NewCancelTaskService(
+				repository.NewMockTaskExecutor(),
+				nil,
+				logger.NewFakeLogger(),
+			),
+		},
+		{
+			desc: "Testing error cancelling a task on the CancelTaskService having an empty task id",
+			id:   "",
+			err:  domainerror.NewTaskNotProvidedError(ErrTaskIDNotProvided),
+			service: NewCancelTaskService(
+				repository.NewMockTaskExecutor(),
+				repository.NewMockTaskRepository(),
+				logger.NewFakeLogger(),
+			),
+		},
+		{
+			desc: "Testing error cancelling a task on the CancelTaskService when the task does not exist",
+			id:   "task-id",
+			err: domainerror.NewTaskNotFoundError(
+				fmt.Errorf("%s %s: %w", ErrFindingTask.Error(), "task-id", errors.New("task not found")),
+			),
+			service: NewCancelTaskService(
+				repository.NewMockTaskExecutor(),
+				repository.NewMockTaskRepository(),
+				logger.NewFakeLogger(),
+			),
+			arrangeFunc: func(t *testing.T, service *CancelTaskService) {
+				service.repository.(*repository.MockTaskRepository).On("Find", "task-id").Return(nil, errors.New("task not found"))
+			},
+		},
+		{
+			desc: "Testing error cancelling a task on the CancelTaskService when the task is already finished",
+			id:   "task-id",
+			err: domainerror.NewTaskNotCancellableError(
+				fmt.Errorf("%s %s: %w", ErrCancellingTask.Error(), "task-id", ErrTaskAlreadyFinished),
+			),
+			service: NewCancelTaskService(
+				repository.NewMockTaskExecutor(),
+				repository.NewMockTaskRepository(),
+				logger.NewFakeLogger(),
+			),
+			arrangeFunc: func(t *testing.T, service *CancelTaskService) {
+				service.repository.(*repository.MockTaskRepository).On("Find", "task-id").Return(&entity.Task{ID: "task-id", Status: entity.SUCCESS}, nil)
+			},
+		},
+		{
+			desc: "Testing error cancelling a task on the CancelTaskService when the executor fails cancelling the task",
+			id:   "task-id",
+			err:  fmt.Errorf("%s %s: %w", ErrCancellingTask.Error(), "task-id", errors.New("error cancelling")),
+			service: NewCancelTaskService(
+				repository.NewMockTaskExecutor(),
+				repository.NewMockTaskRepository(),
+				logger.NewFakeLogger(),
+			),
+			arrangeFunc: func(t *testing.T, service *CancelTaskService) {
+				service.repository.(*repository.MockTaskRepository).On("Find", "task-id").Return(&entity.Task{ID: "task-id", Status: entity.PENDING}, nil)
+				service.executor.(*repository.MockTaskExecutor).On("Cancel", &entity.Task{ID: "task-id", Status: entity.PENDING}).Return(errors.New("error cancelling"))
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			if test.arrangeFunc != nil {
+				test.arrangeFunc(t, test.service)
+			}
+
+			task, err := test.service.CancelTask(test.id)
+			if err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.Equal(t, test.expected, task)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package task
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/apenella/ransidble/internal/domain/core/entity"
 	domainerror "github.com/apenella/ransidble/internal/domain/core/error"
@@ -27,10 +28,16 @@ var (
 	ErrProjectNotProvided = fmt.Errorf("project not provided")
 	// ErrFindingProject represents an error when the project is not found
 	ErrFindingProject = fmt.Errorf("error finding project")
+	// ErrFindingProjectVersion represents an error when the project version is not found
+	ErrFindingProjectVersion = fmt.Errorf("error finding project version")
 	// ErrSettingUpProject represents an error when setting up a project
 	ErrSettingUpProject = fmt.Errorf("error setting up project")
 	// ErrGeneratingRandomString represents an error when generating a random string
 	ErrGeneratingRandomString = fmt.Errorf("error generating random string")
+	// ErrInventoryNotProvided represents an error when the inventory is neither provided by the task nor by the project manifest defaults
+	ErrInventoryNotProvided = fmt.Errorf("inventory not provided")
+	// ErrExecutionTimeoutExceedsMaximum represents an error when the requested execution timeout exceeds the maximum allowed
+	ErrExecutionTimeoutExceedsMaximum = fmt.Errorf("execution timeout exceeds the maximum allowed")
 )
 
 // CreateTaskAnsiblePlaybookService represents the service to run an Ansible playbook
@@ -40,6 +47,11 @@ type CreateTaskAnsiblePlaybookService struct {
 	projectRepository repository.ProjectRepository
 	taskRepository    repository.TaskRepository
 	// workspaceBuilder  service.WorkspaceBuilder
+
+	// defaultExecutionTimeout is the execution timeout applied to the tasks that do not define one
+	defaultExecutionTimeout time.Duration
+	// maxExecutionTimeout is the maximum execution timeout a task can request
+	maxExecutionTimeout time.Duration
 }
 
 // NewCreateTaskAnsiblePlaybookService creates a new CreateTaskAnsiblePlaybookService
@@ -58,6 +70,13 @@ func NewCreateTaskAnsiblePlaybookService(
 	}
 }
 
+// WithExecutionTimeout sets the default and the maximum execution timeout for the tasks. A zero value disables the default or the maximum
+func (s *CreateTaskAnsiblePlaybookService) WithExecutionTimeout(defaultTimeout, maxTimeout time.Duration) *CreateTaskAnsiblePlaybookService {
+	s.defaultExecutionTimeout = defaultTimeout
+	s.maxExecutionTimeout = maxTimeout
+	return s
+}
+
 // GenerateID generates an ID
 func (s *CreateTaskAnsiblePlaybookService) GenerateID() string {
 	// TODO id generatior should be injected as a dependency
@@ -71,6 +90,7 @@ func (s *CreateTaskAnsiblePlaybookService) Run(
 	task *entity.Task,
 ) error {
 	var err error
+	var project *entity.Project
 	var projectID string
 
 	if s.executor == nil {
@@ -115,7 +135,7 @@ func (s *CreateTaskAnsiblePlaybookService) Run(
 		return domainerror.NewProjectNotProvidedError(ErrProjectNotProvided)
 	}
 
-	_, err = s.projectRepository.Find(projectID)
+	project, err = s.projectRepository.Find(projectID)
 	if err != nil {
 		s.logger.Error(ErrFindingProject.Error(), map[string]interface{}{
 			"component":  "CreateTaskAnsiblePlaybookService.Run",
@@ -125,9 +145,94 @@ func (s *CreateTaskAnsiblePlaybookService) Run(
 		return domainerror.NewProjectNotFoundError(ErrFindingProject)
 	}
 
+	// a pinned version must exist when the task is created
+	if task.ProjectVersion != "" && task.ProjectVersion != entity.LatestVersion {
+		err = entity.ValidateProjectVersion(task.ProjectVersion)
+		if err != nil {
+			s.logger.Error(err.Error(), map[string]interface{}{
+				"component":       "CreateTaskAnsiblePlaybookService.Run",
+				"package":         "github.com/apenella/ransidble/internal/domain/core/service/task",
+				"project_id":      projectID,
+				"project_version": task.ProjectVersion,
+			})
+			return domainerror.NewTaskInvalidParametersError(err)
+		}
+
+		project, err = s.projectRepository.FindVersion(projectID, task.ProjectVersion)
+		if err != nil {
+			s.logger.Error(ErrFindingProjectVersion.Error(), map[string]interface{}{
+				"component":       "CreateTaskAnsiblePlaybookService.Run",
+				"package":         "github.com/apenella/ransidble/internal/domain/core/service/task",
+				"project_id":      projectID,
+				"project_version": task.ProjectVersion,
+			})
+			return domainerror.NewProjectNotFoundError(ErrFindingProjectVersion)
+		}
+	}
+
+	// the latest alias is resolved when the task is created, and the resolved version is pinned on the task, so the task is executed against the version its parameters are checked against, even when a newer version is created before the task is dispatched
+	if project != nil && project.Version != "" {
+		task.ProjectVersion = project.Version
+	}
+
+	parameters, isAnsiblePlaybookParameters := task.Parameters.(*entity.AnsiblePlaybookParameters)
+	if isAnsiblePlaybookParameters && parameters != nil {
+		// the project manifest defaults are merged before the parameters are checked, so the callers only provide what differs
+		if project != nil {
+			project.Manifest.Apply(parameters)
+		}
+
+		if parameters.Inventory == "" {
+			s.logger.Error(ErrInventoryNotProvided.Error(), map[string]interface{}{
+				"component":  "CreateTaskAnsiblePlaybookService.Run",
+				"package":    "github.com/apenella/ransidble/internal/domain/core/service/task",
+				"project_id": projectID,
+				"task_id":    task.ID,
+			})
+			return domainerror.NewTaskInvalidParametersError(ErrInventoryNotProvided)
+		}
+
+		if parameters.ExecutionTimeout == 0 && s.defaultExecutionTimeout > 0 {
+			parameters.ExecutionTimeout = timeoutSeconds(s.defaultExecutionTimeout)
+		}
+
+		maxExecutionTimeout := timeoutSeconds(s.maxExecutionTimeout)
+		if maxExecutionTimeout > 0 && parameters.ExecutionTimeout > maxExecutionTimeout {
+			errMsg := fmt.Sprintf("%s: %ds > %ds", ErrExecutionTimeoutExceedsMaximum, parameters.ExecutionTimeout, maxExecutionTimeout)
+			s.logger.Error(errMsg, map[string]interface{}{
+				"component":  "CreateTaskAnsiblePlaybookService.Run",
+				"package":    "github.com/apenella/ransidble/internal/domain/core/service/task",
+				"project_id": projectID,
+				"task_id":    task.ID,
+			})
+			return domainerror.NewTaskInvalidParametersError(fmt.Errorf("%s", errMsg))
+		}
+
+		// the parameters are checked against the project manifest, and the playbooks and the inventory against the contents discovered when the project was created, so the task fails before it is queued
+		if project != nil {
+			err = project.Manifest.Check(parameters)
+			if err == nil {
+				err = project.Contents.CheckPlaybooks(parameters.Playbooks)
+			}
+			if err == nil {
+				err = project.Contents.CheckInventory(parameters.Inventory)
+			}
+			if err != nil {
+				s.logger.Error(err.Error(), map[string]interface{}{
+					"component":       "CreateTaskAnsiblePlaybookService.Run",
+					"package":         "github.com/apenella/ransidble/internal/domain/core/service/task",
+					"project_id":      projectID,
+					"project_version": project.Version,
+					"task_id":         task.ID,
+				})
+				return domainerror.NewTaskInvalidParametersError(err)
+			}
+		}
+	}
+
 	err = s.taskRepository.SafeStore(task.ID, task)
 	if err != nil {
-		s.logger.Error("%s: %s", ErrorStoreTask, err.Error(), map[string]interface{}{
+		s.logger.Error(fmt.Sprintf("%s: %s", ErrorStoreTask, err.Error()), map[string]interface{}{
 			"component":  "CreateTaskAnsiblePlaybookService.Run",
 			"package":    "github.com/apenella/ransidble/internal/domain/core/service/task",
 			"project_id": projectID,
@@ -146,7 +251,7 @@ func (s *CreateTaskAnsiblePlaybookService) Run(
 
 	err = s.executor.Execute(task)
 	if err != nil {
-		s.logger.Error("%s: %s", ErrorExecuteTask, err.Error(),
+		s.logger.Error(fmt.Sprintf("%s: %s", ErrorExecuteTask, err.Error()),
 			map[string]interface{}{
 				"component":  "CreateTaskAnsiblePlaybookService.Run",
 				"package":    "github.com/apenella/ransidble/internal/domain/core/service/task",
@@ -158,3 +263,8 @@ func (s *CreateTaskAnsiblePlaybookService) Run(
 
 	return nil
 }
+
+// timeoutSeconds returns the timeout in seconds, rounded up, so a timeout shorter than a second is not turned into no timeout
+func timeoutSeconds(timeout time.Duration) int {
+	return int((timeout + time.Second - 1) / time.Second)
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/apenella/ransidble/internal/domain/core/entity"
 	domainerror "github.com/apenella/ransidble/internal/domain/core/error"
@@ -116,6 +117,41 @@ func TestRun(t *testing.T) {
 				service.projectRepository.(*repository.MockProjectRepository).On("Find", "project-id").Return(nil, errors.New("error finding project"))
 			},
 		},
+		{
+			desc: "Testing error running a task on the CreateTaskAnsiblePlaybookService pinning a project version that does not exist",
+			err:  domainerror.NewProjectNotFoundError(ErrFindingProjectVersion),
+			service: NewCreateTaskAnsiblePlaybookService(
+				repository.NewMockTaskExecutor(),
+				repository.NewMockTaskRepository(),
+				repository.NewMockProjectRepository(),
+				logger.NewFakeLogger(),
+			),
+			task: &entity.Task{
+				ProjectID:      "project-id",
+				ProjectVersion: "v2",
+			},
+			arrangeFunc: func(t *testing.T, service *CreateTaskAnsiblePlaybookService) {
+				service.projectRepository.(*repository.MockProjectRepository).On("Find", "project-id").Return(&entity.Project{Name: "project-id"}, nil)
+				service.projectRepository.(*repository.MockProjectRepository).On("FindVersion", "project-id", "v2").Return(nil, errors.New("version not found"))
+			},
+		},
+		{
+			desc: "Testing error running a task on the CreateTaskAnsiblePlaybookService pinning an invalid project version",
+			err:  domainerror.NewTaskInvalidParametersError(entity.ValidateProjectVersion("../v2")),
+			service: NewCreateTaskAnsiblePlaybookService(
+				repository.NewMockTaskExecutor(),
+				repository.NewMockTaskRepository(),
+				repository.NewMockProjectRepository(),
+				logger.NewFakeLogger(),
+			),
+			task: &entity.Task{
+				ProjectID:      "project-id",
+				ProjectVersion: "../v2",
+			},
+			arrangeFunc: func(t *testing.T, service *CreateTaskAnsiblePlaybookService) {
+				service.projectRepository.(*repository.MockProjectRepository).On("Find", "project-id").Return(&entity.Project{Name: "project-id"}, nil)
+			},
+		},
 		{
 			desc: "Testing error running a task on the CreateTaskAnsiblePlaybookService having an error on store the task into the repository",
 			err:  fmt.Errorf("%s: %w", ErrorStoreTask, errors.New("error storing task")),
@@ -128,7 +164,7 @@ func TestRun(t *testing.T) {
 			task: &entity.Task{
 				ID:         "task-id",
 				Status:     "PENDING",
-				Parameters: &entity.AnsiblePlaybookParameters{},
+				Parameters: &entity.AnsiblePlaybookParameters{Inventory: "inventory"},
 				Command:    "ansible-playbook",
 				ProjectID:  "project-id",
 			},
@@ -142,7 +178,7 @@ func TestRun(t *testing.T) {
 				service.taskRepository.(*repository.MockTaskRepository).On("SafeStore", "task-id", &entity.Task{
 					ID:         "task-id",
 					Status:     "PENDING",
-					Parameters: &entity.AnsiblePlaybookParameters{},
+					Parameters: &entity.AnsiblePlaybookParameters{Inventory: "inventory"},
 					Command:    "ansible-playbook",
 					ProjectID:  "project-id",
 				}).Return(errors.New("error storing task"))
@@ -160,7 +196,7 @@ func TestRun(t *testing.T) {
 			task: &entity.Task{
 				ID:         "task-id",
 				Status:     "PENDING",
-				Parameters: &entity.AnsiblePlaybookParameters{},
+				Parameters: &entity.AnsiblePlaybookParameters{Inventory: "inventory"},
 				Command:    "ansible-playbook",
 				ProjectID:  "project-id",
 			},
@@ -174,19 +210,444 @@ func TestRun(t *testing.T) {
 				service.taskRepository.(*repository.MockTaskRepository).On("SafeStore", "task-id", &entity.Task{
 					ID:         "task-id",
 					Status:     "PENDING",
-					Parameters: &entity.AnsiblePlaybookParameters{},
+					Parameters: &entity.AnsiblePlaybookParameters{Inventory: "inventory"},
 					Command:    "ansible-playbook",
 					ProjectID:  "project-id",
 				}).Return(nil)
 				service.executor.(*repository.MockTaskExecutor).On("Execute", &entity.Task{
 					ID:         "task-id",
 					Status:     "PENDING",
-					Parameters: &entity.AnsiblePlaybookParameters{},
+					Parameters: &entity.AnsiblePlaybookParameters{Inventory: "inventory"},
 					Command:    "ansible-playbook",
 					ProjectID:  "project-id",
 				}).Return(errors.New("error executing task"))
 			},
 		},
+		{
+			desc: "Testing error running a task on the CreateTaskAnsiblePlaybookService having an execution timeout greater than the maximum",
+			err:  domainerror.NewTaskInvalidParametersError(fmt.Errorf("%s: %ds > %ds", ErrExecutionTimeoutExceedsMaximum, 120, 60)),
+			service: NewCreateTaskAnsiblePlaybookService(
+				repository.NewMockTaskExecutor(),
+				repository.NewMockTaskRepository(),
+				repository.NewMockProjectRepository(),
+				logger.NewFakeLogger(),
+			).WithExecutionTimeout(30*time.Second, 60*time.Second),
+			task: &entity.Task{
+				ID:     "task-id",
+				Status: "PENDING",
+				Parameters: &entity.AnsiblePlaybookParameters{
+					ExecutionTimeout: 120,
+					Inventory:        "inventory",
+				},
+				Command:   "ansible-playbook",
+				ProjectID: "project-id",
+			},
+			arrangeFunc: func(t *testing.T, service *CreateTaskAnsiblePlaybookService) {
+				service.projectRepository.(*repository.MockProjectRepository).On("Find", "project-id").Return(&entity.Project{
+					Name:      "project-id",
+					Reference: "project-id",
+					Format:    "plain",
+					Storage:   "local",
+				}, nil)
+			},
+		},
+		{
+			desc: "Testing error running a task on the CreateTaskAnsiblePlaybookService having a playbook that is not in the project",
+			err:  domainerror.NewTaskInvalidParametersError(fmt.Errorf("%w: %s", entity.ErrProjectPlaybookNotFound, "unknown.yml")),
+			service: NewCreateTaskAnsiblePlaybookService(
+				repository.NewMockTaskExecutor(),
+				repository.NewMockTaskRepository(),
+				repository.NewMockProjectRepository(),
+				logger.NewFakeLogger(),
+			),
+			task: &entity.Task{
+				ID:     "task-id",
+				Status: "PENDING",
+				Parameters: &entity.AnsiblePlaybookParameters{
+					Playbooks: []string{"site.yml", "unknown.yml"},
+					Inventory: "inventory",
+				},
+				Command:   "ansible-playbook",
+				ProjectID: "project-id",
+			},
+			arrangeFunc: func(t *testing.T, service *CreateTaskAnsiblePlaybookService) {
+				service.projectRepository.(*repository.MockProjectRepository).On("Find", "project-id").Return(&entity.Project{
+					Name:      "project-id",
+					Reference: "project-id.tar.gz",
+					Format:    "targz",
+					Storage:   "local",
+					Contents: &entity.ProjectContents{
+						Files:       []string{"inventory/hosts.yml", "site.yml"},
+						Inventories: []string{"inventory"},
+						Playbooks:   []string{"site.yml"},
+					},
+				}, nil)
+			},
+		},
+		{
+			desc: "Testing error running a task on the CreateTaskAnsiblePlaybookService having an inventory that is not in the pinned project version",
+			err:  domainerror.NewTaskInvalidParametersError(fmt.Errorf("%w: %s", entity.ErrProjectInventoryNotFound, "inventories/staging")),
+			service: NewCreateTaskAnsiblePlaybookService(
+				repository.NewMockTaskExecutor(),
+				repository.NewMockTaskRepository(),
+				repository.NewMockProjectRepository(),
+				logger.NewFakeLogger(),
+			),
+			task: &entity.Task{
+				ID:     "task-id",
+				Status: "PENDING",
+				Parameters: &entity.AnsiblePlaybookParameters{
+					Playbooks: []string{"site.yml"},
+					Inventory: "inventories/staging",
+				},
+				Command:        "ansible-playbook",
+				ProjectID:      "project-id",
+				ProjectVersion: "v1",
+			},
+			arrangeFunc: func(t *testing.T, service *CreateTaskAnsiblePlaybookService) {
+				service.projectRepository.(*repository.MockProjectRepository).On("Find", "project-id").Return(&entity.Project{
+					Name:      "project-id",
+					Reference: "project-id.tar.gz",
+					Format:    "targz",
+					Storage:   "local",
+					Version:   "v2",
+					Contents: &entity.ProjectContents{
+						Files:       []string{"inventories/staging/hosts.yml", "site.yml"},
+						Inventories: []string{"inventories/staging"},
+						Playbooks:   []string{"site.yml"},
+					},
+				}, nil)
+				service.projectRepository.(*repository.MockProjectRepository).On("FindVersion", "project-id", "v1").Return(&entity.Project{
+					Name:      "project-id",
+					Reference: "project-id@v1.tar.gz",
+					Format:    "targz",
+					Storage:   "local",
+					Version:   "v1",
+					Contents: &entity.ProjectContents{
+						Files:       []string{"inventories/production/hosts.yml", "site.yml"},
+						Inventories: []string{"inventories/production"},
+						Playbooks:   []string{"site.yml"},
+					},
+				}, nil)
+			},
+		},
+		{
+			desc: "Testing success running a task on the CreateTaskAnsiblePlaybookService having the playbooks and the inventory of the project",
+			err:  errors.New(""),
+			service: NewCreateTaskAnsiblePlaybookService(
+				repository.NewMockTaskExecutor(),
+				repository.NewMockTaskRepository(),
+				repository.NewMockProjectRepository(),
+				logger.NewFakeLogger(),
+			),
+			task: &entity.Task{
+				ID:     "task-id",
+				Status: "PENDING",
+				Parameters: &entity.AnsiblePlaybookParameters{
+					Playbooks: []string{"site.yml"},
+					Inventory: "inventory/hosts.yml",
+				},
+				Command:   "ansible-playbook",
+				ProjectID: "project-id",
+			},
+			arrangeFunc: func(t *testing.T, service *CreateTaskAnsiblePlaybookService) {
+				expectedTask := &entity.Task{
+					ID:     "task-id",
+					Status: "PENDING",
+					Parameters: &entity.AnsiblePlaybookParameters{
+						Playbooks: []string{"site.yml"},
+						Inventory: "inventory/hosts.yml",
+					},
+					Command:   "ansible-playbook",
+					ProjectID: "project-id",
+				}
+
+				service.projectRepository.(*repository.MockProjectRepository).On("Find", "project-id").Return(&entity.Project{
+					Name:      "project-id",
+					Reference: "project-id.tar.gz",
+					Format:    "targz",
+					Storage:   "local",
+					Contents: &entity.ProjectContents{
+						Files:       []string{"inventory/hosts.yml", "site.yml"},
+						Inventories: []string{"inventory"},
+						Playbooks:   []string{"site.yml"},
+					},
+				}, nil)
+				service.taskRepository.(*repository.MockTaskRepository).On("SafeStore", "task-id", expectedTask).Return(nil)
+				service.executor.(*repository.MockTaskExecutor).On("Execute", expectedTask).Return(nil)
+			},
+		},
+		{
+			desc: "Testing success running a task on the CreateTaskAnsiblePlaybookService pinning the most recent version of the project when the task does not pin a version",
+			err:  errors.New(""),
+			service: NewCreateTaskAnsiblePlaybookService(
+				repository.NewMockTaskExecutor(),
+				repository.NewMockTaskRepository(),
+				repository.NewMockProjectRepository(),
+				logger.NewFakeLogger(),
+			),
+			task: &entity.Task{
+				ID:     "task-id",
+				Status: "PENDING",
+				Parameters: &entity.AnsiblePlaybookParameters{
+					Playbooks: []string{"site.yml"},
+					Inventory: "inventory/hosts.yml",
+				},
+				Command:        "ansible-playbook",
+				ProjectID:      "project-id",
+				ProjectVersion: entity.LatestVersion,
+			},
+			arrangeFunc: func(t *testing.T, service *CreateTaskAnsiblePlaybookService) {
+				expectedTask := &entity.Task{
+					ID:     "task-id",
+					Status: "PENDING",
+					Parameters: &entity.AnsiblePlaybookParameters{
+						Playbooks: []string{"site.yml"},
+						Inventory: "inventory/hosts.yml",
+					},
+					Command:        "ansible-playbook",
+					ProjectID:      "project-id",
+					ProjectVersion: "v2",
+				}
+
+				service.projectRepository.(*repository.MockProjectRepository).On("Find", "project-id").Return(&entity.Project{
+					Name:      "project-id",
+					Reference: "project-id@v2.tar.gz",
+					Format:    "targz",
+					Storage:   "local",
+					Version:   "v2",
+					Contents: &entity.ProjectContents{
+						Files:       []string{"inventory/hosts.yml", "site.yml"},
+						Inventories: []string{"inventory"},
+						Playbooks:   []string{"site.yml"},
+					},
+				}, nil)
+				service.taskRepository.(*repository.MockTaskRepository).On("SafeStore", "task-id", expectedTask).Return(nil)
+				service.executor.(*repository.MockTaskExecutor).On("Execute", expectedTask).Return(nil)
+			},
+		},
+		{
+			desc: "Testing error running a task on the CreateTaskAnsiblePlaybookService without an inventory",
+			err:  domainerror.NewTaskInvalidParametersError(ErrInventoryNotProvided),
+			service: NewCreateTaskAnsiblePlaybookService(
+				repository.NewMockTaskExecutor(),
+				repository.NewMockTaskRepository(),
+				repository.NewMockProjectRepository(),
+				logger.NewFakeLogger(),
+			),
+			task: &entity.Task{
+				ID:     "task-id",
+				Status: "PENDING",
+				Parameters: &entity.AnsiblePlaybookParameters{
+					Playbooks: []string{"site.yml"},
+				},
+				Command:   "ansible-playbook",
+				ProjectID: "project-id",
+			},
+			arrangeFunc: func(t *testing.T, service *CreateTaskAnsiblePlaybookService) {
+				service.projectRepository.(*repository.MockProjectRepository).On("Find", "project-id").Return(&entity.Project{
+					Name:      "project-id",
+					Reference: "project-id.tar.gz",
+					Format:    "targz",
+					Storage:   "local",
+				}, nil)
+			},
+		},
+		{
+			desc: "Testing error running a task on the CreateTaskAnsiblePlaybookService missing an extra variable required by the project manifest",
+			err:  domainerror.NewTaskInvalidParametersError(fmt.Errorf("%w: %s", entity.ErrProjectVariableRequired, "environment")),
+			service: NewCreateTaskAnsiblePlaybookService(
+				repository.NewMockTaskExecutor(),
+				repository.NewMockTaskRepository(),
+				repository.NewMockProjectRepository(),
+				logger.NewFakeLogger(),
+			),
+			task: &entity.Task{
+				ID:     "task-id",
+				Status: "PENDING",
+				Parameters: &entity.AnsiblePlaybookParameters{
+					Playbooks: []string{"site.yml"},
+				},
+				Command:   "ansible-playbook",
+				ProjectID: "project-id",
+			},
+			arrangeFunc: func(t *testing.T, service *CreateTaskAnsiblePlaybookService) {
+				service.projectRepository.(*repository.MockProjectRepository).On("Find", "project-id").Return(&entity.Project{
+					Name:      "project-id",
+					Reference: "project-id.tar.gz",
+					Format:    "targz",
+					Storage:   "local",
+					Manifest: &entity.ProjectManifest{
+						Defaults:  &entity.ProjectManifestDefaults{Inventory: "inventory"},
+						Variables: &entity.ProjectManifestVariables{Required: []string{"environment"}},
+					},
+				}, nil)
+			},
+		},
+		{
+			desc: "Testing success running a task on the CreateTaskAnsiblePlaybookService applying the defaults of the project manifest",
+			err:  errors.New(""),
+			service: NewCreateTaskAnsiblePlaybookService(
+				repository.NewMockTaskExecutor(),
+				repository.NewMockTaskRepository(),
+				repository.NewMockProjectRepository(),
+				logger.NewFakeLogger(),
+			),
+			task: &entity.Task{
+				ID:     "task-id",
+				Status: "PENDING",
+				Parameters: &entity.AnsiblePlaybookParameters{
+					Playbooks: []string{"site.yml"},
+					ExtraVars: map[string]interface{}{"environment": "production"},
+				},
+				Command:   "ansible-playbook",
+				ProjectID: "project-id",
+			},
+			arrangeFunc: func(t *testing.T, service *CreateTaskAnsiblePlaybookService) {
+				expectedTask := &entity.Task{
+					ID:     "task-id",
+					Status: "PENDING",
+					Parameters: &entity.AnsiblePlaybookParameters{
+						Playbooks: []string{"site.yml"},
+						ExtraVars: map[string]interface{}{"environment": "production", "version": "1.0.0"},
+						Forks:     10,
+						Inventory: "inventory",
+					},
+					Command:   "ansible-playbook",
+					ProjectID: "project-id",
+				}
+
+				service.projectRepository.(*repository.MockProjectRepository).On("Find", "project-id").Return(&entity.Project{
+					Name:      "project-id",
+					Reference: "project-id.tar.gz",
+					Format:    "targz",
+					Storage:   "local",
+					Contents: &entity.ProjectContents{
+						Files:       []string{"inventory/hosts.yml", "site.yml"},
+						Inventories: []string{"inventory"},
+						Playbooks:   []string{"site.yml"},
+					},
+					Manifest: &entity.ProjectManifest{
+						Defaults: &entity.ProjectManifestDefaults{
+							ExtraVars: map[string]interface{}{"version": "1.0.0"},
+							Forks:     10,
+							Inventory: "inventory",
+						},
+						Playbooks: []string{"site.yml"},
+						Variables: &entity.ProjectManifestVariables{
+							Allowed:  []string{"version"},
+							Required: []string{"environment"},
+						},
+					},
+				}, nil)
+				service.taskRepository.(*repository.MockTaskRepository).On("SafeStore", "task-id", expectedTask).Return(nil)
+				service.executor.(*repository.MockTaskExecutor).On("Execute", expectedTask).Return(nil)
+			},
+		},
+		{
+			desc: "Testing success running a task on the CreateTaskAnsiblePlaybookService rounding up a default execution timeout shorter than a second",
+			err:  errors.New(""),
+			service: NewCreateTaskAnsiblePlaybookService(
+				repository.NewMockTaskExecutor(),
+				repository.NewMockTaskRepository(),
+				repository.NewMockProjectRepository(),
+				logger.NewFakeLogger(),
+			).WithExecutionTimeout(500*time.Millisecond, 0),
+			task: &entity.Task{
+				ID:         "task-id",
+				Status:     "PENDING",
+				Parameters: &entity.AnsiblePlaybookParameters{Inventory: "inventory"},
+				Command:    "ansible-playbook",
+				ProjectID:  "project-id",
+			},
+			arrangeFunc: func(t *testing.T, service *CreateTaskAnsiblePlaybookService) {
+				expectedTask := &entity.Task{
+					ID:     "task-id",
+					Status: "PENDING",
+					Parameters: &entity.AnsiblePlaybookParameters{
+						ExecutionTimeout: 1,
+						Inventory:        "inventory",
+					},
+					Command:   "ansible-playbook",
+					ProjectID: "project-id",
+				}
+
+				service.projectRepository.(*repository.MockProjectRepository).On("Find", "project-id").Return(&entity.Project{
+					Name:      "project-id",
+					Reference: "project-id",
+					Format:    "plain",
+					Storage:   "local",
+				}, nil)
+				service.taskRepository.(*repository.MockTaskRepository).On("SafeStore", "task-id", expectedTask).Return(nil)
+				service.executor.(*repository.MockTaskExecutor).On("Execute", expectedTask).Return(nil)
+			},
+		},
+		{
+			desc: "Testing error running a task on the CreateTaskAnsiblePlaybookService having an execution timeout greater than a maximum shorter than a second",
+			err:  domainerror.NewTaskInvalidParametersError(fmt.Errorf("%s: %ds > %ds", ErrExecutionTimeoutExceedsMaximum, 2, 1)),
+			service: NewCreateTaskAnsiblePlaybookService(
+				repository.NewMockTaskExecutor(),
+				repository.NewMockTaskRepository(),
+				repository.NewMockProjectRepository(),
+				logger.NewFakeLogger(),
+			).WithExecutionTimeout(0, 500*time.Millisecond),
+			task: &entity.Task{
+				ID:     "task-id",
+				Status: "PENDING",
+				Parameters: &entity.AnsiblePlaybookParameters{
+					ExecutionTimeout: 2,
+					Inventory:        "inventory",
+				},
+				Command:   "ansible-playbook",
+				ProjectID: "project-id",
+			},
+			arrangeFunc: func(t *testing.T, service *CreateTaskAnsiblePlaybookService) {
+				service.projectRepository.(*repository.MockProjectRepository).On("Find", "project-id").Return(&entity.Project{
+					Name:      "project-id",
+					Reference: "project-id",
+					Format:    "plain",
+					Storage:   "local",
+				}, nil)
+			},
+		},
+		{
+			desc: "Testing success running a task on the CreateTaskAnsiblePlaybookService applying the default execution timeout",
+			err:  errors.New(""),
+			service: NewCreateTaskAnsiblePlaybookService(
+				repository.NewMockTaskExecutor(),
+				repository.NewMockTaskRepository(),
+				repository.NewMockProjectRepository(),
+				logger.NewFakeLogger(),
+			).WithExecutionTimeout(30*time.Second, 60*time.Second),
+			task: &entity.Task{
+				ID:         "task-id",
+				Status:     "PENDING",
+				Parameters: &entity.AnsiblePlaybookParameters{Inventory: "inventory"},
+				Command:    "ansible-playbook",
+				ProjectID:  "project-id",
+			},
+			arrangeFunc: func(t *testing.T, service *CreateTaskAnsiblePlaybookService) {
+				expectedTask := &entity.Task{
+					ID:     "task-id",
+					Status: "PENDING",
+					Parameters: &entity.AnsiblePlaybookParameters{
+						ExecutionTimeout: 30,
+						Inventory:        "inventory",
+					},
+					Command:   "ansible-playbook",
+					ProjectID: "project-id",
+				}
+
+				service.projectRepository.(*repository.MockProjectRepository).On("Find", "project-id").Return(&entity.Project{
+					Name:      "project-id",
+					Reference: "project-id",
+					Format:    "plain",
+					Storage:   "local",
+				}, nil)
+				service.taskRepository.(*repository.MockTaskRepository).On("SafeStore", "task-id", expectedTask).Return(nil)
+				service.executor.(*repository.MockTaskExecutor).On("Execute", expectedTask).Return(nil)
+			},
+		},
 		{
 			desc: "Testing success running a task on the CreateTaskAnsiblePlaybookService",
 			err:  errors.New(""),
@@ -199,7 +660,7 @@ func TestRun(t *testing.T) {
 			task: &entity.Task{
 				ID:         "task-id",
 				Status:     "PENDING",
-				Parameters: &entity.AnsiblePlaybookParameters{},
+				Parameters: &entity.AnsiblePlaybookParameters{Inventory: "inventory"},
 				Command:    "ansible-playbook",
 				ProjectID:  "project-id",
 			},
@@ -213,14 +674,14 @@ func TestRun(t *testing.T) {
 				service.taskRepository.(*repository.MockTaskRepository).On("SafeStore", "task-id", &entity.Task{
 					ID:         "task-id",
 					Status:     "PENDING",
-					Parameters: &entity.AnsiblePlaybookParameters{},
+					Parameters: &entity.AnsiblePlaybookParameters{Inventory: "inventory"},
 					Command:    "ansible-playbook",
 					ProjectID:  "project-id",
 				}).Return(nil)
 				service.executor.(*repository.MockTaskExecutor).On("Execute", &entity.Task{
 					ID:         "task-id",
 					Status:     "PENDING",
-					Parameters: &entity.AnsiblePlaybookParameters{},
+					Parameters: &entity.AnsiblePlaybookParameters{Inventory: "inventory"},
 					Command:    "ansible-playbook",
 					ProjectID:  "project-id",
 				}).Return(nil)
@@ -0,0 +1,89 @@
+package task
+
+import (
+	"fmt"
+
+	domainerror "github.com/apenella/ransidble/internal/domain/core/error"
+	"github.com/apenella/ransidble/internal/domain/ports/repository"
+)
+
+var (
+	// ErrFindingTaskOutput represents an error when the task output can not be found
+	ErrFindingTaskOutput = fmt.Errorf("error finding task output")
+	// ErrOutputRepositoryNotInitialized represents an error when the task output repository is not initialized
+	ErrOutputRepositoryNotInitialized = fmt.Errorf("task output repository not initialized")
+)
+
+// GetTaskOutputService is a service to get the output of a task
+type GetTaskOutputService struct {
+	repository       repository.TaskRepository
+	outputRepository repository.TaskOutputRepository
+	logger           repository.Logger
+}
+
+// NewGetTaskOutputService creates a new GetTaskOutputService
+func NewGetTaskOutputService(repository repository.TaskRepository, outputRepository repository.TaskOutputRepository, logger repository.Logger) *GetTaskOutputService {
+	return &GetTaskOutputService{
+		repository:       repository,
+		outputRepository: outputRepository,
+		logger:           logger,
+	}
+}
+
+// GetTaskOutput returns the output of a task by its id
+func (t *GetTaskOutputService) GetTaskOutput(id string) ([]byte, error) {
+
+	if t.repository == nil {
+		t.logger.Error(ErrRepositoryNotInitialized.Error(), map[string]interface{}{
+			"component": "GetTaskOutputService.GetTaskOutput",
+			"package":   "github.com/apenella/ransidble/internal/domain/core/service/task",
+			"task_id":   id,
+		})
+		return nil, ErrRepositoryNotInitialized
+	}
+
+	if t.outputRepository == nil {
+		t.logger.Error(ErrOutputRepositoryNotInitialized.Error(), map[string]interface{}{
+			"component": "GetTaskOutputService.GetTaskOutput",
+			"package":   "github.com/apenella/ransidble/internal/domain/core/service/task",
+			"task_id":   id,
+		})
+		return nil, ErrOutputRepositoryNotInitialized
+	}
+
+	if id == "" {
+		t.logger.Error(ErrTaskIDNotProvided.Error(), map[string]interface{}{
+			"component": "GetTaskOutputService.GetTaskOutput",
+			"package":   "github.com/apenella/ransidble/internal/domain/core/service/task",
+		})
+
+		return nil, domainerror.NewTaskNotProvidedError(ErrTaskIDNotProvided)
+	}
+
+	// the task is searched to distinguish between a task without output and a task that does not exist
+	_, err := t.repository.Find(id)
+	if err != nil {
+		t.logger.Error(fmt.Sprintf("%s: %s", ErrFindingTask.Error(), err.Error()), map[string]interface{}{
+			"component": "GetTaskOutputService.GetTaskOutput",
+			"package":   "github.com/apenella/ransidble/internal/domain/core/service/task",
+			"task_id":   id,
+		})
+
+		return nil, domainerror.NewTaskNotFoundError(
+			fmt.Errorf("%s %s: %w", ErrFindingTask.Error(), id, err),
+		)
+	}
+
+	output, err := t.outputRepository.Find(id)
+	if err != nil {
+		t.logger.Error(fmt.Sprintf("%s: %s", ErrFindingTaskOutput.Error(), err.Error()), map[string]interface{}{
+			"component": "GetTaskOutputService.GetTaskOutput",
+			"package":   "github.com/apenella/ransidble/internal/domain/core/service/task",
+			"task_id":   id,
+		})
+
+		return nil, fmt.Errorf("%s %s: %w", ErrFindingTaskOutput.Error(), id, err)
+	}
+
+	return output, nil
+}
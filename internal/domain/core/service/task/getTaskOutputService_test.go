@@ -0,0 +1,116 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	domainerror "github.com/apenella/ransidble/internal/domain/core/error"
+	"github.com/apenella/ransidble/internal/domain/ports/repository"
+	"github.com/apenella/ransidble/internal/infrastructure/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetTaskOutput(t *testing.T) {
+	tests := []struct {
+		desc        string
+		id          string
+		err         error
+		expected    []byte
+		service     *GetTaskOutputService
+		arrangeFunc func(*testing.T, *GetTaskOutputService)
+	}{
+		{
+			desc:     "Testing getting the output of a task on the GetTaskOutputService",
+			id:       "task-id",
+			expected: []byte("PLAY [all] ***\n"),
+			service: NewGetTaskOutputService(
+				repository.NewMockTaskRepository(),
+				repository.NewMockTaskOutputRepository(),
+				logger.NewFakeLogger(),
+			),
+			arrangeFunc: func(t *testing.T, service *GetTaskOutputService) {
+				service.repository.(*repository.MockTaskRepository).On("Find", "task-id").Return(&entity.Task{ID: "task-id"}, nil)
+				service.outputRepository.(*repository.MockTaskOutputRepository).On("Find", "task-id").Return([]byte("PLAY [all] ***\n"), nil)
+			},
+		},
+		{
+			desc: "Testing error getting the output of a task on the GetTaskOutputService having a nil task repository",
+			id:   "task-id",
+			err:  ErrRepositoryNotInitialized,
+			service: NewGetTaskOutputService(
+				nil,
+				repository.NewMockTaskOutputRepository(),
+				logger.NewFakeLogger(),
+			),
+		},
+		{
+			desc: "Testing error getting the output of a task on the GetTaskOutputService having a nil task output repository",
+			id:   "task-id",
+			err:  ErrOutputRepositoryNotInitialized,
+			service: NewGetTaskOutputService(
+				repository.NewMockTaskRepository(),
+				nil,
+				logger.NewFakeLogger(),
+			),
+		},
+		{
+			desc: "Testing error getting the output of a task on the GetTaskOutputService having an empty task id",
+			id:   "",
+			err:  domainerror.NewTaskNotProvidedError(ErrTaskIDNotProvided),
+			service: NewGetTaskOutputService(
+				repository.NewMockTaskRepository(),
+				repository.NewMockTaskOutputRepository(),
+				logger.NewFakeLogger(),
+			),
+		},
+		{
+			desc: "Testing error getting the output of a task on the GetTaskOutputService when the task does not exist",
+			id:   "task-id",
+			err: domainerror.NewTaskNotFoundError(
+				fmt.Errorf("%s %s: %w", ErrFindingTask.Error(), "task-id", errors.New("task not found")),
+			),
+			service: NewGetTaskOutputService(
+				repository.NewMockTaskRepository(),
+				repository.NewMockTaskOutputRepository(),
+				logger.NewFakeLogger(),
+			),
+			arrangeFunc: func(t *testing.T, service *GetTaskOutputService) {
+				service.repository.(*repository.MockTaskRepository).On("Find", "task-id").Return(nil, errors.New("task not found"))
+			},
+		},
+		{
+			desc: "Testing error getting the output of a task on the GetTaskOutputService having an error finding the output",
+			id:   "task-id",
+			err:  fmt.Errorf("%s %s: %w", ErrFindingTaskOutput.Error(), "task-id", errors.New("error finding output")),
+			service: NewGetTaskOutputService(
+				repository.NewMockTaskRepository(),
+				repository.NewMockTaskOutputRepository(),
+				logger.NewFakeLogger(),
+			),
+			arrangeFunc: func(t *testing.T, service *GetTaskOutputService) {
+				service.repository.(*repository.MockTaskRepository).On("Find", "task-id").Return(&entity.Task{ID: "task-id"}, nil)
+				service.outputRepository.(*repository.MockTaskOutputRepository).On("Find", "task-id").Return(nil, errors.New("error finding output"))
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			if test.arrangeFunc != nil {
+				test.arrangeFunc(t, test.service)
+			}
+
+			output, err := test.service.GetTaskOutput(test.id)
+			if err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.Equal(t, test.expected, output)
+			}
+		})
+	}
+}
@@ -15,6 +15,10 @@ var (
 	ErrRepositoryNotInitialized = fmt.Errorf("task repository not initialized")
 	// ErrTaskIDNotProvided represents an error when the task id is not provided
 	ErrTaskIDNotProvided = fmt.Errorf("task id not provided")
+	// ErrInvalidTaskQuery represents an error when the task query is not valid
+	ErrInvalidTaskQuery = fmt.Errorf("invalid task query")
+	// ErrSearchingTasks represents an error when searching the tasks
+	ErrSearchingTasks = fmt.Errorf("error searching tasks")
 )
 
 // GetTaskService is a service to get a task
@@ -60,7 +64,7 @@ func (t *GetTaskService) GetTask(id string) (*entity.Task, error) {
 
 	task, err := t.repository.Find(id)
 	if err != nil {
-		t.logger.Error("%s: %s", ErrFindingTask.Error(), err.Error(), map[string]interface{}{
+		t.logger.Error(fmt.Sprintf("%s: %s", ErrFindingTask.Error(), err.Error()), map[string]interface{}{
 			"component": "GetTaskService.GetTask",
 			"package":   "github.com/apenella/ransidble/internal/domain/core/service/task",
 			"task_id":   id,
@@ -73,3 +77,48 @@ func (t *GetTaskService) GetTask(id string) (*entity.Task, error) {
 
 	return task, nil
 }
+
+// GetTasksList returns the page of tasks that fulfills the query. When the query is not provided, the first page of the tasks sorted by creation time is returned
+func (t *GetTaskService) GetTasksList(query *entity.TaskQuery) (*entity.TaskPage, error) {
+
+	if t.repository == nil {
+		t.logger.Error(ErrRepositoryNotInitialized.Error(), map[string]interface{}{
+			"component": "GetTaskService.GetTasksList",
+			"package":   "github.com/apenella/ransidble/internal/domain/core/service/task",
+		})
+		return nil, ErrRepositoryNotInitialized
+	}
+
+	if query == nil {
+		query = entity.NewTaskQuery()
+	}
+
+	err := query.Validate()
+	if err != nil {
+		t.logger.Error(fmt.Sprintf("%s: %s", ErrInvalidTaskQuery.Error(), err.Error()), map[string]interface{}{
+			"component": "GetTaskService.GetTasksList",
+			"package":   "github.com/apenella/ransidble/internal/domain/core/service/task",
+		})
+
+		return nil, domainerror.NewTaskInvalidQueryError(
+			fmt.Errorf("%s: %w", ErrInvalidTaskQuery.Error(), err),
+		)
+	}
+
+	t.logger.Debug("getting task list", map[string]interface{}{
+		"component": "GetTaskService.GetTasksList",
+		"package":   "github.com/apenella/ransidble/internal/domain/core/service/task",
+	})
+
+	page, err := t.repository.Search(query)
+	if err != nil {
+		t.logger.Error(fmt.Sprintf("%s: %s", ErrSearchingTasks.Error(), err.Error()), map[string]interface{}{
+			"component": "GetTaskService.GetTasksList",
+			"package":   "github.com/apenella/ransidble/internal/domain/core/service/task",
+		})
+
+		return nil, fmt.Errorf("%s: %w", ErrSearchingTasks.Error(), err)
+	}
+
+	return page, nil
+}
@@ -103,3 +103,112 @@ func TestGetTask(t *testing.T) {
 		})
 	}
 }
+
+func TestGetTasksList(t *testing.T) {
+	tests := []struct {
+		desc        string
+		query       *entity.TaskQuery
+		err         error
+		expected    *entity.TaskPage
+		service     *GetTaskService
+		arrangeFunc func(*testing.T, *GetTaskService)
+	}{
+		{
+			desc:  "Testing getting a list of tasks on the GetTaskService",
+			query: entity.NewTaskQuery(),
+			err:   errors.New(""),
+			expected: &entity.TaskPage{
+				NextCursor: "cursor",
+				Tasks: []*entity.Task{
+					{ID: "task-id", Status: "PENDING", Command: "ansible-playbook", ProjectID: "project-id"},
+				},
+			},
+			service: NewGetTaskService(
+				repository.NewMockTaskRepository(),
+				logger.NewFakeLogger(),
+			),
+			arrangeFunc: func(t *testing.T, service *GetTaskService) {
+				service.repository.(*repository.MockTaskRepository).On("Search", entity.NewTaskQuery()).Return(&entity.TaskPage{
+					NextCursor: "cursor",
+					Tasks: []*entity.Task{
+						{ID: "task-id", Status: "PENDING", Command: "ansible-playbook", ProjectID: "project-id"},
+					},
+				}, nil)
+			},
+		},
+		{
+			desc:  "Testing getting a list of tasks on the GetTaskService using the default query when it is not provided",
+			query: nil,
+			err:   errors.New(""),
+			expected: &entity.TaskPage{
+				Tasks: []*entity.Task{},
+			},
+			service: NewGetTaskService(
+				repository.NewMockTaskRepository(),
+				logger.NewFakeLogger(),
+			),
+			arrangeFunc: func(t *testing.T, service *GetTaskService) {
+				service.repository.(*repository.MockTaskRepository).On("Search", entity.NewTaskQuery()).Return(&entity.TaskPage{
+					Tasks: []*entity.Task{},
+				}, nil)
+			},
+		},
+		{
+			desc:     "Testing error getting a list of tasks on the GetTaskService when the repository is not initialized",
+			query:    entity.NewTaskQuery(),
+			err:      ErrRepositoryNotInitialized,
+			expected: nil,
+			service: NewGetTaskService(
+				nil,
+				logger.NewFakeLogger(),
+			),
+		},
+		{
+			desc: "Testing error getting a list of tasks on the GetTaskService when the query is not valid",
+			query: &entity.TaskQuery{
+				Order:  entity.TaskSortOrderAsc,
+				SortBy: entity.TaskSortByCreatedAt,
+				Cursor: "invalid-cursor",
+			},
+			err: domainerror.NewTaskInvalidQueryError(
+				fmt.Errorf("%s: %w", ErrInvalidTaskQuery.Error(), entity.ErrInvalidTaskQueryCursor),
+			),
+			expected: nil,
+			service: NewGetTaskService(
+				repository.NewMockTaskRepository(),
+				logger.NewFakeLogger(),
+			),
+		},
+		{
+			desc:     "Testing error getting a list of tasks on the GetTaskService when the repository search fails",
+			query:    entity.NewTaskQuery(),
+			err:      fmt.Errorf("%s: %w", ErrSearchingTasks.Error(), errors.New("error searching tasks")),
+			expected: nil,
+			service: NewGetTaskService(
+				repository.NewMockTaskRepository(),
+				logger.NewFakeLogger(),
+			),
+			arrangeFunc: func(t *testing.T, service *GetTaskService) {
+				service.repository.(*repository.MockTaskRepository).On("Search", entity.NewTaskQuery()).Return(nil, errors.New("error searching tasks"))
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			if test.arrangeFunc != nil {
+				test.arrangeFunc(t, test.service)
+			}
+
+			page, err := test.service.GetTasksList(test.query)
+			if err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.Equal(t, test.expected, page)
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package task
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	domainerror "github.com/apenella/ransidble/internal/domain/core/error"
+	"github.com/apenella/ransidble/internal/domain/ports/repository"
+)
+
+const (
+	// DefaultStreamTaskOutputPollInterval is the default interval to look for new output of the task being streamed
+	DefaultStreamTaskOutputPollInterval = 500 * time.Millisecond
+)
+
+var (
+	// ErrSendingTaskOutputEvent represents an error when an event can not be sent to the stream
+	ErrSendingTaskOutputEvent = fmt.Errorf("error sending task output event")
+)
+
+// StreamTaskOutputService is a service to stream the output of a task while it is generated
+type StreamTaskOutputService struct {
+	repository       repository.TaskRepository
+	outputRepository repository.TaskOutputRepository
+	logger           repository.Logger
+	pollInterval     time.Duration
+}
+
+// NewStreamTaskOutputService creates a new StreamTaskOutputService
+func NewStreamTaskOutputService(repository repository.TaskRepository, outputRepository repository.TaskOutputRepository, logger repository.Logger) *StreamTaskOutputService {
+	return &StreamTaskOutputService{
+		repository:       repository,
+		outputRepository: outputRepository,
+		logger:           logger,
+		pollInterval:     DefaultStreamTaskOutputPollInterval,
+	}
+}
+
+// StreamTaskOutput sends the output of a task line by line, starting at the given byte offset, along with the task status changes. It returns once the task reaches a final status, after sending the whole output, or when the context is done
+func (t *StreamTaskOutputService) StreamTaskOutput(ctx context.Context, id string, offset int64, send func(event *entity.TaskOutputEvent) error) error {
+
+	var err error
+	var lastStatus string
+	var output []byte
+	var task *entity.Task
+
+	if t.repository == nil {
+		t.logger.Error(ErrRepositoryNotInitialized.Error(), map[string]interface{}{
+			"component": "StreamTaskOutputService.StreamTaskOutput",
+			"package":   "github.com/apenella/ransidble/internal/domain/core/service/task",
+			"task_id":   id,
+		})
+		return ErrRepositoryNotInitialized
+	}
+
+	if t.outputRepository == nil {
+		t.logger.Error(ErrOutputRepositoryNotInitialized.Error(), map[string]interface{}{
+			"component": "StreamTaskOutputService.StreamTaskOutput",
+			"package":   "github.com/apenella/ransidble/internal/domain/core/service/task",
+			"task_id":   id,
+		})
+		return ErrOutputRepositoryNotInitialized
+	}
+
+	if id == "" {
+		t.logger.Error(ErrTaskIDNotProvided.Error(), map[string]interface{}{
+			"component": "StreamTaskOutputService.StreamTaskOutput",
+			"package":   "github.com/apenella/ransidble/internal/domain/core/service/task",
+		})
+
+		return domainerror.NewTaskNotProvidedError(ErrTaskIDNotProvided)
+	}
+
+	if offset < 0 {
+		offset = 0
+	}
+
+	pollInterval := t.pollInterval
+	if pollInterval <= 0 {
+		pollInterval = DefaultStreamTaskOutputPollInterval
+	}
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		task, err = t.repository.Find(id)
+		if err != nil {
+			t.logger.Error(fmt.Sprintf("%s: %s", ErrFindingTask.Error(), err.Error()), map[string]interface{}{
+				"component": "StreamTaskOutputService.StreamTaskOutput",
+				"package":   "github.com/apenella/ransidble/internal/domain/core/service/task",
+				"task_id":   id,
+			})
+
+			return domainerror.NewTaskNotFoundError(
+				fmt.Errorf("%s %s: %w", ErrFindingTask.Error(), id, err),
+			)
+		}
+
+		// the status is read before the output to ensure that the whole output is already stored when the task is finished
+		status := task.GetStatus()
+		finished := task.IsFinished()
+
+		output, err = t.outputRepository.Find(id)
+		if err != nil {
+			t.logger.Error(fmt.Sprintf("%s: %s", ErrFindingTaskOutput.Error(), err.Error()), map[string]interface{}{
+				"component": "StreamTaskOutputService.StreamTaskOutput",
+				"package":   "github.com/apenella/ransidble/internal/domain/core/service/task",
+				"task_id":   id,
+			})
+
+			return fmt.Errorf("%s %s: %w", ErrFindingTaskOutput.Error(), id, err)
+		}
+
+		offset, err = sendTaskOutputLines(output, offset, finished, send)
+		if err != nil {
+			return t.sendError(id, err)
+		}
+
+		if status != lastStatus {
+			err = send(entity.NewTaskOutputStatusEvent(status, offset))
+			if err != nil {
+				return t.sendError(id, err)
+			}
+			lastStatus = status
+		}
+
+		if finished {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			t.logger.Debug("task output stream closed before the task is finished", map[string]interface{}{
+				"component": "StreamTaskOutputService.StreamTaskOutput",
+				"package":   "github.com/apenella/ransidble/internal/domain/core/service/task",
+				"task_id":   id,
+			})
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// sendError logs and returns the error produced sending an event
+func (t *StreamTaskOutputService) sendError(id string, err error) error {
+	t.logger.Error(fmt.Sprintf("%s: %s", ErrSendingTaskOutputEvent.Error(), err.Error()), map[string]interface{}{
+		"component": "StreamTaskOutputService.StreamTaskOutput",
+		"package":   "github.com/apenella/ransidble/internal/domain/core/service/task",
+		"task_id":   id,
+	})
+
+	return fmt.Errorf("%s: %w", ErrSendingTaskOutputEvent.Error(), err)
+}
+
+// sendTaskOutputLines sends the complete lines of the output found after the offset and returns the offset where the unsent output starts. When flush is true, the trailing data without line break is also sent
+func sendTaskOutputLines(output []byte, offset int64, flush bool, send func(event *entity.TaskOutputEvent) error) (int64, error) {
+
+	for offset < int64(len(output)) {
+		pending := output[offset:]
+		next := offset + int64(len(pending))
+		line := pending
+
+		lineBreak := bytes.IndexByte(pending, '\n')
+		if lineBreak >= 0 {
+			line = pending[:lineBreak]
+			next = offset + int64(lineBreak) + 1
+		} else if !flush {
+			break
+		}
+
+		err := send(entity.NewTaskOutputLineEvent(strings.TrimSuffix(string(line), "\r"), next))
+		if err != nil {
+			return offset, err
+		}
+
+		offset = next
+	}
+
+	return offset, nil
+}
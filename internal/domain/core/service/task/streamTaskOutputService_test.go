@@ -0,0 +1,224 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	domainerror "github.com/apenella/ransidble/internal/domain/core/error"
+	"github.com/apenella/ransidble/internal/domain/ports/repository"
+	"github.com/apenella/ransidble/internal/infrastructure/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStreamTaskOutput(t *testing.T) {
+
+	cancelledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		desc        string
+		ctx         context.Context
+		id          string
+		offset      int64
+		sendErr     error
+		err         error
+		expected    []*entity.TaskOutputEvent
+		service     *StreamTaskOutputService
+		arrangeFunc func(*testing.T, *StreamTaskOutputService)
+	}{
+		{
+			desc:   "Testing streaming the output of a finished task on the StreamTaskOutputService",
+			ctx:    context.Background(),
+			id:     "task-id",
+			offset: 0,
+			expected: []*entity.TaskOutputEvent{
+				entity.NewTaskOutputLineEvent("line1", 6),
+				entity.NewTaskOutputLineEvent("line2", 13),
+				entity.NewTaskOutputLineEvent("partial", 20),
+				entity.NewTaskOutputStatusEvent(entity.SUCCESS, 20),
+			},
+			service: NewStreamTaskOutputService(
+				repository.NewMockTaskRepository(),
+				repository.NewMockTaskOutputRepository(),
+				logger.NewFakeLogger(),
+			),
+			arrangeFunc: func(t *testing.T, service *StreamTaskOutputService) {
+				service.repository.(*repository.MockTaskRepository).On("Find", "task-id").Return(&entity.Task{ID: "task-id", Status: entity.SUCCESS}, nil)
+				service.outputRepository.(*repository.MockTaskOutputRepository).On("Find", "task-id").Return([]byte("line1\nline2\r\npartial"), nil)
+			},
+		},
+		{
+			desc:   "Testing streaming the output of a finished task from an offset on the StreamTaskOutputService",
+			ctx:    context.Background(),
+			id:     "task-id",
+			offset: 6,
+			expected: []*entity.TaskOutputEvent{
+				entity.NewTaskOutputLineEvent("line2", 12),
+				entity.NewTaskOutputStatusEvent(entity.FAILED, 12),
+			},
+			service: NewStreamTaskOutputService(
+				repository.NewMockTaskRepository(),
+				repository.NewMockTaskOutputRepository(),
+				logger.NewFakeLogger(),
+			),
+			arrangeFunc: func(t *testing.T, service *StreamTaskOutputService) {
+				service.repository.(*repository.MockTaskRepository).On("Find", "task-id").Return(&entity.Task{ID: "task-id", Status: entity.FAILED}, nil)
+				service.outputRepository.(*repository.MockTaskOutputRepository).On("Find", "task-id").Return([]byte("line1\nline2\n"), nil)
+			},
+		},
+		{
+			desc:   "Testing streaming the output of a running task until it is finished on the StreamTaskOutputService",
+			ctx:    context.Background(),
+			id:     "task-id",
+			offset: 0,
+			expected: []*entity.TaskOutputEvent{
+				entity.NewTaskOutputLineEvent("line1", 6),
+				entity.NewTaskOutputStatusEvent(entity.RUNNING, 6),
+				entity.NewTaskOutputLineEvent("line2", 12),
+				entity.NewTaskOutputStatusEvent(entity.SUCCESS, 12),
+			},
+			service: NewStreamTaskOutputService(
+				repository.NewMockTaskRepository(),
+				repository.NewMockTaskOutputRepository(),
+				logger.NewFakeLogger(),
+			),
+			arrangeFunc: func(t *testing.T, service *StreamTaskOutputService) {
+				service.pollInterval = time.Millisecond
+				service.repository.(*repository.MockTaskRepository).On("Find", "task-id").Return(&entity.Task{ID: "task-id", Status: entity.RUNNING}, nil).Once()
+				service.repository.(*repository.MockTaskRepository).On("Find", "task-id").Return(&entity.Task{ID: "task-id", Status: entity.SUCCESS}, nil)
+				service.outputRepository.(*repository.MockTaskOutputRepository).On("Find", "task-id").Return([]byte("line1\nli"), nil).Once()
+				service.outputRepository.(*repository.MockTaskOutputRepository).On("Find", "task-id").Return([]byte("line1\nline2\n"), nil)
+			},
+		},
+		{
+			desc:   "Testing streaming the output of a running task until the context is done on the StreamTaskOutputService",
+			ctx:    cancelledCtx,
+			id:     "task-id",
+			offset: 0,
+			expected: []*entity.TaskOutputEvent{
+				entity.NewTaskOutputStatusEvent(entity.PENDING, 0),
+			},
+			service: NewStreamTaskOutputService(
+				repository.NewMockTaskRepository(),
+				repository.NewMockTaskOutputRepository(),
+				logger.NewFakeLogger(),
+			),
+			arrangeFunc: func(t *testing.T, service *StreamTaskOutputService) {
+				service.pollInterval = time.Hour
+				service.repository.(*repository.MockTaskRepository).On("Find", "task-id").Return(&entity.Task{ID: "task-id", Status: entity.PENDING}, nil)
+				service.outputRepository.(*repository.MockTaskOutputRepository).On("Find", "task-id").Return([]byte{}, nil)
+			},
+		},
+		{
+			desc: "Testing error streaming the output of a task on the StreamTaskOutputService having a nil task repository",
+			ctx:  context.Background(),
+			id:   "task-id",
+			err:  ErrRepositoryNotInitialized,
+			service: NewStreamTaskOutputService(
+				nil,
+				repository.NewMockTaskOutputRepository(),
+				logger.NewFakeLogger(),
+			),
+		},
+		{
+			desc: "Testing error streaming the output of a task on the StreamTaskOutputService having a nil task output repository",
+			ctx:  context.Background(),
+			id:   "task-id",
+			err:  ErrOutputRepositoryNotInitialized,
+			service: NewStreamTaskOutputService(
+				repository.NewMockTaskRepository(),
+				nil,
+				logger.NewFakeLogger(),
+			),
+		},
+		{
+			desc: "Testing error streaming the output of a task on the StreamTaskOutputService having an empty task id",
+			ctx:  context.Background(),
+			id:   "",
+			err:  domainerror.NewTaskNotProvidedError(ErrTaskIDNotProvided),
+			service: NewStreamTaskOutputService(
+				repository.NewMockTaskRepository(),
+				repository.NewMockTaskOutputRepository(),
+				logger.NewFakeLogger(),
+			),
+		},
+		{
+			desc: "Testing error streaming the output of a task on the StreamTaskOutputService when the task does not exist",
+			ctx:  context.Background(),
+			id:   "task-id",
+			err: domainerror.NewTaskNotFoundError(
+				fmt.Errorf("%s %s: %w", ErrFindingTask.Error(), "task-id", errors.New("task not found")),
+			),
+			service: NewStreamTaskOutputService(
+				repository.NewMockTaskRepository(),
+				repository.NewMockTaskOutputRepository(),
+				logger.NewFakeLogger(),
+			),
+			arrangeFunc: func(t *testing.T, service *StreamTaskOutputService) {
+				service.repository.(*repository.MockTaskRepository).On("Find", "task-id").Return(nil, errors.New("task not found"))
+			},
+		},
+		{
+			desc: "Testing error streaming the output of a task on the StreamTaskOutputService having an error finding the output",
+			ctx:  context.Background(),
+			id:   "task-id",
+			err:  fmt.Errorf("%s %s: %w", ErrFindingTaskOutput.Error(), "task-id", errors.New("error finding output")),
+			service: NewStreamTaskOutputService(
+				repository.NewMockTaskRepository(),
+				repository.NewMockTaskOutputRepository(),
+				logger.NewFakeLogger(),
+			),
+			arrangeFunc: func(t *testing.T, service *StreamTaskOutputService) {
+				service.repository.(*repository.MockTaskRepository).On("Find", "task-id").Return(&entity.Task{ID: "task-id", Status: entity.RUNNING}, nil)
+				service.outputRepository.(*repository.MockTaskOutputRepository).On("Find", "task-id").Return(nil, errors.New("error finding output"))
+			},
+		},
+		{
+			desc:    "Testing error streaming the output of a task on the StreamTaskOutputService when an event can not be sent",
+			ctx:     context.Background(),
+			id:      "task-id",
+			sendErr: errors.New("connection closed"),
+			err:     fmt.Errorf("%s: %w", ErrSendingTaskOutputEvent.Error(), errors.New("connection closed")),
+			service: NewStreamTaskOutputService(
+				repository.NewMockTaskRepository(),
+				repository.NewMockTaskOutputRepository(),
+				logger.NewFakeLogger(),
+			),
+			arrangeFunc: func(t *testing.T, service *StreamTaskOutputService) {
+				service.repository.(*repository.MockTaskRepository).On("Find", "task-id").Return(&entity.Task{ID: "task-id", Status: entity.RUNNING}, nil)
+				service.outputRepository.(*repository.MockTaskOutputRepository).On("Find", "task-id").Return([]byte("line1\n"), nil)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			if test.arrangeFunc != nil {
+				test.arrangeFunc(t, test.service)
+			}
+
+			events := []*entity.TaskOutputEvent{}
+			send := func(event *entity.TaskOutputEvent) error {
+				if test.sendErr != nil {
+					return test.sendErr
+				}
+				events = append(events, event)
+				return nil
+			}
+
+			err := test.service.StreamTaskOutput(test.ctx, test.id, test.offset, send)
+			if err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.Equal(t, test.expected, events)
+			}
+		})
+	}
+}
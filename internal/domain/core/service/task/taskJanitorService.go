@@ -0,0 +1,190 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	"github.com/apenella/ransidble/internal/domain/ports/repository"
+)
+
+const (
+	// DefaultTaskJanitorInterval represents the default time between two runs of the task janitor
+	DefaultTaskJanitorInterval = 1 * time.Hour
+)
+
+var (
+	// ErrFindingExpiredTasks represents an error when the tasks to purge can not be found
+	ErrFindingExpiredTasks = fmt.Errorf("error finding expired tasks")
+	// ErrPurgingTask represents an error when an expired task can not be purged
+	ErrPurgingTask = fmt.Errorf("error purging task")
+	// ErrPurgingTaskOutput represents an error when the output of an expired task can not be purged
+	ErrPurgingTaskOutput = fmt.Errorf("error purging task output")
+)
+
+// TaskJanitorService is a service that periodically purges the tasks, and their outputs, exceeding the task retention policy
+type TaskJanitorService struct {
+	interval         time.Duration
+	logger           repository.Logger
+	mutex            sync.Mutex
+	now              func() time.Time
+	onceStart        sync.Once
+	onceStop         sync.Once
+	outputRepository repository.TaskOutputRepository
+	policy           *entity.TaskRetentionPolicy
+	repository       repository.TaskRepository
+	stats            entity.TaskJanitorStats
+	stopCh           chan struct{}
+}
+
+// NewTaskJanitorService creates a new TaskJanitorService
+func NewTaskJanitorService(policy *entity.TaskRetentionPolicy, interval time.Duration, repository repository.TaskRepository, outputRepository repository.TaskOutputRepository, logger repository.Logger) *TaskJanitorService {
+
+	if interval <= 0 {
+		interval = DefaultTaskJanitorInterval
+	}
+
+	return &TaskJanitorService{
+		interval:         interval,
+		logger:           logger,
+		now:              time.Now,
+		outputRepository: outputRepository,
+		policy:           policy,
+		repository:       repository,
+		stopCh:           make(chan struct{}),
+	}
+}
+
+// Start runs the janitor once when it starts and then on every interval, until the context is done or the janitor is stopped. The janitor does not run when the retention policy sets no limit
+func (s *TaskJanitorService) Start(ctx context.Context) {
+
+	if !s.policy.IsEnabled() {
+		s.logger.Info("Task janitor not started because the task retention policy sets no limit", map[string]interface{}{
+			"component": "TaskJanitorService.Start",
+			"package":   "github.com/apenella/ransidble/internal/domain/core/service/task",
+		})
+		return
+	}
+
+	s.onceStart.Do(func() {
+		go func() {
+			// the tasks that outlived the retention policy while the server was down are purged without waiting for the first interval
+			_, _ = s.Purge()
+
+			ticker := time.NewTicker(s.interval)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-ticker.C:
+					// the errors are already logged and reported on the janitor statistics
+					_, _ = s.Purge()
+				case <-ctx.Done():
+					return
+				case <-s.stopCh:
+					return
+				}
+			}
+		}()
+	})
+}
+
+// Stop stops the janitor
+func (s *TaskJanitorService) Stop() {
+	s.onceStop.Do(func() {
+		close(s.stopCh)
+	})
+}
+
+// Purge removes the tasks exceeding the retention policy along with their outputs, and returns the statistics of the run. A task or output that can not be removed does not stop the run
+func (s *TaskJanitorService) Purge() (*entity.TaskJanitorStats, error) {
+
+	if s.repository == nil {
+		s.logger.Error(ErrRepositoryNotInitialized.Error(), map[string]interface{}{
+			"component": "TaskJanitorService.Purge",
+			"package":   "github.com/apenella/ransidble/internal/domain/core/service/task",
+		})
+		return nil, ErrRepositoryNotInitialized
+	}
+
+	if s.outputRepository == nil {
+		s.logger.Error(ErrOutputRepositoryNotInitialized.Error(), map[string]interface{}{
+			"component": "TaskJanitorService.Purge",
+			"package":   "github.com/apenella/ransidble/internal/domain/core/service/task",
+		})
+		return nil, ErrOutputRepositoryNotInitialized
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	run := entity.TaskJanitorStats{
+		Runs:      s.stats.Runs + 1,
+		StartedAt: s.now(),
+	}
+
+	tasks, err := s.repository.FindAll()
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("%s: %s", ErrFindingExpiredTasks.Error(), err.Error()), map[string]interface{}{
+			"component": "TaskJanitorService.Purge",
+			"package":   "github.com/apenella/ransidble/internal/domain/core/service/task",
+		})
+
+		run.Errors++
+		run.CompletedAt = s.now()
+		s.stats = run
+
+		return nil, fmt.Errorf("%w: %w", ErrFindingExpiredTasks, err)
+	}
+
+	for _, task := range s.policy.Expired(tasks, run.StartedAt) {
+		err = s.repository.Remove(task.ID)
+		if err != nil {
+			s.logger.Error(fmt.Sprintf("%s: %s", ErrPurgingTask.Error(), err.Error()), map[string]interface{}{
+				"component": "TaskJanitorService.Purge",
+				"package":   "github.com/apenella/ransidble/internal/domain/core/service/task",
+				"task_id":   task.ID,
+			})
+			run.Errors++
+			continue
+		}
+		run.PurgedTasks++
+
+		err = s.outputRepository.Remove(task.ID)
+		if err != nil {
+			s.logger.Error(fmt.Sprintf("%s: %s", ErrPurgingTaskOutput.Error(), err.Error()), map[string]interface{}{
+				"component": "TaskJanitorService.Purge",
+				"package":   "github.com/apenella/ransidble/internal/domain/core/service/task",
+				"task_id":   task.ID,
+			})
+			run.Errors++
+			continue
+		}
+		run.PurgedOutputs++
+	}
+
+	run.CompletedAt = s.now()
+	s.stats = run
+
+	s.logger.Info("Task janitor run completed", map[string]interface{}{
+		"component":      "TaskJanitorService.Purge",
+		"errors":         run.Errors,
+		"package":        "github.com/apenella/ransidble/internal/domain/core/service/task",
+		"purged_outputs": run.PurgedOutputs,
+		"purged_tasks":   run.PurgedTasks,
+	})
+
+	stats := run
+	return &stats, nil
+}
+
+// GetLastRunStats returns the statistics of the last run of the janitor. The statistics have zero runs when the janitor has not run yet
+func (s *TaskJanitorService) GetLastRunStats() *entity.TaskJanitorStats {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	stats := s.stats
+	return &stats
+}
@@ -0,0 +1,200 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	"github.com/apenella/ransidble/internal/domain/ports/repository"
+	"github.com/apenella/ransidble/internal/infrastructure/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+func testingTaskJanitorNow() time.Time {
+	return time.Date(2026, 1, 5, 10, 0, 0, 0, time.UTC)
+}
+
+func TestNewTaskJanitorService(t *testing.T) {
+	t.Log("Testing creating a TaskJanitorService applying the default interval")
+	t.Parallel()
+
+	service := NewTaskJanitorService(&entity.TaskRetentionPolicy{}, 0, nil, nil, logger.NewFakeLogger())
+
+	assert.Equal(t, DefaultTaskJanitorInterval, service.interval)
+	assert.Equal(t, 0, service.GetLastRunStats().Runs)
+}
+
+func TestTaskJanitorPurge(t *testing.T) {
+	tests := []struct {
+		desc        string
+		err         error
+		expected    *entity.TaskJanitorStats
+		service     *TaskJanitorService
+		arrangeFunc func(*testing.T, *TaskJanitorService)
+	}{
+		{
+			desc: "Testing purging the expired tasks and their outputs on the TaskJanitorService",
+			expected: &entity.TaskJanitorStats{
+				CompletedAt:   testingTaskJanitorNow(),
+				PurgedOutputs: 1,
+				PurgedTasks:   2,
+				Errors:        1,
+				Runs:          1,
+				StartedAt:     testingTaskJanitorNow(),
+			},
+			service: NewTaskJanitorService(
+				&entity.TaskRetentionPolicy{MaxAge: 24 * time.Hour},
+				time.Minute,
+				repository.NewMockTaskRepository(),
+				repository.NewMockTaskOutputRepository(),
+				logger.NewFakeLogger(),
+			),
+			arrangeFunc: func(t *testing.T, service *TaskJanitorService) {
+				service.repository.(*repository.MockTaskRepository).On("FindAll").Return([]*entity.Task{
+					{ID: "task-1", Status: entity.SUCCESS, CompletedAt: "2026-01-01T10:00:00Z"},
+					{ID: "task-2", Status: entity.FAILED, CompletedAt: "2026-01-02T10:00:00Z"},
+					{ID: "task-3", Status: entity.SUCCESS, CompletedAt: "2026-01-05T09:00:00Z"},
+					{ID: "task-4", Status: entity.RUNNING, CreatedAt: "2026-01-01T10:00:00Z"},
+				}, nil)
+				service.repository.(*repository.MockTaskRepository).On("Remove", "task-2").Return(nil)
+				service.repository.(*repository.MockTaskRepository).On("Remove", "task-1").Return(nil)
+				service.outputRepository.(*repository.MockTaskOutputRepository).On("Remove", "task-2").Return(nil)
+				service.outputRepository.(*repository.MockTaskOutputRepository).On("Remove", "task-1").Return(errors.New("error removing output"))
+			},
+		},
+		{
+			desc: "Testing purging a task that can not be removed on the TaskJanitorService",
+			expected: &entity.TaskJanitorStats{
+				CompletedAt: testingTaskJanitorNow(),
+				Errors:      1,
+				Runs:        1,
+				StartedAt:   testingTaskJanitorNow(),
+			},
+			service: NewTaskJanitorService(
+				&entity.TaskRetentionPolicy{MaxCountPerProject: 1},
+				time.Minute,
+				repository.NewMockTaskRepository(),
+				repository.NewMockTaskOutputRepository(),
+				logger.NewFakeLogger(),
+			),
+			arrangeFunc: func(t *testing.T, service *TaskJanitorService) {
+				service.repository.(*repository.MockTaskRepository).On("FindAll").Return([]*entity.Task{
+					{ID: "task-1", ProjectID: "project-1", Status: entity.SUCCESS, CompletedAt: "2026-01-01T10:00:00Z"},
+					{ID: "task-2", ProjectID: "project-1", Status: entity.SUCCESS, CompletedAt: "2026-01-02T10:00:00Z"},
+				}, nil)
+				service.repository.(*repository.MockTaskRepository).On("Remove", "task-1").Return(errors.New("error removing task"))
+			},
+		},
+		{
+			desc: "Testing error purging the tasks on the TaskJanitorService when the tasks can not be found",
+			err:  fmt.Errorf("%w: %w", ErrFindingExpiredTasks, errors.New("error finding tasks")),
+			service: NewTaskJanitorService(
+				&entity.TaskRetentionPolicy{MaxAge: 24 * time.Hour},
+				time.Minute,
+				repository.NewMockTaskRepository(),
+				repository.NewMockTaskOutputRepository(),
+				logger.NewFakeLogger(),
+			),
+			arrangeFunc: func(t *testing.T, service *TaskJanitorService) {
+				service.repository.(*repository.MockTaskRepository).On("FindAll").Return(nil, errors.New("error finding tasks"))
+			},
+		},
+		{
+			desc: "Testing error purging the tasks on the TaskJanitorService having a nil task repository",
+			err:  ErrRepositoryNotInitialized,
+			service: NewTaskJanitorService(
+				&entity.TaskRetentionPolicy{MaxAge: 24 * time.Hour},
+				time.Minute,
+				nil,
+				repository.NewMockTaskOutputRepository(),
+				logger.NewFakeLogger(),
+			),
+		},
+		{
+			desc: "Testing error purging the tasks on the TaskJanitorService having a nil task output repository",
+			err:  ErrOutputRepositoryNotInitialized,
+			service: NewTaskJanitorService(
+				&entity.TaskRetentionPolicy{MaxAge: 24 * time.Hour},
+				time.Minute,
+				repository.NewMockTaskRepository(),
+				nil,
+				logger.NewFakeLogger(),
+			),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			test.service.now = testingTaskJanitorNow
+
+			if test.arrangeFunc != nil {
+				test.arrangeFunc(t, test.service)
+			}
+
+			stats, err := test.service.Purge()
+			if test.err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expected, stats)
+				assert.Equal(t, test.expected, test.service.GetLastRunStats())
+			}
+		})
+	}
+}
+
+func TestTaskJanitorStart(t *testing.T) {
+	t.Log("Testing the TaskJanitorService runs periodically once it is started")
+	t.Parallel()
+
+	taskRepository := repository.NewMockTaskRepository()
+	taskRepository.On("FindAll").Return([]*entity.Task{}, nil)
+
+	service := NewTaskJanitorService(
+		&entity.TaskRetentionPolicy{MaxAge: time.Hour},
+		10*time.Millisecond,
+		taskRepository,
+		repository.NewMockTaskOutputRepository(),
+		logger.NewFakeLogger(),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	service.Start(ctx)
+	assert.Eventually(t, func() bool {
+		return service.GetLastRunStats().Runs > 0
+	}, time.Second, 10*time.Millisecond)
+	service.Stop()
+}
+
+func TestTaskJanitorStart_PurgesOnStart(t *testing.T) {
+	t.Log("Testing the TaskJanitorService runs once when it is started, without waiting for the first interval")
+	t.Parallel()
+
+	taskRepository := repository.NewMockTaskRepository()
+	taskRepository.On("FindAll").Return([]*entity.Task{}, nil)
+
+	service := NewTaskJanitorService(
+		&entity.TaskRetentionPolicy{MaxAge: time.Hour},
+		time.Hour,
+		taskRepository,
+		repository.NewMockTaskOutputRepository(),
+		logger.NewFakeLogger(),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	service.Start(ctx)
+	assert.Eventually(t, func() bool {
+		return service.GetLastRunStats().Runs == 1
+	}, time.Second, 10*time.Millisecond)
+	service.Stop()
+}
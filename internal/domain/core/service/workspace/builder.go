@@ -41,6 +41,14 @@ func NewBuilder(
 	}
 }
 
+// WithSignatureVerifier sets the verifier of the project signatures
+func (w *Builder) WithSignatureVerifier(verifier repository.SourceCodeSignatureVerifier) *Builder {
+	w.options = append(w.options, func(w *Workspace) {
+		w.signatureVerifier = verifier
+	})
+	return w
+}
+
 // WithTask sets the project
 func (w *Builder) WithTask(task *entity.Task) service.WorkspaceBuilder {
 	w.options = append(w.options, func(w *Workspace) {
@@ -1,6 +1,7 @@
 package workspace
 
 import (
+	"context"
 	"fmt"
 	"path/filepath"
 
@@ -26,6 +27,8 @@ var (
 	ErrWorkingDirNotDefined = fmt.Errorf("workspace path not defined")
 	// ErrFetchingProject represents an error when fetching a project
 	ErrFetchingProject = fmt.Errorf("error fetching project")
+	// ErrVerifyingProjectSignature represents an error when the signature of the project is not verified against the trust policy
+	ErrVerifyingProjectSignature = fmt.Errorf("error verifying project signature")
 	// ErrUnpackingProject represents an error when unpacking a project
 	ErrUnpackingProject = fmt.Errorf("error unpacking project")
 	// ErrProjectFetcherNotAvailable represents an error when the project fetcher is not available
@@ -55,6 +58,8 @@ type Workspace struct {
 	workingDir string
 	// repository is the repository to get the project from the catalog
 	repository repository.ProjectRepository
+	// signatureVerifier verifies the signature of the fetched project before it is unpacked. The signatures are not verified when it is not provided
+	signatureVerifier repository.SourceCodeSignatureVerifier
 	// task is the task to be executed
 	task *entity.Task
 	// unpackFactory returns the unpacker to unpack the project
@@ -79,8 +84,8 @@ func (w *Workspace) Options(options ...FuncOptions) *Workspace {
 	return w
 }
 
-// Prepare fetches and unpacks a project
-func (w *Workspace) Prepare() error {
+// Prepare fetches, verifies and unpacks a project. The project is fetched using the context, so fetching it is stopped when the context is done
+func (w *Workspace) Prepare(ctx context.Context) error {
 
 	var err error
 	var workingDir string
@@ -140,12 +145,19 @@ func (w *Workspace) Prepare() error {
 		return ErrProjectNotProvided
 	}
 
-	project, err := w.repository.Find(projectID)
+	// the tasks pin the project version resolved when they are created, so the latest alias is only resolved here for the tasks stored without a pinned version
+	var project *entity.Project
+	if w.task.ProjectVersion == "" || w.task.ProjectVersion == entity.LatestVersion {
+		project, err = w.repository.Find(projectID)
+	} else {
+		project, err = w.repository.FindVersion(projectID, w.task.ProjectVersion)
+	}
 	if err != nil {
 		w.logger.Error(fmt.Sprintf("%s: %s", ErrFindingProject, err.Error()), map[string]interface{}{
-			"component":  "Workspace.Prepare",
-			"package":    "github.com/apenella/ransidble/internal/domain/core/service/workspace",
-			"project_id": projectID,
+			"component":       "Workspace.Prepare",
+			"package":         "github.com/apenella/ransidble/internal/domain/core/service/workspace",
+			"project_id":      projectID,
+			"project_version": w.task.ProjectVersion,
 		})
 
 		return domainerror.NewProjectNotFoundError(
@@ -217,7 +229,7 @@ func (w *Workspace) Prepare() error {
 		return ErrProjectFetcherNotAvailable
 	}
 
-	err = fetcher.Fetch(project, workingDir)
+	err = fetcher.Fetch(ctx, project, workingDir)
 	if err != nil {
 		w.logger.Error(fmt.Sprintf("%s: %s", ErrFetchingProject.Error(), err.Error()), map[string]interface{}{
 			"component":  "Workspace.Prepare",
@@ -229,6 +241,21 @@ func (w *Workspace) Prepare() error {
 		return fmt.Errorf("%s: %w", ErrFetchingProject.Error(), err)
 	}
 
+	// the unverified source code is never unpacked, so it cannot be executed
+	if w.signatureVerifier != nil {
+		err = w.signatureVerifier.Verify(project, workingDir)
+		if err != nil {
+			w.logger.Error(fmt.Sprintf("%s: %s", ErrVerifyingProjectSignature.Error(), err.Error()), map[string]interface{}{
+				"component":  "Workspace.Prepare",
+				"package":    "github.com/apenella/ransidble/internal/domain/core/service/workspace",
+				"project_id": projectID,
+				"task_id":    w.task.ID,
+			})
+
+			return fmt.Errorf("%s: %w", ErrVerifyingProjectSignature.Error(), err)
+		}
+	}
+
 	unpacker := w.unpackFactory.Get(project.Format)
 	if unpacker == nil {
 		w.logger.Error(ErrProjectUnpackerNotAvailable.Error(), map[string]interface{}{
@@ -243,7 +270,7 @@ func (w *Workspace) Prepare() error {
 	}
 
 	// TODO: workingDir should be set to a directory when the project is a directory and a file otherwise
-	err = unpacker.Unpack(project, workingDir)
+	err = unpacker.Unpack(ctx, project, workingDir)
 	if err != nil {
 		w.logger.Error(fmt.Sprintf("%s: %s", ErrUnpackingProject.Error(), err.Error()), map[string]interface{}{
 			"component":  "Workspace.Prepare",
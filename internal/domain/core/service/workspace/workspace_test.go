@@ -1,6 +1,7 @@
 package workspace
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
@@ -153,6 +154,7 @@ func TestPrepare(t *testing.T) {
 		workspace   *Workspace
 		err         error
 		arrangeFunc func(*testing.T, *Workspace)
+		assertFunc  func(*testing.T, *Workspace)
 	}{
 		{
 			desc: "Testing error preparing the workspace when fetchFactory is not provided",
@@ -378,7 +380,7 @@ func TestPrepare(t *testing.T) {
 				w.repository.(*repository.MockProjectRepository).On("Find", "project-id").Return(project, nil)
 
 				fetcher := &repository.MockProjectSourceCodeFetcher{}
-				fetcher.On("Fetch", project, filepath.Join(workingDir, w.task.ProjectID, w.task.ID)).Return(errors.New("error fetching project source code"))
+				fetcher.On("Fetch", mock.Anything, project, filepath.Join(workingDir, w.task.ProjectID, w.task.ID)).Return(errors.New("error fetching project source code"))
 
 				w.fetchFactory.(*repository.MockProjectSourceCodeFetchFactory).On("Get", "local").Return(fetcher)
 			},
@@ -413,7 +415,7 @@ func TestPrepare(t *testing.T) {
 				w.repository.(*repository.MockProjectRepository).On("Find", "project-id").Return(project, nil)
 
 				fetcher := &repository.MockProjectSourceCodeFetcher{}
-				fetcher.On("Fetch", project, filepath.Join(workingDir, w.task.ProjectID, w.task.ID)).Return(nil)
+				fetcher.On("Fetch", mock.Anything, project, filepath.Join(workingDir, w.task.ProjectID, w.task.ID)).Return(nil)
 
 				w.fetchFactory.(*repository.MockProjectSourceCodeFetchFactory).On("Get", "local").Return(fetcher)
 
@@ -450,12 +452,12 @@ func TestPrepare(t *testing.T) {
 				w.repository.(*repository.MockProjectRepository).On("Find", "project-id").Return(project, nil)
 
 				fetcher := &repository.MockProjectSourceCodeFetcher{}
-				fetcher.On("Fetch", project, filepath.Join(workingDir, w.task.ProjectID, w.task.ID)).Return(nil)
+				fetcher.On("Fetch", mock.Anything, project, filepath.Join(workingDir, w.task.ProjectID, w.task.ID)).Return(nil)
 
 				w.fetchFactory.(*repository.MockProjectSourceCodeFetchFactory).On("Get", "local").Return(fetcher)
 
 				unpacker := &repository.MockProjectSourceCodeUnpacker{}
-				unpacker.On("Unpack", project, filepath.Join(workingDir, w.task.ProjectID, w.task.ID)).Return(errors.New("error unpacking project source code"))
+				unpacker.On("Unpack", mock.Anything, project, filepath.Join(workingDir, w.task.ProjectID, w.task.ID)).Return(errors.New("error unpacking project source code"))
 
 				w.unpackFactory.(*repository.MockProjectSourceCodeUnpackFactory).On("Get", "plain").Return(unpacker)
 			},
@@ -495,16 +497,145 @@ func TestPrepare(t *testing.T) {
 				w.repository.(*repository.MockProjectRepository).On("Find", "project-id").Return(project, nil)
 
 				fetcher := &repository.MockProjectSourceCodeFetcher{}
-				fetcher.On("Fetch", project, filepath.Join(workingDir, task.ProjectID, task.ID)).Return(nil)
+				fetcher.On("Fetch", mock.Anything, project, filepath.Join(workingDir, task.ProjectID, task.ID)).Return(nil)
 
 				w.fetchFactory.(*repository.MockProjectSourceCodeFetchFactory).On("Get", "local").Return(fetcher)
 
 				unpacker := &repository.MockProjectSourceCodeUnpacker{}
-				unpacker.On("Unpack", project, filepath.Join(workingDir, task.ProjectID, task.ID)).Return(nil)
+				unpacker.On("Unpack", mock.Anything, project, filepath.Join(workingDir, task.ProjectID, task.ID)).Return(nil)
 
 				w.unpackFactory.(*repository.MockProjectSourceCodeUnpackFactory).On("Get", "plain").Return(unpacker)
 			},
 		},
+		{
+			desc: "Testing preparing the workspace of a task that pins a project version",
+			workspace: &Workspace{
+				logger:        logger.NewFakeLogger(),
+				fetchFactory:  &repository.MockProjectSourceCodeFetchFactory{},
+				unpackFactory: &repository.MockProjectSourceCodeUnpackFactory{},
+				repository:    &repository.MockProjectRepository{},
+				task: &entity.Task{
+					ID:             "task-id",
+					ProjectID:      "project-id",
+					ProjectVersion: "v1",
+				},
+				fs: repository.NewMockFilesystemer(),
+			},
+			err: nil,
+			arrangeFunc: func(t *testing.T, w *Workspace) {
+				project := &entity.Project{
+					Format:    "plain",
+					Name:      "project-id",
+					Reference: "project-id",
+					Storage:   "local",
+					Version:   "v1",
+				}
+
+				workingDir := filepath.Join("/tmp", "project-id", "task-id")
+
+				w.fs.(*repository.MockFilesystemer).On("TempDir", "", "ransidble").Return("/tmp", nil)
+				w.fs.(*repository.MockFilesystemer).On("Stat", workingDir).Return(nil, os.ErrNotExist)
+				w.fs.(*repository.MockFilesystemer).On("MkdirAll", workingDir, mock.Anything).Return(nil)
+
+				w.repository.(*repository.MockProjectRepository).On("FindVersion", "project-id", "v1").Return(project, nil)
+
+				fetcher := &repository.MockProjectSourceCodeFetcher{}
+				fetcher.On("Fetch", mock.Anything, project, workingDir).Return(nil)
+
+				w.fetchFactory.(*repository.MockProjectSourceCodeFetchFactory).On("Get", "local").Return(fetcher)
+
+				unpacker := &repository.MockProjectSourceCodeUnpacker{}
+				unpacker.On("Unpack", mock.Anything, project, workingDir).Return(nil)
+
+				w.unpackFactory.(*repository.MockProjectSourceCodeUnpackFactory).On("Get", "plain").Return(unpacker)
+			},
+		},
+		{
+			desc: "Testing error preparing the workspace when the project signature is not verified",
+			workspace: &Workspace{
+				logger:            logger.NewFakeLogger(),
+				fetchFactory:      &repository.MockProjectSourceCodeFetchFactory{},
+				unpackFactory:     &repository.MockProjectSourceCodeUnpackFactory{},
+				repository:        &repository.MockProjectRepository{},
+				signatureVerifier: repository.NewMockProjectSourceCodeSignatureVerifier(),
+				task: &entity.Task{
+					ID:        "task-id",
+					ProjectID: "project-id",
+				},
+				fs: repository.NewMockFilesystemer(),
+			},
+			err: fmt.Errorf("%s: %w", ErrVerifyingProjectSignature.Error(), entity.ErrProjectSignatureNotTrusted),
+			arrangeFunc: func(t *testing.T, w *Workspace) {
+				project := &entity.Project{
+					Format:    "targz",
+					Name:      "project-id",
+					Reference: "project-id.tar.gz",
+					Signature: "c2lnbmF0dXJl",
+					Storage:   "local",
+				}
+				workingDir := filepath.Join("/tmp", "project-id", "task-id")
+
+				w.fs.(*repository.MockFilesystemer).On("TempDir", "", "ransidble").Return("/tmp", nil)
+				w.fs.(*repository.MockFilesystemer).On("Stat", workingDir).Return(nil, os.ErrNotExist)
+				w.fs.(*repository.MockFilesystemer).On("MkdirAll", workingDir, mock.Anything).Return(nil)
+
+				w.repository.(*repository.MockProjectRepository).On("Find", "project-id").Return(project, nil)
+
+				fetcher := &repository.MockProjectSourceCodeFetcher{}
+				fetcher.On("Fetch", mock.Anything, project, workingDir).Return(nil)
+
+				w.fetchFactory.(*repository.MockProjectSourceCodeFetchFactory).On("Get", "local").Return(fetcher)
+
+				w.signatureVerifier.(*repository.MockProjectSourceCodeSignatureVerifier).On("Verify", project, workingDir).Return(entity.ErrProjectSignatureNotTrusted)
+			},
+			assertFunc: func(t *testing.T, w *Workspace) {
+				w.unpackFactory.(*repository.MockProjectSourceCodeUnpackFactory).AssertNotCalled(t, "Get", mock.Anything)
+			},
+		},
+		{
+			desc: "Testing preparing the workspace of a signed project",
+			workspace: &Workspace{
+				logger:            logger.NewFakeLogger(),
+				fetchFactory:      &repository.MockProjectSourceCodeFetchFactory{},
+				unpackFactory:     &repository.MockProjectSourceCodeUnpackFactory{},
+				repository:        &repository.MockProjectRepository{},
+				signatureVerifier: repository.NewMockProjectSourceCodeSignatureVerifier(),
+				task: &entity.Task{
+					ID:        "task-id",
+					ProjectID: "project-id",
+				},
+				fs: repository.NewMockFilesystemer(),
+			},
+			err: nil,
+			arrangeFunc: func(t *testing.T, w *Workspace) {
+				project := &entity.Project{
+					Format:    "targz",
+					Name:      "project-id",
+					Reference: "project-id.tar.gz",
+					Signature: "c2lnbmF0dXJl",
+					Storage:   "local",
+				}
+				workingDir := filepath.Join("/tmp", "project-id", "task-id")
+
+				w.fs.(*repository.MockFilesystemer).On("TempDir", "", "ransidble").Return("/tmp", nil)
+				w.fs.(*repository.MockFilesystemer).On("Stat", workingDir).Return(nil, os.ErrNotExist)
+				w.fs.(*repository.MockFilesystemer).On("MkdirAll", workingDir, mock.Anything).Return(nil)
+
+				w.repository.(*repository.MockProjectRepository).On("Find", "project-id").Return(project, nil)
+
+				fetcher := &repository.MockProjectSourceCodeFetcher{}
+				fetcher.On("Fetch", mock.Anything, project, workingDir).Return(nil)
+
+				w.fetchFactory.(*repository.MockProjectSourceCodeFetchFactory).On("Get", "local").Return(fetcher)
+
+				w.signatureVerifier.(*repository.MockProjectSourceCodeSignatureVerifier).On("Verify", project, workingDir).Return(nil)
+
+				unpacker := &repository.MockProjectSourceCodeUnpacker{}
+				unpacker.On("Unpack", mock.Anything, project, workingDir).Return(nil)
+
+				w.unpackFactory.(*repository.MockProjectSourceCodeUnpackFactory).On("Get", "targz").Return(unpacker)
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -516,8 +647,12 @@ func TestPrepare(t *testing.T) {
 				test.arrangeFunc(t, test.workspace)
 			}
 
-			err := test.workspace.Prepare()
+			err := test.workspace.Prepare(context.Background())
 			assert.Equal(t, test.err, err)
+
+			if test.assertFunc != nil {
+				test.assertFunc(t, test.workspace)
+			}
 		})
 	}
 }
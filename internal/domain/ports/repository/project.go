@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"io"
 
 	"github.com/apenella/ransidble/internal/domain/core/entity"
@@ -11,19 +12,30 @@ type ProjectRepositoryFactory interface {
 	Get(projectType string) ProjectRepository
 }
 
-// ProjectRepository represents a repository to manage projects
+// ProjectRepository represents a repository to manage projects. The project returned by Find is the most recent version of the project, and the versions are returned by FindVersions sorted from the oldest to the most recent. SafeReplace replaces the most recent version of a project only when its revision matches the expected one, or returns entity.ErrProjectRevisionMismatch otherwise. Trash moves a project and its versions to the trash, where they are kept until they are restored or purged, and the trashed projects are only found by the FindAllTrashed and FindTrashedVersions methods
 type ProjectRepository interface {
 	Find(id string) (*entity.Project, error)
 	FindAll() ([]*entity.Project, error)
+	FindVersion(id string, version string) (*entity.Project, error)
+	FindVersions(id string) ([]*entity.Project, error)
 	Delete(id string) error
+	DeleteVersion(id string, version string) error
 	SafeStore(id string, project *entity.Project) error
+	SafeStoreVersion(id string, project *entity.Project) error
+	SafeReplace(id string, project *entity.Project, revision string) error
+	Search(query *entity.ProjectQuery) (*entity.ProjectPage, error)
+	Trash(id string, deletedAt string) error
+	FindAllTrashed() ([]*entity.Project, error)
+	FindTrashedVersions(id string) ([]*entity.Project, error)
+	Restore(id string) error
+	Purge(id string) error
 	// Store(id string, project *entity.Project) error
 	// Update(id string, project *entity.Project) error
 }
 
 // SourceCodeFetcher represents the component to fetch a project from a repository
 type SourceCodeFetcher interface {
-	Fetch(project *entity.Project, destination string) error
+	Fetch(ctx context.Context, project *entity.Project, destination string) error
 }
 
 // SourceCodeFetchFactory represents the component to create a SourceCodeFetcher
@@ -31,10 +43,13 @@ type SourceCodeFetchFactory interface {
 	Get(projectType string) SourceCodeFetcher
 }
 
-// SourceCodeStorer represents the component to save a project in a storage
+// SourceCodeStorer represents the component to save a project in a storage. The source code of a deleted project is moved to the trash by Trash, and it is either moved back by Restore or removed by Purge
 type SourceCodeStorer interface {
 	Store(project *entity.Project, file io.Reader) error
 	Delete(project *entity.Project) error
+	Trash(project *entity.Project) error
+	Restore(project *entity.Project) error
+	Purge(project *entity.Project) error
 }
 
 // SourceCodeStorageFactory represents the component to create a SourceCodeStorer
@@ -44,12 +59,12 @@ type SourceCodeStorageFactory interface {
 
 // Unpacker represents the component to archive and unarchive projects before executing tasks
 type Unpacker interface {
-	Unpack(project *entity.Project, workingDir string) error
+	Unpack(ctx context.Context, project *entity.Project, workingDir string) error
 }
 
 // SourceCodeUnpacker represents the component to unpack a project
 type SourceCodeUnpacker interface {
-	Unpack(project *entity.Project, destination string) error
+	Unpack(ctx context.Context, project *entity.Project, destination string) error
 }
 
 // SourceCodeUnpackFactory represents the component to create a SourceCodeUnpacker
@@ -57,7 +72,66 @@ type SourceCodeUnpackFactory interface {
 	Get(projectType string) SourceCodeUnpacker
 }
 
-// SourceCodeTarExtractorer represents the component to extract a tar file
+// SourceCodeTarExtractorer represents the component to extract a tar file. The extracted entries are accounted on the archive usage, which is not limited when it is nil
 type SourceCodeTarExtractorer interface {
-	Extract(reader io.Reader, destination string) error
+	Extract(reader io.Reader, destination string, usage *entity.ProjectArchiveUsage) error
+}
+
+// SourceCodeArchiveInspector represents the component to check the content of a packed source code against the archive limits, without extracting it
+type SourceCodeArchiveInspector interface {
+	Inspect(format string, content io.ReaderAt, size int64, limits *entity.ProjectArchiveLimits) error
+}
+
+// SourceCodeSignatureVerifier represents the component to verify the signature of the fetched source code of a project against the trust policy, before it is unpacked
+type SourceCodeSignatureVerifier interface {
+	Verify(project *entity.Project, workingDir string) error
+}
+
+// SourceCodeBrowser represents the component to read the stored source code of a project, either as the stored archive or as the files of the unpacked project tree
+type SourceCodeBrowser interface {
+	Content(ctx context.Context, project *entity.Project) (*entity.ProjectContent, error)
+	Files(ctx context.Context, project *entity.Project) ([]*entity.ProjectFile, error)
+	ReadFile(ctx context.Context, project *entity.Project, path string) (*entity.ProjectContent, error)
+}
+
+// SourceCodeDiscoverer represents the component to discover the Ansible content of the stored source code of a project, such as its playbooks, inventories and roles
+type SourceCodeDiscoverer interface {
+	Discover(project *entity.Project) (*entity.ProjectContents, error)
+}
+
+// SourceCodeScanner represents the component to find the projects to import in the filesystem and to read their source code. The source code must be seekable, since its digest is computed before the project is created
+type SourceCodeScanner interface {
+	Scan(path string) ([]*entity.ProjectImportSource, error)
+	Open(source *entity.ProjectImportSource) (io.ReadSeekCloser, error)
+}
+
+// ProjectUploadRepository represents the component to stage the source code received by the upload sessions until they are finalized into a project. The offset of the uploads is the number of bytes staged, and the chunks are only appended at that offset
+type ProjectUploadRepository interface {
+	Append(id string, offset int64, chunk io.Reader) (int64, error)
+	Create(upload *entity.ProjectUpload) error
+	Find(id string) (*entity.ProjectUpload, error)
+	FindAll() ([]*entity.ProjectUpload, error)
+	Open(id string) (io.ReadSeekCloser, error)
+	Remove(id string) error
+	Update(upload *entity.ProjectUpload) error
+}
+
+// ObjectStorer represents the component to put, get and delete the objects of an object storage. The requests are interrupted when the context is done
+type ObjectStorer interface {
+	PutObject(ctx context.Context, key string, reader io.Reader) error
+	GetObject(ctx context.Context, key string) (io.ReadCloser, error)
+	DeleteObject(ctx context.Context, key string) error
+}
+
+// OCIRegistryClient represents the component to resolve and get the manifests and the blobs of the artifacts stored in an OCI distribution registry. The requests are interrupted when the context is done
+type OCIRegistryClient interface {
+	Resolve(ctx context.Context, reference string) (string, error)
+	Manifest(ctx context.Context, reference string, digest string) ([]byte, error)
+	Blob(ctx context.Context, reference string, digest string) (io.ReadCloser, error)
+}
+
+// SourceCodeOCIResolver represents the component to resolve the manifest digest of an OCI artifact, either stored in a registry or uploaded as an OCI image-layout tarball
+type SourceCodeOCIResolver interface {
+	ResolveReference(reference string) (string, error)
+	ResolveLayout(reader io.Reader) (string, error)
 }
@@ -32,6 +32,17 @@ func (m *MockProjectRepository) Find(id string) (*entity.Project, error) {
 	return project, args.Error(1)
 }
 
+// Search mock method to search the projects that fulfill a query
+func (m *MockProjectRepository) Search(query *entity.ProjectQuery) (*entity.ProjectPage, error) {
+	args := m.Called(query)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entity.ProjectPage), args.Error(1)
+}
+
 // FindAll mock method to find all projects
 func (m *MockProjectRepository) FindAll() ([]*entity.Project, error) {
 	var projects []*entity.Project
@@ -47,6 +58,46 @@ func (m *MockProjectRepository) FindAll() ([]*entity.Project, error) {
 	return projects, args.Error(1)
 }
 
+// FindVersion mock method to find a project version
+func (m *MockProjectRepository) FindVersion(id string, version string) (*entity.Project, error) {
+	args := m.Called(id, version)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entity.Project), args.Error(1)
+}
+
+// FindVersions mock method to find the versions of a project
+func (m *MockProjectRepository) FindVersions(id string) ([]*entity.Project, error) {
+	args := m.Called(id)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]*entity.Project), args.Error(1)
+}
+
+// SafeStoreVersion mock method to store a project version
+func (m *MockProjectRepository) SafeStoreVersion(id string, project *entity.Project) error {
+	args := m.Called(id, project)
+	return args.Error(0)
+}
+
+// SafeReplace mock method to replace the most recent version of a project
+func (m *MockProjectRepository) SafeReplace(id string, project *entity.Project, revision string) error {
+	args := m.Called(id, project, revision)
+	return args.Error(0)
+}
+
+// DeleteVersion mock method to delete a project version
+func (m *MockProjectRepository) DeleteVersion(id string, version string) error {
+	args := m.Called(id, version)
+	return args.Error(0)
+}
+
 // SafeStore mock method to store a project
 func (m *MockProjectRepository) SafeStore(id string, project *entity.Project) error {
 	args := m.Called(id, project)
@@ -58,3 +109,43 @@ func (m *MockProjectRepository) Delete(id string) error {
 	args := m.Called(id)
 	return args.Error(0)
 }
+
+// Trash mock method to move a project to the trash
+func (m *MockProjectRepository) Trash(id string, deletedAt string) error {
+	args := m.Called(id, deletedAt)
+	return args.Error(0)
+}
+
+// FindAllTrashed mock method to find all the trashed projects
+func (m *MockProjectRepository) FindAllTrashed() ([]*entity.Project, error) {
+	args := m.Called()
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]*entity.Project), args.Error(1)
+}
+
+// FindTrashedVersions mock method to find the versions of a trashed project
+func (m *MockProjectRepository) FindTrashedVersions(id string) ([]*entity.Project, error) {
+	args := m.Called(id)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]*entity.Project), args.Error(1)
+}
+
+// Restore mock method to restore a trashed project
+func (m *MockProjectRepository) Restore(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+// Purge mock method to remove a trashed project
+func (m *MockProjectRepository) Purge(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"io"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockProjectSourceCodeArchiveInspector is a mock type for the SourceCodeArchiveInspector
+type MockProjectSourceCodeArchiveInspector struct {
+	mock.Mock
+}
+
+// Ensure MockProjectSourceCodeArchiveInspector implements the SourceCodeArchiveInspector interface
+var _ SourceCodeArchiveInspector = (*MockProjectSourceCodeArchiveInspector)(nil)
+
+// NewMockProjectSourceCodeArchiveInspector provides a mock for the SourceCodeArchiveInspector
+func NewMockProjectSourceCodeArchiveInspector() *MockProjectSourceCodeArchiveInspector {
+	return &MockProjectSourceCodeArchiveInspector{}
+}
+
+// Inspect provides a mock function with given fields: format, content, size, limits
+func (m *MockProjectSourceCodeArchiveInspector) Inspect(format string, content io.ReaderAt, size int64, limits *entity.ProjectArchiveLimits) error {
+	args := m.Called(format, content, size, limits)
+	return args.Error(0)
+}
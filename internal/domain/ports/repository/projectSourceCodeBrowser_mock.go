@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockProjectSourceCodeBrowser is a mock type for the SourceCodeBrowser
+type MockProjectSourceCodeBrowser struct {
+	mock.Mock
+}
+
+// Ensure MockProjectSourceCodeBrowser implements the SourceCodeBrowser interface
+var _ SourceCodeBrowser = (*MockProjectSourceCodeBrowser)(nil)
+
+// NewMockProjectSourceCodeBrowser provides a mock for the SourceCodeBrowser
+func NewMockProjectSourceCodeBrowser() *MockProjectSourceCodeBrowser {
+	return &MockProjectSourceCodeBrowser{}
+}
+
+// Content provides a mock function with given fields: ctx, project
+func (m *MockProjectSourceCodeBrowser) Content(ctx context.Context, project *entity.Project) (*entity.ProjectContent, error) {
+	args := m.Called(ctx, project)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entity.ProjectContent), args.Error(1)
+}
+
+// Files provides a mock function with given fields: ctx, project
+func (m *MockProjectSourceCodeBrowser) Files(ctx context.Context, project *entity.Project) ([]*entity.ProjectFile, error) {
+	args := m.Called(ctx, project)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]*entity.ProjectFile), args.Error(1)
+}
+
+// ReadFile provides a mock function with given fields: ctx, project, path
+func (m *MockProjectSourceCodeBrowser) ReadFile(ctx context.Context, project *entity.Project, path string) (*entity.ProjectContent, error) {
+	args := m.Called(ctx, project, path)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entity.ProjectContent), args.Error(1)
+}
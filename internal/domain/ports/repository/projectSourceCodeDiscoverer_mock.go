@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockProjectSourceCodeDiscoverer is a mock type for the SourceCodeDiscoverer
+type MockProjectSourceCodeDiscoverer struct {
+	mock.Mock
+}
+
+// Ensure MockProjectSourceCodeDiscoverer implements the SourceCodeDiscoverer interface
+var _ SourceCodeDiscoverer = (*MockProjectSourceCodeDiscoverer)(nil)
+
+// NewMockProjectSourceCodeDiscoverer provides a mock for the SourceCodeDiscoverer
+func NewMockProjectSourceCodeDiscoverer() *MockProjectSourceCodeDiscoverer {
+	return &MockProjectSourceCodeDiscoverer{}
+}
+
+// Discover provides a mock function with given fields: project
+func (m *MockProjectSourceCodeDiscoverer) Discover(project *entity.Project) (*entity.ProjectContents, error) {
+	args := m.Called(project)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entity.ProjectContents), args.Error(1)
+}
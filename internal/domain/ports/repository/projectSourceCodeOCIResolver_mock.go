@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"io"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockProjectSourceCodeOCIResolver is a mock type for the SourceCodeOCIResolver
+type MockProjectSourceCodeOCIResolver struct {
+	mock.Mock
+}
+
+// Ensure MockProjectSourceCodeOCIResolver implements the SourceCodeOCIResolver interface
+var _ SourceCodeOCIResolver = (*MockProjectSourceCodeOCIResolver)(nil)
+
+// NewMockProjectSourceCodeOCIResolver provides a mock for the SourceCodeOCIResolver
+func NewMockProjectSourceCodeOCIResolver() *MockProjectSourceCodeOCIResolver {
+	return &MockProjectSourceCodeOCIResolver{}
+}
+
+// ResolveReference provides a mock function with given fields: reference
+func (m *MockProjectSourceCodeOCIResolver) ResolveReference(reference string) (string, error) {
+	args := m.Called(reference)
+	return args.String(0), args.Error(1)
+}
+
+// ResolveLayout provides a mock function with given fields: reader
+func (m *MockProjectSourceCodeOCIResolver) ResolveLayout(reader io.Reader) (string, error) {
+	args := m.Called(reader)
+	return args.String(0), args.Error(1)
+}
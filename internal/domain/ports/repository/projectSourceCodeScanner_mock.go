@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"io"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockProjectSourceCodeScanner is a mock type for the SourceCodeScanner
+type MockProjectSourceCodeScanner struct {
+	mock.Mock
+}
+
+// Ensure MockProjectSourceCodeScanner implements the SourceCodeScanner interface
+var _ SourceCodeScanner = (*MockProjectSourceCodeScanner)(nil)
+
+// NewMockProjectSourceCodeScanner provides a mock for the SourceCodeScanner
+func NewMockProjectSourceCodeScanner() *MockProjectSourceCodeScanner {
+	return &MockProjectSourceCodeScanner{}
+}
+
+// Scan provides a mock function with given fields: path
+func (m *MockProjectSourceCodeScanner) Scan(path string) ([]*entity.ProjectImportSource, error) {
+	args := m.Called(path)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]*entity.ProjectImportSource), args.Error(1)
+}
+
+// Open provides a mock function with given fields: source
+func (m *MockProjectSourceCodeScanner) Open(source *entity.ProjectImportSource) (io.ReadSeekCloser, error) {
+	args := m.Called(source)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(io.ReadSeekCloser), args.Error(1)
+}
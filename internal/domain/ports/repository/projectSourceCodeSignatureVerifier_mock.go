@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockProjectSourceCodeSignatureVerifier is a mock type for the SourceCodeSignatureVerifier
+type MockProjectSourceCodeSignatureVerifier struct {
+	mock.Mock
+}
+
+// Ensure MockProjectSourceCodeSignatureVerifier implements the SourceCodeSignatureVerifier interface
+var _ SourceCodeSignatureVerifier = (*MockProjectSourceCodeSignatureVerifier)(nil)
+
+// NewMockProjectSourceCodeSignatureVerifier provides a mock for the SourceCodeSignatureVerifier
+func NewMockProjectSourceCodeSignatureVerifier() *MockProjectSourceCodeSignatureVerifier {
+	return &MockProjectSourceCodeSignatureVerifier{}
+}
+
+// Verify provides a mock function with given fields: project, workingDir
+func (m *MockProjectSourceCodeSignatureVerifier) Verify(project *entity.Project, workingDir string) error {
+	args := m.Called(project, workingDir)
+	return args.Error(0)
+}
@@ -31,3 +31,21 @@ func (m *MockProjectSourceCodeStorer) Delete(project *entity.Project) error {
 	args := m.Called(project)
 	return args.Error(0)
 }
+
+// Trash provides a mock function with given fields: project
+func (m *MockProjectSourceCodeStorer) Trash(project *entity.Project) error {
+	args := m.Called(project)
+	return args.Error(0)
+}
+
+// Restore provides a mock function with given fields: project
+func (m *MockProjectSourceCodeStorer) Restore(project *entity.Project) error {
+	args := m.Called(project)
+	return args.Error(0)
+}
+
+// Purge provides a mock function with given fields: project
+func (m *MockProjectSourceCodeStorer) Purge(project *entity.Project) error {
+	args := m.Called(project)
+	return args.Error(0)
+}
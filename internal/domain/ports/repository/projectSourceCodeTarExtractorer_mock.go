@@ -3,6 +3,7 @@ package repository
 import (
 	"io"
 
+	"github.com/apenella/ransidble/internal/domain/core/entity"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -20,7 +21,7 @@ func NewMockProjectSourceCodeTarExtractorer() *MockProjectSourceCodeTarExtractor
 }
 
 // Extract method extracts a tar file
-func (m *MockProjectSourceCodeTarExtractorer) Extract(reader io.Reader, dest string) error {
-	args := m.Called(reader, dest)
+func (m *MockProjectSourceCodeTarExtractorer) Extract(reader io.Reader, dest string, usage *entity.ProjectArchiveUsage) error {
+	args := m.Called(reader, dest, usage)
 	return args.Error(0)
 }
@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"context"
+
 	"github.com/apenella/ransidble/internal/domain/core/entity"
 	"github.com/stretchr/testify/mock"
 )
@@ -13,9 +15,9 @@ type MockProjectSourceCodeUnpacker struct {
 // Ensure MockProjectSourceCodeUnpacker implements the SourceCodeUnpacker interface
 var _ SourceCodeUnpacker = (*MockProjectSourceCodeUnpacker)(nil)
 
-// Unpack provides a mock function with given fields: project, destination
-func (m *MockProjectSourceCodeUnpacker) Unpack(project *entity.Project, destination string) error {
-	ret := m.Called(project, destination)
+// Unpack provides a mock function with given fields: ctx, project, destination
+func (m *MockProjectSourceCodeUnpacker) Unpack(ctx context.Context, project *entity.Project, destination string) error {
+	ret := m.Called(ctx, project, destination)
 
 	var r0 error
 	if ret.Get(0) != nil {
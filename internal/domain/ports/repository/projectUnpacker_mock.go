@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"context"
+
 	"github.com/apenella/ransidble/internal/domain/core/entity"
 	"github.com/stretchr/testify/mock"
 )
@@ -13,9 +15,9 @@ type MockProjectUnpacker struct {
 // Ensure MockProjectUnpacker implements the Unpacker interface
 var _ Unpacker = (*MockProjectUnpacker)(nil)
 
-// Unpack provides a mock function with given fields: project, workingDir
-func (m *MockProjectUnpacker) Unpack(project *entity.Project, workingDir string) error {
-	ret := m.Called(project, workingDir)
+// Unpack provides a mock function with given fields: ctx, project, workingDir
+func (m *MockProjectUnpacker) Unpack(ctx context.Context, project *entity.Project, workingDir string) error {
+	ret := m.Called(ctx, project, workingDir)
 
 	var r0 error
 	if ret.Get(0) != nil {
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"io"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockProjectUploadRepository is a mock type for the ProjectUploadRepository
+type MockProjectUploadRepository struct {
+	mock.Mock
+}
+
+// Ensure MockProjectUploadRepository implements the ProjectUploadRepository interface
+var _ ProjectUploadRepository = (*MockProjectUploadRepository)(nil)
+
+// NewMockProjectUploadRepository provides a mock for the ProjectUploadRepository
+func NewMockProjectUploadRepository() *MockProjectUploadRepository {
+	return &MockProjectUploadRepository{}
+}
+
+// Append provides a mock function with given fields: id, offset, chunk
+func (m *MockProjectUploadRepository) Append(id string, offset int64, chunk io.Reader) (int64, error) {
+	args := m.Called(id, offset, chunk)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// Create provides a mock function with given fields: upload
+func (m *MockProjectUploadRepository) Create(upload *entity.ProjectUpload) error {
+	args := m.Called(upload)
+	return args.Error(0)
+}
+
+// Find provides a mock function with given fields: id
+func (m *MockProjectUploadRepository) Find(id string) (*entity.ProjectUpload, error) {
+	args := m.Called(id)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entity.ProjectUpload), args.Error(1)
+}
+
+// FindAll provides a mock function
+func (m *MockProjectUploadRepository) FindAll() ([]*entity.ProjectUpload, error) {
+	args := m.Called()
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]*entity.ProjectUpload), args.Error(1)
+}
+
+// Open provides a mock function with given fields: id
+func (m *MockProjectUploadRepository) Open(id string) (io.ReadSeekCloser, error) {
+	args := m.Called(id)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(io.ReadSeekCloser), args.Error(1)
+}
+
+// Remove provides a mock function with given fields: id
+func (m *MockProjectUploadRepository) Remove(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+// Update provides a mock function with given fields: upload
+func (m *MockProjectUploadRepository) Update(upload *entity.ProjectUpload) error {
+	args := m.Called(upload)
+	return args.Error(0)
+}
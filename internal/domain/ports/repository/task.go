@@ -6,6 +6,7 @@ import (
 
 // Executor represents an executor to run tasks
 type Executor interface {
+	Cancel(task *entity.Task) error
 	Execute(task *entity.Task) error
 }
 
@@ -15,6 +16,14 @@ type TaskRepository interface {
 	FindAll() ([]*entity.Task, error)
 	Remove(id string) error
 	SafeStore(id string, task *entity.Task) error
+	Search(query *entity.TaskQuery) (*entity.TaskPage, error)
 	Store(id string, task *entity.Task) error
 	Update(id string, task *entity.Task) error
 }
+
+// TaskOutputRepository represents a repository to manage the output generated by the tasks
+type TaskOutputRepository interface {
+	Append(id string, data []byte) error
+	Find(id string) ([]byte, error)
+	Remove(id string) error
+}
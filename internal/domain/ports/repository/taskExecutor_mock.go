@@ -18,6 +18,12 @@ func NewMockTaskExecutor() *MockTaskExecutor {
 	return &MockTaskExecutor{}
 }
 
+// Cancel mocks the Cancel method
+func (m *MockTaskExecutor) Cancel(task *entity.Task) error {
+	args := m.Called(task)
+	return args.Error(0)
+}
+
 // Execute mocks the Execute method
 func (m *MockTaskExecutor) Execute(task *entity.Task) error {
 	args := m.Called(task)
@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"github.com/stretchr/testify/mock"
+)
+
+// MockTaskOutputRepository struct for mocking task output repository
+type MockTaskOutputRepository struct {
+	mock.Mock
+}
+
+// Ensure MockTaskOutputRepository implements the TaskOutputRepository interface
+var _ TaskOutputRepository = (*MockTaskOutputRepository)(nil)
+
+// NewMockTaskOutputRepository returns a new MockTaskOutputRepository
+func NewMockTaskOutputRepository() *MockTaskOutputRepository {
+	return &MockTaskOutputRepository{}
+}
+
+// Append mocks the Append method
+func (m *MockTaskOutputRepository) Append(id string, data []byte) error {
+	args := m.Called(id, data)
+	return args.Error(0)
+}
+
+// Find mocks the Find method
+func (m *MockTaskOutputRepository) Find(id string) ([]byte, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+// Remove mocks the Remove method
+func (m *MockTaskOutputRepository) Remove(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
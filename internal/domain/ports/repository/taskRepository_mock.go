@@ -52,6 +52,17 @@ func (m *MockTaskRepository) SafeStore(id string, task *entity.Task) error {
 	return args.Error(0)
 }
 
+// Search mocks the Search method
+func (m *MockTaskRepository) Search(query *entity.TaskQuery) (*entity.TaskPage, error) {
+	args := m.Called(query)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entity.TaskPage), args.Error(1)
+}
+
 // Store mocks the Store method
 func (m *MockTaskRepository) Store(id string, task *entity.Task) error {
 	args := m.Called(id, task)
@@ -1,13 +1,14 @@
 package repository
 
 import (
+	"context"
 	"os"
 )
 
 // Workspacer interface to manage a workspace
 type Workspacer interface {
-	// Prepare prepares the workspace
-	Prepare() error
+	// Prepare prepares the workspace. The commands run to prepare it are stopped when the context is done
+	Prepare(ctx context.Context) error
 	// GetWorkingDir returns the working directory
 	GetWorkingDir() (string, error)
 	// Cleanup cleans up the workspace
@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"context"
+
 	"github.com/stretchr/testify/mock"
 )
 
@@ -13,8 +15,8 @@ type MockWorkspace struct {
 var _ Workspacer = (*MockWorkspace)(nil)
 
 // Prepare prepares the mock workspace
-func (m *MockWorkspace) Prepare() error {
-	args := m.Called()
+func (m *MockWorkspace) Prepare(ctx context.Context) error {
+	args := m.Called(ctx)
 	return args.Error(0)
 }
 
@@ -0,0 +1,25 @@
+package service
+
+import (
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockCancelTaskService struct to mock CancelTaskServicer
+type MockCancelTaskService struct {
+	mock.Mock
+}
+
+// NewMockCancelTaskService creates a new MockCancelTaskService
+func NewMockCancelTaskService() *MockCancelTaskService {
+	return &MockCancelTaskService{}
+}
+
+// CancelTask method to cancel a task
+func (m *MockCancelTaskService) CancelTask(id string) (*entity.Task, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Task), args.Error(1)
+}
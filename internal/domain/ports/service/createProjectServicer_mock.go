@@ -3,6 +3,7 @@ package service
 import (
 	"io"
 
+	"github.com/apenella/ransidble/internal/domain/core/entity"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -19,9 +20,26 @@ func NewMockCreateProjectService() *MockCreateProjectService {
 	return &MockCreateProjectService{}
 }
 
-// Create method to create a project
-// func (m *MockCreateProjectService) Create(format string, storage string, file *multipart.FileHeader) error {
-func (m *MockCreateProjectService) Create(format string, storage string, projectID string, version string, file io.Reader) error {
-	args := m.Called(format, storage, projectID, version, file)
-	return args.Error(0)
+// Create method to create a project, a new version of a project, or to replace the source code of a project
+func (m *MockCreateProjectService) Create(request *entity.CreateProjectRequest, file io.Reader) (string, error) {
+	args := m.Called(request, file)
+	return args.String(0), args.Error(1)
+}
+
+// CreateFromGit method to create a project, or a new version of a project, stored in a git repository
+func (m *MockCreateProjectService) CreateFromGit(request *entity.CreateProjectRequest, source *entity.ProjectGitSource) (string, error) {
+	args := m.Called(request, source)
+	return args.String(0), args.Error(1)
+}
+
+// CreateFromOCI method to create a project, or a new version of a project, stored in an OCI registry
+func (m *MockCreateProjectService) CreateFromOCI(request *entity.CreateProjectRequest, source *entity.ProjectOCISource) (string, error) {
+	args := m.Called(request, source)
+	return args.String(0), args.Error(1)
+}
+
+// CreateFromUpload method to create a project, a new version of a project, or to replace the source code of a project, from the source code received by an upload session
+func (m *MockCreateProjectService) CreateFromUpload(request *entity.CreateProjectRequest, uploadID string) (string, error) {
+	args := m.Called(request, uploadID)
+	return args.String(0), args.Error(1)
 }
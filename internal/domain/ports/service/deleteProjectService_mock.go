@@ -13,8 +13,8 @@ func NewMockDeleteProjectService() *MockDeleteProjectService {
 }
 
 // Delete method to delete a project
-func (m *MockDeleteProjectService) Delete(projectID string) error {
-	args := m.Called(projectID)
+func (m *MockDeleteProjectService) Delete(projectID string, force bool) error {
+	args := m.Called(projectID, force)
 	return args.Error(0)
 }
 
@@ -23,3 +23,9 @@ func (m *MockDeleteProjectService) DeleteVersion(projectID string, version strin
 	args := m.Called(projectID, version)
 	return args.Error(0)
 }
+
+// Restore method to restore a deleted project
+func (m *MockDeleteProjectService) Restore(projectID string) error {
+	args := m.Called(projectID)
+	return args.Error(0)
+}
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockGetProjectContentService struct to mock GetProjectContentService
+type MockGetProjectContentService struct {
+	mock.Mock
+}
+
+// NewMockGetProjectContentService creates a new MockGetProjectContentService
+func NewMockGetProjectContentService() *MockGetProjectContentService {
+	return &MockGetProjectContentService{}
+}
+
+// GetProjectContent method to get the stored source code of a project
+func (m *MockGetProjectContentService) GetProjectContent(ctx context.Context, id string, version string) (*entity.ProjectContent, error) {
+	args := m.Called(ctx, id, version)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entity.ProjectContent), args.Error(1)
+}
+
+// GetProjectFiles method to get the files of the project tree
+func (m *MockGetProjectContentService) GetProjectFiles(ctx context.Context, id string, version string) ([]*entity.ProjectFile, error) {
+	args := m.Called(ctx, id, version)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]*entity.ProjectFile), args.Error(1)
+}
+
+// GetProjectFile method to get a file of the project tree
+func (m *MockGetProjectContentService) GetProjectFile(ctx context.Context, id string, version string, path string) (*entity.ProjectContent, error) {
+	args := m.Called(ctx, id, version, path)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entity.ProjectContent), args.Error(1)
+}
@@ -23,7 +23,23 @@ func (m *MockGetProjectService) GetProject(id string) (*entity.Project, error) {
 }
 
 // GetProjectsList method to get a list of projects
-func (m *MockGetProjectService) GetProjectsList() ([]*entity.Project, error) {
-	args := m.Called()
+func (m *MockGetProjectService) GetProjectsList(query *entity.ProjectQuery) (*entity.ProjectPage, error) {
+	args := m.Called(query)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entity.ProjectPage), args.Error(1)
+}
+
+// GetProjectVersions method to get the versions of a project
+func (m *MockGetProjectService) GetProjectVersions(id string) ([]*entity.Project, error) {
+	args := m.Called(id)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
 	return args.Get(0).([]*entity.Project), args.Error(1)
 }
@@ -0,0 +1,24 @@
+package service
+
+import (
+	"github.com/stretchr/testify/mock"
+)
+
+// MockGetTaskOutputService struct to mock GetTaskOutputServicer
+type MockGetTaskOutputService struct {
+	mock.Mock
+}
+
+// NewMockGetTaskOutputService creates a new MockGetTaskOutputService
+func NewMockGetTaskOutputService() *MockGetTaskOutputService {
+	return &MockGetTaskOutputService{}
+}
+
+// GetTaskOutput method to get the output of a task
+func (m *MockGetTaskOutputService) GetTaskOutput(id string) ([]byte, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
@@ -20,3 +20,14 @@ func (m *MockGetTaskService) GetTask(id string) (*entity.Task, error) {
 	args := m.Called(id)
 	return args.Get(0).(*entity.Task), args.Error(1)
 }
+
+// GetTasksList method to get a list of tasks
+func (m *MockGetTaskService) GetTasksList(query *entity.TaskQuery) (*entity.TaskPage, error) {
+	args := m.Called(query)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entity.TaskPage), args.Error(1)
+}
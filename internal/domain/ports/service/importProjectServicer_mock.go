@@ -0,0 +1,30 @@
+package service
+
+import (
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockImportProjectService struct to mock ImportProjectService
+type MockImportProjectService struct {
+	mock.Mock
+}
+
+// Ensure MockImportProjectService implements ImportProjectServicer interface
+var _ ImportProjectServicer = (*MockImportProjectService)(nil)
+
+// NewMockImportProjectService creates a new MockImportProjectService
+func NewMockImportProjectService() *MockImportProjectService {
+	return &MockImportProjectService{}
+}
+
+// Import method to import the projects found in the paths
+func (m *MockImportProjectService) Import(paths ...string) (*entity.ProjectImportReport, error) {
+	args := m.Called(paths)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entity.ProjectImportReport), args.Error(1)
+}
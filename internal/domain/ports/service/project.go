@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"io"
 
 	"github.com/apenella/ransidble/internal/domain/core/entity"
@@ -9,16 +10,42 @@ import (
 // GetProjectServicer represents the service to get a task
 type GetProjectServicer interface {
 	GetProject(id string) (*entity.Project, error)
-	GetProjectsList() ([]*entity.Project, error)
+	GetProjectsList(query *entity.ProjectQuery) (*entity.ProjectPage, error)
+	GetProjectVersions(id string) ([]*entity.Project, error)
 }
 
-// CreateProjectServicer represents the service to create a project. It returns the project ID on success and an error on failure.
+// GetProjectContentServicer represents the service to read the source code stored for a project
+type GetProjectContentServicer interface {
+	GetProjectContent(ctx context.Context, id string, version string) (*entity.ProjectContent, error)
+	GetProjectFiles(ctx context.Context, id string, version string) ([]*entity.ProjectFile, error)
+	GetProjectFile(ctx context.Context, id string, version string, path string) (*entity.ProjectContent, error)
+}
+
+// CreateProjectServicer represents the service to create a project. Each source of the source code has its own entry point, and the mode of the request sets whether a project is created, a new version of a project is created, or the source code of the most recent version of a project is replaced. Every entry point returns the revision of the stored project
 type CreateProjectServicer interface {
-	Create(format string, storage string, projectID string, version string, file io.Reader) error
+	Create(request *entity.CreateProjectRequest, file io.Reader) (string, error)
+	CreateFromGit(request *entity.CreateProjectRequest, source *entity.ProjectGitSource) (string, error)
+	CreateFromOCI(request *entity.CreateProjectRequest, source *entity.ProjectOCISource) (string, error)
+	CreateFromUpload(request *entity.CreateProjectRequest, uploadID string) (string, error)
+}
+
+// ProjectUploadServicer represents the service to manage the upload sessions where the source code of the projects is received in chunks
+type ProjectUploadServicer interface {
+	AppendUpload(id string, offset int64, chunk io.Reader) (*entity.ProjectUpload, error)
+	CreateUpload(length int64) (*entity.ProjectUpload, error)
+	DeleteUpload(id string) error
+	FinalizeUpload(id string, finalize func(content io.ReadSeeker) error) error
+	GetUpload(id string) (*entity.ProjectUpload, error)
+}
+
+// ImportProjectServicer represents the service to import the projects found in the filesystem. It returns a report of the added, skipped and failed projects
+type ImportProjectServicer interface {
+	Import(paths ...string) (*entity.ProjectImportReport, error)
 }
 
-// DeleteProjectServicer represents the service to delete a project. It returns an error on failure.
+// DeleteProjectServicer represents the service to delete a project. A deleted project is kept in the trash, from where it can be restored. It returns an error on failure.
 type DeleteProjectServicer interface {
-	Delete(projectID string) error
+	Delete(projectID string, force bool) error
 	DeleteVersion(projectID string, version string) error
+	Restore(projectID string) error
 }
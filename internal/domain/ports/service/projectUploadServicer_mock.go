@@ -0,0 +1,60 @@
+package service
+
+import (
+	"io"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockProjectUploadService struct to mock ProjectUploadServicer
+type MockProjectUploadService struct {
+	mock.Mock
+}
+
+// Ensure MockProjectUploadService implements ProjectUploadServicer interface
+var _ ProjectUploadServicer = (*MockProjectUploadService)(nil)
+
+// NewMockProjectUploadService creates a new MockProjectUploadService
+func NewMockProjectUploadService() *MockProjectUploadService {
+	return &MockProjectUploadService{}
+}
+
+// AppendUpload method to append a chunk to an upload
+func (m *MockProjectUploadService) AppendUpload(id string, offset int64, chunk io.Reader) (*entity.ProjectUpload, error) {
+	args := m.Called(id, offset, chunk)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ProjectUpload), args.Error(1)
+}
+
+// CreateUpload method to open an upload
+func (m *MockProjectUploadService) CreateUpload(length int64) (*entity.ProjectUpload, error) {
+	args := m.Called(length)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ProjectUpload), args.Error(1)
+}
+
+// DeleteUpload method to delete an upload
+func (m *MockProjectUploadService) DeleteUpload(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+// FinalizeUpload method to finalize an upload
+func (m *MockProjectUploadService) FinalizeUpload(id string, finalize func(content io.ReadSeeker) error) error {
+	args := m.Called(id, finalize)
+	return args.Error(0)
+}
+
+// GetUpload method to get an upload
+func (m *MockProjectUploadService) GetUpload(id string) (*entity.ProjectUpload, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ProjectUpload), args.Error(1)
+}
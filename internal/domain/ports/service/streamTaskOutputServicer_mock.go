@@ -0,0 +1,24 @@
+package service
+
+import (
+	"context"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockStreamTaskOutputService struct to mock StreamTaskOutputServicer
+type MockStreamTaskOutputService struct {
+	mock.Mock
+}
+
+// NewMockStreamTaskOutputService creates a new MockStreamTaskOutputService
+func NewMockStreamTaskOutputService() *MockStreamTaskOutputService {
+	return &MockStreamTaskOutputService{}
+}
+
+// StreamTaskOutput method to stream the output of a task
+func (m *MockStreamTaskOutputService) StreamTaskOutput(ctx context.Context, id string, offset int64, send func(event *entity.TaskOutputEvent) error) error {
+	args := m.Called(ctx, id, offset, send)
+	return args.Error(0)
+}
@@ -12,7 +12,28 @@ type AnsiblePlaybookServicer interface {
 	Run(ctx context.Context, task *entity.Task) error
 }
 
+// CancelTaskServicer represents the service to cancel a task
+type CancelTaskServicer interface {
+	CancelTask(id string) (*entity.Task, error)
+}
+
 // GetTaskServicer represents the service to get a task
 type GetTaskServicer interface {
 	GetTask(id string) (*entity.Task, error)
+	GetTasksList(query *entity.TaskQuery) (*entity.TaskPage, error)
+}
+
+// GetTaskOutputServicer represents the service to get the output of a task
+type GetTaskOutputServicer interface {
+	GetTaskOutput(id string) ([]byte, error)
+}
+
+// TaskJanitorServicer represents the service purging the tasks exceeding the task retention policy
+type TaskJanitorServicer interface {
+	GetLastRunStats() *entity.TaskJanitorStats
+}
+
+// StreamTaskOutputServicer represents the service to stream the output of a task
+type StreamTaskOutputServicer interface {
+	StreamTaskOutput(ctx context.Context, id string, offset int64, send func(event *entity.TaskOutputEvent) error) error
 }
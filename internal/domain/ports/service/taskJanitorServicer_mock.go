@@ -0,0 +1,25 @@
+package service
+
+import (
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockTaskJanitorService struct to mock TaskJanitorServicer
+type MockTaskJanitorService struct {
+	mock.Mock
+}
+
+// NewMockTaskJanitorService creates a new MockTaskJanitorService
+func NewMockTaskJanitorService() *MockTaskJanitorService {
+	return &MockTaskJanitorService{}
+}
+
+// GetLastRunStats method to get the statistics of the last run of the task janitor
+func (m *MockTaskJanitorService) GetLastRunStats() *entity.TaskJanitorStats {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(*entity.TaskJanitorStats)
+}
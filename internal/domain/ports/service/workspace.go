@@ -1,12 +1,14 @@
 package service
 
 import (
+	"context"
+
 	"github.com/apenella/ransidble/internal/domain/core/entity"
 )
 
 // Workspacer interface to manage a workspace
 type Workspacer interface {
-	Prepare() error
+	Prepare(ctx context.Context) error
 	Cleanup() error
 	GetWorkingDir() (string, error)
 }
@@ -0,0 +1,113 @@
+package project
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/apenella/ransidble/internal/configuration"
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	projectService "github.com/apenella/ransidble/internal/domain/core/service/project"
+	portsrepository "github.com/apenella/ransidble/internal/domain/ports/repository"
+	"github.com/apenella/ransidble/internal/handler/cli/sourcecode"
+	"github.com/apenella/ransidble/internal/infrastructure/browse"
+	"github.com/apenella/ransidble/internal/infrastructure/logger"
+	"github.com/apenella/ransidble/internal/infrastructure/oci"
+	"github.com/apenella/ransidble/internal/infrastructure/persistence/project/repository"
+	"github.com/apenella/ransidble/internal/infrastructure/persistence/project/repository/local"
+	"github.com/apenella/ransidble/internal/infrastructure/scan"
+	"github.com/apenella/ransidble/internal/infrastructure/unpack"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// ErrImportingProjects represents an error when any of the projects cannot be imported
+	ErrImportingProjects = fmt.Errorf("error importing projects")
+)
+
+// newImportCommand returns a new cobra.Command to import the projects found in the filesystem. Each path is either a project archive, a directory holding a project tree, or a directory holding project trees and project archives
+func newImportCommand(config *configuration.Configuration) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import <path> [<path>...]",
+		Short: "Import registers the projects found in the filesystem",
+		Long:  "Import registers the projects found in the filesystem, either directories holding the project tree or project archives named after the <name>@<version> convention. The projects already registered with the same source code are skipped, and those registered with a different source code are never overwritten",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// the import report already explains why the projects failed
+			cmd.SilenceUsage = true
+
+			log := logger.NewLogger()
+			afs := afero.NewOsFs()
+
+			importProjectService, err := newImportProjectService(config, afs, log)
+			if err != nil {
+				log.Error(
+					err.Error(),
+					map[string]interface{}{
+						"component": "ProjectImport",
+						"package":   "github.com/apenella/ransidble/internal/handler/cli/project",
+					})
+				return err
+			}
+
+			report, err := importProjectService.Import(args...)
+			if err != nil {
+				return err
+			}
+
+			writer := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+			fmt.Fprintln(writer, "STATUS\tPROJECT\tVERSION\tPATH\tREASON")
+			for _, result := range report.Results {
+				fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\n", result.Status, result.Name, result.Version, result.Path, result.Reason)
+			}
+			err = writer.Flush()
+			if err != nil {
+				return err
+			}
+
+			failed := report.Count(entity.ProjectImportStatusFailed)
+			if failed > 0 {
+				return fmt.Errorf("%w: %d of %d failed", ErrImportingProjects, failed, len(report.Results))
+			}
+
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// newImportProjectService creates the service to import the projects into the project repository and the storages set in the configuration. The projects are created through the same pipeline as the projects uploaded to the server, so the archive limits are applied and their contents and manifest are discovered
+func newImportProjectService(config *configuration.Configuration, afs afero.Fs, log portsrepository.Logger) (*projectService.ImportProjectService, error) {
+
+	projectLocalRepository := local.NewDatabaseDriver(afs, config.Server.Project.ProjectRepositoryConfiguration.LocalRepositoryPath, log)
+	err := projectLocalRepository.Initialize()
+	if err != nil {
+		return nil, err
+	}
+
+	projectsRepositoryFactory := repository.NewFactory()
+	projectsRepositoryFactory.Register(entity.ProjectTypeLocal, projectLocalRepository)
+	projectsRepository := projectsRepositoryFactory.Get(config.Server.Project.ProjectRepositoryConfiguration.Type)
+
+	registries, err := sourcecode.NewRegistries(config, afs, log)
+	if err != nil {
+		return nil, err
+	}
+
+	createProjectService := projectService.NewCreateProjectService(
+		projectsRepository,
+		registries.StoreFactory,
+		log,
+	).WithOCIResolver(oci.NewResolver(registries.OCIClient)).
+		WithArchiveLimits(registries.ArchiveLimits).
+		WithArchiveInspector(unpack.NewArchiveInspector(log)).
+		WithDiscoverer(browse.NewBrowser(afs, registries.FetchFactory, registries.UnpackFactory, log))
+
+	return projectService.NewImportProjectService(
+		createProjectService,
+		projectsRepository,
+		scan.NewScanner(afs, log),
+		log,
+	), nil
+}
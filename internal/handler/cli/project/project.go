@@ -0,0 +1,22 @@
+package project
+
+import (
+	"github.com/apenella/ransidble/internal/configuration"
+	"github.com/spf13/cobra"
+)
+
+// NewCommand returns a new cobra.Command to manage the Ransidble projects
+func NewCommand(config *configuration.Configuration) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "project",
+		Short: "Project is a command to manage the Ransidble projects",
+		Long:  "Project is a command to manage the Ransidble projects",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(newImportCommand(config))
+
+	return cmd
+}
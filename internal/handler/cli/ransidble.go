@@ -2,6 +2,7 @@ package cli
 
 import (
 	"github.com/apenella/ransidble/internal/configuration"
+	"github.com/apenella/ransidble/internal/handler/cli/project"
 	"github.com/apenella/ransidble/internal/handler/cli/serve"
 	"github.com/spf13/cobra"
 )
@@ -21,6 +22,7 @@ func NewCommand(config *configuration.Configuration) *cobra.Command {
 		},
 	}
 
+	cmd.AddCommand(project.NewCommand(config))
 	cmd.AddCommand(serve.NewCommand(config))
 
 	return cmd
@@ -13,18 +13,23 @@ import (
 	projectService "github.com/apenella/ransidble/internal/domain/core/service/project"
 	taskService "github.com/apenella/ransidble/internal/domain/core/service/task"
 	"github.com/apenella/ransidble/internal/domain/core/service/workspace"
+	portsrepository "github.com/apenella/ransidble/internal/domain/ports/repository"
+	"github.com/apenella/ransidble/internal/handler/cli/sourcecode"
 	server "github.com/apenella/ransidble/internal/handler/http"
 	projectHandler "github.com/apenella/ransidble/internal/handler/http/project"
 	taskHandler "github.com/apenella/ransidble/internal/handler/http/task"
+	"github.com/apenella/ransidble/internal/infrastructure/browse"
 	ansibleexecutor "github.com/apenella/ransidble/internal/infrastructure/executor"
 	"github.com/apenella/ransidble/internal/infrastructure/filesystem"
 	"github.com/apenella/ransidble/internal/infrastructure/logger"
-	"github.com/apenella/ransidble/internal/infrastructure/persistence/project/fetch"
+	"github.com/apenella/ransidble/internal/infrastructure/oci"
 	"github.com/apenella/ransidble/internal/infrastructure/persistence/project/repository"
 	"github.com/apenella/ransidble/internal/infrastructure/persistence/project/repository/local"
-	"github.com/apenella/ransidble/internal/infrastructure/persistence/project/store"
+	"github.com/apenella/ransidble/internal/infrastructure/persistence/project/upload"
 	taskpersistence "github.com/apenella/ransidble/internal/infrastructure/persistence/task"
-	"github.com/apenella/ransidble/internal/infrastructure/tar"
+	"github.com/apenella/ransidble/internal/infrastructure/s3"
+	"github.com/apenella/ransidble/internal/infrastructure/scan"
+	"github.com/apenella/ransidble/internal/infrastructure/signature"
 	"github.com/apenella/ransidble/internal/infrastructure/unpack"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
@@ -35,8 +40,14 @@ import (
 var (
 	// ErrStartDispatcher represents an error when starting the dispatcher
 	ErrStartDispatcher = fmt.Errorf("error starting dispatcher")
-	// ErrLoadProjects represents an error when loading projects
+	// ErrLoadProjects represents an error when loading the projects of the import paths
 	ErrLoadProjects = fmt.Errorf("error loading projects")
+	// ErrUnknownTaskRepositoryType represents an error when the task repository type is not supported
+	ErrUnknownTaskRepositoryType = fmt.Errorf("unknown task repository type")
+	// ErrUnknownProjectUploadsStorage represents an error when the project uploads storage is not supported
+	ErrUnknownProjectUploadsStorage = fmt.Errorf("unknown project uploads storage")
+	// ErrProjectUploadsS3StorageNotConfigured represents an error when the project uploads are staged in the S3 storage but no bucket is configured
+	ErrProjectUploadsS3StorageNotConfigured = fmt.Errorf("project uploads S3 storage not configured")
 )
 
 // NewCommand returns a new cobra.Command to serve a Ransidble server
@@ -69,65 +80,35 @@ func NewCommand(config *configuration.Configuration) *cobra.Command {
 
 			projectsRepository := projectsRepositoryFactory.Get(config.Server.Project.ProjectRepositoryConfiguration.Type)
 
-			// At this moment, the project repository loads the projects from the local storage. In the future, the plan is to have a database where you need to create a project before running it.
-			// projectsRepository := localprojectpersistence.NewProjectRepository(
-			// 	// afs,
-			// 	// config.Server.Project.LocalStoragePath,
-			// 	projectRepositoryBackendDriver,
-			// 	log,
-			// )
-
-			// err = projectsRepository.Initialize()
-			// if err != nil {
-			// 	log.Error(
-			// 		err.Error(),
-			// 		map[string]interface{}{
-			// 			"component": "Serve",
-			// 			"package":   "github.com/apenella/ransidble/internal/handler/cli/serve",
-			// 		})
-			// 	return
-			// }
-
-			// Commented because it must be handled from an specific use case
-			//
-			// errLoadProjects := projectsRepository.LoadProjects()
-			// if errLoadProjects != nil {
-			// 	errMsg := fmt.Sprintf("%s: %s", ErrLoadProjects, errLoadProjects)
-
-			// 	log.Error(
-			// 		errMsg,
-			// 		map[string]interface{}{
-			// 			"component": "Serve",
-			// 			"package":   "github.com/apenella/ransidble/internal/handler/cli/serve",
-			// 		})
-
-			// 	err = fmt.Errorf("%s", errMsg)
-			// 	return
-			// }
-
-			// TO DO: do not fetch from the local storage but from the project repository
-			fetchFactory := fetch.NewFactory()
-			fetchFactory.Register(
-				entity.ProjectTypeLocal,
-				fetch.NewLocalStorage(
-					afs,
-					config.Server.Project.ProjectStorageConfiguration.LocalStoragePath,
-					log,
-				),
-			)
-
-			unpackFactory := unpack.NewFactory()
-			unpackFactory.Register(entity.ProjectFormatPlain, unpack.NewPlainFormat(
-				afs,
-				log,
-			))
-
-			tarExtractor := tar.NewTar(afs, log)
-			unpackFactory.Register(entity.ProjectFormatTarGz, unpack.NewTarGzipFormat(
-				afs,
-				tarExtractor,
-				log,
-			))
+			registries, err := sourcecode.NewRegistries(config, afs, log)
+			if err != nil {
+				log.Error(
+					err.Error(),
+					map[string]interface{}{
+						"component": "Serve",
+						"package":   "github.com/apenella/ransidble/internal/handler/cli/serve",
+					})
+				return err
+			}
+			fetchFactory := registries.FetchFactory
+			unpackFactory := registries.UnpackFactory
+			storeFactory := registries.StoreFactory
+			archiveLimits := registries.ArchiveLimits
+			s3Client := registries.S3Client
+			ociClient := registries.OCIClient
+
+			trustPolicyConfiguration := config.Server.Project.ProjectTrustPolicyConfiguration
+			trustedPublicKeys, err := signature.LoadPublicKeys(afs, trustPolicyConfiguration.PublicKeys)
+			if err != nil {
+				log.Error(
+					err.Error(),
+					map[string]interface{}{
+						"component": "Serve",
+						"package":   "github.com/apenella/ransidble/internal/handler/cli/serve",
+					})
+				return err
+			}
+			trustPolicy := entity.NewProjectTrustPolicy(trustPolicyConfiguration.RequireSignature, trustedPublicKeys...)
 
 			workspaceBuilder := workspace.NewBuilder(
 				fs,
@@ -135,39 +116,85 @@ func NewCommand(config *configuration.Configuration) *cobra.Command {
 				unpackFactory,
 				projectsRepository,
 				log,
-			)
+			).WithSignatureVerifier(signature.NewVerifier(afs, trustPolicy, log))
+
+			taskOutputRepository := taskpersistence.NewMemoryTaskOutputRepository(log).WithMaxSize(config.Server.Task.MaxOutputSize)
+
+			taskRepository, err := newTaskRepository(config.Server.Task.TaskRepositoryConfiguration, afs, log)
+			if err != nil {
+				log.Error(
+					err.Error(),
+					map[string]interface{}{
+						"component": "Serve",
+						"package":   "github.com/apenella/ransidble/internal/handler/cli/serve",
+					})
+				return err
+			}
 
 			dispatcher := executor.NewDispatch(
 				config.Server.WorkerPoolSize,
 				workspaceBuilder,
 				ansibleexecutor.NewAnsiblePlaybook(log),
+				taskOutputRepository,
 				log,
-			)
+			).WithTaskRepository(taskRepository)
 
-			taskRepository := taskpersistence.NewMemoryTaskRepository(log)
 			createTaskAnsiblePlaybookService := taskService.NewCreateTaskAnsiblePlaybookService(
 				dispatcher,
 				taskRepository,
 				projectsRepository,
 				log,
+			).WithExecutionTimeout(
+				config.Server.Task.DefaultExecutionTimeout,
+				config.Server.Task.MaxExecutionTimeout,
 			)
 
 			createTaskAnsiblePlaybookHandler := taskHandler.NewCreateTaskAnsiblePlaybookHandler(createTaskAnsiblePlaybookService, log)
 
 			getTaskService := taskService.NewGetTaskService(taskRepository, log)
 			getTaskHandler := taskHandler.NewGetTaskHandler(getTaskService, log)
+			getTasksListHandler := taskHandler.NewGetTasksListHandler(getTaskService, log)
+
+			cancelTaskService := taskService.NewCancelTaskService(dispatcher, taskRepository, log)
+			cancelTaskHandler := taskHandler.NewCancelTaskHandler(cancelTaskService, log)
+
+			getTaskOutputService := taskService.NewGetTaskOutputService(taskRepository, taskOutputRepository, log)
+			getTaskOutputHandler := taskHandler.NewGetTaskOutputHandler(getTaskOutputService, log)
+
+			retention := config.Server.Task.TaskRetentionConfiguration
+			taskJanitorService := taskService.NewTaskJanitorService(
+				&entity.TaskRetentionPolicy{
+					MaxAge:             retention.MaxAge,
+					MaxCountPerProject: retention.MaxCountPerProject,
+					MaxCountPerStatus:  retention.MaxCountPerStatus,
+				},
+				retention.Interval,
+				taskRepository,
+				taskOutputRepository,
+				log,
+			)
+			getTaskJanitorStatsHandler := taskHandler.NewGetTaskJanitorStatsHandler(taskJanitorService, log)
+
+			streamTaskOutputService := taskService.NewStreamTaskOutputService(taskRepository, taskOutputRepository, log)
+			streamTaskOutputHandler := taskHandler.NewStreamTaskOutputHandler(streamTaskOutputService, log)
 
 			getProjectService := projectService.NewGetProjectService(projectsRepository, log)
 			getProjectHandler := projectHandler.NewGetProjectHandler(getProjectService, log)
 			getProjectListHandler := projectHandler.NewGetProjectListHandler(getProjectService, log)
+			getProjectVersionsHandler := projectHandler.NewGetProjectVersionsHandler(getProjectService, log)
 
-			storeFactory := store.NewFactory()
-			localStorageStore := store.NewLocalStorage(
-				afs,
-				config.Server.Project.ProjectStorageConfiguration.LocalStoragePath,
+			sourceCodeBrowser := browse.NewBrowser(afs, fetchFactory, unpackFactory, log)
+			getProjectContentService := projectService.NewGetProjectContentService(
+				projectsRepository,
+				sourceCodeBrowser,
 				log,
 			)
-			err = localStorageStore.Initialize()
+			getProjectContentHandler := projectHandler.NewGetProjectContentHandler(getProjectContentService, log)
+			getProjectFilesHandler := projectHandler.NewGetProjectFilesHandler(getProjectContentService, log)
+			getProjectFileHandler := projectHandler.NewGetProjectFileHandler(getProjectContentService, log)
+
+			uploadsConfiguration := config.Server.Project.ProjectUploadsConfiguration
+			projectUploadRepository, err := newProjectUploadRepository(uploadsConfiguration, afs, s3Client, log)
 			if err != nil {
 				log.Error(
 					err.Error(),
@@ -177,35 +204,117 @@ func NewCommand(config *configuration.Configuration) *cobra.Command {
 					})
 				return err
 			}
-			storeFactory.Register(entity.ProjectTypeLocal, localStorageStore)
+
+			projectUploadService := projectService.NewProjectUploadService(
+				projectUploadRepository,
+				uploadsConfiguration.Expiration,
+				uploadsConfiguration.PurgeInterval,
+				log,
+			).WithArchiveLimits(archiveLimits)
+			createProjectUploadHandler := projectHandler.NewCreateProjectUploadHandler(projectUploadService, log)
+			getProjectUploadHandler := projectHandler.NewGetProjectUploadHandler(projectUploadService, log)
+			appendProjectUploadHandler := projectHandler.NewAppendProjectUploadHandler(projectUploadService, log)
+			deleteProjectUploadHandler := projectHandler.NewDeleteProjectUploadHandler(projectUploadService, log)
+
 			createProjectService := projectService.NewCreateProjectService(
 				projectsRepository,
 				storeFactory,
 				log,
-			)
+			).WithOCIResolver(oci.NewResolver(ociClient)).
+				WithArchiveLimits(archiveLimits).
+				WithArchiveInspector(unpack.NewArchiveInspector(log)).
+				WithDiscoverer(sourceCodeBrowser).
+				WithUploadService(projectUploadService)
+
+			// the projects found in the import paths are registered before the server starts serving requests. The projects that cannot be imported do not prevent the server from starting
+			if len(config.Server.Project.ImportPaths) > 0 {
+				importProjectService := projectService.NewImportProjectService(
+					createProjectService,
+					projectsRepository,
+					scan.NewScanner(afs, log),
+					log,
+				)
 
-			createProjectHandler := projectHandler.NewCreateProjectHandler(createProjectService, log)
+				report, errImport := importProjectService.Import(config.Server.Project.ImportPaths...)
+				if errImport != nil {
+					errMsg := fmt.Sprintf("%s: %s", ErrLoadProjects, errImport)
+					log.Error(
+						errMsg,
+						map[string]interface{}{
+							"component": "Serve",
+							"package":   "github.com/apenella/ransidble/internal/handler/cli/serve",
+						})
+					return fmt.Errorf("%s", errMsg)
+				}
+
+				log.Info(
+					fmt.Sprintf("Projects imported: %d added, %d skipped, %d failed",
+						report.Count(entity.ProjectImportStatusAdded),
+						report.Count(entity.ProjectImportStatusSkipped),
+						report.Count(entity.ProjectImportStatusFailed),
+					),
+					map[string]interface{}{
+						"component": "Serve",
+						"package":   "github.com/apenella/ransidble/internal/handler/cli/serve",
+					})
+			}
+
+			createProjectHandler := projectHandler.NewCreateProjectHandler(createProjectService, log).
+				WithMaxUploadSize(archiveLimits.MaxUploadSize)
+			createProjectVersionHandler := projectHandler.NewCreateProjectVersionHandler(createProjectService, log).
+				WithMaxUploadSize(archiveLimits.MaxUploadSize)
+			replaceProjectHandler := projectHandler.NewReplaceProjectHandler(createProjectService, log).
+				WithMaxUploadSize(archiveLimits.MaxUploadSize)
 
 			deleteProjectService := projectService.NewDeleteProjectService(
 				projectsRepository,
 				storeFactory,
 				log,
-			)
+			).WithTaskRepository(taskRepository).
+				WithTaskCanceller(cancelTaskService).
+				WithTrashPolicy(
+					config.Server.Project.ProjectTrashConfiguration.GracePeriod,
+					config.Server.Project.ProjectTrashConfiguration.PurgeInterval,
+				)
 
 			deleteProjectHandler := projectHandler.NewDeleteProjectHandler(deleteProjectService, log)
+			deleteProjectVersionHandler := projectHandler.NewDeleteProjectVersionHandler(deleteProjectService, log)
+			restoreProjectHandler := projectHandler.NewRestoreProjectHandler(deleteProjectService, log)
 
 			router := echo.New()
 			router.Use(middleware.Logger())
 			router.Use(middleware.GzipWithConfig(middleware.GzipConfig{
 				Level: 5,
+				// the task output stream must reach the client as soon as it is written, and the stored source code is already compressed
+				Skipper: func(c echo.Context) bool {
+					return c.Path() == server.GetTaskOutputStreamPath || c.Path() == server.GetProjectContentPath
+				},
 			}))
 
 			router.POST(server.CreateProjectPath, createProjectHandler.Handle)
 			router.POST(server.CreateTaskAnsiblePlaybookPath, createTaskAnsiblePlaybookHandler.Handle)
 			router.GET(server.GetTaskPath, getTaskHandler.Handle)
+			router.GET(server.GetTasksPath, getTasksListHandler.Handle)
+			router.POST(server.CancelTaskPath, cancelTaskHandler.Handle)
+			router.GET(server.GetTaskOutputPath, getTaskOutputHandler.Handle)
+			router.GET(server.GetTaskOutputStreamPath, streamTaskOutputHandler.Handle)
+			router.GET(server.GetTaskJanitorStatsPath, getTaskJanitorStatsHandler.Handle)
 			router.GET(server.GetProjectPath, getProjectHandler.Handle)
 			router.GET(server.GetProjectsPath, getProjectListHandler.Handle)
 			router.DELETE(server.DeleteProjectPath, deleteProjectHandler.Handle)
+			router.POST(server.RestoreProjectPath, restoreProjectHandler.Handle)
+			router.PUT(server.ReplaceProjectPath, replaceProjectHandler.Handle)
+			router.POST(server.CreateProjectVersionPath, createProjectVersionHandler.Handle)
+			router.GET(server.GetProjectVersionsPath, getProjectVersionsHandler.Handle)
+			router.DELETE(server.DeleteProjectVersionPath, deleteProjectVersionHandler.Handle)
+			router.GET(server.GetProjectContentPath, getProjectContentHandler.Handle)
+			router.GET(server.GetProjectFilesPath, getProjectFilesHandler.Handle)
+			router.GET(server.GetProjectFilePath, getProjectFileHandler.Handle)
+			router.POST(server.CreateProjectUploadPath, createProjectUploadHandler.Handle)
+			router.GET(server.GetProjectUploadPath, getProjectUploadHandler.Handle)
+			router.HEAD(server.GetProjectUploadPath, getProjectUploadHandler.Handle)
+			router.PATCH(server.AppendProjectUploadPath, appendProjectUploadHandler.Handle)
+			router.DELETE(server.DeleteProjectUploadPath, deleteProjectUploadHandler.Handle)
 
 			go func() {
 				errStartDispatcher := dispatcher.Start(cmd.Context())
@@ -223,6 +332,10 @@ func NewCommand(config *configuration.Configuration) *cobra.Command {
 				}
 			}()
 
+			taskJanitorService.Start(cmd.Context())
+			projectUploadService.Start(cmd.Context())
+			deleteProjectService.Start(cmd.Context())
+
 			// Wait for interrupt signal to gracefully shutdown the server
 			quitCh := make(chan os.Signal, 1)
 			signal.Notify(quitCh, syscall.SIGINT, syscall.SIGTERM)
@@ -262,6 +375,9 @@ func NewCommand(config *configuration.Configuration) *cobra.Command {
 					})
 
 				srv.Stop()
+				taskJanitorService.Stop()
+				projectUploadService.Stop()
+				deleteProjectService.Stop()
 				dispatcher.Stop()
 			}
 
@@ -275,3 +391,48 @@ func NewCommand(config *configuration.Configuration) *cobra.Command {
 
 	return cmd
 }
+
+// newTaskRepository creates the task repository set in the configuration. The local task repository is initialized, which fails the tasks interrupted by the previous server stop
+func newTaskRepository(config configuration.TaskRepositoryConfiguration, afs afero.Fs, log portsrepository.Logger) (portsrepository.TaskRepository, error) {
+
+	switch config.Type {
+	case configuration.TaskRepositoryTypeLocal:
+		taskRepository := taskpersistence.NewLocalTaskRepository(afs, config.LocalRepositoryPath, log)
+		err := taskRepository.Initialize()
+		if err != nil {
+			return nil, err
+		}
+		return taskRepository, nil
+	case configuration.TaskRepositoryTypeMemory:
+		return taskpersistence.NewMemoryTaskRepository(log), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownTaskRepositoryType, config.Type)
+	}
+}
+
+// newProjectUploadRepository creates the repository where the project uploads are staged, in the storage set in the configuration, and initializes it
+func newProjectUploadRepository(config configuration.ProjectUploadsConfiguration, afs afero.Fs, s3Client *s3.Client, log portsrepository.Logger) (portsrepository.ProjectUploadRepository, error) {
+
+	switch config.Storage {
+	case configuration.ProjectUploadsStorageLocal:
+		uploadRepository := upload.NewLocalUploadRepository(afs, config.LocalPath, log)
+		err := uploadRepository.Initialize()
+		if err != nil {
+			return nil, err
+		}
+		return uploadRepository, nil
+	case configuration.ProjectUploadsStorageS3:
+		// the client is checked before it is set as an object storer, since a nil client would not be a nil object storer
+		if s3Client == nil {
+			return nil, ErrProjectUploadsS3StorageNotConfigured
+		}
+		uploadRepository := upload.NewS3UploadRepository(s3Client, afs, config.LocalPath, log)
+		err := uploadRepository.Initialize()
+		if err != nil {
+			return nil, err
+		}
+		return uploadRepository, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownProjectUploadsStorage, config.Storage)
+	}
+}
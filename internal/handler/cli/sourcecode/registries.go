@@ -0,0 +1,158 @@
+package sourcecode
+
+import (
+	"fmt"
+
+	"github.com/apenella/ransidble/internal/configuration"
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	portsrepository "github.com/apenella/ransidble/internal/domain/ports/repository"
+	"github.com/apenella/ransidble/internal/infrastructure/oci"
+	"github.com/apenella/ransidble/internal/infrastructure/persistence/project/fetch"
+	"github.com/apenella/ransidble/internal/infrastructure/persistence/project/store"
+	"github.com/apenella/ransidble/internal/infrastructure/s3"
+	"github.com/apenella/ransidble/internal/infrastructure/tar"
+	"github.com/apenella/ransidble/internal/infrastructure/unpack"
+	"github.com/spf13/afero"
+)
+
+// Registries holds the components to store, fetch and unpack the source code of the projects. Every command handling the source code of the projects builds them through NewRegistries, so all of them support the same storages and formats
+type Registries struct {
+	// ArchiveLimits are the limits applied to the project archives
+	ArchiveLimits *entity.ProjectArchiveLimits
+	// FetchFactory holds the fetchers of each storage type
+	FetchFactory *fetch.Factory
+	// OCIClient is the client of the OCI registry storage
+	OCIClient *oci.Client
+	// S3Client is the client of the S3 storage. It is nil when no bucket is configured
+	S3Client *s3.Client
+	// StoreFactory holds the stores of each storage type
+	StoreFactory *store.Factory
+	// UnpackFactory holds the unpackers of each project format
+	UnpackFactory *unpack.Factory
+}
+
+// NewRegistries creates the components to store, fetch and unpack the source code of the projects from the configuration
+func NewRegistries(config *configuration.Configuration, afs afero.Fs, log portsrepository.Logger) (*Registries, error) {
+	var err error
+
+	storageConfiguration := config.Server.Project.ProjectStorageConfiguration
+	registries := &Registries{
+		FetchFactory:  fetch.NewFactory(),
+		StoreFactory:  store.NewFactory(),
+		UnpackFactory: unpack.NewFactory(),
+	}
+
+	localStorageStore := store.NewLocalStorage(afs, storageConfiguration.LocalStoragePath, log)
+	err = localStorageStore.Initialize()
+	if err != nil {
+		return nil, err
+	}
+
+	// TO DO: do not fetch from the local storage but from the project repository
+	registries.FetchFactory.Register(entity.ProjectTypeLocal, fetch.NewLocalStorage(afs, storageConfiguration.LocalStoragePath, log))
+	registries.StoreFactory.Register(entity.ProjectTypeLocal, localStorageStore)
+
+	tarExtractor := tar.NewTar(afs, log)
+
+	gitStorageConfiguration := storageConfiguration.Git
+	registries.FetchFactory.Register(
+		entity.ProjectTypeGit,
+		fetch.NewGitRepository(
+			gitStorageConfiguration.CachePath,
+			tarExtractor,
+			&fetch.GitAuth{
+				Hosts:      gitStorageConfiguration.CredentialHosts,
+				SSHKeyPath: gitStorageConfiguration.SSHKeyPath,
+				Token:      gitStorageConfiguration.Token,
+				Username:   gitStorageConfiguration.Username,
+			},
+			log,
+		),
+	)
+	registries.StoreFactory.Register(entity.ProjectTypeGit, store.NewGitStorage(log))
+
+	// the S3 storage is only available when a bucket is configured
+	s3StorageConfiguration := storageConfiguration.S3
+	if s3StorageConfiguration.Bucket != "" {
+		registries.S3Client, err = s3.NewClient(
+			s3.Config{
+				AccessKeyID:     s3StorageConfiguration.AccessKeyID,
+				Bucket:          s3StorageConfiguration.Bucket,
+				Endpoint:        s3StorageConfiguration.Endpoint,
+				PathStyle:       s3StorageConfiguration.PathStyle,
+				Prefix:          s3StorageConfiguration.Prefix,
+				Region:          s3StorageConfiguration.Region,
+				SecretAccessKey: s3StorageConfiguration.SecretAccessKey,
+				SessionToken:    s3StorageConfiguration.SessionToken,
+				Timeout:         s3StorageConfiguration.Timeout,
+			},
+			nil,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		registries.FetchFactory.Register(entity.ProjectTypeS3, fetch.NewS3Storage(afs, registries.S3Client, log))
+		registries.StoreFactory.Register(entity.ProjectTypeS3, store.NewS3Storage(registries.S3Client, log))
+	}
+
+	ociStorageConfiguration := storageConfiguration.OCI
+	registries.OCIClient = oci.NewClient(
+		oci.Config{
+			AuthHosts: ociStorageConfiguration.AuthHosts,
+			Password:  ociStorageConfiguration.Password,
+			PlainHTTP: ociStorageConfiguration.PlainHTTP,
+			Registry:  ociStorageConfiguration.Registry,
+			Timeout:   ociStorageConfiguration.Timeout,
+			Username:  ociStorageConfiguration.Username,
+		},
+		nil,
+	)
+	registries.FetchFactory.Register(entity.ProjectTypeOCI, fetch.NewOCIRegistry(afs, registries.OCIClient, log))
+	registries.StoreFactory.Register(entity.ProjectTypeOCI, store.NewOCIRegistryStorage(log))
+
+	limitsConfiguration := config.Server.Project.ProjectLimitsConfiguration
+	registries.ArchiveLimits = &entity.ProjectArchiveLimits{
+		MaxCompressionRatio: limitsConfiguration.MaxCompressionRatio,
+		MaxEntries:          limitsConfiguration.MaxEntries,
+		MaxFileSize:         limitsConfiguration.MaxFileSize,
+		MaxUncompressedSize: limitsConfiguration.MaxUncompressedSize,
+		MaxUploadSize:       limitsConfiguration.MaxUploadSize,
+	}
+
+	registries.UnpackFactory.Register(entity.ProjectFormatPlain, unpack.NewPlainFormat(afs, log))
+	registries.UnpackFactory.Register(entity.ProjectFormatTarGz, unpack.NewTarGzipFormat(afs, tarExtractor, log).WithLimits(registries.ArchiveLimits))
+	registries.UnpackFactory.Register(entity.ProjectFormatTar, unpack.NewTarFormat(afs, tarExtractor, log).WithLimits(registries.ArchiveLimits))
+	registries.UnpackFactory.Register(entity.ProjectFormatZip, unpack.NewZipFormat(afs, log).WithLimits(registries.ArchiveLimits))
+	registries.UnpackFactory.Register(entity.ProjectFormatOCI, unpack.NewOCIFormat(afs, log).WithLimits(registries.ArchiveLimits))
+
+	// the tar.zst and tar.xz files are decompressed by an external command, so their formats are only registered when the command is available
+	tarZstdFormat := unpack.NewTarZstdFormat(afs, tarExtractor, log).WithLimits(registries.ArchiveLimits)
+	err = tarZstdFormat.Available()
+	if err == nil {
+		registries.UnpackFactory.Register(entity.ProjectFormatTarZst, tarZstdFormat)
+	} else {
+		warnFormatNotAvailable(log, entity.ProjectFormatTarZst, err)
+	}
+
+	tarXzFormat := unpack.NewTarXzFormat(afs, tarExtractor, log).WithLimits(registries.ArchiveLimits)
+	err = tarXzFormat.Available()
+	if err == nil {
+		registries.UnpackFactory.Register(entity.ProjectFormatTarXz, tarXzFormat)
+	} else {
+		warnFormatNotAvailable(log, entity.ProjectFormatTarXz, err)
+	}
+
+	return registries, nil
+}
+
+// warnFormatNotAvailable logs that the projects in the given format cannot be unpacked by the server
+func warnFormatNotAvailable(log portsrepository.Logger, format string, err error) {
+	log.Warn(
+		fmt.Sprintf("project format %s not available: %s", format, err.Error()),
+		map[string]interface{}{
+			"component": "NewRegistries",
+			"format":    format,
+			"package":   "github.com/apenella/ransidble/internal/handler/cli/sourcecode",
+		})
+}
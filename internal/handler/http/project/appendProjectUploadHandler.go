@@ -0,0 +1,92 @@
+package project
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	"github.com/apenella/ransidble/internal/domain/core/model/response"
+	"github.com/apenella/ransidble/internal/domain/ports/repository"
+	"github.com/apenella/ransidble/internal/domain/ports/service"
+	"github.com/labstack/echo/v4"
+)
+
+// AppendProjectUploadHandler handles the request to append a chunk to a project upload
+type AppendProjectUploadHandler struct {
+	service service.ProjectUploadServicer
+	logger  repository.Logger
+}
+
+// NewAppendProjectUploadHandler creates a new AppendProjectUploadHandler
+func NewAppendProjectUploadHandler(service service.ProjectUploadServicer, logger repository.Logger) *AppendProjectUploadHandler {
+	return &AppendProjectUploadHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// Handle method to append the request body to a project upload, at the offset given by the Upload-Offset header
+func (h *AppendProjectUploadHandler) Handle(c echo.Context) error {
+	var err error
+	var errorMsg string
+	var errorResponse *response.ProjectErrorResponse
+	var offset int64
+	var upload *entity.ProjectUpload
+	var uploadID string
+
+	if h.service == nil {
+		errorResponse = &response.ProjectErrorResponse{
+			Error:  ErrProjectUploadServiceNotInitialized,
+			Status: http.StatusInternalServerError,
+		}
+		h.logger.Error(
+			ErrProjectUploadServiceNotInitialized,
+			map[string]interface{}{
+				"component": "AppendProjectUploadHandler.Handle",
+				"package":   "github.com/apenella/ransidble/internal/handler/http/project",
+			})
+		return c.JSON(http.StatusInternalServerError, errorResponse)
+	}
+
+	uploadID = c.Param("id")
+	if uploadID == "" {
+		errorResponse = &response.ProjectErrorResponse{
+			Error:  ErrProjectUploadIDNotProvided,
+			Status: http.StatusBadRequest,
+		}
+		h.logger.Error(
+			ErrProjectUploadIDNotProvided,
+			map[string]interface{}{
+				"component": "AppendProjectUploadHandler.Handle",
+				"package":   "github.com/apenella/ransidble/internal/handler/http/project",
+			})
+		return c.JSON(http.StatusBadRequest, errorResponse)
+	}
+
+	offset, err = strconv.ParseInt(c.Request().Header.Get(HeaderUploadOffset), 10, 64)
+	if err != nil || offset < 0 {
+		errorMsg = fmt.Sprintf("%s: %s must be a non-negative integer", ErrInvalidProjectUploadOffsetHeader, HeaderUploadOffset)
+		errorResponse = &response.ProjectErrorResponse{
+			Error:  errorMsg,
+			Status: http.StatusBadRequest,
+		}
+		h.logger.Error(
+			errorMsg,
+			map[string]interface{}{
+				"component": "AppendProjectUploadHandler.Handle",
+				"package":   "github.com/apenella/ransidble/internal/handler/http/project",
+				"upload_id": uploadID,
+			})
+		return c.JSON(http.StatusBadRequest, errorResponse)
+	}
+
+	upload, err = h.service.AppendUpload(uploadID, offset, c.Request().Body)
+	if err != nil {
+		return projectUploadErrorResponse(c, h.logger, "AppendProjectUploadHandler.Handle", ErrAppendingProjectUpload, uploadID, err)
+	}
+
+	setProjectUploadHeaders(c, upload)
+
+	return c.NoContent(http.StatusNoContent)
+}
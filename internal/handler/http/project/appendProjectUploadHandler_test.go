@@ -0,0 +1,237 @@
+package project
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	domainerror "github.com/apenella/ransidble/internal/domain/core/error"
+	"github.com/apenella/ransidble/internal/domain/core/model/response"
+	"github.com/apenella/ransidble/internal/domain/ports/service"
+	"github.com/apenella/ransidble/internal/infrastructure/logger"
+	"github.com/apenella/ransidble/test/openapi"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandle_AppendProjectUploadHandler(t *testing.T) {
+	openAPIValidator, err := openapi.PrepareOpenAPIValidator("../../../../api/openapi.yaml")
+	if err != nil {
+		t.Errorf("Error initializing OpenAPI validator: %s", err)
+		t.FailNow()
+		return
+	}
+
+	tests := []struct {
+		desc               string
+		handler            *AppendProjectUploadHandler
+		offset             string
+		arrangeContextFunc func(r *http.Request, w http.ResponseWriter) echo.Context
+		arrangeTestFunc    func(t *testing.T, h *AppendProjectUploadHandler)
+		assertTestFunc     func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			desc: "Testing AppendProjectUploadHandler.Handle responding with an error when service not initialized and is returning an StatusInternalServerError",
+			handler: NewAppendProjectUploadHandler(
+				nil,
+				logger.NewFakeLogger(),
+			),
+			offset: "0",
+			arrangeContextFunc: func(r *http.Request, w http.ResponseWriter) echo.Context {
+				return echo.New().NewContext(r, w)
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var body *response.ProjectErrorResponse
+				expectedBody := &response.ProjectErrorResponse{
+					Error:  ErrProjectUploadServiceNotInitialized,
+					Status: http.StatusInternalServerError,
+				}
+
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				assert.Equal(t, expectedBody, body)
+				assert.Equal(t, http.StatusInternalServerError, rec.Code)
+			},
+		},
+		{
+			desc: "Testing AppendProjectUploadHandler.Handle responding with an error when upload id not provided and is returning an StatusBadRequest",
+			handler: NewAppendProjectUploadHandler(
+				service.NewMockProjectUploadService(),
+				logger.NewFakeLogger(),
+			),
+			offset: "0",
+			arrangeContextFunc: func(r *http.Request, w http.ResponseWriter) echo.Context {
+				return echo.New().NewContext(r, w)
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var body *response.ProjectErrorResponse
+				expectedBody := &response.ProjectErrorResponse{
+					Error:  ErrProjectUploadIDNotProvided,
+					Status: http.StatusBadRequest,
+				}
+
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				assert.Equal(t, expectedBody, body)
+				assert.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			desc: "Testing AppendProjectUploadHandler.Handle responding with an error when the offset header is not valid and is returning an StatusBadRequest",
+			handler: NewAppendProjectUploadHandler(
+				service.NewMockProjectUploadService(),
+				logger.NewFakeLogger(),
+			),
+			offset: "-1",
+			arrangeContextFunc: func(r *http.Request, w http.ResponseWriter) echo.Context {
+				c := echo.New().NewContext(r, w)
+				c.SetParamNames("id")
+				c.SetParamValues("upload-id")
+				return c
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var body *response.ProjectErrorResponse
+				expectedBody := &response.ProjectErrorResponse{
+					Error:  fmt.Sprintf("%s: %s must be a non-negative integer", ErrInvalidProjectUploadOffsetHeader, HeaderUploadOffset),
+					Status: http.StatusBadRequest,
+				}
+
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				assert.Equal(t, expectedBody, body)
+				assert.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			desc: "Testing AppendProjectUploadHandler.Handle responding with an error when the offset does not match the upload offset and is returning an StatusConflict",
+			handler: NewAppendProjectUploadHandler(
+				service.NewMockProjectUploadService(),
+				logger.NewFakeLogger(),
+			),
+			offset: "0",
+			arrangeContextFunc: func(r *http.Request, w http.ResponseWriter) echo.Context {
+				c := echo.New().NewContext(r, w)
+				c.SetParamNames("id")
+				c.SetParamValues("upload-id")
+				return c
+			},
+			arrangeTestFunc: func(t *testing.T, h *AppendProjectUploadHandler) {
+				h.service.(*service.MockProjectUploadService).On(
+					"AppendUpload",
+					"upload-id",
+					int64(0),
+					mock.Anything,
+				).Return(
+					nil,
+					domainerror.NewProjectUploadConflictError(fmt.Errorf("project upload offset mismatch: expected 512, got 0")),
+				)
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var body *response.ProjectErrorResponse
+				expectedBody := &response.ProjectErrorResponse{
+					Error:  fmt.Sprintf("%s: %s", ErrAppendingProjectUpload, "project upload offset mismatch: expected 512, got 0"),
+					Status: http.StatusConflict,
+				}
+
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				assert.Equal(t, expectedBody, body)
+				assert.Equal(t, http.StatusConflict, rec.Code)
+			},
+		},
+		{
+			desc: "Testing AppendProjectUploadHandler.Handle responding with an error when the chunk exceeds the upload length and is returning an StatusRequestEntityTooLarge",
+			handler: NewAppendProjectUploadHandler(
+				service.NewMockProjectUploadService(),
+				logger.NewFakeLogger(),
+			),
+			offset: "512",
+			arrangeContextFunc: func(r *http.Request, w http.ResponseWriter) echo.Context {
+				c := echo.New().NewContext(r, w)
+				c.SetParamNames("id")
+				c.SetParamValues("upload-id")
+				return c
+			},
+			arrangeTestFunc: func(t *testing.T, h *AppendProjectUploadHandler) {
+				h.service.(*service.MockProjectUploadService).On(
+					"AppendUpload",
+					"upload-id",
+					int64(512),
+					mock.Anything,
+				).Return(
+					nil,
+					domainerror.NewProjectTooLargeError(fmt.Errorf("project upload chunk exceeds the upload length")),
+				)
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+			},
+		},
+		{
+			desc: "Testing AppendProjectUploadHandler.Handle appending a chunk to an upload and is returning an StatusNoContent",
+			handler: NewAppendProjectUploadHandler(
+				service.NewMockProjectUploadService(),
+				logger.NewFakeLogger(),
+			),
+			offset: "512",
+			arrangeContextFunc: func(r *http.Request, w http.ResponseWriter) echo.Context {
+				c := echo.New().NewContext(r, w)
+				c.SetParamNames("id")
+				c.SetParamValues("upload-id")
+				return c
+			},
+			arrangeTestFunc: func(t *testing.T, h *AppendProjectUploadHandler) {
+				h.service.(*service.MockProjectUploadService).On(
+					"AppendUpload",
+					"upload-id",
+					int64(512),
+					mock.Anything,
+				).Return(
+					&entity.ProjectUpload{
+						ExpiresAt: "2026-01-06T10:10:00Z",
+						ID:        "upload-id",
+						Length:    1024,
+						Offset:    1024,
+					},
+					nil,
+				)
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusNoContent, rec.Code)
+				assert.Equal(t, "1024", rec.Header().Get(HeaderUploadOffset))
+				assert.Equal(t, "Tue, 06 Jan 2026 10:10:00 GMT", rec.Header().Get(HeaderUploadExpires))
+			},
+		},
+	}
+
+	for _, test := range tests {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPatch, "/uploads/upload-id", strings.NewReader("chunk"))
+		req.Header.Set(echo.HeaderContentType, "application/offset+octet-stream")
+		req.Header.Set(HeaderUploadOffset, test.offset)
+		context := test.arrangeContextFunc(req, rec)
+
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.arrangeTestFunc != nil {
+				test.arrangeTestFunc(t, test.handler)
+			}
+
+			err := test.handler.Handle(context)
+			assert.NoError(t, err)
+
+			test.assertTestFunc(t, rec)
+		})
+
+		t.Run(fmt.Sprintf("OpenAPI %s", test.desc), func(t *testing.T) {
+			err := openAPIValidator.ValidateResponse(rec.Body.Bytes(), req, rec.Code, rec.Header())
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -6,8 +6,11 @@ import (
 	"fmt"
 	"mime/multipart"
 	"net/http"
+	"strings"
 
+	"github.com/apenella/ransidble/internal/domain/core/entity"
 	domainerror "github.com/apenella/ransidble/internal/domain/core/error"
+	"github.com/apenella/ransidble/internal/domain/core/mapper"
 	"github.com/apenella/ransidble/internal/domain/core/model/request"
 	"github.com/apenella/ransidble/internal/domain/core/model/response"
 	"github.com/apenella/ransidble/internal/domain/ports/repository"
@@ -21,12 +24,46 @@ const (
 	RequestFormProjectMetadataFieldName = "metadata"
 	// RequestFormProjectFileFieldeName represents the form field name for the project file
 	RequestFormProjectFileFieldeName = "file"
+	// RequestFormProjectSignatureFieldName represents the form field name for the base64 encoded detached signature of the project file
+	RequestFormProjectSignatureFieldName = "signature"
+	// HeaderProjectDigest is the request header containing the expected digest of the uploaded project source code
+	HeaderProjectDigest = "X-Project-Digest"
+
+	// multipartFormMaxMemory is the size, in bytes, of the multipart form kept in memory while it is parsed. The rest of the form is stored in temporary files
+	multipartFormMaxMemory = 32 << 20
+	// multipartFormOverhead is the size, in bytes, allowed on top of the maximum upload size for the metadata, the signature and the boundaries of the multipart form
+	multipartFormOverhead = 1 << 20
+)
+
+// createRequest represents the kind of request handled by the CreateProjectHandler, since the requests to create a project, to create a project version and to replace a project share the same multipart form
+type createRequest int
+
+const (
+	// createProjectRequest creates a new project
+	createProjectRequest createRequest = iota
+	// createProjectVersionRequest creates a new version of an existing project
+	createProjectVersionRequest
+	// replaceProjectRequest replaces the source code of the most recent version of an existing project
+	replaceProjectRequest
 )
 
+// mode returns the mode of the request to create a project that corresponds to the kind of request
+func (kind createRequest) mode() string {
+	switch kind {
+	case createProjectVersionRequest:
+		return entity.CreateProjectModeVersion
+	case replaceProjectRequest:
+		return entity.CreateProjectModeReplace
+	default:
+		return entity.CreateProjectModeProject
+	}
+}
+
 // CreateProjectHandler handles the request to create a new project
 type CreateProjectHandler struct {
-	service service.CreateProjectServicer
-	logger  repository.Logger
+	service       service.CreateProjectServicer
+	logger        repository.Logger
+	maxUploadSize int64
 }
 
 // NewCreateProjectHandler creates a new CreateProjectHandler
@@ -37,17 +74,30 @@ func NewCreateProjectHandler(service service.CreateProjectServicer, logger repos
 	}
 }
 
+// WithMaxUploadSize sets the maximum size, in bytes, of the uploaded source code. The request body is limited accordingly before the multipart form is parsed, and no limit is set when it is zero
+func (h *CreateProjectHandler) WithMaxUploadSize(size int64) *CreateProjectHandler {
+	h.maxUploadSize = size
+	return h
+}
+
 // Handle method to create a new project
 func (h *CreateProjectHandler) Handle(c echo.Context) error {
+	return h.create(c, createProjectRequest)
+}
+
+// create method creates a new project, creates a new version of an existing project, or replaces an existing project, depending on the kind of request. All the requests share the same multipart form
+func (h *CreateProjectHandler) create(c echo.Context, kind createRequest) error {
 	var err error
 	var errorMsg string
 	var errorResponse *response.ProjectErrorResponse
+	var maxBytesErr *http.MaxBytesError
 	var metadata string
-	var projectAlreadyExists *domainerror.ProjectAlreadyExistsError
-	var projectErrorResponseStatus int
+	var projectDigest string
 	var projectFileHeader *multipart.FileHeader
 	var projectID string
 	var projectReceivedFile multipart.File
+	var projectRevision string
+	var projectSignature string
 	var requestParameters request.ProjectParameters
 
 	if h.service == nil {
@@ -77,6 +127,26 @@ func (h *CreateProjectHandler) Handle(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, errorResponse)
 	}
 
+	// the request body is limited before the multipart form is parsed, so an oversized upload is rejected before it is spooled to disk
+	if h.maxUploadSize > 0 {
+		c.Request().Body = http.MaxBytesReader(c.Response(), c.Request().Body, h.maxUploadSize+multipartFormOverhead)
+	}
+
+	err = c.Request().ParseMultipartForm(multipartFormMaxMemory)
+	if errors.As(err, &maxBytesErr) {
+		errorMsg = fmt.Sprintf("%s: %s", ErrProjectRequestTooLarge, err.Error())
+		errorResponse = &response.ProjectErrorResponse{
+			Error:  errorMsg,
+			Status: http.StatusRequestEntityTooLarge,
+		}
+		h.logger.Error(errorMsg, map[string]interface{}{
+			"component":  "CreateProjectHandler.Handle",
+			"package":    "github.com/apenella/ransidble/internal/handler/http/project",
+			"project_id": projectID,
+		})
+		return c.JSON(http.StatusRequestEntityTooLarge, errorResponse)
+	}
+
 	metadata = c.FormValue(RequestFormProjectMetadataFieldName)
 	if metadata == "" {
 
@@ -125,6 +195,121 @@ func (h *CreateProjectHandler) Handle(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, errorResponse)
 	}
 
+	// the source code of the projects stored in a git repository or an OCI registry is not uploaded, so there is no source code to replace
+	if kind == replaceProjectRequest && (requestParameters.Storage == entity.ProjectTypeGit || requestParameters.Storage == entity.ProjectTypeOCI) {
+		errorResponse = &response.ProjectErrorResponse{
+			Error:  fmt.Sprintf("%s: %s", ErrReplacingProject, ErrProjectSourceCodeNotReplaceable),
+			Status: http.StatusBadRequest,
+		}
+		h.logger.Error(errorResponse.Error, map[string]interface{}{
+			"component":  "CreateProjectHandler.Handle",
+			"package":    "github.com/apenella/ransidble/internal/handler/http/project",
+			"project_id": projectID,
+			"storage":    requestParameters.Storage,
+		})
+		return c.JSON(http.StatusBadRequest, errorResponse)
+	}
+
+	// the projects stored in a git repository do not upload their source code, which is fetched from the repository when a task is executed
+	if requestParameters.Storage == entity.ProjectTypeGit {
+		projectMapper := mapper.NewProjectMapper()
+		_, err = h.service.CreateFromGit(&entity.CreateProjectRequest{
+			Mode:      kind.mode(),
+			ProjectID: projectID,
+			Version:   requestParameters.Version,
+		}, projectMapper.ToProjectGitSourceEntity(requestParameters.Git))
+		if err != nil {
+			return h.createProjectErrorResponse(c, kind, err)
+		}
+
+		return h.createdProjectResponse(c, projectID, kind)
+	}
+
+	// the projects stored in an OCI registry do not upload their source code, which is fetched from the registry when a task is executed
+	if requestParameters.Storage == entity.ProjectTypeOCI {
+		projectMapper := mapper.NewProjectMapper()
+		_, err = h.service.CreateFromOCI(&entity.CreateProjectRequest{
+			Mode:      kind.mode(),
+			ProjectID: projectID,
+			Version:   requestParameters.Version,
+		}, projectMapper.ToProjectOCISourceEntity(requestParameters.OCI))
+		if err != nil {
+			return h.createProjectErrorResponse(c, kind, err)
+		}
+
+		return h.createdProjectResponse(c, projectID, kind)
+	}
+
+	projectDigest = c.Request().Header.Get(HeaderProjectDigest)
+	if projectDigest != "" {
+		err = entity.ValidateProjectDigest(projectDigest)
+		if err != nil {
+			errorMsg = fmt.Sprintf("%s: %s", ErrInvalidProjectDigestHeader, err.Error())
+			errorResponse = &response.ProjectErrorResponse{
+				Error:  errorMsg,
+				Status: http.StatusBadRequest,
+			}
+			h.logger.Error(
+				errorMsg,
+				map[string]interface{}{
+					"component":  "CreateProjectHandler.Handle",
+					"package":    "github.com/apenella/ransidble/internal/handler/http/project",
+					"project_id": projectID,
+				})
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+	}
+
+	projectSignature = strings.TrimSpace(c.FormValue(RequestFormProjectSignatureFieldName))
+	if projectSignature != "" {
+		err = entity.ValidateProjectSignature(projectSignature)
+		if err != nil {
+			errorMsg = fmt.Sprintf("%s: %s", ErrInvalidProjectSignatureField, err.Error())
+			errorResponse = &response.ProjectErrorResponse{
+				Error:  errorMsg,
+				Status: http.StatusBadRequest,
+			}
+			h.logger.Error(
+				errorMsg,
+				map[string]interface{}{
+					"component":  "CreateProjectHandler.Handle",
+					"package":    "github.com/apenella/ransidble/internal/handler/http/project",
+					"project_id": projectID,
+				})
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+	}
+
+	// the replaced project must still be at the revision the client read, when the client provides it
+	if kind == replaceProjectRequest {
+		projectRevision = projectRevisionFromIfMatch(c)
+	}
+
+	projectRequest := &entity.CreateProjectRequest{
+		Digest:    projectDigest,
+		Format:    requestParameters.Format,
+		Mode:      kind.mode(),
+		ProjectID: projectID,
+		Revision:  projectRevision,
+		Signature: projectSignature,
+		Storage:   requestParameters.Storage,
+		Version:   requestParameters.Version,
+	}
+
+	// the source code received through a resumable upload is taken from the upload instead of the request
+	if requestParameters.Upload != "" {
+		projectRevision, err = h.service.CreateFromUpload(projectRequest, requestParameters.Upload)
+		if err != nil {
+			return h.createProjectErrorResponse(c, kind, err)
+		}
+
+		if kind == replaceProjectRequest {
+			return h.replacedProjectResponse(c, projectRevision)
+		}
+
+		return h.createdProjectResponse(c, projectID, kind)
+	}
+
 	projectFileHeader, err = c.FormFile(RequestFormProjectFileFieldeName)
 	if err != nil {
 		errorMsg = fmt.Sprintf("%s: %s", ErrReadingFormProjectFileField, err.Error())
@@ -159,34 +344,98 @@ func (h *CreateProjectHandler) Handle(c echo.Context) error {
 		return c.JSON(http.StatusInternalServerError, errorResponse)
 	}
 
-	err = h.service.Create(requestParameters.Format, requestParameters.Storage, projectID, requestParameters.Version, projectReceivedFile)
+	projectRevision, err = h.service.Create(projectRequest, projectReceivedFile)
 	if err != nil {
+		return h.createProjectErrorResponse(c, kind, err)
+	}
 
-		httpStatus := http.StatusInternalServerError
-		projectErrorResponseStatus = http.StatusInternalServerError
+	if kind == replaceProjectRequest {
+		return h.replacedProjectResponse(c, projectRevision)
+	}
 
-		if errors.As(err, &projectAlreadyExists) {
-			httpStatus = http.StatusConflict
-			projectErrorResponseStatus = http.StatusConflict
-		}
+	return h.createdProjectResponse(c, projectID, kind)
+}
 
-		errorMsg = fmt.Sprintf("%s: %s", ErrCreatingProject, err.Error())
-		errorResponse = &response.ProjectErrorResponse{
-			Error:  errorMsg,
-			Status: projectErrorResponseStatus,
-		}
-		h.logger.Error(
-			errorMsg,
-			map[string]interface{}{
-				"component": "CreateProjectHandler.Handle",
-				"package":   "github.com/apenella/ransidble/internal/handler/http/project",
-			})
-		return c.JSON(httpStatus, errorResponse)
+// createProjectErrorResponse responds the error returned by the service when creating or replacing a project
+func (h *CreateProjectHandler) createProjectErrorResponse(c echo.Context, kind createRequest, err error) error {
+	var projectAlreadyExists *domainerror.ProjectAlreadyExistsError
+	var projectInvalidFormat *domainerror.ProjectInvalidFormatError
+	var projectInvalidSource *domainerror.ProjectInvalidSourceError
+	var projectInvalidVersion *domainerror.ProjectInvalidVersionError
+	var projectNotFound *domainerror.ProjectNotFoundError
+	var projectTooLarge *domainerror.ProjectTooLargeError
+	var projectArchiveLimitExceeded *domainerror.ProjectArchiveLimitExceededError
+	var projectIntegrity *domainerror.ProjectIntegrityError
+	var projectInvalidManifest *domainerror.ProjectInvalidManifestError
+	var projectPreconditionFailed *domainerror.ProjectPreconditionFailedError
+	var projectUploadConflict *domainerror.ProjectUploadConflictError
+	var projectUploadInvalid *domainerror.ProjectUploadInvalidError
+	var projectUploadNotFound *domainerror.ProjectUploadNotFoundError
+
+	httpStatus := http.StatusInternalServerError
+	switch {
+	case errors.As(err, &projectAlreadyExists):
+		httpStatus = http.StatusConflict
+	case errors.As(err, &projectInvalidFormat):
+		httpStatus = http.StatusBadRequest
+	case errors.As(err, &projectInvalidSource):
+		httpStatus = http.StatusBadRequest
+	case errors.As(err, &projectInvalidVersion):
+		httpStatus = http.StatusBadRequest
+	case errors.As(err, &projectNotFound):
+		httpStatus = http.StatusNotFound
+	case errors.As(err, &projectTooLarge):
+		httpStatus = http.StatusRequestEntityTooLarge
+	case errors.As(err, &projectArchiveLimitExceeded):
+		httpStatus = http.StatusUnprocessableEntity
+	case errors.As(err, &projectIntegrity):
+		httpStatus = http.StatusUnprocessableEntity
+	case errors.As(err, &projectInvalidManifest):
+		httpStatus = http.StatusUnprocessableEntity
+	case errors.As(err, &projectUploadConflict):
+		httpStatus = http.StatusConflict
+	case errors.As(err, &projectUploadInvalid):
+		httpStatus = http.StatusBadRequest
+	case errors.As(err, &projectUploadNotFound):
+		httpStatus = http.StatusNotFound
+	case errors.As(err, &projectPreconditionFailed):
+		httpStatus = http.StatusPreconditionFailed
 	}
 
+	message := ErrCreatingProject
+	if kind == replaceProjectRequest {
+		message = ErrReplacingProject
+	}
+
+	errorMsg := fmt.Sprintf("%s: %s", message, err.Error())
+	errorResponse := &response.ProjectErrorResponse{
+		Error:  errorMsg,
+		Status: httpStatus,
+	}
+	h.logger.Error(
+		errorMsg,
+		map[string]interface{}{
+			"component": "CreateProjectHandler.Handle",
+			"package":   "github.com/apenella/ransidble/internal/handler/http/project",
+		})
+	return c.JSON(httpStatus, errorResponse)
+}
+
+// createdProjectResponse responds a project, or a project version, has been created
+func (h *CreateProjectHandler) createdProjectResponse(c echo.Context, projectID string, kind createRequest) error {
 	// Use the route constant but replace the parameter placeholder with the actual ID
 	location := fmt.Sprintf("%s/%s", serverhttp.ProjectBasePath, projectID)
+	if kind == createProjectVersionRequest {
+		location = fmt.Sprintf("%s/%s/versions", serverhttp.ProjectBasePath, projectID)
+	}
 	c.Response().Header().Set("Location", location)
 
 	return c.NoContent(http.StatusCreated)
 }
+
+// replacedProjectResponse responds a project has been replaced, along with its new revision
+func (h *CreateProjectHandler) replacedProjectResponse(c echo.Context, revision string) error {
+	setProjectRevisionHeader(c, revision)
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -336,12 +336,14 @@ func TestHandle_CreateProjectHandler(t *testing.T) {
 			arrangeTestFunc: func(h *CreateProjectHandler) {
 				h.service.(*service.MockCreateProjectService).On(
 					"Create",
-					entity.ProjectFormatTarGz,
-					entity.ProjectTypeLocal,
-					"project-id",
-					"",
+					&entity.CreateProjectRequest{
+						Format:    entity.ProjectFormatTarGz,
+						Mode:      entity.CreateProjectModeProject,
+						ProjectID: "project-id",
+						Storage:   entity.ProjectTypeLocal,
+					},
 					mock.Anything,
-				).Return(fmt.Errorf("error opening project file"))
+				).Return("", fmt.Errorf("error opening project file"))
 			},
 			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
 				var body *response.ProjectErrorResponse
@@ -403,12 +405,15 @@ func TestHandle_CreateProjectHandler(t *testing.T) {
 			arrangeTestFunc: func(h *CreateProjectHandler) {
 				h.service.(*service.MockCreateProjectService).On(
 					"Create",
-					entity.ProjectFormatTarGz,
-					entity.ProjectTypeLocal,
-					"project-id",
-					"",
+					&entity.CreateProjectRequest{
+						Format:    entity.ProjectFormatTarGz,
+						Mode:      entity.CreateProjectModeProject,
+						ProjectID: "project-id",
+						Storage:   entity.ProjectTypeLocal,
+					},
 					mock.Anything,
 				).Return(
+					"",
 					domainerror.NewProjectAlreadyExistsError(
 						fmt.Errorf("project already exists"),
 					),
@@ -427,7 +432,7 @@ func TestHandle_CreateProjectHandler(t *testing.T) {
 			},
 		},
 		{
-			desc: "Testing CreateProjectHandler.Handle request without version success and it is returning a StatusCreated",
+			desc: "Testing CreateProjectHandler.Handle responding with an error when the project format cannot be detected and is returning a StatusBadRequest",
 			handler: NewCreateProjectHandler(
 				service.NewMockCreateProjectService(),
 				logger.NewFakeLogger(),
@@ -438,7 +443,7 @@ func TestHandle_CreateProjectHandler(t *testing.T) {
 				var bodyBuffer bytes.Buffer
 
 				requestParameters := &request.ProjectParameters{
-					Format:  entity.ProjectFormatTarGz,
+					Format:  entity.ProjectFormatAuto,
 					Storage: entity.ProjectTypeLocal,
 				}
 
@@ -447,12 +452,12 @@ func TestHandle_CreateProjectHandler(t *testing.T) {
 					t.Fatal(err)
 				}
 
-				multipartWriter := multipart.NewWriter(&bodyBuffer)
-				defer multipartWriter.Close()
+				multiparWriter := multipart.NewWriter(&bodyBuffer)
+				defer multiparWriter.Close()
 
-				multipartWriter.WriteField(RequestFormProjectMetadataFieldName, string(requestParametersJSON))
+				multiparWriter.WriteField(RequestFormProjectMetadataFieldName, string(requestParametersJSON))
 
-				part, err := multipartWriter.CreateFormFile(RequestFormProjectFileFieldeName, "project.tar.gz")
+				part, err := multiparWriter.CreateFormFile(RequestFormProjectFileFieldeName, "project.tar.gz")
 				if err != nil {
 					t.Fatal(err)
 				}
@@ -463,30 +468,119 @@ func TestHandle_CreateProjectHandler(t *testing.T) {
 				}
 
 				r = httptest.NewRequest(http.MethodPost, "/projects/project-id", &bodyBuffer)
-				r.Header.Set(echo.HeaderContentType, multipartWriter.FormDataContentType())
+				r.Header.Set(echo.HeaderContentType, multiparWriter.FormDataContentType())
 
 				c := echo.New().NewContext(r, w)
 				c.SetParamNames("id")
 				c.SetParamValues("project-id")
+
 				return c
 			},
 			arrangeTestFunc: func(h *CreateProjectHandler) {
 				h.service.(*service.MockCreateProjectService).On(
 					"Create",
-					entity.ProjectFormatTarGz,
-					entity.ProjectTypeLocal,
-					"project-id",
+					&entity.CreateProjectRequest{
+						Format:    entity.ProjectFormatAuto,
+						Mode:      entity.CreateProjectModeProject,
+						ProjectID: "project-id",
+						Storage:   entity.ProjectTypeLocal,
+					},
+					mock.Anything,
+				).Return(
 					"",
+					domainerror.NewProjectInvalidFormatError(
+						fmt.Errorf("project format could not be detected"),
+					),
+				)
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var body *response.ProjectErrorResponse
+				expectedBody := &response.ProjectErrorResponse{
+					Error:  fmt.Sprintf("%s: %s", ErrCreatingProject, "project format could not be detected"),
+					Status: http.StatusBadRequest,
+				}
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				assert.Equal(t, expectedBody, body)
+				assert.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			desc: "Testing CreateProjectHandler.Handle responding with an error when the uploaded project exceeds the maximum upload size and is returning a StatusRequestEntityTooLarge",
+			handler: NewCreateProjectHandler(
+				service.NewMockCreateProjectService(),
+				logger.NewFakeLogger(),
+			),
+			method: http.MethodPost,
+			path:   "/projects/project-id",
+			arrangeContextFunc: func(r *http.Request, w http.ResponseWriter) echo.Context {
+				var bodyBuffer bytes.Buffer
+
+				requestParameters := &request.ProjectParameters{
+					Format:  entity.ProjectFormatAuto,
+					Storage: entity.ProjectTypeLocal,
+				}
+
+				requestParametersJSON, err := json.Marshal(requestParameters)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				multiparWriter := multipart.NewWriter(&bodyBuffer)
+				defer multiparWriter.Close()
+
+				multiparWriter.WriteField(RequestFormProjectMetadataFieldName, string(requestParametersJSON))
+
+				part, err := multiparWriter.CreateFormFile(RequestFormProjectFileFieldeName, "project.tar.gz")
+				if err != nil {
+					t.Fatal(err)
+				}
+				projectContentFile := strings.NewReader("project-content")
+				_, err = io.Copy(part, projectContentFile)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				r = httptest.NewRequest(http.MethodPost, "/projects/project-id", &bodyBuffer)
+				r.Header.Set(echo.HeaderContentType, multiparWriter.FormDataContentType())
+
+				c := echo.New().NewContext(r, w)
+				c.SetParamNames("id")
+				c.SetParamValues("project-id")
+
+				return c
+			},
+			arrangeTestFunc: func(h *CreateProjectHandler) {
+				h.service.(*service.MockCreateProjectService).On(
+					"Create",
+					&entity.CreateProjectRequest{
+						Format:    entity.ProjectFormatAuto,
+						Mode:      entity.CreateProjectModeProject,
+						ProjectID: "project-id",
+						Storage:   entity.ProjectTypeLocal,
+					},
 					mock.Anything,
-				).Return(nil)
+				).Return(
+					"",
+					domainerror.NewProjectTooLargeError(
+						fmt.Errorf("project upload size exceeded"),
+					),
+				)
 			},
 			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
-				assert.Equal(t, http.StatusCreated, rec.Code)
-				assert.Equal(t, rec.Header().Get("Location"), "/projects/project-id")
+				var body *response.ProjectErrorResponse
+				expectedBody := &response.ProjectErrorResponse{
+					Error:  fmt.Sprintf("%s: %s", ErrCreatingProject, "project upload size exceeded"),
+					Status: http.StatusRequestEntityTooLarge,
+				}
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				assert.Equal(t, expectedBody, body)
+				assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
 			},
 		},
 		{
-			desc: "Testing CreateProjectHandler.Handle request with version success and it is returning a StatusCreated",
+			desc: "Testing CreateProjectHandler.Handle responding with an error when the uploaded project exceeds the archive limits and is returning a StatusUnprocessableEntity",
 			handler: NewCreateProjectHandler(
 				service.NewMockCreateProjectService(),
 				logger.NewFakeLogger(),
@@ -497,9 +591,8 @@ func TestHandle_CreateProjectHandler(t *testing.T) {
 				var bodyBuffer bytes.Buffer
 
 				requestParameters := &request.ProjectParameters{
-					Format:  entity.ProjectFormatTarGz,
+					Format:  entity.ProjectFormatAuto,
 					Storage: entity.ProjectTypeLocal,
-					Version: "1.0.0",
 				}
 
 				requestParametersJSON, err := json.Marshal(requestParameters)
@@ -528,21 +621,951 @@ func TestHandle_CreateProjectHandler(t *testing.T) {
 				c := echo.New().NewContext(r, w)
 				c.SetParamNames("id")
 				c.SetParamValues("project-id")
+
 				return c
 			},
 			arrangeTestFunc: func(h *CreateProjectHandler) {
 				h.service.(*service.MockCreateProjectService).On(
 					"Create",
-					entity.ProjectFormatTarGz,
-					entity.ProjectTypeLocal,
-					"project-id",
-					"1.0.0",
+					&entity.CreateProjectRequest{
+						Format:    entity.ProjectFormatAuto,
+						Mode:      entity.CreateProjectModeProject,
+						ProjectID: "project-id",
+						Storage:   entity.ProjectTypeLocal,
+					},
 					mock.Anything,
-				).Return(nil)
+				).Return(
+					"",
+					domainerror.NewProjectArchiveLimitExceededError(
+						fmt.Errorf("project archive limit exceeded"),
+					),
+				)
 			},
 			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
-				assert.Equal(t, http.StatusCreated, rec.Code)
-				assert.Equal(t, rec.Header().Get("Location"), "/projects/project-id")
+				var body *response.ProjectErrorResponse
+				expectedBody := &response.ProjectErrorResponse{
+					Error:  fmt.Sprintf("%s: %s", ErrCreatingProject, "project archive limit exceeded"),
+					Status: http.StatusUnprocessableEntity,
+				}
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				assert.Equal(t, expectedBody, body)
+				assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+			},
+		},
+		{
+			desc: "Testing CreateProjectHandler.Handle responding with an error when the uploaded project does not match the expected digest and is returning a StatusUnprocessableEntity",
+			handler: NewCreateProjectHandler(
+				service.NewMockCreateProjectService(),
+				logger.NewFakeLogger(),
+			),
+			method: http.MethodPost,
+			path:   "/projects/project-id",
+			arrangeContextFunc: func(r *http.Request, w http.ResponseWriter) echo.Context {
+				var bodyBuffer bytes.Buffer
+
+				requestParameters := &request.ProjectParameters{
+					Format:  entity.ProjectFormatAuto,
+					Storage: entity.ProjectTypeLocal,
+				}
+
+				requestParametersJSON, err := json.Marshal(requestParameters)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				multiparWriter := multipart.NewWriter(&bodyBuffer)
+				defer multiparWriter.Close()
+
+				multiparWriter.WriteField(RequestFormProjectMetadataFieldName, string(requestParametersJSON))
+
+				part, err := multiparWriter.CreateFormFile(RequestFormProjectFileFieldeName, "project.tar.gz")
+				if err != nil {
+					t.Fatal(err)
+				}
+				projectContentFile := strings.NewReader("project-content")
+				_, err = io.Copy(part, projectContentFile)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				r = httptest.NewRequest(http.MethodPost, "/projects/project-id", &bodyBuffer)
+				r.Header.Set(echo.HeaderContentType, multiparWriter.FormDataContentType())
+				r.Header.Set(HeaderProjectDigest, "sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+
+				c := echo.New().NewContext(r, w)
+				c.SetParamNames("id")
+				c.SetParamValues("project-id")
+
+				return c
+			},
+			arrangeTestFunc: func(h *CreateProjectHandler) {
+				h.service.(*service.MockCreateProjectService).On(
+					"Create",
+					&entity.CreateProjectRequest{
+						Digest:    "sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+						Format:    entity.ProjectFormatAuto,
+						Mode:      entity.CreateProjectModeProject,
+						ProjectID: "project-id",
+						Storage:   entity.ProjectTypeLocal,
+					},
+					mock.Anything,
+				).Return(
+					"",
+					domainerror.NewProjectIntegrityError(
+						fmt.Errorf("project digest mismatch"),
+					),
+				)
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var body *response.ProjectErrorResponse
+				expectedBody := &response.ProjectErrorResponse{
+					Error:  fmt.Sprintf("%s: %s", ErrCreatingProject, "project digest mismatch"),
+					Status: http.StatusUnprocessableEntity,
+				}
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				assert.Equal(t, expectedBody, body)
+				assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+			},
+		},
+		{
+			desc: "Testing CreateProjectHandler.Handle responding with an error when the uploaded project holds an invalid manifest and is returning a StatusUnprocessableEntity",
+			handler: NewCreateProjectHandler(
+				service.NewMockCreateProjectService(),
+				logger.NewFakeLogger(),
+			),
+			method: http.MethodPost,
+			path:   "/projects/project-id",
+			arrangeContextFunc: func(r *http.Request, w http.ResponseWriter) echo.Context {
+				var bodyBuffer bytes.Buffer
+
+				requestParameters := &request.ProjectParameters{
+					Format:  entity.ProjectFormatAuto,
+					Storage: entity.ProjectTypeLocal,
+				}
+
+				requestParametersJSON, err := json.Marshal(requestParameters)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				multiparWriter := multipart.NewWriter(&bodyBuffer)
+				defer multiparWriter.Close()
+
+				multiparWriter.WriteField(RequestFormProjectMetadataFieldName, string(requestParametersJSON))
+
+				part, err := multiparWriter.CreateFormFile(RequestFormProjectFileFieldeName, "project.tar.gz")
+				if err != nil {
+					t.Fatal(err)
+				}
+				projectContentFile := strings.NewReader("project-content")
+				_, err = io.Copy(part, projectContentFile)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				r = httptest.NewRequest(http.MethodPost, "/projects/project-id", &bodyBuffer)
+				r.Header.Set(echo.HeaderContentType, multiparWriter.FormDataContentType())
+
+				c := echo.New().NewContext(r, w)
+				c.SetParamNames("id")
+				c.SetParamValues("project-id")
+
+				return c
+			},
+			arrangeTestFunc: func(h *CreateProjectHandler) {
+				h.service.(*service.MockCreateProjectService).On(
+					"Create",
+					&entity.CreateProjectRequest{
+						Format:    entity.ProjectFormatAuto,
+						Mode:      entity.CreateProjectModeProject,
+						ProjectID: "project-id",
+						Storage:   entity.ProjectTypeLocal,
+					},
+					mock.Anything,
+				).Return(
+					"",
+					domainerror.NewProjectInvalidManifestError(
+						fmt.Errorf("error validating project manifest"),
+					),
+				)
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var body *response.ProjectErrorResponse
+				expectedBody := &response.ProjectErrorResponse{
+					Error:  fmt.Sprintf("%s: %s", ErrCreatingProject, "error validating project manifest"),
+					Status: http.StatusUnprocessableEntity,
+				}
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				assert.Equal(t, expectedBody, body)
+				assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+			},
+		},
+		{
+			desc: "Testing CreateProjectHandler.Handle responding with an error when the project digest header is not valid and is returning a StatusBadRequest",
+			handler: NewCreateProjectHandler(
+				service.NewMockCreateProjectService(),
+				logger.NewFakeLogger(),
+			),
+			method: http.MethodPost,
+			path:   "/projects/project-id",
+			arrangeContextFunc: func(r *http.Request, w http.ResponseWriter) echo.Context {
+				var bodyBuffer bytes.Buffer
+
+				requestParameters := &request.ProjectParameters{
+					Format:  entity.ProjectFormatAuto,
+					Storage: entity.ProjectTypeLocal,
+				}
+
+				requestParametersJSON, err := json.Marshal(requestParameters)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				multiparWriter := multipart.NewWriter(&bodyBuffer)
+				defer multiparWriter.Close()
+
+				multiparWriter.WriteField(RequestFormProjectMetadataFieldName, string(requestParametersJSON))
+
+				part, err := multiparWriter.CreateFormFile(RequestFormProjectFileFieldeName, "project.tar.gz")
+				if err != nil {
+					t.Fatal(err)
+				}
+				projectContentFile := strings.NewReader("project-content")
+				_, err = io.Copy(part, projectContentFile)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				r = httptest.NewRequest(http.MethodPost, "/projects/project-id", &bodyBuffer)
+				r.Header.Set(echo.HeaderContentType, multiparWriter.FormDataContentType())
+				r.Header.Set(HeaderProjectDigest, "md5:invalid")
+
+				c := echo.New().NewContext(r, w)
+				c.SetParamNames("id")
+				c.SetParamValues("project-id")
+
+				return c
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var body *response.ProjectErrorResponse
+				expectedBody := &response.ProjectErrorResponse{
+					Error:  fmt.Sprintf("%s: %s: %s", ErrInvalidProjectDigestHeader, entity.ErrInvalidProjectDigest, "md5:invalid"),
+					Status: http.StatusBadRequest,
+				}
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				assert.Equal(t, expectedBody, body)
+				assert.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			desc: "Testing CreateProjectHandler.Handle responding with an error when the project signature field is not valid and is returning a StatusBadRequest",
+			handler: NewCreateProjectHandler(
+				service.NewMockCreateProjectService(),
+				logger.NewFakeLogger(),
+			),
+			method: http.MethodPost,
+			path:   "/projects/project-id",
+			arrangeContextFunc: func(r *http.Request, w http.ResponseWriter) echo.Context {
+				var bodyBuffer bytes.Buffer
+
+				requestParameters := &request.ProjectParameters{
+					Format:  entity.ProjectFormatAuto,
+					Storage: entity.ProjectTypeLocal,
+				}
+
+				requestParametersJSON, err := json.Marshal(requestParameters)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				multiparWriter := multipart.NewWriter(&bodyBuffer)
+				defer multiparWriter.Close()
+
+				multiparWriter.WriteField(RequestFormProjectMetadataFieldName, string(requestParametersJSON))
+				multiparWriter.WriteField(RequestFormProjectSignatureFieldName, "not base64!")
+
+				part, err := multiparWriter.CreateFormFile(RequestFormProjectFileFieldeName, "project.tar.gz")
+				if err != nil {
+					t.Fatal(err)
+				}
+				projectContentFile := strings.NewReader("project-content")
+				_, err = io.Copy(part, projectContentFile)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				r = httptest.NewRequest(http.MethodPost, "/projects/project-id", &bodyBuffer)
+				r.Header.Set(echo.HeaderContentType, multiparWriter.FormDataContentType())
+
+				c := echo.New().NewContext(r, w)
+				c.SetParamNames("id")
+				c.SetParamValues("project-id")
+
+				return c
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var body *response.ProjectErrorResponse
+				expectedBody := &response.ProjectErrorResponse{
+					Error:  fmt.Sprintf("%s: %s: %s", ErrInvalidProjectSignatureField, entity.ErrInvalidProjectSignature, "illegal base64 data at input byte 3"),
+					Status: http.StatusBadRequest,
+				}
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				assert.Equal(t, expectedBody, body)
+				assert.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			desc: "Testing CreateProjectHandler.Handle request without version success and it is returning a StatusCreated",
+			handler: NewCreateProjectHandler(
+				service.NewMockCreateProjectService(),
+				logger.NewFakeLogger(),
+			),
+			method: http.MethodPost,
+			path:   "/projects/project-id",
+			arrangeContextFunc: func(r *http.Request, w http.ResponseWriter) echo.Context {
+				var bodyBuffer bytes.Buffer
+
+				requestParameters := &request.ProjectParameters{
+					Format:  entity.ProjectFormatTarGz,
+					Storage: entity.ProjectTypeLocal,
+				}
+
+				requestParametersJSON, err := json.Marshal(requestParameters)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				multipartWriter := multipart.NewWriter(&bodyBuffer)
+				defer multipartWriter.Close()
+
+				multipartWriter.WriteField(RequestFormProjectMetadataFieldName, string(requestParametersJSON))
+
+				part, err := multipartWriter.CreateFormFile(RequestFormProjectFileFieldeName, "project.tar.gz")
+				if err != nil {
+					t.Fatal(err)
+				}
+				projectContentFile := strings.NewReader("project-content")
+				_, err = io.Copy(part, projectContentFile)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				r = httptest.NewRequest(http.MethodPost, "/projects/project-id", &bodyBuffer)
+				r.Header.Set(echo.HeaderContentType, multipartWriter.FormDataContentType())
+
+				c := echo.New().NewContext(r, w)
+				c.SetParamNames("id")
+				c.SetParamValues("project-id")
+				return c
+			},
+			arrangeTestFunc: func(h *CreateProjectHandler) {
+				h.service.(*service.MockCreateProjectService).On(
+					"Create",
+					&entity.CreateProjectRequest{
+						Format:    entity.ProjectFormatTarGz,
+						Mode:      entity.CreateProjectModeProject,
+						ProjectID: "project-id",
+						Storage:   entity.ProjectTypeLocal,
+					},
+					mock.Anything,
+				).Return("", nil)
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusCreated, rec.Code)
+				assert.Equal(t, rec.Header().Get("Location"), "/projects/project-id")
+			},
+		},
+		{
+			desc: "Testing CreateProjectHandler.Handle request of a signed project success and it is returning a StatusCreated",
+			handler: NewCreateProjectHandler(
+				service.NewMockCreateProjectService(),
+				logger.NewFakeLogger(),
+			),
+			method: http.MethodPost,
+			path:   "/projects/project-id",
+			arrangeContextFunc: func(r *http.Request, w http.ResponseWriter) echo.Context {
+				var bodyBuffer bytes.Buffer
+
+				requestParameters := &request.ProjectParameters{
+					Format:  entity.ProjectFormatTarGz,
+					Storage: entity.ProjectTypeLocal,
+				}
+
+				requestParametersJSON, err := json.Marshal(requestParameters)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				multipartWriter := multipart.NewWriter(&bodyBuffer)
+				defer multipartWriter.Close()
+
+				multipartWriter.WriteField(RequestFormProjectMetadataFieldName, string(requestParametersJSON))
+				multipartWriter.WriteField(RequestFormProjectSignatureFieldName, "c2lnbmF0dXJl\n")
+
+				part, err := multipartWriter.CreateFormFile(RequestFormProjectFileFieldeName, "project.tar.gz")
+				if err != nil {
+					t.Fatal(err)
+				}
+				projectContentFile := strings.NewReader("project-content")
+				_, err = io.Copy(part, projectContentFile)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				r = httptest.NewRequest(http.MethodPost, "/projects/project-id", &bodyBuffer)
+				r.Header.Set(echo.HeaderContentType, multipartWriter.FormDataContentType())
+
+				c := echo.New().NewContext(r, w)
+				c.SetParamNames("id")
+				c.SetParamValues("project-id")
+				return c
+			},
+			arrangeTestFunc: func(h *CreateProjectHandler) {
+				h.service.(*service.MockCreateProjectService).On(
+					"Create",
+					&entity.CreateProjectRequest{
+						Format:    entity.ProjectFormatTarGz,
+						Mode:      entity.CreateProjectModeProject,
+						ProjectID: "project-id",
+						Signature: "c2lnbmF0dXJl",
+						Storage:   entity.ProjectTypeLocal,
+					},
+					mock.Anything,
+				).Return("", nil)
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusCreated, rec.Code)
+				assert.Equal(t, rec.Header().Get("Location"), "/projects/project-id")
+			},
+		},
+		{
+			desc: "Testing CreateProjectHandler.Handle request with version success and it is returning a StatusCreated",
+			handler: NewCreateProjectHandler(
+				service.NewMockCreateProjectService(),
+				logger.NewFakeLogger(),
+			),
+			method: http.MethodPost,
+			path:   "/projects/project-id",
+			arrangeContextFunc: func(r *http.Request, w http.ResponseWriter) echo.Context {
+				var bodyBuffer bytes.Buffer
+
+				requestParameters := &request.ProjectParameters{
+					Format:  entity.ProjectFormatTarGz,
+					Storage: entity.ProjectTypeLocal,
+					Version: "1.0.0",
+				}
+
+				requestParametersJSON, err := json.Marshal(requestParameters)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				multiparWriter := multipart.NewWriter(&bodyBuffer)
+				defer multiparWriter.Close()
+
+				multiparWriter.WriteField(RequestFormProjectMetadataFieldName, string(requestParametersJSON))
+
+				part, err := multiparWriter.CreateFormFile(RequestFormProjectFileFieldeName, "project.tar.gz")
+				if err != nil {
+					t.Fatal(err)
+				}
+				projectContentFile := strings.NewReader("project-content")
+				_, err = io.Copy(part, projectContentFile)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				r = httptest.NewRequest(http.MethodPost, "/projects/project-id", &bodyBuffer)
+				r.Header.Set(echo.HeaderContentType, multiparWriter.FormDataContentType())
+
+				c := echo.New().NewContext(r, w)
+				c.SetParamNames("id")
+				c.SetParamValues("project-id")
+				return c
+			},
+			arrangeTestFunc: func(h *CreateProjectHandler) {
+				h.service.(*service.MockCreateProjectService).On(
+					"Create",
+					&entity.CreateProjectRequest{
+						Format:    entity.ProjectFormatTarGz,
+						Mode:      entity.CreateProjectModeProject,
+						ProjectID: "project-id",
+						Storage:   entity.ProjectTypeLocal,
+						Version:   "1.0.0",
+					},
+					mock.Anything,
+				).Return("", nil)
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusCreated, rec.Code)
+				assert.Equal(t, rec.Header().Get("Location"), "/projects/project-id")
+			},
+		},
+		{
+			desc: "Testing CreateProjectHandler.Handle request creating a project stored in a git repository success and it is returning a StatusCreated",
+			handler: NewCreateProjectHandler(
+				service.NewMockCreateProjectService(),
+				logger.NewFakeLogger(),
+			),
+			method: http.MethodPost,
+			path:   "/projects/project-id",
+			arrangeContextFunc: func(r *http.Request, w http.ResponseWriter) echo.Context {
+				var bodyBuffer bytes.Buffer
+
+				requestParameters := &request.ProjectParameters{
+					Format: entity.ProjectFormatPlain,
+					Git: &request.ProjectGitParameters{
+						Ref:          "v1.0.0",
+						Subdirectory: "ansible",
+						URL:          "https://example.com/project.git",
+					},
+					Storage: entity.ProjectTypeGit,
+					Version: "1.0.0",
+				}
+
+				requestParametersJSON, err := json.Marshal(requestParameters)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				multiparWriter := multipart.NewWriter(&bodyBuffer)
+				defer multiparWriter.Close()
+
+				multiparWriter.WriteField(RequestFormProjectMetadataFieldName, string(requestParametersJSON))
+
+				r = httptest.NewRequest(http.MethodPost, "/projects/project-id", &bodyBuffer)
+				r.Header.Set(echo.HeaderContentType, multiparWriter.FormDataContentType())
+
+				c := echo.New().NewContext(r, w)
+				c.SetParamNames("id")
+				c.SetParamValues("project-id")
+				return c
+			},
+			arrangeTestFunc: func(h *CreateProjectHandler) {
+				h.service.(*service.MockCreateProjectService).On(
+					"CreateFromGit",
+					&entity.CreateProjectRequest{
+						Mode:      entity.CreateProjectModeProject,
+						ProjectID: "project-id",
+						Version:   "1.0.0",
+					},
+					entity.NewProjectGitSource("https://example.com/project.git", "v1.0.0", "ansible"),
+				).Return("", nil)
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusCreated, rec.Code)
+				assert.Equal(t, rec.Header().Get("Location"), "/projects/project-id")
+			},
+		},
+		{
+			desc: "Testing CreateProjectHandler.Handle responding with an error when the project stored in a git repository already exists and is returning a StatusConflict",
+			handler: NewCreateProjectHandler(
+				service.NewMockCreateProjectService(),
+				logger.NewFakeLogger(),
+			),
+			method: http.MethodPost,
+			path:   "/projects/project-id",
+			arrangeContextFunc: func(r *http.Request, w http.ResponseWriter) echo.Context {
+				var bodyBuffer bytes.Buffer
+
+				requestParameters := &request.ProjectParameters{
+					Format: entity.ProjectFormatPlain,
+					Git: &request.ProjectGitParameters{
+						Ref:          "v1.0.0",
+						Subdirectory: "ansible",
+						URL:          "https://example.com/project.git",
+					},
+					Storage: entity.ProjectTypeGit,
+					Version: "1.0.0",
+				}
+
+				requestParametersJSON, err := json.Marshal(requestParameters)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				multiparWriter := multipart.NewWriter(&bodyBuffer)
+				defer multiparWriter.Close()
+
+				multiparWriter.WriteField(RequestFormProjectMetadataFieldName, string(requestParametersJSON))
+
+				r = httptest.NewRequest(http.MethodPost, "/projects/project-id", &bodyBuffer)
+				r.Header.Set(echo.HeaderContentType, multiparWriter.FormDataContentType())
+
+				c := echo.New().NewContext(r, w)
+				c.SetParamNames("id")
+				c.SetParamValues("project-id")
+				return c
+			},
+			arrangeTestFunc: func(h *CreateProjectHandler) {
+				h.service.(*service.MockCreateProjectService).On(
+					"CreateFromGit",
+					&entity.CreateProjectRequest{
+						Mode:      entity.CreateProjectModeProject,
+						ProjectID: "project-id",
+						Version:   "1.0.0",
+					},
+					entity.NewProjectGitSource("https://example.com/project.git", "v1.0.0", "ansible"),
+				).Return("", domainerror.NewProjectAlreadyExistsError(fmt.Errorf("project already exists")))
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var body *response.ProjectErrorResponse
+				expectedBody := &response.ProjectErrorResponse{
+					Error:  fmt.Sprintf("%s: %s", ErrCreatingProject, "project already exists"),
+					Status: http.StatusConflict,
+				}
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				assert.Equal(t, expectedBody, body)
+				assert.Equal(t, http.StatusConflict, rec.Code)
+			},
+		},
+		{
+			desc: "Testing CreateProjectHandler.Handle request creating a project stored in an OCI registry success and it is returning a StatusCreated",
+			handler: NewCreateProjectHandler(
+				service.NewMockCreateProjectService(),
+				logger.NewFakeLogger(),
+			),
+			method: http.MethodPost,
+			path:   "/projects/project-id",
+			arrangeContextFunc: func(r *http.Request, w http.ResponseWriter) echo.Context {
+				var bodyBuffer bytes.Buffer
+
+				requestParameters := &request.ProjectParameters{
+					Format: entity.ProjectFormatOCI,
+					OCI: &request.ProjectOCIParameters{
+						Reference: "registry.example.com/project:v1.0.0",
+					},
+					Storage: entity.ProjectTypeOCI,
+					Version: "1.0.0",
+				}
+
+				requestParametersJSON, err := json.Marshal(requestParameters)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				multiparWriter := multipart.NewWriter(&bodyBuffer)
+				defer multiparWriter.Close()
+
+				multiparWriter.WriteField(RequestFormProjectMetadataFieldName, string(requestParametersJSON))
+
+				r = httptest.NewRequest(http.MethodPost, "/projects/project-id", &bodyBuffer)
+				r.Header.Set(echo.HeaderContentType, multiparWriter.FormDataContentType())
+
+				c := echo.New().NewContext(r, w)
+				c.SetParamNames("id")
+				c.SetParamValues("project-id")
+				return c
+			},
+			arrangeTestFunc: func(h *CreateProjectHandler) {
+				h.service.(*service.MockCreateProjectService).On(
+					"CreateFromOCI",
+					&entity.CreateProjectRequest{
+						Mode:      entity.CreateProjectModeProject,
+						ProjectID: "project-id",
+						Version:   "1.0.0",
+					},
+					entity.NewProjectOCISource("registry.example.com/project:v1.0.0", ""),
+				).Return("", nil)
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusCreated, rec.Code)
+				assert.Equal(t, rec.Header().Get("Location"), "/projects/project-id")
+			},
+		},
+		{
+			desc: "Testing CreateProjectHandler.Handle responding with an error when the reference of the project stored in an OCI registry cannot be resolved and is returning a StatusBadRequest",
+			handler: NewCreateProjectHandler(
+				service.NewMockCreateProjectService(),
+				logger.NewFakeLogger(),
+			),
+			method: http.MethodPost,
+			path:   "/projects/project-id",
+			arrangeContextFunc: func(r *http.Request, w http.ResponseWriter) echo.Context {
+				var bodyBuffer bytes.Buffer
+
+				requestParameters := &request.ProjectParameters{
+					Format: entity.ProjectFormatOCI,
+					OCI: &request.ProjectOCIParameters{
+						Reference: "registry.example.com/project:v1.0.0",
+					},
+					Storage: entity.ProjectTypeOCI,
+					Version: "1.0.0",
+				}
+
+				requestParametersJSON, err := json.Marshal(requestParameters)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				multiparWriter := multipart.NewWriter(&bodyBuffer)
+				defer multiparWriter.Close()
+
+				multiparWriter.WriteField(RequestFormProjectMetadataFieldName, string(requestParametersJSON))
+
+				r = httptest.NewRequest(http.MethodPost, "/projects/project-id", &bodyBuffer)
+				r.Header.Set(echo.HeaderContentType, multiparWriter.FormDataContentType())
+
+				c := echo.New().NewContext(r, w)
+				c.SetParamNames("id")
+				c.SetParamValues("project-id")
+				return c
+			},
+			arrangeTestFunc: func(h *CreateProjectHandler) {
+				h.service.(*service.MockCreateProjectService).On(
+					"CreateFromOCI",
+					&entity.CreateProjectRequest{
+						Mode:      entity.CreateProjectModeProject,
+						ProjectID: "project-id",
+						Version:   "1.0.0",
+					},
+					entity.NewProjectOCISource("registry.example.com/project:v1.0.0", ""),
+				).Return("", domainerror.NewProjectInvalidSourceError(fmt.Errorf("manifest not found")))
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var body *response.ProjectErrorResponse
+				expectedBody := &response.ProjectErrorResponse{
+					Error:  fmt.Sprintf("%s: %s", ErrCreatingProject, "manifest not found"),
+					Status: http.StatusBadRequest,
+				}
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				assert.Equal(t, expectedBody, body)
+				assert.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			desc: "Testing CreateProjectHandler.Handle request creating a project from an upload success and it is returning a StatusCreated",
+			handler: NewCreateProjectHandler(
+				service.NewMockCreateProjectService(),
+				logger.NewFakeLogger(),
+			),
+			method: http.MethodPost,
+			path:   "/projects/project-id",
+			arrangeContextFunc: func(r *http.Request, w http.ResponseWriter) echo.Context {
+				var bodyBuffer bytes.Buffer
+
+				requestParameters := &request.ProjectParameters{
+					Format:  entity.ProjectFormatTarGz,
+					Storage: entity.ProjectTypeLocal,
+					Upload:  "upload-id",
+					Version: "1.0.0",
+				}
+
+				requestParametersJSON, err := json.Marshal(requestParameters)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				multiparWriter := multipart.NewWriter(&bodyBuffer)
+				defer multiparWriter.Close()
+
+				multiparWriter.WriteField(RequestFormProjectMetadataFieldName, string(requestParametersJSON))
+
+				r = httptest.NewRequest(http.MethodPost, "/projects/project-id", &bodyBuffer)
+				r.Header.Set(echo.HeaderContentType, multiparWriter.FormDataContentType())
+
+				c := echo.New().NewContext(r, w)
+				c.SetParamNames("id")
+				c.SetParamValues("project-id")
+				return c
+			},
+			arrangeTestFunc: func(h *CreateProjectHandler) {
+				h.service.(*service.MockCreateProjectService).On(
+					"CreateFromUpload",
+					&entity.CreateProjectRequest{
+						Format:    entity.ProjectFormatTarGz,
+						Mode:      entity.CreateProjectModeProject,
+						ProjectID: "project-id",
+						Storage:   entity.ProjectTypeLocal,
+						Version:   "1.0.0",
+					},
+					"upload-id",
+				).Return("", nil)
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusCreated, rec.Code)
+				assert.Equal(t, rec.Header().Get("Location"), "/projects/project-id")
+			},
+		},
+		{
+			desc: "Testing CreateProjectHandler.Handle responding with an error when the upload is not complete and is returning a StatusConflict",
+			handler: NewCreateProjectHandler(
+				service.NewMockCreateProjectService(),
+				logger.NewFakeLogger(),
+			),
+			method: http.MethodPost,
+			path:   "/projects/project-id",
+			arrangeContextFunc: func(r *http.Request, w http.ResponseWriter) echo.Context {
+				var bodyBuffer bytes.Buffer
+
+				requestParameters := &request.ProjectParameters{
+					Format:  entity.ProjectFormatTarGz,
+					Storage: entity.ProjectTypeLocal,
+					Upload:  "upload-id",
+				}
+
+				requestParametersJSON, err := json.Marshal(requestParameters)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				multiparWriter := multipart.NewWriter(&bodyBuffer)
+				defer multiparWriter.Close()
+
+				multiparWriter.WriteField(RequestFormProjectMetadataFieldName, string(requestParametersJSON))
+
+				r = httptest.NewRequest(http.MethodPost, "/projects/project-id", &bodyBuffer)
+				r.Header.Set(echo.HeaderContentType, multiparWriter.FormDataContentType())
+
+				c := echo.New().NewContext(r, w)
+				c.SetParamNames("id")
+				c.SetParamValues("project-id")
+				return c
+			},
+			arrangeTestFunc: func(h *CreateProjectHandler) {
+				h.service.(*service.MockCreateProjectService).On(
+					"CreateFromUpload",
+					&entity.CreateProjectRequest{
+						Format:    entity.ProjectFormatTarGz,
+						Mode:      entity.CreateProjectModeProject,
+						ProjectID: "project-id",
+						Storage:   entity.ProjectTypeLocal,
+					},
+					"upload-id",
+				).Return("", domainerror.NewProjectUploadConflictError(fmt.Errorf("project upload is not complete")))
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var body *response.ProjectErrorResponse
+				expectedBody := &response.ProjectErrorResponse{
+					Error:  fmt.Sprintf("%s: %s", ErrCreatingProject, "project upload is not complete"),
+					Status: http.StatusConflict,
+				}
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				assert.Equal(t, expectedBody, body)
+				assert.Equal(t, http.StatusConflict, rec.Code)
+			},
+		},
+		{
+			desc: "Testing CreateProjectHandler.Handle responding with an error when the upload is not found and is returning a StatusNotFound",
+			handler: NewCreateProjectHandler(
+				service.NewMockCreateProjectService(),
+				logger.NewFakeLogger(),
+			),
+			method: http.MethodPost,
+			path:   "/projects/project-id",
+			arrangeContextFunc: func(r *http.Request, w http.ResponseWriter) echo.Context {
+				var bodyBuffer bytes.Buffer
+
+				requestParameters := &request.ProjectParameters{
+					Format:  entity.ProjectFormatTarGz,
+					Storage: entity.ProjectTypeLocal,
+					Upload:  "upload-id",
+				}
+
+				requestParametersJSON, err := json.Marshal(requestParameters)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				multiparWriter := multipart.NewWriter(&bodyBuffer)
+				defer multiparWriter.Close()
+
+				multiparWriter.WriteField(RequestFormProjectMetadataFieldName, string(requestParametersJSON))
+
+				r = httptest.NewRequest(http.MethodPost, "/projects/project-id", &bodyBuffer)
+				r.Header.Set(echo.HeaderContentType, multiparWriter.FormDataContentType())
+
+				c := echo.New().NewContext(r, w)
+				c.SetParamNames("id")
+				c.SetParamValues("project-id")
+				return c
+			},
+			arrangeTestFunc: func(h *CreateProjectHandler) {
+				h.service.(*service.MockCreateProjectService).On(
+					"CreateFromUpload",
+					&entity.CreateProjectRequest{
+						Format:    entity.ProjectFormatTarGz,
+						Mode:      entity.CreateProjectModeProject,
+						ProjectID: "project-id",
+						Storage:   entity.ProjectTypeLocal,
+					},
+					"upload-id",
+				).Return("", domainerror.NewProjectUploadNotFoundError(fmt.Errorf("project upload not found")))
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var body *response.ProjectErrorResponse
+				expectedBody := &response.ProjectErrorResponse{
+					Error:  fmt.Sprintf("%s: %s", ErrCreatingProject, "project upload not found"),
+					Status: http.StatusNotFound,
+				}
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				assert.Equal(t, expectedBody, body)
+				assert.Equal(t, http.StatusNotFound, rec.Code)
+			},
+		},
+		{
+			desc: "Testing CreateProjectHandler.Handle responding with an error when the request body exceeds the maximum upload size and is returning a StatusRequestEntityTooLarge",
+			handler: NewCreateProjectHandler(
+				service.NewMockCreateProjectService(),
+				logger.NewFakeLogger(),
+			).WithMaxUploadSize(1),
+			method: http.MethodPost,
+			path:   "/projects/project-id",
+			arrangeContextFunc: func(r *http.Request, w http.ResponseWriter) echo.Context {
+				var bodyBuffer bytes.Buffer
+
+				requestParameters := &request.ProjectParameters{
+					Format:  entity.ProjectFormatTarGz,
+					Storage: entity.ProjectTypeLocal,
+				}
+
+				requestParametersJSON, err := json.Marshal(requestParameters)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				multiparWriter := multipart.NewWriter(&bodyBuffer)
+				multiparWriter.WriteField(RequestFormProjectMetadataFieldName, string(requestParametersJSON))
+
+				part, err := multiparWriter.CreateFormFile(RequestFormProjectFileFieldeName, "project.tar.gz")
+				if err != nil {
+					t.Fatal(err)
+				}
+				_, err = part.Write(bytes.Repeat([]byte("a"), multipartFormOverhead+1))
+				if err != nil {
+					t.Fatal(err)
+				}
+				multiparWriter.Close()
+
+				r = httptest.NewRequest(http.MethodPost, "/projects/project-id", &bodyBuffer)
+				r.Header.Set(echo.HeaderContentType, multiparWriter.FormDataContentType())
+
+				c := echo.New().NewContext(r, w)
+				c.SetParamNames("id")
+				c.SetParamValues("project-id")
+
+				return c
+			},
+			arrangeTestFunc: func(h *CreateProjectHandler) {},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var body *response.ProjectErrorResponse
+				expectedBody := &response.ProjectErrorResponse{
+					Error:  fmt.Sprintf("%s: %s", ErrProjectRequestTooLarge, "http: request body too large"),
+					Status: http.StatusRequestEntityTooLarge,
+				}
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				assert.Equal(t, expectedBody, body)
+				assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
 			},
 		},
 	}
@@ -0,0 +1,93 @@
+package project
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	"github.com/apenella/ransidble/internal/domain/core/mapper"
+	"github.com/apenella/ransidble/internal/domain/core/model/request"
+	"github.com/apenella/ransidble/internal/domain/core/model/response"
+	"github.com/apenella/ransidble/internal/domain/ports/repository"
+	"github.com/apenella/ransidble/internal/domain/ports/service"
+	"github.com/labstack/echo/v4"
+)
+
+// CreateProjectUploadHandler handles the request to open a resumable upload of a project source code
+type CreateProjectUploadHandler struct {
+	service service.ProjectUploadServicer
+	logger  repository.Logger
+}
+
+// NewCreateProjectUploadHandler creates a new CreateProjectUploadHandler
+func NewCreateProjectUploadHandler(service service.ProjectUploadServicer, logger repository.Logger) *CreateProjectUploadHandler {
+	return &CreateProjectUploadHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// Handle method to open a resumable upload of a project source code
+func (h *CreateProjectUploadHandler) Handle(c echo.Context) error {
+	var err error
+	var errorMsg string
+	var errorResponse *response.ProjectErrorResponse
+	var requestParameters request.ProjectUploadParameters
+	var upload *entity.ProjectUpload
+
+	if h.service == nil {
+		errorResponse = &response.ProjectErrorResponse{
+			Error:  ErrProjectUploadServiceNotInitialized,
+			Status: http.StatusInternalServerError,
+		}
+		h.logger.Error(
+			ErrProjectUploadServiceNotInitialized,
+			map[string]interface{}{
+				"component": "CreateProjectUploadHandler.Handle",
+				"package":   "github.com/apenella/ransidble/internal/handler/http/project",
+			})
+		return c.JSON(http.StatusInternalServerError, errorResponse)
+	}
+
+	err = c.Bind(&requestParameters)
+	if err != nil {
+		errorMsg = fmt.Sprintf("%s: %s", ErrBindingProjectUploadParameters, err.Error())
+		errorResponse = &response.ProjectErrorResponse{
+			Error:  errorMsg,
+			Status: http.StatusBadRequest,
+		}
+		h.logger.Error(
+			errorMsg,
+			map[string]interface{}{
+				"component": "CreateProjectUploadHandler.Handle",
+				"package":   "github.com/apenella/ransidble/internal/handler/http/project",
+			})
+		return c.JSON(http.StatusBadRequest, errorResponse)
+	}
+
+	err = requestParameters.Validate()
+	if err != nil {
+		errorMsg = fmt.Sprintf("%s: %s", ErrInvalidProjectUploadParameters, err.Error())
+		errorResponse = &response.ProjectErrorResponse{
+			Error:  errorMsg,
+			Status: http.StatusBadRequest,
+		}
+		h.logger.Error(
+			errorMsg,
+			map[string]interface{}{
+				"component": "CreateProjectUploadHandler.Handle",
+				"package":   "github.com/apenella/ransidble/internal/handler/http/project",
+			})
+		return c.JSON(http.StatusBadRequest, errorResponse)
+	}
+
+	upload, err = h.service.CreateUpload(requestParameters.Length)
+	if err != nil {
+		return projectUploadErrorResponse(c, h.logger, "CreateProjectUploadHandler.Handle", ErrCreatingProjectUpload, "", err)
+	}
+
+	setProjectUploadHeaders(c, upload)
+	c.Response().Header().Set(echo.HeaderLocation, projectUploadLocation(upload.ID))
+
+	return c.JSON(http.StatusCreated, mapper.NewProjectMapper().ToProjectUploadResponse(upload))
+}
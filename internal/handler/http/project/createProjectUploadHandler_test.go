@@ -0,0 +1,185 @@
+package project
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	domainerror "github.com/apenella/ransidble/internal/domain/core/error"
+	"github.com/apenella/ransidble/internal/domain/core/model/response"
+	"github.com/apenella/ransidble/internal/domain/ports/service"
+	"github.com/apenella/ransidble/internal/infrastructure/logger"
+	"github.com/apenella/ransidble/test/openapi"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandle_CreateProjectUploadHandler(t *testing.T) {
+	openAPIValidator, err := openapi.PrepareOpenAPIValidator("../../../../api/openapi.yaml")
+	if err != nil {
+		t.Errorf("Error initializing OpenAPI validator: %s", err)
+		t.FailNow()
+		return
+	}
+
+	tests := []struct {
+		desc            string
+		handler         *CreateProjectUploadHandler
+		body            string
+		arrangeTestFunc func(t *testing.T, h *CreateProjectUploadHandler)
+		assertTestFunc  func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			desc: "Testing CreateProjectUploadHandler.Handle responding with an error when service not initialized and is returning an StatusInternalServerError",
+			handler: NewCreateProjectUploadHandler(
+				nil,
+				logger.NewFakeLogger(),
+			),
+			body: `{"length": 1024}`,
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var body *response.ProjectErrorResponse
+				expectedBody := &response.ProjectErrorResponse{
+					Error:  ErrProjectUploadServiceNotInitialized,
+					Status: http.StatusInternalServerError,
+				}
+
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				assert.Equal(t, expectedBody, body)
+				assert.Equal(t, http.StatusInternalServerError, rec.Code)
+			},
+		},
+		{
+			desc: "Testing CreateProjectUploadHandler.Handle responding with an error when the length is not provided and is returning an StatusBadRequest",
+			handler: NewCreateProjectUploadHandler(
+				service.NewMockProjectUploadService(),
+				logger.NewFakeLogger(),
+			),
+			body: `{}`,
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var body *response.ProjectErrorResponse
+
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				assert.Contains(t, body.Error, ErrInvalidProjectUploadParameters)
+				assert.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			desc: "Testing CreateProjectUploadHandler.Handle responding with an error when the request body can not be bound and is returning an StatusBadRequest",
+			handler: NewCreateProjectUploadHandler(
+				service.NewMockProjectUploadService(),
+				logger.NewFakeLogger(),
+			),
+			body: `{"length": "large"}`,
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var body *response.ProjectErrorResponse
+
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				assert.Contains(t, body.Error, ErrBindingProjectUploadParameters)
+				assert.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			desc: "Testing CreateProjectUploadHandler.Handle responding with an error when the length exceeds the maximum upload size and is returning an StatusRequestEntityTooLarge",
+			handler: NewCreateProjectUploadHandler(
+				service.NewMockProjectUploadService(),
+				logger.NewFakeLogger(),
+			),
+			body: `{"length": 1024}`,
+			arrangeTestFunc: func(t *testing.T, h *CreateProjectUploadHandler) {
+				h.service.(*service.MockProjectUploadService).On(
+					"CreateUpload",
+					int64(1024),
+				).Return(
+					nil,
+					domainerror.NewProjectTooLargeError(fmt.Errorf("project exceeds the maximum upload size")),
+				)
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var body *response.ProjectErrorResponse
+				expectedBody := &response.ProjectErrorResponse{
+					Error:  fmt.Sprintf("%s: %s", ErrCreatingProjectUpload, "project exceeds the maximum upload size"),
+					Status: http.StatusRequestEntityTooLarge,
+				}
+
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				assert.Equal(t, expectedBody, body)
+				assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+			},
+		},
+		{
+			desc: "Testing CreateProjectUploadHandler.Handle opening an upload and is returning an StatusCreated",
+			handler: NewCreateProjectUploadHandler(
+				service.NewMockProjectUploadService(),
+				logger.NewFakeLogger(),
+			),
+			body: `{"length": 1024}`,
+			arrangeTestFunc: func(t *testing.T, h *CreateProjectUploadHandler) {
+				h.service.(*service.MockProjectUploadService).On(
+					"CreateUpload",
+					int64(1024),
+				).Return(
+					&entity.ProjectUpload{
+						CreatedAt: "2026-01-05T10:00:00Z",
+						ExpiresAt: "2026-01-06T10:00:00Z",
+						ID:        "upload-id",
+						Length:    1024,
+						UpdatedAt: "2026-01-05T10:00:00Z",
+					},
+					nil,
+				)
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var body *response.ProjectUploadResponse
+				expectedBody := &response.ProjectUploadResponse{
+					CreatedAt: "2026-01-05T10:00:00Z",
+					ExpiresAt: "2026-01-06T10:00:00Z",
+					ID:        "upload-id",
+					Length:    1024,
+					UpdatedAt: "2026-01-05T10:00:00Z",
+				}
+
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				assert.Equal(t, expectedBody, body)
+				assert.Equal(t, http.StatusCreated, rec.Code)
+				assert.Equal(t, "/uploads/upload-id", rec.Header().Get("Location"))
+				assert.Equal(t, "0", rec.Header().Get(HeaderUploadOffset))
+				assert.Equal(t, "1024", rec.Header().Get(HeaderUploadLength))
+				assert.Equal(t, "Tue, 06 Jan 2026 10:00:00 GMT", rec.Header().Get(HeaderUploadExpires))
+			},
+		},
+	}
+
+	for _, test := range tests {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/uploads", strings.NewReader(test.body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		context := echo.New().NewContext(req, rec)
+
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.arrangeTestFunc != nil {
+				test.arrangeTestFunc(t, test.handler)
+			}
+
+			err := test.handler.Handle(context)
+			assert.NoError(t, err)
+
+			test.assertTestFunc(t, rec)
+		})
+
+		t.Run(fmt.Sprintf("OpenAPI %s", test.desc), func(t *testing.T) {
+			err := openAPIValidator.ValidateResponse(rec.Body.Bytes(), req, rec.Code, rec.Header())
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package project
+
+import (
+	"github.com/apenella/ransidble/internal/domain/ports/repository"
+	"github.com/apenella/ransidble/internal/domain/ports/service"
+	"github.com/labstack/echo/v4"
+)
+
+// CreateProjectVersionHandler handles the request to create a new version of an existing project
+type CreateProjectVersionHandler struct {
+	handler *CreateProjectHandler
+}
+
+// NewCreateProjectVersionHandler creates a new CreateProjectVersionHandler
+func NewCreateProjectVersionHandler(service service.CreateProjectServicer, logger repository.Logger) *CreateProjectVersionHandler {
+	return &CreateProjectVersionHandler{
+		handler: NewCreateProjectHandler(service, logger),
+	}
+}
+
+// WithMaxUploadSize sets the maximum size, in bytes, of the uploaded source code
+func (h *CreateProjectVersionHandler) WithMaxUploadSize(size int64) *CreateProjectVersionHandler {
+	h.handler.WithMaxUploadSize(size)
+	return h
+}
+
+// Handle method to create a new version of a project. The request is the same multipart form used to create a project, where the version is required
+func (h *CreateProjectVersionHandler) Handle(c echo.Context) error {
+	return h.handler.create(c, createProjectVersionRequest)
+}
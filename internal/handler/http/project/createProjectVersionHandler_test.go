@@ -0,0 +1,211 @@
+package project
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	domainerror "github.com/apenella/ransidble/internal/domain/core/error"
+	"github.com/apenella/ransidble/internal/domain/core/model/request"
+	"github.com/apenella/ransidble/internal/domain/core/model/response"
+	"github.com/apenella/ransidble/internal/domain/ports/service"
+	"github.com/apenella/ransidble/internal/infrastructure/logger"
+	"github.com/apenella/ransidble/test/openapi"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// newProjectVersionContext creates the context of a request to create a project version, attaching the project file when it is provided
+func newProjectVersionContext(t *testing.T, w http.ResponseWriter, parameters *request.ProjectParameters, file string) echo.Context {
+	var bodyBuffer bytes.Buffer
+
+	requestParametersJSON, err := json.Marshal(parameters)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	multiparWriter := multipart.NewWriter(&bodyBuffer)
+	multiparWriter.WriteField(RequestFormProjectMetadataFieldName, string(requestParametersJSON))
+
+	if file != "" {
+		part, err := multiparWriter.CreateFormFile(RequestFormProjectFileFieldeName, "project.tar.gz")
+		if err != nil {
+			t.Fatal(err)
+		}
+		part.Write([]byte(file))
+	}
+	multiparWriter.Close()
+
+	r := httptest.NewRequest(http.MethodPost, "/projects/project-id/versions", &bodyBuffer)
+	r.Header.Set(echo.HeaderContentType, multiparWriter.FormDataContentType())
+
+	c := echo.New().NewContext(r, w)
+	c.SetParamNames("id")
+	c.SetParamValues("project-id")
+	return c
+}
+
+func TestHandle_CreateProjectVersionHandler(t *testing.T) {
+
+	openAPIValidator, err := openapi.PrepareOpenAPIValidator("../../../../api/openapi.yaml")
+	if err != nil {
+		t.Errorf("Error initializing OpenAPI validator: %s", err)
+		t.FailNow()
+		return
+	}
+
+	tests := []struct {
+		desc               string
+		handler            *CreateProjectVersionHandler
+		arrangeContextFunc func(w http.ResponseWriter) echo.Context
+		arrangeTestFunc    func(h *CreateProjectVersionHandler)
+		assertTestFunc     func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			desc:    "Testing CreateProjectVersionHandler.Handle request success and it is returning a StatusCreated",
+			handler: NewCreateProjectVersionHandler(service.NewMockCreateProjectService(), logger.NewFakeLogger()),
+			arrangeContextFunc: func(w http.ResponseWriter) echo.Context {
+				return newProjectVersionContext(t, w, &request.ProjectParameters{
+					Format:  entity.ProjectFormatTarGz,
+					Storage: entity.ProjectTypeLocal,
+					Version: "2.0.0",
+				}, "project-content")
+			},
+			arrangeTestFunc: func(h *CreateProjectVersionHandler) {
+				h.handler.service.(*service.MockCreateProjectService).On(
+					"Create",
+					&entity.CreateProjectRequest{
+						Format:    entity.ProjectFormatTarGz,
+						Mode:      entity.CreateProjectModeVersion,
+						ProjectID: "project-id",
+						Storage:   entity.ProjectTypeLocal,
+						Version:   "2.0.0",
+					},
+					mock.Anything,
+				).Return("", nil)
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusCreated, rec.Code)
+				assert.Equal(t, "/projects/project-id/versions", rec.Header().Get("Location"))
+			},
+		},
+		{
+			desc:    "Testing CreateProjectVersionHandler.Handle request creating a version of a project stored in a git repository success and it is returning a StatusCreated",
+			handler: NewCreateProjectVersionHandler(service.NewMockCreateProjectService(), logger.NewFakeLogger()),
+			arrangeContextFunc: func(w http.ResponseWriter) echo.Context {
+				return newProjectVersionContext(t, w, &request.ProjectParameters{
+					Format:  entity.ProjectFormatPlain,
+					Git:     &request.ProjectGitParameters{Ref: "v2.0.0", URL: "https://example.com/project.git"},
+					Storage: entity.ProjectTypeGit,
+					Version: "2.0.0",
+				}, "")
+			},
+			arrangeTestFunc: func(h *CreateProjectVersionHandler) {
+				h.handler.service.(*service.MockCreateProjectService).On(
+					"CreateFromGit",
+					&entity.CreateProjectRequest{
+						Mode:      entity.CreateProjectModeVersion,
+						ProjectID: "project-id",
+						Version:   "2.0.0",
+					},
+					entity.NewProjectGitSource("https://example.com/project.git", "v2.0.0", ""),
+				).Return("", nil)
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusCreated, rec.Code)
+				assert.Equal(t, "/projects/project-id/versions", rec.Header().Get("Location"))
+			},
+		},
+		{
+			desc:    "Testing CreateProjectVersionHandler.Handle responding with an error when the project does not exist and is returning a StatusNotFound",
+			handler: NewCreateProjectVersionHandler(service.NewMockCreateProjectService(), logger.NewFakeLogger()),
+			arrangeContextFunc: func(w http.ResponseWriter) echo.Context {
+				return newProjectVersionContext(t, w, &request.ProjectParameters{
+					Format:  entity.ProjectFormatTarGz,
+					Storage: entity.ProjectTypeLocal,
+					Version: "2.0.0",
+				}, "project-content")
+			},
+			arrangeTestFunc: func(h *CreateProjectVersionHandler) {
+				h.handler.service.(*service.MockCreateProjectService).On("Create", mock.Anything, mock.Anything).
+					Return("", domainerror.NewProjectNotFoundError(fmt.Errorf("project not found")))
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var body *response.ProjectErrorResponse
+				expectedBody := &response.ProjectErrorResponse{
+					Error:  fmt.Sprintf("%s: %s", ErrCreatingProject, "project not found"),
+					Status: http.StatusNotFound,
+				}
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				assert.Equal(t, expectedBody, body)
+				assert.Equal(t, http.StatusNotFound, rec.Code)
+			},
+		},
+		{
+			desc:    "Testing CreateProjectVersionHandler.Handle responding with an error when the version is not valid and is returning a StatusBadRequest",
+			handler: NewCreateProjectVersionHandler(service.NewMockCreateProjectService(), logger.NewFakeLogger()),
+			arrangeContextFunc: func(w http.ResponseWriter) echo.Context {
+				return newProjectVersionContext(t, w, &request.ProjectParameters{
+					Format:  entity.ProjectFormatTarGz,
+					Storage: entity.ProjectTypeLocal,
+					Version: entity.LatestVersion,
+				}, "project-content")
+			},
+			arrangeTestFunc: func(h *CreateProjectVersionHandler) {
+				h.handler.service.(*service.MockCreateProjectService).On("Create", mock.Anything, mock.Anything).
+					Return("", domainerror.NewProjectInvalidVersionError(fmt.Errorf("invalid project version")))
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			desc:    "Testing CreateProjectVersionHandler.Handle responding with an error when the version already exists and is returning a StatusConflict",
+			handler: NewCreateProjectVersionHandler(service.NewMockCreateProjectService(), logger.NewFakeLogger()),
+			arrangeContextFunc: func(w http.ResponseWriter) echo.Context {
+				return newProjectVersionContext(t, w, &request.ProjectParameters{
+					Format:  entity.ProjectFormatTarGz,
+					Storage: entity.ProjectTypeLocal,
+					Version: "1.0.0",
+				}, "project-content")
+			},
+			arrangeTestFunc: func(h *CreateProjectVersionHandler) {
+				h.handler.service.(*service.MockCreateProjectService).On("Create", mock.Anything, mock.Anything).
+					Return("", domainerror.NewProjectAlreadyExistsError(fmt.Errorf("project version already exists")))
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusConflict, rec.Code)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/projects/project-id/versions", nil)
+		context := test.arrangeContextFunc(rec)
+
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.arrangeTestFunc != nil {
+				test.arrangeTestFunc(test.handler)
+			}
+
+			err := test.handler.Handle(context)
+			assert.NoError(t, err)
+			test.assertTestFunc(t, rec)
+		})
+
+		t.Run(fmt.Sprintf("OpenAPI %s", test.desc), func(t *testing.T) {
+			err := openAPIValidator.ValidateResponse(rec.Body.Bytes(), req, rec.Code, rec.Header())
+			assert.NoError(t, err)
+		})
+	}
+}
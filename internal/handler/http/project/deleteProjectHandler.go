@@ -1,8 +1,10 @@
 package project
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 
 	domainerror "github.com/apenella/ransidble/internal/domain/core/error"
 	"github.com/apenella/ransidble/internal/domain/core/model/response"
@@ -11,6 +13,11 @@ import (
 	"github.com/labstack/echo/v4"
 )
 
+const (
+	// ProjectForceQueryParam is the query parameter to delete a project cancelling the tasks that are not finished
+	ProjectForceQueryParam = "force"
+)
+
 // DeleteProjectHandler is the HTTP handler for deleting a project.
 type DeleteProjectHandler struct {
 	service service.DeleteProjectServicer
@@ -30,8 +37,11 @@ func (h *DeleteProjectHandler) Handle(c echo.Context) error {
 	var err error
 	var errorMsg string
 	var errorResponse *response.ProjectErrorResponse
+	var force bool
+	var projectConflictErr *domainerror.ProjectConflictError
 	var projectErrorResponseStatus int
 	var projectID string
+	var projectNotFoundErr *domainerror.ProjectNotFoundError
 
 	if h.service == nil {
 		errorResponse = &response.ProjectErrorResponse{
@@ -63,12 +73,33 @@ func (h *DeleteProjectHandler) Handle(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, errorResponse)
 	}
 
-	err = h.service.Delete(projectID)
+	if value := c.QueryParam(ProjectForceQueryParam); value != "" {
+		force, err = strconv.ParseBool(value)
+		if err != nil {
+			errorMsg = fmt.Sprintf("%s: %s", ErrInvalidProjectForceQueryParameter, err.Error())
+			errorResponse = &response.ProjectErrorResponse{
+				Error:  errorMsg,
+				Status: http.StatusBadRequest,
+			}
+			h.logger.Error(
+				errorMsg,
+				map[string]interface{}{
+					"component":  "DeleteProjectHandler.Handle",
+					"package":    "github.com/apenella/ransidble/internal/handler/http/project",
+					"project_id": projectID,
+				})
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+	}
+
+	err = h.service.Delete(projectID, force)
 	if err != nil {
-		if _, ok := err.(*domainerror.ProjectNotFoundError); ok {
+		projectErrorResponseStatus = http.StatusInternalServerError
+		switch {
+		case errors.As(err, &projectNotFoundErr):
 			projectErrorResponseStatus = http.StatusNotFound
-		} else {
-			projectErrorResponseStatus = http.StatusInternalServerError
+		case errors.As(err, &projectConflictErr):
+			projectErrorResponseStatus = http.StatusConflict
 		}
 		errorMsg = fmt.Sprintf("%s: %s", ErrDeletingProject, err.Error())
 		errorResponse = &response.ProjectErrorResponse{
@@ -80,6 +111,7 @@ func (h *DeleteProjectHandler) Handle(c echo.Context) error {
 			map[string]interface{}{
 				"component":  "DeleteProjectHandler.Handle",
 				"package":    "github.com/apenella/ransidble/internal/handler/http/project",
+				"force":      force,
 				"project_id": projectID,
 			})
 		return c.JSON(projectErrorResponseStatus, errorResponse)
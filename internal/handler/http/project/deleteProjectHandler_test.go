@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	domainerror "github.com/apenella/ransidble/internal/domain/core/error"
@@ -96,6 +97,7 @@ func TestHandle_DeleteProjectHandler(t *testing.T) {
 				h.service.(*service.MockDeleteProjectService).On(
 					"Delete",
 					"test-id",
+					false,
 				).Return(
 					fmt.Errorf("project not found"),
 				)
@@ -130,6 +132,7 @@ func TestHandle_DeleteProjectHandler(t *testing.T) {
 				h.service.(*service.MockDeleteProjectService).On(
 					"Delete",
 					"test-id",
+					false,
 				).Return(
 					domainerror.NewProjectNotFoundError(fmt.Errorf("project not found")),
 				)
@@ -138,6 +141,90 @@ func TestHandle_DeleteProjectHandler(t *testing.T) {
 				assert.Equal(t, http.StatusNotFound, rec.Code)
 			},
 		},
+		{
+			desc: "Testing DeleteProjectHandler.Handle responding with an error when the force query parameter is not valid and is returning an StatusBadRequest",
+			handler: NewDeleteProjectHandler(
+				service.NewMockDeleteProjectService(),
+				logger.NewFakeLogger(),
+			),
+			path: "/projects/test-id?force=maybe",
+			arrangeContextFunc: func(r *http.Request, w http.ResponseWriter) echo.Context {
+				c := echo.New().NewContext(r, w)
+				c.SetParamNames("id")
+				c.SetParamValues("test-id")
+				return c
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var body *response.ProjectErrorResponse
+
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				assert.True(t, strings.HasPrefix(body.Error, ErrInvalidProjectForceQueryParameter))
+				assert.Equal(t, http.StatusBadRequest, body.Status)
+				assert.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			desc: "Testing DeleteProjectHandler.Handle responding with an error when project has tasks that are not finished and is returning an StatusConflict",
+			handler: NewDeleteProjectHandler(
+				service.NewMockDeleteProjectService(),
+				logger.NewFakeLogger(),
+			),
+			path: "/projects/test-id",
+			arrangeContextFunc: func(r *http.Request, w http.ResponseWriter) echo.Context {
+				c := echo.New().NewContext(r, w)
+				c.SetParamNames("id")
+				c.SetParamValues("test-id")
+				return c
+			},
+			arrangeTestFunc: func(t *testing.T, h *DeleteProjectHandler) {
+				h.service.(*service.MockDeleteProjectService).On(
+					"Delete",
+					"test-id",
+					false,
+				).Return(
+					domainerror.NewProjectConflictError(fmt.Errorf("project has tasks that are not finished")),
+				)
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var body *response.ProjectErrorResponse
+				expectedBody := &response.ProjectErrorResponse{
+					Error:  fmt.Sprintf("%s: %s", ErrDeletingProject, "project has tasks that are not finished"),
+					Status: http.StatusConflict,
+				}
+
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				assert.Equal(t, expectedBody, body)
+				assert.Equal(t, http.StatusConflict, rec.Code)
+			},
+		},
+		{
+			desc: "Testing DeleteProjectHandler.Handle responding with no content when project is forcibly deleted successfully and is returning an StatusNoContent",
+			handler: NewDeleteProjectHandler(
+				service.NewMockDeleteProjectService(),
+				logger.NewFakeLogger(),
+			),
+			path: "/projects/test-id?force=true",
+			arrangeContextFunc: func(r *http.Request, w http.ResponseWriter) echo.Context {
+				c := echo.New().NewContext(r, w)
+				c.SetParamNames("id")
+				c.SetParamValues("test-id")
+				return c
+			},
+			arrangeTestFunc: func(t *testing.T, h *DeleteProjectHandler) {
+				h.service.(*service.MockDeleteProjectService).On(
+					"Delete",
+					"test-id",
+					true,
+				).Return(
+					nil,
+				)
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusNoContent, rec.Code)
+			},
+		},
 		{
 			desc: "Testing DeleteProjectHandler.Handle responding with no content when project is deleted successfully and is returning an StatusNoContent",
 			handler: NewDeleteProjectHandler(
@@ -155,6 +242,7 @@ func TestHandle_DeleteProjectHandler(t *testing.T) {
 				h.service.(*service.MockDeleteProjectService).On(
 					"Delete",
 					"test-id",
+					false,
 				).Return(
 					nil,
 				)
@@ -0,0 +1,67 @@
+package project
+
+import (
+	"net/http"
+
+	"github.com/apenella/ransidble/internal/domain/core/model/response"
+	"github.com/apenella/ransidble/internal/domain/ports/repository"
+	"github.com/apenella/ransidble/internal/domain/ports/service"
+	"github.com/labstack/echo/v4"
+)
+
+// DeleteProjectUploadHandler handles the request to abandon a project upload
+type DeleteProjectUploadHandler struct {
+	service service.ProjectUploadServicer
+	logger  repository.Logger
+}
+
+// NewDeleteProjectUploadHandler creates a new DeleteProjectUploadHandler
+func NewDeleteProjectUploadHandler(service service.ProjectUploadServicer, logger repository.Logger) *DeleteProjectUploadHandler {
+	return &DeleteProjectUploadHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// Handle method to delete a project upload along with the bytes already received
+func (h *DeleteProjectUploadHandler) Handle(c echo.Context) error {
+	var err error
+	var errorResponse *response.ProjectErrorResponse
+	var uploadID string
+
+	if h.service == nil {
+		errorResponse = &response.ProjectErrorResponse{
+			Error:  ErrProjectUploadServiceNotInitialized,
+			Status: http.StatusInternalServerError,
+		}
+		h.logger.Error(
+			ErrProjectUploadServiceNotInitialized,
+			map[string]interface{}{
+				"component": "DeleteProjectUploadHandler.Handle",
+				"package":   "github.com/apenella/ransidble/internal/handler/http/project",
+			})
+		return c.JSON(http.StatusInternalServerError, errorResponse)
+	}
+
+	uploadID = c.Param("id")
+	if uploadID == "" {
+		errorResponse = &response.ProjectErrorResponse{
+			Error:  ErrProjectUploadIDNotProvided,
+			Status: http.StatusBadRequest,
+		}
+		h.logger.Error(
+			ErrProjectUploadIDNotProvided,
+			map[string]interface{}{
+				"component": "DeleteProjectUploadHandler.Handle",
+				"package":   "github.com/apenella/ransidble/internal/handler/http/project",
+			})
+		return c.JSON(http.StatusBadRequest, errorResponse)
+	}
+
+	err = h.service.DeleteUpload(uploadID)
+	if err != nil {
+		return projectUploadErrorResponse(c, h.logger, "DeleteProjectUploadHandler.Handle", ErrDeletingProjectUpload, uploadID, err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,182 @@
+package project
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	domainerror "github.com/apenella/ransidble/internal/domain/core/error"
+	"github.com/apenella/ransidble/internal/domain/core/model/response"
+	"github.com/apenella/ransidble/internal/domain/ports/service"
+	"github.com/apenella/ransidble/internal/infrastructure/logger"
+	"github.com/apenella/ransidble/test/openapi"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandle_DeleteProjectUploadHandler(t *testing.T) {
+	openAPIValidator, err := openapi.PrepareOpenAPIValidator("../../../../api/openapi.yaml")
+	if err != nil {
+		t.Errorf("Error initializing OpenAPI validator: %s", err)
+		t.FailNow()
+		return
+	}
+
+	tests := []struct {
+		desc               string
+		handler            *DeleteProjectUploadHandler
+		arrangeContextFunc func(r *http.Request, w http.ResponseWriter) echo.Context
+		arrangeTestFunc    func(t *testing.T, h *DeleteProjectUploadHandler)
+		assertTestFunc     func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			desc: "Testing DeleteProjectUploadHandler.Handle responding with an error when service not initialized and is returning an StatusInternalServerError",
+			handler: NewDeleteProjectUploadHandler(
+				nil,
+				logger.NewFakeLogger(),
+			),
+			arrangeContextFunc: func(r *http.Request, w http.ResponseWriter) echo.Context {
+				return echo.New().NewContext(r, w)
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var body *response.ProjectErrorResponse
+				expectedBody := &response.ProjectErrorResponse{
+					Error:  ErrProjectUploadServiceNotInitialized,
+					Status: http.StatusInternalServerError,
+				}
+
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				assert.Equal(t, expectedBody, body)
+				assert.Equal(t, http.StatusInternalServerError, rec.Code)
+			},
+		},
+		{
+			desc: "Testing DeleteProjectUploadHandler.Handle responding with an error when upload id not provided and is returning an StatusBadRequest",
+			handler: NewDeleteProjectUploadHandler(
+				service.NewMockProjectUploadService(),
+				logger.NewFakeLogger(),
+			),
+			arrangeContextFunc: func(r *http.Request, w http.ResponseWriter) echo.Context {
+				return echo.New().NewContext(r, w)
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var body *response.ProjectErrorResponse
+				expectedBody := &response.ProjectErrorResponse{
+					Error:  ErrProjectUploadIDNotProvided,
+					Status: http.StatusBadRequest,
+				}
+
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				assert.Equal(t, expectedBody, body)
+				assert.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			desc: "Testing DeleteProjectUploadHandler.Handle responding with an error when the upload is receiving a chunk and is returning an StatusConflict",
+			handler: NewDeleteProjectUploadHandler(
+				service.NewMockProjectUploadService(),
+				logger.NewFakeLogger(),
+			),
+			arrangeContextFunc: func(r *http.Request, w http.ResponseWriter) echo.Context {
+				c := echo.New().NewContext(r, w)
+				c.SetParamNames("id")
+				c.SetParamValues("upload-id")
+				return c
+			},
+			arrangeTestFunc: func(t *testing.T, h *DeleteProjectUploadHandler) {
+				h.service.(*service.MockProjectUploadService).On(
+					"DeleteUpload",
+					"upload-id",
+				).Return(
+					domainerror.NewProjectUploadConflictError(fmt.Errorf("project upload is receiving another chunk")),
+				)
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var body *response.ProjectErrorResponse
+				expectedBody := &response.ProjectErrorResponse{
+					Error:  fmt.Sprintf("%s: %s", ErrDeletingProjectUpload, "project upload is receiving another chunk"),
+					Status: http.StatusConflict,
+				}
+
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				assert.Equal(t, expectedBody, body)
+				assert.Equal(t, http.StatusConflict, rec.Code)
+			},
+		},
+		{
+			desc: "Testing DeleteProjectUploadHandler.Handle responding with an error when the upload is not found and is returning an StatusNotFound",
+			handler: NewDeleteProjectUploadHandler(
+				service.NewMockProjectUploadService(),
+				logger.NewFakeLogger(),
+			),
+			arrangeContextFunc: func(r *http.Request, w http.ResponseWriter) echo.Context {
+				c := echo.New().NewContext(r, w)
+				c.SetParamNames("id")
+				c.SetParamValues("upload-id")
+				return c
+			},
+			arrangeTestFunc: func(t *testing.T, h *DeleteProjectUploadHandler) {
+				h.service.(*service.MockProjectUploadService).On(
+					"DeleteUpload",
+					"upload-id",
+				).Return(
+					domainerror.NewProjectUploadNotFoundError(fmt.Errorf("project upload not found")),
+				)
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusNotFound, rec.Code)
+			},
+		},
+		{
+			desc: "Testing DeleteProjectUploadHandler.Handle deleting an upload and is returning an StatusNoContent",
+			handler: NewDeleteProjectUploadHandler(
+				service.NewMockProjectUploadService(),
+				logger.NewFakeLogger(),
+			),
+			arrangeContextFunc: func(r *http.Request, w http.ResponseWriter) echo.Context {
+				c := echo.New().NewContext(r, w)
+				c.SetParamNames("id")
+				c.SetParamValues("upload-id")
+				return c
+			},
+			arrangeTestFunc: func(t *testing.T, h *DeleteProjectUploadHandler) {
+				h.service.(*service.MockProjectUploadService).On(
+					"DeleteUpload",
+					"upload-id",
+				).Return(nil)
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusNoContent, rec.Code)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodDelete, "/uploads/upload-id", nil)
+		context := test.arrangeContextFunc(req, rec)
+
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.arrangeTestFunc != nil {
+				test.arrangeTestFunc(t, test.handler)
+			}
+
+			err := test.handler.Handle(context)
+			assert.NoError(t, err)
+
+			test.assertTestFunc(t, rec)
+		})
+
+		t.Run(fmt.Sprintf("OpenAPI %s", test.desc), func(t *testing.T) {
+			err := openAPIValidator.ValidateResponse(rec.Body.Bytes(), req, rec.Code, rec.Header())
+			assert.NoError(t, err)
+		})
+	}
+}
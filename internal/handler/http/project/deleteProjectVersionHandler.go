@@ -0,0 +1,115 @@
+package project
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	domainerror "github.com/apenella/ransidble/internal/domain/core/error"
+	"github.com/apenella/ransidble/internal/domain/core/model/response"
+	"github.com/apenella/ransidble/internal/domain/ports/repository"
+	"github.com/apenella/ransidble/internal/domain/ports/service"
+	"github.com/labstack/echo/v4"
+)
+
+// DeleteProjectVersionHandler is the HTTP handler for deleting a project version.
+type DeleteProjectVersionHandler struct {
+	service service.DeleteProjectServicer
+	logger  repository.Logger
+}
+
+// NewDeleteProjectVersionHandler creates a new instance of DeleteProjectVersionHandler.
+func NewDeleteProjectVersionHandler(service service.DeleteProjectServicer, logger repository.Logger) *DeleteProjectVersionHandler {
+	return &DeleteProjectVersionHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// Handle handles the HTTP request for deleting a project version.
+func (h *DeleteProjectVersionHandler) Handle(c echo.Context) error {
+	var err error
+	var errorMsg string
+	var errorResponse *response.ProjectErrorResponse
+	var httpStatus int
+	var projectID string
+	var projectInvalidVersionErr *domainerror.ProjectInvalidVersionError
+	var projectLastVersionErr *domainerror.ProjectLastVersionError
+	var projectNotFoundErr *domainerror.ProjectNotFoundError
+	var version string
+
+	if h.service == nil {
+		errorResponse = &response.ProjectErrorResponse{
+			Error:  ErrDeleteProjectServiceNotInitialized,
+			Status: http.StatusInternalServerError,
+		}
+		h.logger.Error(
+			ErrDeleteProjectServiceNotInitialized,
+			map[string]interface{}{
+				"component": "DeleteProjectVersionHandler.Handle",
+				"package":   "github.com/apenella/ransidble/internal/handler/http/project",
+			})
+		return c.JSON(http.StatusInternalServerError, errorResponse)
+	}
+
+	projectID = c.Param("id")
+	if len(projectID) == 0 {
+		errorResponse = &response.ProjectErrorResponse{
+			Error:  ErrProjectIDNotProvided,
+			Status: http.StatusBadRequest,
+		}
+		h.logger.Error(
+			ErrProjectIDNotProvided,
+			map[string]interface{}{
+				"component": "DeleteProjectVersionHandler.Handle",
+				"package":   "github.com/apenella/ransidble/internal/handler/http/project",
+			})
+		return c.JSON(http.StatusBadRequest, errorResponse)
+	}
+
+	version = c.Param("version")
+	if len(version) == 0 {
+		errorResponse = &response.ProjectErrorResponse{
+			Error:  ErrProjectVersionNotProvided,
+			Status: http.StatusBadRequest,
+		}
+		h.logger.Error(
+			ErrProjectVersionNotProvided,
+			map[string]interface{}{
+				"component":  "DeleteProjectVersionHandler.Handle",
+				"package":    "github.com/apenella/ransidble/internal/handler/http/project",
+				"project_id": projectID,
+			})
+		return c.JSON(http.StatusBadRequest, errorResponse)
+	}
+
+	err = h.service.DeleteVersion(projectID, version)
+	if err != nil {
+		httpStatus = http.StatusInternalServerError
+		switch {
+		case errors.As(err, &projectNotFoundErr):
+			httpStatus = http.StatusNotFound
+		case errors.As(err, &projectLastVersionErr):
+			httpStatus = http.StatusConflict
+		case errors.As(err, &projectInvalidVersionErr):
+			httpStatus = http.StatusBadRequest
+		}
+
+		errorMsg = fmt.Sprintf("%s: %s", ErrDeletingProjectVersion, err.Error())
+		errorResponse = &response.ProjectErrorResponse{
+			Error:  errorMsg,
+			Status: httpStatus,
+		}
+		h.logger.Error(
+			errorMsg,
+			map[string]interface{}{
+				"component":       "DeleteProjectVersionHandler.Handle",
+				"package":         "github.com/apenella/ransidble/internal/handler/http/project",
+				"project_id":      projectID,
+				"project_version": version,
+			})
+		return c.JSON(httpStatus, errorResponse)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
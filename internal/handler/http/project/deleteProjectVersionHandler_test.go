@@ -0,0 +1,133 @@
+package project
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	domainerror "github.com/apenella/ransidble/internal/domain/core/error"
+	"github.com/apenella/ransidble/internal/domain/core/model/response"
+	"github.com/apenella/ransidble/internal/domain/ports/service"
+	"github.com/apenella/ransidble/internal/infrastructure/logger"
+	"github.com/apenella/ransidble/test/openapi"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandle_DeleteProjectVersionHandler(t *testing.T) {
+	openAPIValidator, err := openapi.PrepareOpenAPIValidator("../../../../api/openapi.yaml")
+	if err != nil {
+		t.Errorf("Error initializing OpenAPI validator: %s", err)
+		t.FailNow()
+		return
+	}
+
+	tests := []struct {
+		desc            string
+		handler         *DeleteProjectVersionHandler
+		params          []string
+		arrangeTestFunc func(h *DeleteProjectVersionHandler)
+		assertTestFunc  func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			desc:    "Testing DeleteProjectVersionHandler.Handle responding with an error when service is not initialized and is returning a StatusInternalServerError",
+			handler: NewDeleteProjectVersionHandler(nil, logger.NewFakeLogger()),
+			params:  []string{"project-id", "v1"},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var body *response.ProjectErrorResponse
+				expectedBody := &response.ProjectErrorResponse{
+					Error:  ErrDeleteProjectServiceNotInitialized,
+					Status: http.StatusInternalServerError,
+				}
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				assert.Equal(t, expectedBody, body)
+				assert.Equal(t, http.StatusInternalServerError, rec.Code)
+			},
+		},
+		{
+			desc:    "Testing DeleteProjectVersionHandler.Handle responding with an error when the version is not provided and is returning a StatusBadRequest",
+			handler: NewDeleteProjectVersionHandler(service.NewMockDeleteProjectService(), logger.NewFakeLogger()),
+			params:  []string{"project-id", ""},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var body *response.ProjectErrorResponse
+				expectedBody := &response.ProjectErrorResponse{
+					Error:  ErrProjectVersionNotProvided,
+					Status: http.StatusBadRequest,
+				}
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				assert.Equal(t, expectedBody, body)
+				assert.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			desc:    "Testing DeleteProjectVersionHandler.Handle responding with an error when the version is not found and is returning a StatusNotFound",
+			handler: NewDeleteProjectVersionHandler(service.NewMockDeleteProjectService(), logger.NewFakeLogger()),
+			params:  []string{"project-id", "v3"},
+			arrangeTestFunc: func(h *DeleteProjectVersionHandler) {
+				h.service.(*service.MockDeleteProjectService).On("DeleteVersion", "project-id", "v3").Return(domainerror.NewProjectNotFoundError(fmt.Errorf("project version not found")))
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusNotFound, rec.Code)
+			},
+		},
+		{
+			desc:    "Testing DeleteProjectVersionHandler.Handle responding with an error when the version is the only version of the project and is returning a StatusConflict",
+			handler: NewDeleteProjectVersionHandler(service.NewMockDeleteProjectService(), logger.NewFakeLogger()),
+			params:  []string{"project-id", "v1"},
+			arrangeTestFunc: func(h *DeleteProjectVersionHandler) {
+				h.service.(*service.MockDeleteProjectService).On("DeleteVersion", "project-id", "v1").Return(domainerror.NewProjectLastVersionError(fmt.Errorf("the only version of a project cannot be removed")))
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var body *response.ProjectErrorResponse
+				expectedBody := &response.ProjectErrorResponse{
+					Error:  fmt.Sprintf("%s: %s", ErrDeletingProjectVersion, "the only version of a project cannot be removed"),
+					Status: http.StatusConflict,
+				}
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				assert.Equal(t, expectedBody, body)
+				assert.Equal(t, http.StatusConflict, rec.Code)
+			},
+		},
+		{
+			desc:    "Testing DeleteProjectVersionHandler.Handle responding with no content when the version is deleted successfully and is returning a StatusNoContent",
+			handler: NewDeleteProjectVersionHandler(service.NewMockDeleteProjectService(), logger.NewFakeLogger()),
+			params:  []string{"project-id", "v2"},
+			arrangeTestFunc: func(h *DeleteProjectVersionHandler) {
+				h.service.(*service.MockDeleteProjectService).On("DeleteVersion", "project-id", "v2").Return(nil)
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusNoContent, rec.Code)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodDelete, "/projects/project-id/versions/v1", nil)
+		context := echo.New().NewContext(req, rec)
+		context.SetParamNames("id", "version")
+		context.SetParamValues(test.params...)
+
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.arrangeTestFunc != nil {
+				test.arrangeTestFunc(test.handler)
+			}
+
+			err := test.handler.Handle(context)
+			assert.NoError(t, err)
+			test.assertTestFunc(t, rec)
+		})
+
+		t.Run(fmt.Sprintf("OpenAPI %s", test.desc), func(t *testing.T) {
+			err := openAPIValidator.ValidateResponse(rec.Body.Bytes(), req, rec.Code, rec.Header())
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -7,6 +7,10 @@ const (
 	ErrGettingProject = "error getting project"
 	// ErrGettingProjectList represents an error executing the method getting project list
 	ErrGettingProjectList = "error getting project list"
+	// ErrBindingProjectQueryParameters represents an error when binding the project query parameters
+	ErrBindingProjectQueryParameters = "error binding project query parameters"
+	// ErrInvalidProjectQueryParameters represents an error when the project query parameters are not valid
+	ErrInvalidProjectQueryParameters = "invalid project query parameters"
 	// ErrGetProjectServiceNotInitialized represents an error when the GetProjectService is not initialized
 	ErrGetProjectServiceNotInitialized = "get project service not initialized"
 	// ErrInvalidRequestMetadata represents an error when the request metadata is invalid
@@ -15,6 +19,8 @@ const (
 	ErrProjectIDNotProvided = "project id not provided"
 	// ErrProjectMetadataFieldNotProvided represents an error when the project metadata is not provided by the user
 	ErrProjectMetadataFieldNotProvided = "project metadata not provided in the request"
+	// ErrProjectRequestTooLarge represents an error when the request body exceeds the maximum upload size
+	ErrProjectRequestTooLarge = "request body exceeds the maximum upload size"
 	// ErrReadingFormProjectFileField represents an error when the form field for the project file can not be read
 	ErrReadingFormProjectFileField = "error reading project file field"
 	// ErrReadingFormProjectMetadataField represents an error when the form field for the project metadata can not be read
@@ -23,6 +29,52 @@ const (
 	ErrCreateProjectServiceNotInitialized = "create project service not initialized"
 	// ErrDeletingProject represents an error when the project can not be deleted
 	ErrDeletingProject = "error deleting project"
+	// ErrDeletingProjectVersion represents an error when the project version can not be deleted
+	ErrDeletingProjectVersion = "error deleting project version"
+	// ErrGettingProjectVersions represents an error executing the method getting the project versions
+	ErrGettingProjectVersions = "error getting project versions"
+	// ErrProjectVersionNotProvided represents an error when the project version is not provided
+	ErrProjectVersionNotProvided = "project version not provided"
+	// ErrInvalidProjectDigestHeader represents an error when the expected digest of the project source code is not valid
+	ErrInvalidProjectDigestHeader = "invalid project digest header"
+	// ErrInvalidProjectSignatureField represents an error when the signature of the project file is not valid
+	ErrInvalidProjectSignatureField = "invalid project signature field"
+	// ErrGetProjectContentServiceNotInitialized represents an error when the GetProjectContentService is not initialized
+	ErrGetProjectContentServiceNotInitialized = "get project content service not initialized"
+	// ErrGettingProjectContent represents an error executing the method getting the project content
+	ErrGettingProjectContent = "error getting project content"
+	// ErrGettingProjectFiles represents an error executing the method getting the project files
+	ErrGettingProjectFiles = "error getting project files"
+	// ErrGettingProjectFile represents an error executing the method getting a project file
+	ErrGettingProjectFile = "error getting project file"
+	// ErrProjectFilePathNotProvided represents an error when the path of the project file is not provided
+	ErrProjectFilePathNotProvided = "project file path not provided"
+	// ErrInvalidProjectForceQueryParameter represents an error when the force query parameter of a project deletion is not a boolean
+	ErrInvalidProjectForceQueryParameter = "invalid project force query parameter"
+	// ErrRestoringProject represents an error when the deleted project can not be restored
+	ErrRestoringProject = "error restoring project"
 	// ErrDeleteProjectServiceNotInitialized represents an error when the DeleteProjectService is not initialized
 	ErrDeleteProjectServiceNotInitialized = "delete project service not initialized"
+	// ErrProjectUploadServiceNotInitialized represents an error when the ProjectUploadService is not initialized
+	ErrProjectUploadServiceNotInitialized = "project upload service not initialized"
+	// ErrProjectUploadIDNotProvided represents an error when the project upload id is not provided
+	ErrProjectUploadIDNotProvided = "project upload id not provided"
+	// ErrBindingProjectUploadParameters represents an error when binding the project upload parameters
+	ErrBindingProjectUploadParameters = "error binding project upload parameters"
+	// ErrInvalidProjectUploadParameters represents an error when the project upload parameters are not valid
+	ErrInvalidProjectUploadParameters = "invalid project upload parameters"
+	// ErrInvalidProjectUploadOffsetHeader represents an error when the offset of a project upload chunk is not valid
+	ErrInvalidProjectUploadOffsetHeader = "invalid project upload offset header"
+	// ErrCreatingProjectUpload represents an error when the project upload can not be created
+	ErrCreatingProjectUpload = "error creating project upload"
+	// ErrGettingProjectUpload represents an error executing the method getting a project upload
+	ErrGettingProjectUpload = "error getting project upload"
+	// ErrAppendingProjectUpload represents an error when a chunk can not be appended to a project upload
+	ErrAppendingProjectUpload = "error appending chunk to project upload"
+	// ErrDeletingProjectUpload represents an error when the project upload can not be deleted
+	ErrDeletingProjectUpload = "error deleting project upload"
+	// ErrReplacingProject represents an error when the project can not be replaced
+	ErrReplacingProject = "error replacing project"
+	// ErrProjectSourceCodeNotReplaceable represents an error when replacing a project with a source code that is not uploaded, such as the projects stored in a git repository or an OCI registry
+	ErrProjectSourceCodeNotReplaceable = "only the uploaded source code of a project can be replaced"
 )
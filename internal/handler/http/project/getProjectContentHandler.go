@@ -0,0 +1,122 @@
+package project
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	domainerror "github.com/apenella/ransidble/internal/domain/core/error"
+	"github.com/apenella/ransidble/internal/domain/core/model/response"
+	"github.com/apenella/ransidble/internal/domain/ports/repository"
+	"github.com/apenella/ransidble/internal/domain/ports/service"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// QueryParamProjectVersion is the query parameter to select the project version to read. The most recent version is read when it is not provided
+	QueryParamProjectVersion = "version"
+)
+
+// GetProjectContentHandler struct to handle requests to download the stored source code of a project
+type GetProjectContentHandler struct {
+	service service.GetProjectContentServicer
+	logger  repository.Logger
+}
+
+// NewGetProjectContentHandler creates a new GetProjectContentHandler
+func NewGetProjectContentHandler(s service.GetProjectContentServicer, logger repository.Logger) *GetProjectContentHandler {
+	return &GetProjectContentHandler{
+		service: s,
+		logger:  logger,
+	}
+}
+
+// Handle method to stream the stored source code of a project as an attachment
+func (h *GetProjectContentHandler) Handle(c echo.Context) error {
+
+	var errorMsg string
+	var errorResponse *response.ProjectErrorResponse
+	var httpStatus int
+
+	if h.service == nil {
+		errorResponse = &response.ProjectErrorResponse{
+			Error:  ErrGetProjectContentServiceNotInitialized,
+			Status: http.StatusInternalServerError,
+		}
+
+		h.logger.Error(ErrGetProjectContentServiceNotInitialized, map[string]interface{}{
+			"component": "GetProjectContentHandler.Handle",
+			"package":   "github.com/apenella/ransidble/internal/handler/http/project",
+		})
+		return c.JSON(http.StatusInternalServerError, errorResponse)
+	}
+
+	id := c.Param("id")
+	if id == "" {
+		errorResponse = &response.ProjectErrorResponse{
+			Error:  ErrProjectIDNotProvided,
+			Status: http.StatusBadRequest,
+		}
+		h.logger.Error(ErrProjectIDNotProvided, map[string]interface{}{
+			"component": "GetProjectContentHandler.Handle",
+			"package":   "github.com/apenella/ransidble/internal/handler/http/project",
+		})
+		return c.JSON(http.StatusBadRequest, errorResponse)
+	}
+
+	version := c.QueryParam(QueryParamProjectVersion)
+
+	content, err := h.service.GetProjectContent(c.Request().Context(), id, version)
+	if err != nil {
+		httpStatus = projectContentErrorStatus(err)
+		errorMsg = fmt.Sprintf("%s: %s", ErrGettingProjectContent, err.Error())
+
+		errorResponse = &response.ProjectErrorResponse{
+			Error:  errorMsg,
+			Status: httpStatus,
+		}
+
+		h.logger.Error(errorMsg, map[string]interface{}{
+			"component":       "GetProjectContentHandler.Handle",
+			"package":         "github.com/apenella/ransidble/internal/handler/http/project",
+			"project_id":      id,
+			"project_version": version,
+		})
+		return c.JSON(httpStatus, errorResponse)
+	}
+	defer content.Reader.Close()
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, mime.FormatMediaType("attachment", map[string]string{"filename": content.Name}))
+
+	return streamProjectContent(c, content)
+}
+
+// streamProjectContent writes the project content in the response body as binary data, announcing its length
+func streamProjectContent(c echo.Context, content *entity.ProjectContent) error {
+	c.Response().Header().Set(echo.HeaderContentLength, strconv.FormatInt(content.Size, 10))
+
+	return c.Stream(http.StatusOK, echo.MIMEOctetStream, content.Reader)
+}
+
+// projectContentErrorStatus returns the HTTP status code of the errors returned when the stored source code of a project is read
+func projectContentErrorStatus(err error) int {
+	var projectContentNotArchivedErr *domainerror.ProjectContentNotArchivedError
+	var projectFileNotFoundErr *domainerror.ProjectFileNotFoundError
+	var projectInvalidFilePathErr *domainerror.ProjectInvalidFilePathError
+	var projectNotFoundErr *domainerror.ProjectNotFoundError
+	var projectNotProvidedErr *domainerror.ProjectNotProvidedError
+
+	switch {
+	case errors.As(err, &projectNotFoundErr), errors.As(err, &projectFileNotFoundErr):
+		return http.StatusNotFound
+	case errors.As(err, &projectNotProvidedErr), errors.As(err, &projectInvalidFilePathErr):
+		return http.StatusBadRequest
+	case errors.As(err, &projectContentNotArchivedErr):
+		return http.StatusConflict
+	}
+
+	return http.StatusInternalServerError
+}
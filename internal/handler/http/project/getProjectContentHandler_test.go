@@ -0,0 +1,148 @@
+package project
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	domainerror "github.com/apenella/ransidble/internal/domain/core/error"
+	"github.com/apenella/ransidble/internal/domain/core/model/response"
+	"github.com/apenella/ransidble/internal/domain/ports/service"
+	"github.com/apenella/ransidble/internal/infrastructure/logger"
+	"github.com/apenella/ransidble/test/openapi"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandle_GetProjectContentHandler(t *testing.T) {
+	openAPIValidator, err := openapi.PrepareOpenAPIValidator("../../../../api/openapi.yaml")
+	if err != nil {
+		t.Errorf("Error initializing OpenAPI validator: %s", err)
+		t.FailNow()
+		return
+	}
+
+	tests := []struct {
+		desc            string
+		handler         *GetProjectContentHandler
+		id              string
+		query           string
+		arrangeTestFunc func(h *GetProjectContentHandler)
+		assertTestFunc  func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			desc:    "Testing GetProjectContentHandler.Handle responding with an error when service is not initialized and is returning a StatusInternalServerError",
+			handler: NewGetProjectContentHandler(nil, logger.NewFakeLogger()),
+			id:      "project-id",
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusInternalServerError, rec.Code)
+			},
+		},
+		{
+			desc:    "Testing GetProjectContentHandler.Handle responding with an error when project id is not provided and is returning a StatusBadRequest",
+			handler: NewGetProjectContentHandler(service.NewMockGetProjectContentService(), logger.NewFakeLogger()),
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var body *response.ProjectErrorResponse
+				expectedBody := &response.ProjectErrorResponse{
+					Error:  ErrProjectIDNotProvided,
+					Status: http.StatusBadRequest,
+				}
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				assert.Equal(t, expectedBody, body)
+				assert.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			desc:    "Testing GetProjectContentHandler.Handle responding with an error when the project does not exist and is returning a StatusNotFound",
+			handler: NewGetProjectContentHandler(service.NewMockGetProjectContentService(), logger.NewFakeLogger()),
+			id:      "project-id",
+			arrangeTestFunc: func(h *GetProjectContentHandler) {
+				h.service.(*service.MockGetProjectContentService).On("GetProjectContent", mock.Anything, "project-id", "").Return(nil, domainerror.NewProjectNotFoundError(fmt.Errorf("project not found")))
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var body *response.ProjectErrorResponse
+				expectedBody := &response.ProjectErrorResponse{
+					Error:  fmt.Sprintf("%s: %s", ErrGettingProjectContent, "project not found"),
+					Status: http.StatusNotFound,
+				}
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				assert.Equal(t, expectedBody, body)
+				assert.Equal(t, http.StatusNotFound, rec.Code)
+			},
+		},
+		{
+			desc:    "Testing GetProjectContentHandler.Handle responding with an error when the project source code is not stored as an archive and is returning a StatusConflict",
+			handler: NewGetProjectContentHandler(service.NewMockGetProjectContentService(), logger.NewFakeLogger()),
+			id:      "project-id",
+			arrangeTestFunc: func(h *GetProjectContentHandler) {
+				h.service.(*service.MockGetProjectContentService).On("GetProjectContent", mock.Anything, "project-id", "").Return(nil, domainerror.NewProjectContentNotArchivedError(entity.ErrProjectContentNotArchived))
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var body *response.ProjectErrorResponse
+				expectedBody := &response.ProjectErrorResponse{
+					Error:  fmt.Sprintf("%s: %s", ErrGettingProjectContent, entity.ErrProjectContentNotArchived),
+					Status: http.StatusConflict,
+				}
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				assert.Equal(t, expectedBody, body)
+				assert.Equal(t, http.StatusConflict, rec.Code)
+			},
+		},
+		{
+			desc:    "Testing GetProjectContentHandler.Handle responding with the source code of a project version and is returning a StatusOK",
+			handler: NewGetProjectContentHandler(service.NewMockGetProjectContentService(), logger.NewFakeLogger()),
+			id:      "project-id",
+			query:   "?version=v1",
+			arrangeTestFunc: func(h *GetProjectContentHandler) {
+				h.service.(*service.MockGetProjectContentService).On("GetProjectContent", mock.Anything, "project-id", "v1").Return(&entity.ProjectContent{
+					Name:   "project-id@v1.tar.gz",
+					Reader: io.NopCloser(strings.NewReader("archive content")),
+					Size:   15,
+				}, nil)
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, rec.Code)
+				assert.Equal(t, "archive content", rec.Body.String())
+				assert.Equal(t, echo.MIMEOctetStream, rec.Header().Get(echo.HeaderContentType))
+				assert.Equal(t, "15", rec.Header().Get(echo.HeaderContentLength))
+				assert.Equal(t, `attachment; filename="project-id@v1.tar.gz"`, rec.Header().Get(echo.HeaderContentDisposition))
+			},
+		},
+	}
+
+	for _, test := range tests {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/projects/project-id/content"+test.query, nil)
+		context := echo.New().NewContext(req, rec)
+		if test.id != "" {
+			context.SetParamNames("id")
+			context.SetParamValues(test.id)
+		}
+
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.arrangeTestFunc != nil {
+				test.arrangeTestFunc(test.handler)
+			}
+
+			err := test.handler.Handle(context)
+			assert.NoError(t, err)
+			test.assertTestFunc(t, rec)
+		})
+
+		t.Run(fmt.Sprintf("OpenAPI %s", test.desc), func(t *testing.T) {
+			err := openAPIValidator.ValidateResponse(rec.Body.Bytes(), req, rec.Code, rec.Header())
+			assert.NoError(t, err)
+		})
+	}
+}
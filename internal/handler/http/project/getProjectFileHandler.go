@@ -0,0 +1,98 @@
+package project
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/apenella/ransidble/internal/domain/core/model/response"
+	"github.com/apenella/ransidble/internal/domain/ports/repository"
+	"github.com/apenella/ransidble/internal/domain/ports/service"
+	"github.com/labstack/echo/v4"
+)
+
+// GetProjectFileHandler struct to handle requests to read a file of the project tree of a project
+type GetProjectFileHandler struct {
+	service service.GetProjectContentServicer
+	logger  repository.Logger
+}
+
+// NewGetProjectFileHandler creates a new GetProjectFileHandler
+func NewGetProjectFileHandler(s service.GetProjectContentServicer, logger repository.Logger) *GetProjectFileHandler {
+	return &GetProjectFileHandler{
+		service: s,
+		logger:  logger,
+	}
+}
+
+// Handle method to stream a file of the project tree. The file path is the remainder of the request path
+func (h *GetProjectFileHandler) Handle(c echo.Context) error {
+
+	var errorMsg string
+	var errorResponse *response.ProjectErrorResponse
+	var httpStatus int
+
+	if h.service == nil {
+		errorResponse = &response.ProjectErrorResponse{
+			Error:  ErrGetProjectContentServiceNotInitialized,
+			Status: http.StatusInternalServerError,
+		}
+
+		h.logger.Error(ErrGetProjectContentServiceNotInitialized, map[string]interface{}{
+			"component": "GetProjectFileHandler.Handle",
+			"package":   "github.com/apenella/ransidble/internal/handler/http/project",
+		})
+		return c.JSON(http.StatusInternalServerError, errorResponse)
+	}
+
+	id := c.Param("id")
+	if id == "" {
+		errorResponse = &response.ProjectErrorResponse{
+			Error:  ErrProjectIDNotProvided,
+			Status: http.StatusBadRequest,
+		}
+		h.logger.Error(ErrProjectIDNotProvided, map[string]interface{}{
+			"component": "GetProjectFileHandler.Handle",
+			"package":   "github.com/apenella/ransidble/internal/handler/http/project",
+		})
+		return c.JSON(http.StatusBadRequest, errorResponse)
+	}
+
+	path := c.Param("*")
+	if path == "" {
+		errorResponse = &response.ProjectErrorResponse{
+			Error:  ErrProjectFilePathNotProvided,
+			Status: http.StatusBadRequest,
+		}
+		h.logger.Error(ErrProjectFilePathNotProvided, map[string]interface{}{
+			"component":  "GetProjectFileHandler.Handle",
+			"package":    "github.com/apenella/ransidble/internal/handler/http/project",
+			"project_id": id,
+		})
+		return c.JSON(http.StatusBadRequest, errorResponse)
+	}
+
+	version := c.QueryParam(QueryParamProjectVersion)
+
+	content, err := h.service.GetProjectFile(c.Request().Context(), id, version, path)
+	if err != nil {
+		httpStatus = projectContentErrorStatus(err)
+		errorMsg = fmt.Sprintf("%s: %s", ErrGettingProjectFile, err.Error())
+
+		errorResponse = &response.ProjectErrorResponse{
+			Error:  errorMsg,
+			Status: httpStatus,
+		}
+
+		h.logger.Error(errorMsg, map[string]interface{}{
+			"component":       "GetProjectFileHandler.Handle",
+			"package":         "github.com/apenella/ransidble/internal/handler/http/project",
+			"path":            path,
+			"project_id":      id,
+			"project_version": version,
+		})
+		return c.JSON(httpStatus, errorResponse)
+	}
+	defer content.Reader.Close()
+
+	return streamProjectContent(c, content)
+}
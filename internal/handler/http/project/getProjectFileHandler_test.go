@@ -0,0 +1,151 @@
+package project
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	domainerror "github.com/apenella/ransidble/internal/domain/core/error"
+	"github.com/apenella/ransidble/internal/domain/core/model/response"
+	"github.com/apenella/ransidble/internal/domain/ports/service"
+	"github.com/apenella/ransidble/internal/infrastructure/logger"
+	"github.com/apenella/ransidble/test/openapi"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandle_GetProjectFileHandler(t *testing.T) {
+	openAPIValidator, err := openapi.PrepareOpenAPIValidator("../../../../api/openapi.yaml")
+	if err != nil {
+		t.Errorf("Error initializing OpenAPI validator: %s", err)
+		t.FailNow()
+		return
+	}
+
+	tests := []struct {
+		desc            string
+		handler         *GetProjectFileHandler
+		id              string
+		path            string
+		arrangeTestFunc func(h *GetProjectFileHandler)
+		assertTestFunc  func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			desc:    "Testing GetProjectFileHandler.Handle responding with an error when service is not initialized and is returning a StatusInternalServerError",
+			handler: NewGetProjectFileHandler(nil, logger.NewFakeLogger()),
+			id:      "project-id",
+			path:    "site.yml",
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusInternalServerError, rec.Code)
+			},
+		},
+		{
+			desc:    "Testing GetProjectFileHandler.Handle responding with an error when the file path is not provided and is returning a StatusBadRequest",
+			handler: NewGetProjectFileHandler(service.NewMockGetProjectContentService(), logger.NewFakeLogger()),
+			id:      "project-id",
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var body *response.ProjectErrorResponse
+				expectedBody := &response.ProjectErrorResponse{
+					Error:  ErrProjectFilePathNotProvided,
+					Status: http.StatusBadRequest,
+				}
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				assert.Equal(t, expectedBody, body)
+				assert.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			desc:    "Testing GetProjectFileHandler.Handle responding with an error when the file path points outside the project tree and is returning a StatusBadRequest",
+			handler: NewGetProjectFileHandler(service.NewMockGetProjectContentService(), logger.NewFakeLogger()),
+			id:      "project-id",
+			path:    "..",
+			arrangeTestFunc: func(h *GetProjectFileHandler) {
+				h.service.(*service.MockGetProjectContentService).On("GetProjectFile", mock.Anything, "project-id", "", "..").Return(nil, domainerror.NewProjectInvalidFilePathError(entity.ErrInvalidProjectFilePath))
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var body *response.ProjectErrorResponse
+				expectedBody := &response.ProjectErrorResponse{
+					Error:  fmt.Sprintf("%s: %s", ErrGettingProjectFile, entity.ErrInvalidProjectFilePath),
+					Status: http.StatusBadRequest,
+				}
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				assert.Equal(t, expectedBody, body)
+				assert.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			desc:    "Testing GetProjectFileHandler.Handle responding with an error when the file is not in the project tree and is returning a StatusNotFound",
+			handler: NewGetProjectFileHandler(service.NewMockGetProjectContentService(), logger.NewFakeLogger()),
+			id:      "project-id",
+			path:    "unknown.yml",
+			arrangeTestFunc: func(h *GetProjectFileHandler) {
+				h.service.(*service.MockGetProjectContentService).On("GetProjectFile", mock.Anything, "project-id", "", "unknown.yml").Return(nil, domainerror.NewProjectFileNotFoundError(entity.ErrProjectFileNotFound))
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var body *response.ProjectErrorResponse
+				expectedBody := &response.ProjectErrorResponse{
+					Error:  fmt.Sprintf("%s: %s", ErrGettingProjectFile, entity.ErrProjectFileNotFound),
+					Status: http.StatusNotFound,
+				}
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				assert.Equal(t, expectedBody, body)
+				assert.Equal(t, http.StatusNotFound, rec.Code)
+			},
+		},
+		{
+			desc:    "Testing GetProjectFileHandler.Handle responding with a file of the project tree and is returning a StatusOK",
+			handler: NewGetProjectFileHandler(service.NewMockGetProjectContentService(), logger.NewFakeLogger()),
+			id:      "project-id",
+			path:    "site.yml",
+			arrangeTestFunc: func(h *GetProjectFileHandler) {
+				h.service.(*service.MockGetProjectContentService).On("GetProjectFile", mock.Anything, "project-id", "", "site.yml").Return(&entity.ProjectContent{
+					Name:   "site.yml",
+					Reader: io.NopCloser(strings.NewReader("- hosts: all")),
+					Size:   12,
+				}, nil)
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, rec.Code)
+				assert.Equal(t, "- hosts: all", rec.Body.String())
+				assert.Equal(t, echo.MIMEOctetStream, rec.Header().Get(echo.HeaderContentType))
+				assert.Equal(t, "12", rec.Header().Get(echo.HeaderContentLength))
+			},
+		},
+	}
+
+	for _, test := range tests {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/projects/project-id/files/site.yml", nil)
+		context := echo.New().NewContext(req, rec)
+		if test.id != "" {
+			context.SetParamNames("id", "*")
+			context.SetParamValues(test.id, test.path)
+		}
+
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.arrangeTestFunc != nil {
+				test.arrangeTestFunc(test.handler)
+			}
+
+			err := test.handler.Handle(context)
+			assert.NoError(t, err)
+			test.assertTestFunc(t, rec)
+		})
+
+		t.Run(fmt.Sprintf("OpenAPI %s", test.desc), func(t *testing.T) {
+			err := openAPIValidator.ValidateResponse(rec.Body.Bytes(), req, rec.Code, rec.Header())
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package project
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/apenella/ransidble/internal/domain/core/mapper"
+	"github.com/apenella/ransidble/internal/domain/core/model/response"
+	"github.com/apenella/ransidble/internal/domain/ports/repository"
+	"github.com/apenella/ransidble/internal/domain/ports/service"
+	"github.com/labstack/echo/v4"
+)
+
+// GetProjectFilesHandler struct to handle requests to list the project tree of a project
+type GetProjectFilesHandler struct {
+	service service.GetProjectContentServicer
+	logger  repository.Logger
+}
+
+// NewGetProjectFilesHandler creates a new GetProjectFilesHandler
+func NewGetProjectFilesHandler(s service.GetProjectContentServicer, logger repository.Logger) *GetProjectFilesHandler {
+	return &GetProjectFilesHandler{
+		service: s,
+		logger:  logger,
+	}
+}
+
+// Handle method to list the entries of the project tree, sorted by their path
+func (h *GetProjectFilesHandler) Handle(c echo.Context) error {
+
+	var errorMsg string
+	var errorResponse *response.ProjectErrorResponse
+	var httpStatus int
+
+	if h.service == nil {
+		errorResponse = &response.ProjectErrorResponse{
+			Error:  ErrGetProjectContentServiceNotInitialized,
+			Status: http.StatusInternalServerError,
+		}
+
+		h.logger.Error(ErrGetProjectContentServiceNotInitialized, map[string]interface{}{
+			"component": "GetProjectFilesHandler.Handle",
+			"package":   "github.com/apenella/ransidble/internal/handler/http/project",
+		})
+		return c.JSON(http.StatusInternalServerError, errorResponse)
+	}
+
+	id := c.Param("id")
+	if id == "" {
+		errorResponse = &response.ProjectErrorResponse{
+			Error:  ErrProjectIDNotProvided,
+			Status: http.StatusBadRequest,
+		}
+		h.logger.Error(ErrProjectIDNotProvided, map[string]interface{}{
+			"component": "GetProjectFilesHandler.Handle",
+			"package":   "github.com/apenella/ransidble/internal/handler/http/project",
+		})
+		return c.JSON(http.StatusBadRequest, errorResponse)
+	}
+
+	version := c.QueryParam(QueryParamProjectVersion)
+
+	files, err := h.service.GetProjectFiles(c.Request().Context(), id, version)
+	if err != nil {
+		httpStatus = projectContentErrorStatus(err)
+		errorMsg = fmt.Sprintf("%s: %s", ErrGettingProjectFiles, err.Error())
+
+		errorResponse = &response.ProjectErrorResponse{
+			Error:  errorMsg,
+			Status: httpStatus,
+		}
+
+		h.logger.Error(errorMsg, map[string]interface{}{
+			"component":       "GetProjectFilesHandler.Handle",
+			"package":         "github.com/apenella/ransidble/internal/handler/http/project",
+			"project_id":      id,
+			"project_version": version,
+		})
+		return c.JSON(httpStatus, errorResponse)
+	}
+
+	projectMapper := mapper.NewProjectMapper()
+
+	return c.JSON(http.StatusOK, projectMapper.ToProjectFilesResponse(files))
+}
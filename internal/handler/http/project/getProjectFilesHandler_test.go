@@ -0,0 +1,132 @@
+package project
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	domainerror "github.com/apenella/ransidble/internal/domain/core/error"
+	"github.com/apenella/ransidble/internal/domain/core/model/response"
+	"github.com/apenella/ransidble/internal/domain/ports/service"
+	"github.com/apenella/ransidble/internal/infrastructure/logger"
+	"github.com/apenella/ransidble/test/openapi"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandle_GetProjectFilesHandler(t *testing.T) {
+	openAPIValidator, err := openapi.PrepareOpenAPIValidator("../../../../api/openapi.yaml")
+	if err != nil {
+		t.Errorf("Error initializing OpenAPI validator: %s", err)
+		t.FailNow()
+		return
+	}
+
+	tests := []struct {
+		desc            string
+		handler         *GetProjectFilesHandler
+		id              string
+		arrangeTestFunc func(h *GetProjectFilesHandler)
+		assertTestFunc  func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			desc:    "Testing GetProjectFilesHandler.Handle responding with an error when service is not initialized and is returning a StatusInternalServerError",
+			handler: NewGetProjectFilesHandler(nil, logger.NewFakeLogger()),
+			id:      "project-id",
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusInternalServerError, rec.Code)
+			},
+		},
+		{
+			desc:    "Testing GetProjectFilesHandler.Handle responding with an error when project id is not provided and is returning a StatusBadRequest",
+			handler: NewGetProjectFilesHandler(service.NewMockGetProjectContentService(), logger.NewFakeLogger()),
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var body *response.ProjectErrorResponse
+				expectedBody := &response.ProjectErrorResponse{
+					Error:  ErrProjectIDNotProvided,
+					Status: http.StatusBadRequest,
+				}
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				assert.Equal(t, expectedBody, body)
+				assert.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			desc:    "Testing GetProjectFilesHandler.Handle responding with an error when the project does not exist and is returning a StatusNotFound",
+			handler: NewGetProjectFilesHandler(service.NewMockGetProjectContentService(), logger.NewFakeLogger()),
+			id:      "project-id",
+			arrangeTestFunc: func(h *GetProjectFilesHandler) {
+				h.service.(*service.MockGetProjectContentService).On("GetProjectFiles", mock.Anything, "project-id", "").Return(nil, domainerror.NewProjectNotFoundError(fmt.Errorf("project not found")))
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var body *response.ProjectErrorResponse
+				expectedBody := &response.ProjectErrorResponse{
+					Error:  fmt.Sprintf("%s: %s", ErrGettingProjectFiles, "project not found"),
+					Status: http.StatusNotFound,
+				}
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				assert.Equal(t, expectedBody, body)
+				assert.Equal(t, http.StatusNotFound, rec.Code)
+			},
+		},
+		{
+			desc:    "Testing GetProjectFilesHandler.Handle responding with the project files and is returning a StatusOK",
+			handler: NewGetProjectFilesHandler(service.NewMockGetProjectContentService(), logger.NewFakeLogger()),
+			id:      "project-id",
+			arrangeTestFunc: func(h *GetProjectFilesHandler) {
+				h.service.(*service.MockGetProjectContentService).On("GetProjectFiles", mock.Anything, "project-id", "").Return([]*entity.ProjectFile{
+					{Path: "inventory", Type: entity.ProjectFileTypeDirectory},
+					{Path: "inventory/hosts.yml", Size: 7, Type: entity.ProjectFileTypeFile},
+					{Path: "site.yml", Size: 12, Type: entity.ProjectFileTypeFile},
+				}, nil)
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var body *response.ProjectFilesResponse
+				expectedBody := &response.ProjectFilesResponse{
+					Files: []*response.ProjectFileResponse{
+						{Path: "inventory", Type: "directory"},
+						{Path: "inventory/hosts.yml", Size: 7, Type: "file"},
+						{Path: "site.yml", Size: 12, Type: "file"},
+					},
+				}
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				assert.Equal(t, expectedBody, body)
+				assert.Equal(t, http.StatusOK, rec.Code)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/projects/project-id/files", nil)
+		context := echo.New().NewContext(req, rec)
+		if test.id != "" {
+			context.SetParamNames("id")
+			context.SetParamValues(test.id)
+		}
+
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.arrangeTestFunc != nil {
+				test.arrangeTestFunc(test.handler)
+			}
+
+			err := test.handler.Handle(context)
+			assert.NoError(t, err)
+			test.assertTestFunc(t, rec)
+		})
+
+		t.Run(fmt.Sprintf("OpenAPI %s", test.desc), func(t *testing.T) {
+			err := openAPIValidator.ValidateResponse(rec.Body.Bytes(), req, rec.Code, rec.Header())
+			assert.NoError(t, err)
+		})
+	}
+}
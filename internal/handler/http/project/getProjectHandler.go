@@ -103,6 +103,7 @@ func (h *GetProjectHandler) Handle(c echo.Context) error {
 
 	projectMapper := mapper.NewProjectMapper()
 	projectResponse := projectMapper.ToProjectResponse(project)
+	setProjectRevisionHeader(c, project.Revision)
 
 	return c.JSON(http.StatusOK, projectResponse)
 }
@@ -202,6 +202,36 @@ func TestHandle_GetProjectHandler(t *testing.T) {
 				assert.NoError(t, err)
 				assert.Equal(t, expectedBody, body)
 				assert.Equal(t, http.StatusOK, rec.Code)
+				assert.Empty(t, rec.Header().Get(HeaderETag))
+			},
+		},
+		{
+			desc: "Testing GetProjectHandler.Handle request success and is returning an StatusOK with the project revision in the ETag header",
+			handler: NewGetProjectHandler(
+				service.NewMockGetProjectService(),
+				logger.NewFakeLogger(),
+			),
+			arrangeContextFunc: func(r *http.Request, w http.ResponseWriter) echo.Context {
+				c := echo.New().NewContext(r, w)
+				c.SetParamNames("id")
+				c.SetParamValues("1")
+				return c
+			},
+			arrangeTestFunc: func(h *GetProjectHandler) {
+				h.service.(*service.MockGetProjectService).On("GetProject", "1").Return(
+					&entity.Project{
+						Format:    "plain",
+						Name:      "project1",
+						Reference: "project1",
+						Revision:  "revision-1",
+						Storage:   "local",
+					},
+					nil,
+				)
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, rec.Code)
+				assert.Equal(t, `"revision-1"`, rec.Header().Get(HeaderETag))
 			},
 		},
 	}
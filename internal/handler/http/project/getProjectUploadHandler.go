@@ -0,0 +1,76 @@
+package project
+
+import (
+	"net/http"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	"github.com/apenella/ransidble/internal/domain/core/mapper"
+	"github.com/apenella/ransidble/internal/domain/core/model/response"
+	"github.com/apenella/ransidble/internal/domain/ports/repository"
+	"github.com/apenella/ransidble/internal/domain/ports/service"
+	"github.com/labstack/echo/v4"
+)
+
+// GetProjectUploadHandler handles the request to get a project upload, which tells the clients the offset to resume it from
+type GetProjectUploadHandler struct {
+	service service.ProjectUploadServicer
+	logger  repository.Logger
+}
+
+// NewGetProjectUploadHandler creates a new GetProjectUploadHandler
+func NewGetProjectUploadHandler(service service.ProjectUploadServicer, logger repository.Logger) *GetProjectUploadHandler {
+	return &GetProjectUploadHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// Handle method to get a project upload. A HEAD request only responds the headers describing the state of the upload
+func (h *GetProjectUploadHandler) Handle(c echo.Context) error {
+	var err error
+	var errorResponse *response.ProjectErrorResponse
+	var upload *entity.ProjectUpload
+	var uploadID string
+
+	if h.service == nil {
+		errorResponse = &response.ProjectErrorResponse{
+			Error:  ErrProjectUploadServiceNotInitialized,
+			Status: http.StatusInternalServerError,
+		}
+		h.logger.Error(
+			ErrProjectUploadServiceNotInitialized,
+			map[string]interface{}{
+				"component": "GetProjectUploadHandler.Handle",
+				"package":   "github.com/apenella/ransidble/internal/handler/http/project",
+			})
+		return c.JSON(http.StatusInternalServerError, errorResponse)
+	}
+
+	uploadID = c.Param("id")
+	if uploadID == "" {
+		errorResponse = &response.ProjectErrorResponse{
+			Error:  ErrProjectUploadIDNotProvided,
+			Status: http.StatusBadRequest,
+		}
+		h.logger.Error(
+			ErrProjectUploadIDNotProvided,
+			map[string]interface{}{
+				"component": "GetProjectUploadHandler.Handle",
+				"package":   "github.com/apenella/ransidble/internal/handler/http/project",
+			})
+		return c.JSON(http.StatusBadRequest, errorResponse)
+	}
+
+	upload, err = h.service.GetUpload(uploadID)
+	if err != nil {
+		return projectUploadErrorResponse(c, h.logger, "GetProjectUploadHandler.Handle", ErrGettingProjectUpload, uploadID, err)
+	}
+
+	setProjectUploadHeaders(c, upload)
+
+	if c.Request().Method == http.MethodHead {
+		return c.NoContent(http.StatusOK)
+	}
+
+	return c.JSON(http.StatusOK, mapper.NewProjectMapper().ToProjectUploadResponse(upload))
+}
@@ -0,0 +1,216 @@
+package project
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	domainerror "github.com/apenella/ransidble/internal/domain/core/error"
+	"github.com/apenella/ransidble/internal/domain/core/model/response"
+	"github.com/apenella/ransidble/internal/domain/ports/service"
+	"github.com/apenella/ransidble/internal/infrastructure/logger"
+	"github.com/apenella/ransidble/test/openapi"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandle_GetProjectUploadHandler(t *testing.T) {
+	openAPIValidator, err := openapi.PrepareOpenAPIValidator("../../../../api/openapi.yaml")
+	if err != nil {
+		t.Errorf("Error initializing OpenAPI validator: %s", err)
+		t.FailNow()
+		return
+	}
+
+	upload := &entity.ProjectUpload{
+		CreatedAt: "2026-01-05T10:00:00Z",
+		ExpiresAt: "2026-01-06T10:05:00Z",
+		ID:        "upload-id",
+		Length:    1024,
+		Offset:    512,
+		UpdatedAt: "2026-01-05T10:05:00Z",
+	}
+
+	tests := []struct {
+		desc               string
+		handler            *GetProjectUploadHandler
+		method             string
+		arrangeContextFunc func(r *http.Request, w http.ResponseWriter) echo.Context
+		arrangeTestFunc    func(t *testing.T, h *GetProjectUploadHandler)
+		assertTestFunc     func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			desc: "Testing GetProjectUploadHandler.Handle responding with an error when service not initialized and is returning an StatusInternalServerError",
+			handler: NewGetProjectUploadHandler(
+				nil,
+				logger.NewFakeLogger(),
+			),
+			method: http.MethodGet,
+			arrangeContextFunc: func(r *http.Request, w http.ResponseWriter) echo.Context {
+				return echo.New().NewContext(r, w)
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var body *response.ProjectErrorResponse
+				expectedBody := &response.ProjectErrorResponse{
+					Error:  ErrProjectUploadServiceNotInitialized,
+					Status: http.StatusInternalServerError,
+				}
+
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				assert.Equal(t, expectedBody, body)
+				assert.Equal(t, http.StatusInternalServerError, rec.Code)
+			},
+		},
+		{
+			desc: "Testing GetProjectUploadHandler.Handle responding with an error when upload id not provided and is returning an StatusBadRequest",
+			handler: NewGetProjectUploadHandler(
+				service.NewMockProjectUploadService(),
+				logger.NewFakeLogger(),
+			),
+			method: http.MethodGet,
+			arrangeContextFunc: func(r *http.Request, w http.ResponseWriter) echo.Context {
+				return echo.New().NewContext(r, w)
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var body *response.ProjectErrorResponse
+				expectedBody := &response.ProjectErrorResponse{
+					Error:  ErrProjectUploadIDNotProvided,
+					Status: http.StatusBadRequest,
+				}
+
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				assert.Equal(t, expectedBody, body)
+				assert.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			desc: "Testing GetProjectUploadHandler.Handle responding with an error when upload not found and is returning an StatusNotFound",
+			handler: NewGetProjectUploadHandler(
+				service.NewMockProjectUploadService(),
+				logger.NewFakeLogger(),
+			),
+			method: http.MethodGet,
+			arrangeContextFunc: func(r *http.Request, w http.ResponseWriter) echo.Context {
+				c := echo.New().NewContext(r, w)
+				c.SetParamNames("id")
+				c.SetParamValues("upload-id")
+				return c
+			},
+			arrangeTestFunc: func(t *testing.T, h *GetProjectUploadHandler) {
+				h.service.(*service.MockProjectUploadService).On(
+					"GetUpload",
+					"upload-id",
+				).Return(
+					nil,
+					domainerror.NewProjectUploadNotFoundError(fmt.Errorf("project upload not found")),
+				)
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var body *response.ProjectErrorResponse
+				expectedBody := &response.ProjectErrorResponse{
+					Error:  fmt.Sprintf("%s: %s", ErrGettingProjectUpload, "project upload not found"),
+					Status: http.StatusNotFound,
+				}
+
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				assert.Equal(t, expectedBody, body)
+				assert.Equal(t, http.StatusNotFound, rec.Code)
+			},
+		},
+		{
+			desc: "Testing GetProjectUploadHandler.Handle getting an upload and is returning an StatusOK",
+			handler: NewGetProjectUploadHandler(
+				service.NewMockProjectUploadService(),
+				logger.NewFakeLogger(),
+			),
+			method: http.MethodGet,
+			arrangeContextFunc: func(r *http.Request, w http.ResponseWriter) echo.Context {
+				c := echo.New().NewContext(r, w)
+				c.SetParamNames("id")
+				c.SetParamValues("upload-id")
+				return c
+			},
+			arrangeTestFunc: func(t *testing.T, h *GetProjectUploadHandler) {
+				h.service.(*service.MockProjectUploadService).On(
+					"GetUpload",
+					"upload-id",
+				).Return(upload, nil)
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var body *response.ProjectUploadResponse
+				expectedBody := &response.ProjectUploadResponse{
+					CreatedAt: "2026-01-05T10:00:00Z",
+					ExpiresAt: "2026-01-06T10:05:00Z",
+					ID:        "upload-id",
+					Length:    1024,
+					Offset:    512,
+					UpdatedAt: "2026-01-05T10:05:00Z",
+				}
+
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				assert.Equal(t, expectedBody, body)
+				assert.Equal(t, http.StatusOK, rec.Code)
+				assert.Equal(t, "512", rec.Header().Get(HeaderUploadOffset))
+				assert.Equal(t, "1024", rec.Header().Get(HeaderUploadLength))
+				assert.Equal(t, "no-store", rec.Header().Get(echo.HeaderCacheControl))
+			},
+		},
+		{
+			desc: "Testing GetProjectUploadHandler.Handle getting the offset of an upload on a HEAD request and is returning an StatusOK without body",
+			handler: NewGetProjectUploadHandler(
+				service.NewMockProjectUploadService(),
+				logger.NewFakeLogger(),
+			),
+			method: http.MethodHead,
+			arrangeContextFunc: func(r *http.Request, w http.ResponseWriter) echo.Context {
+				c := echo.New().NewContext(r, w)
+				c.SetParamNames("id")
+				c.SetParamValues("upload-id")
+				return c
+			},
+			arrangeTestFunc: func(t *testing.T, h *GetProjectUploadHandler) {
+				h.service.(*service.MockProjectUploadService).On(
+					"GetUpload",
+					"upload-id",
+				).Return(upload, nil)
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, rec.Code)
+				assert.Empty(t, rec.Body.Bytes())
+				assert.Equal(t, "512", rec.Header().Get(HeaderUploadOffset))
+				assert.Equal(t, "Tue, 06 Jan 2026 10:05:00 GMT", rec.Header().Get(HeaderUploadExpires))
+			},
+		},
+	}
+
+	for _, test := range tests {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(test.method, "/uploads/upload-id", nil)
+		context := test.arrangeContextFunc(req, rec)
+
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.arrangeTestFunc != nil {
+				test.arrangeTestFunc(t, test.handler)
+			}
+
+			err := test.handler.Handle(context)
+			assert.NoError(t, err)
+
+			test.assertTestFunc(t, rec)
+		})
+
+		t.Run(fmt.Sprintf("OpenAPI %s", test.desc), func(t *testing.T) {
+			err := openAPIValidator.ValidateResponse(rec.Body.Bytes(), req, rec.Code, rec.Header())
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package project
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	domainerror "github.com/apenella/ransidble/internal/domain/core/error"
+	"github.com/apenella/ransidble/internal/domain/core/mapper"
+	"github.com/apenella/ransidble/internal/domain/core/model/response"
+	"github.com/apenella/ransidble/internal/domain/ports/repository"
+	"github.com/apenella/ransidble/internal/domain/ports/service"
+	"github.com/labstack/echo/v4"
+)
+
+// GetProjectVersionsHandler struct to handle requests to get the versions of a project
+type GetProjectVersionsHandler struct {
+	service service.GetProjectServicer
+	logger  repository.Logger
+}
+
+// NewGetProjectVersionsHandler creates a new GetProjectVersionsHandler
+func NewGetProjectVersionsHandler(s service.GetProjectServicer, logger repository.Logger) *GetProjectVersionsHandler {
+	return &GetProjectVersionsHandler{
+		service: s,
+		logger:  logger,
+	}
+}
+
+// Handle method to get the versions of a project, sorted from the oldest to the most recent
+func (h *GetProjectVersionsHandler) Handle(c echo.Context) error {
+
+	var errorMsg string
+	var errorResponse *response.ProjectErrorResponse
+	var httpStatus int
+	var projectNotFoundErr *domainerror.ProjectNotFoundError
+	var projectNotProvidedErr *domainerror.ProjectNotProvidedError
+
+	if h.service == nil {
+		errorResponse = &response.ProjectErrorResponse{
+			Error:  ErrGetProjectServiceNotInitialized,
+			Status: http.StatusInternalServerError,
+		}
+
+		h.logger.Error(ErrGetProjectServiceNotInitialized, map[string]interface{}{
+			"component": "GetProjectVersionsHandler.Handle",
+			"package":   "github.com/apenella/ransidble/internal/handler/http/project",
+		})
+		return c.JSON(http.StatusInternalServerError, errorResponse)
+	}
+
+	id := c.Param("id")
+	if id == "" {
+		errorResponse = &response.ProjectErrorResponse{
+			Error:  ErrProjectIDNotProvided,
+			Status: http.StatusBadRequest,
+		}
+		h.logger.Error(ErrProjectIDNotProvided, map[string]interface{}{
+			"component": "GetProjectVersionsHandler.Handle",
+			"package":   "github.com/apenella/ransidble/internal/handler/http/project",
+		})
+		return c.JSON(http.StatusBadRequest, errorResponse)
+	}
+
+	versions, err := h.service.GetProjectVersions(id)
+	if err != nil {
+		httpStatus = http.StatusInternalServerError
+
+		if errors.As(err, &projectNotFoundErr) {
+			httpStatus = http.StatusNotFound
+		}
+
+		if errors.As(err, &projectNotProvidedErr) {
+			httpStatus = http.StatusBadRequest
+		}
+
+		errorMsg = fmt.Sprintf("%s: %s", ErrGettingProjectVersions, err.Error())
+		errorResponse = &response.ProjectErrorResponse{
+			Error:  errorMsg,
+			Status: httpStatus,
+		}
+
+		h.logger.Error(errorMsg, map[string]interface{}{
+			"component":  "GetProjectVersionsHandler.Handle",
+			"package":    "github.com/apenella/ransidble/internal/handler/http/project",
+			"project_id": id,
+		})
+		return c.JSON(httpStatus, errorResponse)
+	}
+
+	projectMapper := mapper.NewProjectMapper()
+
+	return c.JSON(http.StatusOK, projectMapper.ToProjectVersionsResponse(versions))
+}
@@ -0,0 +1,130 @@
+package project
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	domainerror "github.com/apenella/ransidble/internal/domain/core/error"
+	"github.com/apenella/ransidble/internal/domain/core/model/response"
+	"github.com/apenella/ransidble/internal/domain/ports/service"
+	"github.com/apenella/ransidble/internal/infrastructure/logger"
+	"github.com/apenella/ransidble/test/openapi"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandle_GetProjectVersionsHandler(t *testing.T) {
+	openAPIValidator, err := openapi.PrepareOpenAPIValidator("../../../../api/openapi.yaml")
+	if err != nil {
+		t.Errorf("Error initializing OpenAPI validator: %s", err)
+		t.FailNow()
+		return
+	}
+
+	tests := []struct {
+		desc            string
+		handler         *GetProjectVersionsHandler
+		id              string
+		arrangeTestFunc func(h *GetProjectVersionsHandler)
+		assertTestFunc  func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			desc:    "Testing GetProjectVersionsHandler.Handle responding with an error when service is not initialized and is returning a StatusInternalServerError",
+			handler: NewGetProjectVersionsHandler(nil, logger.NewFakeLogger()),
+			id:      "project-id",
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusInternalServerError, rec.Code)
+			},
+		},
+		{
+			desc:    "Testing GetProjectVersionsHandler.Handle responding with an error when project id is not provided and is returning a StatusBadRequest",
+			handler: NewGetProjectVersionsHandler(service.NewMockGetProjectService(), logger.NewFakeLogger()),
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var body *response.ProjectErrorResponse
+				expectedBody := &response.ProjectErrorResponse{
+					Error:  ErrProjectIDNotProvided,
+					Status: http.StatusBadRequest,
+				}
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				assert.Equal(t, expectedBody, body)
+				assert.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			desc:    "Testing GetProjectVersionsHandler.Handle responding with an error when the project does not exist and is returning a StatusNotFound",
+			handler: NewGetProjectVersionsHandler(service.NewMockGetProjectService(), logger.NewFakeLogger()),
+			id:      "project-id",
+			arrangeTestFunc: func(h *GetProjectVersionsHandler) {
+				h.service.(*service.MockGetProjectService).On("GetProjectVersions", "project-id").Return(nil, domainerror.NewProjectNotFoundError(fmt.Errorf("project not found")))
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var body *response.ProjectErrorResponse
+				expectedBody := &response.ProjectErrorResponse{
+					Error:  fmt.Sprintf("%s: %s", ErrGettingProjectVersions, "project not found"),
+					Status: http.StatusNotFound,
+				}
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				assert.Equal(t, expectedBody, body)
+				assert.Equal(t, http.StatusNotFound, rec.Code)
+			},
+		},
+		{
+			desc:    "Testing GetProjectVersionsHandler.Handle responding with the project versions and is returning a StatusOK",
+			handler: NewGetProjectVersionsHandler(service.NewMockGetProjectService(), logger.NewFakeLogger()),
+			id:      "project-id",
+			arrangeTestFunc: func(h *GetProjectVersionsHandler) {
+				h.service.(*service.MockGetProjectService).On("GetProjectVersions", "project-id").Return([]*entity.Project{
+					{CreatedAt: "2025-01-01T10:00:00Z", Format: "targz", Name: "project-id", Reference: "project-id.tar.gz", Storage: "local", Version: "v1"},
+					{CreatedAt: "2025-02-01T10:00:00Z", Format: "targz", Name: "project-id", Reference: "project-id@v2.tar.gz", Storage: "local", Version: "v2"},
+				}, nil)
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var body *response.ProjectVersionsResponse
+				expectedBody := &response.ProjectVersionsResponse{
+					Latest: "v2",
+					Versions: []*response.ProjectResponse{
+						{CreatedAt: "2025-01-01T10:00:00Z", Format: "targz", Name: "project-id", Reference: "project-id.tar.gz", Storage: "local", Version: "v1"},
+						{CreatedAt: "2025-02-01T10:00:00Z", Format: "targz", Name: "project-id", Reference: "project-id@v2.tar.gz", Storage: "local", Version: "v2"},
+					},
+				}
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				assert.Equal(t, expectedBody, body)
+				assert.Equal(t, http.StatusOK, rec.Code)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/projects/project-id/versions", nil)
+		context := echo.New().NewContext(req, rec)
+		if test.id != "" {
+			context.SetParamNames("id")
+			context.SetParamValues(test.id)
+		}
+
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.arrangeTestFunc != nil {
+				test.arrangeTestFunc(test.handler)
+			}
+
+			err := test.handler.Handle(context)
+			assert.NoError(t, err)
+			test.assertTestFunc(t, rec)
+		})
+
+		t.Run(fmt.Sprintf("OpenAPI %s", test.desc), func(t *testing.T) {
+			err := openAPIValidator.ValidateResponse(rec.Body.Bytes(), req, rec.Code, rec.Header())
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -1,10 +1,14 @@
 package project
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 
+	domainerror "github.com/apenella/ransidble/internal/domain/core/error"
 	"github.com/apenella/ransidble/internal/domain/core/mapper"
+	"github.com/apenella/ransidble/internal/domain/core/model/request"
 	"github.com/apenella/ransidble/internal/domain/core/model/response"
 	"github.com/apenella/ransidble/internal/domain/ports/repository"
 	"github.com/apenella/ransidble/internal/domain/ports/service"
@@ -12,7 +16,10 @@ import (
 )
 
 const (
-// ErrGetProjectServiceNotInitialized represents an error when the GetProjectService is not initialized
+	// HeaderLink is the response header containing the link to the next page of projects
+	HeaderLink = "Link"
+	// HeaderTotalCount is the response header containing the number of projects that fulfill the query filters
+	HeaderTotalCount = "X-Total-Count"
 )
 
 // GetProjectListHandler struct to handle get project requests
@@ -29,11 +36,15 @@ func NewGetProjectListHandler(s service.GetProjectServicer, logger repository.Lo
 	}
 }
 
-// Handle method to get a task
+// Handle method to get the list of projects
 func (h *GetProjectListHandler) Handle(c echo.Context) error {
 
+	var err error
 	var errorMsg string
 	var errorResponse *response.ProjectErrorResponse
+	var httpStatus int
+	var projectInvalidQueryErr *domainerror.ProjectInvalidQueryError
+	var queryParameters request.ProjectQueryParameters
 
 	if h.service == nil {
 		errorResponse = &response.ProjectErrorResponse{
@@ -49,13 +60,55 @@ func (h *GetProjectListHandler) Handle(c echo.Context) error {
 		return c.JSON(http.StatusInternalServerError, errorResponse)
 	}
 
+	err = c.Bind(&queryParameters)
+	if err != nil {
+		errorMsg = fmt.Sprintf("%s: %s", ErrBindingProjectQueryParameters, err.Error())
+
+		h.logger.Error(errorMsg, map[string]interface{}{
+			"component": "GetProjectListHandler.Handle",
+			"package":   "github.com/apenella/ransidble/internal/handler/http/project",
+		})
+
+		errorResponse = &response.ProjectErrorResponse{
+			Error:  errorMsg,
+			Status: http.StatusBadRequest,
+		}
+
+		return c.JSON(http.StatusBadRequest, errorResponse)
+	}
+
+	err = queryParameters.Validate()
+	if err != nil {
+		errorMsg = fmt.Sprintf("%s: %s", ErrInvalidProjectQueryParameters, err.Error())
+
+		h.logger.Error(errorMsg, map[string]interface{}{
+			"component": "GetProjectListHandler.Handle",
+			"package":   "github.com/apenella/ransidble/internal/handler/http/project",
+		})
+
+		errorResponse = &response.ProjectErrorResponse{
+			Error:  errorMsg,
+			Status: http.StatusBadRequest,
+		}
+
+		return c.JSON(http.StatusBadRequest, errorResponse)
+	}
+
+	projectQueryMapper := mapper.NewProjectQueryMapper()
+	query := projectQueryMapper.ToProjectQueryEntity(&queryParameters)
+
 	h.logger.Debug("getting project list", map[string]interface{}{
 		"component": "GetProjectListHandler.Handle",
 		"package":   "github.com/apenella/ransidble/internal/handler/http/project",
 	})
 
-	projects, err := h.service.GetProjectsList()
+	page, err := h.service.GetProjectsList(query)
 	if err != nil {
+		httpStatus = http.StatusInternalServerError
+		if errors.As(err, &projectInvalidQueryErr) {
+			httpStatus = http.StatusBadRequest
+		}
+
 		errorMsg = fmt.Sprintf("%s: %s", ErrGettingProjectList, err.Error())
 
 		h.logger.Error(errorMsg, map[string]interface{}{
@@ -65,17 +118,41 @@ func (h *GetProjectListHandler) Handle(c echo.Context) error {
 
 		errorResponse = &response.ProjectErrorResponse{
 			Error:  errorMsg,
-			Status: http.StatusInternalServerError,
+			Status: httpStatus,
 		}
 
-		return c.JSON(http.StatusInternalServerError, errorResponse)
+		return c.JSON(httpStatus, errorResponse)
+	}
+
+	c.Response().Header().Set(HeaderTotalCount, strconv.Itoa(page.Total))
+	if page.NextCursor != "" {
+		c.Response().Header().Set(HeaderLink, h.nextPageLink(c, page.NextCursor))
 	}
 
-	projectListResponse := make([]*response.ProjectResponse, 0)
 	projectMapper := mapper.NewProjectMapper()
-	for _, project := range projects {
+	fields := queryParameters.FieldList()
+
+	if len(fields) > 0 {
+		projectFieldsListResponse := make([]map[string]interface{}, 0)
+		for _, project := range page.Projects {
+			projectFieldsListResponse = append(projectFieldsListResponse, projectMapper.ToProjectFieldsResponse(project, fields))
+		}
+
+		return c.JSON(http.StatusOK, projectFieldsListResponse)
+	}
+
+	projectListResponse := make([]*response.ProjectResponse, 0)
+	for _, project := range page.Projects {
 		projectListResponse = append(projectListResponse, projectMapper.ToProjectResponse(project))
 	}
 
 	return c.JSON(http.StatusOK, projectListResponse)
 }
+
+// nextPageLink returns the Link header value pointing to the next page. It keeps the request query parameters and replaces the cursor
+func (h *GetProjectListHandler) nextPageLink(c echo.Context, cursor string) string {
+	values := c.Request().URL.Query()
+	values.Set("cursor", cursor)
+
+	return fmt.Sprintf("<%s?%s>; rel=\"next\"", c.Request().URL.Path, values.Encode())
+}
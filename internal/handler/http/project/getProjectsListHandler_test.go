@@ -2,12 +2,14 @@ package project
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/apenella/ransidble/internal/domain/core/entity"
+	domainerror "github.com/apenella/ransidble/internal/domain/core/error"
 	"github.com/apenella/ransidble/internal/domain/core/model/response"
 	"github.com/apenella/ransidble/internal/domain/ports/service"
 	"github.com/apenella/ransidble/internal/infrastructure/logger"
@@ -28,6 +30,7 @@ func TestHandle_GetProjectListHandler(t *testing.T) {
 	tests := []struct {
 		desc               string
 		handler            *GetProjectListHandler
+		path               string
 		arrangeContextFunc func(r *http.Request, w http.ResponseWriter) echo.Context
 		arrangeTestFunc    func(h *GetProjectListHandler)
 		assertTestFunc     func(t *testing.T, rec *httptest.ResponseRecorder)
@@ -38,6 +41,7 @@ func TestHandle_GetProjectListHandler(t *testing.T) {
 				nil,
 				logger.NewFakeLogger(),
 			),
+			path: "/projects",
 			arrangeContextFunc: func(r *http.Request, w http.ResponseWriter) echo.Context {
 				return echo.New().NewContext(r, w)
 			},
@@ -59,11 +63,33 @@ func TestHandle_GetProjectListHandler(t *testing.T) {
 				service.NewMockGetProjectService(),
 				logger.NewFakeLogger(),
 			),
+			path: "/projects",
 			arrangeContextFunc: func(r *http.Request, w http.ResponseWriter) echo.Context {
 				return echo.New().NewContext(r, w)
 			},
 			arrangeTestFunc: func(h *GetProjectListHandler) {
-				h.service.(*service.MockGetProjectService).On("GetProjectsList").Return([]*entity.Project{
+				h.service.(*service.MockGetProjectService).On("GetProjectsList", entity.NewProjectQuery()).Return(&entity.ProjectPage{
+					Projects: []*entity.Project{
+						{
+							Name:      "project1",
+							Format:    "plain",
+							Reference: "project1",
+							Storage:   "local",
+						},
+						{
+							Name:      "project2",
+							Format:    "plain",
+							Reference: "project2",
+							Storage:   "local",
+							Version:   "v1.0.0",
+						},
+					},
+					Total: 2,
+				}, nil)
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var body []*response.ProjectResponse
+				expectedBody := []*response.ProjectResponse{
 					{
 						Name:      "project1",
 						Format:    "plain",
@@ -75,7 +101,43 @@ func TestHandle_GetProjectListHandler(t *testing.T) {
 						Format:    "plain",
 						Reference: "project2",
 						Storage:   "local",
+						Version:   "v1.0.0",
 					},
+				}
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				assert.Equal(t, expectedBody, body)
+				assert.Equal(t, http.StatusOK, rec.Code)
+				assert.Equal(t, "2", rec.Header().Get(HeaderTotalCount))
+				assert.Empty(t, rec.Header().Get(HeaderLink))
+			},
+		},
+		{
+			desc: "Testing GetProjectsListHandler.Handle filtered and paginated project list is returned with the link to the next page and is returning an StatusOK",
+			handler: NewGetProjectListHandler(
+				service.NewMockGetProjectService(),
+				logger.NewFakeLogger(),
+			),
+			path: "/projects?format=plain&limit=1&name_prefix=project",
+			arrangeContextFunc: func(r *http.Request, w http.ResponseWriter) echo.Context {
+				return echo.New().NewContext(r, w)
+			},
+			arrangeTestFunc: func(h *GetProjectListHandler) {
+				h.service.(*service.MockGetProjectService).On("GetProjectsList", &entity.ProjectQuery{
+					Format:     "plain",
+					Limit:      1,
+					NamePrefix: "project",
+				}).Return(&entity.ProjectPage{
+					NextCursor: "cHJvamVjdDE",
+					Projects: []*entity.Project{
+						{
+							Name:      "project1",
+							Format:    "plain",
+							Reference: "project1",
+							Storage:   "local",
+						},
+					},
+					Total: 2,
 				}, nil)
 			},
 			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
@@ -87,11 +149,46 @@ func TestHandle_GetProjectListHandler(t *testing.T) {
 						Reference: "project1",
 						Storage:   "local",
 					},
+				}
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				assert.Equal(t, expectedBody, body)
+				assert.Equal(t, http.StatusOK, rec.Code)
+				assert.Equal(t, "2", rec.Header().Get(HeaderTotalCount))
+				assert.Equal(t, `</projects?cursor=cHJvamVjdDE&format=plain&limit=1&name_prefix=project>; rel="next"`, rec.Header().Get(HeaderLink))
+			},
+		},
+		{
+			desc: "Testing GetProjectsListHandler.Handle project list is returned with the selected fields and is returning an StatusOK",
+			handler: NewGetProjectListHandler(
+				service.NewMockGetProjectService(),
+				logger.NewFakeLogger(),
+			),
+			path: "/projects?fields=version,format",
+			arrangeContextFunc: func(r *http.Request, w http.ResponseWriter) echo.Context {
+				return echo.New().NewContext(r, w)
+			},
+			arrangeTestFunc: func(h *GetProjectListHandler) {
+				h.service.(*service.MockGetProjectService).On("GetProjectsList", entity.NewProjectQuery()).Return(&entity.ProjectPage{
+					Projects: []*entity.Project{
+						{
+							Name:      "project1",
+							Format:    "plain",
+							Reference: "project1",
+							Storage:   "local",
+							Version:   "v1.0.0",
+						},
+					},
+					Total: 1,
+				}, nil)
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var body []map[string]interface{}
+				expectedBody := []map[string]interface{}{
 					{
-						Name:      "project2",
-						Format:    "plain",
-						Reference: "project2",
-						Storage:   "local",
+						"format":  "plain",
+						"name":    "project1",
+						"version": "v1.0.0",
 					},
 				}
 				err := json.Unmarshal(rec.Body.Bytes(), &body)
@@ -100,6 +197,102 @@ func TestHandle_GetProjectListHandler(t *testing.T) {
 				assert.Equal(t, http.StatusOK, rec.Code)
 			},
 		},
+		{
+			desc: "Testing GetProjectsListHandler.Handle responding with an error when the query parameters can not be bound and is returning an StatusBadRequest",
+			handler: NewGetProjectListHandler(
+				service.NewMockGetProjectService(),
+				logger.NewFakeLogger(),
+			),
+			path: "/projects?limit=many",
+			arrangeContextFunc: func(r *http.Request, w http.ResponseWriter) echo.Context {
+				return echo.New().NewContext(r, w)
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var body *response.ProjectErrorResponse
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				assert.Contains(t, body.Error, ErrBindingProjectQueryParameters)
+				assert.Equal(t, http.StatusBadRequest, body.Status)
+				assert.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			desc: "Testing GetProjectsListHandler.Handle responding with an error when the selected fields are not valid and is returning an StatusBadRequest",
+			handler: NewGetProjectListHandler(
+				service.NewMockGetProjectService(),
+				logger.NewFakeLogger(),
+			),
+			path: "/projects?fields=name,hash",
+			arrangeContextFunc: func(r *http.Request, w http.ResponseWriter) echo.Context {
+				return echo.New().NewContext(r, w)
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var body *response.ProjectErrorResponse
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				assert.Contains(t, body.Error, ErrInvalidProjectQueryParameters)
+				assert.Equal(t, http.StatusBadRequest, body.Status)
+				assert.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			desc: "Testing GetProjectsListHandler.Handle responding with an error when the project query is not valid and is returning an StatusBadRequest",
+			handler: NewGetProjectListHandler(
+				service.NewMockGetProjectService(),
+				logger.NewFakeLogger(),
+			),
+			path: "/projects?cursor=invalid",
+			arrangeContextFunc: func(r *http.Request, w http.ResponseWriter) echo.Context {
+				return echo.New().NewContext(r, w)
+			},
+			arrangeTestFunc: func(h *GetProjectListHandler) {
+				query := entity.NewProjectQuery()
+				query.Cursor = "invalid"
+				h.service.(*service.MockGetProjectService).On("GetProjectsList", query).Return(
+					nil,
+					domainerror.NewProjectInvalidQueryError(entity.ErrInvalidProjectQueryCursor),
+				)
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var body *response.ProjectErrorResponse
+				expectedBody := &response.ProjectErrorResponse{
+					Error:  fmt.Sprintf("%s: %s", ErrGettingProjectList, entity.ErrInvalidProjectQueryCursor.Error()),
+					Status: http.StatusBadRequest,
+				}
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				assert.Equal(t, expectedBody, body)
+				assert.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			desc: "Testing GetProjectsListHandler.Handle responding with an error when the project list can not be obtained and is returning an StatusInternalServerError",
+			handler: NewGetProjectListHandler(
+				service.NewMockGetProjectService(),
+				logger.NewFakeLogger(),
+			),
+			path: "/projects",
+			arrangeContextFunc: func(r *http.Request, w http.ResponseWriter) echo.Context {
+				return echo.New().NewContext(r, w)
+			},
+			arrangeTestFunc: func(h *GetProjectListHandler) {
+				h.service.(*service.MockGetProjectService).On("GetProjectsList", entity.NewProjectQuery()).Return(
+					nil,
+					errors.New("testing error"),
+				)
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var body *response.ProjectErrorResponse
+				expectedBody := &response.ProjectErrorResponse{
+					Error:  fmt.Sprintf("%s: %s", ErrGettingProjectList, "testing error"),
+					Status: http.StatusInternalServerError,
+				}
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				assert.Equal(t, expectedBody, body)
+				assert.Equal(t, http.StatusInternalServerError, rec.Code)
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -109,7 +302,7 @@ func TestHandle_GetProjectListHandler(t *testing.T) {
 		t.Run(test.desc, func(t *testing.T) {
 			t.Log(test.desc)
 
-			req = httptest.NewRequest(http.MethodGet, "/projects", nil)
+			req = httptest.NewRequest(http.MethodGet, test.path, nil)
 			context := test.arrangeContextFunc(req, rec)
 
 			if test.arrangeTestFunc != nil {
@@ -0,0 +1,38 @@
+package project
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// HeaderETag is the response header containing the revision of a project, which changes every time the project is stored
+	HeaderETag = "ETag"
+	// HeaderIfMatch is the request header containing the revision of a project expected by a conditional request
+	HeaderIfMatch = "If-Match"
+)
+
+// setProjectRevisionHeader sets the header containing the revision of a project, which the clients send back on the conditional requests. The header is not set when the revision is unknown
+func setProjectRevisionHeader(c echo.Context, revision string) {
+	if revision == "" {
+		return
+	}
+
+	c.Response().Header().Set(HeaderETag, fmt.Sprintf("%q", revision))
+}
+
+// projectRevisionFromIfMatch returns the revision of a project expected by a conditional request. The wildcard matches any revision, so no revision is expected. The weak entity tags are kept as they are, since they never match a revision
+func projectRevisionFromIfMatch(c echo.Context) string {
+	value := strings.TrimSpace(c.Request().Header.Get(HeaderIfMatch))
+	if value == "*" {
+		return ""
+	}
+
+	if strings.HasPrefix(value, `"`) && strings.HasSuffix(value, `"`) && len(value) > 1 {
+		return value[1 : len(value)-1]
+	}
+
+	return value
+}
@@ -0,0 +1,77 @@
+package project
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	domainerror "github.com/apenella/ransidble/internal/domain/core/error"
+	"github.com/apenella/ransidble/internal/domain/core/model/response"
+	"github.com/apenella/ransidble/internal/domain/ports/repository"
+	serverhttp "github.com/apenella/ransidble/internal/handler/http"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// HeaderUploadOffset is the header containing the number of bytes received by a project upload. On a chunk request, it contains the offset where the chunk is appended
+	HeaderUploadOffset = "Upload-Offset"
+	// HeaderUploadLength is the response header containing the size in bytes of the project source code to upload
+	HeaderUploadLength = "Upload-Length"
+	// HeaderUploadExpires is the response header containing the time when the project upload expires
+	HeaderUploadExpires = "Upload-Expires"
+)
+
+// setProjectUploadHeaders sets the headers describing the state of a project upload, which let the clients resume the upload from the bytes already received
+func setProjectUploadHeaders(c echo.Context, upload *entity.ProjectUpload) {
+	c.Response().Header().Set(HeaderUploadOffset, strconv.FormatInt(upload.Offset, 10))
+	c.Response().Header().Set(HeaderUploadLength, strconv.FormatInt(upload.Length, 10))
+
+	expiresAt, err := time.Parse(time.RFC3339, upload.ExpiresAt)
+	if err == nil {
+		c.Response().Header().Set(HeaderUploadExpires, expiresAt.UTC().Format(http.TimeFormat))
+	}
+
+	c.Response().Header().Set(echo.HeaderCacheControl, "no-store")
+}
+
+// projectUploadLocation returns the URL of a project upload
+func projectUploadLocation(id string) string {
+	return fmt.Sprintf("%s/%s", serverhttp.ProjectUploadBasePath, id)
+}
+
+// projectUploadErrorResponse responds the error returned by the service when handling a project upload request
+func projectUploadErrorResponse(c echo.Context, logger repository.Logger, component string, message string, id string, err error) error {
+	var projectUploadConflict *domainerror.ProjectUploadConflictError
+	var projectUploadInvalid *domainerror.ProjectUploadInvalidError
+	var projectUploadNotFound *domainerror.ProjectUploadNotFoundError
+	var projectTooLarge *domainerror.ProjectTooLargeError
+
+	httpStatus := http.StatusInternalServerError
+	switch {
+	case errors.As(err, &projectUploadConflict):
+		httpStatus = http.StatusConflict
+	case errors.As(err, &projectUploadInvalid):
+		httpStatus = http.StatusBadRequest
+	case errors.As(err, &projectUploadNotFound):
+		httpStatus = http.StatusNotFound
+	case errors.As(err, &projectTooLarge):
+		httpStatus = http.StatusRequestEntityTooLarge
+	}
+
+	errorMsg := fmt.Sprintf("%s: %s", message, err.Error())
+	errorResponse := &response.ProjectErrorResponse{
+		Error:  errorMsg,
+		Status: httpStatus,
+	}
+	logger.Error(
+		errorMsg,
+		map[string]interface{}{
+			"component": component,
+			"package":   "github.com/apenella/ransidble/internal/handler/http/project",
+			"upload_id": id,
+		})
+	return c.JSON(httpStatus, errorResponse)
+}
@@ -0,0 +1,30 @@
+package project
+
+import (
+	"github.com/apenella/ransidble/internal/domain/ports/repository"
+	"github.com/apenella/ransidble/internal/domain/ports/service"
+	"github.com/labstack/echo/v4"
+)
+
+// ReplaceProjectHandler handles the request to replace the source code of an existing project
+type ReplaceProjectHandler struct {
+	handler *CreateProjectHandler
+}
+
+// NewReplaceProjectHandler creates a new ReplaceProjectHandler
+func NewReplaceProjectHandler(service service.CreateProjectServicer, logger repository.Logger) *ReplaceProjectHandler {
+	return &ReplaceProjectHandler{
+		handler: NewCreateProjectHandler(service, logger),
+	}
+}
+
+// WithMaxUploadSize sets the maximum size, in bytes, of the uploaded source code
+func (h *ReplaceProjectHandler) WithMaxUploadSize(size int64) *ReplaceProjectHandler {
+	h.handler.WithMaxUploadSize(size)
+	return h
+}
+
+// Handle method to replace the source code of the most recent version of a project. The request is the same multipart form used to create a project, and the If-Match header holds the revision of the project the client expects to replace
+func (h *ReplaceProjectHandler) Handle(c echo.Context) error {
+	return h.handler.create(c, replaceProjectRequest)
+}
@@ -0,0 +1,222 @@
+package project
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	domainerror "github.com/apenella/ransidble/internal/domain/core/error"
+	"github.com/apenella/ransidble/internal/domain/core/model/request"
+	"github.com/apenella/ransidble/internal/domain/core/model/response"
+	"github.com/apenella/ransidble/internal/domain/ports/service"
+	"github.com/apenella/ransidble/internal/infrastructure/logger"
+	"github.com/apenella/ransidble/test/openapi"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// newReplaceProjectContext creates the context of a request to replace a project, attaching the project file and the If-Match header when they are provided
+func newReplaceProjectContext(t *testing.T, w http.ResponseWriter, parameters *request.ProjectParameters, file string, ifMatch string) echo.Context {
+	var bodyBuffer bytes.Buffer
+
+	requestParametersJSON, err := json.Marshal(parameters)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	multiparWriter := multipart.NewWriter(&bodyBuffer)
+	multiparWriter.WriteField(RequestFormProjectMetadataFieldName, string(requestParametersJSON))
+
+	if file != "" {
+		part, err := multiparWriter.CreateFormFile(RequestFormProjectFileFieldeName, "project.tar.gz")
+		if err != nil {
+			t.Fatal(err)
+		}
+		part.Write([]byte(file))
+	}
+	multiparWriter.Close()
+
+	r := httptest.NewRequest(http.MethodPut, "/projects/project-id", &bodyBuffer)
+	r.Header.Set(echo.HeaderContentType, multiparWriter.FormDataContentType())
+	if ifMatch != "" {
+		r.Header.Set(HeaderIfMatch, ifMatch)
+	}
+
+	c := echo.New().NewContext(r, w)
+	c.SetParamNames("id")
+	c.SetParamValues("project-id")
+	return c
+}
+
+func TestHandle_ReplaceProjectHandler(t *testing.T) {
+
+	openAPIValidator, err := openapi.PrepareOpenAPIValidator("../../../../api/openapi.yaml")
+	if err != nil {
+		t.Errorf("Error initializing OpenAPI validator: %s", err)
+		t.FailNow()
+		return
+	}
+
+	tests := []struct {
+		desc               string
+		handler            *ReplaceProjectHandler
+		arrangeContextFunc func(w http.ResponseWriter) echo.Context
+		arrangeTestFunc    func(h *ReplaceProjectHandler)
+		assertTestFunc     func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			desc:    "Testing ReplaceProjectHandler.Handle request success and it is returning a StatusNoContent with the new revision",
+			handler: NewReplaceProjectHandler(service.NewMockCreateProjectService(), logger.NewFakeLogger()),
+			arrangeContextFunc: func(w http.ResponseWriter) echo.Context {
+				return newReplaceProjectContext(t, w, &request.ProjectParameters{
+					Format:  entity.ProjectFormatTarGz,
+					Storage: entity.ProjectTypeLocal,
+					Version: "1.0.0",
+				}, "project-content", `"revision-1"`)
+			},
+			arrangeTestFunc: func(h *ReplaceProjectHandler) {
+				h.handler.service.(*service.MockCreateProjectService).On(
+					"Create",
+					&entity.CreateProjectRequest{
+						Format:    entity.ProjectFormatTarGz,
+						Mode:      entity.CreateProjectModeReplace,
+						ProjectID: "project-id",
+						Revision:  "revision-1",
+						Storage:   entity.ProjectTypeLocal,
+						Version:   "1.0.0",
+					},
+					mock.Anything,
+				).Return("revision-2", nil)
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusNoContent, rec.Code)
+				assert.Equal(t, `"revision-2"`, rec.Header().Get(HeaderETag))
+			},
+		},
+		{
+			desc:    "Testing ReplaceProjectHandler.Handle request replacing a project with the source code of an upload success and it is returning a StatusNoContent",
+			handler: NewReplaceProjectHandler(service.NewMockCreateProjectService(), logger.NewFakeLogger()),
+			arrangeContextFunc: func(w http.ResponseWriter) echo.Context {
+				return newReplaceProjectContext(t, w, &request.ProjectParameters{
+					Format:  entity.ProjectFormatTarGz,
+					Storage: entity.ProjectTypeLocal,
+					Upload:  "upload-id",
+					Version: "1.0.0",
+				}, "", "*")
+			},
+			arrangeTestFunc: func(h *ReplaceProjectHandler) {
+				h.handler.service.(*service.MockCreateProjectService).On(
+					"CreateFromUpload",
+					&entity.CreateProjectRequest{
+						Format:    entity.ProjectFormatTarGz,
+						Mode:      entity.CreateProjectModeReplace,
+						ProjectID: "project-id",
+						Storage:   entity.ProjectTypeLocal,
+						Version:   "1.0.0",
+					},
+					"upload-id",
+				).Return("revision-2", nil)
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusNoContent, rec.Code)
+				assert.Equal(t, `"revision-2"`, rec.Header().Get(HeaderETag))
+			},
+		},
+		{
+			desc:    "Testing ReplaceProjectHandler.Handle responding with an error when the project is stored in a git repository and is returning a StatusBadRequest",
+			handler: NewReplaceProjectHandler(service.NewMockCreateProjectService(), logger.NewFakeLogger()),
+			arrangeContextFunc: func(w http.ResponseWriter) echo.Context {
+				return newReplaceProjectContext(t, w, &request.ProjectParameters{
+					Format:  entity.ProjectFormatPlain,
+					Git:     &request.ProjectGitParameters{Ref: "v1.0.0", URL: "https://example.com/project.git"},
+					Storage: entity.ProjectTypeGit,
+					Version: "1.0.0",
+				}, "", "")
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var body *response.ProjectErrorResponse
+				expectedBody := &response.ProjectErrorResponse{
+					Error:  fmt.Sprintf("%s: %s", ErrReplacingProject, ErrProjectSourceCodeNotReplaceable),
+					Status: http.StatusBadRequest,
+				}
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				assert.Equal(t, expectedBody, body)
+				assert.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			desc:    "Testing ReplaceProjectHandler.Handle responding with an error when the project revision does not match and is returning a StatusPreconditionFailed",
+			handler: NewReplaceProjectHandler(service.NewMockCreateProjectService(), logger.NewFakeLogger()),
+			arrangeContextFunc: func(w http.ResponseWriter) echo.Context {
+				return newReplaceProjectContext(t, w, &request.ProjectParameters{
+					Format:  entity.ProjectFormatTarGz,
+					Storage: entity.ProjectTypeLocal,
+					Version: "1.0.0",
+				}, "project-content", `"revision-0"`)
+			},
+			arrangeTestFunc: func(h *ReplaceProjectHandler) {
+				h.handler.service.(*service.MockCreateProjectService).On("Create", mock.Anything, mock.Anything).
+					Return("", domainerror.NewProjectPreconditionFailedError(fmt.Errorf("project revision mismatch")))
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var body *response.ProjectErrorResponse
+				expectedBody := &response.ProjectErrorResponse{
+					Error:  fmt.Sprintf("%s: %s", ErrReplacingProject, "project revision mismatch"),
+					Status: http.StatusPreconditionFailed,
+				}
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				assert.Equal(t, expectedBody, body)
+				assert.Equal(t, http.StatusPreconditionFailed, rec.Code)
+			},
+		},
+		{
+			desc:    "Testing ReplaceProjectHandler.Handle responding with an error when the project does not exist and is returning a StatusNotFound",
+			handler: NewReplaceProjectHandler(service.NewMockCreateProjectService(), logger.NewFakeLogger()),
+			arrangeContextFunc: func(w http.ResponseWriter) echo.Context {
+				return newReplaceProjectContext(t, w, &request.ProjectParameters{
+					Format:  entity.ProjectFormatTarGz,
+					Storage: entity.ProjectTypeLocal,
+					Version: "1.0.0",
+				}, "project-content", "")
+			},
+			arrangeTestFunc: func(h *ReplaceProjectHandler) {
+				h.handler.service.(*service.MockCreateProjectService).On("Create", mock.Anything, mock.Anything).
+					Return("", domainerror.NewProjectNotFoundError(fmt.Errorf("project not found")))
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusNotFound, rec.Code)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPut, "/projects/project-id", nil)
+		context := test.arrangeContextFunc(rec)
+
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.arrangeTestFunc != nil {
+				test.arrangeTestFunc(test.handler)
+			}
+
+			err := test.handler.Handle(context)
+			assert.NoError(t, err)
+			test.assertTestFunc(t, rec)
+		})
+
+		t.Run(fmt.Sprintf("OpenAPI %s", test.desc), func(t *testing.T) {
+			err := openAPIValidator.ValidateResponse(rec.Body.Bytes(), req, rec.Code, rec.Header())
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package project
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	domainerror "github.com/apenella/ransidble/internal/domain/core/error"
+	"github.com/apenella/ransidble/internal/domain/core/model/response"
+	"github.com/apenella/ransidble/internal/domain/ports/repository"
+	"github.com/apenella/ransidble/internal/domain/ports/service"
+	"github.com/labstack/echo/v4"
+)
+
+// RestoreProjectHandler is the HTTP handler for restoring a deleted project.
+type RestoreProjectHandler struct {
+	service service.DeleteProjectServicer
+	logger  repository.Logger
+}
+
+// NewRestoreProjectHandler creates a new instance of RestoreProjectHandler.
+func NewRestoreProjectHandler(service service.DeleteProjectServicer, logger repository.Logger) *RestoreProjectHandler {
+	return &RestoreProjectHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// Handle handles the HTTP request for restoring a deleted project.
+func (h *RestoreProjectHandler) Handle(c echo.Context) error {
+	var err error
+	var errorMsg string
+	var errorResponse *response.ProjectErrorResponse
+	var httpStatus int
+	var projectAlreadyExistsErr *domainerror.ProjectAlreadyExistsError
+	var projectID string
+	var projectNotFoundErr *domainerror.ProjectNotFoundError
+
+	if h.service == nil {
+		errorResponse = &response.ProjectErrorResponse{
+			Error:  ErrDeleteProjectServiceNotInitialized,
+			Status: http.StatusInternalServerError,
+		}
+		h.logger.Error(
+			ErrDeleteProjectServiceNotInitialized,
+			map[string]interface{}{
+				"component": "RestoreProjectHandler.Handle",
+				"package":   "github.com/apenella/ransidble/internal/handler/http/project",
+			})
+		return c.JSON(http.StatusInternalServerError, errorResponse)
+	}
+
+	projectID = c.Param("id")
+
+	if len(projectID) == 0 {
+		errorResponse = &response.ProjectErrorResponse{
+			Error:  ErrProjectIDNotProvided,
+			Status: http.StatusBadRequest,
+		}
+		h.logger.Error(
+			ErrProjectIDNotProvided,
+			map[string]interface{}{
+				"component": "RestoreProjectHandler.Handle",
+				"package":   "github.com/apenella/ransidble/internal/handler/http/project",
+			})
+		return c.JSON(http.StatusBadRequest, errorResponse)
+	}
+
+	err = h.service.Restore(projectID)
+	if err != nil {
+		httpStatus = http.StatusInternalServerError
+		switch {
+		case errors.As(err, &projectNotFoundErr):
+			httpStatus = http.StatusNotFound
+		case errors.As(err, &projectAlreadyExistsErr):
+			httpStatus = http.StatusConflict
+		}
+
+		errorMsg = fmt.Sprintf("%s: %s", ErrRestoringProject, err.Error())
+		errorResponse = &response.ProjectErrorResponse{
+			Error:  errorMsg,
+			Status: httpStatus,
+		}
+		h.logger.Error(
+			errorMsg,
+			map[string]interface{}{
+				"component":  "RestoreProjectHandler.Handle",
+				"package":    "github.com/apenella/ransidble/internal/handler/http/project",
+				"project_id": projectID,
+			})
+		return c.JSON(httpStatus, errorResponse)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
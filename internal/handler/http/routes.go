@@ -12,15 +12,50 @@ const (
 	GetProjectsPath = "/projects"
 	// DeleteProjectPath is the endpoint to delete a project by ID
 	DeleteProjectPath = "/projects/:id"
+	// RestoreProjectPath is the endpoint to restore a deleted project by ID
+	RestoreProjectPath = "/projects/:id/restore"
+	// ReplaceProjectPath is the endpoint to replace the source code of a project by ID
+	ReplaceProjectPath = "/projects/:id"
+	// CreateProjectVersionPath is the endpoint to create a new version of a project
+	CreateProjectVersionPath = "/projects/:id/versions"
+	// GetProjectVersionsPath is the endpoint to list the versions of a project
+	GetProjectVersionsPath = "/projects/:id/versions"
+	// DeleteProjectVersionPath is the endpoint to delete a version of a project
+	DeleteProjectVersionPath = "/projects/:id/versions/:version"
+	// GetProjectContentPath is the endpoint to download the stored source code of a project
+	GetProjectContentPath = "/projects/:id/content"
+	// GetProjectFilesPath is the endpoint to list the project tree of a project
+	GetProjectFilesPath = "/projects/:id/files"
+	// GetProjectFilePath is the endpoint to read a file of the project tree of a project
+	GetProjectFilePath = "/projects/:id/files/*"
+
+	// ProjectUploadBasePath is the base path for all project upload-related endpoints
+	ProjectUploadBasePath = "/uploads"
+	// CreateProjectUploadPath is the endpoint to open a resumable upload of a project source code
+	CreateProjectUploadPath = "/uploads"
+	// GetProjectUploadPath is the endpoint to get the offset of a project upload by ID
+	GetProjectUploadPath = "/uploads/:id"
+	// AppendProjectUploadPath is the endpoint to append a chunk to a project upload by ID
+	AppendProjectUploadPath = "/uploads/:id"
+	// DeleteProjectUploadPath is the endpoint to delete a project upload by ID
+	DeleteProjectUploadPath = "/uploads/:id"
 
 	// TaskBasePath is the base path for all task-related endpoints
 	TaskBasePath = "/tasks"
 	// CreateTaskAnsiblePlaybookPath is the endpoint to create a new Ansible playbook task
 	CreateTaskAnsiblePlaybookPath = "/tasks/ansible-playbook/:project_id"
+	// CancelTaskPath is the endpoint to cancel a task by ID
+	CancelTaskPath = "/tasks/:id/cancel"
 	// GetTaskPath is the endpoint to get a task by ID
 	GetTaskPath = "/tasks/:id"
+	// GetTaskOutputPath is the endpoint to get the output of a task by ID
+	GetTaskOutputPath = "/tasks/:id/output"
+	// GetTaskOutputStreamPath is the endpoint to stream the output of a task by ID
+	GetTaskOutputStreamPath = "/tasks/:id/output/stream"
 	// GetTasksPath is the endpoint to list all tasks
 	GetTasksPath = "/tasks"
+	// GetTaskJanitorStatsPath is the endpoint to get the statistics of the last run of the task janitor
+	GetTaskJanitorStatsPath = "/janitor/tasks"
 
 	// GetHealthPath is the endpoint to check the health of the service
 	GetHealthPath = "/health"
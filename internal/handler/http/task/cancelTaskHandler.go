@@ -0,0 +1,127 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	domainerror "github.com/apenella/ransidble/internal/domain/core/error"
+	"github.com/apenella/ransidble/internal/domain/core/mapper"
+	"github.com/apenella/ransidble/internal/domain/core/model/response"
+	"github.com/apenella/ransidble/internal/domain/ports/repository"
+	"github.com/apenella/ransidble/internal/domain/ports/service"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// ErrCancelTaskServiceNotInitialized represents an error when the CancelTaskService is not initialized
+	ErrCancelTaskServiceNotInitialized = "cancel task service not initialized"
+	// ErrCancellingTask represents an error executing the method cancelling a task
+	ErrCancellingTask = "error cancelling task"
+)
+
+// CancelTaskHandler is a handler for cancelling a task
+type CancelTaskHandler struct {
+	service service.CancelTaskServicer
+	logger  repository.Logger
+}
+
+// NewCancelTaskHandler creates a new CancelTaskHandler
+func NewCancelTaskHandler(s service.CancelTaskServicer, logger repository.Logger) *CancelTaskHandler {
+	return &CancelTaskHandler{
+		service: s,
+		logger:  logger,
+	}
+}
+
+// Handle handles the request to cancel a task
+func (h *CancelTaskHandler) Handle(c echo.Context) error {
+
+	var errorResponse *response.TaskErrorResponse
+	var errorMsg string
+	var httpStatus int
+	var taskNotCancellableErr *domainerror.TaskNotCancellableError
+	var taskNotFoundErr *domainerror.TaskNotFoundError
+	var taskNotProvidedErr *domainerror.TaskNotProvidedError
+
+	if h.service == nil {
+		errorResponse = &response.TaskErrorResponse{
+			Error:  ErrCancelTaskServiceNotInitialized,
+			Status: http.StatusInternalServerError,
+		}
+
+		h.logger.Error(
+			ErrCancelTaskServiceNotInitialized,
+			map[string]interface{}{
+				"component": "CancelTaskHandler.Handle",
+				"package":   "github.com/apenella/ransidble/internal/handler/http/task",
+			})
+		return c.JSON(http.StatusInternalServerError, errorResponse)
+	}
+
+	id := c.Param("id")
+	if id == "" {
+
+		errorResponse = &response.TaskErrorResponse{
+			Error:  ErrTaskIDNotProvided,
+			Status: http.StatusBadRequest,
+		}
+		h.logger.Error(
+			ErrTaskIDNotProvided,
+			map[string]interface{}{
+				"component": "CancelTaskHandler.Handle",
+				"package":   "github.com/apenella/ransidble/internal/handler/http/task",
+			})
+
+		return c.JSON(http.StatusBadRequest, errorResponse)
+	}
+
+	h.logger.Debug(
+		fmt.Sprintf("cancelling task %s", id),
+		map[string]interface{}{
+			"component": "CancelTaskHandler.Handle",
+			"package":   "github.com/apenella/ransidble/internal/handler/http/task",
+			"task_id":   id,
+		})
+
+	task, err := h.service.CancelTask(id)
+	if err != nil {
+
+		httpStatus = http.StatusInternalServerError
+
+		if errors.As(err, &taskNotFoundErr) {
+			httpStatus = http.StatusNotFound
+		}
+
+		if errors.As(err, &taskNotProvidedErr) {
+			httpStatus = http.StatusBadRequest
+		}
+
+		if errors.As(err, &taskNotCancellableErr) {
+			httpStatus = http.StatusConflict
+		}
+
+		errorMsg = fmt.Sprintf("%s: %s", ErrCancellingTask, err.Error())
+
+		errorResponse = &response.TaskErrorResponse{
+			ID:     id,
+			Error:  errorMsg,
+			Status: httpStatus,
+		}
+
+		h.logger.Error(
+			errorMsg,
+			map[string]interface{}{
+				"component": "CancelTaskHandler.Handle",
+				"package":   "github.com/apenella/ransidble/internal/handler/http/task",
+				"task_id":   id,
+			})
+		return c.JSON(httpStatus, errorResponse)
+	}
+
+	taskMapper := mapper.NewTaskMapper()
+	taskResponse := taskMapper.ToTaskResponse(task)
+
+	// the task is cancelled but the commands it runs could still be terminating
+	return c.JSON(http.StatusAccepted, taskResponse)
+}
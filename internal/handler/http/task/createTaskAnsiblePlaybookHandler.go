@@ -51,6 +51,7 @@ func (h *CreateTaskAnsiblePlaybookHandler) Handle(c echo.Context) error {
 	var httpStatus int
 	var projectNotFoundErr *domainerror.ProjectNotFoundError
 	var projectNotProvidedErr *domainerror.ProjectNotProvidedError
+	var taskInvalidParametersErr *domainerror.TaskInvalidParametersError
 	var requestParameters request.AnsiblePlaybookParameters
 	var taskErrorResponseStatus int
 
@@ -126,6 +127,7 @@ func (h *CreateTaskAnsiblePlaybookHandler) Handle(c echo.Context) error {
 	}
 
 	task := entity.NewTask(taskID, projectID, entity.AnsiblePlaybookCommand, parameters)
+	task.ProjectVersion = requestParameters.ProjectVersion
 
 	h.logger.Debug(
 		fmt.Sprintf("creating task %s to run an Ansible playbook on project %s\n", taskID, projectID),
@@ -151,6 +153,11 @@ func (h *CreateTaskAnsiblePlaybookHandler) Handle(c echo.Context) error {
 			taskErrorResponseStatus = http.StatusBadRequest
 		}
 
+		if errors.As(err, &taskInvalidParametersErr) {
+			httpStatus = http.StatusBadRequest
+			taskErrorResponseStatus = http.StatusBadRequest
+		}
+
 		errorMsg = fmt.Sprintf("%s: %s", ErrRunningAnsiblePlaybook, err.Error())
 		errorResponse = &response.TaskErrorResponse{
 			Error:  errorMsg,
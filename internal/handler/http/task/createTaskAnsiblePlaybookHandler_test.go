@@ -9,6 +9,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/apenella/ransidble/internal/domain/core/entity"
 	"github.com/apenella/ransidble/internal/domain/core/error"
 	"github.com/apenella/ransidble/internal/domain/core/model/request"
 	"github.com/apenella/ransidble/internal/domain/core/model/response"
@@ -120,7 +121,7 @@ func TestHandle_CreateTaskAnsiblePlaybookHandler(t *testing.T) {
 				var body *response.TaskErrorResponse
 				expectedBody := &response.TaskErrorResponse{
 					// This is a weak test because it depend on the error message returned by the validation
-					Error:  fmt.Sprintf("%s: %s", ErrInvalidRequestPayload, "Key: 'AnsiblePlaybookParameters.Playbooks' Error:Field validation for 'Playbooks' failed on the 'required' tag\nKey: 'AnsiblePlaybookParameters.Inventory' Error:Field validation for 'Inventory' failed on the 'required' tag"),
+					Error:  fmt.Sprintf("%s: %s", ErrInvalidRequestPayload, "Key: 'AnsiblePlaybookParameters.Playbooks' Error:Field validation for 'Playbooks' failed on the 'required' tag"),
 					Status: http.StatusBadRequest,
 				}
 				err := json.Unmarshal(rec.Body.Bytes(), &body)
@@ -263,6 +264,52 @@ func TestHandle_CreateTaskAnsiblePlaybookHandler(t *testing.T) {
 				assert.Equal(t, http.StatusBadRequest, rec.Code)
 			},
 		},
+		// Testing CreateTaskAnsiblePlaybookHandler.Handle responding with an error when receiving a TaskInvalidParametersError error from the Run method and is returning a StatusBadRequest
+		{
+			desc: "Testing CreateTaskAnsiblePlaybookHandler.Handle responding with an error when receiving a TaskInvalidParametersError error from the Run method and is returning a StatusBadRequest",
+			handler: NewCreateTaskAnsiblePlaybookHandler(
+				service.NewMockAnsiblePlaybookService(),
+				logger.NewFakeLogger(),
+			),
+			method: http.MethodPost,
+			path:   "/tasks/ansible-playbook/1",
+			arrangeContextFunc: func(r *http.Request, w http.ResponseWriter) echo.Context {
+				// The error for this test case is forced when the Run method returns a TaskInvalidParametersError error
+
+				requestParameters := &request.AnsiblePlaybookParameters{
+					Playbooks:        []string{"playbook.yml"},
+					Inventory:        "inventory.yml",
+					ExecutionTimeout: 120,
+				}
+
+				body, _ := json.Marshal(requestParameters)
+				// The overrided request provides a proper JSON payload. The MIME type is also provided
+				r = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(string(body)))
+				r.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+				c := echo.New().NewContext(r, w)
+				c.SetParamNames("project_id")
+				c.SetParamValues("1")
+
+				return c
+			},
+			arrangeTestFunc: func(h *CreateTaskAnsiblePlaybookHandler) {
+				h.service.(*service.MockAnsiblePlaybookService).On("GenerateID").Return("testing_task_id")
+				h.service.(*service.MockAnsiblePlaybookService).On("Run", mock.Anything, mock.Anything).Return(
+					error.NewTaskInvalidParametersError(errors.New("testing execution timeout exceeds the maximum")),
+				)
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var body *response.TaskErrorResponse
+				expectedBody := &response.TaskErrorResponse{
+					Error:  fmt.Sprintf("%s: %s", ErrRunningAnsiblePlaybook, "testing execution timeout exceeds the maximum"),
+					Status: http.StatusBadRequest,
+				}
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				assert.Equal(t, expectedBody, body)
+				assert.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
 		{
 			desc: "Testing CreateTaskAnsiblePlaybookHandler.Handle succeeded request and is returning a StatusAccepted",
 			handler: NewCreateTaskAnsiblePlaybookHandler(
@@ -298,6 +345,41 @@ func TestHandle_CreateTaskAnsiblePlaybookHandler(t *testing.T) {
 				assert.Equal(t, fmt.Sprintf("%s/%s", serverhttp.TaskBasePath, "testing_task_id"), rec.Header().Get("Location"))
 			},
 		},
+		{
+			desc: "Testing CreateTaskAnsiblePlaybookHandler.Handle succeeded request pinning a project version and is returning a StatusAccepted",
+			handler: NewCreateTaskAnsiblePlaybookHandler(
+				service.NewMockAnsiblePlaybookService(),
+				logger.NewFakeLogger(),
+			),
+			method: http.MethodPost,
+			path:   "/tasks/ansible-playbook/1",
+			arrangeContextFunc: func(r *http.Request, w http.ResponseWriter) echo.Context {
+
+				requestParameters := &request.AnsiblePlaybookParameters{
+					Playbooks:      []string{"playbook.yml"},
+					Inventory:      "inventory.yml",
+					ProjectVersion: "v1",
+				}
+
+				body, _ := json.Marshal(requestParameters)
+				r = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(string(body)))
+				r.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+				c := echo.New().NewContext(r, w)
+				c.SetParamNames("project_id")
+				c.SetParamValues("1")
+
+				return c
+			},
+			arrangeTestFunc: func(h *CreateTaskAnsiblePlaybookHandler) {
+				h.service.(*service.MockAnsiblePlaybookService).On("GenerateID").Return("testing_task_id")
+				h.service.(*service.MockAnsiblePlaybookService).On("Run", mock.Anything, mock.MatchedBy(func(task *entity.Task) bool {
+					return task.ProjectID == "1" && task.ProjectVersion == "v1"
+				})).Return(nil)
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusAccepted, rec.Code)
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -233,6 +233,97 @@ func TestHandle_GetTaskHandler(t *testing.T) {
 				assert.Equal(t, http.StatusOK, rec.Code)
 			},
 		},
+		{
+			desc: "Testing GetTaskHandler.Handle request success with the structured result of the task and is returning an StatusOK",
+			handler: NewGetTaskHandler(
+				service.NewMockGetTaskService(),
+				logger.NewFakeLogger(),
+			),
+			method: http.MethodGet,
+			path:   "/tasks/task-id",
+			arrangeContextFunc: func(r *http.Request, w http.ResponseWriter) echo.Context {
+				c := echo.New().NewContext(r, w)
+				c.SetParamNames("id")
+				c.SetParamValues("1")
+				return c
+			},
+			arrangeTestFunc: func(h *GetTaskHandler) {
+				h.service.(*service.MockGetTaskService).On("GetTask", "1").Return(
+					&entity.Task{
+						ID:        "1",
+						ProjectID: "project1",
+						Command:   entity.AnsiblePlaybookCommand,
+						Parameters: &entity.AnsiblePlaybookParameters{
+							Playbooks:        []string{"playbook.yml"},
+							Inventory:        "inventory.yml",
+							StructuredResult: true,
+						},
+						CompletedAt:  "0000-01-01T01:01:01",
+						CreatedAt:    "0000-01-01T01:01:01",
+						ErrorMessage: "error running ansible playbook",
+						ExecutedAt:   "0000-01-01T01:01:01",
+						Result: &entity.TaskResult{
+							FailedTasks: []*entity.TaskResultFailedTask{
+								{
+									Host:    "127.0.0.1",
+									Message: "non-zero return code",
+									Module:  "ansible.builtin.command",
+									Play:    "play",
+									Task:    "command",
+								},
+							},
+							Hosts: map[string]*entity.TaskResultHostRecap{
+								"127.0.0.1": {
+									Failed: 1,
+									Ok:     1,
+								},
+							},
+						},
+						Status: entity.FAILED,
+					},
+					nil,
+				)
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var body *response.TaskResponse
+				expectedBody := &response.TaskResponse{
+					ID:        "1",
+					ProjectID: "project1",
+					Command:   entity.AnsiblePlaybookCommand,
+					Parameters: map[string]interface{}{
+						"playbooks":         []interface{}{"playbook.yml"},
+						"inventory":         "inventory.yml",
+						"structured_result": true,
+					},
+					CompletedAt:  "0000-01-01T01:01:01",
+					CreatedAt:    "0000-01-01T01:01:01",
+					ErrorMessage: "error running ansible playbook",
+					ExecutedAt:   "0000-01-01T01:01:01",
+					Result: &response.TaskResultResponse{
+						FailedTasks: []*response.TaskResultFailedTaskResponse{
+							{
+								Host:    "127.0.0.1",
+								Message: "non-zero return code",
+								Module:  "ansible.builtin.command",
+								Play:    "play",
+								Task:    "command",
+							},
+						},
+						Hosts: map[string]*response.TaskResultHostRecapResponse{
+							"127.0.0.1": {
+								Failed: 1,
+								Ok:     1,
+							},
+						},
+					},
+					Status: entity.FAILED,
+				}
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				assert.Equal(t, expectedBody, body)
+				assert.Equal(t, http.StatusOK, rec.Code)
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -0,0 +1,56 @@
+package task
+
+import (
+	"net/http"
+
+	"github.com/apenella/ransidble/internal/domain/core/mapper"
+	"github.com/apenella/ransidble/internal/domain/core/model/response"
+	"github.com/apenella/ransidble/internal/domain/ports/repository"
+	"github.com/apenella/ransidble/internal/domain/ports/service"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// ErrTaskJanitorServiceNotInitialized represents an error when the TaskJanitorService is not initialized
+	ErrTaskJanitorServiceNotInitialized = "task janitor service not initialized"
+)
+
+// GetTaskJanitorStatsHandler is a handler for getting the statistics of the last run of the task janitor
+type GetTaskJanitorStatsHandler struct {
+	service service.TaskJanitorServicer
+	logger  repository.Logger
+}
+
+// NewGetTaskJanitorStatsHandler creates a new GetTaskJanitorStatsHandler
+func NewGetTaskJanitorStatsHandler(s service.TaskJanitorServicer, logger repository.Logger) *GetTaskJanitorStatsHandler {
+	return &GetTaskJanitorStatsHandler{
+		service: s,
+		logger:  logger,
+	}
+}
+
+// Handle handles the request to get the statistics of the last run of the task janitor
+func (h *GetTaskJanitorStatsHandler) Handle(c echo.Context) error {
+
+	var errorResponse *response.TaskErrorResponse
+
+	if h.service == nil {
+		errorResponse = &response.TaskErrorResponse{
+			Error:  ErrTaskJanitorServiceNotInitialized,
+			Status: http.StatusInternalServerError,
+		}
+
+		h.logger.Error(
+			ErrTaskJanitorServiceNotInitialized,
+			map[string]interface{}{
+				"component": "GetTaskJanitorStatsHandler.Handle",
+				"package":   "github.com/apenella/ransidble/internal/handler/http/task",
+			})
+		return c.JSON(http.StatusInternalServerError, errorResponse)
+	}
+
+	taskMapper := mapper.NewTaskMapper()
+	statsResponse := taskMapper.ToTaskJanitorStatsResponse(h.service.GetLastRunStats())
+
+	return c.JSON(http.StatusOK, statsResponse)
+}
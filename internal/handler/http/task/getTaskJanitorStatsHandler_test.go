@@ -0,0 +1,135 @@
+package task
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	"github.com/apenella/ransidble/internal/domain/core/model/response"
+	"github.com/apenella/ransidble/internal/domain/ports/service"
+	"github.com/apenella/ransidble/internal/infrastructure/logger"
+	"github.com/apenella/ransidble/test/openapi"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandle_GetTaskJanitorStatsHandler(t *testing.T) {
+
+	openAPIValidator, err := openapi.PrepareOpenAPIValidator("../../../../api/openapi.yaml")
+	if err != nil {
+		t.Errorf("Error initializing OpenAPI validator: %s", err)
+		t.FailNow()
+		return
+	}
+
+	tests := []struct {
+		desc            string
+		handler         *GetTaskJanitorStatsHandler
+		method          string
+		path            string
+		arrangeTestFunc func(h *GetTaskJanitorStatsHandler)
+		assertTestFunc  func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			desc: "Testing GetTaskJanitorStatsHandler.Handle responding with an error when service not initialized and is returning an StatusInternalServerError",
+			handler: NewGetTaskJanitorStatsHandler(
+				nil,
+				logger.NewFakeLogger(),
+			),
+			method: http.MethodGet,
+			path:   "/janitor/tasks",
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var body *response.TaskErrorResponse
+				expectedBody := &response.TaskErrorResponse{
+					Error:  ErrTaskJanitorServiceNotInitialized,
+					Status: http.StatusInternalServerError,
+				}
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				assert.Equal(t, expectedBody, body)
+				assert.Equal(t, http.StatusInternalServerError, rec.Code)
+			},
+		},
+		{
+			desc: "Testing GetTaskJanitorStatsHandler.Handle request success when the janitor has not run yet and is returning an StatusOK",
+			handler: NewGetTaskJanitorStatsHandler(
+				service.NewMockTaskJanitorService(),
+				logger.NewFakeLogger(),
+			),
+			method: http.MethodGet,
+			path:   "/janitor/tasks",
+			arrangeTestFunc: func(h *GetTaskJanitorStatsHandler) {
+				h.service.(*service.MockTaskJanitorService).On("GetLastRunStats").Return(&entity.TaskJanitorStats{})
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var body *response.TaskJanitorStatsResponse
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				assert.Equal(t, &response.TaskJanitorStatsResponse{}, body)
+				assert.Equal(t, http.StatusOK, rec.Code)
+			},
+		},
+		{
+			desc: "Testing GetTaskJanitorStatsHandler.Handle request success and is returning an StatusOK",
+			handler: NewGetTaskJanitorStatsHandler(
+				service.NewMockTaskJanitorService(),
+				logger.NewFakeLogger(),
+			),
+			method: http.MethodGet,
+			path:   "/janitor/tasks",
+			arrangeTestFunc: func(h *GetTaskJanitorStatsHandler) {
+				h.service.(*service.MockTaskJanitorService).On("GetLastRunStats").Return(&entity.TaskJanitorStats{
+					CompletedAt:   time.Date(2026, 1, 1, 10, 0, 5, 0, time.UTC),
+					Errors:        1,
+					PurgedOutputs: 2,
+					PurgedTasks:   3,
+					Runs:          4,
+					StartedAt:     time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC),
+				})
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var body *response.TaskJanitorStatsResponse
+				expectedBody := &response.TaskJanitorStatsResponse{
+					CompletedAt:   "2026-01-01T10:00:05Z",
+					Errors:        1,
+					PurgedOutputs: 2,
+					PurgedTasks:   3,
+					Runs:          4,
+					StartedAt:     "2026-01-01T10:00:00Z",
+				}
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				assert.Equal(t, expectedBody, body)
+				assert.Equal(t, http.StatusOK, rec.Code)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest(test.method, test.path, nil)
+		rec := httptest.NewRecorder()
+
+		context := echo.New().NewContext(req, rec)
+
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.arrangeTestFunc != nil {
+				test.arrangeTestFunc(test.handler)
+			}
+
+			err := test.handler.Handle(context)
+			assert.NoError(t, err)
+			test.assertTestFunc(t, rec)
+		})
+
+		t.Run(fmt.Sprintf("OpenAPI %s", test.desc), func(t *testing.T) {
+			err := openAPIValidator.ValidateResponse(rec.Body.Bytes(), req, rec.Code, rec.Header())
+			assert.NoError(t, err)
+		})
+	}
+}
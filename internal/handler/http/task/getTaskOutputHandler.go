@@ -0,0 +1,119 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	domainerror "github.com/apenella/ransidble/internal/domain/core/error"
+	"github.com/apenella/ransidble/internal/domain/core/model/response"
+	"github.com/apenella/ransidble/internal/domain/ports/repository"
+	"github.com/apenella/ransidble/internal/domain/ports/service"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// ErrGetTaskOutputServiceNotInitialized represents an error when the GetTaskOutputService is not initialized
+	ErrGetTaskOutputServiceNotInitialized = "get task output service not initialized"
+	// ErrGettingTaskOutput represents an error executing the method getting task output
+	ErrGettingTaskOutput = "error getting task output"
+)
+
+// GetTaskOutputHandler is a handler for getting the output of a task
+type GetTaskOutputHandler struct {
+	service service.GetTaskOutputServicer
+	logger  repository.Logger
+}
+
+// NewGetTaskOutputHandler creates a new GetTaskOutputHandler
+func NewGetTaskOutputHandler(s service.GetTaskOutputServicer, logger repository.Logger) *GetTaskOutputHandler {
+	return &GetTaskOutputHandler{
+		service: s,
+		logger:  logger,
+	}
+}
+
+// Handle handles the request to get the output of a task
+func (h *GetTaskOutputHandler) Handle(c echo.Context) error {
+
+	var errorResponse *response.TaskErrorResponse
+	var errorMsg string
+	var httpStatus int
+	var taskNotFoundErr *domainerror.TaskNotFoundError
+	var taskNotProvidedErr *domainerror.TaskNotProvidedError
+	var taskErrorResponseStatus int
+
+	if h.service == nil {
+		errorResponse = &response.TaskErrorResponse{
+			Error:  ErrGetTaskOutputServiceNotInitialized,
+			Status: http.StatusInternalServerError,
+		}
+
+		h.logger.Error(
+			ErrGetTaskOutputServiceNotInitialized,
+			map[string]interface{}{
+				"component": "GetTaskOutputHandler.Handle",
+				"package":   "github.com/apenella/ransidble/internal/handler/http/task",
+			})
+		return c.JSON(http.StatusInternalServerError, errorResponse)
+	}
+
+	id := c.Param("id")
+	if id == "" {
+
+		errorResponse = &response.TaskErrorResponse{
+			Error:  ErrTaskIDNotProvided,
+			Status: http.StatusBadRequest,
+		}
+		h.logger.Error(
+			ErrTaskIDNotProvided,
+			map[string]interface{}{
+				"component": "GetTaskOutputHandler.Handle",
+				"package":   "github.com/apenella/ransidble/internal/handler/http/task",
+			})
+
+		return c.JSON(http.StatusBadRequest, errorResponse)
+	}
+
+	h.logger.Debug(
+		fmt.Sprintf("getting task output %s\n", id),
+		map[string]interface{}{
+			"component": "GetTaskOutputHandler.Handle",
+			"package":   "github.com/apenella/ransidble/internal/handler/http/task",
+			"task_id":   id,
+		})
+	output, err := h.service.GetTaskOutput(id)
+	if err != nil {
+
+		httpStatus = http.StatusInternalServerError
+		taskErrorResponseStatus = http.StatusInternalServerError
+
+		if errors.As(err, &taskNotFoundErr) {
+			httpStatus = http.StatusNotFound
+			taskErrorResponseStatus = http.StatusNotFound
+		}
+
+		if errors.As(err, &taskNotProvidedErr) {
+			httpStatus = http.StatusBadRequest
+			taskErrorResponseStatus = http.StatusBadRequest
+		}
+
+		errorMsg = fmt.Sprintf("%s: %s", ErrGettingTaskOutput, err.Error())
+
+		errorResponse = &response.TaskErrorResponse{
+			Error:  errorMsg,
+			Status: taskErrorResponseStatus,
+		}
+
+		h.logger.Error(
+			errorMsg,
+			map[string]interface{}{
+				"component": "GetTaskOutputHandler.Handle",
+				"package":   "github.com/apenella/ransidble/internal/handler/http/task",
+				"task_id":   id,
+			})
+		return c.JSON(httpStatus, errorResponse)
+	}
+
+	return c.Blob(http.StatusOK, echo.MIMETextPlainCharsetUTF8, output)
+}
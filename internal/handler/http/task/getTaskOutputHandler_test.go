@@ -0,0 +1,231 @@
+package task
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/apenella/ransidble/internal/domain/core/error"
+	"github.com/apenella/ransidble/internal/domain/core/model/response"
+	"github.com/apenella/ransidble/internal/domain/ports/service"
+	"github.com/apenella/ransidble/internal/infrastructure/logger"
+	"github.com/apenella/ransidble/test/openapi"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandle_GetTaskOutputHandler(t *testing.T) {
+
+	openAPIValidator, err := openapi.PrepareOpenAPIValidator("../../../../api/openapi.yaml")
+	if err != nil {
+		t.Errorf("Error initializing OpenAPI validator: %s", err)
+		t.FailNow()
+		return
+	}
+
+	tests := []struct {
+		desc               string
+		handler            *GetTaskOutputHandler
+		method             string
+		path               string
+		arrangeContextFunc func(r *http.Request, w http.ResponseWriter) echo.Context
+		arrangeTestFunc    func(h *GetTaskOutputHandler)
+		assertTestFunc     func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			desc: "Testing GetTaskOutputHandler.Handle responding with an error when service not initialized and is returning an StatusInternalServerError",
+			handler: NewGetTaskOutputHandler(
+				nil,
+				logger.NewFakeLogger(),
+			),
+			method: http.MethodGet,
+			path:   "/tasks/task-id/output",
+			arrangeContextFunc: func(r *http.Request, w http.ResponseWriter) echo.Context {
+				return echo.New().NewContext(r, w)
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var body *response.TaskErrorResponse
+				expectedBody := &response.TaskErrorResponse{
+					Error:  ErrGetTaskOutputServiceNotInitialized,
+					Status: http.StatusInternalServerError,
+				}
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				assert.Equal(t, expectedBody, body)
+				assert.Equal(t, http.StatusInternalServerError, rec.Code)
+			},
+		},
+		{
+			desc: "Testing GetTaskOutputHandler.Handle responding with an error when task id not provided and is returning an StatusBadRequest",
+			handler: NewGetTaskOutputHandler(
+				service.NewMockGetTaskOutputService(),
+				logger.NewFakeLogger(),
+			),
+			method: http.MethodGet,
+			path:   "/tasks/1/output",
+			arrangeContextFunc: func(r *http.Request, w http.ResponseWriter) echo.Context {
+				return echo.New().NewContext(r, w)
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var body *response.TaskErrorResponse
+				expectedBody := &response.TaskErrorResponse{
+					Error:  ErrTaskIDNotProvided,
+					Status: http.StatusBadRequest,
+				}
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				assert.Equal(t, expectedBody, body)
+				assert.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			desc: "Testing GetTaskOutputHandler.Handle responding with an error when task not found and is returning an StatusNotFound",
+			handler: NewGetTaskOutputHandler(
+				service.NewMockGetTaskOutputService(),
+				logger.NewFakeLogger(),
+			),
+			method: http.MethodGet,
+			path:   "/tasks/1/output",
+			arrangeContextFunc: func(r *http.Request, w http.ResponseWriter) echo.Context {
+				c := echo.New().NewContext(r, w)
+				c.SetParamNames("id")
+				c.SetParamValues("1")
+				return c
+			},
+			arrangeTestFunc: func(h *GetTaskOutputHandler) {
+				h.service.(*service.MockGetTaskOutputService).On("GetTaskOutput", "1").Return(
+					nil,
+					error.NewTaskNotFoundError(errors.New("testing task not found error")),
+				)
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var body *response.TaskErrorResponse
+				expectedBody := &response.TaskErrorResponse{
+					Error:  fmt.Errorf("%s: %s", ErrGettingTaskOutput, "testing task not found error").Error(),
+					Status: http.StatusNotFound,
+				}
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				assert.Equal(t, expectedBody, body)
+				assert.Equal(t, http.StatusNotFound, rec.Code)
+			},
+		},
+		{
+			desc: "Testing GetTaskOutputHandler.Handle responding with an error when receiving a task not provided error and is returning an StatusBadRequest",
+			handler: NewGetTaskOutputHandler(
+				service.NewMockGetTaskOutputService(),
+				logger.NewFakeLogger(),
+			),
+			method: http.MethodGet,
+			path:   "/tasks/1/output",
+			arrangeContextFunc: func(r *http.Request, w http.ResponseWriter) echo.Context {
+				c := echo.New().NewContext(r, w)
+				c.SetParamNames("id")
+				c.SetParamValues("1")
+				return c
+			},
+			arrangeTestFunc: func(h *GetTaskOutputHandler) {
+				h.service.(*service.MockGetTaskOutputService).On("GetTaskOutput", "1").Return(
+					nil,
+					error.NewTaskNotProvidedError(errors.New("testing task not provided error")),
+				)
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var body *response.TaskErrorResponse
+				expectedBody := &response.TaskErrorResponse{
+					Error:  fmt.Errorf("%s: %s", ErrGettingTaskOutput, "testing task not provided error").Error(),
+					Status: http.StatusBadRequest,
+				}
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				assert.Equal(t, expectedBody, body)
+				assert.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			desc: "Testing GetTaskOutputHandler.Handle responding with an error when gets a task output unknown error and is returning an StatusInternalServerError",
+			handler: NewGetTaskOutputHandler(
+				service.NewMockGetTaskOutputService(),
+				logger.NewFakeLogger(),
+			),
+			method: http.MethodGet,
+			path:   "/tasks/1/output",
+			arrangeContextFunc: func(r *http.Request, w http.ResponseWriter) echo.Context {
+				c := echo.New().NewContext(r, w)
+				c.SetParamNames("id")
+				c.SetParamValues("1")
+				return c
+			},
+			arrangeTestFunc: func(h *GetTaskOutputHandler) {
+				h.service.(*service.MockGetTaskOutputService).On("GetTaskOutput", "1").Return(
+					nil,
+					errors.New("testing task output unknown error"),
+				)
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var body *response.TaskErrorResponse
+				expectedBody := &response.TaskErrorResponse{
+					Error:  fmt.Errorf("%s: %s", ErrGettingTaskOutput, "testing task output unknown error").Error(),
+					Status: http.StatusInternalServerError,
+				}
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				assert.Equal(t, expectedBody, body)
+				assert.Equal(t, http.StatusInternalServerError, rec.Code)
+			},
+		},
+		{
+			desc: "Testing GetTaskOutputHandler.Handle request success and is returning an StatusOK",
+			handler: NewGetTaskOutputHandler(
+				service.NewMockGetTaskOutputService(),
+				logger.NewFakeLogger(),
+			),
+			method: http.MethodGet,
+			path:   "/tasks/1/output",
+			arrangeContextFunc: func(r *http.Request, w http.ResponseWriter) echo.Context {
+				c := echo.New().NewContext(r, w)
+				c.SetParamNames("id")
+				c.SetParamValues("1")
+				return c
+			},
+			arrangeTestFunc: func(h *GetTaskOutputHandler) {
+				h.service.(*service.MockGetTaskOutputService).On("GetTaskOutput", "1").Return(
+					[]byte("PLAY [all] ***\n"),
+					nil,
+				)
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				assert.Equal(t, "PLAY [all] ***\n", rec.Body.String())
+				assert.Equal(t, echo.MIMETextPlainCharsetUTF8, rec.Header().Get(echo.HeaderContentType))
+				assert.Equal(t, http.StatusOK, rec.Code)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest(test.method, test.path, nil)
+		rec := httptest.NewRecorder()
+
+		context := test.arrangeContextFunc(req, rec)
+
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.arrangeTestFunc != nil {
+				test.arrangeTestFunc(test.handler)
+			}
+
+			err := test.handler.Handle(context)
+			assert.NoError(t, err)
+			test.assertTestFunc(t, rec)
+		})
+
+		t.Run(fmt.Sprintf("OpenAPI %s", test.desc), func(t *testing.T) {
+			err := openAPIValidator.ValidateResponse(rec.Body.Bytes(), req, rec.Code, rec.Header())
+			assert.NoError(t, err)
+		})
+	}
+}
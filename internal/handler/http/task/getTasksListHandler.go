@@ -0,0 +1,137 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	domainerror "github.com/apenella/ransidble/internal/domain/core/error"
+	"github.com/apenella/ransidble/internal/domain/core/mapper"
+	"github.com/apenella/ransidble/internal/domain/core/model/request"
+	"github.com/apenella/ransidble/internal/domain/core/model/response"
+	"github.com/apenella/ransidble/internal/domain/ports/repository"
+	"github.com/apenella/ransidble/internal/domain/ports/service"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// ErrBindingTaskQueryParameters represents an error when binding the task query parameters
+	ErrBindingTaskQueryParameters = "error binding task query parameters"
+	// ErrInvalidTaskQueryParameters represents an error when the task query parameters are not valid
+	ErrInvalidTaskQueryParameters = "invalid task query parameters"
+	// ErrGettingTasksList represents an error executing the method getting the list of tasks
+	ErrGettingTasksList = "error getting tasks list"
+)
+
+// GetTasksListHandler is a handler for listing tasks
+type GetTasksListHandler struct {
+	service service.GetTaskServicer
+	logger  repository.Logger
+}
+
+// NewGetTasksListHandler creates a new GetTasksListHandler
+func NewGetTasksListHandler(s service.GetTaskServicer, logger repository.Logger) *GetTasksListHandler {
+	return &GetTasksListHandler{
+		service: s,
+		logger:  logger,
+	}
+}
+
+// Handle handles the request to list the tasks
+func (h *GetTasksListHandler) Handle(c echo.Context) error {
+
+	var err error
+	var errorMsg string
+	var errorResponse *response.TaskErrorResponse
+	var httpStatus int
+	var queryParameters request.TaskQueryParameters
+	var taskErrorResponseStatus int
+	var taskInvalidQueryErr *domainerror.TaskInvalidQueryError
+
+	if h.service == nil {
+		errorResponse = &response.TaskErrorResponse{
+			Error:  ErrGetTaskServiceNotInitialized,
+			Status: http.StatusInternalServerError,
+		}
+
+		h.logger.Error(
+			ErrGetTaskServiceNotInitialized,
+			map[string]interface{}{
+				"component": "GetTasksListHandler.Handle",
+				"package":   "github.com/apenella/ransidble/internal/handler/http/task",
+			})
+		return c.JSON(http.StatusInternalServerError, errorResponse)
+	}
+
+	err = c.Bind(&queryParameters)
+	if err != nil {
+		errorMsg = fmt.Sprintf("%s: %s", ErrBindingTaskQueryParameters, err.Error())
+		errorResponse = &response.TaskErrorResponse{
+			Error:  errorMsg,
+			Status: http.StatusBadRequest,
+		}
+		h.logger.Error(
+			errorMsg,
+			map[string]interface{}{
+				"component": "GetTasksListHandler.Handle",
+				"package":   "github.com/apenella/ransidble/internal/handler/http/task",
+			})
+		return c.JSON(http.StatusBadRequest, errorResponse)
+	}
+
+	err = queryParameters.Validate()
+	if err != nil {
+		errorMsg = fmt.Sprintf("%s: %s", ErrInvalidTaskQueryParameters, err.Error())
+		errorResponse = &response.TaskErrorResponse{
+			Error:  errorMsg,
+			Status: http.StatusBadRequest,
+		}
+		h.logger.Error(
+			errorMsg,
+			map[string]interface{}{
+				"component": "GetTasksListHandler.Handle",
+				"package":   "github.com/apenella/ransidble/internal/handler/http/task",
+			})
+		return c.JSON(http.StatusBadRequest, errorResponse)
+	}
+
+	taskQueryMapper := mapper.NewTaskQueryMapper()
+	query := taskQueryMapper.ToTaskQueryEntity(&queryParameters)
+
+	h.logger.Debug(
+		"getting task list",
+		map[string]interface{}{
+			"component": "GetTasksListHandler.Handle",
+			"package":   "github.com/apenella/ransidble/internal/handler/http/task",
+		})
+
+	page, err := h.service.GetTasksList(query)
+	if err != nil {
+		httpStatus = http.StatusInternalServerError
+		taskErrorResponseStatus = http.StatusInternalServerError
+
+		if errors.As(err, &taskInvalidQueryErr) {
+			httpStatus = http.StatusBadRequest
+			taskErrorResponseStatus = http.StatusBadRequest
+		}
+
+		errorMsg = fmt.Sprintf("%s: %s", ErrGettingTasksList, err.Error())
+		errorResponse = &response.TaskErrorResponse{
+			Error:  errorMsg,
+			Status: taskErrorResponseStatus,
+		}
+
+		h.logger.Error(
+			errorMsg,
+			map[string]interface{}{
+				"component": "GetTasksListHandler.Handle",
+				"package":   "github.com/apenella/ransidble/internal/handler/http/task",
+			})
+		return c.JSON(httpStatus, errorResponse)
+	}
+
+	taskMapper := mapper.NewTaskMapper()
+	taskListResponse := taskMapper.ToTaskListResponse(page)
+
+	return c.JSON(http.StatusOK, taskListResponse)
+}
@@ -0,0 +1,253 @@
+package task
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	"github.com/apenella/ransidble/internal/domain/core/error"
+	"github.com/apenella/ransidble/internal/domain/core/model/response"
+	"github.com/apenella/ransidble/internal/domain/ports/service"
+	"github.com/apenella/ransidble/internal/infrastructure/logger"
+	"github.com/apenella/ransidble/test/openapi"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandle_GetTasksListHandler(t *testing.T) {
+
+	openAPIValidator, err := openapi.PrepareOpenAPIValidator("../../../../api/openapi.yaml")
+	if err != nil {
+		t.Errorf("Error initializing OpenAPI validator: %s", err)
+		t.FailNow()
+		return
+	}
+
+	tests := []struct {
+		desc               string
+		handler            *GetTasksListHandler
+		method             string
+		path               string
+		arrangeContextFunc func(r *http.Request, w http.ResponseWriter) echo.Context
+		arrangeTestFunc    func(h *GetTasksListHandler)
+		assertTestFunc     func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			desc: "Testing GetTasksListHandler.Handle responding with an error when service not initialized and is returning an StatusInternalServerError",
+			handler: NewGetTasksListHandler(
+				nil,
+				logger.NewFakeLogger(),
+			),
+			method: http.MethodGet,
+			path:   "/tasks",
+			arrangeContextFunc: func(r *http.Request, w http.ResponseWriter) echo.Context {
+				return echo.New().NewContext(r, w)
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var body *response.TaskErrorResponse
+				expectedBody := &response.TaskErrorResponse{
+					Error:  ErrGetTaskServiceNotInitialized,
+					Status: http.StatusInternalServerError,
+				}
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				assert.Equal(t, expectedBody, body)
+				assert.Equal(t, http.StatusInternalServerError, rec.Code)
+			},
+		},
+		{
+			desc: "Testing GetTasksListHandler.Handle responding with an error when query parameters binding fails and is returning an StatusBadRequest",
+			handler: NewGetTasksListHandler(
+				service.NewMockGetTaskService(),
+				logger.NewFakeLogger(),
+			),
+			method: http.MethodGet,
+			path:   "/tasks?limit=many",
+			arrangeContextFunc: func(r *http.Request, w http.ResponseWriter) echo.Context {
+				return echo.New().NewContext(r, w)
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var body *response.TaskErrorResponse
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				assert.True(t, strings.HasPrefix(body.Error, ErrBindingTaskQueryParameters))
+				assert.Equal(t, http.StatusBadRequest, body.Status)
+				assert.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			desc: "Testing GetTasksListHandler.Handle responding with an error when query parameters validation fails and is returning an StatusBadRequest",
+			handler: NewGetTasksListHandler(
+				service.NewMockGetTaskService(),
+				logger.NewFakeLogger(),
+			),
+			method: http.MethodGet,
+			path:   "/tasks?sort_by=id",
+			arrangeContextFunc: func(r *http.Request, w http.ResponseWriter) echo.Context {
+				return echo.New().NewContext(r, w)
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var body *response.TaskErrorResponse
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				assert.True(t, strings.HasPrefix(body.Error, ErrInvalidTaskQueryParameters))
+				assert.Equal(t, http.StatusBadRequest, body.Status)
+				assert.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			desc: "Testing GetTasksListHandler.Handle responding with an error when receiving a TaskInvalidQueryError error and is returning an StatusBadRequest",
+			handler: NewGetTasksListHandler(
+				service.NewMockGetTaskService(),
+				logger.NewFakeLogger(),
+			),
+			method: http.MethodGet,
+			path:   "/tasks?cursor=invalid",
+			arrangeContextFunc: func(r *http.Request, w http.ResponseWriter) echo.Context {
+				return echo.New().NewContext(r, w)
+			},
+			arrangeTestFunc: func(h *GetTasksListHandler) {
+				h.service.(*service.MockGetTaskService).On("GetTasksList", mock.Anything).Return(
+					nil,
+					error.NewTaskInvalidQueryError(errors.New("testing invalid task query")),
+				)
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var body *response.TaskErrorResponse
+				expectedBody := &response.TaskErrorResponse{
+					Error:  fmt.Sprintf("%s: %s", ErrGettingTasksList, "testing invalid task query"),
+					Status: http.StatusBadRequest,
+				}
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				assert.Equal(t, expectedBody, body)
+				assert.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			desc: "Testing GetTasksListHandler.Handle responding with an error when gets an unknown error and is returning an StatusInternalServerError",
+			handler: NewGetTasksListHandler(
+				service.NewMockGetTaskService(),
+				logger.NewFakeLogger(),
+			),
+			method: http.MethodGet,
+			path:   "/tasks",
+			arrangeContextFunc: func(r *http.Request, w http.ResponseWriter) echo.Context {
+				return echo.New().NewContext(r, w)
+			},
+			arrangeTestFunc: func(h *GetTasksListHandler) {
+				h.service.(*service.MockGetTaskService).On("GetTasksList", entity.NewTaskQuery()).Return(
+					nil,
+					errors.New("testing unknown error"),
+				)
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var body *response.TaskErrorResponse
+				expectedBody := &response.TaskErrorResponse{
+					Error:  fmt.Sprintf("%s: %s", ErrGettingTasksList, "testing unknown error"),
+					Status: http.StatusInternalServerError,
+				}
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				assert.Equal(t, expectedBody, body)
+				assert.Equal(t, http.StatusInternalServerError, rec.Code)
+			},
+		},
+		{
+			desc: "Testing GetTasksListHandler.Handle request success and is returning an StatusOK",
+			handler: NewGetTasksListHandler(
+				service.NewMockGetTaskService(),
+				logger.NewFakeLogger(),
+			),
+			method: http.MethodGet,
+			path:   "/tasks?project_id=project1&status=SUCCESS,FAILED&sort_by=completed_at&order=asc&limit=1",
+			arrangeContextFunc: func(r *http.Request, w http.ResponseWriter) echo.Context {
+				return echo.New().NewContext(r, w)
+			},
+			arrangeTestFunc: func(h *GetTasksListHandler) {
+				h.service.(*service.MockGetTaskService).On("GetTasksList", &entity.TaskQuery{
+					Limit:     1,
+					Order:     entity.TaskSortOrderAsc,
+					ProjectID: "project1",
+					SortBy:    entity.TaskSortByCompletedAt,
+					Status:    []string{entity.SUCCESS, entity.FAILED},
+				}).Return(
+					&entity.TaskPage{
+						NextCursor: "next-cursor",
+						Tasks: []*entity.Task{
+							{
+								ID:        "1",
+								ProjectID: "project1",
+								Command:   entity.AnsiblePlaybookCommand,
+								Parameters: &entity.AnsiblePlaybookParameters{
+									Playbooks: []string{"playbook.yml"},
+									Inventory: "inventory.yml",
+								},
+								CompletedAt: "2026-01-01T10:02:00Z",
+								CreatedAt:   "2026-01-01T10:00:00Z",
+								ExecutedAt:  "2026-01-01T10:01:00Z",
+								Status:      entity.SUCCESS,
+							},
+						},
+					},
+					nil,
+				)
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var body *response.TaskListResponse
+				expectedBody := &response.TaskListResponse{
+					NextCursor: "next-cursor",
+					Tasks: []*response.TaskResponse{
+						{
+							ID:        "1",
+							ProjectID: "project1",
+							Command:   entity.AnsiblePlaybookCommand,
+							Parameters: map[string]interface{}{
+								"playbooks": []interface{}{"playbook.yml"},
+								"inventory": "inventory.yml",
+							},
+							CompletedAt: "2026-01-01T10:02:00Z",
+							CreatedAt:   "2026-01-01T10:00:00Z",
+							ExecutedAt:  "2026-01-01T10:01:00Z",
+							Status:      entity.SUCCESS,
+						},
+					},
+				}
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				assert.Equal(t, expectedBody, body)
+				assert.Equal(t, http.StatusOK, rec.Code)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest(test.method, test.path, nil)
+		rec := httptest.NewRecorder()
+
+		context := test.arrangeContextFunc(req, rec)
+
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.arrangeTestFunc != nil {
+				test.arrangeTestFunc(test.handler)
+			}
+
+			err := test.handler.Handle(context)
+			assert.NoError(t, err)
+			test.assertTestFunc(t, rec)
+		})
+
+		t.Run(fmt.Sprintf("OpenAPI %s", test.desc), func(t *testing.T) {
+			err := openAPIValidator.ValidateResponse(rec.Body.Bytes(), req, rec.Code, rec.Header())
+			assert.NoError(t, err)
+		})
+	}
+}
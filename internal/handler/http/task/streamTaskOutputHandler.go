@@ -0,0 +1,248 @@
+package task
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	domainerror "github.com/apenella/ransidble/internal/domain/core/error"
+	"github.com/apenella/ransidble/internal/domain/core/mapper"
+	"github.com/apenella/ransidble/internal/domain/core/model/response"
+	"github.com/apenella/ransidble/internal/domain/ports/repository"
+	"github.com/apenella/ransidble/internal/domain/ports/service"
+	"github.com/labstack/echo/v4"
+	"golang.org/x/net/websocket"
+)
+
+const (
+	// ErrStreamTaskOutputServiceNotInitialized represents an error when the StreamTaskOutputService is not initialized
+	ErrStreamTaskOutputServiceNotInitialized = "stream task output service not initialized"
+	// ErrStreamingTaskOutput represents an error executing the method streaming task output
+	ErrStreamingTaskOutput = "error streaming task output"
+	// ErrInvalidTaskOutputOffset represents an error when the offset to start streaming from is not valid
+	ErrInvalidTaskOutputOffset = "task output offset must be a non-negative integer"
+
+	// MIMETextEventStream is the content type of the Server-Sent Events responses
+	MIMETextEventStream = "text/event-stream"
+	// TaskOutputOffsetQueryParam is the query parameter to set the byte offset to start streaming from
+	TaskOutputOffsetQueryParam = "offset"
+	// HeaderLastEventID is the header sent by the Server-Sent Events clients when they reconnect
+	HeaderLastEventID = "Last-Event-ID"
+	// TaskOutputErrorEventType is the type of the Server-Sent Event sent when the stream fails after it is started
+	TaskOutputErrorEventType = "error"
+)
+
+// StreamTaskOutputHandler is a handler for streaming the output of a task over Server-Sent Events or WebSocket
+type StreamTaskOutputHandler struct {
+	service service.StreamTaskOutputServicer
+	logger  repository.Logger
+}
+
+// NewStreamTaskOutputHandler creates a new StreamTaskOutputHandler
+func NewStreamTaskOutputHandler(s service.StreamTaskOutputServicer, logger repository.Logger) *StreamTaskOutputHandler {
+	return &StreamTaskOutputHandler{
+		service: s,
+		logger:  logger,
+	}
+}
+
+// Handle handles the request to stream the output of a task. The request is upgraded to WebSocket when the client asks for it, otherwise the output is streamed using Server-Sent Events
+func (h *StreamTaskOutputHandler) Handle(c echo.Context) error {
+
+	var errorResponse *response.TaskErrorResponse
+
+	if h.service == nil {
+		errorResponse = &response.TaskErrorResponse{
+			Error:  ErrStreamTaskOutputServiceNotInitialized,
+			Status: http.StatusInternalServerError,
+		}
+
+		h.logger.Error(
+			ErrStreamTaskOutputServiceNotInitialized,
+			map[string]interface{}{
+				"component": "StreamTaskOutputHandler.Handle",
+				"package":   "github.com/apenella/ransidble/internal/handler/http/task",
+			})
+		return c.JSON(http.StatusInternalServerError, errorResponse)
+	}
+
+	id := c.Param("id")
+	if id == "" {
+		errorResponse = &response.TaskErrorResponse{
+			Error:  ErrTaskIDNotProvided,
+			Status: http.StatusBadRequest,
+		}
+		h.logger.Error(
+			ErrTaskIDNotProvided,
+			map[string]interface{}{
+				"component": "StreamTaskOutputHandler.Handle",
+				"package":   "github.com/apenella/ransidble/internal/handler/http/task",
+			})
+
+		return c.JSON(http.StatusBadRequest, errorResponse)
+	}
+
+	offset, err := taskOutputOffset(c)
+	if err != nil {
+		errorResponse = &response.TaskErrorResponse{
+			ID:     id,
+			Error:  ErrInvalidTaskOutputOffset,
+			Status: http.StatusBadRequest,
+		}
+		h.logger.Error(
+			ErrInvalidTaskOutputOffset,
+			map[string]interface{}{
+				"component": "StreamTaskOutputHandler.Handle",
+				"package":   "github.com/apenella/ransidble/internal/handler/http/task",
+				"task_id":   id,
+			})
+
+		return c.JSON(http.StatusBadRequest, errorResponse)
+	}
+
+	h.logger.Debug(
+		fmt.Sprintf("streaming task output %s from offset %d\n", id, offset),
+		map[string]interface{}{
+			"component": "StreamTaskOutputHandler.Handle",
+			"package":   "github.com/apenella/ransidble/internal/handler/http/task",
+			"task_id":   id,
+		})
+
+	if c.IsWebSocket() {
+		return h.handleWebSocket(c, id, offset)
+	}
+
+	return h.handleServerSentEvents(c, id, offset)
+}
+
+// handleServerSentEvents streams the task output using Server-Sent Events. The response headers are written along with the first event, so errors found before that are responded as any other error
+func (h *StreamTaskOutputHandler) handleServerSentEvents(c echo.Context, id string, offset int64) error {
+
+	started := false
+	res := c.Response()
+
+	err := h.service.StreamTaskOutput(c.Request().Context(), id, offset, func(event *entity.TaskOutputEvent) error {
+		if !started {
+			res.Header().Set(echo.HeaderContentType, MIMETextEventStream)
+			res.Header().Set(echo.HeaderCacheControl, "no-cache")
+			res.Header().Set(echo.HeaderConnection, "keep-alive")
+			res.WriteHeader(http.StatusOK)
+			started = true
+		}
+
+		data := event.Data
+		if event.Type == entity.TaskOutputEventTypeStatus {
+			data = event.Status
+		}
+
+		_, err := fmt.Fprintf(res, "id: %d\nevent: %s\ndata: %s\n\n", event.Offset, event.Type, data)
+		if err != nil {
+			return err
+		}
+		res.Flush()
+
+		return nil
+	})
+	if err != nil {
+		errorResponse, httpStatus := h.streamErrorResponse(id, err)
+
+		if !started {
+			return c.JSON(httpStatus, errorResponse)
+		}
+
+		data, errMarshal := json.Marshal(errorResponse)
+		if errMarshal != nil {
+			return nil
+		}
+		_, _ = fmt.Fprintf(res, "event: %s\ndata: %s\n\n", TaskOutputErrorEventType, data)
+		res.Flush()
+	}
+
+	return nil
+}
+
+// handleWebSocket streams the task output over a WebSocket connection. Each event is sent as a JSON message, and the connection is closed once the stream finishes
+func (h *StreamTaskOutputHandler) handleWebSocket(c echo.Context, id string, offset int64) error {
+
+	taskMapper := mapper.NewTaskMapper()
+
+	// the origin is not checked because the API is not meant to be consumed from browsers
+	server := websocket.Server{
+		Handler: func(ws *websocket.Conn) {
+			defer ws.Close()
+
+			err := h.service.StreamTaskOutput(ws.Request().Context(), id, offset, func(event *entity.TaskOutputEvent) error {
+				return websocket.JSON.Send(ws, taskMapper.ToTaskOutputEventResponse(event))
+			})
+			if err != nil {
+				errorResponse, _ := h.streamErrorResponse(id, err)
+				_ = websocket.JSON.Send(ws, errorResponse)
+			}
+		},
+	}
+
+	server.ServeHTTP(c.Response(), c.Request())
+
+	return nil
+}
+
+// streamErrorResponse returns the error response and the HTTP status for an error streaming the task output
+func (h *StreamTaskOutputHandler) streamErrorResponse(id string, err error) (*response.TaskErrorResponse, int) {
+
+	var taskNotFoundErr *domainerror.TaskNotFoundError
+	var taskNotProvidedErr *domainerror.TaskNotProvidedError
+
+	httpStatus := http.StatusInternalServerError
+
+	if errors.As(err, &taskNotFoundErr) {
+		httpStatus = http.StatusNotFound
+	}
+
+	if errors.As(err, &taskNotProvidedErr) {
+		httpStatus = http.StatusBadRequest
+	}
+
+	errorMsg := fmt.Sprintf("%s: %s", ErrStreamingTaskOutput, err.Error())
+
+	h.logger.Error(
+		errorMsg,
+		map[string]interface{}{
+			"component": "StreamTaskOutputHandler.Handle",
+			"package":   "github.com/apenella/ransidble/internal/handler/http/task",
+			"task_id":   id,
+		})
+
+	return &response.TaskErrorResponse{
+		Error:  errorMsg,
+		Status: httpStatus,
+	}, httpStatus
+}
+
+// taskOutputOffset returns the byte offset to start streaming from. The offset query parameter takes precedence over the Last-Event-ID header, which is sent by the Server-Sent Events clients when they reconnect
+func taskOutputOffset(c echo.Context) (int64, error) {
+
+	value := c.QueryParam(TaskOutputOffsetQueryParam)
+	if value == "" {
+		value = c.Request().Header.Get(HeaderLastEventID)
+	}
+
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+
+	offset, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	if offset < 0 {
+		return 0, fmt.Errorf("%s", ErrInvalidTaskOutputOffset)
+	}
+
+	return offset, nil
+}
@@ -0,0 +1,355 @@
+package task
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	domainerror "github.com/apenella/ransidble/internal/domain/core/error"
+	"github.com/apenella/ransidble/internal/domain/core/model/response"
+	"github.com/apenella/ransidble/internal/domain/ports/service"
+	"github.com/apenella/ransidble/internal/infrastructure/logger"
+	"github.com/apenella/ransidble/test/openapi"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/net/websocket"
+)
+
+// sendTaskOutputEvents returns a function to be used on the service mock that sends the events to the stream
+func sendTaskOutputEvents(events ...*entity.TaskOutputEvent) func(args mock.Arguments) {
+	return func(args mock.Arguments) {
+		send := args.Get(3).(func(event *entity.TaskOutputEvent) error)
+		for _, event := range events {
+			_ = send(event)
+		}
+	}
+}
+
+func TestHandle_StreamTaskOutputHandler(t *testing.T) {
+
+	openAPIValidator, err := openapi.PrepareOpenAPIValidator("../../../../api/openapi.yaml")
+	if err != nil {
+		t.Errorf("Error initializing OpenAPI validator: %s", err)
+		t.FailNow()
+		return
+	}
+
+	tests := []struct {
+		desc               string
+		handler            *StreamTaskOutputHandler
+		method             string
+		path               string
+		headers            map[string]string
+		arrangeContextFunc func(r *http.Request, w http.ResponseWriter) echo.Context
+		arrangeTestFunc    func(h *StreamTaskOutputHandler)
+		assertTestFunc     func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			desc: "Testing StreamTaskOutputHandler.Handle responding with an error when service not initialized and is returning an StatusInternalServerError",
+			handler: NewStreamTaskOutputHandler(
+				nil,
+				logger.NewFakeLogger(),
+			),
+			method: http.MethodGet,
+			path:   "/tasks/task-id/output/stream",
+			arrangeContextFunc: func(r *http.Request, w http.ResponseWriter) echo.Context {
+				return echo.New().NewContext(r, w)
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var body *response.TaskErrorResponse
+				expectedBody := &response.TaskErrorResponse{
+					Error:  ErrStreamTaskOutputServiceNotInitialized,
+					Status: http.StatusInternalServerError,
+				}
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				assert.Equal(t, expectedBody, body)
+				assert.Equal(t, http.StatusInternalServerError, rec.Code)
+			},
+		},
+		{
+			desc: "Testing StreamTaskOutputHandler.Handle responding with an error when task id not provided and is returning an StatusBadRequest",
+			handler: NewStreamTaskOutputHandler(
+				service.NewMockStreamTaskOutputService(),
+				logger.NewFakeLogger(),
+			),
+			method: http.MethodGet,
+			path:   "/tasks/1/output/stream",
+			arrangeContextFunc: func(r *http.Request, w http.ResponseWriter) echo.Context {
+				return echo.New().NewContext(r, w)
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var body *response.TaskErrorResponse
+				expectedBody := &response.TaskErrorResponse{
+					Error:  ErrTaskIDNotProvided,
+					Status: http.StatusBadRequest,
+				}
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				assert.Equal(t, expectedBody, body)
+				assert.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			desc: "Testing StreamTaskOutputHandler.Handle responding with an error when the offset is not valid and is returning an StatusBadRequest",
+			handler: NewStreamTaskOutputHandler(
+				service.NewMockStreamTaskOutputService(),
+				logger.NewFakeLogger(),
+			),
+			method: http.MethodGet,
+			path:   "/tasks/1/output/stream?offset=invalid",
+			arrangeContextFunc: func(r *http.Request, w http.ResponseWriter) echo.Context {
+				c := echo.New().NewContext(r, w)
+				c.SetParamNames("id")
+				c.SetParamValues("1")
+				return c
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var body *response.TaskErrorResponse
+				expectedBody := &response.TaskErrorResponse{
+					ID:     "1",
+					Error:  ErrInvalidTaskOutputOffset,
+					Status: http.StatusBadRequest,
+				}
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				assert.Equal(t, expectedBody, body)
+				assert.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			desc: "Testing StreamTaskOutputHandler.Handle responding with an error when task not found and is returning an StatusNotFound",
+			handler: NewStreamTaskOutputHandler(
+				service.NewMockStreamTaskOutputService(),
+				logger.NewFakeLogger(),
+			),
+			method: http.MethodGet,
+			path:   "/tasks/1/output/stream",
+			arrangeContextFunc: func(r *http.Request, w http.ResponseWriter) echo.Context {
+				c := echo.New().NewContext(r, w)
+				c.SetParamNames("id")
+				c.SetParamValues("1")
+				return c
+			},
+			arrangeTestFunc: func(h *StreamTaskOutputHandler) {
+				h.service.(*service.MockStreamTaskOutputService).On("StreamTaskOutput", mock.Anything, "1", int64(0), mock.Anything).Return(
+					domainerror.NewTaskNotFoundError(errors.New("testing task not found error")),
+				)
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var body *response.TaskErrorResponse
+				expectedBody := &response.TaskErrorResponse{
+					Error:  fmt.Errorf("%s: %s", ErrStreamingTaskOutput, "testing task not found error").Error(),
+					Status: http.StatusNotFound,
+				}
+				err := json.Unmarshal(rec.Body.Bytes(), &body)
+				assert.NoError(t, err)
+				assert.Equal(t, expectedBody, body)
+				assert.Equal(t, http.StatusNotFound, rec.Code)
+			},
+		},
+		{
+			desc: "Testing StreamTaskOutputHandler.Handle streaming the task output as Server-Sent Events and is returning an StatusOK",
+			handler: NewStreamTaskOutputHandler(
+				service.NewMockStreamTaskOutputService(),
+				logger.NewFakeLogger(),
+			),
+			method: http.MethodGet,
+			path:   "/tasks/1/output/stream?offset=6",
+			arrangeContextFunc: func(r *http.Request, w http.ResponseWriter) echo.Context {
+				c := echo.New().NewContext(r, w)
+				c.SetParamNames("id")
+				c.SetParamValues("1")
+				return c
+			},
+			arrangeTestFunc: func(h *StreamTaskOutputHandler) {
+				h.service.(*service.MockStreamTaskOutputService).On("StreamTaskOutput", mock.Anything, "1", int64(6), mock.Anything).Run(
+					sendTaskOutputEvents(
+						entity.NewTaskOutputLineEvent("line2", 12),
+						entity.NewTaskOutputStatusEvent(entity.SUCCESS, 12),
+					),
+				).Return(nil)
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				expectedBody := "id: 12\nevent: output\ndata: line2\n\nid: 12\nevent: status\ndata: SUCCESS\n\n"
+				assert.Equal(t, expectedBody, rec.Body.String())
+				assert.Equal(t, MIMETextEventStream, rec.Header().Get(echo.HeaderContentType))
+				assert.Equal(t, http.StatusOK, rec.Code)
+			},
+		},
+		{
+			desc: "Testing StreamTaskOutputHandler.Handle resuming the stream from the Last-Event-ID header and is returning an StatusOK",
+			handler: NewStreamTaskOutputHandler(
+				service.NewMockStreamTaskOutputService(),
+				logger.NewFakeLogger(),
+			),
+			method: http.MethodGet,
+			path:   "/tasks/1/output/stream",
+			headers: map[string]string{
+				HeaderLastEventID: "12",
+			},
+			arrangeContextFunc: func(r *http.Request, w http.ResponseWriter) echo.Context {
+				c := echo.New().NewContext(r, w)
+				c.SetParamNames("id")
+				c.SetParamValues("1")
+				return c
+			},
+			arrangeTestFunc: func(h *StreamTaskOutputHandler) {
+				h.service.(*service.MockStreamTaskOutputService).On("StreamTaskOutput", mock.Anything, "1", int64(12), mock.Anything).Run(
+					sendTaskOutputEvents(
+						entity.NewTaskOutputStatusEvent(entity.FAILED, 12),
+					),
+				).Return(nil)
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				expectedBody := "id: 12\nevent: status\ndata: FAILED\n\n"
+				assert.Equal(t, expectedBody, rec.Body.String())
+				assert.Equal(t, http.StatusOK, rec.Code)
+			},
+		},
+		{
+			desc: "Testing StreamTaskOutputHandler.Handle sending an error event when the stream fails after it is started and is returning an StatusOK",
+			handler: NewStreamTaskOutputHandler(
+				service.NewMockStreamTaskOutputService(),
+				logger.NewFakeLogger(),
+			),
+			method: http.MethodGet,
+			path:   "/tasks/1/output/stream",
+			arrangeContextFunc: func(r *http.Request, w http.ResponseWriter) echo.Context {
+				c := echo.New().NewContext(r, w)
+				c.SetParamNames("id")
+				c.SetParamValues("1")
+				return c
+			},
+			arrangeTestFunc: func(h *StreamTaskOutputHandler) {
+				h.service.(*service.MockStreamTaskOutputService).On("StreamTaskOutput", mock.Anything, "1", int64(0), mock.Anything).Run(
+					sendTaskOutputEvents(
+						entity.NewTaskOutputStatusEvent(entity.RUNNING, 0),
+					),
+				).Return(errors.New("testing task output unknown error"))
+			},
+			assertTestFunc: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				expectedBody := "id: 0\nevent: status\ndata: RUNNING\n\n" +
+					"event: error\ndata: {\"id\":\"\",\"error\":\"error streaming task output: testing task output unknown error\",\"status\":500}\n\n"
+				assert.Equal(t, expectedBody, rec.Body.String())
+				assert.Equal(t, http.StatusOK, rec.Code)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest(test.method, test.path, nil)
+		for key, value := range test.headers {
+			req.Header.Set(key, value)
+		}
+		rec := httptest.NewRecorder()
+
+		context := test.arrangeContextFunc(req, rec)
+
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.arrangeTestFunc != nil {
+				test.arrangeTestFunc(test.handler)
+			}
+
+			err := test.handler.Handle(context)
+			assert.NoError(t, err)
+			test.assertTestFunc(t, rec)
+		})
+
+		t.Run(fmt.Sprintf("OpenAPI %s", test.desc), func(t *testing.T) {
+			err := openAPIValidator.ValidateResponse(rec.Body.Bytes(), req, rec.Code, rec.Header())
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestHandle_StreamTaskOutputHandlerWebSocket(t *testing.T) {
+
+	tests := []struct {
+		desc            string
+		handler         *StreamTaskOutputHandler
+		path            string
+		arrangeTestFunc func(h *StreamTaskOutputHandler)
+		expected        []string
+	}{
+		{
+			desc: "Testing StreamTaskOutputHandler.Handle streaming the task output over WebSocket",
+			handler: NewStreamTaskOutputHandler(
+				service.NewMockStreamTaskOutputService(),
+				logger.NewFakeLogger(),
+			),
+			path: "/tasks/1/output/stream",
+			arrangeTestFunc: func(h *StreamTaskOutputHandler) {
+				h.service.(*service.MockStreamTaskOutputService).On("StreamTaskOutput", mock.Anything, "1", int64(0), mock.Anything).Run(
+					sendTaskOutputEvents(
+						entity.NewTaskOutputLineEvent("line1", 6),
+						entity.NewTaskOutputStatusEvent(entity.SUCCESS, 6),
+					),
+				).Return(nil)
+			},
+			expected: []string{
+				"{\"data\":\"line1\",\"offset\":6,\"type\":\"output\"}",
+				"{\"offset\":6,\"status\":\"SUCCESS\",\"type\":\"status\"}",
+			},
+		},
+		{
+			desc: "Testing StreamTaskOutputHandler.Handle sending an error message over WebSocket when the task is not found",
+			handler: NewStreamTaskOutputHandler(
+				service.NewMockStreamTaskOutputService(),
+				logger.NewFakeLogger(),
+			),
+			path: "/tasks/1/output/stream?offset=6",
+			arrangeTestFunc: func(h *StreamTaskOutputHandler) {
+				h.service.(*service.MockStreamTaskOutputService).On("StreamTaskOutput", mock.Anything, "1", int64(6), mock.Anything).Return(
+					domainerror.NewTaskNotFoundError(errors.New("testing task not found error")),
+				)
+			},
+			expected: []string{
+				"{\"id\":\"\",\"error\":\"error streaming task output: testing task not found error\",\"status\":404}",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.arrangeTestFunc != nil {
+				test.arrangeTestFunc(test.handler)
+			}
+
+			router := echo.New()
+			router.GET("/tasks/:id/output/stream", test.handler.Handle)
+			server := httptest.NewServer(router)
+			defer server.Close()
+
+			url := "ws" + strings.TrimPrefix(server.URL, "http") + test.path
+			ws, err := websocket.Dial(url, "", server.URL)
+			if err != nil {
+				t.Errorf("Error connecting to WebSocket: %s", err)
+				return
+			}
+			defer ws.Close()
+
+			messages := []string{}
+			for {
+				var message string
+				err = websocket.Message.Receive(ws, &message)
+				if err != nil {
+					break
+				}
+				messages = append(messages, strings.TrimSpace(message))
+			}
+
+			assert.Equal(t, test.expected, messages)
+		})
+	}
+}
@@ -0,0 +1,388 @@
+package browse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	"github.com/apenella/ransidble/internal/domain/ports/repository"
+	"github.com/spf13/afero"
+)
+
+const (
+	// DefaultTimeout is the default time limit to fetch and unpack the source code of a project
+	DefaultTimeout = 5 * time.Minute
+	// workingDirPrefix is the prefix of the temporary working directories where the source code is fetched to be browsed
+	workingDirPrefix = "ransidble-browse"
+)
+
+// Browser reads the stored source code of the projects. The source code is fetched, and unpacked when the project tree is browsed, into a temporary working directory that is removed once the content is read
+type Browser struct {
+	// fetchFactory returns the fetcher to get the source code from the project storage
+	fetchFactory repository.SourceCodeFetchFactory
+	// fs is the filesystem
+	fs afero.Fs
+	// logger is the logger
+	logger repository.Logger
+	// timeout is the time limit to fetch and unpack the source code. Zero means no time limit
+	timeout time.Duration
+	// unpackFactory returns the unpacker to unpack the source code
+	unpackFactory repository.SourceCodeUnpackFactory
+}
+
+// Ensure Browser implements the SourceCodeBrowser interface
+var _ repository.SourceCodeBrowser = (*Browser)(nil)
+
+// Ensure Browser implements the SourceCodeDiscoverer interface
+var _ repository.SourceCodeDiscoverer = (*Browser)(nil)
+
+// NewBrowser method creates a new Browser struct
+func NewBrowser(fs afero.Fs, fetchFactory repository.SourceCodeFetchFactory, unpackFactory repository.SourceCodeUnpackFactory, logger repository.Logger) *Browser {
+	return &Browser{
+		fetchFactory:  fetchFactory,
+		fs:            fs,
+		logger:        logger,
+		timeout:       DefaultTimeout,
+		unpackFactory: unpackFactory,
+	}
+}
+
+// WithTimeout method sets the time limit to fetch and unpack the source code. Zero means no time limit
+func (b *Browser) WithTimeout(timeout time.Duration) *Browser {
+	b.timeout = timeout
+	return b
+}
+
+// Content method returns the stored source code of the project, as it is fetched from the project storage. It returns an error when the source code is not stored as a single file, such as the projects stored in a git repository
+func (b *Browser) Content(ctx context.Context, project *entity.Project) (*entity.ProjectContent, error) {
+
+	ctx, cancel := b.withTimeout(ctx)
+	defer cancel()
+
+	workingDir, err := b.fetch(ctx, project)
+	if err != nil {
+		return nil, err
+	}
+
+	sourceCodeFile := filepath.Join(workingDir, project.Reference)
+	info, err := b.fs.Stat(sourceCodeFile)
+	if err != nil || info.IsDir() {
+		b.removeWorkingDir(workingDir)
+		b.logger.Error(entity.ErrProjectContentNotArchived.Error(),
+			map[string]interface{}{
+				"component":       "Browser.Content",
+				"package":         "github.com/apenella/ransidble/internal/infrastructure/browse",
+				"project_id":      project.Name,
+				"project_version": project.Version,
+				"reference":       project.Reference,
+			})
+		return nil, fmt.Errorf("%w: %s", entity.ErrProjectContentNotArchived, project.Reference)
+	}
+
+	return b.open(workingDir, sourceCodeFile, info)
+}
+
+// Files method returns the entries of the unpacked project tree, sorted by their path. The stored archive is not part of the project tree
+func (b *Browser) Files(ctx context.Context, project *entity.Project) ([]*entity.ProjectFile, error) {
+
+	ctx, cancel := b.withTimeout(ctx)
+	defer cancel()
+
+	workingDir, err := b.unpack(ctx, project)
+	if err != nil {
+		return nil, err
+	}
+	defer b.removeWorkingDir(workingDir)
+
+	files := []*entity.ProjectFile{}
+	err = afero.Walk(b.fs, workingDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if path == workingDir || b.isSourceCodeFile(project, workingDir, path) {
+			return nil
+		}
+
+		relPath, err := filepath.Rel(workingDir, path)
+		if err != nil {
+			return err
+		}
+
+		file := &entity.ProjectFile{
+			Path: filepath.ToSlash(relPath),
+			Type: entity.ProjectFileTypeFile,
+		}
+
+		switch {
+		case info.Mode()&os.ModeSymlink != 0:
+			file.Type = entity.ProjectFileTypeSymlink
+		case info.IsDir():
+			file.Type = entity.ProjectFileTypeDirectory
+		default:
+			file.Size = info.Size()
+		}
+
+		files = append(files, file)
+
+		return nil
+	})
+	if err != nil {
+		b.logger.Error(
+			fmt.Sprintf("%s: %s", ErrListingSourceCodeFiles, err),
+			map[string]interface{}{
+				"component":  "Browser.Files",
+				"package":    "github.com/apenella/ransidble/internal/infrastructure/browse",
+				"project_id": project.Name,
+			})
+		return nil, fmt.Errorf("%w: %w", ErrListingSourceCodeFiles, err)
+	}
+
+	return files, nil
+}
+
+// ReadFile method returns a regular file of the unpacked project tree. The path is relative to the root of the project tree, and neither the symbolic links nor the stored archive are read
+func (b *Browser) ReadFile(ctx context.Context, project *entity.Project, path string) (*entity.ProjectContent, error) {
+
+	cleanPath, err := entity.CleanProjectFilePath(path)
+	if err != nil {
+		b.logger.Error(err.Error(),
+			map[string]interface{}{
+				"component": "Browser.ReadFile",
+				"package":   "github.com/apenella/ransidble/internal/infrastructure/browse",
+				"path":      path,
+			})
+		return nil, err
+	}
+
+	ctx, cancel := b.withTimeout(ctx)
+	defer cancel()
+
+	workingDir, err := b.unpack(ctx, project)
+	if err != nil {
+		return nil, err
+	}
+
+	filePath := filepath.Join(workingDir, filepath.FromSlash(cleanPath))
+	info, err := b.lstatTree(workingDir, cleanPath)
+	if err != nil || !info.Mode().IsRegular() || b.isSourceCodeFile(project, workingDir, filePath) {
+		b.removeWorkingDir(workingDir)
+		b.logger.Error(entity.ErrProjectFileNotFound.Error(),
+			map[string]interface{}{
+				"component":  "Browser.ReadFile",
+				"package":    "github.com/apenella/ransidble/internal/infrastructure/browse",
+				"path":       cleanPath,
+				"project_id": project.Name,
+			})
+		return nil, fmt.Errorf("%w: %s", entity.ErrProjectFileNotFound, cleanPath)
+	}
+
+	return b.open(workingDir, filePath, info)
+}
+
+// withTimeout returns a context bound to the time limit to fetch and unpack the source code. The context is only used while the source code is fetched and unpacked, so the returned content can still be read once it is cancelled
+func (b *Browser) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if b.timeout > 0 {
+		return context.WithTimeout(ctx, b.timeout)
+	}
+
+	return context.WithCancel(ctx)
+}
+
+// fetch fetches the source code of the project into a new temporary working directory, which is removed when the source code cannot be fetched
+func (b *Browser) fetch(ctx context.Context, project *entity.Project) (string, error) {
+
+	if project == nil {
+		b.logger.Error(ErrProjectNotProvided.Error(),
+			map[string]interface{}{
+				"component": "Browser.fetch",
+				"package":   "github.com/apenella/ransidble/internal/infrastructure/browse",
+			})
+		return "", ErrProjectNotProvided
+	}
+
+	if b.fs == nil {
+		b.logger.Error(ErrFilesystemNotProvided.Error(),
+			map[string]interface{}{
+				"component":  "Browser.fetch",
+				"package":    "github.com/apenella/ransidble/internal/infrastructure/browse",
+				"project_id": project.Name,
+			})
+		return "", ErrFilesystemNotProvided
+	}
+
+	var fetcher repository.SourceCodeFetcher
+	if b.fetchFactory != nil {
+		fetcher = b.fetchFactory.Get(project.Storage)
+	}
+	if fetcher == nil {
+		b.logger.Error(ErrSourceCodeFetcherNotAvailable.Error(),
+			map[string]interface{}{
+				"component":  "Browser.fetch",
+				"package":    "github.com/apenella/ransidble/internal/infrastructure/browse",
+				"project_id": project.Name,
+				"storage":    project.Storage,
+			})
+		return "", fmt.Errorf("%w: %s", ErrSourceCodeFetcherNotAvailable, project.Storage)
+	}
+
+	workingDir, err := afero.TempDir(b.fs, "", workingDirPrefix)
+	if err != nil {
+		b.logger.Error(
+			fmt.Sprintf("%s: %s", ErrCreatingWorkingDir, err),
+			map[string]interface{}{
+				"component":  "Browser.fetch",
+				"package":    "github.com/apenella/ransidble/internal/infrastructure/browse",
+				"project_id": project.Name,
+			})
+		return "", fmt.Errorf("%w: %w", ErrCreatingWorkingDir, err)
+	}
+
+	err = fetcher.Fetch(ctx, project, workingDir)
+	if err != nil {
+		b.removeWorkingDir(workingDir)
+		b.logger.Error(
+			fmt.Sprintf("%s: %s", ErrFetchingSourceCode, err),
+			map[string]interface{}{
+				"component":       "Browser.fetch",
+				"package":         "github.com/apenella/ransidble/internal/infrastructure/browse",
+				"project_id":      project.Name,
+				"project_version": project.Version,
+			})
+		return "", fmt.Errorf("%w: %w", ErrFetchingSourceCode, err)
+	}
+
+	return workingDir, nil
+}
+
+// unpack fetches and unpacks the source code of the project into a new temporary working directory, which is removed when the source code cannot be unpacked
+func (b *Browser) unpack(ctx context.Context, project *entity.Project) (string, error) {
+
+	workingDir, err := b.fetch(ctx, project)
+	if err != nil {
+		return "", err
+	}
+
+	var unpacker repository.SourceCodeUnpacker
+	if b.unpackFactory != nil {
+		unpacker = b.unpackFactory.Get(project.Format)
+	}
+	if unpacker == nil {
+		b.removeWorkingDir(workingDir)
+		b.logger.Error(ErrSourceCodeUnpackerNotAvailable.Error(),
+			map[string]interface{}{
+				"component":  "Browser.unpack",
+				"package":    "github.com/apenella/ransidble/internal/infrastructure/browse",
+				"format":     project.Format,
+				"project_id": project.Name,
+			})
+		return "", fmt.Errorf("%w: %s", ErrSourceCodeUnpackerNotAvailable, project.Format)
+	}
+
+	err = unpacker.Unpack(ctx, project, workingDir)
+	if err != nil {
+		b.removeWorkingDir(workingDir)
+		b.logger.Error(
+			fmt.Sprintf("%s: %s", ErrUnpackingSourceCode, err),
+			map[string]interface{}{
+				"component":       "Browser.unpack",
+				"package":         "github.com/apenella/ransidble/internal/infrastructure/browse",
+				"project_id":      project.Name,
+				"project_version": project.Version,
+			})
+		return "", fmt.Errorf("%w: %w", ErrUnpackingSourceCode, err)
+	}
+
+	return workingDir, nil
+}
+
+// open opens a file of the working directory. The working directory is removed once the file is closed or when it cannot be opened
+func (b *Browser) open(workingDir string, path string, info os.FileInfo) (*entity.ProjectContent, error) {
+
+	file, err := b.fs.Open(path)
+	if err != nil {
+		b.removeWorkingDir(workingDir)
+		b.logger.Error(
+			fmt.Sprintf("%s: %s", ErrOpeningSourceCodeFile, err),
+			map[string]interface{}{
+				"component": "Browser.open",
+				"package":   "github.com/apenella/ransidble/internal/infrastructure/browse",
+				"path":      path,
+			})
+		return nil, fmt.Errorf("%w: %w", ErrOpeningSourceCodeFile, err)
+	}
+
+	return &entity.ProjectContent{
+		Name: info.Name(),
+		Reader: &workingDirFile{
+			ReadCloser: file,
+			fs:         b.fs,
+			workingDir: workingDir,
+		},
+		Size: info.Size(),
+	}, nil
+}
+
+// lstatTree returns the file information of a path of the project tree without following any symbolic link, so the files outside the working directory cannot be reached through them
+func (b *Browser) lstatTree(workingDir string, cleanPath string) (os.FileInfo, error) {
+	var info os.FileInfo
+	var err error
+
+	currentPath := workingDir
+	elements := strings.Split(cleanPath, "/")
+	for i, element := range elements {
+		currentPath = filepath.Join(currentPath, element)
+
+		if lstater, ok := b.fs.(afero.Lstater); ok {
+			info, _, err = lstater.LstatIfPossible(currentPath)
+		} else {
+			info, err = b.fs.Stat(currentPath)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if i < len(elements)-1 && !info.IsDir() {
+			return nil, os.ErrNotExist
+		}
+	}
+
+	return info, nil
+}
+
+// isSourceCodeFile returns whether the path is the stored archive of a packed project, which is fetched into the working directory along with the project tree
+func (b *Browser) isSourceCodeFile(project *entity.Project, workingDir string, path string) bool {
+	return project.Format != entity.ProjectFormatPlain && path == filepath.Join(workingDir, project.Reference)
+}
+
+// removeWorkingDir removes a temporary working directory. The error is only logged since the content has already been served or the request has already failed
+func (b *Browser) removeWorkingDir(workingDir string) {
+	err := b.fs.RemoveAll(workingDir)
+	if err != nil {
+		b.logger.Warn(
+			fmt.Sprintf("error removing working directory: %s", err),
+			map[string]interface{}{
+				"component":   "Browser.removeWorkingDir",
+				"package":     "github.com/apenella/ransidble/internal/infrastructure/browse",
+				"working_dir": workingDir,
+			})
+	}
+}
+
+// workingDirFile is a file of a temporary working directory, which is removed once the file is closed
+type workingDirFile struct {
+	io.ReadCloser
+	fs         afero.Fs
+	workingDir string
+}
+
+// Close closes the file and removes the working directory
+func (f *workingDirFile) Close() error {
+	return errors.Join(f.ReadCloser.Close(), f.fs.RemoveAll(f.workingDir))
+}
@@ -0,0 +1,260 @@
+package browse
+
+import (
+	"context"
+	"io"
+	"path/filepath"
+	"testing"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	"github.com/apenella/ransidble/internal/domain/ports/repository"
+	"github.com/apenella/ransidble/internal/infrastructure/logger"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// newTestBrowser returns a browser whose fetcher copies a tar.gz archive into the working directory and whose unpacker writes the project tree next to it
+func newTestBrowser() *Browser {
+	fs := afero.NewMemMapFs()
+
+	fetcher := &repository.MockProjectSourceCodeFetcher{}
+	fetcher.On("Fetch", mock.Anything, mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		project := args.Get(1).(*entity.Project)
+		if project.Storage == entity.ProjectTypeGit {
+			_ = afero.WriteFile(fs, filepath.Join(args.String(2), "site.yml"), []byte("- hosts: all"), 0644)
+			return
+		}
+		_ = afero.WriteFile(fs, filepath.Join(args.String(2), project.Reference), []byte("archive content"), 0644)
+	})
+
+	unpacker := &repository.MockProjectSourceCodeUnpacker{}
+	unpacker.On("Unpack", mock.Anything, mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		_ = afero.WriteFile(fs, filepath.Join(args.String(2), "site.yml"), []byte("- hosts: all"), 0644)
+		_ = afero.WriteFile(fs, filepath.Join(args.String(2), "inventory", "hosts.yml"), []byte("all: {}"), 0644)
+	})
+
+	fetchFactory := &repository.MockProjectSourceCodeFetchFactory{}
+	fetchFactory.On("Get", entity.ProjectTypeLocal).Return(fetcher)
+	fetchFactory.On("Get", entity.ProjectTypeGit).Return(fetcher)
+	fetchFactory.On("Get", mock.Anything).Return(nil)
+
+	unpackFactory := &repository.MockProjectSourceCodeUnpackFactory{}
+	unpackFactory.On("Get", entity.ProjectFormatTarGz).Return(unpacker)
+	unpackFactory.On("Get", mock.Anything).Return(nil)
+
+	return NewBrowser(fs, fetchFactory, unpackFactory, logger.NewFakeLogger())
+}
+
+func TestBrowserContent(t *testing.T) {
+	tests := []struct {
+		desc     string
+		browser  *Browser
+		project  *entity.Project
+		expected string
+		err      error
+	}{
+		{
+			desc:     "Testing reading the stored source code of a project",
+			browser:  newTestBrowser(),
+			project:  &entity.Project{Name: "project", Format: entity.ProjectFormatTarGz, Reference: "project.tar.gz", Storage: entity.ProjectTypeLocal},
+			expected: "archive content",
+		},
+		{
+			desc:    "Testing error reading the stored source code of a project that is not stored as an archive",
+			browser: newTestBrowser(),
+			project: &entity.Project{Name: "project", Format: entity.ProjectFormatPlain, Reference: "https://github.com/apenella/project.git", Storage: entity.ProjectTypeGit},
+			err:     entity.ErrProjectContentNotArchived,
+		},
+		{
+			desc:    "Testing error reading the stored source code of a project when there is no fetcher for its storage",
+			browser: newTestBrowser(),
+			project: &entity.Project{Name: "project", Format: entity.ProjectFormatTarGz, Reference: "project.tar.gz", Storage: entity.ProjectTypeS3},
+			err:     ErrSourceCodeFetcherNotAvailable,
+		},
+		{
+			desc:    "Testing error reading the stored source code when the project is not provided",
+			browser: newTestBrowser(),
+			err:     ErrProjectNotProvided,
+		},
+		{
+			desc:    "Testing error reading the stored source code when the filesystem is not provided",
+			browser: NewBrowser(nil, nil, nil, logger.NewFakeLogger()),
+			project: &entity.Project{Name: "project"},
+			err:     ErrFilesystemNotProvided,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			content, err := test.browser.Content(context.Background(), test.project)
+			if test.err != nil {
+				assert.ErrorIs(t, err, test.err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.project.Reference, content.Name)
+			assert.Equal(t, int64(len(test.expected)), content.Size)
+
+			data, err := io.ReadAll(content.Reader)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, string(data))
+			assert.NoError(t, content.Reader.Close())
+
+			// the working directory is removed once the content is closed
+			workingDirs, err := afero.Glob(test.browser.fs, filepath.Join(afero.GetTempDir(test.browser.fs, ""), workingDirPrefix+"*"))
+			assert.NoError(t, err)
+			assert.Empty(t, workingDirs)
+		})
+	}
+}
+
+func TestBrowserFiles(t *testing.T) {
+	tests := []struct {
+		desc     string
+		browser  *Browser
+		project  *entity.Project
+		expected []*entity.ProjectFile
+		err      error
+	}{
+		{
+			desc:    "Testing listing the files of a packed project, which do not include the stored archive",
+			browser: newTestBrowser(),
+			project: &entity.Project{Name: "project", Format: entity.ProjectFormatTarGz, Reference: "project.tar.gz", Storage: entity.ProjectTypeLocal},
+			expected: []*entity.ProjectFile{
+				{Path: "inventory", Type: entity.ProjectFileTypeDirectory},
+				{Path: "inventory/hosts.yml", Size: 7, Type: entity.ProjectFileTypeFile},
+				{Path: "site.yml", Size: 12, Type: entity.ProjectFileTypeFile},
+			},
+		},
+		{
+			desc:    "Testing error listing the files of a project when there is no unpacker for its format",
+			browser: newTestBrowser(),
+			project: &entity.Project{Name: "project", Format: entity.ProjectFormatZip, Reference: "project.zip", Storage: entity.ProjectTypeLocal},
+			err:     ErrSourceCodeUnpackerNotAvailable,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			files, err := test.browser.Files(context.Background(), test.project)
+			if test.err != nil {
+				assert.ErrorIs(t, err, test.err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expected, files)
+			}
+		})
+	}
+}
+
+func TestBrowserReadFile(t *testing.T) {
+	project := &entity.Project{Name: "project", Format: entity.ProjectFormatTarGz, Reference: "project.tar.gz", Storage: entity.ProjectTypeLocal}
+
+	tests := []struct {
+		desc     string
+		path     string
+		expected string
+		err      error
+	}{
+		{desc: "Testing reading a file of the project tree", path: "site.yml", expected: "- hosts: all"},
+		{desc: "Testing reading a nested file of the project tree", path: "inventory/./hosts.yml", expected: "all: {}"},
+		{desc: "Testing error reading a file that is not in the project tree", path: "unknown.yml", err: entity.ErrProjectFileNotFound},
+		{desc: "Testing error reading a directory of the project tree", path: "inventory", err: entity.ErrProjectFileNotFound},
+		{desc: "Testing error reading the stored archive as a file of the project tree", path: "project.tar.gz", err: entity.ErrProjectFileNotFound},
+		{desc: "Testing error reading a file outside the project tree", path: "../../etc/passwd", err: entity.ErrInvalidProjectFilePath},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			content, err := newTestBrowser().ReadFile(context.Background(), project, test.path)
+			if test.err != nil {
+				assert.ErrorIs(t, err, test.err)
+				return
+			}
+
+			assert.NoError(t, err)
+			data, err := io.ReadAll(content.Reader)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, string(data))
+			assert.NoError(t, content.Reader.Close())
+		})
+	}
+}
+
+func TestBrowserFilesContext(t *testing.T) {
+	cancelledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// newContextBrowser returns a browser whose fetcher only succeeds when the context it receives is not done and has a deadline
+	newContextBrowser := func() *Browser {
+		fetcher := &repository.MockProjectSourceCodeFetcher{}
+		fetcher.On("Fetch", mock.MatchedBy(func(ctx context.Context) bool {
+			_, hasDeadline := ctx.Deadline()
+			return ctx.Err() == nil && hasDeadline
+		}), mock.Anything, mock.Anything).Return(nil)
+		fetcher.On("Fetch", mock.Anything, mock.Anything, mock.Anything).Return(context.Canceled)
+
+		unpacker := &repository.MockProjectSourceCodeUnpacker{}
+		unpacker.On("Unpack", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+		fetchFactory := &repository.MockProjectSourceCodeFetchFactory{}
+		fetchFactory.On("Get", entity.ProjectTypeLocal).Return(fetcher)
+
+		unpackFactory := &repository.MockProjectSourceCodeUnpackFactory{}
+		unpackFactory.On("Get", entity.ProjectFormatTarGz).Return(unpacker)
+
+		return NewBrowser(afero.NewMemMapFs(), fetchFactory, unpackFactory, logger.NewFakeLogger())
+	}
+
+	tests := []struct {
+		desc    string
+		browser *Browser
+		ctx     context.Context
+		err     error
+	}{
+		{
+			desc:    "Testing listing the files of a project within the time limit of the browser",
+			browser: newContextBrowser(),
+			ctx:     context.Background(),
+		},
+		{
+			desc:    "Testing error listing the files of a project when the context of the request is done",
+			browser: newContextBrowser(),
+			ctx:     cancelledCtx,
+			err:     context.Canceled,
+		},
+		{
+			desc:    "Testing error listing the files of a project when the browser has no time limit and the context of the request has no deadline",
+			browser: newContextBrowser().WithTimeout(0),
+			ctx:     context.Background(),
+			err:     context.Canceled,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			project := &entity.Project{Name: "project", Format: entity.ProjectFormatTarGz, Reference: "project.tar.gz", Storage: entity.ProjectTypeLocal}
+			_, err := test.browser.Files(test.ctx, project)
+			if test.err != nil {
+				assert.ErrorIs(t, err, ErrFetchingSourceCode)
+				assert.ErrorIs(t, err, test.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
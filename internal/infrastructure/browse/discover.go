@@ -0,0 +1,166 @@
+package browse
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v3"
+)
+
+// maxDiscoveredFileSize is the size of the largest YAML file parsed to discover whether it is a playbook or an inventory
+const maxDiscoveredFileSize = 1 << 20
+
+// Discover method returns the Ansible content of the unpacked project tree, along with the content of the manifest located at its root and the list of files of the tree. The role directories are the directories within a roles directory, the inventories are the inventory directories, the hosts and inventory files, and the YAML files holding an inventory, and the playbooks are the YAML files holding a list of plays. The content of the hidden directories, the group_vars and host_vars directories, the role and inventory directories and the symbolic links is not inspected, although their files are listed
+func (b *Browser) Discover(project *entity.Project) (*entity.ProjectContents, error) {
+
+	ctx, cancel := b.withTimeout(context.Background())
+	defer cancel()
+
+	workingDir, err := b.unpack(ctx, project)
+	if err != nil {
+		return nil, err
+	}
+	defer b.removeWorkingDir(workingDir)
+
+	contents := &entity.ProjectContents{
+		Files: []string{},
+	}
+	// uninspectedDirs holds the directories whose content is listed but not inspected
+	uninspectedDirs := []string{}
+	err = afero.Walk(b.fs, workingDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if path == workingDir || b.isSourceCodeFile(project, workingDir, path) {
+			return nil
+		}
+
+		relPath, err := filepath.Rel(workingDir, path)
+		if err != nil {
+			return err
+		}
+		relPath = filepath.ToSlash(relPath)
+
+		if !info.IsDir() {
+			contents.Files = append(contents.Files, relPath)
+		}
+
+		if info.Mode()&os.ModeSymlink != 0 || isWithinDirs(relPath, uninspectedDirs) {
+			return nil
+		}
+
+		name := info.Name()
+		if info.IsDir() {
+			switch {
+			case strings.HasPrefix(name, "."), name == "group_vars", name == "host_vars":
+				uninspectedDirs = append(uninspectedDirs, relPath)
+			case filepath.Base(filepath.Dir(path)) == "roles":
+				contents.Roles = append(contents.Roles, relPath)
+				uninspectedDirs = append(uninspectedDirs, relPath)
+			case name == "inventory", name == "inventories":
+				contents.Inventories = append(contents.Inventories, relPath)
+				uninspectedDirs = append(uninspectedDirs, relPath)
+			}
+
+			return nil
+		}
+
+		extension := filepath.Ext(name)
+		switch {
+		case filepath.Dir(path) == workingDir && (name == entity.ProjectManifestFileName || name == entity.ProjectManifestAlternativeFileName):
+			if contents.Manifest != nil {
+				return fmt.Errorf("%w: %s and %s", ErrDuplicatedManifest, entity.ProjectManifestFileName, entity.ProjectManifestAlternativeFileName)
+			}
+
+			contents.Manifest, err = afero.ReadFile(b.fs, path)
+			if err != nil {
+				return err
+			}
+		case name == "ansible.cfg":
+			contents.AnsibleConfigs = append(contents.AnsibleConfigs, relPath)
+		case name == "requirements.yml", name == "requirements.yaml":
+			contents.Requirements = append(contents.Requirements, relPath)
+		case strings.TrimSuffix(name, extension) == "hosts", strings.TrimSuffix(name, extension) == "inventory":
+			contents.Inventories = append(contents.Inventories, relPath)
+		case (extension == ".yml" || extension == ".yaml") && info.Size() <= maxDiscoveredFileSize:
+			data, err := afero.ReadFile(b.fs, path)
+			if err != nil {
+				return err
+			}
+
+			switch {
+			case isPlaybook(data):
+				contents.Playbooks = append(contents.Playbooks, relPath)
+			case isInventory(data):
+				contents.Inventories = append(contents.Inventories, relPath)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		b.logger.Error(
+			fmt.Sprintf("%s: %s", ErrDiscoveringSourceCodeContents, err),
+			map[string]interface{}{
+				"component":       "Browser.Discover",
+				"package":         "github.com/apenella/ransidble/internal/infrastructure/browse",
+				"project_id":      project.Name,
+				"project_version": project.Version,
+			})
+		return nil, fmt.Errorf("%w: %w", ErrDiscoveringSourceCodeContents, err)
+	}
+
+	return contents, nil
+}
+
+// isWithinDirs returns whether the path is located within any of the directories
+func isWithinDirs(path string, dirs []string) bool {
+	for _, dir := range dirs {
+		if strings.HasPrefix(path, dir+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isPlaybook returns whether the YAML document is a list of plays, either defining the hosts they target or importing another playbook
+func isPlaybook(data []byte) bool {
+	plays := []map[string]interface{}{}
+
+	err := yaml.Unmarshal(data, &plays)
+	if err != nil || len(plays) == 0 {
+		return false
+	}
+
+	for _, play := range plays {
+		_, hosts := play["hosts"]
+		_, importPlaybook := play["import_playbook"]
+		_, builtinImportPlaybook := play["ansible.builtin.import_playbook"]
+		if !hosts && !importPlaybook && !builtinImportPlaybook {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isInventory returns whether the YAML document is an inventory, whose top level group is the all group
+func isInventory(data []byte) bool {
+	inventory := map[string]interface{}{}
+
+	err := yaml.Unmarshal(data, &inventory)
+	if err != nil {
+		return false
+	}
+
+	_, all := inventory["all"]
+
+	return all
+}
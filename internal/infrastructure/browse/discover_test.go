@@ -0,0 +1,154 @@
+package browse
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	"github.com/apenella/ransidble/internal/domain/ports/repository"
+	"github.com/apenella/ransidble/internal/infrastructure/logger"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// newTestDiscoverBrowser returns a browser whose unpacker writes the files of the project tree into the working directory
+func newTestDiscoverBrowser(files map[string]string) *Browser {
+	fs := afero.NewMemMapFs()
+
+	fetcher := &repository.MockProjectSourceCodeFetcher{}
+	fetcher.On("Fetch", mock.Anything, mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		project := args.Get(1).(*entity.Project)
+		_ = afero.WriteFile(fs, filepath.Join(args.String(2), project.Reference), []byte("- hosts: all"), 0644)
+	})
+
+	unpacker := &repository.MockProjectSourceCodeUnpacker{}
+	unpacker.On("Unpack", mock.Anything, mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		for path, content := range files {
+			_ = afero.WriteFile(fs, filepath.Join(args.String(2), filepath.FromSlash(path)), []byte(content), 0644)
+		}
+	})
+
+	fetchFactory := &repository.MockProjectSourceCodeFetchFactory{}
+	fetchFactory.On("Get", entity.ProjectTypeLocal).Return(fetcher)
+	fetchFactory.On("Get", mock.Anything).Return(nil)
+
+	unpackFactory := &repository.MockProjectSourceCodeUnpackFactory{}
+	unpackFactory.On("Get", entity.ProjectFormatTarGz).Return(unpacker)
+	unpackFactory.On("Get", mock.Anything).Return(nil)
+
+	return NewBrowser(fs, fetchFactory, unpackFactory, logger.NewFakeLogger())
+}
+
+func TestBrowserDiscover(t *testing.T) {
+	project := &entity.Project{Name: "project", Format: entity.ProjectFormatTarGz, Reference: "project.yml", Storage: entity.ProjectTypeLocal}
+
+	tests := []struct {
+		desc     string
+		browser  *Browser
+		project  *entity.Project
+		expected *entity.ProjectContents
+		err      error
+	}{
+		{
+			desc: "Testing discovering the Ansible content of a project tree",
+			browser: newTestDiscoverBrowser(map[string]string{
+				"ansible.cfg":                         "[defaults]",
+				"collections/requirements.yml":        "collections: []",
+				"group_vars/all.yml":                  "- hosts: all",
+				"hosts.ini":                           "[all]\nlocalhost",
+				"inventories/production/hosts.yml":    "all: {}",
+				"playbooks/deploy.yaml":               "- name: deploy\n  hosts: webservers\n  tasks: []",
+				"roles/nginx/tasks/main.yml":          "- name: install\n  package: {}",
+				"roles/nginx/tests/test.yml":          "- hosts: localhost",
+				"site.yml":                            "- import_playbook: playbooks/deploy.yaml",
+				"staging.yml":                         "all:\n  hosts:\n    localhost: {}",
+				"vars/main.yml":                       "packages: []",
+				"tasks/main.yml":                      "- name: install\n  package: {}",
+				".github/workflows/ci.yml":            "- hosts: all",
+				"playbooks/invalid.yml":               "- hosts: [",
+				"playbooks/templates/playbook.yml.j2": "- hosts: all",
+			}),
+			project: project,
+			expected: &entity.ProjectContents{
+				AnsibleConfigs: []string{"ansible.cfg"},
+				Files: []string{
+					".github/workflows/ci.yml",
+					"ansible.cfg",
+					"collections/requirements.yml",
+					"group_vars/all.yml",
+					"hosts.ini",
+					"inventories/production/hosts.yml",
+					"playbooks/deploy.yaml",
+					"playbooks/invalid.yml",
+					"playbooks/templates/playbook.yml.j2",
+					"roles/nginx/tasks/main.yml",
+					"roles/nginx/tests/test.yml",
+					"site.yml",
+					"staging.yml",
+					"tasks/main.yml",
+					"vars/main.yml",
+				},
+				Inventories:  []string{"hosts.ini", "inventories", "staging.yml"},
+				Playbooks:    []string{"playbooks/deploy.yaml", "site.yml"},
+				Requirements: []string{"collections/requirements.yml"},
+				Roles:        []string{"roles/nginx"},
+			},
+		},
+		{
+			desc: "Testing discovering the Ansible content of a project tree holding a manifest",
+			browser: newTestDiscoverBrowser(map[string]string{
+				"ransidble.yaml":      "playbooks: [site.yml]",
+				"site.yml":            "- hosts: all",
+				"docs/ransidble.yaml": "playbooks: [docs.yml]",
+			}),
+			project: project,
+			expected: &entity.ProjectContents{
+				Files:     []string{"docs/ransidble.yaml", "ransidble.yaml", "site.yml"},
+				Manifest:  []byte("playbooks: [site.yml]"),
+				Playbooks: []string{"site.yml"},
+			},
+		},
+		{
+			desc: "Testing error discovering the Ansible content of a project tree holding more than one manifest",
+			browser: newTestDiscoverBrowser(map[string]string{
+				"ransidble.yaml": "playbooks: [site.yml]",
+				"ransidble.yml":  "playbooks: [site.yml]",
+			}),
+			project: project,
+			err:     ErrDuplicatedManifest,
+		},
+		{
+			desc:     "Testing discovering the Ansible content of a project tree, which does not include the stored archive",
+			browser:  newTestDiscoverBrowser(map[string]string{}),
+			project:  project,
+			expected: &entity.ProjectContents{Files: []string{}},
+		},
+		{
+			desc:    "Testing error discovering the Ansible content of a project when there is no unpacker for its format",
+			browser: newTestDiscoverBrowser(map[string]string{}),
+			project: &entity.Project{Name: "project", Format: entity.ProjectFormatZip, Reference: "project.zip", Storage: entity.ProjectTypeLocal},
+			err:     ErrSourceCodeUnpackerNotAvailable,
+		},
+		{
+			desc:    "Testing error discovering the Ansible content when the project is not provided",
+			browser: newTestDiscoverBrowser(map[string]string{}),
+			err:     ErrProjectNotProvided,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			contents, err := test.browser.Discover(test.project)
+			if test.err != nil {
+				assert.ErrorIs(t, err, test.err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expected, contents)
+			}
+		})
+	}
+}
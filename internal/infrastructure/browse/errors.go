@@ -0,0 +1,28 @@
+package browse
+
+import "errors"
+
+var (
+	// ErrProjectNotProvided represents an error when the project is not provided
+	ErrProjectNotProvided = errors.New("project not provided")
+	// ErrFilesystemNotProvided represents an error when the filesystem is not provided
+	ErrFilesystemNotProvided = errors.New("filesystem not provided")
+	// ErrSourceCodeFetcherNotAvailable represents an error when there is no fetcher for the project storage
+	ErrSourceCodeFetcherNotAvailable = errors.New("source code fetcher not available")
+	// ErrSourceCodeUnpackerNotAvailable represents an error when there is no unpacker for the project format
+	ErrSourceCodeUnpackerNotAvailable = errors.New("source code unpacker not available")
+	// ErrCreatingWorkingDir represents an error when the working directory where the source code is fetched cannot be created
+	ErrCreatingWorkingDir = errors.New("error creating working directory")
+	// ErrFetchingSourceCode represents an error when the source code cannot be fetched
+	ErrFetchingSourceCode = errors.New("error fetching source code")
+	// ErrUnpackingSourceCode represents an error when the source code cannot be unpacked
+	ErrUnpackingSourceCode = errors.New("error unpacking source code")
+	// ErrListingSourceCodeFiles represents an error when the files of the project tree cannot be listed
+	ErrListingSourceCodeFiles = errors.New("error listing source code files")
+	// ErrDiscoveringSourceCodeContents represents an error when the Ansible content of the project tree cannot be discovered
+	ErrDiscoveringSourceCodeContents = errors.New("error discovering source code contents")
+	// ErrDuplicatedManifest represents an error when the project tree holds more than one manifest
+	ErrDuplicatedManifest = errors.New("project tree holds more than one manifest")
+	// ErrOpeningSourceCodeFile represents an error when a source code file cannot be opened
+	ErrOpeningSourceCodeFile = errors.New("error opening source code file")
+)
@@ -1,13 +1,19 @@
 package executor
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 
 	"github.com/apenella/go-ansible/v2/pkg/execute"
 	"github.com/apenella/go-ansible/v2/pkg/execute/configuration"
+	jsonresults "github.com/apenella/go-ansible/v2/pkg/execute/result/json"
 	"github.com/apenella/go-ansible/v2/pkg/execute/workflow"
 	collection "github.com/apenella/go-ansible/v2/pkg/galaxy/collection/install"
 	role "github.com/apenella/go-ansible/v2/pkg/galaxy/role/install"
@@ -21,6 +27,8 @@ const (
 	CollectionsPath = ".collections"
 	// RolesPath represents the path where the roles are stored
 	RolesPath = ".roles"
+	// JSONStdoutCallback represents the ansible stdout callback used to achieve the structured result of the execution
+	JSONStdoutCallback = "json"
 )
 
 var (
@@ -30,6 +38,8 @@ var (
 	ErrParametersNotProvided = fmt.Errorf("parameters not provided")
 	// ErrRunningAnsiblePlaybook represents an error when running an ansible playbook
 	ErrRunningAnsiblePlaybook = fmt.Errorf("error running ansible playbook")
+	// ErrParsingAnsiblePlaybookResult represents an error when the structured result of an ansible playbook execution can not be parsed
+	ErrParsingAnsiblePlaybookResult = fmt.Errorf("error parsing ansible playbook result")
 )
 
 // AnsiblePlaybook represents an executor for running ansible playbooks
@@ -45,8 +55,8 @@ func NewAnsiblePlaybook(logger repository.Logger) *AnsiblePlaybook {
 	}
 }
 
-// Run runs an ansible playbook
-func (a *AnsiblePlaybook) Run(ctx context.Context, workingDir string, parameters *entity.AnsiblePlaybookParameters) error {
+// Run runs an ansible playbook. The standard output and standard error of the commands are written to output, or to the server's standard output when output is nil. When the parameters ask for the structured result, the playbook is run using the JSON stdout callback and the result is returned, even when the execution fails
+func (a *AnsiblePlaybook) Run(ctx context.Context, workingDir string, parameters *entity.AnsiblePlaybookParameters, output io.Writer) (*entity.TaskResult, error) {
 
 	if workingDir == "" {
 		a.logger.Error(
@@ -56,7 +66,7 @@ func (a *AnsiblePlaybook) Run(ctx context.Context, workingDir string, parameters
 				"package":   "github.com/apenella/ransidble/internal/infrastructure/executor",
 			})
 
-		return ErrWorkingDirNotProvided
+		return nil, ErrWorkingDirNotProvided
 	}
 
 	if parameters == nil {
@@ -67,18 +77,19 @@ func (a *AnsiblePlaybook) Run(ctx context.Context, workingDir string, parameters
 				"package":   "github.com/apenella/ransidble/internal/infrastructure/executor",
 			})
 
-		return ErrParametersNotProvided
+		return nil, ErrParametersNotProvided
 	}
 
+	results := &bytes.Buffer{}
 	workflowTasks := make([]execute.Executor, 0)
-	playbookExecutor := a.createAnsiblePlaybookExecutor(workingDir, parameters)
+	playbookExecutor := a.createAnsiblePlaybookExecutor(workingDir, parameters, output, results)
 
-	galaxyInstallCollectionExecutor := a.createGalaxyCollectionInstallExecutor(workingDir, parameters)
+	galaxyInstallCollectionExecutor := a.createGalaxyCollectionInstallExecutor(workingDir, parameters, output)
 	if galaxyInstallCollectionExecutor != nil {
 		workflowTasks = append(workflowTasks, galaxyInstallCollectionExecutor)
 	}
 
-	galaxyInstallRoleExecutor := a.createGalaxyRoleInstallExecutor(workingDir, parameters)
+	galaxyInstallRoleExecutor := a.createGalaxyRoleInstallExecutor(workingDir, parameters, output)
 	if galaxyInstallRoleExecutor != nil {
 		workflowTasks = append(workflowTasks, galaxyInstallRoleExecutor)
 	}
@@ -87,6 +98,16 @@ func (a *AnsiblePlaybook) Run(ctx context.Context, workingDir string, parameters
 
 	workflowExecutor := workflow.NewWorkflowExecute(workflowTasks...)
 	err := workflowExecutor.Execute(ctx)
+	// the commands killed because the context is done do not return an error, which happens when the task is cancelled
+	if err == nil && ctx.Err() != nil {
+		err = ctx.Err()
+	}
+
+	var result *entity.TaskResult
+	if parameters.StructuredResult {
+		result = a.parseTaskResult(results)
+	}
+
 	if err != nil {
 		a.logger.Error(
 			fmt.Sprintf("%s: %s", ErrRunningAnsiblePlaybook, err),
@@ -95,13 +116,30 @@ func (a *AnsiblePlaybook) Run(ctx context.Context, workingDir string, parameters
 				"package":   "github.com/apenella/ransidble/internal/infrastructure/executor",
 			})
 
-		return fmt.Errorf("%s: %w", ErrRunningAnsiblePlaybook, err)
+		return result, fmt.Errorf("%s: %w", ErrRunningAnsiblePlaybook, err)
 	}
 
-	return nil
+	return result, nil
 }
 
-func (a *AnsiblePlaybook) createGalaxyRoleInstallExecutor(workingDir string, parameters *entity.AnsiblePlaybookParameters) *configuration.AnsibleWithConfigurationSettingsExecute {
+// parseTaskResult parses the output generated by the JSON stdout callback. It returns nil when the output can not be parsed, which happens when the playbook fails before the callback writes the results
+func (a *AnsiblePlaybook) parseTaskResult(results io.Reader) *entity.TaskResult {
+	ansiblePlaybookResults, err := jsonresults.ParseJSONResultsStream(results)
+	if err != nil {
+		a.logger.Warn(
+			fmt.Sprintf("%s: %s", ErrParsingAnsiblePlaybookResult, err),
+			map[string]interface{}{
+				"component": "AnsiblePlaybook.parseTaskResult",
+				"package":   "github.com/apenella/ransidble/internal/infrastructure/executor",
+			})
+
+		return nil
+	}
+
+	return taskResultMapper(ansiblePlaybookResults)
+}
+
+func (a *AnsiblePlaybook) createGalaxyRoleInstallExecutor(workingDir string, parameters *entity.AnsiblePlaybookParameters, output io.Writer) *configuration.AnsibleWithConfigurationSettingsExecute {
 	var galaxyInstallRolesExecutor *configuration.AnsibleWithConfigurationSettingsExecute
 
 	if parameters == nil {
@@ -142,8 +180,12 @@ func (a *AnsiblePlaybook) createGalaxyRoleInstallExecutor(workingDir string, par
 
 			galaxyInstallRolesExecutor = configuration.NewAnsibleWithConfigurationSettingsExecute(
 				execute.NewDefaultExecute(
-					execute.WithCmd(galaxyInstallRolesCmd),
-					execute.WithCmdRunDir(workingDir),
+					append(
+						outputExecuteOptions(output),
+						execute.WithCmd(galaxyInstallRolesCmd),
+						execute.WithCmdRunDir(workingDir),
+						execute.WithExecutable(NewProcessGroupExec()),
+					)...,
 				),
 				configuration.WithAnsibleRolesPath(filepath.Join(workingDir, RolesPath)),
 			)
@@ -186,7 +228,7 @@ func ansibleGalaxyRolesInstallOptionsMapper(parameters *entity.AnsiblePlaybookRo
 }
 
 // createGalaxyCollectionInstallExecutor returns an Executor to run the Ansible Galaxy Collection install command
-func (a *AnsiblePlaybook) createGalaxyCollectionInstallExecutor(workingDir string, parameters *entity.AnsiblePlaybookParameters) *configuration.AnsibleWithConfigurationSettingsExecute {
+func (a *AnsiblePlaybook) createGalaxyCollectionInstallExecutor(workingDir string, parameters *entity.AnsiblePlaybookParameters, output io.Writer) *configuration.AnsibleWithConfigurationSettingsExecute {
 
 	var galaxyInstallCollectionExecutor *configuration.AnsibleWithConfigurationSettingsExecute
 
@@ -228,8 +270,12 @@ func (a *AnsiblePlaybook) createGalaxyCollectionInstallExecutor(workingDir strin
 
 			galaxyInstallCollectionExecutor = configuration.NewAnsibleWithConfigurationSettingsExecute(
 				execute.NewDefaultExecute(
-					execute.WithCmd(galaxyInstallCollectionCmd),
-					execute.WithCmdRunDir(workingDir),
+					append(
+						outputExecuteOptions(output),
+						execute.WithCmd(galaxyInstallCollectionCmd),
+						execute.WithCmdRunDir(workingDir),
+						execute.WithExecutable(NewProcessGroupExec()),
+					)...,
 				),
 				configuration.WithAnsibleCollectionsPaths(filepath.Join(workingDir, CollectionsPath)),
 			)
@@ -238,8 +284,8 @@ func (a *AnsiblePlaybook) createGalaxyCollectionInstallExecutor(workingDir strin
 	return galaxyInstallCollectionExecutor
 }
 
-// createAnsiblePlaybookExecutor returns an Executor to run the Ansible Playbook command
-func (a *AnsiblePlaybook) createAnsiblePlaybookExecutor(workingDir string, parameters *entity.AnsiblePlaybookParameters) *configuration.AnsibleWithConfigurationSettingsExecute {
+// createAnsiblePlaybookExecutor returns an Executor to run the Ansible Playbook command. When the structured result is requested, the output of the JSON stdout callback is also written to results
+func (a *AnsiblePlaybook) createAnsiblePlaybookExecutor(workingDir string, parameters *entity.AnsiblePlaybookParameters, output io.Writer, results io.Writer) *configuration.AnsibleWithConfigurationSettingsExecute {
 
 	var playbookExecutor *configuration.AnsibleWithConfigurationSettingsExecute
 
@@ -277,11 +323,41 @@ func (a *AnsiblePlaybook) createAnsiblePlaybookExecutor(workingDir string, param
 		playbook.WithPlaybookOptions(ansiblePlaybookOptions),
 	)
 
-	playbookExecutor = configuration.NewAnsibleWithConfigurationSettingsExecute(
-		execute.NewDefaultExecute(
+	if parameters.StructuredResult && results != nil {
+		if output == nil {
+			output = os.Stdout
+		}
+
+		defaultExecutor := execute.NewDefaultExecute(
 			execute.WithCmd(playbookCmd),
 			execute.WithErrorEnrich(playbook.NewAnsiblePlaybookErrorEnrich()),
 			execute.WithCmdRunDir(workingDir),
+			execute.WithExecutable(NewProcessGroupExec()),
+			execute.WithOutput(jsonresults.NewJSONStdoutCallbackResults()),
+			execute.WithWrite(io.MultiWriter(output, results)),
+			execute.WithWriteError(output),
+		)
+		// verbose flags are removed because they break the JSON document written by the callback
+		defaultExecutor.Quiet()
+
+		playbookExecutor = configuration.NewAnsibleWithConfigurationSettingsExecute(
+			defaultExecutor,
+			configuration.WithAnsibleCollectionsPaths(filepath.Join(workingDir, CollectionsPath)),
+			configuration.WithAnsibleStdoutCallback(JSONStdoutCallback),
+		)
+
+		return playbookExecutor
+	}
+
+	playbookExecutor = configuration.NewAnsibleWithConfigurationSettingsExecute(
+		execute.NewDefaultExecute(
+			append(
+				outputExecuteOptions(output),
+				execute.WithCmd(playbookCmd),
+				execute.WithErrorEnrich(playbook.NewAnsiblePlaybookErrorEnrich()),
+				execute.WithCmdRunDir(workingDir),
+				execute.WithExecutable(NewProcessGroupExec()),
+			)...,
 		),
 		configuration.WithAnsibleCollectionsPaths(filepath.Join(workingDir, CollectionsPath)),
 	)
@@ -289,6 +365,103 @@ func (a *AnsiblePlaybook) createAnsiblePlaybookExecutor(workingDir string, param
 	return playbookExecutor
 }
 
+// taskResultMapper maps the results generated by the ansible JSON stdout callback to an entity.TaskResult
+func taskResultMapper(results *jsonresults.AnsiblePlaybookJSONResults) *entity.TaskResult {
+
+	taskResult := entity.NewTaskResult()
+
+	if results == nil {
+		return taskResult
+	}
+
+	for host, stats := range results.Stats {
+		if stats == nil {
+			continue
+		}
+
+		taskResult.Hosts[host] = &entity.TaskResultHostRecap{
+			Changed:     stats.Changed,
+			Failed:      stats.Failures,
+			Ignored:     stats.Ignored,
+			Ok:          stats.Ok,
+			Rescued:     stats.Rescued,
+			Skipped:     stats.Skipped,
+			Unreachable: stats.Unreachable,
+		}
+	}
+
+	for _, play := range results.Plays {
+		playName := ""
+		if play.Play != nil {
+			playName = play.Play.Name
+		}
+
+		for _, task := range play.Tasks {
+			taskName := ""
+			if task.Task != nil {
+				taskName = task.Task.Name
+			}
+
+			// hosts are sorted to provide the failed tasks in a deterministic order
+			hosts := make([]string, 0, len(task.Hosts))
+			for host := range task.Hosts {
+				hosts = append(hosts, host)
+			}
+			sort.Strings(hosts)
+
+			for _, host := range hosts {
+				item := task.Hosts[host]
+				if item == nil || !(item.Failed || item.Unreachable) {
+					continue
+				}
+
+				taskResult.FailedTasks = append(taskResult.FailedTasks, &entity.TaskResultFailedTask{
+					Host:        host,
+					Message:     taskResultMessage(item.Msg),
+					Module:      item.Action,
+					Play:        playName,
+					Task:        taskName,
+					Unreachable: item.Unreachable,
+				})
+			}
+		}
+	}
+
+	return taskResult
+}
+
+// taskResultMessage returns the message of a task result as a string. Messages which are not strings are encoded as JSON
+func taskResultMessage(msg interface{}) string {
+	if msg == nil {
+		return ""
+	}
+
+	if str, ok := msg.(string); ok {
+		return str
+	}
+
+	encoded, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Sprintf("%v", msg)
+	}
+
+	return string(encoded)
+}
+
+// outputExecuteOptions returns the execute options to write the command output to the given writer. When the writer is nil, the default output is kept
+func outputExecuteOptions(output io.Writer) []execute.ExecuteOptions {
+	options := make([]execute.ExecuteOptions, 0)
+
+	if output != nil {
+		options = append(options,
+			execute.WithWrite(output),
+			execute.WithWriteError(output),
+		)
+	}
+
+	return options
+}
+
 // ansiblePlaybookOptionsMapper maps an entity.AnsiblePlaybookParameters to a playbook.AnsiblePlaybookOptions
 func ansiblePlaybookOptionsMapper(parameters *entity.AnsiblePlaybookParameters) *playbook.AnsiblePlaybookOptions {
 
@@ -1,11 +1,14 @@
 package executor
 
 import (
+	"bytes"
+	"io"
 	"path/filepath"
 	"testing"
 
 	"github.com/apenella/go-ansible/v2/pkg/execute"
 	"github.com/apenella/go-ansible/v2/pkg/execute/configuration"
+	jsonresults "github.com/apenella/go-ansible/v2/pkg/execute/result/json"
 	collection "github.com/apenella/go-ansible/v2/pkg/galaxy/collection/install"
 	role "github.com/apenella/go-ansible/v2/pkg/galaxy/role/install"
 	"github.com/apenella/go-ansible/v2/pkg/playbook"
@@ -210,6 +213,7 @@ func TestCreateGalaxyCollectionInstallExecutor(t *testing.T) {
 						),
 					),
 					execute.WithCmdRunDir("/tmp"),
+					execute.WithExecutable(NewProcessGroupExec()),
 				),
 				configuration.WithAnsibleCollectionsPaths(
 					filepath.Join("/tmp", CollectionsPath),
@@ -223,7 +227,7 @@ func TestCreateGalaxyCollectionInstallExecutor(t *testing.T) {
 			t.Log(test.desc)
 			t.Parallel()
 
-			res := test.run.createGalaxyCollectionInstallExecutor(test.workingDir, test.in)
+			res := test.run.createGalaxyCollectionInstallExecutor(test.workingDir, test.in, nil)
 			assert.Equal(t, test.out, res)
 		})
 	}
@@ -233,12 +237,32 @@ func TestCreateAnsiblePlaybookExecutor(t *testing.T) {
 	run := NewAnsiblePlaybook(
 		logger.NewFakeLogger(),
 	)
+	output := &bytes.Buffer{}
+	results := &bytes.Buffer{}
+
+	structuredResultExecutor := execute.NewDefaultExecute(
+		execute.WithCmd(
+			playbook.NewAnsiblePlaybookCmd(
+				playbook.WithPlaybooks([]string{"playbook.yml"}...),
+				playbook.WithPlaybookOptions(&playbook.AnsiblePlaybookOptions{}),
+			),
+		),
+		execute.WithErrorEnrich(playbook.NewAnsiblePlaybookErrorEnrich()),
+		execute.WithCmdRunDir("/tmp"),
+		execute.WithExecutable(NewProcessGroupExec()),
+		execute.WithOutput(jsonresults.NewJSONStdoutCallbackResults()),
+		execute.WithWrite(io.MultiWriter(output, results)),
+		execute.WithWriteError(output),
+	)
+	structuredResultExecutor.Quiet()
 
 	tests := []struct {
 		desc       string
 		run        *AnsiblePlaybook
 		workingDir string
 		in         *entity.AnsiblePlaybookParameters
+		output     io.Writer
+		results    io.Writer
 		out        *configuration.AnsibleWithConfigurationSettingsExecute
 	}{
 		{
@@ -281,12 +305,58 @@ func TestCreateAnsiblePlaybookExecutor(t *testing.T) {
 					),
 					execute.WithErrorEnrich(playbook.NewAnsiblePlaybookErrorEnrich()),
 					execute.WithCmdRunDir("/tmp"),
+					execute.WithExecutable(NewProcessGroupExec()),
 				),
 				configuration.WithAnsibleCollectionsPaths(
 					filepath.Join("/tmp", CollectionsPath),
 				),
 			),
 		},
+		{
+			desc:       "Testing creating a AnsiblePlaybookExecutor writing the output to the provided writer",
+			run:        run,
+			workingDir: "/tmp",
+			in: &entity.AnsiblePlaybookParameters{
+				Playbooks: []string{"playbook.yml"},
+			},
+			output: output,
+			out: configuration.NewAnsibleWithConfigurationSettingsExecute(
+				execute.NewDefaultExecute(
+					execute.WithCmd(
+						playbook.NewAnsiblePlaybookCmd(
+							playbook.WithPlaybooks([]string{"playbook.yml"}...),
+							playbook.WithPlaybookOptions(&playbook.AnsiblePlaybookOptions{}),
+						),
+					),
+					execute.WithErrorEnrich(playbook.NewAnsiblePlaybookErrorEnrich()),
+					execute.WithCmdRunDir("/tmp"),
+					execute.WithExecutable(NewProcessGroupExec()),
+					execute.WithWrite(output),
+					execute.WithWriteError(output),
+				),
+				configuration.WithAnsibleCollectionsPaths(
+					filepath.Join("/tmp", CollectionsPath),
+				),
+			),
+		},
+		{
+			desc:       "Testing creating a AnsiblePlaybookExecutor using the JSON stdout callback when the structured result is requested",
+			run:        run,
+			workingDir: "/tmp",
+			in: &entity.AnsiblePlaybookParameters{
+				Playbooks:        []string{"playbook.yml"},
+				StructuredResult: true,
+			},
+			output:  output,
+			results: results,
+			out: configuration.NewAnsibleWithConfigurationSettingsExecute(
+				structuredResultExecutor,
+				configuration.WithAnsibleCollectionsPaths(
+					filepath.Join("/tmp", CollectionsPath),
+				),
+				configuration.WithAnsibleStdoutCallback(JSONStdoutCallback),
+			),
+		},
 	}
 
 	for _, test := range tests {
@@ -294,7 +364,7 @@ func TestCreateAnsiblePlaybookExecutor(t *testing.T) {
 			t.Log(test.desc)
 			t.Parallel()
 
-			res := test.run.createAnsiblePlaybookExecutor(test.workingDir, test.in)
+			res := test.run.createAnsiblePlaybookExecutor(test.workingDir, test.in, test.output, test.results)
 			assert.Equal(t, test.out, res)
 		})
 	}
@@ -405,6 +475,7 @@ func TestCreateGalaxyRoleInstallExecutor(t *testing.T) {
 						),
 					),
 					execute.WithCmdRunDir("/tmp"),
+					execute.WithExecutable(NewProcessGroupExec()),
 				),
 				configuration.WithAnsibleRolesPath(
 					filepath.Join("/tmp", RolesPath),
@@ -418,7 +489,151 @@ func TestCreateGalaxyRoleInstallExecutor(t *testing.T) {
 			t.Log(test.desc)
 			t.Parallel()
 
-			res := test.run.createGalaxyRoleInstallExecutor(test.workingDir, test.in)
+			res := test.run.createGalaxyRoleInstallExecutor(test.workingDir, test.in, nil)
+			assert.Equal(t, test.out, res)
+		})
+	}
+}
+
+func TestTaskResultMapper(t *testing.T) {
+	tests := []struct {
+		desc string
+		in   *jsonresults.AnsiblePlaybookJSONResults
+		out  *entity.TaskResult
+	}{
+		{
+			desc: "Testing TaskResultMapper when results are not provided",
+			in:   nil,
+			out:  entity.NewTaskResult(),
+		},
+		{
+			desc: "Testing TaskResultMapper with the host stats and failed tasks",
+			in: &jsonresults.AnsiblePlaybookJSONResults{
+				Plays: []jsonresults.AnsiblePlaybookJSONResultsPlay{
+					{
+						Play: &jsonresults.AnsiblePlaybookJSONResultsPlaysPlay{
+							Name: "play",
+						},
+						Tasks: []jsonresults.AnsiblePlaybookJSONResultsPlayTask{
+							{
+								Task: &jsonresults.AnsiblePlaybookJSONResultsPlayTaskItem{
+									Name: "ping",
+								},
+								Hosts: map[string]*jsonresults.AnsiblePlaybookJSONResultsPlayTaskHostsItem{
+									"host2": {
+										Action:      "ansible.builtin.ping",
+										Msg:         "host unreachable",
+										Unreachable: true,
+									},
+									"host1": {
+										Action: "ansible.builtin.ping",
+									},
+								},
+							},
+							{
+								Task: &jsonresults.AnsiblePlaybookJSONResultsPlayTaskItem{
+									Name: "command",
+								},
+								Hosts: map[string]*jsonresults.AnsiblePlaybookJSONResultsPlayTaskHostsItem{
+									"host1": {
+										Action: "ansible.builtin.command",
+										Failed: true,
+										Msg:    []interface{}{"non-zero return code"},
+									},
+								},
+							},
+						},
+					},
+				},
+				Stats: map[string]*jsonresults.AnsiblePlaybookJSONResultsStats{
+					"host1": {
+						Failures: 1,
+						Ok:       1,
+					},
+					"host2": {
+						Unreachable: 1,
+					},
+				},
+			},
+			out: &entity.TaskResult{
+				FailedTasks: []*entity.TaskResultFailedTask{
+					{
+						Host:        "host2",
+						Message:     "host unreachable",
+						Module:      "ansible.builtin.ping",
+						Play:        "play",
+						Task:        "ping",
+						Unreachable: true,
+					},
+					{
+						Host:    "host1",
+						Message: "[\"non-zero return code\"]",
+						Module:  "ansible.builtin.command",
+						Play:    "play",
+						Task:    "command",
+					},
+				},
+				Hosts: map[string]*entity.TaskResultHostRecap{
+					"host1": {
+						Failed: 1,
+						Ok:     1,
+					},
+					"host2": {
+						Unreachable: 1,
+					},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+			t.Parallel()
+
+			res := taskResultMapper(test.in)
+			assert.Equal(t, test.out, res)
+		})
+	}
+}
+
+func TestParseTaskResult(t *testing.T) {
+	run := NewAnsiblePlaybook(
+		logger.NewFakeLogger(),
+	)
+
+	tests := []struct {
+		desc string
+		in   string
+		out  *entity.TaskResult
+	}{
+		{
+			desc: "Testing parsing the output of the JSON stdout callback",
+			in:   `{"plays": [], "stats": {"127.0.0.1": {"changed": 1, "failures": 0, "ignored": 0, "ok": 2, "rescued": 0, "skipped": 1, "unreachable": 0}}}`,
+			out: &entity.TaskResult{
+				FailedTasks: []*entity.TaskResultFailedTask{},
+				Hosts: map[string]*entity.TaskResultHostRecap{
+					"127.0.0.1": {
+						Changed: 1,
+						Ok:      2,
+						Skipped: 1,
+					},
+				},
+			},
+		},
+		{
+			desc: "Testing parsing an output which is not generated by the JSON stdout callback",
+			in:   "ERROR! the playbook could not be found",
+			out:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+			t.Parallel()
+
+			res := run.parseTaskResult(bytes.NewBufferString(test.in))
 			assert.Equal(t, test.out, res)
 		})
 	}
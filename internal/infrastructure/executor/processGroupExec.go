@@ -0,0 +1,32 @@
+package executor
+
+import (
+	"context"
+	osexec "os/exec"
+
+	"github.com/apenella/go-ansible/v2/pkg/execute/exec"
+)
+
+// ProcessGroupExec is an executable that runs the commands in their own process group. When the context is done, the whole process group is terminated, including the processes forked by ansible-playbook
+type ProcessGroupExec struct{}
+
+// NewProcessGroupExec returns a new ProcessGroupExec instance
+func NewProcessGroupExec() *ProcessGroupExec {
+	return &ProcessGroupExec{}
+}
+
+// Command returns a command to run the named program with the given arguments
+func (e *ProcessGroupExec) Command(name string, arg ...string) exec.Cmder {
+	cmd := osexec.Command(name, arg...)
+	setProcessGroup(cmd)
+
+	return cmd
+}
+
+// CommandContext returns a command to run the named program with the given arguments. The process group of the command is killed when the context is done
+func (e *ProcessGroupExec) CommandContext(ctx context.Context, name string, arg ...string) exec.Cmder {
+	cmd := osexec.CommandContext(ctx, name, arg...)
+	setProcessGroup(cmd)
+
+	return cmd
+}
@@ -0,0 +1,10 @@
+//go:build !unix
+
+package executor
+
+import (
+	osexec "os/exec"
+)
+
+// setProcessGroup keeps the default behaviour, which kills only the command process when it is cancelled
+func setProcessGroup(cmd *osexec.Cmd) {}
@@ -0,0 +1,54 @@
+//go:build unix
+
+package executor
+
+import (
+	"context"
+	"os"
+	osexec "os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProcessGroupExecCommandContext(t *testing.T) {
+	t.Log("Testing ProcessGroupExec kills the whole process group when the context is cancelled")
+
+	pidFile := filepath.Join(t.TempDir(), "child.pid")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// the command forks a child process which writes its pid before sleeping
+	cmd := NewProcessGroupExec().CommandContext(ctx, "sh", "-c", "sh -c 'echo $$ > "+pidFile+"; sleep 30' & wait")
+	err := cmd.Start()
+	if err != nil {
+		t.Skipf("unable to start the command: %v", err)
+	}
+
+	assert.Eventually(t, func() bool {
+		_, err := os.Stat(pidFile)
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	cancel()
+	_ = cmd.Wait()
+
+	data, err := os.ReadFile(pidFile)
+	assert.NoError(t, err)
+
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	assert.NoError(t, err)
+
+	assert.Eventually(t, func() bool {
+		// signal 0 checks whether the process still exists
+		return syscall.Kill(pid, 0) != nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	assert.True(t, cmd.(*osexec.Cmd).SysProcAttr.Setpgid)
+}
@@ -0,0 +1,17 @@
+//go:build unix
+
+package executor
+
+import (
+	osexec "os/exec"
+	"syscall"
+)
+
+// setProcessGroup runs the command in a new process group, which is killed when the command is cancelled
+func setProcessGroup(cmd *osexec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		// a negative pid sends the signal to every process in the process group
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+}
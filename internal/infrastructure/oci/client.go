@@ -0,0 +1,466 @@
+package oci
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apenella/ransidble/internal/domain/ports/repository"
+)
+
+const (
+	// DefaultTimeout is the time limit of a request, including the transfer of the blob, when no timeout is configured
+	DefaultTimeout = 10 * time.Minute
+
+	// headerContentDigest is the header where the registries return the digest of a manifest
+	headerContentDigest = "Docker-Content-Digest"
+	// headerAuthenticate is the header where the registries return the authentication challenge
+	headerAuthenticate = "WWW-Authenticate"
+
+	// authSchemeBasic is the basic authentication scheme
+	authSchemeBasic = "basic"
+	// authSchemeBearer is the token authentication scheme
+	authSchemeBearer = "bearer"
+)
+
+var (
+	// manifestAcceptedMediaTypes are the media types of the manifests requested to the registry. The indexes are requested too, to report them as unsupported instead of failing with a not found error
+	manifestAcceptedMediaTypes = []string{
+		MediaTypeImageManifest,
+		MediaTypeDockerManifest,
+		MediaTypeImageIndex,
+		MediaTypeDockerManifestList,
+	}
+)
+
+// Config represents the configuration to access an OCI distribution registry
+type Config struct {
+	// AuthHosts are the hosts, other than the registry, of the authorization services allowed to receive the credentials when they are set as the realm of a token challenge
+	AuthHosts []string
+	// PlainHTTP uses HTTP instead of HTTPS to reach the registry, as required by most local registries
+	PlainHTTP bool
+	// Registry is the host, optionally followed by a port, of the registry the credentials are sent to. The requests to any other registry are anonymous
+	Registry string
+	// Timeout is the time limit of a request, including the transfer of the blob. DefaultTimeout is used when it is not provided. It only applies to the HTTP client created by NewClient
+	Timeout time.Duration
+	// Username is the user to authenticate to the registry. The requests are anonymous when it is not provided
+	Username string
+	// Password is the password or the token of the user
+	Password string
+}
+
+// Client is a client of an OCI distribution registry. It supports the read-only operations required to fetch the artifacts, authenticating either with basic authentication or with the token flow
+type Client struct {
+	config     Config
+	httpClient *http.Client
+
+	mutex sync.Mutex
+	// tokens holds the tokens obtained for each registry and repository
+	tokens map[string]string
+}
+
+// Ensure Client implements the OCIRegistryClient interface
+var _ repository.OCIRegistryClient = (*Client)(nil)
+
+// NewClient creates a new Client. An HTTP client limited by the configured timeout is created when no HTTP client is provided, so a stalled registry does not block the requests forever
+func NewClient(config Config, httpClient *http.Client) *Client {
+
+	if config.Timeout <= 0 {
+		config.Timeout = DefaultTimeout
+	}
+
+	if httpClient == nil {
+		httpClient = &http.Client{
+			Timeout: config.Timeout,
+		}
+	}
+
+	return &Client{
+		config:     config,
+		httpClient: httpClient,
+		tokens:     make(map[string]string),
+	}
+}
+
+// Resolve returns the digest of the manifest pointed by a reference. When the reference is set by digest, the manifest content is verified against it. The requests are interrupted when the context is done
+func (c *Client) Resolve(ctx context.Context, reference string) (string, error) {
+
+	parsed, err := ParseReference(reference)
+	if err != nil {
+		return "", err
+	}
+
+	content, _, err := c.getManifest(ctx, parsed)
+	if err != nil {
+		return "", err
+	}
+
+	if parsed.Digest != "" {
+		err = VerifyContent(content, parsed.Digest)
+		if err != nil {
+			return "", err
+		}
+
+		return parsed.Digest, nil
+	}
+
+	return Digest(content), nil
+}
+
+// Manifest returns the content of the manifest of the reference repository that matches the digest, verifying it. The requests are interrupted when the context is done
+func (c *Client) Manifest(ctx context.Context, reference string, digest string) ([]byte, error) {
+
+	parsed, err := ParseReference(reference)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ValidateDigest(digest)
+	if err != nil {
+		return nil, err
+	}
+
+	content, _, err := c.getManifest(ctx, parsed.WithDigest(digest))
+	if err != nil {
+		return nil, err
+	}
+
+	err = VerifyContent(content, digest)
+	if err != nil {
+		return nil, err
+	}
+
+	return content, nil
+}
+
+// Blob returns a reader of the blob of the reference repository that matches the digest, which must be closed by the caller. The content is not verified, since it is streamed. The requests, including the transfer of the blob, are interrupted when the context is done
+func (c *Client) Blob(ctx context.Context, reference string, digest string) (io.ReadCloser, error) {
+
+	parsed, err := ParseReference(reference)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ValidateDigest(digest)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.do(ctx, http.MethodGet, parsed, fmt.Sprintf("blobs/%s", digest), nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w %s: %w", ErrGettingBlob, digest, err)
+	}
+
+	switch res.StatusCode {
+	case http.StatusOK:
+		return res.Body, nil
+	case http.StatusNotFound:
+		res.Body.Close()
+		return nil, fmt.Errorf("%w: %s", ErrBlobNotFound, digest)
+	default:
+		defer res.Body.Close()
+		return nil, fmt.Errorf("%w %s: %w", ErrGettingBlob, digest, readErrorResponse(res))
+	}
+}
+
+// getManifest returns the content and the media type of the manifest pointed by a reference
+func (c *Client) getManifest(ctx context.Context, reference *Reference) ([]byte, string, error) {
+
+	res, err := c.do(ctx, http.MethodGet, reference, fmt.Sprintf("manifests/%s", reference.manifestReference()), manifestAcceptedMediaTypes)
+	if err != nil {
+		return nil, "", fmt.Errorf("%w %s: %w", ErrGettingManifest, reference, err)
+	}
+	defer res.Body.Close()
+
+	switch res.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, "", fmt.Errorf("%w: %s", ErrManifestNotFound, reference)
+	default:
+		return nil, "", fmt.Errorf("%w %s: %w", ErrGettingManifest, reference, readErrorResponse(res))
+	}
+
+	mediaType := res.Header.Get("Content-Type")
+	switch mediaType {
+	case MediaTypeImageIndex, MediaTypeDockerManifestList:
+		return nil, "", fmt.Errorf("%w: %s", ErrUnsupportedMediaType, mediaType)
+	}
+
+	content, err := io.ReadAll(io.LimitReader(res.Body, maxManifestSize+1))
+	if err != nil {
+		return nil, "", fmt.Errorf("%w %s: %w", ErrGettingManifest, reference, err)
+	}
+
+	if len(content) > maxManifestSize {
+		return nil, "", fmt.Errorf("%w: the manifest of %s exceeds %d bytes", ErrInvalidManifest, reference, maxManifestSize)
+	}
+
+	_, err = ParseManifest(content)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return content, mediaType, nil
+}
+
+// do sends a request to the distribution API of the reference repository. When the registry requires authentication, the request is sent again with the credentials obtained from the challenge
+func (c *Client) do(ctx context.Context, method string, reference *Reference, path string, accept []string) (*http.Response, error) {
+
+	scheme := "https"
+	if c.config.PlainHTTP {
+		scheme = "http"
+	}
+
+	requestURL := fmt.Sprintf("%s://%s/v2/%s/%s", scheme, reference.registryHost(), reference.Repository, path)
+	scope := fmt.Sprintf("repository:%s:pull", reference.Repository)
+	tokenKey := reference.Registry + "/" + reference.Repository
+
+	newRequest := func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, method, requestURL, nil)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrCreatingRequest, err)
+		}
+
+		if len(accept) > 0 {
+			req.Header.Set("Accept", strings.Join(accept, ", "))
+		}
+
+		return req, nil
+	}
+
+	req, err := newRequest()
+	if err != nil {
+		return nil, err
+	}
+
+	if token := c.token(tokenKey); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusUnauthorized {
+		return res, nil
+	}
+
+	challenge := res.Header.Get(headerAuthenticate)
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, 64*1024))
+	res.Body.Close()
+
+	req, err = newRequest()
+	if err != nil {
+		return nil, err
+	}
+
+	authenticated := c.authenticates(reference)
+
+	scheme, params := parseChallenge(challenge)
+	switch scheme {
+	case authSchemeBasic:
+		if !authenticated {
+			return nil, fmt.Errorf("%w: the registry requires credentials", ErrAuthenticating)
+		}
+		req.SetBasicAuth(c.config.Username, c.config.Password)
+	case authSchemeBearer:
+		token, err := c.fetchToken(ctx, params, scope, reference, authenticated)
+		if err != nil {
+			return nil, err
+		}
+		c.setToken(tokenKey, token)
+		req.Header.Set("Authorization", "Bearer "+token)
+	default:
+		return nil, fmt.Errorf("%w: unsupported challenge %q", ErrAuthenticating, challenge)
+	}
+
+	return c.httpClient.Do(req)
+}
+
+// tokenResponse represents the response of the token endpoint of a registry
+type tokenResponse struct {
+	Token       string `json:"token"`
+	AccessToken string `json:"access_token"`
+}
+
+// fetchToken requests a token to the authorization service described by the challenge parameters. When the request is authenticated, the authorization service must be placed on the registry host or on one of the allowed authorization hosts, so the credentials are not sent to a host chosen by the registry
+func (c *Client) fetchToken(ctx context.Context, params map[string]string, scope string, reference *Reference, authenticated bool) (string, error) {
+
+	realm, err := url.Parse(params["realm"])
+	if err != nil || realm.Scheme == "" || realm.Host == "" {
+		return "", fmt.Errorf("%w: invalid realm %q", ErrAuthenticating, params["realm"])
+	}
+
+	if authenticated && !strings.EqualFold(realm.Host, reference.registryHost()) && !matchHost(c.config.AuthHosts, realm.Host) {
+		return "", fmt.Errorf("%w: the realm %q is not an allowed authorization host", ErrAuthenticating, params["realm"])
+	}
+
+	query := realm.Query()
+	if params["service"] != "" {
+		query.Set("service", params["service"])
+	}
+	if params["scope"] != "" {
+		scope = params["scope"]
+	}
+	query.Set("scope", scope)
+	realm.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrCreatingRequest, err)
+	}
+
+	if authenticated {
+		req.SetBasicAuth(c.config.Username, c.config.Password)
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrAuthenticating, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%w: %w", ErrAuthenticating, readErrorResponse(res))
+	}
+
+	document := &tokenResponse{}
+	err = json.NewDecoder(io.LimitReader(res.Body, 64*1024)).Decode(document)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrAuthenticating, err)
+	}
+
+	if document.Token != "" {
+		return document.Token, nil
+	}
+
+	if document.AccessToken != "" {
+		return document.AccessToken, nil
+	}
+
+	return "", fmt.Errorf("%w: the authorization service did not return a token", ErrAuthenticating)
+}
+
+// authenticates returns whether the credentials are sent to the registry of the reference, which must be the configured registry
+func (c *Client) authenticates(reference *Reference) bool {
+	if c.config.Username == "" || c.config.Registry == "" {
+		return false
+	}
+
+	return matchHost([]string{c.config.Registry}, reference.Registry) || matchHost([]string{c.config.Registry}, reference.registryHost())
+}
+
+// matchHost returns whether the host matches any of the hosts, either by its name and port or, when the matching host does not define a port, by its name
+func matchHost(hosts []string, host string) bool {
+	hostname := host
+	if parsed, err := url.Parse("//" + host); err == nil {
+		hostname = parsed.Hostname()
+	}
+
+	for _, allowed := range hosts {
+		allowed = strings.TrimSpace(allowed)
+		if allowed == "" {
+			continue
+		}
+
+		if strings.EqualFold(allowed, host) {
+			return true
+		}
+
+		if parsed, err := url.Parse("//" + allowed); err == nil && parsed.Port() == "" && strings.EqualFold(parsed.Hostname(), hostname) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// token returns the token obtained for a key
+func (c *Client) token(key string) string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.tokens[key]
+}
+
+// setToken stores the token obtained for a key
+func (c *Client) setToken(key string, token string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.tokens[key] = token
+}
+
+// parseChallenge parses an authentication challenge, such as Bearer realm="https://auth.example.com/token",service="registry.example.com", returning the lowercase scheme and its parameters
+func parseChallenge(challenge string) (string, map[string]string) {
+
+	params := make(map[string]string)
+	scheme, remainder, _ := strings.Cut(strings.TrimSpace(challenge), " ")
+	scheme = strings.ToLower(scheme)
+
+	for {
+		remainder = strings.TrimLeft(remainder, " ,")
+		if remainder == "" {
+			break
+		}
+
+		key, value, found := strings.Cut(remainder, "=")
+		if !found {
+			break
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+
+		if strings.HasPrefix(value, `"`) {
+			// the quoted values may contain commas, such as the scopes with several actions
+			end := strings.Index(value[1:], `"`)
+			if end < 0 {
+				params[key] = value[1:]
+				break
+			}
+			params[key] = value[1 : end+1]
+			remainder = value[end+2:]
+			continue
+		}
+
+		value, remainder, _ = strings.Cut(value, ",")
+		params[key] = strings.TrimSpace(value)
+	}
+
+	return scheme, params
+}
+
+// errorResponse represents the error document returned by the registry
+type errorResponse struct {
+	Errors []struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"errors"`
+
+	status int
+}
+
+// readErrorResponse reads the error document of a response of the registry
+func readErrorResponse(res *http.Response) *errorResponse {
+	document := &errorResponse{status: res.StatusCode}
+
+	body, _ := io.ReadAll(io.LimitReader(res.Body, 64*1024))
+	_ = json.Unmarshal(body, document)
+
+	return document
+}
+
+// Error returns the error described by the error document
+func (e *errorResponse) Error() string {
+	if len(e.Errors) == 0 {
+		return fmt.Sprintf("unexpected status %d", e.status)
+	}
+
+	return fmt.Sprintf("unexpected status %d: %s: %s", e.status, e.Errors[0].Code, e.Errors[0].Message)
+}
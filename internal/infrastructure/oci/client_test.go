@@ -0,0 +1,283 @@
+package oci
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/apenella/ransidble/test/ociregistry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewClient(t *testing.T) {
+	tests := []struct {
+		desc    string
+		config  Config
+		timeout time.Duration
+	}{
+		{
+			desc:    "Testing creating a client with the default timeout",
+			config:  Config{},
+			timeout: DefaultTimeout,
+		},
+		{
+			desc:    "Testing creating a client with a custom timeout",
+			config:  Config{Timeout: time.Minute},
+			timeout: time.Minute,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			client := NewClient(test.config, nil)
+			assert.Equal(t, test.timeout, client.httpClient.Timeout)
+		})
+	}
+}
+
+func TestClient(t *testing.T) {
+	artifact := ociregistry.NewArtifact(map[string]string{"site.yml": "---"})
+	manifest, err := ParseManifest(artifact.Manifest)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		desc     string
+		username string
+		password string
+		config   Config
+	}{
+		{
+			desc:   "Testing resolving and getting an artifact from an anonymous registry",
+			config: Config{PlainHTTP: true},
+		},
+		{
+			desc:     "Testing resolving and getting an artifact from a registry that requires a token",
+			username: "user",
+			password: "secret",
+			config:   Config{PlainHTTP: true, Username: "user", Password: "secret"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			server := ociregistry.NewServer(test.username, test.password)
+			t.Cleanup(server.Close)
+			server.Push("team/project", "v1.0.0", artifact)
+
+			// the credentials are bound to the registry, whose host is only known once it is started
+			config := test.config
+			if config.Username != "" {
+				config.Registry = server.Host()
+			}
+
+			client := NewClient(config, server.Client())
+			reference := server.Host() + "/team/project:v1.0.0"
+
+			digest, err := client.Resolve(context.Background(), reference)
+			assert.NoError(t, err)
+			assert.Equal(t, artifact.Digest, digest)
+
+			digest, err = client.Resolve(context.Background(), server.Host()+"/team/project@"+artifact.Digest)
+			assert.NoError(t, err)
+			assert.Equal(t, artifact.Digest, digest)
+
+			content, err := client.Manifest(context.Background(), reference, digest)
+			assert.NoError(t, err)
+			assert.Equal(t, artifact.Manifest, content)
+
+			blob, err := client.Blob(context.Background(), reference, manifest.Layers[0].Digest)
+			assert.NoError(t, err)
+			data, err := io.ReadAll(blob)
+			assert.NoError(t, err)
+			assert.NoError(t, blob.Close())
+			assert.Equal(t, artifact.Blobs[manifest.Layers[0].Digest], data)
+		})
+	}
+}
+
+func TestClientErrors(t *testing.T) {
+	artifact := ociregistry.NewArtifact(map[string]string{"site.yml": "---"})
+
+	server := ociregistry.NewServer("", "")
+	t.Cleanup(server.Close)
+	server.Push("project", "v1.0.0", artifact)
+
+	privateServer := ociregistry.NewServer("user", "secret")
+	t.Cleanup(privateServer.Close)
+	privateServer.Push("project", "v1.0.0", artifact)
+
+	// the authorization service of another host records whether it receives the credentials
+	var leaked atomic.Bool
+	foreignAuthServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, _, ok := r.BasicAuth(); ok {
+			leaked.Store(true)
+		}
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	t.Cleanup(foreignAuthServer.Close)
+
+	foreignRealmServer := ociregistry.NewServer("user", "secret")
+	t.Cleanup(foreignRealmServer.Close)
+	foreignRealmServer.Push("project", "v1.0.0", artifact)
+	foreignRealmServer.SetRealm(foreignAuthServer.URL + "/token")
+
+	allowedRealmServer := ociregistry.NewServer("user", "secret")
+	t.Cleanup(allowedRealmServer.Close)
+	allowedRealmServer.Push("project", "v1.0.0", artifact)
+	allowedRealmServer.SetRealm(privateServer.URL + "/token")
+
+	tests := []struct {
+		desc         string
+		client       *Client
+		arrangeFunc  func(client *Client) error
+		err          error
+		errContained error
+	}{
+		{
+			desc:   "Testing error resolving a reference that does not exist",
+			client: NewClient(Config{PlainHTTP: true}, server.Client()),
+			arrangeFunc: func(client *Client) error {
+				_, err := client.Resolve(context.Background(), server.Host()+"/project:unknown")
+				return err
+			},
+			errContained: ErrManifestNotFound,
+		},
+		{
+			desc:   "Testing error resolving a reference by a digest that does not exist",
+			client: NewClient(Config{PlainHTTP: true}, server.Client()),
+			arrangeFunc: func(client *Client) error {
+				_, err := client.Resolve(context.Background(), server.Host()+"/project@"+Digest([]byte("unknown")))
+				return err
+			},
+			errContained: ErrManifestNotFound,
+		},
+		{
+			desc:   "Testing error getting a blob when the context is done",
+			client: NewClient(Config{PlainHTTP: true}, server.Client()),
+			arrangeFunc: func(client *Client) error {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				_, err := client.Blob(ctx, server.Host()+"/project:v1.0.0", artifact.Digest)
+				return err
+			},
+			errContained: context.Canceled,
+		},
+		{
+			desc:   "Testing error resolving a reference when the context is done",
+			client: NewClient(Config{PlainHTTP: true}, server.Client()),
+			arrangeFunc: func(client *Client) error {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				_, err := client.Resolve(ctx, server.Host()+"/project:v1.0.0")
+				return err
+			},
+			errContained: context.Canceled,
+		},
+		{
+			desc:   "Testing error resolving an empty reference",
+			client: NewClient(Config{PlainHTTP: true}, server.Client()),
+			arrangeFunc: func(client *Client) error {
+				_, err := client.Resolve(context.Background(), "")
+				return err
+			},
+			err: ErrReferenceNotProvided,
+		},
+		{
+			desc:   "Testing error getting a blob that does not exist",
+			client: NewClient(Config{PlainHTTP: true}, server.Client()),
+			arrangeFunc: func(client *Client) error {
+				_, err := client.Blob(context.Background(), server.Host()+"/project:v1.0.0", Digest([]byte("unknown")))
+				return err
+			},
+			errContained: ErrBlobNotFound,
+		},
+		{
+			desc:   "Testing error getting a manifest with an invalid digest",
+			client: NewClient(Config{PlainHTTP: true}, server.Client()),
+			arrangeFunc: func(client *Client) error {
+				_, err := client.Manifest(context.Background(), server.Host()+"/project:v1.0.0", "invalid")
+				return err
+			},
+			errContained: ErrInvalidDigest,
+		},
+		{
+			desc:   "Testing error resolving a reference in a registry that requires a token with invalid credentials",
+			client: NewClient(Config{PlainHTTP: true, Registry: privateServer.Host(), Username: "user", Password: "invalid"}, privateServer.Client()),
+			arrangeFunc: func(client *Client) error {
+				_, err := client.Resolve(context.Background(), privateServer.Host()+"/project:v1.0.0")
+				return err
+			},
+			errContained: ErrAuthenticating,
+		},
+		{
+			desc:   "Testing error resolving a reference in a registry that requires a token with the credentials of another registry",
+			client: NewClient(Config{PlainHTTP: true, Registry: "registry.example.com", Username: "user", Password: "secret"}, privateServer.Client()),
+			arrangeFunc: func(client *Client) error {
+				_, err := client.Resolve(context.Background(), privateServer.Host()+"/project:v1.0.0")
+				return err
+			},
+			errContained: ErrAuthenticating,
+		},
+		{
+			desc:   "Testing error resolving a reference in a registry announcing a realm on a host that is not allowed",
+			client: NewClient(Config{PlainHTTP: true, Registry: foreignRealmServer.Host(), Username: "user", Password: "secret"}, foreignRealmServer.Client()),
+			arrangeFunc: func(client *Client) error {
+				_, err := client.Resolve(context.Background(), foreignRealmServer.Host()+"/project:v1.0.0")
+				if leaked.Load() {
+					return errors.New("the credentials were sent to an authorization host that is not allowed")
+				}
+				return err
+			},
+			errContained: ErrAuthenticating,
+		},
+		{
+			desc:   "Testing resolving a reference in a registry announcing a realm on an allowed authorization host",
+			client: NewClient(Config{AuthHosts: []string{privateServer.Host()}, PlainHTTP: true, Registry: allowedRealmServer.Host(), Username: "user", Password: "secret"}, allowedRealmServer.Client()),
+			arrangeFunc: func(client *Client) error {
+				_, err := client.Resolve(context.Background(), allowedRealmServer.Host()+"/project:v1.0.0")
+				return err
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			err := test.arrangeFunc(test.client)
+			switch {
+			case test.err != nil:
+				assert.Equal(t, test.err, err)
+			case test.errContained != nil:
+				assert.ErrorIs(t, err, test.errContained)
+			default:
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestParseChallenge(t *testing.T) {
+	t.Log("Testing parsing an authentication challenge with quoted values holding commas")
+
+	scheme, params := parseChallenge(`Bearer realm="https://auth.example.com/token",service="registry",scope="repository:project:pull,push"`)
+
+	assert.Equal(t, authSchemeBearer, scheme)
+	assert.Equal(t, map[string]string{
+		"realm":   "https://auth.example.com/token",
+		"service": "registry",
+		"scope":   "repository:project:pull,push",
+	}, params)
+}
@@ -0,0 +1,136 @@
+package oci
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"regexp"
+	"strings"
+)
+
+const (
+	// algorithmSHA256 is the sha256 digest algorithm
+	algorithmSHA256 = "sha256"
+	// algorithmSHA512 is the sha512 digest algorithm
+	algorithmSHA512 = "sha512"
+)
+
+var (
+	// digestEncodedPatterns represents the encoded part of the digest of each supported algorithm
+	digestEncodedPatterns = map[string]*regexp.Regexp{
+		algorithmSHA256: regexp.MustCompile(`^[a-f0-9]{64}$`),
+		algorithmSHA512: regexp.MustCompile(`^[a-f0-9]{128}$`),
+	}
+)
+
+// ValidateDigest validates a digest, which is formed by the algorithm and the encoded hash separated by a colon, such as sha256:...
+func ValidateDigest(digest string) error {
+
+	algorithm, encoded, found := strings.Cut(digest, ":")
+	if !found {
+		return fmt.Errorf("%w: %s", ErrInvalidDigest, digest)
+	}
+
+	pattern, supported := digestEncodedPatterns[algorithm]
+	if !supported || !pattern.MatchString(encoded) {
+		return fmt.Errorf("%w: %s", ErrInvalidDigest, digest)
+	}
+
+	return nil
+}
+
+// Digest returns the sha256 digest of a content
+func Digest(content []byte) string {
+	sum := sha256.Sum256(content)
+	return fmt.Sprintf("%s:%s", algorithmSHA256, hex.EncodeToString(sum[:]))
+}
+
+// VerifyContent verifies that a content matches a digest
+func VerifyContent(content []byte, digest string) error {
+
+	verifier, err := NewVerifier(digest, int64(len(content)))
+	if err != nil {
+		return err
+	}
+
+	_, err = verifier.Write(content)
+	if err != nil {
+		return err
+	}
+
+	return verifier.Verify()
+}
+
+// Verifier is a writer that computes the digest of the content written on it, to verify it matches the expected digest and size
+type Verifier struct {
+	digest string
+	hash   hash.Hash
+	size   int64
+	// written is the number of bytes written
+	written int64
+}
+
+// NewVerifier creates a Verifier of the given digest. The size is not verified when it is negative
+func NewVerifier(digest string, size int64) (*Verifier, error) {
+
+	err := ValidateDigest(digest)
+	if err != nil {
+		return nil, err
+	}
+
+	algorithm, _, _ := strings.Cut(digest, ":")
+	verifier := &Verifier{
+		digest: digest,
+		size:   size,
+	}
+
+	switch algorithm {
+	case algorithmSHA512:
+		verifier.hash = sha512.New()
+	default:
+		verifier.hash = sha256.New()
+	}
+
+	return verifier, nil
+}
+
+// Write computes the digest of the written content
+func (v *Verifier) Write(p []byte) (int, error) {
+	v.written += int64(len(p))
+	return v.hash.Write(p)
+}
+
+// Verify returns an error when the digest or the size of the written content do not match the expected ones
+func (v *Verifier) Verify() error {
+
+	if v.size >= 0 && v.written != v.size {
+		return fmt.Errorf("%w: %s has %d bytes, expected %d", ErrDigestMismatch, v.digest, v.written, v.size)
+	}
+
+	algorithm, encoded, _ := strings.Cut(v.digest, ":")
+	computed := hex.EncodeToString(v.hash.Sum(nil))
+	if computed != encoded {
+		return fmt.Errorf("%w: expected %s, computed %s:%s", ErrDigestMismatch, v.digest, algorithm, computed)
+	}
+
+	return nil
+}
+
+// VerifyReader reads the whole content of a reader and verifies it matches a digest and size
+func VerifyReader(reader io.Reader, digest string, size int64) error {
+
+	verifier, err := NewVerifier(digest, size)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(verifier, reader)
+	if err != nil {
+		return err
+	}
+
+	return verifier.Verify()
+}
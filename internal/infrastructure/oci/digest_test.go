@@ -0,0 +1,95 @@
+package oci
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateDigest(t *testing.T) {
+	tests := []struct {
+		desc   string
+		digest string
+		valid  bool
+	}{
+		{desc: "Testing validating a sha256 digest", digest: "sha256:" + strings.Repeat("a", 64), valid: true},
+		{desc: "Testing validating a sha512 digest", digest: "sha512:" + strings.Repeat("b", 128), valid: true},
+		{desc: "Testing error validating a digest without algorithm", digest: strings.Repeat("a", 64)},
+		{desc: "Testing error validating a digest with an unsupported algorithm", digest: "md5:" + strings.Repeat("a", 32)},
+		{desc: "Testing error validating a digest with an invalid encoded hash", digest: "sha256:" + strings.Repeat("A", 64)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			err := ValidateDigest(test.digest)
+			if test.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, ErrInvalidDigest)
+			}
+		})
+	}
+}
+
+func TestVerifyReader(t *testing.T) {
+	content := "content"
+
+	tests := []struct {
+		desc         string
+		content      string
+		digest       string
+		size         int64
+		errContained error
+	}{
+		{
+			desc:    "Testing verifying a content that matches its digest and size",
+			content: content,
+			digest:  Digest([]byte(content)),
+			size:    int64(len(content)),
+		},
+		{
+			desc:    "Testing verifying a content without verifying its size",
+			content: content,
+			digest:  Digest([]byte(content)),
+			size:    -1,
+		},
+		{
+			desc:         "Testing error verifying a content that does not match its digest",
+			content:      "tampered",
+			digest:       Digest([]byte(content)),
+			size:         -1,
+			errContained: ErrDigestMismatch,
+		},
+		{
+			desc:         "Testing error verifying a content that does not match its size",
+			content:      content,
+			digest:       Digest([]byte(content)),
+			size:         1,
+			errContained: ErrDigestMismatch,
+		},
+		{
+			desc:         "Testing error verifying a content with an invalid digest",
+			content:      content,
+			digest:       "invalid",
+			errContained: ErrInvalidDigest,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			err := VerifyReader(strings.NewReader(test.content), test.digest, test.size)
+			if test.errContained != nil {
+				assert.ErrorIs(t, err, test.errContained)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
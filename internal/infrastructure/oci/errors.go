@@ -0,0 +1,36 @@
+package oci
+
+import "fmt"
+
+var (
+	// ErrBlobNotFound is returned when a blob does not exist
+	ErrBlobNotFound = fmt.Errorf("blob not found")
+	// ErrCreatingRequest is returned when the request to the registry can not be created
+	ErrCreatingRequest = fmt.Errorf("error creating registry request")
+	// ErrDigestMismatch is returned when a content does not match its digest
+	ErrDigestMismatch = fmt.Errorf("digest mismatch")
+	// ErrGettingBlob is returned when a blob can not be got
+	ErrGettingBlob = fmt.Errorf("error getting blob")
+	// ErrGettingManifest is returned when a manifest can not be got
+	ErrGettingManifest = fmt.Errorf("error getting manifest")
+	// ErrAuthenticating is returned when the credentials to access the registry can not be obtained
+	ErrAuthenticating = fmt.Errorf("error authenticating to the registry")
+	// ErrInvalidDigest is returned when a digest is not valid or its algorithm is not supported
+	ErrInvalidDigest = fmt.Errorf("invalid digest")
+	// ErrInvalidLayout is returned when an OCI image layout is not valid
+	ErrInvalidLayout = fmt.Errorf("invalid OCI image layout")
+	// ErrInvalidManifest is returned when a manifest is not valid
+	ErrInvalidManifest = fmt.Errorf("invalid manifest")
+	// ErrInvalidReference is returned when an artifact reference is not valid
+	ErrInvalidReference = fmt.Errorf("invalid artifact reference")
+	// ErrManifestNotFound is returned when a manifest does not exist
+	ErrManifestNotFound = fmt.Errorf("manifest not found")
+	// ErrReaderNotProvided is returned when the reader is not provided
+	ErrReaderNotProvided = fmt.Errorf("reader not provided")
+	// ErrReferenceNotProvided is returned when the artifact reference is not provided
+	ErrReferenceNotProvided = fmt.Errorf("artifact reference not provided")
+	// ErrRegistryClientNotInitialized is returned when the registry client is not initialized
+	ErrRegistryClientNotInitialized = fmt.Errorf("registry client not initialized")
+	// ErrUnsupportedMediaType is returned when a manifest has a media type that is not supported
+	ErrUnsupportedMediaType = fmt.Errorf("unsupported media type")
+)
@@ -0,0 +1,356 @@
+package oci
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/afero"
+)
+
+const (
+	// layoutFile is the file that identifies an OCI image layout
+	layoutFile = "oci-layout"
+	// layoutIndexFile is the file holding the index of an OCI image layout
+	layoutIndexFile = "index.json"
+	// layoutBlobsDir is the directory holding the blobs of an OCI image layout
+	layoutBlobsDir = "blobs"
+	// layoutVersion is the version of the OCI image layouts written
+	layoutVersion = "1.0.0"
+)
+
+// layoutMarker represents the content of the oci-layout file
+type layoutMarker struct {
+	ImageLayoutVersion string `json:"imageLayoutVersion"`
+}
+
+// ReadLayoutManifestDigest reads an OCI image-layout tarball and returns the digest of its manifest. The index of the layout must contain a single manifest
+func ReadLayoutManifestDigest(reader io.Reader) (string, error) {
+
+	if reader == nil {
+		return "", ErrReaderNotProvided
+	}
+
+	tarReader := tar.NewReader(reader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return "", fmt.Errorf("%w: %s not found", ErrInvalidLayout, layoutIndexFile)
+		}
+		if err != nil {
+			return "", fmt.Errorf("%w: %w", ErrInvalidLayout, err)
+		}
+
+		if layoutEntryName(header.Name) != layoutIndexFile {
+			continue
+		}
+
+		content, err := io.ReadAll(io.LimitReader(tarReader, maxManifestSize))
+		if err != nil {
+			return "", fmt.Errorf("%w: %w", ErrInvalidLayout, err)
+		}
+
+		index, err := parseIndex(content)
+		if err != nil {
+			return "", err
+		}
+
+		descriptor, err := index.selectManifest("")
+		if err != nil {
+			return "", err
+		}
+
+		return descriptor.Digest, nil
+	}
+}
+
+// WriteLayout writes an OCI image-layout tarball holding a manifest and its blobs. The content of each blob is provided by the open function, and it is verified against its digest while it is written
+func WriteLayout(writer io.Writer, manifestDigest string, manifestContent []byte, manifest *Manifest, open func(Descriptor) (io.ReadCloser, error)) error {
+
+	tarWriter := tar.NewWriter(writer)
+
+	marker, err := json.Marshal(&layoutMarker{ImageLayoutVersion: layoutVersion})
+	if err != nil {
+		return err
+	}
+
+	mediaType := manifest.MediaType
+	if mediaType == "" {
+		mediaType = MediaTypeImageManifest
+	}
+
+	index, err := json.Marshal(&Index{
+		SchemaVersion: 2,
+		MediaType:     MediaTypeImageIndex,
+		Manifests: []Descriptor{
+			{MediaType: mediaType, Digest: manifestDigest, Size: int64(len(manifestContent))},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	err = writeLayoutFile(tarWriter, layoutFile, marker)
+	if err != nil {
+		return err
+	}
+
+	err = writeLayoutFile(tarWriter, layoutIndexFile, index)
+	if err != nil {
+		return err
+	}
+
+	err = writeLayoutFile(tarWriter, blobPath(manifestDigest), manifestContent)
+	if err != nil {
+		return err
+	}
+
+	for _, descriptor := range manifest.Blobs() {
+		err = writeLayoutBlob(tarWriter, descriptor, open)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tarWriter.Close()
+}
+
+// writeLayoutFile writes a file into the OCI image-layout tarball
+func writeLayoutFile(tarWriter *tar.Writer, name string, content []byte) error {
+
+	err := tarWriter.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Mode:     0644,
+		Size:     int64(len(content)),
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = tarWriter.Write(content)
+	return err
+}
+
+// writeLayoutBlob writes a blob into the OCI image-layout tarball, verifying its content
+func writeLayoutBlob(tarWriter *tar.Writer, descriptor Descriptor, open func(Descriptor) (io.ReadCloser, error)) error {
+
+	verifier, err := NewVerifier(descriptor.Digest, descriptor.Size)
+	if err != nil {
+		return err
+	}
+
+	content, err := open(descriptor)
+	if err != nil {
+		return err
+	}
+	defer content.Close()
+
+	err = tarWriter.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     blobPath(descriptor.Digest),
+		Mode:     0644,
+		Size:     descriptor.Size,
+	})
+	if err != nil {
+		return err
+	}
+
+	// the content is limited to the expected size, and one more byte is read to detect a longer content
+	_, err = io.CopyN(io.MultiWriter(tarWriter, verifier), content, descriptor.Size)
+	if err != nil {
+		return fmt.Errorf("%w: %s: %w", ErrDigestMismatch, descriptor.Digest, err)
+	}
+
+	extra, _ := io.Copy(verifier, io.LimitReader(content, 1))
+	if extra > 0 {
+		return fmt.Errorf("%w: %s is longer than %d bytes", ErrDigestMismatch, descriptor.Digest, descriptor.Size)
+	}
+
+	return verifier.Verify()
+}
+
+// Layout represents an OCI image layout whose blobs are staged in a directory
+type Layout struct {
+	// dir is the directory where the blobs are staged
+	dir string
+	// fs is the filesystem where the blobs are staged
+	fs afero.Fs
+	// index is the index of the layout
+	index *Index
+}
+
+// StageLayout reads an OCI image-layout tarball and stages its blobs into a directory, so they can be read in any order. The blobs are stored by digest, so the entry names of the tarball are never used as paths
+func StageLayout(fs afero.Fs, reader io.Reader, dir string) (*Layout, error) {
+	var markerFound bool
+
+	if reader == nil {
+		return nil, ErrReaderNotProvided
+	}
+
+	layout := &Layout{
+		dir: dir,
+		fs:  fs,
+	}
+
+	tarReader := tar.NewReader(reader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidLayout, err)
+		}
+
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		name := layoutEntryName(header.Name)
+		switch {
+		case name == layoutFile:
+			markerFound = true
+		case name == layoutIndexFile:
+			content, err := io.ReadAll(io.LimitReader(tarReader, maxManifestSize))
+			if err != nil {
+				return nil, fmt.Errorf("%w: %w", ErrInvalidLayout, err)
+			}
+
+			layout.index, err = parseIndex(content)
+			if err != nil {
+				return nil, err
+			}
+		case strings.HasPrefix(name, layoutBlobsDir+"/"):
+			err = layout.stageBlob(name, tarReader)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if !markerFound {
+		return nil, fmt.Errorf("%w: %s not found", ErrInvalidLayout, layoutFile)
+	}
+
+	if layout.index == nil {
+		return nil, fmt.Errorf("%w: %s not found", ErrInvalidLayout, layoutIndexFile)
+	}
+
+	return layout, nil
+}
+
+// stageBlob stores the content of a blob entry of the layout tarball into the staging directory
+func (l *Layout) stageBlob(name string, reader io.Reader) error {
+
+	parts := strings.Split(name, "/")
+	if len(parts) != 3 {
+		return nil
+	}
+
+	digest := parts[1] + ":" + parts[2]
+	err := ValidateDigest(digest)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidLayout, err)
+	}
+
+	err = l.fs.MkdirAll(filepath.Join(l.dir, parts[1]), 0755)
+	if err != nil {
+		return err
+	}
+
+	file, err := l.fs.OpenFile(filepath.Join(l.dir, parts[1], parts[2]), os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = io.Copy(file, reader)
+	return err
+}
+
+// Manifest returns the manifest of the layout that matches the digest, verifying its content. When the digest is not provided, the layout must contain a single manifest
+func (l *Layout) Manifest(digest string) (*Manifest, error) {
+
+	descriptor, err := l.index.selectManifest(digest)
+	if err != nil {
+		return nil, err
+	}
+
+	switch descriptor.MediaType {
+	case MediaTypeImageManifest, MediaTypeDockerManifest, "":
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedMediaType, descriptor.MediaType)
+	}
+
+	if descriptor.Size > maxManifestSize {
+		return nil, fmt.Errorf("%w: the manifest exceeds %d bytes", ErrInvalidManifest, maxManifestSize)
+	}
+
+	err = l.VerifyBlob(*descriptor)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := afero.ReadFile(l.fs, l.blobFile(descriptor.Digest))
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseManifest(content)
+}
+
+// VerifyBlob verifies that the staged content of a blob matches its descriptor
+func (l *Layout) VerifyBlob(descriptor Descriptor) error {
+
+	file, err := l.OpenBlob(descriptor)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	err = VerifyReader(file, descriptor.Digest, descriptor.Size)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// OpenBlob opens the staged content of a blob, which must be closed by the caller
+func (l *Layout) OpenBlob(descriptor Descriptor) (io.ReadCloser, error) {
+
+	err := ValidateDigest(descriptor.Digest)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := l.fs.Open(l.blobFile(descriptor.Digest))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrBlobNotFound, descriptor.Digest)
+	}
+
+	return file, nil
+}
+
+// blobFile returns the path where a blob is staged
+func (l *Layout) blobFile(digest string) string {
+	algorithm, encoded, _ := strings.Cut(digest, ":")
+	return filepath.Join(l.dir, algorithm, encoded)
+}
+
+// blobPath returns the path of a blob in an OCI image layout
+func blobPath(digest string) string {
+	algorithm, encoded, _ := strings.Cut(digest, ":")
+	return path.Join(layoutBlobsDir, algorithm, encoded)
+}
+
+// layoutEntryName returns the name of a layout tarball entry relative to the layout root
+func layoutEntryName(name string) string {
+	return strings.TrimPrefix(path.Clean("/"+name), "/")
+}
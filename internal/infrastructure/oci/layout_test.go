@@ -0,0 +1,157 @@
+package oci
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/apenella/ransidble/test/ociregistry"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadLayoutManifestDigest(t *testing.T) {
+	artifact := ociregistry.NewArtifact(map[string]string{"site.yml": "---"})
+
+	tests := []struct {
+		desc         string
+		reader       io.Reader
+		digest       string
+		err          error
+		errContained error
+	}{
+		{
+			desc:   "Testing reading the manifest digest of an OCI image-layout tarball",
+			reader: bytes.NewReader(artifact.Layout()),
+			digest: artifact.Digest,
+		},
+		{
+			desc:         "Testing error reading the manifest digest of a content that is not an OCI image-layout tarball",
+			reader:       bytes.NewReader([]byte("content")),
+			errContained: ErrInvalidLayout,
+		},
+		{
+			desc:   "Testing error reading the manifest digest without reader",
+			reader: nil,
+			err:    ErrReaderNotProvided,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			digest, err := ReadLayoutManifestDigest(test.reader)
+			switch {
+			case test.err != nil:
+				assert.Equal(t, test.err, err)
+			case test.errContained != nil:
+				assert.ErrorIs(t, err, test.errContained)
+			default:
+				assert.NoError(t, err)
+				assert.Equal(t, test.digest, digest)
+			}
+		})
+	}
+}
+
+func TestStageLayout(t *testing.T) {
+	artifact := ociregistry.NewArtifact(map[string]string{"site.yml": "---"})
+
+	tests := []struct {
+		desc         string
+		digest       string
+		errContained error
+	}{
+		{
+			desc: "Testing staging an OCI image-layout tarball and reading its single manifest",
+		},
+		{
+			desc:   "Testing staging an OCI image-layout tarball and reading the manifest that matches a digest",
+			digest: artifact.Digest,
+		},
+		{
+			desc:         "Testing error reading a manifest that does not exist in the layout",
+			digest:       Digest([]byte("unknown")),
+			errContained: ErrManifestNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			fs := afero.NewMemMapFs()
+			layout, err := StageLayout(fs, bytes.NewReader(artifact.Layout()), "/staging")
+			assert.NoError(t, err)
+
+			manifest, err := layout.Manifest(test.digest)
+			if test.errContained != nil {
+				assert.ErrorIs(t, err, test.errContained)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Len(t, manifest.Layers, 1)
+			for _, blob := range manifest.Blobs() {
+				assert.NoError(t, layout.VerifyBlob(blob))
+			}
+		})
+	}
+}
+
+func TestStageLayout_Errors(t *testing.T) {
+	t.Log("Testing error staging a content that is not an OCI image-layout tarball")
+
+	_, err := StageLayout(afero.NewMemMapFs(), bytes.NewReader([]byte("content")), "/staging")
+	assert.ErrorIs(t, err, ErrInvalidLayout)
+}
+
+func TestWriteLayout(t *testing.T) {
+	artifact := ociregistry.NewArtifact(map[string]string{"site.yml": "---"})
+	manifest, err := ParseManifest(artifact.Manifest)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		desc         string
+		blobs        map[string][]byte
+		errContained error
+	}{
+		{
+			desc:  "Testing writing an OCI image-layout tarball",
+			blobs: artifact.Blobs,
+		},
+		{
+			desc: "Testing error writing an OCI image-layout tarball with a blob that does not match its digest",
+			blobs: map[string][]byte{
+				manifest.Config.Digest:    []byte("{}"),
+				manifest.Layers[0].Digest: bytes.Repeat([]byte("x"), int(manifest.Layers[0].Size)),
+			},
+			errContained: ErrDigestMismatch,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			buffer := &bytes.Buffer{}
+			err := WriteLayout(buffer, artifact.Digest, artifact.Manifest, manifest, func(descriptor Descriptor) (io.ReadCloser, error) {
+				return io.NopCloser(bytes.NewReader(test.blobs[descriptor.Digest])), nil
+			})
+
+			if test.errContained != nil {
+				assert.ErrorIs(t, err, test.errContained)
+				return
+			}
+
+			assert.NoError(t, err)
+			digest, err := ReadLayoutManifestDigest(bytes.NewReader(buffer.Bytes()))
+			assert.NoError(t, err)
+			assert.Equal(t, artifact.Digest, digest)
+		})
+	}
+}
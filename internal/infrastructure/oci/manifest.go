@@ -0,0 +1,157 @@
+package oci
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+const (
+	// MediaTypeImageManifest is the media type of the OCI image manifests
+	MediaTypeImageManifest = "application/vnd.oci.image.manifest.v1+json"
+	// MediaTypeImageIndex is the media type of the OCI image indexes
+	MediaTypeImageIndex = "application/vnd.oci.image.index.v1+json"
+	// MediaTypeDockerManifest is the media type of the Docker image manifests
+	MediaTypeDockerManifest = "application/vnd.docker.distribution.manifest.v2+json"
+	// MediaTypeDockerManifestList is the media type of the Docker manifest lists
+	MediaTypeDockerManifestList = "application/vnd.docker.distribution.manifest.list.v2+json"
+	// MediaTypeImageLayer is the media type of the uncompressed tar layers
+	MediaTypeImageLayer = "application/vnd.oci.image.layer.v1.tar"
+	// MediaTypeImageLayerGzip is the media type of the gzip compressed tar layers
+	MediaTypeImageLayerGzip = "application/vnd.oci.image.layer.v1.tar+gzip"
+	// MediaTypeDockerLayer is the media type of the Docker layers, which are gzip compressed tar files
+	MediaTypeDockerLayer = "application/vnd.docker.image.rootfs.diff.tar.gzip"
+
+	// AnnotationTitle is the annotation holding the file name of a layer that is not a tar file, as set by the artifact tools such as ORAS
+	AnnotationTitle = "org.opencontainers.image.title"
+
+	// maxManifestSize is the maximum size of the manifests and indexes read
+	maxManifestSize = 4 * 1024 * 1024
+)
+
+// Descriptor describes a content stored in a registry or in an image layout
+type Descriptor struct {
+	// MediaType is the media type of the content
+	MediaType string `json:"mediaType"`
+	// Digest is the digest of the content
+	Digest string `json:"digest"`
+	// Size is the size, in bytes, of the content
+	Size int64 `json:"size"`
+	// Annotations holds the annotations of the content
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// Manifest represents an image manifest, which describes the configuration and the layers of an artifact
+type Manifest struct {
+	// SchemaVersion is the version of the manifest schema. It must be 2
+	SchemaVersion int `json:"schemaVersion"`
+	// MediaType is the media type of the manifest
+	MediaType string `json:"mediaType,omitempty"`
+	// ArtifactType is the type of the artifact
+	ArtifactType string `json:"artifactType,omitempty"`
+	// Config describes the configuration of the artifact
+	Config Descriptor `json:"config"`
+	// Layers describes the layers of the artifact, from the base to the top layer
+	Layers []Descriptor `json:"layers"`
+}
+
+// Index represents an image index, which lists the manifests of an image layout
+type Index struct {
+	// SchemaVersion is the version of the index schema. It must be 2
+	SchemaVersion int `json:"schemaVersion"`
+	// MediaType is the media type of the index
+	MediaType string `json:"mediaType,omitempty"`
+	// Manifests describes the manifests of the index
+	Manifests []Descriptor `json:"manifests"`
+}
+
+// ParseManifest parses and validates an image manifest. The indexes are not supported, since a project is a single artifact
+func ParseManifest(content []byte) (*Manifest, error) {
+	var manifest Manifest
+
+	err := json.Unmarshal(content, &manifest)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidManifest, err)
+	}
+
+	switch manifest.MediaType {
+	case MediaTypeImageManifest, MediaTypeDockerManifest, "":
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedMediaType, manifest.MediaType)
+	}
+
+	if manifest.SchemaVersion != 2 {
+		return nil, fmt.Errorf("%w: unsupported schema version %d", ErrInvalidManifest, manifest.SchemaVersion)
+	}
+
+	for _, descriptor := range append([]Descriptor{manifest.Config}, manifest.Layers...) {
+		err = ValidateDigest(descriptor.Digest)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidManifest, err)
+		}
+
+		if descriptor.Size < 0 {
+			return nil, fmt.Errorf("%w: %s has a negative size", ErrInvalidManifest, descriptor.Digest)
+		}
+	}
+
+	return &manifest, nil
+}
+
+// Blobs returns the descriptors of the blobs referenced by the manifest, which are the configuration and the layers, without duplicates
+func (m *Manifest) Blobs() []Descriptor {
+	blobs := []Descriptor{m.Config}
+	seen := map[string]bool{m.Config.Digest: true}
+
+	for _, layer := range m.Layers {
+		if seen[layer.Digest] {
+			continue
+		}
+		seen[layer.Digest] = true
+		blobs = append(blobs, layer)
+	}
+
+	return blobs
+}
+
+// parseIndex parses and validates an image index
+func parseIndex(content []byte) (*Index, error) {
+	var index Index
+
+	err := json.Unmarshal(content, &index)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidLayout, err)
+	}
+
+	if index.SchemaVersion != 2 {
+		return nil, fmt.Errorf("%w: unsupported index schema version %d", ErrInvalidLayout, index.SchemaVersion)
+	}
+
+	for _, descriptor := range index.Manifests {
+		err = ValidateDigest(descriptor.Digest)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidLayout, err)
+		}
+	}
+
+	return &index, nil
+}
+
+// selectManifest returns the descriptor of the index manifest that matches the digest. When the digest is not provided, the index must contain a single manifest
+func (i *Index) selectManifest(digest string) (*Descriptor, error) {
+
+	if digest != "" {
+		for _, descriptor := range i.Manifests {
+			if descriptor.Digest == digest {
+				return &descriptor, nil
+			}
+		}
+
+		return nil, fmt.Errorf("%w: %s", ErrManifestNotFound, digest)
+	}
+
+	if len(i.Manifests) != 1 {
+		return nil, fmt.Errorf("%w: the index must contain a single manifest, found %d", ErrInvalidLayout, len(i.Manifests))
+	}
+
+	return &i.Manifests[0], nil
+}
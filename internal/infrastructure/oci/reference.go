@@ -0,0 +1,117 @@
+package oci
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// DefaultRegistry is the registry used when the reference does not include one
+	DefaultRegistry = "docker.io"
+	// DefaultTag is the tag used when the reference does not include a tag nor a digest
+	DefaultTag = "latest"
+
+	// dockerHubRegistry is the host serving the distribution API of the default registry
+	dockerHubRegistry = "registry-1.docker.io"
+	// dockerHubOfficialNamespace is the namespace of the official repositories of the default registry
+	dockerHubOfficialNamespace = "library"
+)
+
+// Reference represents the location of an artifact stored in an OCI distribution registry, such as registry.example.com/team/project:v1.0.0 or registry.example.com/team/project@sha256:...
+type Reference struct {
+	// Registry is the host, and optionally the port, of the registry
+	Registry string
+	// Repository is the repository of the artifact in the registry
+	Repository string
+	// Tag is the tag of the artifact. It is empty when the reference is set by digest
+	Tag string
+	// Digest is the digest of the artifact manifest. It is empty when the reference is set by tag
+	Digest string
+}
+
+// ParseReference parses an artifact reference. The registry defaults to DefaultRegistry when the first component of the reference is not a host, and the tag defaults to DefaultTag when neither a tag nor a digest are provided
+func ParseReference(reference string) (*Reference, error) {
+
+	if reference == "" {
+		return nil, ErrReferenceNotProvided
+	}
+
+	parsed := &Reference{}
+	remainder := reference
+
+	if index := strings.Index(remainder, "@"); index >= 0 {
+		parsed.Digest = remainder[index+1:]
+		remainder = remainder[:index]
+
+		err := ValidateDigest(parsed.Digest)
+		if err != nil {
+			return nil, fmt.Errorf("%w %s: %w", ErrInvalidReference, reference, err)
+		}
+	}
+
+	// the tag is placed after the last colon that follows the last slash, so the port of the registry is not taken as a tag
+	if index := strings.LastIndex(remainder, ":"); index > strings.LastIndex(remainder, "/") {
+		parsed.Tag = remainder[index+1:]
+		remainder = remainder[:index]
+	}
+
+	parsed.Registry = DefaultRegistry
+	if index := strings.Index(remainder, "/"); index >= 0 {
+		host := remainder[:index]
+		if strings.ContainsAny(host, ".:") || host == "localhost" {
+			parsed.Registry = host
+			remainder = remainder[index+1:]
+		}
+	}
+
+	if parsed.Registry == DefaultRegistry && !strings.Contains(remainder, "/") {
+		remainder = dockerHubOfficialNamespace + "/" + remainder
+	}
+	parsed.Repository = remainder
+
+	if parsed.Repository == "" || parsed.Repository != strings.ToLower(parsed.Repository) || strings.Contains(parsed.Repository, "//") {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidReference, reference)
+	}
+
+	if parsed.Tag == "" && parsed.Digest == "" {
+		parsed.Tag = DefaultTag
+	}
+
+	return parsed, nil
+}
+
+// String returns the reference using the digest when it is set, and the tag otherwise
+func (r *Reference) String() string {
+	if r.Digest != "" {
+		return fmt.Sprintf("%s/%s@%s", r.Registry, r.Repository, r.Digest)
+	}
+
+	return fmt.Sprintf("%s/%s:%s", r.Registry, r.Repository, r.Tag)
+}
+
+// WithDigest returns a copy of the reference that points to the given manifest digest
+func (r *Reference) WithDigest(digest string) *Reference {
+	return &Reference{
+		Registry:   r.Registry,
+		Repository: r.Repository,
+		Digest:     digest,
+	}
+}
+
+// manifestReference returns the tag or the digest used to request the manifest of the artifact
+func (r *Reference) manifestReference() string {
+	if r.Digest != "" {
+		return r.Digest
+	}
+
+	return r.Tag
+}
+
+// registryHost returns the host that serves the distribution API of the registry
+func (r *Reference) registryHost() string {
+	if r.Registry == DefaultRegistry {
+		return dockerHubRegistry
+	}
+
+	return r.Registry
+}
@@ -0,0 +1,84 @@
+package oci
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseReference(t *testing.T) {
+	digest := "sha256:" + strings.Repeat("a", 64)
+
+	tests := []struct {
+		desc         string
+		reference    string
+		expected     *Reference
+		err          error
+		errContained error
+	}{
+		{
+			desc:      "Testing parsing a reference with registry, repository and tag",
+			reference: "registry.example.com/team/project:v1.0.0",
+			expected:  &Reference{Registry: "registry.example.com", Repository: "team/project", Tag: "v1.0.0"},
+		},
+		{
+			desc:      "Testing parsing a reference with a registry port and without tag",
+			reference: "localhost:5000/project",
+			expected:  &Reference{Registry: "localhost:5000", Repository: "project", Tag: DefaultTag},
+		},
+		{
+			desc:      "Testing parsing a reference by digest",
+			reference: "registry.example.com/project@" + digest,
+			expected:  &Reference{Registry: "registry.example.com", Repository: "project", Digest: digest},
+		},
+		{
+			desc:      "Testing parsing a reference without registry",
+			reference: "project:v1",
+			expected:  &Reference{Registry: DefaultRegistry, Repository: "library/project", Tag: "v1"},
+		},
+		{
+			desc:      "Testing error parsing an empty reference",
+			reference: "",
+			err:       ErrReferenceNotProvided,
+		},
+		{
+			desc:         "Testing error parsing a reference with an invalid digest",
+			reference:    "registry.example.com/project@sha256:invalid",
+			errContained: ErrInvalidDigest,
+		},
+		{
+			desc:         "Testing error parsing a reference with an uppercase repository",
+			reference:    "registry.example.com/Project",
+			errContained: ErrInvalidReference,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			reference, err := ParseReference(test.reference)
+			switch {
+			case test.err != nil:
+				assert.Equal(t, test.err, err)
+			case test.errContained != nil:
+				assert.ErrorIs(t, err, test.errContained)
+			default:
+				assert.NoError(t, err)
+				assert.Equal(t, test.expected, reference)
+			}
+		})
+	}
+}
+
+func TestReferenceString(t *testing.T) {
+	t.Log("Testing the string representation of a reference by tag and by digest")
+
+	digest := "sha256:" + strings.Repeat("a", 64)
+	reference := &Reference{Registry: "registry.example.com", Repository: "project", Tag: "v1"}
+
+	assert.Equal(t, "registry.example.com/project:v1", reference.String())
+	assert.Equal(t, "registry.example.com/project@"+digest, reference.WithDigest(digest).String())
+}
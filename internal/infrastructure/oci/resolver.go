@@ -0,0 +1,39 @@
+package oci
+
+import (
+	"context"
+	"io"
+
+	"github.com/apenella/ransidble/internal/domain/ports/repository"
+)
+
+// Resolver resolves the digest of the artifacts, either stored in a registry or uploaded as an OCI image-layout tarball, so the projects are pinned to a manifest
+type Resolver struct {
+	// client is the registry client
+	client repository.OCIRegistryClient
+}
+
+// Ensure Resolver implements the SourceCodeOCIResolver interface
+var _ repository.SourceCodeOCIResolver = (*Resolver)(nil)
+
+// NewResolver creates a new Resolver
+func NewResolver(client repository.OCIRegistryClient) *Resolver {
+	return &Resolver{
+		client: client,
+	}
+}
+
+// ResolveReference returns the digest of the manifest pointed by an artifact reference. The requests to the registry are bounded by the timeout of the registry client, since the projects are created without a context
+func (r *Resolver) ResolveReference(reference string) (string, error) {
+
+	if r.client == nil {
+		return "", ErrRegistryClientNotInitialized
+	}
+
+	return r.client.Resolve(context.Background(), reference)
+}
+
+// ResolveLayout returns the digest of the manifest of an OCI image-layout tarball
+func (r *Resolver) ResolveLayout(reader io.Reader) (string, error) {
+	return ReadLayoutManifestDigest(reader)
+}
@@ -3,6 +3,10 @@ package fetch
 import "errors"
 
 var (
+	// ErrArchivingGitRepository represents an error when archiving the content of a git repository
+	ErrArchivingGitRepository = errors.New("error archiving git repository")
+	// ErrCloningGitRepository represents an error when cloning a git repository into the cache
+	ErrCloningGitRepository = errors.New("error cloning git repository")
 	// ErrCopyingAFileFromLocalToDirWorkingDir represents an error copying a file in the working directory
 	ErrCopyingAFileFromLocalToDirWorkingDir = errors.New("An error occurred copying a file in the working directory")
 	// ErrCopyingFilesToWorkingDir represents an error copying files to working directory
@@ -11,22 +15,48 @@ var (
 	ErrCreatingADirectoryInLocalDirWorkingDir = errors.New("An error occurred creating a directory in the working directory")
 	// ErrCreatingAFileFromLocalToDirWorkingDir represents an error creating a file in the working directory
 	ErrCreatingAFileFromLocalToDirWorkingDir = errors.New("An error occurred creating a file in the working directory")
+	// ErrExtractingGitRepository represents an error when extracting the content of a git repository into the working directory
+	ErrExtractingGitRepository = errors.New("error extracting git repository")
 	// ErrFetchingProjectFromLocalStorage represents an error when fetching a project from local storage
 	ErrFetchingProjectFromLocalStorage = errors.New("error fetching a project from local storage")
+	// ErrFetchingProjectFromOCIRegistry represents an error when fetching a project from an OCI registry
+	ErrFetchingProjectFromOCIRegistry = errors.New("error fetching a project from oci registry")
+	// ErrFetchingProjectFromS3Storage represents an error when fetching a project from an S3 storage
+	ErrFetchingProjectFromS3Storage = errors.New("error fetching a project from s3 storage")
 	// ErrFileSystemNotInitialized represents an error when the filesystem is not initialized
 	ErrFileSystemNotInitialized = errors.New("filesystem not initialized")
+	// ErrGitCachePathNotProvided represents an error when the path of the git repositories cache is not provided
+	ErrGitCachePathNotProvided = errors.New("git repositories cache path not provided")
 	// ErrGettingSourceCodeRelativePathFromLocalDir represents an error getting the relative path of the source code
 	ErrGettingSourceCodeRelativePathFromLocalDir = errors.New("An error occurred getting the relative path of the source code")
+	// ErrInvalidGitSubdirectory represents an error when the subdirectory of a git repository is not valid
+	ErrInvalidGitSubdirectory = errors.New("invalid git repository subdirectory")
 	// ErrInvalidProjectReference represents an error when the project reference is invalid
 	//ErrInvalidProjectReference = errors.New("invalid project reference")
+	// ErrOCIRegistryClientNotInitialized represents an error when the OCI registry client is not initialized
+	ErrOCIRegistryClientNotInitialized = errors.New("oci registry client not initialized")
+	// ErrObjectStorageClientNotInitialized represents an error when the object storage client is not initialized
+	ErrObjectStorageClientNotInitialized = errors.New("object storage client not initialized")
 	// ErrOpeningASourceCodeFileFromLocalDir represents an error opening a source code file
 	ErrOpeningASourceCodeFileFromLocalDir = errors.New("An error occurred opening a source code file")
+	// ErrProjectGitSourceNotProvided represents an error when the git repository of a project is not provided
+	ErrProjectGitSourceNotProvided = errors.New("project git repository not provided")
+	// ErrProjectOCISourceNotProvided represents an error when the OCI artifact of a project is not provided
+	ErrProjectOCISourceNotProvided = errors.New("project oci artifact not provided")
 	// ErrProjectNotProvided represents an error when the project is not provided
 	ErrProjectNotProvided = errors.New("project not provided")
 	// ErrProjectReferenceNotProvided represents an error when the project reference is not provided
 	ErrProjectReferenceNotProvided = errors.New("project reference not provided")
+	// ErrResolvingGitRef represents an error when a git ref can not be resolved to a commit
+	ErrResolvingGitRef = errors.New("error resolving git ref")
 	// ErrSourceCodeNotExists represents an error when the source code does not exists
 	ErrSourceCodeNotExists = errors.New("source code does not exists")
+	// ErrTarExtractorNotProvided represents an error when the tar extractor is not provided
+	ErrTarExtractorNotProvided = errors.New("tar extractor not provided")
+	// ErrUpdatingGitRepository represents an error when updating a git repository in the cache
+	ErrUpdatingGitRepository = errors.New("error updating git repository")
+	// ErrVerifyingProjectDigest represents an error when the fetched source code does not match the digest of the project
+	ErrVerifyingProjectDigest = errors.New("error verifying project digest")
 	// ErrWalkingDirToFetchSourceCodeFromLocalDir represents an error walking through the source code directory
 	ErrWalkingDirToFetchSourceCodeFromLocalDir = errors.New("An error occurred walking through the source code directory")
 	// ErrWorkingDirNotExists represents an error when the destination to fetch does not exists
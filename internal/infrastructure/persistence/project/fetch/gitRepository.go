@@ -0,0 +1,394 @@
+package fetch
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	"github.com/apenella/ransidble/internal/domain/ports/repository"
+)
+
+const (
+	// DefaultGitUsername is the username sent along with the token when authenticating to a git server over HTTP
+	DefaultGitUsername = "git"
+	// gitDefaultRef is the ref fetched when the project does not define one, which points to the default branch of the repository
+	gitDefaultRef = "HEAD"
+)
+
+// GitAuth represents the credentials used to access the git repositories. The credentials are server-side secrets, so they are never stored along with the projects, and they are only sent to the hosts they are configured for
+type GitAuth struct {
+	// Hosts are the hosts, optionally followed by a port, of the repositories the credentials are sent to. The credentials are never sent when it is empty
+	Hosts []string
+	// SSHKeyPath is the path of the private key used to access the repositories over SSH
+	SSHKeyPath string
+	// Token is the token used to access the repositories over HTTP
+	Token string
+	// Username is the username sent along with the token. DefaultGitUsername is used when it is not provided
+	Username string
+}
+
+// GitRepository represents a repository to fetch the projects stored in git repositories. The repositories are mirrored in a local cache, which is updated every time a project is fetched
+type GitRepository struct {
+	// auth holds the credentials to access the git repositories
+	auth *GitAuth
+	// cachePath is the path where the git repositories are mirrored
+	cachePath string
+	// extractor extracts the content archived from a git repository into the working directory
+	extractor repository.SourceCodeTarExtractorer
+	// logger is the logger
+	logger repository.Logger
+	// mirrorsMutex protects the mirrors map
+	mirrorsMutex sync.Mutex
+	// mirrors holds a mutex for each mirrored repository, so a repository is not updated while it is being archived
+	mirrors map[string]*sync.Mutex
+}
+
+// Ensure GitRepository implements the SourceCodeFetcher interface
+var _ repository.SourceCodeFetcher = (*GitRepository)(nil)
+
+// NewGitRepository creates a new git project repository
+func NewGitRepository(cachePath string, extractor repository.SourceCodeTarExtractorer, auth *GitAuth, logger repository.Logger) *GitRepository {
+	return &GitRepository{
+		auth:      auth,
+		cachePath: cachePath,
+		extractor: extractor,
+		logger:    logger,
+		mirrors:   make(map[string]*sync.Mutex),
+	}
+}
+
+// Fetch method copies the project from its git repository to working directory. The project ref is resolved to a commit on the mirrored repository, and the content of the commit, or of its subdirectory, is extracted into the working directory. The git commands are killed when the context is done
+func (g *GitRepository) Fetch(ctx context.Context, project *entity.Project, workingDir string) error {
+
+	if project == nil {
+		g.logger.Error(
+			ErrProjectNotProvided.Error(),
+			map[string]interface{}{
+				"component": "GitRepository.Fetch",
+				"package":   "github.com/apenella/ransidble/internal/infrastructure/persistence/project/fetch",
+			})
+		return ErrProjectNotProvided
+	}
+
+	if project.Git == nil || project.Git.URL == "" {
+		g.logger.Error(
+			ErrProjectGitSourceNotProvided.Error(),
+			map[string]interface{}{
+				"component":  "GitRepository.Fetch",
+				"package":    "github.com/apenella/ransidble/internal/infrastructure/persistence/project/fetch",
+				"project_id": project.Name,
+			})
+		return ErrProjectGitSourceNotProvided
+	}
+
+	if workingDir == "" {
+		g.logger.Error(
+			ErrWorkingDirNotProvided.Error(),
+			map[string]interface{}{
+				"component":  "GitRepository.Fetch",
+				"package":    "github.com/apenella/ransidble/internal/infrastructure/persistence/project/fetch",
+				"project_id": project.Name,
+			})
+		return ErrWorkingDirNotProvided
+	}
+
+	if g.cachePath == "" {
+		g.logger.Error(
+			ErrGitCachePathNotProvided.Error(),
+			map[string]interface{}{
+				"component":  "GitRepository.Fetch",
+				"package":    "github.com/apenella/ransidble/internal/infrastructure/persistence/project/fetch",
+				"project_id": project.Name,
+			})
+		return ErrGitCachePathNotProvided
+	}
+
+	if g.extractor == nil {
+		g.logger.Error(
+			ErrTarExtractorNotProvided.Error(),
+			map[string]interface{}{
+				"component":  "GitRepository.Fetch",
+				"package":    "github.com/apenella/ransidble/internal/infrastructure/persistence/project/fetch",
+				"project_id": project.Name,
+			})
+		return ErrTarExtractorNotProvided
+	}
+
+	subdirectory, err := gitSubdirectory(project.Git.Subdirectory)
+	if err != nil {
+		g.logger.Error(
+			err.Error(),
+			map[string]interface{}{
+				"component":    "GitRepository.Fetch",
+				"package":      "github.com/apenella/ransidble/internal/infrastructure/persistence/project/fetch",
+				"project_id":   project.Name,
+				"subdirectory": project.Git.Subdirectory,
+			})
+		return err
+	}
+
+	ref := project.Git.Ref
+	if ref == "" {
+		ref = gitDefaultRef
+	}
+
+	mirror := g.mirrorPath(project.Git.URL)
+
+	mutex := g.mirrorMutex(mirror)
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	err = g.updateMirror(ctx, project.Git.URL, mirror)
+	if err != nil {
+		g.logger.Error(
+			err.Error(),
+			map[string]interface{}{
+				"component":  "GitRepository.Fetch",
+				"package":    "github.com/apenella/ransidble/internal/infrastructure/persistence/project/fetch",
+				"project_id": project.Name,
+				"url":        project.Git.URL,
+			})
+		return err
+	}
+
+	commit, err := g.git(ctx, g.environment(""), "--git-dir", mirror, "rev-parse", "--verify", "--quiet", ref+"^{commit}")
+	if err != nil {
+		g.logger.Error(
+			fmt.Sprintf("%s: %s", ErrResolvingGitRef, err),
+			map[string]interface{}{
+				"component":  "GitRepository.Fetch",
+				"package":    "github.com/apenella/ransidble/internal/infrastructure/persistence/project/fetch",
+				"project_id": project.Name,
+				"ref":        ref,
+				"url":        project.Git.URL,
+			})
+		return fmt.Errorf("%w %s: %w", ErrResolvingGitRef, ref, err)
+	}
+
+	treeish := strings.TrimSpace(commit)
+	if subdirectory != "" {
+		treeish = treeish + ":" + subdirectory
+	}
+
+	g.logger.Debug("fetching project", map[string]interface{}{
+		"component":   "GitRepository.Fetch",
+		"commit":      strings.TrimSpace(commit),
+		"package":     "github.com/apenella/ransidble/internal/infrastructure/persistence/project/fetch",
+		"project_id":  project.Name,
+		"ref":         ref,
+		"url":         project.Git.URL,
+		"working_dir": workingDir,
+	})
+
+	err = g.archive(ctx, mirror, treeish, workingDir)
+	if err != nil {
+		g.logger.Error(
+			err.Error(),
+			map[string]interface{}{
+				"component":   "GitRepository.Fetch",
+				"package":     "github.com/apenella/ransidble/internal/infrastructure/persistence/project/fetch",
+				"project_id":  project.Name,
+				"ref":         ref,
+				"url":         project.Git.URL,
+				"working_dir": workingDir,
+			})
+		return err
+	}
+
+	return nil
+}
+
+// updateMirror clones the repository into the cache when it is not mirrored yet, or fetches its changes otherwise
+func (g *GitRepository) updateMirror(ctx context.Context, url string, mirror string) error {
+
+	_, err := os.Stat(mirror)
+	if err == nil {
+		_, err = g.git(ctx, g.environment(url), "--git-dir", mirror, "fetch", "--prune", "--quiet", "origin")
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrUpdatingGitRepository, err)
+		}
+		return nil
+	}
+
+	err = os.MkdirAll(g.cachePath, 0755)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrCloningGitRepository, err)
+	}
+
+	_, err = g.git(ctx, g.environment(url), "clone", "--mirror", "--quiet", "--", url, mirror)
+	if err != nil {
+		// a partial clone must not be taken as a mirrored repository
+		_ = os.RemoveAll(mirror)
+		return fmt.Errorf("%w: %w", ErrCloningGitRepository, err)
+	}
+
+	return nil
+}
+
+// archive extracts the content of the tree-ish from the mirrored repository into the working directory
+func (g *GitRepository) archive(ctx context.Context, mirror string, treeish string, workingDir string) error {
+	var stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, "git", "--git-dir", mirror, "archive", "--format=tar", treeish)
+	cmd.Env = g.environment("")
+	cmd.Stderr = &stderr
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrArchivingGitRepository, err)
+	}
+
+	err = cmd.Start()
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrArchivingGitRepository, err)
+	}
+
+	errExtract := g.extractor.Extract(stdout, workingDir, nil)
+	if errExtract != nil {
+		// the archive command could be blocked writing to the pipe that is not read anymore
+		_ = cmd.Process.Kill()
+	}
+
+	err = cmd.Wait()
+	if errExtract != nil {
+		return fmt.Errorf("%w: %w", ErrExtractingGitRepository, errExtract)
+	}
+
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrArchivingGitRepository, gitCommandError(err, stderr.String()))
+	}
+
+	return nil
+}
+
+// git runs a git command using the given environment and returns its output. The command is killed when the context is done
+func (g *GitRepository) git(ctx context.Context, env []string, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Env = env
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	if err != nil {
+		return "", gitCommandError(err, stderr.String())
+	}
+
+	return stdout.String(), nil
+}
+
+// environment returns the environment of the git commands reaching the repository URL, or of the commands that do not reach any repository when the URL is empty. Git never prompts for credentials, and the configured credentials are provided through the environment, so they are not stored on the mirrored repositories nor shown on the command arguments. The credentials are only provided when the repository host is allowed to receive them
+func (g *GitRepository) environment(repositoryURL string) []string {
+	env := append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+
+	if g.auth == nil || repositoryURL == "" {
+		return env
+	}
+
+	scheme, host, err := entity.ParseProjectGitURL(repositoryURL)
+	if err != nil || !g.credentialHost(host) {
+		return env
+	}
+
+	if g.auth.SSHKeyPath != "" && scheme == "ssh" {
+		env = append(env, fmt.Sprintf("GIT_SSH_COMMAND=ssh -i %s -o IdentitiesOnly=yes -o BatchMode=yes", g.auth.SSHKeyPath))
+	}
+
+	if g.auth.Token != "" && (scheme == "http" || scheme == "https") {
+		username := g.auth.Username
+		if username == "" {
+			username = DefaultGitUsername
+		}
+
+		credentials := base64.StdEncoding.EncodeToString([]byte(username + ":" + g.auth.Token))
+		// the header is scoped to the repository host, so it is not sent to any other URL
+		env = append(env,
+			"GIT_CONFIG_COUNT=1",
+			fmt.Sprintf("GIT_CONFIG_KEY_0=http.%s://%s/.extraHeader", scheme, host),
+			"GIT_CONFIG_VALUE_0=Authorization: Basic "+credentials,
+		)
+	}
+
+	return env
+}
+
+// credentialHost returns whether the credentials can be sent to the host. The host matches a configured host either by its name and port or, when the configured host does not define a port, by its name
+func (g *GitRepository) credentialHost(host string) bool {
+	hostname := host
+	if index := strings.LastIndex(host, ":"); index >= 0 && !strings.HasSuffix(host, "]") {
+		hostname = host[:index]
+	}
+
+	for _, allowed := range g.auth.Hosts {
+		allowed = strings.TrimSpace(allowed)
+		if allowed == "" {
+			continue
+		}
+
+		if strings.EqualFold(allowed, host) || (!strings.Contains(allowed, ":") && strings.EqualFold(allowed, hostname)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// mirrorPath returns the path where a repository is mirrored. The path is derived from the repository URL, so the projects sharing a repository share its mirror
+func (g *GitRepository) mirrorPath(url string) string {
+	hash := sha256.Sum256([]byte(url))
+	return filepath.Join(g.cachePath, hex.EncodeToString(hash[:])+".git")
+}
+
+// mirrorMutex returns the mutex that protects a mirrored repository
+func (g *GitRepository) mirrorMutex(mirror string) *sync.Mutex {
+	g.mirrorsMutex.Lock()
+	defer g.mirrorsMutex.Unlock()
+
+	mutex, exists := g.mirrors[mirror]
+	if !exists {
+		mutex = &sync.Mutex{}
+		g.mirrors[mirror] = mutex
+	}
+
+	return mutex
+}
+
+// gitSubdirectory cleans the subdirectory of a repository, which must be relative to the repository root
+func gitSubdirectory(subdirectory string) (string, error) {
+
+	if subdirectory == "" {
+		return "", nil
+	}
+
+	cleaned := path.Clean(filepath.ToSlash(subdirectory))
+	if path.IsAbs(cleaned) || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", fmt.Errorf("%w: %s", ErrInvalidGitSubdirectory, subdirectory)
+	}
+
+	if cleaned == "." {
+		return "", nil
+	}
+
+	return cleaned, nil
+}
+
+// gitCommandError returns the error of a git command along with the message it wrote to the standard error
+func gitCommandError(err error, stderr string) error {
+	message := strings.TrimSpace(stderr)
+	if message == "" {
+		return err
+	}
+
+	return fmt.Errorf("%w: %s", err, message)
+}
@@ -0,0 +1,325 @@
+package fetch
+
+import (
+	"context"
+	"encoding/base64"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	"github.com/apenella/ransidble/internal/infrastructure/logger"
+	"github.com/apenella/ransidble/internal/infrastructure/tar"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+// runGit runs a git command on the given directory to arrange the tests
+func runGit(t *testing.T, dir string, args ...string) string {
+	cmd := exec.Command("git", append([]string{"-c", "user.name=ransidble", "-c", "user.email=ransidble@example.com", "-c", "commit.gpgsign=false"}, args...)...)
+	cmd.Dir = dir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %s: %s: %s", strings.Join(args, " "), err, output)
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// commitFiles writes the files into the repository and commits them
+func commitFiles(t *testing.T, dir string, message string, files map[string]string) {
+	for name, content := range files {
+		err := os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0755)
+		assert.NoError(t, err)
+		err = os.WriteFile(filepath.Join(dir, name), []byte(content), 0644)
+		assert.NoError(t, err)
+	}
+	runGit(t, dir, "add", "--all")
+	runGit(t, dir, "commit", "--quiet", "-m", message)
+}
+
+// arrangeGitRepository creates a bare repository with two commits on its main branch, being the first one tagged as v1.0.0. It returns the bare repository path, the work tree used to push changes and the commit tagged
+func arrangeGitRepository(t *testing.T) (string, string, string) {
+	base := t.TempDir()
+	workTree := filepath.Join(base, "work-tree")
+	bare := filepath.Join(base, "project.git")
+
+	err := os.MkdirAll(workTree, 0755)
+	assert.NoError(t, err)
+
+	runGit(t, workTree, "init", "--quiet", "--initial-branch=main")
+	commitFiles(t, workTree, "first commit", map[string]string{
+		"site.yml":         "version: v1.0.0\n",
+		"ansible/site.yml": "subdirectory: v1.0.0\n",
+	})
+	runGit(t, workTree, "tag", "v1.0.0")
+	tagged := runGit(t, workTree, "rev-parse", "HEAD")
+
+	commitFiles(t, workTree, "second commit", map[string]string{
+		"site.yml":         "version: v2.0.0\n",
+		"ansible/site.yml": "subdirectory: v2.0.0\n",
+	})
+
+	runGit(t, base, "clone", "--quiet", "--bare", workTree, bare)
+	runGit(t, workTree, "remote", "add", "origin", bare)
+
+	return bare, workTree, tagged
+}
+
+func TestGitRepositoryFetch(t *testing.T) {
+
+	_, err := exec.LookPath("git")
+	if err != nil {
+		t.Skip("git is not available")
+	}
+
+	bare, _, tagged := arrangeGitRepository(t)
+
+	tests := []struct {
+		desc         string
+		project      *entity.Project
+		expected     map[string]string
+		err          error
+		errContained error
+	}{
+		{
+			desc:     "Testing fetch a project from the default branch of a git repository",
+			project:  &entity.Project{Name: "project", Git: entity.NewProjectGitSource(bare, "", "")},
+			expected: map[string]string{"site.yml": "version: v2.0.0\n", "ansible/site.yml": "subdirectory: v2.0.0\n"},
+		},
+		{
+			desc:     "Testing fetch a project from a branch of a git repository",
+			project:  &entity.Project{Name: "project", Git: entity.NewProjectGitSource(bare, "main", "")},
+			expected: map[string]string{"site.yml": "version: v2.0.0\n", "ansible/site.yml": "subdirectory: v2.0.0\n"},
+		},
+		{
+			desc:     "Testing fetch a project from a tag of a git repository",
+			project:  &entity.Project{Name: "project", Git: entity.NewProjectGitSource(bare, "v1.0.0", "")},
+			expected: map[string]string{"site.yml": "version: v1.0.0\n", "ansible/site.yml": "subdirectory: v1.0.0\n"},
+		},
+		{
+			desc:     "Testing fetch a project from a commit of a git repository",
+			project:  &entity.Project{Name: "project", Git: entity.NewProjectGitSource(bare, tagged, "")},
+			expected: map[string]string{"site.yml": "version: v1.0.0\n", "ansible/site.yml": "subdirectory: v1.0.0\n"},
+		},
+		{
+			desc:     "Testing fetch a project from a subdirectory of a git repository",
+			project:  &entity.Project{Name: "project", Git: entity.NewProjectGitSource(bare, "v1.0.0", "ansible/")},
+			expected: map[string]string{"site.yml": "subdirectory: v1.0.0\n"},
+		},
+		{
+			desc:         "Testing error fetching a project from an unknown ref of a git repository",
+			project:      &entity.Project{Name: "project", Git: entity.NewProjectGitSource(bare, "unknown", "")},
+			errContained: ErrResolvingGitRef,
+		},
+		{
+			desc:         "Testing error fetching a project from an unknown subdirectory of a git repository",
+			project:      &entity.Project{Name: "project", Git: entity.NewProjectGitSource(bare, "main", "unknown")},
+			errContained: ErrArchivingGitRepository,
+		},
+		{
+			desc:         "Testing error fetching a project from a subdirectory outside of a git repository",
+			project:      &entity.Project{Name: "project", Git: entity.NewProjectGitSource(bare, "main", "../outside")},
+			errContained: ErrInvalidGitSubdirectory,
+		},
+		{
+			desc:         "Testing error fetching a project from an unknown git repository",
+			project:      &entity.Project{Name: "project", Git: entity.NewProjectGitSource(filepath.Join(t.TempDir(), "unknown.git"), "", "")},
+			errContained: ErrCloningGitRepository,
+		},
+		{
+			desc:    "Testing error fetching a project without git repository",
+			project: &entity.Project{Name: "project"},
+			err:     ErrProjectGitSourceNotProvided,
+		},
+		{
+			desc: "Testing error fetching a project when the project is not provided",
+			err:  ErrProjectNotProvided,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			workingDir := t.TempDir()
+			fs := afero.NewOsFs()
+			repository := NewGitRepository(t.TempDir(), tar.NewTar(fs, logger.NewFakeLogger()), nil, logger.NewFakeLogger())
+
+			err := repository.Fetch(context.Background(), test.project, workingDir)
+			switch {
+			case test.err != nil:
+				assert.Equal(t, test.err, err)
+			case test.errContained != nil:
+				assert.ErrorIs(t, err, test.errContained)
+			default:
+				assert.NoError(t, err)
+				for name, content := range test.expected {
+					data, err := afero.ReadFile(fs, filepath.Join(workingDir, name))
+					assert.NoError(t, err)
+					assert.Equal(t, content, string(data))
+				}
+				_, err = fs.Stat(filepath.Join(workingDir, ".git"))
+				assert.True(t, os.IsNotExist(err))
+			}
+		})
+	}
+}
+
+func TestGitRepositoryFetch_UpdatesMirror(t *testing.T) {
+	t.Log("Testing fetch a project from a git repository updates the mirrored repository")
+
+	_, err := exec.LookPath("git")
+	if err != nil {
+		t.Skip("git is not available")
+	}
+
+	bare, workTree, _ := arrangeGitRepository(t)
+	fs := afero.NewOsFs()
+	cachePath := t.TempDir()
+	project := &entity.Project{Name: "project", Git: entity.NewProjectGitSource(bare, "main", "")}
+
+	repository := NewGitRepository(cachePath, tar.NewTar(fs, logger.NewFakeLogger()), nil, logger.NewFakeLogger())
+
+	workingDir := t.TempDir()
+	err = repository.Fetch(context.Background(), project, workingDir)
+	assert.NoError(t, err)
+
+	mirrors, err := os.ReadDir(cachePath)
+	assert.NoError(t, err)
+	assert.Len(t, mirrors, 1)
+
+	commitFiles(t, workTree, "third commit", map[string]string{"site.yml": "version: v3.0.0\n"})
+	runGit(t, workTree, "push", "--quiet", "origin", "main")
+
+	workingDir = t.TempDir()
+	err = repository.Fetch(context.Background(), project, workingDir)
+	assert.NoError(t, err)
+
+	data, err := afero.ReadFile(fs, filepath.Join(workingDir, "site.yml"))
+	assert.NoError(t, err)
+	assert.Equal(t, "version: v3.0.0\n", string(data))
+}
+
+func TestGitRepositoryFetch_ContextDone(t *testing.T) {
+	t.Log("Testing fetch a project from a git repository does not run the git commands once the context is done")
+
+	_, err := exec.LookPath("git")
+	if err != nil {
+		t.Skip("git is not available")
+	}
+
+	bare, _, _ := arrangeGitRepository(t)
+	cachePath := t.TempDir()
+	project := &entity.Project{Name: "project", Git: entity.NewProjectGitSource(bare, "main", "")}
+
+	repository := NewGitRepository(cachePath, tar.NewTar(afero.NewOsFs(), logger.NewFakeLogger()), nil, logger.NewFakeLogger())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err = repository.Fetch(ctx, project, t.TempDir())
+	assert.ErrorIs(t, err, ErrCloningGitRepository)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	mirrors, err := os.ReadDir(cachePath)
+	assert.NoError(t, err)
+	assert.Empty(t, mirrors)
+}
+
+func TestGitRepositoryEnvironment(t *testing.T) {
+	tests := []struct {
+		desc       string
+		auth       *GitAuth
+		url        string
+		contains   []string
+		notContain []string
+	}{
+		{
+			desc:       "Testing the git environment without credentials",
+			auth:       nil,
+			url:        "https://git.example.com/project.git",
+			contains:   []string{"GIT_TERMINAL_PROMPT=0"},
+			notContain: []string{"GIT_CONFIG_COUNT=1"},
+		},
+		{
+			desc: "Testing the git environment with an SSH key",
+			auth: &GitAuth{Hosts: []string{"git.example.com"}, SSHKeyPath: "/secrets/id_ed25519"},
+			url:  "git@git.example.com:project.git",
+			contains: []string{
+				"GIT_TERMINAL_PROMPT=0",
+				"GIT_SSH_COMMAND=ssh -i /secrets/id_ed25519 -o IdentitiesOnly=yes -o BatchMode=yes",
+			},
+			notContain: []string{"GIT_CONFIG_COUNT=1"},
+		},
+		{
+			desc: "Testing the git environment with a token",
+			auth: &GitAuth{Hosts: []string{"git.example.com"}, Token: "secret"},
+			url:  "https://git.example.com/project.git",
+			contains: []string{
+				"GIT_CONFIG_COUNT=1",
+				"GIT_CONFIG_KEY_0=http.https://git.example.com/.extraHeader",
+				"GIT_CONFIG_VALUE_0=Authorization: Basic " + base64.StdEncoding.EncodeToString([]byte("git:secret")),
+			},
+		},
+		{
+			desc: "Testing the git environment with a token and a username",
+			auth: &GitAuth{Hosts: []string{"git.example.com"}, Token: "secret", Username: "ransidble"},
+			url:  "https://git.example.com:8443/project.git",
+			contains: []string{
+				"GIT_CONFIG_KEY_0=http.https://git.example.com:8443/.extraHeader",
+				"GIT_CONFIG_VALUE_0=Authorization: Basic " + base64.StdEncoding.EncodeToString([]byte("ransidble:secret")),
+			},
+		},
+		{
+			desc:       "Testing the git environment with a token for a host that is not allowed",
+			auth:       &GitAuth{Hosts: []string{"git.example.com"}, SSHKeyPath: "/secrets/id_ed25519", Token: "secret"},
+			url:        "https://attacker.example.org/project.git",
+			contains:   []string{"GIT_TERMINAL_PROMPT=0"},
+			notContain: []string{"GIT_CONFIG_COUNT=1", "GIT_SSH_COMMAND=ssh -i /secrets/id_ed25519 -o IdentitiesOnly=yes -o BatchMode=yes"},
+		},
+		{
+			desc:       "Testing the git environment with a token for a port that is not allowed",
+			auth:       &GitAuth{Hosts: []string{"git.example.com:8443"}, Token: "secret"},
+			url:        "https://git.example.com:9443/project.git",
+			notContain: []string{"GIT_CONFIG_COUNT=1"},
+		},
+		{
+			desc:       "Testing the git environment with a token without allowed hosts",
+			auth:       &GitAuth{Token: "secret"},
+			url:        "https://git.example.com/project.git",
+			notContain: []string{"GIT_CONFIG_COUNT=1"},
+		},
+		{
+			desc:       "Testing the git environment with a token for a local repository",
+			auth:       &GitAuth{Hosts: []string{"git.example.com"}, Token: "secret"},
+			url:        "file:///srv/project.git",
+			notContain: []string{"GIT_CONFIG_COUNT=1"},
+		},
+		{
+			desc:       "Testing the git environment of the commands that do not reach any repository",
+			auth:       &GitAuth{Hosts: []string{"git.example.com"}, Token: "secret"},
+			url:        "",
+			notContain: []string{"GIT_CONFIG_COUNT=1"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			repository := NewGitRepository("cache", nil, test.auth, logger.NewFakeLogger())
+			env := repository.environment(test.url)
+
+			for _, variable := range test.contains {
+				assert.Contains(t, env, variable)
+			}
+			for _, variable := range test.notContain {
+				assert.NotContains(t, env, variable)
+			}
+		})
+	}
+}
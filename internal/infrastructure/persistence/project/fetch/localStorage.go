@@ -1,7 +1,9 @@
 package fetch
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"path/filepath"
 
 	"github.com/apenella/ransidble/internal/domain/core/entity"
@@ -31,8 +33,8 @@ func NewLocalStorage(fs afero.Fs, path string, logger repository.Logger) *LocalS
 	}
 }
 
-// Fetch method copies the project from local storage to working directory
-func (s *LocalStorage) Fetch(project *entity.Project, workingDir string) (err error) {
+// Fetch method copies the project from local storage to working directory. The packed source code is verified against the digest of the project
+func (s *LocalStorage) Fetch(_ context.Context, project *entity.Project, workingDir string) (err error) {
 
 	var sourceCodeFetcher SourceCodeFetcher
 	var workingDirExist bool
@@ -133,5 +135,43 @@ func (s *LocalStorage) Fetch(project *entity.Project, workingDir string) (err er
 		return fmt.Errorf("%s: %w", ErrFetchingProjectFromLocalStorage, err)
 	}
 
+	// the packed source code is verified once it is copied to the working directory, since that copy is the one unpacked
+	if !infoProjectReference.IsDir() {
+		err = s.verifyDigest(project, filepath.Join(workingDir, infoProjectReference.Name()))
+		if err != nil {
+			s.logger.Error(
+				fmt.Sprintf("%s: %s", ErrVerifyingProjectDigest, err),
+				map[string]interface{}{
+					"component":  "LocalStorage.Fetch",
+					"package":    "github.com/apenella/ransidble/internal/infrastructure/persistence/project/fetch",
+					"project_id": project.Name,
+					"reference":  project.Reference,
+				})
+			return fmt.Errorf("%s: %w", ErrVerifyingProjectDigest, err)
+		}
+	}
+
 	return nil
 }
+
+// verifyDigest computes the digest of the fetched source code and verifies it against the digest of the project
+func (s *LocalStorage) verifyDigest(project *entity.Project, path string) error {
+
+	if project.Digest == "" {
+		return nil
+	}
+
+	file, err := s.fs.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	digester := entity.NewProjectDigester(file)
+	_, err = io.Copy(io.Discard, digester)
+	if err != nil {
+		return err
+	}
+
+	return project.VerifyDigest(digester.Digest())
+}
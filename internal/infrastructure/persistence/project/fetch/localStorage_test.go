@@ -1,6 +1,7 @@
 package fetch
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
@@ -83,6 +84,42 @@ func TestFetch(t *testing.T) {
 				assert.Nil(t, err)
 			},
 		},
+		{
+			desc:    "Testing fetch a project in tar.gz format from local storage verifying its digest",
+			storage: NewLocalStorage(fs, souceCodeStorageLocation, logger.NewFakeLogger()),
+			project: &entity.Project{
+				Digest:    "sha256:019a84ed59e645d80c76c1734c96b164ecfec747c3fe0eb3553cf9807ec67b3f",
+				Name:      project2Name,
+				Reference: sourceProject2,
+				Format:    "targz",
+				Storage:   "local",
+			},
+			workingDir: workingDir,
+			err:        nil,
+			arrangeFunc: func(t *testing.T, storage *LocalStorage) {
+				storage.fs.MkdirAll(workingDir, os.ModePerm)
+			},
+			assertFunc: func(t *testing.T, storage *LocalStorage) {
+				_, err := storage.fs.Stat(project2ExpectedFile)
+				assert.Nil(t, err)
+			},
+		},
+		{
+			desc:    "Testing error fetching a project in tar.gz format from local storage when its digest does not match",
+			storage: NewLocalStorage(fs, souceCodeStorageLocation, logger.NewFakeLogger()),
+			project: &entity.Project{
+				Digest:    "sha256:0000000000000000000000000000000000000000000000000000000000000000",
+				Name:      project2Name,
+				Reference: sourceProject2,
+				Format:    "targz",
+				Storage:   "local",
+			},
+			workingDir: workingDir,
+			err:        fmt.Errorf("%s: %w", ErrVerifyingProjectDigest, fmt.Errorf("%w: expected sha256:0000000000000000000000000000000000000000000000000000000000000000, got sha256:019a84ed59e645d80c76c1734c96b164ecfec747c3fe0eb3553cf9807ec67b3f", entity.ErrProjectDigestMismatch)),
+			arrangeFunc: func(t *testing.T, storage *LocalStorage) {
+				storage.fs.MkdirAll(workingDir, os.ModePerm)
+			},
+		},
 		{
 			desc:       "Testing error fetching a project from local storage when project is not provided",
 			storage:    NewLocalStorage(fs, souceCodeStorageLocation, logger.NewFakeLogger()),
@@ -164,7 +201,7 @@ func TestFetch(t *testing.T) {
 				test.arrangeFunc(t, test.storage)
 			}
 
-			err := test.storage.Fetch(test.project, test.workingDir)
+			err := test.storage.Fetch(context.Background(), test.project, test.workingDir)
 			if err != nil && test.err != nil {
 				assert.Equal(t, test.err.Error(), err.Error())
 			} else {
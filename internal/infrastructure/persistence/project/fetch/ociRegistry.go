@@ -0,0 +1,194 @@
+package fetch
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	"github.com/apenella/ransidble/internal/domain/ports/repository"
+	"github.com/apenella/ransidble/internal/infrastructure/oci"
+	"github.com/spf13/afero"
+)
+
+// OCIRegistry represents a repository to fetch the projects stored as artifacts in an OCI distribution registry
+type OCIRegistry struct {
+	// client is the registry client
+	client repository.OCIRegistryClient
+	// fs is the filesystem where the working directory is located
+	fs afero.Fs
+	// logger is the logger
+	logger repository.Logger
+}
+
+// Ensure OCIRegistry implements the SourceCodeFetcher interface
+var _ repository.SourceCodeFetcher = (*OCIRegistry)(nil)
+
+// NewOCIRegistry creates a new OCI registry project repository
+func NewOCIRegistry(fs afero.Fs, client repository.OCIRegistryClient, logger repository.Logger) *OCIRegistry {
+	return &OCIRegistry{
+		client: client,
+		fs:     fs,
+		logger: logger,
+	}
+}
+
+// Fetch method downloads the artifact the project is pinned to into the working directory, as an OCI image-layout tarball named after the project reference, so it can be unpacked afterwards. The manifest and the blobs are verified against their digests while they are downloaded, and the download is interrupted when the context is done
+func (r *OCIRegistry) Fetch(ctx context.Context, project *entity.Project, workingDir string) (err error) {
+
+	if project == nil {
+		r.logger.Error(
+			ErrProjectNotProvided.Error(),
+			map[string]interface{}{
+				"component": "OCIRegistry.Fetch",
+				"package":   "github.com/apenella/ransidble/internal/infrastructure/persistence/project/fetch",
+			})
+		return ErrProjectNotProvided
+	}
+
+	if workingDir == "" {
+		r.logger.Error(
+			ErrWorkingDirNotProvided.Error(),
+			map[string]interface{}{
+				"component":  "OCIRegistry.Fetch",
+				"package":    "github.com/apenella/ransidble/internal/infrastructure/persistence/project/fetch",
+				"project_id": project.Name,
+			})
+		return ErrWorkingDirNotProvided
+	}
+
+	if project.Reference == "" {
+		r.logger.Error(
+			ErrProjectReferenceNotProvided.Error(),
+			map[string]interface{}{
+				"component":   "OCIRegistry.Fetch",
+				"package":     "github.com/apenella/ransidble/internal/infrastructure/persistence/project/fetch",
+				"project_id":  project.Name,
+				"working_dir": workingDir,
+			})
+		return ErrProjectReferenceNotProvided
+	}
+
+	if project.OCI == nil || project.OCI.Reference == "" || project.OCI.Digest == "" {
+		r.logger.Error(
+			ErrProjectOCISourceNotProvided.Error(),
+			map[string]interface{}{
+				"component":  "OCIRegistry.Fetch",
+				"package":    "github.com/apenella/ransidble/internal/infrastructure/persistence/project/fetch",
+				"project_id": project.Name,
+			})
+		return ErrProjectOCISourceNotProvided
+	}
+
+	if r.fs == nil {
+		r.logger.Error(
+			ErrFileSystemNotInitialized.Error(),
+			map[string]interface{}{
+				"component":  "OCIRegistry.Fetch",
+				"package":    "github.com/apenella/ransidble/internal/infrastructure/persistence/project/fetch",
+				"project_id": project.Name,
+			})
+		return ErrFileSystemNotInitialized
+	}
+
+	if r.client == nil {
+		r.logger.Error(
+			ErrOCIRegistryClientNotInitialized.Error(),
+			map[string]interface{}{
+				"component":  "OCIRegistry.Fetch",
+				"package":    "github.com/apenella/ransidble/internal/infrastructure/persistence/project/fetch",
+				"project_id": project.Name,
+			})
+		return ErrOCIRegistryClientNotInitialized
+	}
+
+	workingDirExist, err := afero.DirExists(r.fs, workingDir)
+	if !workingDirExist || err != nil {
+		r.logger.Error(
+			ErrWorkingDirNotExists.Error(),
+			map[string]interface{}{
+				"component":   "OCIRegistry.Fetch",
+				"package":     "github.com/apenella/ransidble/internal/infrastructure/persistence/project/fetch",
+				"project_id":  project.Name,
+				"working_dir": workingDir,
+			})
+		return ErrWorkingDirNotExists
+	}
+
+	r.logger.Debug("fetching project", map[string]interface{}{
+		"component":   "OCIRegistry.Fetch",
+		"digest":      project.OCI.Digest,
+		"package":     "github.com/apenella/ransidble/internal/infrastructure/persistence/project/fetch",
+		"project_id":  project.Name,
+		"reference":   project.OCI.Reference,
+		"working_dir": workingDir,
+	})
+
+	manifestContent, err := r.client.Manifest(ctx, project.OCI.Reference, project.OCI.Digest)
+	if err != nil {
+		r.logger.Error(
+			fmt.Sprintf("%s: %s", ErrFetchingProjectFromOCIRegistry, err),
+			map[string]interface{}{
+				"component":  "OCIRegistry.Fetch",
+				"digest":     project.OCI.Digest,
+				"package":    "github.com/apenella/ransidble/internal/infrastructure/persistence/project/fetch",
+				"project_id": project.Name,
+				"reference":  project.OCI.Reference,
+			})
+		return fmt.Errorf("%w: %w", ErrFetchingProjectFromOCIRegistry, err)
+	}
+
+	manifest, err := oci.ParseManifest(manifestContent)
+	if err != nil {
+		r.logger.Error(
+			fmt.Sprintf("%s: %s", ErrFetchingProjectFromOCIRegistry, err),
+			map[string]interface{}{
+				"component":  "OCIRegistry.Fetch",
+				"digest":     project.OCI.Digest,
+				"package":    "github.com/apenella/ransidble/internal/infrastructure/persistence/project/fetch",
+				"project_id": project.Name,
+				"reference":  project.OCI.Reference,
+			})
+		return fmt.Errorf("%w: %w", ErrFetchingProjectFromOCIRegistry, err)
+	}
+
+	destPath := filepath.Join(workingDir, filepath.Base(project.Reference))
+	dstFile, err := r.fs.Create(destPath)
+	if err != nil {
+		r.logger.Error(
+			fmt.Sprintf("%s: %s", ErrCreatingAFileFromLocalToDirWorkingDir, err),
+			map[string]interface{}{
+				"component":        "OCIRegistry.Fetch",
+				"destination_path": destPath,
+				"package":          "github.com/apenella/ransidble/internal/infrastructure/persistence/project/fetch",
+				"project_id":       project.Name,
+			})
+		return fmt.Errorf("%w: %w", ErrCreatingAFileFromLocalToDirWorkingDir, err)
+	}
+	defer func() {
+		errClose := dstFile.Close()
+		if err == nil {
+			err = errClose
+		}
+	}()
+
+	err = oci.WriteLayout(dstFile, project.OCI.Digest, manifestContent, manifest, func(descriptor oci.Descriptor) (io.ReadCloser, error) {
+		return r.client.Blob(ctx, project.OCI.Reference, descriptor.Digest)
+	})
+	if err != nil {
+		r.logger.Error(
+			fmt.Sprintf("%s: %s", ErrFetchingProjectFromOCIRegistry, err),
+			map[string]interface{}{
+				"component":        "OCIRegistry.Fetch",
+				"destination_path": destPath,
+				"digest":           project.OCI.Digest,
+				"package":          "github.com/apenella/ransidble/internal/infrastructure/persistence/project/fetch",
+				"project_id":       project.Name,
+				"reference":        project.OCI.Reference,
+			})
+		return fmt.Errorf("%w: %w", ErrFetchingProjectFromOCIRegistry, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,141 @@
+package fetch
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	"github.com/apenella/ransidble/internal/infrastructure/logger"
+	"github.com/apenella/ransidble/internal/infrastructure/oci"
+	"github.com/apenella/ransidble/test/ociregistry"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+// newOCIProject creates a project pinned to an artifact of the OCI registry stand-in
+func newOCIProject(name string, reference string, digest string) *entity.Project {
+	project := entity.NewProject(name, "v1.0.0", name+".oci.tar", entity.ProjectFormatOCI, entity.ProjectTypeOCI)
+	project.OCI = entity.NewProjectOCISource(reference, digest)
+
+	return project
+}
+
+func TestOCIRegistryFetch(t *testing.T) {
+	artifact := ociregistry.NewArtifact(map[string]string{"site.yml": "---"})
+	corrupted := ociregistry.NewArtifact(map[string]string{"site.yml": "- hosts: all"})
+
+	server := ociregistry.NewServer("", "")
+	t.Cleanup(server.Close)
+	server.Push("project", "v1.0.0", artifact)
+	server.Push("corrupted", "v1.0.0", corrupted)
+	for digest, content := range corrupted.Blobs {
+		server.PutBlob("corrupted", digest, bytes.Repeat([]byte("x"), len(content)))
+	}
+
+	client := oci.NewClient(oci.Config{PlainHTTP: true}, server.Client())
+
+	tests := []struct {
+		desc         string
+		project      *entity.Project
+		workingDir   string
+		err          error
+		errContained error
+	}{
+		{
+			desc:       "Testing fetch a project from an OCI registry",
+			project:    newOCIProject("project-1", server.Host()+"/project:v1.0.0", artifact.Digest),
+			workingDir: "/working-dir",
+		},
+		{
+			desc:         "Testing error fetching a project pinned to a manifest that does not exist in an OCI registry",
+			project:      newOCIProject("project-2", server.Host()+"/project:v1.0.0", oci.Digest([]byte("unknown"))),
+			workingDir:   "/working-dir",
+			errContained: oci.ErrManifestNotFound,
+		},
+		{
+			desc:         "Testing error fetching a project whose blobs do not match their digests",
+			project:      newOCIProject("project-3", server.Host()+"/corrupted:v1.0.0", corrupted.Digest),
+			workingDir:   "/working-dir",
+			errContained: oci.ErrDigestMismatch,
+		},
+		{
+			desc:       "Testing error fetching a project without OCI artifact",
+			project:    entity.NewProject("project-4", "v1.0.0", "project-4.oci.tar", entity.ProjectFormatOCI, entity.ProjectTypeOCI),
+			workingDir: "/working-dir",
+			err:        ErrProjectOCISourceNotProvided,
+		},
+		{
+			desc:       "Testing error fetching a project when the working directory does not exist",
+			project:    newOCIProject("project-1", server.Host()+"/project:v1.0.0", artifact.Digest),
+			workingDir: "/unknown",
+			err:        ErrWorkingDirNotExists,
+		},
+		{
+			desc:       "Testing error fetching a project when the project is not provided",
+			workingDir: "/working-dir",
+			err:        ErrProjectNotProvided,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			fs := afero.NewMemMapFs()
+			err := fs.MkdirAll("/working-dir", 0755)
+			assert.NoError(t, err)
+
+			fetcher := NewOCIRegistry(fs, client, logger.NewFakeLogger())
+			err = fetcher.Fetch(context.Background(), test.project, test.workingDir)
+			switch {
+			case test.err != nil:
+				assert.Equal(t, test.err, err)
+			case test.errContained != nil:
+				assert.ErrorIs(t, err, ErrFetchingProjectFromOCIRegistry)
+				assert.ErrorIs(t, err, test.errContained)
+			default:
+				assert.NoError(t, err)
+				file, err := fs.Open(filepath.Join(test.workingDir, test.project.Reference))
+				assert.NoError(t, err)
+				defer file.Close()
+
+				digest, err := oci.ReadLayoutManifestDigest(file)
+				assert.NoError(t, err)
+				assert.Equal(t, artifact.Digest, digest)
+			}
+		})
+	}
+}
+
+func TestOCIRegistryFetch_ClientNotInitialized(t *testing.T) {
+	t.Log("Testing error fetching a project when the OCI registry client is not initialized")
+
+	fetcher := NewOCIRegistry(afero.NewMemMapFs(), nil, logger.NewFakeLogger())
+	err := fetcher.Fetch(context.Background(), newOCIProject("project-1", "registry.example.com/project:v1.0.0", oci.Digest([]byte("manifest"))), "/working-dir")
+	assert.Equal(t, ErrOCIRegistryClientNotInitialized, err)
+}
+
+func TestOCIRegistryFetch_ContextDone(t *testing.T) {
+	t.Log("Testing error fetching a project from an OCI registry when the context is done, as it happens when the task is cancelled or times out")
+
+	artifact := ociregistry.NewArtifact(map[string]string{"site.yml": "---"})
+
+	server := ociregistry.NewServer("", "")
+	t.Cleanup(server.Close)
+	server.Push("project", "v1.0.0", artifact)
+
+	fs := afero.NewMemMapFs()
+	err := fs.MkdirAll("/working-dir", 0755)
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	project := newOCIProject("project-1", server.Host()+"/project:v1.0.0", artifact.Digest)
+	err = NewOCIRegistry(fs, oci.NewClient(oci.Config{PlainHTTP: true}, server.Client()), logger.NewFakeLogger()).Fetch(ctx, project, "/working-dir")
+	assert.ErrorIs(t, err, ErrFetchingProjectFromOCIRegistry)
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,178 @@
+package fetch
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	"github.com/apenella/ransidble/internal/domain/ports/repository"
+	"github.com/spf13/afero"
+)
+
+// S3Storage represents a repository to fetch the projects stored in an S3-compatible object storage
+type S3Storage struct {
+	// client is the object storage client
+	client repository.ObjectStorer
+	// fs is the filesystem where the working directory is located
+	fs afero.Fs
+	// logger is the logger
+	logger repository.Logger
+}
+
+// Ensure S3Storage implements the SourceCodeFetcher interface
+var _ repository.SourceCodeFetcher = (*S3Storage)(nil)
+
+// NewS3Storage creates a new S3 project repository
+func NewS3Storage(fs afero.Fs, client repository.ObjectStorer, logger repository.Logger) *S3Storage {
+	return &S3Storage{
+		client: client,
+		fs:     fs,
+		logger: logger,
+	}
+}
+
+// Fetch method downloads the project object into the working directory, keeping the project reference as file name so it can be unpacked afterwards. The downloaded object is verified against the digest of the project. The download is interrupted when the context is done
+func (s *S3Storage) Fetch(ctx context.Context, project *entity.Project, workingDir string) (err error) {
+
+	if project == nil {
+		s.logger.Error(
+			ErrProjectNotProvided.Error(),
+			map[string]interface{}{
+				"component": "S3Storage.Fetch",
+				"package":   "github.com/apenella/ransidble/internal/infrastructure/persistence/project/fetch",
+			})
+		return ErrProjectNotProvided
+	}
+
+	if workingDir == "" {
+		s.logger.Error(
+			ErrWorkingDirNotProvided.Error(),
+			map[string]interface{}{
+				"component":  "S3Storage.Fetch",
+				"package":    "github.com/apenella/ransidble/internal/infrastructure/persistence/project/fetch",
+				"project_id": project.Name,
+			})
+		return ErrWorkingDirNotProvided
+	}
+
+	if project.Reference == "" {
+		s.logger.Error(
+			ErrProjectReferenceNotProvided.Error(),
+			map[string]interface{}{
+				"component":   "S3Storage.Fetch",
+				"package":     "github.com/apenella/ransidble/internal/infrastructure/persistence/project/fetch",
+				"project_id":  project.Name,
+				"working_dir": workingDir,
+			})
+		return ErrProjectReferenceNotProvided
+	}
+
+	if s.fs == nil {
+		s.logger.Error(
+			ErrFileSystemNotInitialized.Error(),
+			map[string]interface{}{
+				"component":  "S3Storage.Fetch",
+				"package":    "github.com/apenella/ransidble/internal/infrastructure/persistence/project/fetch",
+				"project_id": project.Name,
+			})
+		return ErrFileSystemNotInitialized
+	}
+
+	if s.client == nil {
+		s.logger.Error(
+			ErrObjectStorageClientNotInitialized.Error(),
+			map[string]interface{}{
+				"component":  "S3Storage.Fetch",
+				"package":    "github.com/apenella/ransidble/internal/infrastructure/persistence/project/fetch",
+				"project_id": project.Name,
+			})
+		return ErrObjectStorageClientNotInitialized
+	}
+
+	workingDirExist, err := afero.DirExists(s.fs, workingDir)
+	if !workingDirExist || err != nil {
+		s.logger.Error(
+			ErrWorkingDirNotExists.Error(),
+			map[string]interface{}{
+				"component":   "S3Storage.Fetch",
+				"package":     "github.com/apenella/ransidble/internal/infrastructure/persistence/project/fetch",
+				"project_id":  project.Name,
+				"working_dir": workingDir,
+			})
+		return ErrWorkingDirNotExists
+	}
+
+	s.logger.Debug("fetching project", map[string]interface{}{
+		"component":   "S3Storage.Fetch",
+		"package":     "github.com/apenella/ransidble/internal/infrastructure/persistence/project/fetch",
+		"project_id":  project.Name,
+		"reference":   project.Reference,
+		"working_dir": workingDir,
+	})
+
+	object, err := s.client.GetObject(ctx, project.Reference)
+	if err != nil {
+		s.logger.Error(
+			fmt.Sprintf("%s: %s", ErrFetchingProjectFromS3Storage, err),
+			map[string]interface{}{
+				"component":  "S3Storage.Fetch",
+				"package":    "github.com/apenella/ransidble/internal/infrastructure/persistence/project/fetch",
+				"project_id": project.Name,
+				"reference":  project.Reference,
+			})
+		return fmt.Errorf("%w: %w", ErrFetchingProjectFromS3Storage, err)
+	}
+	defer object.Close()
+
+	destPath := filepath.Join(workingDir, filepath.Base(project.Reference))
+	dstFile, err := s.fs.Create(destPath)
+	if err != nil {
+		s.logger.Error(
+			fmt.Sprintf("%s: %s", ErrCreatingAFileFromLocalToDirWorkingDir, err),
+			map[string]interface{}{
+				"component":        "S3Storage.Fetch",
+				"destination_path": destPath,
+				"package":          "github.com/apenella/ransidble/internal/infrastructure/persistence/project/fetch",
+				"project_id":       project.Name,
+			})
+		return fmt.Errorf("%w: %w", ErrCreatingAFileFromLocalToDirWorkingDir, err)
+	}
+	defer func() {
+		errClose := dstFile.Close()
+		if err == nil {
+			err = errClose
+		}
+	}()
+
+	digester := entity.NewProjectDigester(object)
+	_, err = io.Copy(dstFile, digester)
+	if err != nil {
+		s.logger.Error(
+			fmt.Sprintf("%s: %s", ErrFetchingProjectFromS3Storage, err),
+			map[string]interface{}{
+				"component":        "S3Storage.Fetch",
+				"destination_path": destPath,
+				"package":          "github.com/apenella/ransidble/internal/infrastructure/persistence/project/fetch",
+				"project_id":       project.Name,
+				"reference":        project.Reference,
+			})
+		return fmt.Errorf("%w: %w", ErrFetchingProjectFromS3Storage, err)
+	}
+
+	err = project.VerifyDigest(digester.Digest())
+	if err != nil {
+		s.logger.Error(
+			fmt.Sprintf("%s: %s", ErrVerifyingProjectDigest, err),
+			map[string]interface{}{
+				"component":  "S3Storage.Fetch",
+				"package":    "github.com/apenella/ransidble/internal/infrastructure/persistence/project/fetch",
+				"project_id": project.Name,
+				"reference":  project.Reference,
+			})
+		return fmt.Errorf("%w: %w", ErrVerifyingProjectDigest, err)
+	}
+
+	return nil
+}
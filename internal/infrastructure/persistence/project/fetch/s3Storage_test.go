@@ -0,0 +1,177 @@
+package fetch
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	"github.com/apenella/ransidble/internal/infrastructure/logger"
+	"github.com/apenella/ransidble/internal/infrastructure/s3"
+	"github.com/apenella/ransidble/test/s3server"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestS3StorageFetch(t *testing.T) {
+	server := s3server.NewServer("ransidble", "access", s3.DefaultRegion)
+	t.Cleanup(server.Close)
+	server.PutObject("projects/project-1.tar.gz", []byte("content"))
+
+	client, err := s3.NewClient(s3.Config{
+		AccessKeyID:     "access",
+		Bucket:          "ransidble",
+		Endpoint:        server.URL,
+		PathStyle:       true,
+		Prefix:          "projects",
+		SecretAccessKey: "secret",
+	}, server.Client())
+	assert.NoError(t, err)
+
+	tests := []struct {
+		desc         string
+		client       *s3.Client
+		project      *entity.Project
+		workingDir   string
+		err          error
+		errContained error
+	}{
+		{
+			desc:       "Testing fetch a project from an S3 storage",
+			client:     client,
+			project:    entity.NewProject("project-1", "v1.0.0", "project-1.tar.gz", entity.ProjectFormatTarGz, entity.ProjectTypeS3),
+			workingDir: "/working-dir",
+		},
+		{
+			desc:       "Testing fetch a project from an S3 storage verifying its digest",
+			client:     client,
+			project:    &entity.Project{Digest: "sha256:ed7002b439e9ac845f22357d822bac1444730fbdb6016d3ec9432297b9ec9f73", Name: "project-1", Reference: "project-1.tar.gz", Format: entity.ProjectFormatTarGz, Storage: entity.ProjectTypeS3},
+			workingDir: "/working-dir",
+		},
+		{
+			desc:         "Testing error fetching a project that does not exist in an S3 storage",
+			client:       client,
+			project:      entity.NewProject("project-2", "v1.0.0", "project-2.tar.gz", entity.ProjectFormatTarGz, entity.ProjectTypeS3),
+			workingDir:   "/working-dir",
+			errContained: s3.ErrObjectNotFound,
+		},
+		{
+			desc:       "Testing error fetching a project when the working directory does not exist",
+			client:     client,
+			project:    entity.NewProject("project-1", "v1.0.0", "project-1.tar.gz", entity.ProjectFormatTarGz, entity.ProjectTypeS3),
+			workingDir: "/unknown",
+			err:        ErrWorkingDirNotExists,
+		},
+		{
+			desc:       "Testing error fetching a project without reference",
+			client:     client,
+			project:    &entity.Project{Name: "project-1"},
+			workingDir: "/working-dir",
+			err:        ErrProjectReferenceNotProvided,
+		},
+		{
+			desc:       "Testing error fetching a project when the working directory is not provided",
+			client:     client,
+			project:    entity.NewProject("project-1", "v1.0.0", "project-1.tar.gz", entity.ProjectFormatTarGz, entity.ProjectTypeS3),
+			workingDir: "",
+			err:        ErrWorkingDirNotProvided,
+		},
+		{
+			desc:       "Testing error fetching a project when the project is not provided",
+			client:     client,
+			workingDir: "/working-dir",
+			err:        ErrProjectNotProvided,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			fs := afero.NewMemMapFs()
+			err := fs.MkdirAll("/working-dir", 0755)
+			assert.NoError(t, err)
+
+			storage := NewS3Storage(fs, test.client, logger.NewFakeLogger())
+			err = storage.Fetch(context.Background(), test.project, test.workingDir)
+			switch {
+			case test.err != nil:
+				assert.Equal(t, test.err, err)
+			case test.errContained != nil:
+				assert.ErrorIs(t, err, ErrFetchingProjectFromS3Storage)
+				assert.ErrorIs(t, err, test.errContained)
+			default:
+				assert.NoError(t, err)
+				data, err := afero.ReadFile(fs, filepath.Join(test.workingDir, test.project.Reference))
+				assert.NoError(t, err)
+				assert.Equal(t, "content", string(data))
+			}
+		})
+	}
+}
+
+func TestS3StorageFetch_DigestMismatch(t *testing.T) {
+	t.Log("Testing error fetching a project from an S3 storage when its digest does not match")
+
+	server := s3server.NewServer("ransidble", "access", s3.DefaultRegion)
+	t.Cleanup(server.Close)
+	server.PutObject("projects/project-1.tar.gz", []byte("tampered content"))
+
+	client, err := s3.NewClient(s3.Config{
+		AccessKeyID:     "access",
+		Bucket:          "ransidble",
+		Endpoint:        server.URL,
+		PathStyle:       true,
+		Prefix:          "projects",
+		SecretAccessKey: "secret",
+	}, server.Client())
+	assert.NoError(t, err)
+
+	fs := afero.NewMemMapFs()
+	err = fs.MkdirAll("/working-dir", 0755)
+	assert.NoError(t, err)
+
+	project := &entity.Project{Digest: "sha256:ed7002b439e9ac845f22357d822bac1444730fbdb6016d3ec9432297b9ec9f73", Name: "project-1", Reference: "project-1.tar.gz", Format: entity.ProjectFormatTarGz, Storage: entity.ProjectTypeS3}
+	err = NewS3Storage(fs, client, logger.NewFakeLogger()).Fetch(context.Background(), project, "/working-dir")
+	assert.ErrorIs(t, err, ErrVerifyingProjectDigest)
+	assert.ErrorIs(t, err, entity.ErrProjectDigestMismatch)
+}
+
+func TestS3StorageFetch_ClientNotInitialized(t *testing.T) {
+	t.Log("Testing error fetching a project when the object storage client is not initialized")
+
+	storage := NewS3Storage(afero.NewMemMapFs(), nil, logger.NewFakeLogger())
+	err := storage.Fetch(context.Background(), entity.NewProject("project-1", "v1.0.0", "project-1.tar.gz", entity.ProjectFormatTarGz, entity.ProjectTypeS3), "/working-dir")
+	assert.Equal(t, ErrObjectStorageClientNotInitialized, err)
+}
+
+func TestS3StorageFetch_ContextDone(t *testing.T) {
+	t.Log("Testing error fetching a project from an S3 storage when the context is done, as it happens when the task is cancelled or times out")
+
+	server := s3server.NewServer("ransidble", "access", s3.DefaultRegion)
+	t.Cleanup(server.Close)
+	server.PutObject("projects/project-1.tar.gz", []byte("content"))
+
+	client, err := s3.NewClient(s3.Config{
+		AccessKeyID:     "access",
+		Bucket:          "ransidble",
+		Endpoint:        server.URL,
+		PathStyle:       true,
+		Prefix:          "projects",
+		SecretAccessKey: "secret",
+	}, server.Client())
+	assert.NoError(t, err)
+
+	fs := afero.NewMemMapFs()
+	err = fs.MkdirAll("/working-dir", 0755)
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	project := entity.NewProject("project-1", "v1.0.0", "project-1.tar.gz", entity.ProjectFormatTarGz, entity.ProjectTypeS3)
+	err = NewS3Storage(fs, client, logger.NewFakeLogger()).Fetch(ctx, project, "/working-dir")
+	assert.ErrorIs(t, err, ErrFetchingProjectFromS3Storage)
+	assert.ErrorIs(t, err, context.Canceled)
+}
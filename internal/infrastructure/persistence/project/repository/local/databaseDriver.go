@@ -6,6 +6,9 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"sort"
+	"sync"
+	"time"
 
 	"github.com/apenella/ransidble/internal/domain/core/entity"
 	"github.com/apenella/ransidble/internal/domain/ports/repository"
@@ -43,16 +46,38 @@ const (
 	ErrOpeningFileToWriteRecord = "error opening file to write record"
 	// ErrProjectExists is the error message when the project already exists.
 	ErrProjectExists = "error project already exists"
+	// ErrProjectAlreadyTrashed is the error message when a project with the same ID is already in the trash.
+	ErrProjectAlreadyTrashed = "a project with the same ID is already in the trash"
+	// ErrProjectVersionExists is the error message when the project version already exists.
+	ErrProjectVersionExists = "error project version already exists"
+	// ErrProjectVersionNotFound is the error message when the project version is not found.
+	ErrProjectVersionNotFound = "project version not found"
+	// ErrRemovingLastProjectVersion is the error message when removing the only version of a project.
+	ErrRemovingLastProjectVersion = "the only version of a project cannot be removed"
+	// ErrPurgingProject is the error message when purging a project from the trash fails.
+	ErrPurgingProject = "error purging project from the trash"
 	// ErrReadingRecord is the error message when reading the record fails.
 	ErrReadingRecord = "error reading record"
 	// ErrReadingRecordNotFound is the error message when the record is not found.
 	ErrReadingRecordNotFound = "record not found"
 	// ErrReadingRecordsFromDatabase is the error message when reading the records from the database fails.
 	ErrReadingRecordsFromDatabase = "error reading records from database"
+	// ErrReplacingProject is the error message when replacing a project fails.
+	ErrReplacingProject = "error replacing project"
+	// ErrReplacingOutdatedProjectVersion is the error message when replacing a project version other than the most recent one.
+	ErrReplacingOutdatedProjectVersion = "only the most recent version of a project can be replaced"
+	// ErrRestoringProject is the error message when restoring a project from the trash fails.
+	ErrRestoringProject = "error restoring project from the trash"
 	// ErrRemovingRecord is the error message when removing the record fails.
 	ErrRemovingRecord = "error removing record"
+	// ErrRemovingProjectVersion is the error message when removing a project version fails.
+	ErrRemovingProjectVersion = "error removing project version"
 	// ErrStoringProject is the error message when storing the project fails.
 	ErrStoringProject = "error storing project"
+	// ErrStoringProjectVersion is the error message when storing a project version fails.
+	ErrStoringProjectVersion = "error storing project version"
+	// ErrTrashingProject is the error message when moving a project to the trash fails.
+	ErrTrashingProject = "error moving project to the trash"
 	// ErrVerifyingRecord is the error message when verifying the record fails.
 	ErrVerifyingRecord = "error verifying record"
 	// ErrVerifyingRecordInvalidHash is the error message when the record hash is invalid.
@@ -61,12 +86,23 @@ const (
 	ErrWritingRecord = "error writing record"
 )
 
+// versionsDir is the directory, relative to the database path, where the records of the project versions are stored. Each project has its own directory holding a record per version, while the project record always holds the most recent version
+const versionsDir = ".versions"
+
+// tmpDir is the directory, relative to the database path, where the records are written before they are moved to their location. Moving a complete record file ensures that readers never get a partially written record
+const tmpDir = ".tmp"
+
+// trashDir is the directory, relative to the database path, where the records of the deleted projects are kept until they are restored or purged. Each deleted project has its own directory, which keeps the layout of the database for the project record and its version records
+const trashDir = ".trash"
+
 // DatabaseDriver is a struct that represents a local database to persist the projects references.
 type DatabaseDriver struct {
 	// fs path where projects are stored
 	fs afero.Fs
 	// path is the path to the directory where the projects references are stored
 	path string
+	// mutex serializes the operations that check the stored records before writing them
+	mutex sync.Mutex
 
 	logger repository.Logger
 }
@@ -93,13 +129,30 @@ func (db *DatabaseDriver) FindAll() ([]*entity.Project, error) {
 	return db.readAll()
 }
 
+// Search reads the projects from the local database that fulfill the query.
+func (db *DatabaseDriver) Search(query *entity.ProjectQuery) (*entity.ProjectPage, error) {
+
+	projects, err := db.readAll()
+	if err != nil {
+		return nil, err
+	}
+
+	if query == nil {
+		query = entity.NewProjectQuery()
+	}
+
+	return query.Apply(projects)
+}
+
 // Store stores a project in the local database.
 func (db *DatabaseDriver) Store(id string, data *entity.Project) error {
 	return db.write(id, data)
 }
 
-// SafeStore stores a project in the local database.
+// SafeStore stores a project in the local database. The project is also stored as its first version
 func (db *DatabaseDriver) SafeStore(id string, data *entity.Project) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
 
 	exists, err := db.exists(id)
 
@@ -111,12 +164,380 @@ func (db *DatabaseDriver) SafeStore(id string, data *entity.Project) error {
 		return fmt.Errorf("%s: %s %s", ErrStoringProject, id, ErrProjectExists)
 	}
 
-	return db.write(id, data)
+	stampCreatedAt(data)
+
+	err = db.write(id, data)
+	if err != nil {
+		return err
+	}
+
+	if data.Version == "" {
+		return nil
+	}
+
+	versionID, err := versionRecordID(id, data.Version)
+	if err != nil {
+		return fmt.Errorf("%s: %s", ErrStoringProject, err.Error())
+	}
+
+	return db.write(versionID, data)
 }
 
-// Delete deletes a project from the local database.
+// Delete deletes a project and all its versions from the local database.
 func (db *DatabaseDriver) Delete(id string) error {
-	return db.remove(id)
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	err := db.remove(id)
+	if err != nil {
+		return err
+	}
+
+	err = db.fs.RemoveAll(filepath.Join(db.path, versionsDir, id))
+	if err != nil {
+		db.logger.Error(
+			fmt.Sprintf("%s: %s", ErrRemovingRecord, err.Error()),
+			map[string]interface{}{
+				"component": "DatabaseDriver.Delete",
+				"package":   packageName,
+				"record_id": id,
+			},
+		)
+		return fmt.Errorf("%s: %w", ErrRemovingRecord, err)
+	}
+
+	return nil
+}
+
+// FindVersion reads a project version from the local database.
+func (db *DatabaseDriver) FindVersion(id string, version string) (*entity.Project, error) {
+
+	versions, err := db.readVersions(id)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, project := range versions {
+		if project.Version == version {
+			return project, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%s: %s: %s", ErrReadingRecord, ErrProjectVersionNotFound, version)
+}
+
+// FindVersions reads the versions of a project from the local database, sorted from the oldest to the most recent.
+func (db *DatabaseDriver) FindVersions(id string) ([]*entity.Project, error) {
+	return db.readVersions(id)
+}
+
+// SafeStoreVersion stores a new version of an existing project in the local database. The new version becomes the most recent version of the project
+func (db *DatabaseDriver) SafeStoreVersion(id string, data *entity.Project) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if data == nil {
+		return fmt.Errorf("%s: %s", ErrStoringProjectVersion, ErrDataToWriteIsNotProvided)
+	}
+
+	versionID, err := versionRecordID(id, data.Version)
+	if err != nil {
+		return fmt.Errorf("%s: %s", ErrStoringProjectVersion, err.Error())
+	}
+
+	versions, err := db.readVersions(id)
+	if err != nil {
+		return fmt.Errorf("%s: %s", ErrStoringProjectVersion, err.Error())
+	}
+
+	for _, project := range versions {
+		if project.Version == data.Version {
+			return fmt.Errorf("%s: %s %s", ErrStoringProjectVersion, versionID, ErrProjectVersionExists)
+		}
+	}
+
+	err = db.storeVersionRecords(id, versions)
+	if err != nil {
+		return fmt.Errorf("%s: %s", ErrStoringProjectVersion, err.Error())
+	}
+
+	stampCreatedAt(data)
+
+	err = db.write(versionID, data)
+	if err != nil {
+		return fmt.Errorf("%s: %s", ErrStoringProjectVersion, err.Error())
+	}
+
+	return db.write(id, data)
+}
+
+// DeleteVersion deletes a project version from the local database. When the most recent version is deleted, the previous one becomes the most recent version of the project
+func (db *DatabaseDriver) DeleteVersion(id string, version string) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	versionID, err := versionRecordID(id, version)
+	if err != nil {
+		return fmt.Errorf("%s: %s", ErrRemovingProjectVersion, err.Error())
+	}
+
+	versions, err := db.readVersions(id)
+	if err != nil {
+		return fmt.Errorf("%s: %s", ErrRemovingProjectVersion, err.Error())
+	}
+
+	remaining := make([]*entity.Project, 0, len(versions))
+	for _, project := range versions {
+		if project.Version != version {
+			remaining = append(remaining, project)
+		}
+	}
+
+	if len(remaining) == len(versions) {
+		return fmt.Errorf("%s: %s: %s", ErrRemovingProjectVersion, ErrProjectVersionNotFound, version)
+	}
+
+	if len(remaining) == 0 {
+		return fmt.Errorf("%s: %s", ErrRemovingProjectVersion, ErrRemovingLastProjectVersion)
+	}
+
+	err = db.storeVersionRecords(id, remaining)
+	if err != nil {
+		return fmt.Errorf("%s: %s", ErrRemovingProjectVersion, err.Error())
+	}
+
+	err = db.remove(versionID)
+	if err != nil {
+		return fmt.Errorf("%s: %s", ErrRemovingProjectVersion, err.Error())
+	}
+
+	return db.write(id, remaining[len(remaining)-1])
+}
+
+// SafeReplace replaces the most recent version of a project in the local database, keeping the rest of its versions. The project is only replaced when the revision of its record matches the expected revision, which is not checked when it is empty. The replaced version keeps its creation date, so it remains the most recent version of the project
+func (db *DatabaseDriver) SafeReplace(id string, data *entity.Project, revision string) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if data == nil {
+		return fmt.Errorf("%s: %s", ErrReplacingProject, ErrDataToWriteIsNotProvided)
+	}
+
+	current, err := db.read(id)
+	if err != nil {
+		return fmt.Errorf("%s: %s", ErrReplacingProject, err.Error())
+	}
+
+	if revision != "" && current.Revision != revision {
+		return fmt.Errorf("%s: %w", ErrReplacingProject, entity.ErrProjectRevisionMismatch)
+	}
+
+	if data.Version != current.Version {
+		return fmt.Errorf("%s: %s: %s", ErrReplacingProject, ErrReplacingOutdatedProjectVersion, data.Version)
+	}
+
+	data.CreatedAt = current.CreatedAt
+
+	if data.Version == "" {
+		return db.write(id, data)
+	}
+
+	versionID, err := versionRecordID(id, data.Version)
+	if err != nil {
+		return fmt.Errorf("%s: %s", ErrReplacingProject, err.Error())
+	}
+
+	versions, err := db.readVersions(id)
+	if err != nil {
+		return fmt.Errorf("%s: %s", ErrReplacingProject, err.Error())
+	}
+
+	err = db.storeVersionRecords(id, versions)
+	if err != nil {
+		return fmt.Errorf("%s: %s", ErrReplacingProject, err.Error())
+	}
+
+	err = db.write(versionID, data)
+	if err != nil {
+		return fmt.Errorf("%s: %s", ErrReplacingProject, err.Error())
+	}
+
+	return db.write(id, data)
+}
+
+// Trash moves a project and all its versions to the trash of the local database, setting the deletion time on the project record. The trash keeps a single deleted project for each ID, so a project already in the trash must be purged before another project with the same ID is moved to the trash
+func (db *DatabaseDriver) Trash(id string, deletedAt string) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	project, err := db.read(id)
+	if err != nil {
+		return fmt.Errorf("%s: %s", ErrTrashingProject, err.Error())
+	}
+
+	trash := db.trashDatabase(id)
+	exists, err := afero.Exists(db.fs, trash.path)
+	if err != nil {
+		return fmt.Errorf("%s: %s", ErrTrashingProject, err.Error())
+	}
+
+	if exists {
+		return fmt.Errorf("%s: %s %s", ErrTrashingProject, id, ErrProjectAlreadyTrashed)
+	}
+
+	// the project record is moved first, so the project is not found as soon as it is moved to the trash
+	err = db.move(filepath.Join(db.path, id), filepath.Join(trash.path, id))
+	if err != nil {
+		return fmt.Errorf("%s: %s", ErrTrashingProject, err.Error())
+	}
+
+	err = db.move(filepath.Join(db.path, versionsDir, id), filepath.Join(trash.path, versionsDir, id))
+	if err != nil {
+		return fmt.Errorf("%s: %s", ErrTrashingProject, err.Error())
+	}
+
+	project.DeletedAt = deletedAt
+
+	err = trash.write(id, project)
+	if err != nil {
+		return fmt.Errorf("%s: %s", ErrTrashingProject, err.Error())
+	}
+
+	return nil
+}
+
+// FindAllTrashed reads all the projects in the trash of the local database.
+func (db *DatabaseDriver) FindAllTrashed() ([]*entity.Project, error) {
+	var projects []*entity.Project
+
+	trashPath := filepath.Join(db.path, trashDir)
+	exists, err := afero.DirExists(db.fs, trashPath)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrReadingRecordsFromDatabase, err)
+	}
+
+	if !exists {
+		return projects, nil
+	}
+
+	files, err := afero.ReadDir(db.fs, trashPath)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrReadingRecordsFromDatabase, err)
+	}
+
+	for _, file := range files {
+		if !file.IsDir() {
+			continue
+		}
+
+		project, errRead := db.trashDatabase(file.Name()).read(file.Name())
+		if errRead != nil {
+			db.logger.Error(
+				fmt.Sprintf("%s: %s", ErrReadingRecordsFromDatabase, errRead.Error()),
+				map[string]interface{}{
+					"component": "DatabaseDriver.FindAllTrashed",
+					"package":   packageName,
+					"record_id": file.Name(),
+				},
+			)
+			continue
+		}
+
+		projects = append(projects, project)
+	}
+
+	return projects, nil
+}
+
+// FindTrashedVersions reads the versions of a project in the trash of the local database, sorted from the oldest to the most recent.
+func (db *DatabaseDriver) FindTrashedVersions(id string) ([]*entity.Project, error) {
+	if id == "" {
+		return nil, fmt.Errorf("%s", ErrIDIsNotProvided)
+	}
+
+	return db.trashDatabase(id).readVersions(id)
+}
+
+// Restore moves a project and all its versions from the trash back to the local database, clearing the deletion time of the project record. A project can not be restored while another project with the same ID exists
+func (db *DatabaseDriver) Restore(id string) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if id == "" {
+		return fmt.Errorf("%s: %s", ErrRestoringProject, ErrIDIsNotProvided)
+	}
+
+	trash := db.trashDatabase(id)
+	project, err := trash.read(id)
+	if err != nil {
+		return fmt.Errorf("%s: %s", ErrRestoringProject, err.Error())
+	}
+
+	exists, err := db.exists(id)
+	if err != nil {
+		return fmt.Errorf("%s: %s", ErrRestoringProject, err.Error())
+	}
+
+	if exists {
+		return fmt.Errorf("%s: %s %s", ErrRestoringProject, id, ErrProjectExists)
+	}
+
+	project.DeletedAt = ""
+
+	err = trash.write(id, project)
+	if err != nil {
+		return fmt.Errorf("%s: %s", ErrRestoringProject, err.Error())
+	}
+
+	err = db.move(filepath.Join(trash.path, versionsDir, id), filepath.Join(db.path, versionsDir, id))
+	if err != nil {
+		return fmt.Errorf("%s: %s", ErrRestoringProject, err.Error())
+	}
+
+	// the project record is moved last, so the project is only found once all its versions are restored
+	err = db.move(filepath.Join(trash.path, id), filepath.Join(db.path, id))
+	if err != nil {
+		return fmt.Errorf("%s: %s", ErrRestoringProject, err.Error())
+	}
+
+	err = db.fs.RemoveAll(trash.path)
+	if err != nil {
+		db.logger.Error(
+			fmt.Sprintf("%s: %s", ErrRemovingRecord, err.Error()),
+			map[string]interface{}{
+				"component": "DatabaseDriver.Restore",
+				"package":   packageName,
+				"record_id": id,
+			},
+		)
+	}
+
+	return nil
+}
+
+// Purge removes a project and all its versions from the trash of the local database.
+func (db *DatabaseDriver) Purge(id string) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if id == "" {
+		return fmt.Errorf("%s: %s", ErrPurgingProject, ErrIDIsNotProvided)
+	}
+
+	err := db.fs.RemoveAll(db.trashDatabase(id).path)
+	if err != nil {
+		db.logger.Error(
+			fmt.Sprintf("%s: %s", ErrPurgingProject, err.Error()),
+			map[string]interface{}{
+				"component": "DatabaseDriver.Purge",
+				"package":   packageName,
+				"record_id": id,
+			},
+		)
+		return fmt.Errorf("%s: %w", ErrPurgingProject, err)
+	}
+
+	return nil
 }
 
 // Initialize initializes the local database.
@@ -193,6 +614,19 @@ func (db *DatabaseDriver) Initialize() error {
 		return fmt.Errorf("%s: %w", ErrInitializingDatabase, fmt.Errorf("%s", ErrDatabasePathIsNotADirectory))
 	}
 
+	err = db.migrateVersions()
+	if err != nil {
+		db.logger.Error(
+			fmt.Sprintf("%s: %s", ErrInitializingDatabase, err.Error()),
+			map[string]interface{}{
+				"component": "DatabaseDriver.initialize",
+				"package":   packageName,
+				"path":      db.path,
+			},
+		)
+		return fmt.Errorf("%s: %w", ErrInitializingDatabase, err)
+	}
+
 	return nil
 }
 
@@ -381,6 +815,7 @@ func (db *DatabaseDriver) read(id string) (*entity.Project, error) {
 
 		return nil, fmt.Errorf("%s", msgErr)
 	}
+	project.Revision = record.Hash
 
 	return project, nil
 }
@@ -430,6 +865,21 @@ func (db *DatabaseDriver) readAll() ([]*entity.Project, error) {
 			return fmt.Errorf("%s: %w", ErrReadingRecordsFromDatabase, err)
 		}
 
+		// the versions records are not projects, they are read through the project versions
+		if info.IsDir() && info.Name() == versionsDir {
+			return filepath.SkipDir
+		}
+
+		// the records being written are not complete yet
+		if info.IsDir() && info.Name() == tmpDir {
+			return filepath.SkipDir
+		}
+
+		// the deleted projects are read through the trash
+		if info.IsDir() && info.Name() == trashDir {
+			return filepath.SkipDir
+		}
+
 		if info.IsDir() {
 			db.logger.Debug(
 				fmt.Sprintf("%s: %s", ErrInvalidRecordFormat, ErrInvalidRecordFormatIsDir),
@@ -575,12 +1025,25 @@ func (db *DatabaseDriver) write(id string, data *entity.Project) error {
 		)
 	}
 
-	recordFile, err = db.fs.OpenFile(
-		filepath.Join(db.path, id),
-		os.O_RDWR|os.O_CREATE|os.O_TRUNC,
-		0644,
-	)
+	// the records of the project versions are stored in nested directories that may not exist yet
+	err = db.fs.MkdirAll(filepath.Dir(filepath.Join(db.path, id)), 0755)
+	if err == nil {
+		err = db.fs.MkdirAll(filepath.Join(db.path, tmpDir), 0755)
+	}
+	if err != nil {
+		db.logger.Error(
+			fmt.Sprintf("%s: %s", ErrOpeningFileToWriteRecord, err.Error()),
+			map[string]interface{}{
+				"component": "DatabaseDriver.Write",
+				"package":   packageName,
+				"record_id": id,
+				"project":   data,
+			},
+		)
+		return fmt.Errorf("%s: %w", ErrOpeningFileToWriteRecord, err)
+	}
 
+	recordFile, err = afero.TempFile(db.fs, filepath.Join(db.path, tmpDir), "record-*")
 	if err != nil {
 		db.logger.Error(
 			fmt.Sprintf("%s: %s", ErrOpeningFileToWriteRecord, err.Error()),
@@ -593,6 +1056,7 @@ func (db *DatabaseDriver) write(id string, data *entity.Project) error {
 		)
 		return fmt.Errorf("%s: %w", ErrOpeningFileToWriteRecord, err)
 	}
+	defer db.fs.Remove(recordFile.Name())
 	defer recordFile.Close()
 
 	recordContent, err := json.Marshal(record)
@@ -609,6 +1073,13 @@ func (db *DatabaseDriver) write(id string, data *entity.Project) error {
 		return fmt.Errorf("%s: %w", ErrMarshalingRecordToWrite, err)
 	}
 	_, err = recordFile.Write(recordContent)
+	if err == nil {
+		err = recordFile.Close()
+	}
+	if err == nil {
+		// the complete record replaces the stored one at once, so the readers get either the previous record or the new one
+		err = db.fs.Rename(recordFile.Name(), filepath.Join(db.path, id))
+	}
 	if err != nil {
 		db.logger.Error(
 			fmt.Sprintf("%s: %s", ErrWritingRecord, err.Error()),
@@ -621,6 +1092,7 @@ func (db *DatabaseDriver) write(id string, data *entity.Project) error {
 		)
 		return fmt.Errorf("%s: %w", ErrWritingRecord, err)
 	}
+	data.Revision = record.Hash
 
 	return nil
 }
@@ -793,3 +1265,274 @@ func (db *DatabaseDriver) exists(id string) (bool, error) {
 
 	return true, nil
 }
+
+// readVersions reads the versions of a project from the local database, sorted by creation date. Projects stored before versions were supported only have the project record, which is returned as their only version
+func (db *DatabaseDriver) readVersions(id string) ([]*entity.Project, error) {
+	var versions []*entity.Project
+
+	project, err := db.read(id)
+	if err != nil {
+		return nil, err
+	}
+
+	versionsPath := filepath.Join(db.path, versionsDir, id)
+	exists, err := afero.DirExists(db.fs, versionsPath)
+	if err != nil {
+		db.logger.Error(
+			fmt.Sprintf("%s: %s", ErrReadingRecordsFromDatabase, err.Error()),
+			map[string]interface{}{
+				"component": "DatabaseDriver.readVersions",
+				"package":   packageName,
+				"record_id": id,
+			},
+		)
+		return nil, fmt.Errorf("%s: %w", ErrReadingRecordsFromDatabase, err)
+	}
+
+	if !exists {
+		return []*entity.Project{project}, nil
+	}
+
+	files, err := afero.ReadDir(db.fs, versionsPath)
+	if err != nil {
+		db.logger.Error(
+			fmt.Sprintf("%s: %s", ErrReadingRecordsFromDatabase, err.Error()),
+			map[string]interface{}{
+				"component": "DatabaseDriver.readVersions",
+				"package":   packageName,
+				"record_id": id,
+			},
+		)
+		return nil, fmt.Errorf("%s: %w", ErrReadingRecordsFromDatabase, err)
+	}
+
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+
+		version, errRead := db.read(filepath.Join(versionsDir, id, file.Name()))
+		if errRead != nil {
+			return nil, errRead
+		}
+
+		versions = append(versions, version)
+	}
+
+	if len(versions) == 0 {
+		return []*entity.Project{project}, nil
+	}
+
+	sort.SliceStable(versions, func(i, j int) bool {
+		return createdAt(versions[i]).Before(createdAt(versions[j]))
+	})
+
+	return versions, nil
+}
+
+// storeVersionRecords writes the records of the versions that are not stored yet, which is the case of the projects stored before versions were supported
+func (db *DatabaseDriver) storeVersionRecords(id string, versions []*entity.Project) error {
+	for _, project := range versions {
+		versionID, err := versionRecordID(id, project.Version)
+		if err != nil {
+			return err
+		}
+
+		exists, err := db.exists(versionID)
+		if err != nil {
+			return err
+		}
+
+		if exists {
+			continue
+		}
+
+		err = db.write(versionID, project)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// migrateVersions renames the project versions stored with the alias of the most recent version, which was the version given to the projects created without a version. The projects in the trash are migrated as well, so they keep a concrete version once they are restored
+func (db *DatabaseDriver) migrateVersions() error {
+	files, err := afero.ReadDir(db.fs, db.path)
+	if err != nil {
+		return fmt.Errorf("%s: %w", ErrReadingRecordsFromDatabase, err)
+	}
+
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+
+		err = db.migrateProjectVersions(file.Name())
+		if err != nil {
+			return err
+		}
+	}
+
+	trashPath := filepath.Join(db.path, trashDir)
+	exists, err := afero.DirExists(db.fs, trashPath)
+	if err != nil {
+		return fmt.Errorf("%s: %w", ErrReadingRecordsFromDatabase, err)
+	}
+
+	if !exists {
+		return nil
+	}
+
+	files, err = afero.ReadDir(db.fs, trashPath)
+	if err != nil {
+		return fmt.Errorf("%s: %w", ErrReadingRecordsFromDatabase, err)
+	}
+
+	for _, file := range files {
+		if !file.IsDir() {
+			continue
+		}
+
+		err = db.trashDatabase(file.Name()).migrateProjectVersions(file.Name())
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// migrateProjectVersions renames the version of a project stored with the alias of the most recent version to the fallback version. When the fallback version is already used by the project, a numeric suffix is added to keep the version unique
+func (db *DatabaseDriver) migrateProjectVersions(id string) error {
+	var migrated *entity.Project
+
+	project, err := db.read(id)
+	if err != nil {
+		return err
+	}
+
+	versions, err := db.readVersions(id)
+	if err != nil {
+		return err
+	}
+
+	used := map[string]struct{}{}
+	for _, version := range versions {
+		used[version.Version] = struct{}{}
+		if version.Version == entity.LatestVersion {
+			migrated = version
+		}
+	}
+
+	if migrated == nil {
+		return nil
+	}
+
+	version := entity.FallbackVersion
+	for suffix := 2; ; suffix++ {
+		if _, exists := used[version]; !exists {
+			break
+		}
+		version = fmt.Sprintf("%s-%d", entity.FallbackVersion, suffix)
+	}
+
+	db.logger.Info(
+		fmt.Sprintf("Migrating project version %s to %s", entity.LatestVersion, version),
+		map[string]interface{}{
+			"component": "DatabaseDriver.migrateProjectVersions",
+			"package":   packageName,
+			"record_id": id,
+		},
+	)
+
+	// the record of the version is not validated as a version record because the alias is not a valid version
+	legacyID := filepath.Join(versionsDir, id, entity.LatestVersion)
+	legacyExists, err := db.exists(legacyID)
+	if err != nil {
+		return err
+	}
+
+	if legacyExists {
+		versionID, err := versionRecordID(id, version)
+		if err != nil {
+			return err
+		}
+
+		migrated.Version = version
+		err = db.write(versionID, migrated)
+		if err != nil {
+			return err
+		}
+
+		err = db.remove(legacyID)
+		if err != nil {
+			return err
+		}
+	}
+
+	if project.Version == entity.LatestVersion {
+		project.Version = version
+		err = db.write(id, project)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// trashDatabase returns the local database holding the records of a project in the trash
+func (db *DatabaseDriver) trashDatabase(id string) *DatabaseDriver {
+	return NewDatabaseDriver(db.fs, filepath.Join(db.path, trashDir, id), db.logger)
+}
+
+// move moves a record or a directory of records to a new location, creating its parent directory. Nothing is moved when the source does not exist, which is the case of the versions of the projects stored before versions were supported
+func (db *DatabaseDriver) move(source string, destination string) error {
+	exists, err := afero.Exists(db.fs, source)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return nil
+	}
+
+	err = db.fs.MkdirAll(filepath.Dir(destination), 0755)
+	if err != nil {
+		return err
+	}
+
+	return db.fs.Rename(source, destination)
+}
+
+// versionRecordID returns the record identifier of a project version
+func versionRecordID(id string, version string) (string, error) {
+	if id == "" {
+		return "", fmt.Errorf("%s", ErrIDIsNotProvided)
+	}
+
+	err := entity.ValidateProjectVersion(version)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(versionsDir, id, version), nil
+}
+
+// stampCreatedAt sets the creation date of a project that is stored for the first time
+func stampCreatedAt(project *entity.Project) {
+	if project != nil && project.CreatedAt == "" {
+		project.CreatedAt = time.Now().UTC().Format(time.RFC3339Nano)
+	}
+}
+
+// createdAt returns the creation date of a project. Projects without a valid creation date are considered the oldest ones
+func createdAt(project *entity.Project) time.Time {
+	created, err := time.Parse(time.RFC3339Nano, project.CreatedAt)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return created
+}
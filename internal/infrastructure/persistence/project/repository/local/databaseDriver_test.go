@@ -40,6 +40,7 @@ func TestFind(t *testing.T) {
 				Format:    "plain",
 				Name:      "project-1",
 				Reference: "test/projects/project-1",
+				Revision:  "7e92476636f83579f3fcdf886df224344c960b22311395f2e4c6c1553a7a4a12",
 				Storage:   "local",
 			},
 			err: nil,
@@ -107,6 +108,53 @@ func TestFindAll(t *testing.T) {
 	}
 }
 
+func TestSearch(t *testing.T) {
+	databasePath := filepath.Join("fixtures", "persistence-project-database-local", "read-all", "valid")
+	fs := afero.NewCopyOnWriteFs(
+		afero.NewReadOnlyFs(
+			afero.NewBasePathFs(afero.NewOsFs(), "../../../../../../test"),
+		),
+		afero.NewMemMapFs(),
+	)
+
+	tests := []struct {
+		desc     string
+		driver   *DatabaseDriver
+		query    *entity.ProjectQuery
+		expected *entity.ProjectPage
+		err      error
+	}{
+		{
+			desc:   "Testing searching the projects from the database",
+			driver: NewDatabaseDriver(fs, databasePath, logger.NewFakeLogger()),
+			query:  &entity.ProjectQuery{Limit: 1, NamePrefix: "project-"},
+			expected: &entity.ProjectPage{
+				NextCursor: "cHJvamVjdC0x",
+				Projects: []*entity.Project{
+					{
+						Format:    "plain",
+						Name:      "project-1",
+						Reference: "project-1",
+						Revision:  "5f051b2b6aa808f5fe7d4ad3a36675eeb131da54fb3d9385d3c837a50e1cde8e",
+						Storage:   "local",
+					},
+				},
+				Total: 3,
+			},
+			err: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			result, err := test.driver.Search(test.query)
+
+			assert.Equal(t, test.err, err)
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
+
 func TestStore(t *testing.T) {
 	databasePath := filepath.Join("fixtures", "persistence-project-database-local")
 	fs := afero.NewCopyOnWriteFs(
@@ -140,6 +188,7 @@ func TestStore(t *testing.T) {
 				expected := &entity.Project{
 					Name:      "project-2",
 					Reference: "project-2",
+					Revision:  "3fb29b0013bc691e941498932de943dfe86f1ff12c2f611eecdab00f0d5f595c",
 					Format:    "plain",
 					Storage:   "local",
 				}
@@ -199,9 +248,13 @@ func TestSafeStore(t *testing.T) {
 			assertFunc: func(t *testing.T, driver *DatabaseDriver) {
 				project, err := driver.read("project-2")
 				assert.Nil(t, err, "unexpected error when reading project")
+				assert.NotEmpty(t, project.CreatedAt)
+				assert.NotEmpty(t, project.Revision)
 				expected := &entity.Project{
+					CreatedAt: project.CreatedAt,
 					Name:      "project-2",
 					Reference: "project-2",
+					Revision:  project.Revision,
 					Format:    "plain",
 					Storage:   "local",
 				}
@@ -296,6 +349,490 @@ func TestDelete(t *testing.T) {
 	}
 }
 
+// newVersionedDatabaseDriver returns a driver whose database holds project-1 with the versions v1 and v2, and the legacy project-2 that has no version records
+func newVersionedDatabaseDriver(t *testing.T) *DatabaseDriver {
+	fs := afero.NewMemMapFs()
+	err := fs.MkdirAll("/db", 0755)
+	assert.NoError(t, err)
+
+	driver := NewDatabaseDriver(fs, "/db", logger.NewFakeLogger())
+
+	err = driver.SafeStore("project-1", &entity.Project{CreatedAt: "2024-01-01T00:00:00Z", Name: "project-1", Reference: "project-1.tar.gz", Version: "v1"})
+	assert.NoError(t, err)
+	err = driver.SafeStoreVersion("project-1", &entity.Project{CreatedAt: "2024-02-01T00:00:00Z", Name: "project-1", Reference: "project-1@v2.tar.gz", Version: "v2"})
+	assert.NoError(t, err)
+	err = driver.write("project-2", &entity.Project{Name: "project-2", Reference: "project-2.tar.gz", Version: "v1"})
+	assert.NoError(t, err)
+
+	return driver
+}
+
+func TestFindVersions(t *testing.T) {
+	tests := []struct {
+		desc     string
+		id       string
+		versions []string
+		err      bool
+	}{
+		{
+			desc:     "Testing finding the versions of a project sorted by creation date",
+			id:       "project-1",
+			versions: []string{"v1", "v2"},
+		},
+		{
+			desc:     "Testing finding the versions of a project stored before versions were supported",
+			id:       "project-2",
+			versions: []string{"v1"},
+		},
+		{
+			desc: "Testing error finding the versions of a project that does not exist",
+			id:   "project-3",
+			err:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			driver := newVersionedDatabaseDriver(t)
+			projects, err := driver.FindVersions(test.id)
+			if test.err {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			versions := []string{}
+			for _, project := range projects {
+				versions = append(versions, project.Version)
+			}
+			assert.Equal(t, test.versions, versions)
+
+			latest, err := driver.Find(test.id)
+			assert.NoError(t, err)
+			assert.Equal(t, test.versions[len(test.versions)-1], latest.Version)
+		})
+	}
+}
+
+func TestFindVersion(t *testing.T) {
+	tests := []struct {
+		desc      string
+		id        string
+		version   string
+		reference string
+		err       error
+	}{
+		{
+			desc:      "Testing finding a project version",
+			id:        "project-1",
+			version:   "v1",
+			reference: "project-1.tar.gz",
+		},
+		{
+			desc:      "Testing finding the version of a project stored before versions were supported",
+			id:        "project-2",
+			version:   "v1",
+			reference: "project-2.tar.gz",
+		},
+		{
+			desc:    "Testing error finding a project version that does not exist",
+			id:      "project-1",
+			version: "v3",
+			err:     fmt.Errorf("%s: %s: %s", ErrReadingRecord, ErrProjectVersionNotFound, "v3"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			project, err := newVersionedDatabaseDriver(t).FindVersion(test.id, test.version)
+			if test.err != nil {
+				assert.Equal(t, test.err, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.reference, project.Reference)
+		})
+	}
+}
+
+func TestSafeStoreVersion(t *testing.T) {
+	tests := []struct {
+		desc     string
+		id       string
+		data     *entity.Project
+		versions []string
+		err      error
+	}{
+		{
+			desc:     "Testing storing a new project version",
+			id:       "project-1",
+			data:     &entity.Project{CreatedAt: "2024-03-01T00:00:00Z", Name: "project-1", Version: "v3"},
+			versions: []string{"v1", "v2", "v3"},
+		},
+		{
+			desc:     "Testing storing a new version of a project stored before versions were supported",
+			id:       "project-2",
+			data:     &entity.Project{CreatedAt: "2024-03-01T00:00:00Z", Name: "project-2", Version: "v2"},
+			versions: []string{"v1", "v2"},
+		},
+		{
+			desc: "Testing error storing a project version that already exists",
+			id:   "project-1",
+			data: &entity.Project{Name: "project-1", Version: "v2"},
+			err:  fmt.Errorf("%s: %s %s", ErrStoringProjectVersion, filepath.Join(versionsDir, "project-1", "v2"), ErrProjectVersionExists),
+		},
+		{
+			desc: "Testing error storing a project version with an invalid version",
+			id:   "project-1",
+			data: &entity.Project{Name: "project-1", Version: "../v3"},
+			err:  fmt.Errorf("%s: %s", ErrStoringProjectVersion, "invalid version: ../v3"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			driver := newVersionedDatabaseDriver(t)
+			err := driver.SafeStoreVersion(test.id, test.data)
+			if test.err != nil {
+				assert.Equal(t, test.err, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			projects, err := driver.FindVersions(test.id)
+			assert.NoError(t, err)
+			versions := []string{}
+			for _, project := range projects {
+				versions = append(versions, project.Version)
+			}
+			assert.Equal(t, test.versions, versions)
+
+			latest, err := driver.Find(test.id)
+			assert.NoError(t, err)
+			assert.Equal(t, test.data, latest)
+		})
+	}
+}
+
+func TestSafeReplace(t *testing.T) {
+	tests := []struct {
+		desc string
+		id   string
+		data *entity.Project
+		// currentRevision replaces the revision by the revision of the stored project
+		currentRevision bool
+		revision        string
+		versions        []string
+		err             error
+	}{
+		{
+			desc:            "Testing replacing the most recent project version when its revision matches",
+			id:              "project-1",
+			data:            &entity.Project{Name: "project-1", Reference: "project-1@v2.replaced.tar.gz", Version: "v2"},
+			currentRevision: true,
+			versions:        []string{"v1", "v2"},
+		},
+		{
+			desc:     "Testing replacing the most recent project version without checking its revision",
+			id:       "project-1",
+			data:     &entity.Project{Name: "project-1", Reference: "project-1@v2.replaced.tar.gz", Version: "v2"},
+			versions: []string{"v1", "v2"},
+		},
+		{
+			desc:     "Testing replacing a project stored before versions were supported",
+			id:       "project-2",
+			data:     &entity.Project{Name: "project-2", Reference: "project-2.replaced.tar.gz", Version: "v1"},
+			versions: []string{"v1"},
+		},
+		{
+			desc:     "Testing error replacing a project whose revision does not match",
+			id:       "project-1",
+			data:     &entity.Project{Name: "project-1", Reference: "project-1@v2.replaced.tar.gz", Version: "v2"},
+			revision: "outdated",
+			err:      fmt.Errorf("%s: %w", ErrReplacingProject, entity.ErrProjectRevisionMismatch),
+		},
+		{
+			desc: "Testing error replacing a project version other than the most recent one",
+			id:   "project-1",
+			data: &entity.Project{Name: "project-1", Reference: "project-1@v1.replaced.tar.gz", Version: "v1"},
+			err:  fmt.Errorf("%s: %s: %s", ErrReplacingProject, ErrReplacingOutdatedProjectVersion, "v1"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			driver := newVersionedDatabaseDriver(t)
+			current, err := driver.Find(test.id)
+			assert.NoError(t, err)
+
+			revision := test.revision
+			if test.currentRevision {
+				revision = current.Revision
+			}
+
+			err = driver.SafeReplace(test.id, test.data, revision)
+			if test.err != nil {
+				assert.Equal(t, test.err, err)
+
+				latest, err := driver.Find(test.id)
+				assert.NoError(t, err)
+				assert.Equal(t, current, latest)
+				return
+			}
+
+			assert.NoError(t, err)
+			projects, err := driver.FindVersions(test.id)
+			assert.NoError(t, err)
+			versions := []string{}
+			for _, project := range projects {
+				versions = append(versions, project.Version)
+			}
+			assert.Equal(t, test.versions, versions)
+
+			latest, err := driver.Find(test.id)
+			assert.NoError(t, err)
+			assert.Equal(t, test.data, latest)
+			assert.Equal(t, current.CreatedAt, latest.CreatedAt)
+			assert.NotEqual(t, current.Revision, latest.Revision)
+
+			version, err := driver.FindVersion(test.id, test.data.Version)
+			assert.NoError(t, err)
+			assert.Equal(t, test.data.Reference, version.Reference)
+		})
+	}
+
+	t.Run("Testing error replacing a project that does not exist", func(t *testing.T) {
+		t.Parallel()
+		t.Log("Testing error replacing a project that does not exist")
+
+		driver := newVersionedDatabaseDriver(t)
+		err := driver.SafeReplace("project-3", &entity.Project{Name: "project-3", Version: "v1"}, "")
+		assert.Error(t, err)
+	})
+}
+
+func TestDeleteVersion(t *testing.T) {
+	tests := []struct {
+		desc     string
+		id       string
+		version  string
+		versions []string
+		err      error
+	}{
+		{
+			desc:     "Testing deleting the most recent project version",
+			id:       "project-1",
+			version:  "v2",
+			versions: []string{"v1"},
+		},
+		{
+			desc:     "Testing deleting an old project version",
+			id:       "project-1",
+			version:  "v1",
+			versions: []string{"v2"},
+		},
+		{
+			desc:    "Testing error deleting the only version of a project",
+			id:      "project-2",
+			version: "v1",
+			err:     fmt.Errorf("%s: %s", ErrRemovingProjectVersion, ErrRemovingLastProjectVersion),
+		},
+		{
+			desc:    "Testing error deleting a project version that does not exist",
+			id:      "project-1",
+			version: "v3",
+			err:     fmt.Errorf("%s: %s: %s", ErrRemovingProjectVersion, ErrProjectVersionNotFound, "v3"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			driver := newVersionedDatabaseDriver(t)
+			err := driver.DeleteVersion(test.id, test.version)
+			if test.err != nil {
+				assert.Equal(t, test.err, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			projects, err := driver.FindVersions(test.id)
+			assert.NoError(t, err)
+			versions := []string{}
+			for _, project := range projects {
+				versions = append(versions, project.Version)
+			}
+			assert.Equal(t, test.versions, versions)
+
+			latest, err := driver.Find(test.id)
+			assert.NoError(t, err)
+			assert.Equal(t, test.versions[len(test.versions)-1], latest.Version)
+		})
+	}
+}
+
+func TestDeleteProjectWithVersions(t *testing.T) {
+	t.Log("Testing deleting a project removes all its versions")
+
+	driver := newVersionedDatabaseDriver(t)
+	err := driver.Delete("project-1")
+	assert.NoError(t, err)
+
+	exists, err := afero.DirExists(driver.fs, filepath.Join("/db", versionsDir, "project-1"))
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	projects, err := driver.FindAll()
+	assert.NoError(t, err)
+	assert.Len(t, projects, 1)
+}
+
+func TestTrash(t *testing.T) {
+	tests := []struct {
+		desc     string
+		id       string
+		versions []string
+		err      bool
+	}{
+		{
+			desc:     "Testing moving a project and all its versions to the trash",
+			id:       "project-1",
+			versions: []string{"v1", "v2"},
+		},
+		{
+			desc:     "Testing moving a project stored before versions were supported to the trash",
+			id:       "project-2",
+			versions: []string{"v1"},
+		},
+		{
+			desc: "Testing error moving a project that does not exist to the trash",
+			id:   "project-3",
+			err:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			driver := newVersionedDatabaseDriver(t)
+			err := driver.Trash(test.id, "2026-01-05T10:00:00Z")
+			if test.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+
+			_, err = driver.Find(test.id)
+			assert.Error(t, err)
+			_, err = driver.FindVersions(test.id)
+			assert.Error(t, err)
+
+			projects, err := driver.FindAll()
+			assert.NoError(t, err)
+			assert.Len(t, projects, 1)
+
+			trashed, err := driver.FindAllTrashed()
+			assert.NoError(t, err)
+			assert.Len(t, trashed, 1)
+			assert.Equal(t, test.id, trashed[0].Name)
+			assert.Equal(t, "2026-01-05T10:00:00Z", trashed[0].DeletedAt)
+
+			trashedVersions, err := driver.FindTrashedVersions(test.id)
+			assert.NoError(t, err)
+			versions := []string{}
+			for _, project := range trashedVersions {
+				versions = append(versions, project.Version)
+			}
+			assert.Equal(t, test.versions, versions)
+		})
+	}
+
+	t.Run("Testing error moving a project to the trash when a project with the same ID is already in the trash", func(t *testing.T) {
+		t.Parallel()
+		t.Log("Testing error moving a project to the trash when a project with the same ID is already in the trash")
+
+		driver := newVersionedDatabaseDriver(t)
+		assert.NoError(t, driver.Trash("project-2", "2026-01-05T10:00:00Z"))
+		assert.NoError(t, driver.write("project-2", &entity.Project{Name: "project-2", Reference: "project-2.tar.gz", Version: "v1"}))
+
+		err := driver.Trash("project-2", "2026-01-05T11:00:00Z")
+		assert.Equal(t, fmt.Errorf("%s: %s %s", ErrTrashingProject, "project-2", ErrProjectAlreadyTrashed), err)
+
+		_, err = driver.Find("project-2")
+		assert.NoError(t, err)
+	})
+}
+
+func TestRestore(t *testing.T) {
+	t.Log("Testing restoring a project and all its versions from the trash")
+
+	driver := newVersionedDatabaseDriver(t)
+	current, err := driver.Find("project-1")
+	assert.NoError(t, err)
+	assert.NoError(t, driver.Trash("project-1", "2026-01-05T10:00:00Z"))
+
+	err = driver.Restore("project-1")
+	assert.NoError(t, err)
+
+	restored, err := driver.Find("project-1")
+	assert.NoError(t, err)
+	assert.Empty(t, restored.DeletedAt)
+	assert.Equal(t, current, restored)
+
+	versions, err := driver.FindVersions("project-1")
+	assert.NoError(t, err)
+	assert.Len(t, versions, 2)
+
+	trashed, err := driver.FindAllTrashed()
+	assert.NoError(t, err)
+	assert.Empty(t, trashed)
+
+	err = driver.Restore("project-1")
+	assert.Error(t, err)
+
+	assert.NoError(t, driver.Trash("project-2", "2026-01-05T10:00:00Z"))
+	assert.NoError(t, driver.write("project-2", &entity.Project{Name: "project-2", Reference: "project-2.tar.gz", Version: "v1"}))
+	err = driver.Restore("project-2")
+	assert.Equal(t, fmt.Errorf("%s: %s %s", ErrRestoringProject, "project-2", ErrProjectExists), err)
+}
+
+func TestPurge(t *testing.T) {
+	t.Log("Testing purging a project and all its versions from the trash")
+
+	driver := newVersionedDatabaseDriver(t)
+	assert.NoError(t, driver.Trash("project-1", "2026-01-05T10:00:00Z"))
+
+	err := driver.Purge("project-1")
+	assert.NoError(t, err)
+
+	exists, err := afero.Exists(driver.fs, filepath.Join("/db", trashDir, "project-1"))
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	_, err = driver.FindTrashedVersions("project-1")
+	assert.Error(t, err)
+
+	err = driver.Purge("")
+	assert.Error(t, err)
+}
+
 func TestInitialize(t *testing.T) {
 	// test the Initialize method of the DatabaseDriver. Use a driven test table approach. use a in-memory filsystem. use afero. Start by testing all the errors and then test case where the initialization is successful. In the successful case, check that the database path is created if it does not exist. each test must have a arrange function to prepare the environment and an assert function to check the results.
 
@@ -393,6 +930,98 @@ func TestInitialize(t *testing.T) {
 	}
 }
 
+func TestInitializeMigratesVersions(t *testing.T) {
+	tests := []struct {
+		desc        string
+		id          string
+		arrangeFunc func(*testing.T, *DatabaseDriver)
+		latest      string
+		versions    []string
+		trashed     bool
+	}{
+		{
+			desc: "Testing migrating a project stored with the latest alias before versions were supported",
+			id:   "project-1",
+			arrangeFunc: func(t *testing.T, driver *DatabaseDriver) {
+				assert.NoError(t, driver.write("project-1", &entity.Project{Name: "project-1", Reference: "project-1.tar.gz", Version: entity.LatestVersion}))
+			},
+			latest:   "v1",
+			versions: []string{"v1"},
+		},
+		{
+			desc: "Testing migrating the first version of a project stored with the latest alias",
+			id:   "project-1",
+			arrangeFunc: func(t *testing.T, driver *DatabaseDriver) {
+				first := &entity.Project{CreatedAt: "2024-01-01T00:00:00Z", Name: "project-1", Reference: "project-1.tar.gz", Version: entity.LatestVersion}
+				second := &entity.Project{CreatedAt: "2024-02-01T00:00:00Z", Name: "project-1", Reference: "project-1@v2.tar.gz", Version: "v2"}
+				assert.NoError(t, driver.write(filepath.Join(versionsDir, "project-1", entity.LatestVersion), first))
+				assert.NoError(t, driver.write(filepath.Join(versionsDir, "project-1", "v2"), second))
+				assert.NoError(t, driver.write("project-1", second))
+			},
+			latest:   "v2",
+			versions: []string{"v1", "v2"},
+		},
+		{
+			desc: "Testing migrating the version of a project stored with the latest alias when the fallback version is already used",
+			id:   "project-1",
+			arrangeFunc: func(t *testing.T, driver *DatabaseDriver) {
+				first := &entity.Project{CreatedAt: "2024-01-01T00:00:00Z", Name: "project-1", Reference: "project-1.tar.gz", Version: entity.LatestVersion}
+				second := &entity.Project{CreatedAt: "2024-02-01T00:00:00Z", Name: "project-1", Reference: "project-1@v1.tar.gz", Version: "v1"}
+				assert.NoError(t, driver.write(filepath.Join(versionsDir, "project-1", entity.LatestVersion), first))
+				assert.NoError(t, driver.write(filepath.Join(versionsDir, "project-1", "v1"), second))
+				assert.NoError(t, driver.write("project-1", second))
+			},
+			latest:   "v1",
+			versions: []string{"v1-2", "v1"},
+		},
+		{
+			desc: "Testing migrating a project in the trash stored with the latest alias",
+			id:   "project-1",
+			arrangeFunc: func(t *testing.T, driver *DatabaseDriver) {
+				assert.NoError(t, driver.write(filepath.Join(trashDir, "project-1", "project-1"), &entity.Project{Name: "project-1", Reference: "project-1.tar.gz", Version: entity.LatestVersion}))
+			},
+			latest:   "v1",
+			versions: []string{"v1"},
+			trashed:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			fs := afero.NewMemMapFs()
+			assert.NoError(t, fs.MkdirAll("/db", 0755))
+			driver := NewDatabaseDriver(fs, "/db", logger.NewFakeLogger())
+			test.arrangeFunc(t, driver)
+
+			err := driver.Initialize()
+			assert.NoError(t, err)
+
+			if test.trashed {
+				driver = driver.trashDatabase(test.id)
+			}
+
+			project, err := driver.Find(test.id)
+			assert.NoError(t, err)
+			assert.Equal(t, test.latest, project.Version)
+
+			versions, err := driver.FindVersions(test.id)
+			assert.NoError(t, err)
+			found := []string{}
+			for _, version := range versions {
+				found = append(found, version.Version)
+			}
+			assert.Equal(t, test.versions, found)
+
+			exists, err := driver.exists(filepath.Join(versionsDir, test.id, entity.LatestVersion))
+			assert.NoError(t, err)
+			assert.False(t, exists)
+		})
+	}
+}
+
 func TestRead(t *testing.T) {
 
 	// Arranging the database with the records needed for the test
@@ -436,6 +1065,7 @@ func TestRead(t *testing.T) {
 				Format:    "plain",
 				Name:      "project-1",
 				Reference: "test/projects/project-1",
+				Revision:  "7e92476636f83579f3fcdf886df224344c960b22311395f2e4c6c1553a7a4a12",
 				Storage:   "local",
 			},
 			err: nil,
@@ -542,18 +1172,21 @@ func TestReadAll(t *testing.T) {
 				{
 					Name:      "project-1",
 					Reference: "project-1",
+					Revision:  "5f051b2b6aa808f5fe7d4ad3a36675eeb131da54fb3d9385d3c837a50e1cde8e",
 					Format:    "plain",
 					Storage:   "local",
 				},
 				{
 					Name:      "project-2",
 					Reference: "project-2",
+					Revision:  "ee81c3a3947e9ca885b3a82b4ebd0dfd7d7dd73a632acfb077c9276e150d9b2f",
 					Format:    "plain",
 					Storage:   "local",
 				},
 				{
 					Name:      "project-3",
 					Reference: "project-3",
+					Revision:  "5c0f9d1576d3f33911016527aea448f8bbb0bc0ec7b1a9db94caa81b0577677c",
 					Format:    "plain",
 					Storage:   "local",
 				},
@@ -579,6 +1212,7 @@ func TestReadAll(t *testing.T) {
 				{
 					Name:      "project-1",
 					Reference: "project-1",
+					Revision:  "5f051b2b6aa808f5fe7d4ad3a36675eeb131da54fb3d9385d3c837a50e1cde8e",
 					Format:    "plain",
 					Storage:   "local",
 				},
@@ -653,6 +1287,7 @@ func TestWrite(t *testing.T) {
 				expected := &entity.Project{
 					Name:      "project-1",
 					Reference: "project-1",
+					Revision:  "030e7aa569016af8236599d2cf5b31aa188b33c61a7163ef7cc313ac4854161f",
 					Format:    "plain",
 					Storage:   "local",
 				}
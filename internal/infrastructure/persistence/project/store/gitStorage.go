@@ -0,0 +1,100 @@
+package store
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	"github.com/apenella/ransidble/internal/domain/ports/repository"
+)
+
+const (
+	// ErrStoringProjectInGitStorage represents the error when the source code of a project is stored in a git storage
+	ErrStoringProjectInGitStorage = "the source code of a git project is not stored but fetched from its repository"
+)
+
+// GitStorage represents the storage of the projects located in git repositories. The source code of those projects stays in their repositories, so there is nothing to store or delete
+type GitStorage struct {
+	// logger is the logger
+	logger repository.Logger
+}
+
+// Ensure GitStorage implements the SourceCodeStorer interface
+var _ repository.SourceCodeStorer = (*GitStorage)(nil)
+
+// NewGitStorage creates a new git storage
+func NewGitStorage(logger repository.Logger) *GitStorage {
+	return &GitStorage{
+		logger: logger,
+	}
+}
+
+// Store returns an error because the source code of the git projects can not be uploaded
+func (s *GitStorage) Store(project *entity.Project, file io.Reader) error {
+
+	if project == nil {
+		s.logger.Error(
+			ErrProjectNotProvided,
+			map[string]interface{}{
+				"component": "GitStorage.Store",
+				"package":   "github.com/apenella/ransidble/internal/infrastructure/persistence/project/store",
+			})
+		return fmt.Errorf(ErrProjectNotProvided)
+	}
+
+	s.logger.Error(
+		ErrStoringProjectInGitStorage,
+		map[string]interface{}{
+			"component":  "GitStorage.Store",
+			"package":    "github.com/apenella/ransidble/internal/infrastructure/persistence/project/store",
+			"project_id": project.Name,
+		})
+	return fmt.Errorf(ErrStoringProjectInGitStorage)
+}
+
+// Delete does not remove anything because the source code of the git projects stays in their repositories. The mirrored repositories are kept since other projects could share them
+func (s *GitStorage) Delete(project *entity.Project) error {
+
+	if project == nil {
+		s.logger.Error(
+			ErrProjectNotProvided,
+			map[string]interface{}{
+				"component": "GitStorage.Delete",
+				"package":   "github.com/apenella/ransidble/internal/infrastructure/persistence/project/store",
+			})
+		return fmt.Errorf(ErrProjectNotProvided)
+	}
+
+	return nil
+}
+
+// Trash does not move anything because the source code of the git projects stays in their repositories
+func (s *GitStorage) Trash(project *entity.Project) error {
+	return s.keep("GitStorage.Trash", project)
+}
+
+// Restore does not move anything because the source code of the git projects stays in their repositories
+func (s *GitStorage) Restore(project *entity.Project) error {
+	return s.keep("GitStorage.Restore", project)
+}
+
+// Purge does not remove anything because the source code of the git projects stays in their repositories
+func (s *GitStorage) Purge(project *entity.Project) error {
+	return s.keep("GitStorage.Purge", project)
+}
+
+// keep checks the project provided to a trash operation, which has nothing to do on the source code kept in the repositories
+func (s *GitStorage) keep(component string, project *entity.Project) error {
+
+	if project == nil {
+		s.logger.Error(
+			ErrProjectNotProvided,
+			map[string]interface{}{
+				"component": component,
+				"package":   "github.com/apenella/ransidble/internal/infrastructure/persistence/project/store",
+			})
+		return fmt.Errorf(ErrProjectNotProvided)
+	}
+
+	return nil
+}
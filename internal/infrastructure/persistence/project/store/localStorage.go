@@ -36,8 +36,17 @@ const (
 	ErrInitializingLocalStorage = "error initializing local storage"
 	// ErrDeletingProjectInLocalStorage represents the error when a project cannot be deleted in local storage
 	ErrDeletingProjectInLocalStorage = "error deleting project in local storage"
+	// ErrTrashingProjectInLocalStorage represents the error when a project cannot be moved to the trash in local storage
+	ErrTrashingProjectInLocalStorage = "error moving project to the trash in local storage"
+	// ErrRestoringProjectInLocalStorage represents the error when a project cannot be restored from the trash in local storage
+	ErrRestoringProjectInLocalStorage = "error restoring project from the trash in local storage"
+	// ErrPurgingProjectInLocalStorage represents the error when a project cannot be purged from the trash in local storage
+	ErrPurgingProjectInLocalStorage = "error purging project from the trash in local storage"
 )
 
+// trashDir is the directory, relative to the storage path, where the source code of the deleted projects is kept until they are restored or purged
+const trashDir = ".trash"
+
 // LocalStorage represents a repository on local storage
 type LocalStorage struct {
 	// Filesystem path where projects are stored
@@ -109,7 +118,7 @@ func (s *LocalStorage) Initialize() error {
 	return nil
 }
 
-// Store method copies the project from working directory to local storage
+// Store method copies the project from working directory to local storage, setting the digest of the stored source code on the project
 func (s *LocalStorage) Store(project *entity.Project, srcFile io.Reader) (err error) {
 
 	var dstFile afero.File
@@ -217,7 +226,8 @@ func (s *LocalStorage) Store(project *entity.Project, srcFile io.Reader) (err er
 		err = dstFile.Close()
 	}()
 
-	err = afero.WriteReader(s.fs, destFilePath, srcFile)
+	digester := entity.NewProjectDigester(srcFile)
+	err = afero.WriteReader(s.fs, destFilePath, digester)
 	if err != nil {
 		s.logger.Error(
 			ErrStoringProjectInLocalStorage,
@@ -228,6 +238,7 @@ func (s *LocalStorage) Store(project *entity.Project, srcFile io.Reader) (err er
 			})
 		return fmt.Errorf(ErrStoringProjectInLocalStorage)
 	}
+	project.Digest = digester.Digest()
 
 	return nil
 }
@@ -280,3 +291,116 @@ func (s *LocalStorage) Delete(project *entity.Project) error {
 
 	return nil
 }
+
+// Trash method moves the source code of a deleted project to the trash directory of the local storage
+func (s *LocalStorage) Trash(project *entity.Project) error {
+	return s.move("LocalStorage.Trash", ErrTrashingProjectInLocalStorage, project, "", trashDir)
+}
+
+// Restore method moves the source code of a deleted project from the trash directory back to the local storage
+func (s *LocalStorage) Restore(project *entity.Project) error {
+	return s.move("LocalStorage.Restore", ErrRestoringProjectInLocalStorage, project, trashDir, "")
+}
+
+// Purge method removes the source code of a deleted project from the trash directory of the local storage
+func (s *LocalStorage) Purge(project *entity.Project) error {
+
+	err := s.validate("LocalStorage.Purge", project)
+	if err != nil {
+		return err
+	}
+
+	trashFilePath := filepath.Join(s.path, trashDir, project.Reference)
+
+	// the source code of the plain projects is a directory
+	err = s.fs.RemoveAll(trashFilePath)
+	if err != nil {
+		s.logger.Error(
+			fmt.Sprintf("%s: %s", ErrPurgingProjectInLocalStorage, err.Error()),
+			map[string]interface{}{
+				"component":   "LocalStorage.Purge",
+				"package":     "github.com/apenella/ransidble/internal/infrastructure/persistence/project/store",
+				"destination": trashFilePath,
+			})
+		return fmt.Errorf("%s: %s", ErrPurgingProjectInLocalStorage, err.Error())
+	}
+
+	return nil
+}
+
+// move method moves the source code of a project between two directories of the local storage, relative to the storage path
+func (s *LocalStorage) move(component string, errMsg string, project *entity.Project, from string, to string) error {
+
+	err := s.validate(component, project)
+	if err != nil {
+		return err
+	}
+
+	srcFilePath := filepath.Join(s.path, from, project.Reference)
+	destFilePath := filepath.Join(s.path, to, project.Reference)
+
+	err = s.fs.MkdirAll(filepath.Dir(destFilePath), 0755)
+	if err == nil {
+		err = s.fs.Rename(srcFilePath, destFilePath)
+	}
+	if err != nil {
+		s.logger.Error(
+			fmt.Sprintf("%s: %s", errMsg, err.Error()),
+			map[string]interface{}{
+				"component":   component,
+				"package":     "github.com/apenella/ransidble/internal/infrastructure/persistence/project/store",
+				"source":      srcFilePath,
+				"destination": destFilePath,
+			})
+		return fmt.Errorf("%s: %s", errMsg, err.Error())
+	}
+
+	return nil
+}
+
+// validate method checks that the project source code can be located in the local storage. The reference is required, since the storage path itself would be used otherwise
+func (s *LocalStorage) validate(component string, project *entity.Project) error {
+
+	if project == nil {
+		s.logger.Error(
+			ErrProjectNotProvided,
+			map[string]interface{}{
+				"component": component,
+				"package":   "github.com/apenella/ransidble/internal/infrastructure/persistence/project/store",
+			})
+		return fmt.Errorf(ErrProjectNotProvided)
+	}
+
+	if project.Reference == "" {
+		s.logger.Error(
+			ErrProjectReferenceNotProvided,
+			map[string]interface{}{
+				"component":  component,
+				"package":    "github.com/apenella/ransidble/internal/infrastructure/persistence/project/store",
+				"project_id": project.Name,
+			})
+		return fmt.Errorf(ErrProjectReferenceNotProvided)
+	}
+
+	if s.fs == nil {
+		s.logger.Error(
+			ErrStorageHandlerNotInitialized,
+			map[string]interface{}{
+				"component": component,
+				"package":   "github.com/apenella/ransidble/internal/infrastructure/persistence/project/store",
+			})
+		return fmt.Errorf(ErrStorageHandlerNotInitialized)
+	}
+
+	if s.path == "" {
+		s.logger.Error(
+			ErrStoragePathNotProvided,
+			map[string]interface{}{
+				"component": component,
+				"package":   "github.com/apenella/ransidble/internal/infrastructure/persistence/project/store",
+			})
+		return fmt.Errorf(ErrStoragePathNotProvided)
+	}
+
+	return nil
+}
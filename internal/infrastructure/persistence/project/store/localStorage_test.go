@@ -5,6 +5,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/apenella/ransidble/internal/domain/core/entity"
@@ -277,6 +278,24 @@ func TestLocalStorage_Store(t *testing.T) {
 	}
 }
 
+func TestLocalStorage_Store_Digest(t *testing.T) {
+	t.Log("Testing store a project in local storage setting the digest of the stored source code")
+
+	fs := afero.NewMemMapFs()
+	err := fs.MkdirAll("local-storage", 0755)
+	assert.NoError(t, err)
+
+	project := entity.NewProject("project-1", "v1.0.0", "project-1.tar.gz", entity.ProjectFormatTarGz, entity.ProjectTypeLocal)
+
+	err = NewLocalStorage(fs, "local-storage", logger.NewFakeLogger()).Store(project, strings.NewReader("content"))
+	assert.NoError(t, err)
+	assert.Equal(t, "sha256:ed7002b439e9ac845f22357d822bac1444730fbdb6016d3ec9432297b9ec9f73", project.Digest)
+
+	content, err := afero.ReadFile(fs, filepath.Join("local-storage", "project-1.tar.gz"))
+	assert.NoError(t, err)
+	assert.Equal(t, "content", string(content))
+}
+
 func TestLocalStorage_Delete(t *testing.T) {
 
 	// sourceBase := filepath.Join("fixtures", "persistence-project-store", "local", "delete")
@@ -393,3 +412,111 @@ func TestLocalStorage_Delete(t *testing.T) {
 		})
 	}
 }
+
+func TestLocalStorage_Trash(t *testing.T) {
+
+	project := &entity.Project{
+		Name:      "project-1",
+		Reference: "project-1.tar.gz",
+		Format:    "targz",
+		Storage:   "local",
+	}
+
+	tests := []struct {
+		desc        string
+		storage     *LocalStorage
+		project     *entity.Project
+		arrangeFunc func(*testing.T, *LocalStorage)
+		assertFunc  func(*testing.T, *LocalStorage)
+		err         error
+	}{
+		{
+			desc:    "Testing moving a project to the trash in local storage",
+			storage: NewLocalStorage(afero.NewMemMapFs(), "trash", logger.NewFakeLogger()),
+			project: project,
+			arrangeFunc: func(t *testing.T, storage *LocalStorage) {
+				assert.NoError(t, afero.WriteFile(storage.fs, filepath.Join("trash", "project-1.tar.gz"), []byte("content"), 0644))
+			},
+			assertFunc: func(t *testing.T, storage *LocalStorage) {
+				exists, err := afero.Exists(storage.fs, filepath.Join("trash", "project-1.tar.gz"))
+				assert.NoError(t, err)
+				assert.False(t, exists)
+
+				content, err := afero.ReadFile(storage.fs, filepath.Join("trash", ".trash", "project-1.tar.gz"))
+				assert.NoError(t, err)
+				assert.Equal(t, "content", string(content))
+			},
+		},
+		{
+			desc:    "Testing error moving a project that does not exist to the trash in local storage",
+			storage: NewLocalStorage(afero.NewMemMapFs(), "trash", logger.NewFakeLogger()),
+			project: project,
+			err:     fmt.Errorf(ErrTrashingProjectInLocalStorage),
+		},
+		{
+			desc:    "Testing error moving a project without reference to the trash in local storage",
+			storage: NewLocalStorage(afero.NewMemMapFs(), "trash", logger.NewFakeLogger()),
+			project: &entity.Project{Name: "project-1"},
+			err:     fmt.Errorf(ErrProjectReferenceNotProvided),
+		},
+		{
+			desc:    "Testing error moving a project to the trash in local storage when project is not provided",
+			storage: NewLocalStorage(afero.NewMemMapFs(), "trash", logger.NewFakeLogger()),
+			err:     fmt.Errorf(ErrProjectNotProvided),
+		},
+		{
+			desc:    "Testing error moving a project to the trash in local storage when storage path is not provided",
+			storage: NewLocalStorage(afero.NewMemMapFs(), "", logger.NewFakeLogger()),
+			project: project,
+			err:     fmt.Errorf(ErrStoragePathNotProvided),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			if test.arrangeFunc != nil {
+				test.arrangeFunc(t, test.storage)
+			}
+
+			err := test.storage.Trash(test.project)
+			if test.err != nil {
+				assert.ErrorContains(t, err, test.err.Error())
+			} else {
+				assert.NoError(t, err)
+				test.assertFunc(t, test.storage)
+			}
+		})
+	}
+}
+
+func TestLocalStorage_RestoreAndPurge(t *testing.T) {
+	t.Log("Testing restoring a project from the trash in local storage and purging it once it is moved to the trash again")
+
+	storage := NewLocalStorage(afero.NewMemMapFs(), "storage", logger.NewFakeLogger())
+	project := &entity.Project{
+		Name:      "project-1",
+		Reference: "project-1.tar.gz",
+		Format:    "targz",
+		Storage:   "local",
+	}
+	assert.NoError(t, afero.WriteFile(storage.fs, filepath.Join("storage", ".trash", "project-1.tar.gz"), []byte("content"), 0644))
+
+	assert.NoError(t, storage.Restore(project))
+	content, err := afero.ReadFile(storage.fs, filepath.Join("storage", "project-1.tar.gz"))
+	assert.NoError(t, err)
+	assert.Equal(t, "content", string(content))
+
+	assert.NoError(t, storage.Trash(project))
+	assert.NoError(t, storage.Purge(project))
+	for _, path := range []string{filepath.Join("storage", "project-1.tar.gz"), filepath.Join("storage", ".trash", "project-1.tar.gz")} {
+		exists, err := afero.Exists(storage.fs, path)
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	}
+
+	err = storage.Restore(project)
+	assert.ErrorContains(t, err, ErrRestoringProjectInLocalStorage)
+}
@@ -0,0 +1,100 @@
+package store
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	"github.com/apenella/ransidble/internal/domain/ports/repository"
+)
+
+const (
+	// ErrStoringProjectInOCIRegistryStorage represents the error when the source code of a project is stored in an OCI registry storage
+	ErrStoringProjectInOCIRegistryStorage = "the source code of an oci project is not stored but fetched from its registry"
+)
+
+// OCIRegistryStorage represents the storage of the projects located as artifacts in OCI distribution registries. The artifacts stay in their registries, so there is nothing to store or delete
+type OCIRegistryStorage struct {
+	// logger is the logger
+	logger repository.Logger
+}
+
+// Ensure OCIRegistryStorage implements the SourceCodeStorer interface
+var _ repository.SourceCodeStorer = (*OCIRegistryStorage)(nil)
+
+// NewOCIRegistryStorage creates a new OCI registry storage
+func NewOCIRegistryStorage(logger repository.Logger) *OCIRegistryStorage {
+	return &OCIRegistryStorage{
+		logger: logger,
+	}
+}
+
+// Store returns an error because the source code of the oci projects can not be uploaded
+func (s *OCIRegistryStorage) Store(project *entity.Project, file io.Reader) error {
+
+	if project == nil {
+		s.logger.Error(
+			ErrProjectNotProvided,
+			map[string]interface{}{
+				"component": "OCIRegistryStorage.Store",
+				"package":   "github.com/apenella/ransidble/internal/infrastructure/persistence/project/store",
+			})
+		return fmt.Errorf(ErrProjectNotProvided)
+	}
+
+	s.logger.Error(
+		ErrStoringProjectInOCIRegistryStorage,
+		map[string]interface{}{
+			"component":  "OCIRegistryStorage.Store",
+			"package":    "github.com/apenella/ransidble/internal/infrastructure/persistence/project/store",
+			"project_id": project.Name,
+		})
+	return fmt.Errorf(ErrStoringProjectInOCIRegistryStorage)
+}
+
+// Delete does not remove anything because the artifacts of the oci projects stay in their registries, where they could be shared by other projects
+func (s *OCIRegistryStorage) Delete(project *entity.Project) error {
+
+	if project == nil {
+		s.logger.Error(
+			ErrProjectNotProvided,
+			map[string]interface{}{
+				"component": "OCIRegistryStorage.Delete",
+				"package":   "github.com/apenella/ransidble/internal/infrastructure/persistence/project/store",
+			})
+		return fmt.Errorf(ErrProjectNotProvided)
+	}
+
+	return nil
+}
+
+// Trash does not move anything because the source code of the oci projects stays in their registries
+func (s *OCIRegistryStorage) Trash(project *entity.Project) error {
+	return s.keep("OCIRegistryStorage.Trash", project)
+}
+
+// Restore does not move anything because the source code of the oci projects stays in their registries
+func (s *OCIRegistryStorage) Restore(project *entity.Project) error {
+	return s.keep("OCIRegistryStorage.Restore", project)
+}
+
+// Purge does not remove anything because the source code of the oci projects stays in their registries
+func (s *OCIRegistryStorage) Purge(project *entity.Project) error {
+	return s.keep("OCIRegistryStorage.Purge", project)
+}
+
+// keep checks the project provided to a trash operation, which has nothing to do on the source code kept in the registries
+func (s *OCIRegistryStorage) keep(component string, project *entity.Project) error {
+
+	if project == nil {
+		s.logger.Error(
+			ErrProjectNotProvided,
+			map[string]interface{}{
+				"component": component,
+				"package":   "github.com/apenella/ransidble/internal/infrastructure/persistence/project/store",
+			})
+		return fmt.Errorf(ErrProjectNotProvided)
+	}
+
+	return nil
+}
@@ -0,0 +1,106 @@
+package store
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	"github.com/apenella/ransidble/internal/infrastructure/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOCIRegistryStorage_Store(t *testing.T) {
+	tests := []struct {
+		desc    string
+		project *entity.Project
+		err     error
+	}{
+		{
+			desc:    "Testing error storing the source code of an oci project",
+			project: &entity.Project{Name: "project", Storage: entity.ProjectTypeOCI},
+			err:     fmt.Errorf(ErrStoringProjectInOCIRegistryStorage),
+		},
+		{
+			desc: "Testing error storing the source code of an oci project when the project is not provided",
+			err:  fmt.Errorf(ErrProjectNotProvided),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			storage := NewOCIRegistryStorage(logger.NewFakeLogger())
+			err := storage.Store(test.project, strings.NewReader("content"))
+			assert.Equal(t, test.err, err)
+		})
+	}
+}
+
+func TestOCIRegistryStorage_Delete(t *testing.T) {
+	tests := []struct {
+		desc    string
+		project *entity.Project
+		err     error
+	}{
+		{
+			desc:    "Testing deleting an oci project",
+			project: &entity.Project{Name: "project", Storage: entity.ProjectTypeOCI},
+		},
+		{
+			desc: "Testing error deleting an oci project when the project is not provided",
+			err:  fmt.Errorf(ErrProjectNotProvided),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			storage := NewOCIRegistryStorage(logger.NewFakeLogger())
+			err := storage.Delete(test.project)
+			if test.err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestOCIRegistryStorage_Trash(t *testing.T) {
+	tests := []struct {
+		desc    string
+		project *entity.Project
+		err     error
+	}{
+		{
+			desc:    "Testing moving a oci project to the trash, restoring it and purging it",
+			project: &entity.Project{Name: "project", Storage: entity.ProjectTypeOCI},
+		},
+		{
+			desc: "Testing error moving a oci project to the trash, restoring it and purging it when the project is not provided",
+			err:  fmt.Errorf(ErrProjectNotProvided),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			storage := NewOCIRegistryStorage(logger.NewFakeLogger())
+			for _, operation := range []func(*entity.Project) error{storage.Trash, storage.Restore, storage.Purge} {
+				err := operation(test.project)
+				if test.err != nil {
+					assert.Equal(t, test.err, err)
+				} else {
+					assert.NoError(t, err)
+				}
+			}
+		})
+	}
+}
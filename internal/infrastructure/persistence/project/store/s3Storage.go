@@ -0,0 +1,282 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	"github.com/apenella/ransidble/internal/domain/ports/repository"
+)
+
+const (
+	// ErrObjectStorageClientNotInitialized represents the error when the object storage client is not initialized
+	ErrObjectStorageClientNotInitialized = "object storage client not initialized"
+	// ErrStoringProjectInS3Storage represents the error when a project cannot be stored in an S3 storage
+	ErrStoringProjectInS3Storage = "error storing project in s3 storage"
+	// ErrDeletingProjectInS3Storage represents the error when a project cannot be deleted in an S3 storage
+	ErrDeletingProjectInS3Storage = "error deleting project in s3 storage"
+	// ErrTrashingProjectInS3Storage represents the error when a project cannot be moved to the trash in an S3 storage
+	ErrTrashingProjectInS3Storage = "error moving project to the trash in s3 storage"
+	// ErrRestoringProjectInS3Storage represents the error when a project cannot be restored from the trash in an S3 storage
+	ErrRestoringProjectInS3Storage = "error restoring project from the trash in s3 storage"
+	// ErrPurgingProjectInS3Storage represents the error when a project cannot be purged from the trash in an S3 storage
+	ErrPurgingProjectInS3Storage = "error purging project from the trash in s3 storage"
+)
+
+// trashKeyPrefix is the prefix of the object keys where the source code of the deleted projects is kept until they are restored or purged
+const trashKeyPrefix = ".trash/"
+
+// S3Storage represents the storage of the projects located in an S3-compatible object storage. The source code of each project is stored as an object whose key is the project reference
+type S3Storage struct {
+	// client is the object storage client
+	client repository.ObjectStorer
+	// logger is the logger
+	logger repository.Logger
+}
+
+// Ensure S3Storage implements the SourceCodeStorer interface
+var _ repository.SourceCodeStorer = (*S3Storage)(nil)
+
+// NewS3Storage creates a new S3 storage
+func NewS3Storage(client repository.ObjectStorer, logger repository.Logger) *S3Storage {
+	return &S3Storage{
+		client: client,
+		logger: logger,
+	}
+}
+
+// Store method puts the source code of the project into the object storage, setting the digest of the stored source code on the project
+func (s *S3Storage) Store(project *entity.Project, file io.Reader) error {
+
+	if project == nil {
+		s.logger.Error(
+			ErrProjectNotProvided,
+			map[string]interface{}{
+				"component": "S3Storage.Store",
+				"package":   "github.com/apenella/ransidble/internal/infrastructure/persistence/project/store",
+			})
+		return fmt.Errorf(ErrProjectNotProvided)
+	}
+
+	if file == nil {
+		s.logger.Error(
+			ErrProjectFileNotProvided,
+			map[string]interface{}{
+				"component":  "S3Storage.Store",
+				"package":    "github.com/apenella/ransidble/internal/infrastructure/persistence/project/store",
+				"project_id": project.Name,
+			})
+		return fmt.Errorf(ErrProjectFileNotProvided)
+	}
+
+	if project.Reference == "" {
+		s.logger.Error(
+			ErrProjectReferenceNotProvided,
+			map[string]interface{}{
+				"component":  "S3Storage.Store",
+				"package":    "github.com/apenella/ransidble/internal/infrastructure/persistence/project/store",
+				"project_id": project.Name,
+			})
+		return fmt.Errorf(ErrProjectReferenceNotProvided)
+	}
+
+	if s.client == nil {
+		s.logger.Error(
+			ErrObjectStorageClientNotInitialized,
+			map[string]interface{}{
+				"component":  "S3Storage.Store",
+				"package":    "github.com/apenella/ransidble/internal/infrastructure/persistence/project/store",
+				"project_id": project.Name,
+			})
+		return fmt.Errorf(ErrObjectStorageClientNotInitialized)
+	}
+
+	digester := entity.NewProjectDigester(file)
+	err := s.client.PutObject(context.Background(), project.Reference, digester)
+	if err != nil {
+		s.logger.Error(
+			fmt.Sprintf("%s: %s", ErrStoringProjectInS3Storage, err.Error()),
+			map[string]interface{}{
+				"component":  "S3Storage.Store",
+				"package":    "github.com/apenella/ransidble/internal/infrastructure/persistence/project/store",
+				"project_id": project.Name,
+				"reference":  project.Reference,
+			})
+		return fmt.Errorf("%s: %w", ErrStoringProjectInS3Storage, err)
+	}
+	project.Digest = digester.Digest()
+
+	return nil
+}
+
+// Delete method removes the source code of the project from the object storage
+func (s *S3Storage) Delete(project *entity.Project) error {
+
+	if project == nil {
+		s.logger.Error(
+			ErrProjectNotProvided,
+			map[string]interface{}{
+				"component": "S3Storage.Delete",
+				"package":   "github.com/apenella/ransidble/internal/infrastructure/persistence/project/store",
+			})
+		return fmt.Errorf(ErrProjectNotProvided)
+	}
+
+	if project.Reference == "" {
+		s.logger.Error(
+			ErrProjectReferenceNotProvided,
+			map[string]interface{}{
+				"component":  "S3Storage.Delete",
+				"package":    "github.com/apenella/ransidble/internal/infrastructure/persistence/project/store",
+				"project_id": project.Name,
+			})
+		return fmt.Errorf(ErrProjectReferenceNotProvided)
+	}
+
+	if s.client == nil {
+		s.logger.Error(
+			ErrObjectStorageClientNotInitialized,
+			map[string]interface{}{
+				"component":  "S3Storage.Delete",
+				"package":    "github.com/apenella/ransidble/internal/infrastructure/persistence/project/store",
+				"project_id": project.Name,
+			})
+		return fmt.Errorf(ErrObjectStorageClientNotInitialized)
+	}
+
+	err := s.client.DeleteObject(context.Background(), project.Reference)
+	if err != nil {
+		s.logger.Error(
+			fmt.Sprintf("%s: %s", ErrDeletingProjectInS3Storage, err.Error()),
+			map[string]interface{}{
+				"component":  "S3Storage.Delete",
+				"package":    "github.com/apenella/ransidble/internal/infrastructure/persistence/project/store",
+				"project_id": project.Name,
+				"reference":  project.Reference,
+			})
+		return fmt.Errorf("%s: %w", ErrDeletingProjectInS3Storage, err)
+	}
+
+	return nil
+}
+
+// Trash method moves the source code of a deleted project to the trash of the object storage
+func (s *S3Storage) Trash(project *entity.Project) error {
+
+	err := s.validate("S3Storage.Trash", project)
+	if err != nil {
+		return err
+	}
+
+	return s.move("S3Storage.Trash", ErrTrashingProjectInS3Storage, project, project.Reference, trashKeyPrefix+project.Reference)
+}
+
+// Restore method moves the source code of a deleted project from the trash back to the object storage
+func (s *S3Storage) Restore(project *entity.Project) error {
+
+	err := s.validate("S3Storage.Restore", project)
+	if err != nil {
+		return err
+	}
+
+	return s.move("S3Storage.Restore", ErrRestoringProjectInS3Storage, project, trashKeyPrefix+project.Reference, project.Reference)
+}
+
+// Purge method removes the source code of a deleted project from the trash of the object storage
+func (s *S3Storage) Purge(project *entity.Project) error {
+
+	err := s.validate("S3Storage.Purge", project)
+	if err != nil {
+		return err
+	}
+
+	err = s.client.DeleteObject(context.Background(), trashKeyPrefix+project.Reference)
+	if err != nil {
+		s.logger.Error(
+			fmt.Sprintf("%s: %s", ErrPurgingProjectInS3Storage, err.Error()),
+			map[string]interface{}{
+				"component":  "S3Storage.Purge",
+				"package":    "github.com/apenella/ransidble/internal/infrastructure/persistence/project/store",
+				"project_id": project.Name,
+				"reference":  project.Reference,
+			})
+		return fmt.Errorf("%s: %w", ErrPurgingProjectInS3Storage, err)
+	}
+
+	return nil
+}
+
+// move method copies an object to a new key and removes the original one, since the object storages do not provide a way to rename the objects
+func (s *S3Storage) move(component string, errMsg string, project *entity.Project, from string, to string) error {
+
+	object, err := s.client.GetObject(context.Background(), from)
+	if err != nil {
+		s.logger.Error(
+			fmt.Sprintf("%s: %s", errMsg, err.Error()),
+			map[string]interface{}{
+				"component":  component,
+				"package":    "github.com/apenella/ransidble/internal/infrastructure/persistence/project/store",
+				"project_id": project.Name,
+				"reference":  from,
+			})
+		return fmt.Errorf("%s: %w", errMsg, err)
+	}
+	defer object.Close()
+
+	err = s.client.PutObject(context.Background(), to, object)
+	if err == nil {
+		err = s.client.DeleteObject(context.Background(), from)
+	}
+	if err != nil {
+		s.logger.Error(
+			fmt.Sprintf("%s: %s", errMsg, err.Error()),
+			map[string]interface{}{
+				"component":  component,
+				"package":    "github.com/apenella/ransidble/internal/infrastructure/persistence/project/store",
+				"project_id": project.Name,
+				"reference":  from,
+			})
+		return fmt.Errorf("%s: %w", errMsg, err)
+	}
+
+	return nil
+}
+
+// validate method checks that the project source code can be located in the object storage
+func (s *S3Storage) validate(component string, project *entity.Project) error {
+
+	if project == nil {
+		s.logger.Error(
+			ErrProjectNotProvided,
+			map[string]interface{}{
+				"component": component,
+				"package":   "github.com/apenella/ransidble/internal/infrastructure/persistence/project/store",
+			})
+		return fmt.Errorf(ErrProjectNotProvided)
+	}
+
+	if project.Reference == "" {
+		s.logger.Error(
+			ErrProjectReferenceNotProvided,
+			map[string]interface{}{
+				"component":  component,
+				"package":    "github.com/apenella/ransidble/internal/infrastructure/persistence/project/store",
+				"project_id": project.Name,
+			})
+		return fmt.Errorf(ErrProjectReferenceNotProvided)
+	}
+
+	if s.client == nil {
+		s.logger.Error(
+			ErrObjectStorageClientNotInitialized,
+			map[string]interface{}{
+				"component":  component,
+				"package":    "github.com/apenella/ransidble/internal/infrastructure/persistence/project/store",
+				"project_id": project.Name,
+			})
+		return fmt.Errorf(ErrObjectStorageClientNotInitialized)
+	}
+
+	return nil
+}
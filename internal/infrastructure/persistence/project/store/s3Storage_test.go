@@ -0,0 +1,217 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	"github.com/apenella/ransidble/internal/infrastructure/logger"
+	"github.com/apenella/ransidble/internal/infrastructure/s3"
+	"github.com/apenella/ransidble/test/s3server"
+	"github.com/stretchr/testify/assert"
+)
+
+// newS3Client creates a client of the S3 stand-in server
+func newS3Client(t *testing.T, server *s3server.Server, bucket string) *s3.Client {
+	client, err := s3.NewClient(s3.Config{
+		AccessKeyID:     "access",
+		Bucket:          bucket,
+		Endpoint:        server.URL,
+		PathStyle:       true,
+		Prefix:          "projects",
+		SecretAccessKey: "secret",
+	}, server.Client())
+	assert.NoError(t, err)
+
+	return client
+}
+
+func TestS3Storage_Store(t *testing.T) {
+	server := s3server.NewServer("ransidble", "access", s3.DefaultRegion)
+	t.Cleanup(server.Close)
+
+	tests := []struct {
+		desc    string
+		storage *S3Storage
+		project *entity.Project
+		err     error
+	}{
+		{
+			desc:    "Testing storing the source code of a project in an S3 storage",
+			storage: NewS3Storage(newS3Client(t, server, "ransidble"), logger.NewFakeLogger()),
+			project: entity.NewProject("project-store", "v1.0.0", "project-store.tar.gz", entity.ProjectFormatTarGz, entity.ProjectTypeS3),
+		},
+		{
+			desc:    "Testing error storing the source code of a project in an unknown bucket",
+			storage: NewS3Storage(newS3Client(t, server, "unknown"), logger.NewFakeLogger()),
+			project: entity.NewProject("project-unknown", "v1.0.0", "project-unknown.tar.gz", entity.ProjectFormatTarGz, entity.ProjectTypeS3),
+			err:     s3.ErrPuttingObject,
+		},
+		{
+			desc:    "Testing error storing the source code of a project without reference",
+			storage: NewS3Storage(newS3Client(t, server, "ransidble"), logger.NewFakeLogger()),
+			project: &entity.Project{Name: "project"},
+			err:     fmt.Errorf(ErrProjectReferenceNotProvided),
+		},
+		{
+			desc:    "Testing error storing the source code of a project when the client is not initialized",
+			storage: NewS3Storage(nil, logger.NewFakeLogger()),
+			project: &entity.Project{Name: "project", Reference: "project.tar.gz"},
+			err:     fmt.Errorf(ErrObjectStorageClientNotInitialized),
+		},
+		{
+			desc:    "Testing error storing the source code of a project when the project is not provided",
+			storage: NewS3Storage(newS3Client(t, server, "ransidble"), logger.NewFakeLogger()),
+			err:     fmt.Errorf(ErrProjectNotProvided),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			err := test.storage.Store(test.project, strings.NewReader("content"))
+			switch {
+			case errors.Is(test.err, s3.ErrPuttingObject):
+				assert.ErrorIs(t, err, test.err)
+			case test.err != nil:
+				assert.Equal(t, test.err, err)
+			default:
+				assert.NoError(t, err)
+				content, exists := server.Object("projects/" + test.project.Reference)
+				assert.True(t, exists)
+				assert.Equal(t, "content", string(content))
+				assert.Equal(t, "sha256:ed7002b439e9ac845f22357d822bac1444730fbdb6016d3ec9432297b9ec9f73", test.project.Digest)
+			}
+		})
+	}
+}
+
+func TestS3Storage_Delete(t *testing.T) {
+	server := s3server.NewServer("ransidble", "access", s3.DefaultRegion)
+	t.Cleanup(server.Close)
+	server.PutObject("projects/project-delete.tar.gz", []byte("content"))
+
+	tests := []struct {
+		desc    string
+		storage *S3Storage
+		project *entity.Project
+		err     error
+	}{
+		{
+			desc:    "Testing deleting the source code of a project in an S3 storage",
+			storage: NewS3Storage(newS3Client(t, server, "ransidble"), logger.NewFakeLogger()),
+			project: entity.NewProject("project-delete", "v1.0.0", "project-delete.tar.gz", entity.ProjectFormatTarGz, entity.ProjectTypeS3),
+		},
+		{
+			desc:    "Testing error deleting the source code of a project without reference",
+			storage: NewS3Storage(newS3Client(t, server, "ransidble"), logger.NewFakeLogger()),
+			project: &entity.Project{Name: "project"},
+			err:     fmt.Errorf(ErrProjectReferenceNotProvided),
+		},
+		{
+			desc:    "Testing error deleting the source code of a project when the project is not provided",
+			storage: NewS3Storage(newS3Client(t, server, "ransidble"), logger.NewFakeLogger()),
+			err:     fmt.Errorf(ErrProjectNotProvided),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			err := test.storage.Delete(test.project)
+			if test.err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.NoError(t, err)
+				_, exists := server.Object("projects/" + test.project.Reference)
+				assert.False(t, exists)
+			}
+		})
+	}
+}
+
+func TestS3Storage_Trash(t *testing.T) {
+	server := s3server.NewServer("ransidble", "access", s3.DefaultRegion)
+	t.Cleanup(server.Close)
+	server.PutObject("projects/project-trash.tar.gz", []byte("content"))
+
+	tests := []struct {
+		desc    string
+		storage *S3Storage
+		project *entity.Project
+		err     error
+	}{
+		{
+			desc:    "Testing moving the source code of a project to the trash of an S3 storage",
+			storage: NewS3Storage(newS3Client(t, server, "ransidble"), logger.NewFakeLogger()),
+			project: entity.NewProject("project-trash", "v1.0.0", "project-trash.tar.gz", entity.ProjectFormatTarGz, entity.ProjectTypeS3),
+		},
+		{
+			desc:    "Testing error moving the source code of a project that does not exist to the trash of an S3 storage",
+			storage: NewS3Storage(newS3Client(t, server, "ransidble"), logger.NewFakeLogger()),
+			project: entity.NewProject("project-missing", "v1.0.0", "project-missing.tar.gz", entity.ProjectFormatTarGz, entity.ProjectTypeS3),
+			err:     errors.New(ErrTrashingProjectInS3Storage),
+		},
+		{
+			desc:    "Testing error moving the source code of a project without reference to the trash of an S3 storage",
+			storage: NewS3Storage(newS3Client(t, server, "ransidble"), logger.NewFakeLogger()),
+			project: &entity.Project{Name: "project"},
+			err:     fmt.Errorf(ErrProjectReferenceNotProvided),
+		},
+		{
+			desc:    "Testing error moving the source code of a project to the trash of an S3 storage when the client is not initialized",
+			storage: NewS3Storage(nil, logger.NewFakeLogger()),
+			project: entity.NewProject("project-trash", "v1.0.0", "project-trash.tar.gz", entity.ProjectFormatTarGz, entity.ProjectTypeS3),
+			err:     fmt.Errorf(ErrObjectStorageClientNotInitialized),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			err := test.storage.Trash(test.project)
+			if test.err != nil {
+				assert.ErrorContains(t, err, test.err.Error())
+			} else {
+				assert.NoError(t, err)
+				_, exists := server.Object("projects/" + test.project.Reference)
+				assert.False(t, exists)
+				content, exists := server.Object("projects/.trash/" + test.project.Reference)
+				assert.True(t, exists)
+				assert.Equal(t, "content", string(content))
+			}
+		})
+	}
+}
+
+func TestS3Storage_RestoreAndPurge(t *testing.T) {
+	t.Log("Testing restoring the source code of a project from the trash of an S3 storage and purging it once it is trashed again")
+
+	server := s3server.NewServer("ransidble", "access", s3.DefaultRegion)
+	t.Cleanup(server.Close)
+	server.PutObject("projects/.trash/project-restore.tar.gz", []byte("content"))
+
+	storage := NewS3Storage(newS3Client(t, server, "ransidble"), logger.NewFakeLogger())
+	project := entity.NewProject("project-restore", "v1.0.0", "project-restore.tar.gz", entity.ProjectFormatTarGz, entity.ProjectTypeS3)
+
+	assert.NoError(t, storage.Restore(project))
+	content, exists := server.Object("projects/project-restore.tar.gz")
+	assert.True(t, exists)
+	assert.Equal(t, "content", string(content))
+	_, exists = server.Object("projects/.trash/project-restore.tar.gz")
+	assert.False(t, exists)
+
+	assert.NoError(t, storage.Trash(project))
+	assert.NoError(t, storage.Purge(project))
+	_, exists = server.Object("projects/.trash/project-restore.tar.gz")
+	assert.False(t, exists)
+	_, exists = server.Object("projects/project-restore.tar.gz")
+	assert.False(t, exists)
+}
@@ -0,0 +1,34 @@
+package upload
+
+import "errors"
+
+var (
+	// ErrAppendingUploadChunk is returned when a chunk can not be appended to the staged source code of an upload
+	ErrAppendingUploadChunk = errors.New("error appending upload chunk")
+	// ErrInitializingUploadRepository is returned when an upload repository can not be initialized
+	ErrInitializingUploadRepository = errors.New("error initializing upload repository")
+	// ErrInvalidUploadID is returned when the upload id can not be used as a file name
+	ErrInvalidUploadID = errors.New("invalid upload id")
+	// ErrObjectStorerNotInitialized is returned when the object storage where the chunks of the uploads are staged is not initialized
+	ErrObjectStorerNotInitialized = errors.New("object storage not initialized")
+	// ErrOpeningUploadContent is returned when the staged source code of an upload can not be opened
+	ErrOpeningUploadContent = errors.New("error opening upload content")
+	// ErrReadingUploadRecord is returned when an upload record can not be read
+	ErrReadingUploadRecord = errors.New("error reading upload record")
+	// ErrRemovingUpload is returned when an upload can not be removed
+	ErrRemovingUpload = errors.New("error removing upload")
+	// ErrUploadAlreadyExists is returned when an upload with the same id already exists
+	ErrUploadAlreadyExists = errors.New("upload already exists")
+	// ErrUploadNotFound is returned when an upload does not exist
+	ErrUploadNotFound = errors.New("upload not found")
+	// ErrUploadNotProvided is returned when the upload is not provided
+	ErrUploadNotProvided = errors.New("upload not provided")
+	// ErrUploadOffsetMismatch is returned when a chunk is not appended at the end of the staged source code
+	ErrUploadOffsetMismatch = errors.New("upload offset mismatch")
+	// ErrUploadRepositoryPathIsNotADirectory is returned when the path of the local upload repository is not a directory
+	ErrUploadRepositoryPathIsNotADirectory = errors.New("upload repository path is not a directory")
+	// ErrUploadRepositoryPathNotInitialized is returned when the path of the local upload repository is not initialized
+	ErrUploadRepositoryPathNotInitialized = errors.New("upload repository path not initialized")
+	// ErrWritingUploadRecord is returned when an upload record can not be written
+	ErrWritingUploadRecord = errors.New("error writing upload record")
+)
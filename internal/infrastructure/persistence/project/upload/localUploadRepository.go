@@ -0,0 +1,410 @@
+package upload
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	"github.com/apenella/ransidble/internal/domain/ports/repository"
+	"github.com/spf13/afero"
+)
+
+const (
+	// uploadContentExtension is the extension of the files where the source code received by the uploads is staged
+	uploadContentExtension = ".data"
+	// uploadRecordExtension is the extension of the files where the uploads are persisted
+	uploadRecordExtension = ".json"
+	// uploadRecordTemporaryExtension is the extension of the file where an upload is written before replacing its record
+	uploadRecordTemporaryExtension = ".tmp"
+)
+
+// LocalUploadRepository struct to stage the source code received by the uploads in the local filesystem. Each upload is stored as a JSON record next to the file where its chunks are appended, and the offset of the upload is the size of that file, so it is never out of sync with the bytes actually staged
+type LocalUploadRepository struct {
+	// fs is the filesystem where the uploads are staged
+	fs afero.Fs
+	// path is the directory where the uploads are staged
+	path  string
+	mutex sync.Mutex
+
+	logger repository.Logger
+}
+
+// Ensure LocalUploadRepository implements the ProjectUploadRepository interface
+var _ repository.ProjectUploadRepository = (*LocalUploadRepository)(nil)
+
+// NewLocalUploadRepository creates a new LocalUploadRepository
+func NewLocalUploadRepository(fs afero.Fs, path string, logger repository.Logger) *LocalUploadRepository {
+	return &LocalUploadRepository{
+		fs:     fs,
+		logger: logger,
+		path:   path,
+	}
+}
+
+// Initialize creates the repository path when it does not exist
+func (r *LocalUploadRepository) Initialize() error {
+	return initializePath(r.fs, r.path, "LocalUploadRepository.Initialize", r.logger)
+}
+
+// Create stores a new upload along with an empty file to stage its source code
+func (r *LocalUploadRepository) Create(upload *entity.ProjectUpload) error {
+
+	if upload == nil {
+		return ErrUploadNotProvided
+	}
+
+	err := validateID(upload.ID)
+	if err != nil {
+		return err
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	exists, err := afero.Exists(r.fs, r.recordPath(upload.ID))
+	if err != nil {
+		return fmt.Errorf("%w %s: %w", ErrWritingUploadRecord, upload.ID, err)
+	}
+	if exists {
+		return fmt.Errorf("%w: %s", ErrUploadAlreadyExists, upload.ID)
+	}
+
+	err = afero.WriteFile(r.fs, r.contentPath(upload.ID), []byte{}, 0644)
+	if err != nil {
+		return fmt.Errorf("%w %s: %w", ErrWritingUploadRecord, upload.ID, err)
+	}
+
+	err = r.write(upload)
+	if err != nil {
+		r.logger.Error(
+			err.Error(),
+			map[string]interface{}{
+				"component": "LocalUploadRepository.Create",
+				"package":   "github.com/apenella/ransidble/internal/infrastructure/persistence/project/upload",
+				"upload_id": upload.ID,
+			},
+		)
+
+		return err
+	}
+
+	return nil
+}
+
+// Find returns an upload by id, along with the number of bytes staged as its offset
+func (r *LocalUploadRepository) Find(id string) (*entity.ProjectUpload, error) {
+
+	err := validateID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.read(id)
+}
+
+// FindAll returns all the uploads sorted by creation time. The records that can not be read are skipped
+func (r *LocalUploadRepository) FindAll() ([]*entity.ProjectUpload, error) {
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	entries, err := afero.ReadDir(r.fs, r.path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrReadingUploadRecord, err)
+	}
+
+	uploads := []*entity.ProjectUpload{}
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != uploadRecordExtension {
+			continue
+		}
+
+		id := strings.TrimSuffix(entry.Name(), uploadRecordExtension)
+
+		upload, err := r.read(id)
+		if err != nil {
+			r.logger.Error(
+				err.Error(),
+				map[string]interface{}{
+					"component": "LocalUploadRepository.FindAll",
+					"package":   "github.com/apenella/ransidble/internal/infrastructure/persistence/project/upload",
+					"upload_id": id,
+				},
+			)
+			continue
+		}
+
+		uploads = append(uploads, upload)
+	}
+
+	sort.SliceStable(uploads, func(i, j int) bool {
+		return uploads[i].CreatedAt < uploads[j].CreatedAt
+	})
+
+	return uploads, nil
+}
+
+// Append appends a chunk to the staged source code of an upload, and returns the number of bytes appended. The chunk is only appended when the offset is the number of bytes already staged, and the bytes appended before an error are kept
+func (r *LocalUploadRepository) Append(id string, offset int64, chunk io.Reader) (int64, error) {
+
+	err := validateID(id)
+	if err != nil {
+		return 0, err
+	}
+
+	file, err := r.fs.OpenFile(r.contentPath(id), os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, fmt.Errorf("%w: %s", ErrUploadNotFound, id)
+		}
+		return 0, fmt.Errorf("%w %s: %w", ErrAppendingUploadChunk, id, err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return 0, fmt.Errorf("%w %s: %w", ErrAppendingUploadChunk, id, err)
+	}
+
+	if info.Size() != offset {
+		return 0, fmt.Errorf("%w: expected %d, got %d", ErrUploadOffsetMismatch, info.Size(), offset)
+	}
+
+	written, err := io.Copy(file, chunk)
+	if err != nil {
+		r.logger.Error(
+			fmt.Sprintf("%s: %s", ErrAppendingUploadChunk, err.Error()),
+			map[string]interface{}{
+				"component": "LocalUploadRepository.Append",
+				"package":   "github.com/apenella/ransidble/internal/infrastructure/persistence/project/upload",
+				"upload_id": id,
+				"written":   written,
+			},
+		)
+
+		return written, fmt.Errorf("%w %s: %w", ErrAppendingUploadChunk, id, err)
+	}
+
+	return written, nil
+}
+
+// Open returns the staged source code of an upload
+func (r *LocalUploadRepository) Open(id string) (io.ReadSeekCloser, error) {
+
+	err := validateID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := r.fs.Open(r.contentPath(id))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: %s", ErrUploadNotFound, id)
+		}
+		return nil, fmt.Errorf("%w %s: %w", ErrOpeningUploadContent, id, err)
+	}
+
+	return file, nil
+}
+
+// Remove removes an upload along with its staged source code
+func (r *LocalUploadRepository) Remove(id string) error {
+
+	err := validateID(id)
+	if err != nil {
+		return err
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, path := range []string{r.recordPath(id), r.contentPath(id)} {
+		err = r.fs.Remove(path)
+		if err != nil && !os.IsNotExist(err) {
+			r.logger.Error(
+				fmt.Sprintf("%s: %s", ErrRemovingUpload, err.Error()),
+				map[string]interface{}{
+					"component": "LocalUploadRepository.Remove",
+					"package":   "github.com/apenella/ransidble/internal/infrastructure/persistence/project/upload",
+					"path":      path,
+					"upload_id": id,
+				},
+			)
+
+			return fmt.Errorf("%w %s: %w", ErrRemovingUpload, id, err)
+		}
+	}
+
+	r.logger.Debug(
+		"Upload removed",
+		map[string]interface{}{
+			"component": "LocalUploadRepository.Remove",
+			"package":   "github.com/apenella/ransidble/internal/infrastructure/persistence/project/upload",
+			"upload_id": id,
+		},
+	)
+
+	return nil
+}
+
+// Update updates the record of an existing upload. The offset is not persisted, since it is taken from the staged source code
+func (r *LocalUploadRepository) Update(upload *entity.ProjectUpload) error {
+
+	if upload == nil {
+		return ErrUploadNotProvided
+	}
+
+	err := validateID(upload.ID)
+	if err != nil {
+		return err
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	exists, err := afero.Exists(r.fs, r.recordPath(upload.ID))
+	if err != nil {
+		return fmt.Errorf("%w %s: %w", ErrWritingUploadRecord, upload.ID, err)
+	}
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrUploadNotFound, upload.ID)
+	}
+
+	return r.write(upload)
+}
+
+// read reads an upload record and sets its offset to the size of the staged source code. The caller must hold the repository lock
+func (r *LocalUploadRepository) read(id string) (*entity.ProjectUpload, error) {
+
+	data, err := afero.ReadFile(r.fs, r.recordPath(id))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: %s", ErrUploadNotFound, id)
+		}
+		return nil, fmt.Errorf("%w %s: %w", ErrReadingUploadRecord, id, err)
+	}
+
+	upload := &entity.ProjectUpload{}
+	err = json.Unmarshal(data, upload)
+	if err != nil {
+		return nil, fmt.Errorf("%w %s: %w", ErrReadingUploadRecord, id, err)
+	}
+
+	info, err := r.fs.Stat(r.contentPath(id))
+	if err != nil {
+		return nil, fmt.Errorf("%w %s: %w", ErrReadingUploadRecord, id, err)
+	}
+	upload.Offset = info.Size()
+
+	return upload, nil
+}
+
+// write writes an upload record. The record is written into a temporary file which then replaces the record, to not leave a partial record when the server stops while writing it. The caller must hold the repository lock
+func (r *LocalUploadRepository) write(upload *entity.ProjectUpload) error {
+
+	data, err := json.Marshal(upload)
+	if err != nil {
+		return fmt.Errorf("%w %s: %w", ErrWritingUploadRecord, upload.ID, err)
+	}
+
+	temporaryPath := r.recordPath(upload.ID) + uploadRecordTemporaryExtension
+
+	err = afero.WriteFile(r.fs, temporaryPath, data, 0644)
+	if err != nil {
+		return fmt.Errorf("%w %s: %w", ErrWritingUploadRecord, upload.ID, err)
+	}
+
+	err = r.fs.Rename(temporaryPath, r.recordPath(upload.ID))
+	if err != nil {
+		return fmt.Errorf("%w %s: %w", ErrWritingUploadRecord, upload.ID, err)
+	}
+
+	return nil
+}
+
+// recordPath returns the path of the upload record
+func (r *LocalUploadRepository) recordPath(id string) string {
+	return filepath.Join(r.path, id+uploadRecordExtension)
+}
+
+// contentPath returns the path of the staged source code of the upload
+func (r *LocalUploadRepository) contentPath(id string) string {
+	return filepath.Join(r.path, id+uploadContentExtension)
+}
+
+// validateID returns an error when the upload id can not be used as a file name of the repository path, so the requests can not reach files outside of it
+func validateID(id string) error {
+	if id == "" || strings.HasPrefix(id, ".") || strings.ContainsAny(id, `/\`) {
+		return fmt.Errorf("%w: %q", ErrInvalidUploadID, id)
+	}
+
+	return nil
+}
+
+// initializePath creates the path where an upload repository keeps the uploads when it does not exist
+func initializePath(fs afero.Fs, path string, component string, logger repository.Logger) error {
+
+	if path == "" {
+		logger.Error(
+			ErrUploadRepositoryPathNotInitialized.Error(),
+			map[string]interface{}{
+				"component": component,
+				"package":   "github.com/apenella/ransidble/internal/infrastructure/persistence/project/upload",
+			},
+		)
+
+		return ErrUploadRepositoryPathNotInitialized
+	}
+
+	info, err := fs.Stat(path)
+	if err != nil && !os.IsNotExist(err) {
+		logger.Error(
+			fmt.Sprintf("%s: %s", ErrInitializingUploadRepository, err.Error()),
+			map[string]interface{}{
+				"component": component,
+				"package":   "github.com/apenella/ransidble/internal/infrastructure/persistence/project/upload",
+				"path":      path,
+			},
+		)
+
+		return fmt.Errorf("%w: %w", ErrInitializingUploadRepository, err)
+	}
+
+	if err == nil && !info.IsDir() {
+		logger.Error(
+			fmt.Sprintf("%s: %s", ErrInitializingUploadRepository, ErrUploadRepositoryPathIsNotADirectory),
+			map[string]interface{}{
+				"component": component,
+				"package":   "github.com/apenella/ransidble/internal/infrastructure/persistence/project/upload",
+				"path":      path,
+			},
+		)
+
+		return fmt.Errorf("%w: %w", ErrInitializingUploadRepository, ErrUploadRepositoryPathIsNotADirectory)
+	}
+
+	err = fs.MkdirAll(path, 0755)
+	if err != nil {
+		logger.Error(
+			fmt.Sprintf("%s: %s", ErrInitializingUploadRepository, err.Error()),
+			map[string]interface{}{
+				"component": component,
+				"package":   "github.com/apenella/ransidble/internal/infrastructure/persistence/project/upload",
+				"path":      path,
+			},
+		)
+
+		return fmt.Errorf("%w: %w", ErrInitializingUploadRepository, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,256 @@
+package upload
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	"github.com/apenella/ransidble/internal/infrastructure/logger"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestingLocalUploadRepository returns an initialized repository holding the uploads
+func newTestingLocalUploadRepository(t *testing.T, uploads map[*entity.ProjectUpload]string) *LocalUploadRepository {
+	repository := NewLocalUploadRepository(afero.NewMemMapFs(), "uploads", logger.NewFakeLogger())
+	assert.NoError(t, repository.Initialize())
+
+	for upload, content := range uploads {
+		assert.NoError(t, repository.Create(upload))
+		_, err := repository.Append(upload.ID, 0, strings.NewReader(content))
+		assert.NoError(t, err)
+	}
+
+	return repository
+}
+
+func TestLocalUploadRepository_Initialize(t *testing.T) {
+	tests := []struct {
+		desc        string
+		repository  *LocalUploadRepository
+		arrangeFunc func(*testing.T, *LocalUploadRepository)
+		err         error
+	}{
+		{
+			desc:       "Testing initializing a local upload repository creating its path",
+			repository: NewLocalUploadRepository(afero.NewMemMapFs(), "uploads", logger.NewFakeLogger()),
+		},
+		{
+			desc:       "Testing error initializing a local upload repository when the path is not a directory",
+			repository: NewLocalUploadRepository(afero.NewMemMapFs(), "uploads", logger.NewFakeLogger()),
+			arrangeFunc: func(t *testing.T, r *LocalUploadRepository) {
+				assert.NoError(t, afero.WriteFile(r.fs, "uploads", []byte("not a directory"), 0644))
+			},
+			err: ErrUploadRepositoryPathIsNotADirectory,
+		},
+		{
+			desc:       "Testing error initializing a local upload repository without a path",
+			repository: NewLocalUploadRepository(afero.NewMemMapFs(), "", logger.NewFakeLogger()),
+			err:        ErrUploadRepositoryPathNotInitialized,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			if test.arrangeFunc != nil {
+				test.arrangeFunc(t, test.repository)
+			}
+
+			err := test.repository.Initialize()
+			if test.err != nil {
+				assert.ErrorIs(t, err, test.err)
+			} else {
+				assert.NoError(t, err)
+				exists, err := afero.DirExists(test.repository.fs, "uploads")
+				assert.NoError(t, err)
+				assert.True(t, exists)
+			}
+		})
+	}
+}
+
+func TestLocalUploadRepository_Find(t *testing.T) {
+	tests := []struct {
+		desc       string
+		repository *LocalUploadRepository
+		id         string
+		expected   *entity.ProjectUpload
+		err        error
+	}{
+		{
+			desc: "Testing finding an upload on the local upload repository setting its offset to the staged bytes",
+			repository: newTestingLocalUploadRepository(t, map[*entity.ProjectUpload]string{
+				{ID: "upload-1", Length: 10, CreatedAt: "2026-01-05T10:00:00Z", ExpiresAt: "2026-01-05T11:00:00Z"}: "1234",
+			}),
+			id:       "upload-1",
+			expected: &entity.ProjectUpload{ID: "upload-1", Length: 10, Offset: 4, CreatedAt: "2026-01-05T10:00:00Z", ExpiresAt: "2026-01-05T11:00:00Z"},
+		},
+		{
+			desc:       "Testing error finding an upload that does not exist on the local upload repository",
+			repository: newTestingLocalUploadRepository(t, nil),
+			id:         "upload-1",
+			err:        ErrUploadNotFound,
+		},
+		{
+			desc:       "Testing error finding an upload with an id outside of the repository path on the local upload repository",
+			repository: newTestingLocalUploadRepository(t, nil),
+			id:         "../projects",
+			err:        ErrInvalidUploadID,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			upload, err := test.repository.Find(test.id)
+			if test.err != nil {
+				assert.ErrorIs(t, err, test.err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expected, upload)
+			}
+		})
+	}
+}
+
+func TestLocalUploadRepository_FindAll(t *testing.T) {
+	t.Log("Testing finding all the uploads on the local upload repository sorted by creation time")
+	t.Parallel()
+
+	repository := newTestingLocalUploadRepository(t, map[*entity.ProjectUpload]string{
+		{ID: "upload-1", Length: 10, CreatedAt: "2026-01-05T11:00:00Z"}: "",
+		{ID: "upload-2", Length: 10, CreatedAt: "2026-01-05T10:00:00Z"}: "12",
+	})
+	assert.NoError(t, afero.WriteFile(repository.fs, "uploads/invalid.json", []byte("{"), 0644))
+
+	uploads, err := repository.FindAll()
+	assert.NoError(t, err)
+	assert.Equal(t, []*entity.ProjectUpload{
+		{ID: "upload-2", Length: 10, Offset: 2, CreatedAt: "2026-01-05T10:00:00Z"},
+		{ID: "upload-1", Length: 10, CreatedAt: "2026-01-05T11:00:00Z"},
+	}, uploads)
+}
+
+func TestLocalUploadRepository_Append(t *testing.T) {
+	tests := []struct {
+		desc       string
+		repository *LocalUploadRepository
+		id         string
+		offset     int64
+		chunk      string
+		expected   string
+		written    int64
+		err        error
+	}{
+		{
+			desc: "Testing appending a chunk at the end of the staged source code on the local upload repository",
+			repository: newTestingLocalUploadRepository(t, map[*entity.ProjectUpload]string{
+				{ID: "upload-1", Length: 10}: "1234",
+			}),
+			id:       "upload-1",
+			offset:   4,
+			chunk:    "5678",
+			expected: "12345678",
+			written:  4,
+		},
+		{
+			desc: "Testing error appending a chunk at an offset other than the end of the staged source code on the local upload repository",
+			repository: newTestingLocalUploadRepository(t, map[*entity.ProjectUpload]string{
+				{ID: "upload-1", Length: 10}: "1234",
+			}),
+			id:       "upload-1",
+			offset:   2,
+			chunk:    "5678",
+			expected: "1234",
+			err:      ErrUploadOffsetMismatch,
+		},
+		{
+			desc:       "Testing error appending a chunk to an upload that does not exist on the local upload repository",
+			repository: newTestingLocalUploadRepository(t, nil),
+			id:         "upload-1",
+			chunk:      "1234",
+			err:        ErrUploadNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			written, err := test.repository.Append(test.id, test.offset, strings.NewReader(test.chunk))
+			if test.err != nil {
+				assert.ErrorIs(t, err, test.err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.written, written)
+			}
+
+			if test.expected != "" {
+				content, err := test.repository.Open(test.id)
+				assert.NoError(t, err)
+				data, err := io.ReadAll(content)
+				assert.NoError(t, err)
+				assert.NoError(t, content.Close())
+				assert.Equal(t, test.expected, string(data))
+			}
+		})
+	}
+}
+
+func TestLocalUploadRepository_Update(t *testing.T) {
+	t.Log("Testing updating an upload on the local upload repository keeps its offset")
+	t.Parallel()
+
+	repository := newTestingLocalUploadRepository(t, map[*entity.ProjectUpload]string{
+		{ID: "upload-1", Length: 10, ExpiresAt: "2026-01-05T11:00:00Z"}: "1234",
+	})
+
+	err := repository.Update(&entity.ProjectUpload{ID: "upload-1", Length: 10, Offset: 8, ExpiresAt: "2026-01-05T12:00:00Z"})
+	assert.NoError(t, err)
+
+	upload, err := repository.Find("upload-1")
+	assert.NoError(t, err)
+	assert.Equal(t, &entity.ProjectUpload{ID: "upload-1", Length: 10, Offset: 4, ExpiresAt: "2026-01-05T12:00:00Z"}, upload)
+
+	err = repository.Update(&entity.ProjectUpload{ID: "upload-2", Length: 10})
+	assert.ErrorIs(t, err, ErrUploadNotFound)
+}
+
+func TestLocalUploadRepository_Remove(t *testing.T) {
+	t.Log("Testing removing an upload on the local upload repository along with its staged source code")
+	t.Parallel()
+
+	repository := newTestingLocalUploadRepository(t, map[*entity.ProjectUpload]string{
+		{ID: "upload-1", Length: 10}: "1234",
+	})
+
+	assert.NoError(t, repository.Remove("upload-1"))
+
+	for _, path := range []string{"uploads/upload-1.json", "uploads/upload-1.data"} {
+		exists, err := afero.Exists(repository.fs, path)
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	}
+
+	_, err := repository.Find("upload-1")
+	assert.ErrorIs(t, err, ErrUploadNotFound)
+}
+
+func TestLocalUploadRepository_Create(t *testing.T) {
+	t.Log("Testing error creating an upload that already exists on the local upload repository")
+	t.Parallel()
+
+	repository := newTestingLocalUploadRepository(t, map[*entity.ProjectUpload]string{
+		{ID: "upload-1", Length: 10}: "1234",
+	})
+
+	err := repository.Create(&entity.ProjectUpload{ID: "upload-1", Length: 10})
+	assert.ErrorIs(t, err, ErrUploadAlreadyExists)
+}
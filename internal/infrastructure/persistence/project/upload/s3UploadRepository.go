@@ -0,0 +1,515 @@
+package upload
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	"github.com/apenella/ransidble/internal/domain/ports/repository"
+	"github.com/spf13/afero"
+)
+
+const (
+	// uploadObjectKeyPrefix is the prefix of the keys of the objects where the chunks received by the uploads are staged
+	uploadObjectKeyPrefix = "uploads"
+)
+
+// s3UploadRecord represents an upload persisted by the S3UploadRepository, along with the size of each chunk staged in the object storage
+type s3UploadRecord struct {
+	// Upload is the persisted upload
+	Upload *entity.ProjectUpload `json:"upload"`
+	// Parts are the sizes, in bytes, of the chunks staged in the object storage, in the order they were appended
+	Parts []int64 `json:"parts"`
+}
+
+// offset returns the number of bytes staged in the object storage
+func (r *s3UploadRecord) offset() int64 {
+	var offset int64
+	for _, size := range r.Parts {
+		offset += size
+	}
+
+	return offset
+}
+
+// S3UploadRepository struct to stage the source code received by the uploads in an S3 storage. Each chunk is staged as an object keyed by the upload id and the offset where the chunk starts, while the uploads are stored as JSON records in the local filesystem along with the size of their chunks, so the offset of an upload is the number of bytes actually staged
+type S3UploadRepository struct {
+	// client is the object storage where the chunks are staged
+	client repository.ObjectStorer
+	// fs is the filesystem where the uploads are recorded and the chunks are spooled before being staged
+	fs afero.Fs
+	// path is the directory where the uploads are recorded
+	path  string
+	mutex sync.Mutex
+
+	logger repository.Logger
+}
+
+// Ensure S3UploadRepository implements the ProjectUploadRepository interface
+var _ repository.ProjectUploadRepository = (*S3UploadRepository)(nil)
+
+// NewS3UploadRepository creates a new S3UploadRepository
+func NewS3UploadRepository(client repository.ObjectStorer, fs afero.Fs, path string, logger repository.Logger) *S3UploadRepository {
+	return &S3UploadRepository{
+		client: client,
+		fs:     fs,
+		logger: logger,
+		path:   path,
+	}
+}
+
+// Initialize creates the repository path when it does not exist
+func (r *S3UploadRepository) Initialize() error {
+
+	if r.client == nil {
+		r.logger.Error(
+			ErrObjectStorerNotInitialized.Error(),
+			map[string]interface{}{
+				"component": "S3UploadRepository.Initialize",
+				"package":   "github.com/apenella/ransidble/internal/infrastructure/persistence/project/upload",
+			},
+		)
+
+		return fmt.Errorf("%w: %w", ErrInitializingUploadRepository, ErrObjectStorerNotInitialized)
+	}
+
+	return initializePath(r.fs, r.path, "S3UploadRepository.Initialize", r.logger)
+}
+
+// Create stores a new upload without any chunk staged
+func (r *S3UploadRepository) Create(upload *entity.ProjectUpload) error {
+
+	if upload == nil {
+		return ErrUploadNotProvided
+	}
+
+	err := validateID(upload.ID)
+	if err != nil {
+		return err
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	exists, err := afero.Exists(r.fs, r.recordPath(upload.ID))
+	if err != nil {
+		return fmt.Errorf("%w %s: %w", ErrWritingUploadRecord, upload.ID, err)
+	}
+	if exists {
+		return fmt.Errorf("%w: %s", ErrUploadAlreadyExists, upload.ID)
+	}
+
+	err = r.write(&s3UploadRecord{Upload: upload, Parts: []int64{}})
+	if err != nil {
+		r.logger.Error(
+			err.Error(),
+			map[string]interface{}{
+				"component": "S3UploadRepository.Create",
+				"package":   "github.com/apenella/ransidble/internal/infrastructure/persistence/project/upload",
+				"upload_id": upload.ID,
+			},
+		)
+
+		return err
+	}
+
+	return nil
+}
+
+// Find returns an upload by id, along with the number of bytes staged as its offset
+func (r *S3UploadRepository) Find(id string) (*entity.ProjectUpload, error) {
+
+	err := validateID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	record, err := r.read(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return record.Upload, nil
+}
+
+// FindAll returns all the uploads sorted by creation time. The records that can not be read are skipped
+func (r *S3UploadRepository) FindAll() ([]*entity.ProjectUpload, error) {
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	entries, err := afero.ReadDir(r.fs, r.path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrReadingUploadRecord, err)
+	}
+
+	uploads := []*entity.ProjectUpload{}
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != uploadRecordExtension {
+			continue
+		}
+
+		id := strings.TrimSuffix(entry.Name(), uploadRecordExtension)
+
+		record, err := r.read(id)
+		if err != nil {
+			r.logger.Error(
+				err.Error(),
+				map[string]interface{}{
+					"component": "S3UploadRepository.FindAll",
+					"package":   "github.com/apenella/ransidble/internal/infrastructure/persistence/project/upload",
+					"upload_id": id,
+				},
+			)
+			continue
+		}
+
+		uploads = append(uploads, record.Upload)
+	}
+
+	sort.SliceStable(uploads, func(i, j int) bool {
+		return uploads[i].CreatedAt < uploads[j].CreatedAt
+	})
+
+	return uploads, nil
+}
+
+// Append stages a chunk of an upload in the object storage, and returns the number of bytes staged. The chunk is only staged when the offset is the number of bytes already staged. The chunk is spooled in the local filesystem before it is staged, so the bytes received before an error are kept
+func (r *S3UploadRepository) Append(id string, offset int64, chunk io.Reader) (int64, error) {
+
+	err := validateID(id)
+	if err != nil {
+		return 0, err
+	}
+
+	if r.client == nil {
+		return 0, fmt.Errorf("%w %s: %w", ErrAppendingUploadChunk, id, ErrObjectStorerNotInitialized)
+	}
+
+	r.mutex.Lock()
+	record, err := r.read(id)
+	r.mutex.Unlock()
+	if err != nil {
+		return 0, err
+	}
+
+	if record.offset() != offset {
+		return 0, fmt.Errorf("%w: expected %d, got %d", ErrUploadOffsetMismatch, record.offset(), offset)
+	}
+
+	spool, err := afero.TempFile(r.fs, r.path, id+"-*"+uploadContentExtension)
+	if err != nil {
+		return 0, fmt.Errorf("%w %s: %w", ErrAppendingUploadChunk, id, err)
+	}
+	defer func() {
+		_ = spool.Close()
+		_ = r.fs.Remove(spool.Name())
+	}()
+
+	written, errCopy := io.Copy(spool, chunk)
+	if written > 0 {
+		err = r.stage(id, offset, spool, written)
+		if err != nil {
+			r.logger.Error(
+				err.Error(),
+				map[string]interface{}{
+					"component": "S3UploadRepository.Append",
+					"package":   "github.com/apenella/ransidble/internal/infrastructure/persistence/project/upload",
+					"upload_id": id,
+					"written":   written,
+				},
+			)
+
+			return 0, err
+		}
+	}
+
+	if errCopy != nil {
+		r.logger.Error(
+			fmt.Sprintf("%s: %s", ErrAppendingUploadChunk, errCopy.Error()),
+			map[string]interface{}{
+				"component": "S3UploadRepository.Append",
+				"package":   "github.com/apenella/ransidble/internal/infrastructure/persistence/project/upload",
+				"upload_id": id,
+				"written":   written,
+			},
+		)
+
+		return written, fmt.Errorf("%w %s: %w", ErrAppendingUploadChunk, id, errCopy)
+	}
+
+	return written, nil
+}
+
+// Open returns the staged source code of an upload. The chunks are downloaded into a temporary file of the local filesystem, which is removed once it is closed
+func (r *S3UploadRepository) Open(id string) (io.ReadSeekCloser, error) {
+
+	err := validateID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.client == nil {
+		return nil, fmt.Errorf("%w %s: %w", ErrOpeningUploadContent, id, ErrObjectStorerNotInitialized)
+	}
+
+	r.mutex.Lock()
+	record, err := r.read(id)
+	r.mutex.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := afero.TempFile(r.fs, r.path, id+"-*"+uploadContentExtension)
+	if err != nil {
+		return nil, fmt.Errorf("%w %s: %w", ErrOpeningUploadContent, id, err)
+	}
+	content := &stagedContent{File: file, fs: r.fs}
+
+	var offset int64
+	for _, size := range record.Parts {
+		err = r.download(r.partKey(id, offset), content)
+		if err != nil {
+			_ = content.Close()
+			return nil, fmt.Errorf("%w %s: %w", ErrOpeningUploadContent, id, err)
+		}
+		offset += size
+	}
+
+	_, err = content.Seek(0, io.SeekStart)
+	if err != nil {
+		_ = content.Close()
+		return nil, fmt.Errorf("%w %s: %w", ErrOpeningUploadContent, id, err)
+	}
+
+	return content, nil
+}
+
+// Remove removes an upload along with the chunks staged in the object storage. The record is kept when a chunk can not be removed, so the upload is removed again by the next purge
+func (r *S3UploadRepository) Remove(id string) error {
+
+	err := validateID(id)
+	if err != nil {
+		return err
+	}
+
+	r.mutex.Lock()
+	record, err := r.read(id)
+	r.mutex.Unlock()
+	if err != nil {
+		if errors.Is(err, ErrUploadNotFound) {
+			return nil
+		}
+		return fmt.Errorf("%w %s: %w", ErrRemovingUpload, id, err)
+	}
+
+	if len(record.Parts) > 0 && r.client == nil {
+		return fmt.Errorf("%w %s: %w", ErrRemovingUpload, id, ErrObjectStorerNotInitialized)
+	}
+
+	var offset int64
+	for _, size := range record.Parts {
+		key := r.partKey(id, offset)
+
+		err = r.client.DeleteObject(context.Background(), key)
+		if err != nil {
+			r.logger.Error(
+				fmt.Sprintf("%s: %s", ErrRemovingUpload, err.Error()),
+				map[string]interface{}{
+					"component": "S3UploadRepository.Remove",
+					"key":       key,
+					"package":   "github.com/apenella/ransidble/internal/infrastructure/persistence/project/upload",
+					"upload_id": id,
+				},
+			)
+
+			return fmt.Errorf("%w %s: %w", ErrRemovingUpload, id, err)
+		}
+		offset += size
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	err = r.fs.Remove(r.recordPath(id))
+	if err != nil && !os.IsNotExist(err) {
+		r.logger.Error(
+			fmt.Sprintf("%s: %s", ErrRemovingUpload, err.Error()),
+			map[string]interface{}{
+				"component": "S3UploadRepository.Remove",
+				"package":   "github.com/apenella/ransidble/internal/infrastructure/persistence/project/upload",
+				"path":      r.recordPath(id),
+				"upload_id": id,
+			},
+		)
+
+		return fmt.Errorf("%w %s: %w", ErrRemovingUpload, id, err)
+	}
+
+	r.logger.Debug(
+		"Upload removed",
+		map[string]interface{}{
+			"component": "S3UploadRepository.Remove",
+			"package":   "github.com/apenella/ransidble/internal/infrastructure/persistence/project/upload",
+			"upload_id": id,
+		},
+	)
+
+	return nil
+}
+
+// Update updates the record of an existing upload. The offset is not persisted, since it is taken from the chunks staged
+func (r *S3UploadRepository) Update(upload *entity.ProjectUpload) error {
+
+	if upload == nil {
+		return ErrUploadNotProvided
+	}
+
+	err := validateID(upload.ID)
+	if err != nil {
+		return err
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	record, err := r.read(upload.ID)
+	if err != nil {
+		return err
+	}
+	record.Upload = upload
+
+	return r.write(record)
+}
+
+// stage puts the spooled chunk of an upload into the object storage and records its size. The object is removed when the chunk can not be recorded, so no object is left out of the upload
+func (r *S3UploadRepository) stage(id string, offset int64, spool afero.File, size int64) error {
+
+	_, err := spool.Seek(0, io.SeekStart)
+	if err != nil {
+		return fmt.Errorf("%w %s: %w", ErrAppendingUploadChunk, id, err)
+	}
+
+	key := r.partKey(id, offset)
+
+	err = r.client.PutObject(context.Background(), key, spool)
+	if err != nil {
+		return fmt.Errorf("%w %s: %w", ErrAppendingUploadChunk, id, err)
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	record, err := r.read(id)
+	if err == nil {
+		record.Parts = append(record.Parts, size)
+		err = r.write(record)
+	}
+	if err != nil {
+		_ = r.client.DeleteObject(context.Background(), key)
+		return fmt.Errorf("%w %s: %w", ErrAppendingUploadChunk, id, err)
+	}
+
+	return nil
+}
+
+// download appends an object of the object storage to the writer
+func (r *S3UploadRepository) download(key string, writer io.Writer) error {
+
+	object, err := r.client.GetObject(context.Background(), key)
+	if err != nil {
+		return err
+	}
+	defer object.Close()
+
+	_, err = io.Copy(writer, object)
+
+	return err
+}
+
+// read reads an upload record and sets the offset of the upload to the number of bytes staged. The caller must hold the repository lock
+func (r *S3UploadRepository) read(id string) (*s3UploadRecord, error) {
+
+	data, err := afero.ReadFile(r.fs, r.recordPath(id))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: %s", ErrUploadNotFound, id)
+		}
+		return nil, fmt.Errorf("%w %s: %w", ErrReadingUploadRecord, id, err)
+	}
+
+	record := &s3UploadRecord{}
+	err = json.Unmarshal(data, record)
+	if err != nil {
+		return nil, fmt.Errorf("%w %s: %w", ErrReadingUploadRecord, id, err)
+	}
+
+	if record.Upload == nil {
+		return nil, fmt.Errorf("%w %s: %w", ErrReadingUploadRecord, id, ErrUploadNotProvided)
+	}
+	record.Upload.Offset = record.offset()
+
+	return record, nil
+}
+
+// write writes an upload record. The record is written into a temporary file which then replaces the record, to not leave a partial record when the server stops while writing it. The caller must hold the repository lock
+func (r *S3UploadRepository) write(record *s3UploadRecord) error {
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("%w %s: %w", ErrWritingUploadRecord, record.Upload.ID, err)
+	}
+
+	temporaryPath := r.recordPath(record.Upload.ID) + uploadRecordTemporaryExtension
+
+	err = afero.WriteFile(r.fs, temporaryPath, data, 0644)
+	if err != nil {
+		return fmt.Errorf("%w %s: %w", ErrWritingUploadRecord, record.Upload.ID, err)
+	}
+
+	err = r.fs.Rename(temporaryPath, r.recordPath(record.Upload.ID))
+	if err != nil {
+		return fmt.Errorf("%w %s: %w", ErrWritingUploadRecord, record.Upload.ID, err)
+	}
+
+	return nil
+}
+
+// recordPath returns the path of the upload record
+func (r *S3UploadRepository) recordPath(id string) string {
+	return filepath.Join(r.path, id+uploadRecordExtension)
+}
+
+// partKey returns the key of the object where the chunk of the upload starting at the offset is staged. The offset is zero padded, so the keys of an upload are sorted as its chunks
+func (r *S3UploadRepository) partKey(id string, offset int64) string {
+	return path.Join(uploadObjectKeyPrefix, id, fmt.Sprintf("%020d", offset))
+}
+
+// stagedContent is the staged source code of an upload downloaded into a temporary file, which is removed once it is closed
+type stagedContent struct {
+	afero.File
+	fs afero.Fs
+}
+
+// Close closes and removes the temporary file
+func (c *stagedContent) Close() error {
+	err := c.File.Close()
+	errRemove := c.fs.Remove(c.File.Name())
+	if err != nil {
+		return err
+	}
+
+	return errRemove
+}
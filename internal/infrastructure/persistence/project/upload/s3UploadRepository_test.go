@@ -0,0 +1,225 @@
+package upload
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	"github.com/apenella/ransidble/internal/infrastructure/logger"
+	"github.com/apenella/ransidble/internal/infrastructure/s3"
+	"github.com/apenella/ransidble/test/s3server"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestingS3UploadRepository returns an initialized repository, staging the chunks in the S3 stand-in server, holding the uploads
+func newTestingS3UploadRepository(t *testing.T, server *s3server.Server, uploads map[*entity.ProjectUpload]string) *S3UploadRepository {
+	client, err := s3.NewClient(s3.Config{
+		AccessKeyID:     "access",
+		Bucket:          "ransidble",
+		Endpoint:        server.URL,
+		PathStyle:       true,
+		Prefix:          "projects",
+		SecretAccessKey: "secret",
+	}, server.Client())
+	assert.NoError(t, err)
+
+	repository := NewS3UploadRepository(client, afero.NewMemMapFs(), "uploads", logger.NewFakeLogger())
+	assert.NoError(t, repository.Initialize())
+
+	for upload, content := range uploads {
+		assert.NoError(t, repository.Create(upload))
+		_, err := repository.Append(upload.ID, 0, strings.NewReader(content))
+		assert.NoError(t, err)
+	}
+
+	return repository
+}
+
+func TestS3UploadRepository_Initialize(t *testing.T) {
+	t.Log("Testing error initializing an S3 upload repository without an object storage")
+	t.Parallel()
+
+	err := NewS3UploadRepository(nil, afero.NewMemMapFs(), "uploads", logger.NewFakeLogger()).Initialize()
+	assert.ErrorIs(t, err, ErrObjectStorerNotInitialized)
+}
+
+func TestS3UploadRepository_Append(t *testing.T) {
+	server := s3server.NewServer("ransidble", "access", s3.DefaultRegion)
+	t.Cleanup(server.Close)
+
+	tests := []struct {
+		desc       string
+		repository *S3UploadRepository
+		id         string
+		offset     int64
+		chunk      io.Reader
+		expected   string
+		written    int64
+		objects    map[string]string
+		err        error
+	}{
+		{
+			desc: "Testing appending a chunk at the end of the staged source code on the S3 upload repository",
+			repository: newTestingS3UploadRepository(t, server, map[*entity.ProjectUpload]string{
+				{ID: "upload-append", Length: 10}: "1234",
+			}),
+			id:       "upload-append",
+			offset:   4,
+			chunk:    strings.NewReader("5678"),
+			expected: "12345678",
+			written:  4,
+			objects: map[string]string{
+				"projects/uploads/upload-append/00000000000000000000": "1234",
+				"projects/uploads/upload-append/00000000000000000004": "5678",
+			},
+		},
+		{
+			desc: "Testing appending an interrupted chunk on the S3 upload repository keeps the bytes received",
+			repository: newTestingS3UploadRepository(t, server, map[*entity.ProjectUpload]string{
+				{ID: "upload-interrupted", Length: 10}: "1234",
+			}),
+			id:       "upload-interrupted",
+			offset:   4,
+			chunk:    io.MultiReader(strings.NewReader("56"), iotest.ErrReader(errors.New("testing error"))),
+			expected: "123456",
+			written:  2,
+			objects: map[string]string{
+				"projects/uploads/upload-interrupted/00000000000000000004": "56",
+			},
+			err: ErrAppendingUploadChunk,
+		},
+		{
+			desc: "Testing error appending a chunk at an offset other than the end of the staged source code on the S3 upload repository",
+			repository: newTestingS3UploadRepository(t, server, map[*entity.ProjectUpload]string{
+				{ID: "upload-mismatch", Length: 10}: "1234",
+			}),
+			id:       "upload-mismatch",
+			offset:   2,
+			chunk:    strings.NewReader("5678"),
+			expected: "1234",
+			err:      ErrUploadOffsetMismatch,
+		},
+		{
+			desc:       "Testing error appending a chunk to an upload that does not exist on the S3 upload repository",
+			repository: newTestingS3UploadRepository(t, server, nil),
+			id:         "upload-unknown",
+			chunk:      strings.NewReader("1234"),
+			err:        ErrUploadNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			written, err := test.repository.Append(test.id, test.offset, test.chunk)
+			if test.err != nil {
+				assert.ErrorIs(t, err, test.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.written, written)
+
+			for key, expected := range test.objects {
+				object, exists := server.Object(key)
+				assert.True(t, exists)
+				assert.Equal(t, expected, string(object))
+			}
+
+			if test.expected != "" {
+				upload, err := test.repository.Find(test.id)
+				assert.NoError(t, err)
+				assert.Equal(t, int64(len(test.expected)), upload.Offset)
+
+				content, err := test.repository.Open(test.id)
+				assert.NoError(t, err)
+				data, err := io.ReadAll(content)
+				assert.NoError(t, err)
+				assert.NoError(t, content.Close())
+				assert.Equal(t, test.expected, string(data))
+			}
+		})
+	}
+}
+
+func TestS3UploadRepository_Update(t *testing.T) {
+	t.Log("Testing updating an upload on the S3 upload repository keeps its offset")
+	t.Parallel()
+
+	server := s3server.NewServer("ransidble", "access", s3.DefaultRegion)
+	t.Cleanup(server.Close)
+
+	repository := newTestingS3UploadRepository(t, server, map[*entity.ProjectUpload]string{
+		{ID: "upload-1", Length: 10, ExpiresAt: "2026-01-05T11:00:00Z"}: "1234",
+	})
+
+	err := repository.Update(&entity.ProjectUpload{ID: "upload-1", Length: 10, Offset: 8, ExpiresAt: "2026-01-05T12:00:00Z"})
+	assert.NoError(t, err)
+
+	upload, err := repository.Find("upload-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "2026-01-05T12:00:00Z", upload.ExpiresAt)
+	assert.Equal(t, int64(4), upload.Offset)
+}
+
+func TestS3UploadRepository_Remove(t *testing.T) {
+	t.Log("Testing removing an upload on the S3 upload repository removes its staged chunks")
+	t.Parallel()
+
+	server := s3server.NewServer("ransidble", "access", s3.DefaultRegion)
+	t.Cleanup(server.Close)
+
+	repository := newTestingS3UploadRepository(t, server, map[*entity.ProjectUpload]string{
+		{ID: "upload-1", Length: 10}: "1234",
+	})
+	_, err := repository.Append("upload-1", 4, strings.NewReader("5678"))
+	assert.NoError(t, err)
+
+	err = repository.Remove("upload-1")
+	assert.NoError(t, err)
+
+	_, err = repository.Find("upload-1")
+	assert.ErrorIs(t, err, ErrUploadNotFound)
+
+	for _, key := range []string{
+		"projects/uploads/upload-1/00000000000000000000",
+		"projects/uploads/upload-1/00000000000000000004",
+	} {
+		_, exists := server.Object(key)
+		assert.False(t, exists)
+	}
+
+	// the spooled chunks are not left behind
+	entries, err := afero.ReadDir(repository.fs, "uploads")
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+
+	err = repository.Remove("upload-1")
+	assert.NoError(t, err)
+}
+
+func TestS3UploadRepository_FindAll(t *testing.T) {
+	t.Log("Testing finding all the uploads on the S3 upload repository sorted by creation time")
+	t.Parallel()
+
+	server := s3server.NewServer("ransidble", "access", s3.DefaultRegion)
+	t.Cleanup(server.Close)
+
+	repository := newTestingS3UploadRepository(t, server, map[*entity.ProjectUpload]string{
+		{ID: "upload-2", Length: 10, CreatedAt: "2026-01-05T11:00:00Z"}: "12",
+		{ID: "upload-1", Length: 10, CreatedAt: "2026-01-05T10:00:00Z"}: "1234",
+	})
+
+	uploads, err := repository.FindAll()
+	assert.NoError(t, err)
+	assert.Len(t, uploads, 2)
+	assert.Equal(t, "upload-1", uploads[0].ID)
+	assert.Equal(t, int64(4), uploads[0].Offset)
+	assert.Equal(t, "upload-2", uploads[1].ID)
+	assert.Equal(t, int64(2), uploads[1].Offset)
+}
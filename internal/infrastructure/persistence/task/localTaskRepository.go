@@ -0,0 +1,559 @@
+package persistence
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	"github.com/apenella/ransidble/internal/domain/ports/repository"
+	"github.com/spf13/afero"
+)
+
+const (
+	// taskRecordExtension is the extension of the files where the tasks are persisted
+	taskRecordExtension = ".json"
+	// taskRecordTemporaryExtension is the extension of the file where a task is written before replacing its record
+	taskRecordTemporaryExtension = ".tmp"
+)
+
+var (
+	// ErrInitializingTaskRepository is returned when the local task repository can not be initialized
+	ErrInitializingTaskRepository = fmt.Errorf("error initializing local task repository")
+	// ErrTaskInterrupted is the reason set to the tasks that were not finished when the server stopped
+	ErrTaskInterrupted = fmt.Errorf("task interrupted because the server stopped before it finished")
+	// ErrTaskRepositoryFilesystemNotInitialized is returned when the filesystem of the local task repository is not initialized
+	ErrTaskRepositoryFilesystemNotInitialized = fmt.Errorf("task repository filesystem not initialized")
+	// ErrTaskRepositoryPathIsNotADirectory is returned when the path of the local task repository is not a directory
+	ErrTaskRepositoryPathIsNotADirectory = fmt.Errorf("task repository path is not a directory")
+	// ErrTaskRepositoryPathNotInitialized is returned when the path of the local task repository is not initialized
+	ErrTaskRepositoryPathNotInitialized = fmt.Errorf("task repository path not initialized")
+	// ErrReadingTaskRecord is returned when a task record can not be read
+	ErrReadingTaskRecord = fmt.Errorf("error reading task record")
+	// ErrRemovingTaskRecord is returned when a task record can not be removed
+	ErrRemovingTaskRecord = fmt.Errorf("error removing task record")
+	// ErrWritingTaskRecord is returned when a task record can not be written
+	ErrWritingTaskRecord = fmt.Errorf("error writing task record")
+)
+
+// LocalTaskRepository struct to persist tasks in the local filesystem. Each task is stored as a JSON record in the repository path, and the tasks are also kept in memory to share them with the workers running them
+type LocalTaskRepository struct {
+	// fs is the filesystem where the task records are stored
+	fs afero.Fs
+	// path is the directory where the task records are stored
+	path  string
+	store map[string]*entity.Task
+	mutex sync.Mutex
+
+	logger repository.Logger
+}
+
+// Ensure LocalTaskRepository implements the TaskRepository interface
+var _ repository.TaskRepository = (*LocalTaskRepository)(nil)
+
+// NewLocalTaskRepository creates a new LocalTaskRepository
+func NewLocalTaskRepository(fs afero.Fs, path string, logger repository.Logger) *LocalTaskRepository {
+	return &LocalTaskRepository{
+		fs:     fs,
+		logger: logger,
+		path:   path,
+	}
+}
+
+// Initialize creates the repository path when it does not exist and loads the persisted tasks. The tasks that were not finished when the server stopped are set to FAILED, since their execution can not be resumed
+func (r *LocalTaskRepository) Initialize() error {
+
+	if r.fs == nil {
+		r.logger.Error(
+			ErrTaskRepositoryFilesystemNotInitialized.Error(),
+			map[string]interface{}{
+				"component": "LocalTaskRepository.Initialize",
+				"package":   "github.com/apenella/ransidble/internal/infrastructure/persistence/task",
+			},
+		)
+
+		return ErrTaskRepositoryFilesystemNotInitialized
+	}
+
+	if r.path == "" {
+		r.logger.Error(
+			ErrTaskRepositoryPathNotInitialized.Error(),
+			map[string]interface{}{
+				"component": "LocalTaskRepository.Initialize",
+				"package":   "github.com/apenella/ransidble/internal/infrastructure/persistence/task",
+			},
+		)
+
+		return ErrTaskRepositoryPathNotInitialized
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	info, err := r.fs.Stat(r.path)
+	if err != nil && !os.IsNotExist(err) {
+		r.logger.Error(
+			fmt.Sprintf("%s: %s", ErrInitializingTaskRepository, err.Error()),
+			map[string]interface{}{
+				"component": "LocalTaskRepository.Initialize",
+				"package":   "github.com/apenella/ransidble/internal/infrastructure/persistence/task",
+				"path":      r.path,
+			},
+		)
+
+		return fmt.Errorf("%w: %w", ErrInitializingTaskRepository, err)
+	}
+
+	if os.IsNotExist(err) {
+		r.logger.Info(
+			"Creating local task repository",
+			map[string]interface{}{
+				"component": "LocalTaskRepository.Initialize",
+				"package":   "github.com/apenella/ransidble/internal/infrastructure/persistence/task",
+				"path":      r.path,
+			},
+		)
+
+		err = r.fs.MkdirAll(r.path, 0755)
+		if err != nil {
+			r.logger.Error(
+				fmt.Sprintf("%s: %s", ErrInitializingTaskRepository, err.Error()),
+				map[string]interface{}{
+					"component": "LocalTaskRepository.Initialize",
+					"package":   "github.com/apenella/ransidble/internal/infrastructure/persistence/task",
+					"path":      r.path,
+				},
+			)
+
+			return fmt.Errorf("%w: %w", ErrInitializingTaskRepository, err)
+		}
+	} else if !info.IsDir() {
+		r.logger.Error(
+			fmt.Sprintf("%s: %s", ErrInitializingTaskRepository, ErrTaskRepositoryPathIsNotADirectory),
+			map[string]interface{}{
+				"component": "LocalTaskRepository.Initialize",
+				"package":   "github.com/apenella/ransidble/internal/infrastructure/persistence/task",
+				"path":      r.path,
+			},
+		)
+
+		return fmt.Errorf("%w: %w", ErrInitializingTaskRepository, ErrTaskRepositoryPathIsNotADirectory)
+	}
+
+	store, err := r.load()
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInitializingTaskRepository, err)
+	}
+
+	for id, task := range store {
+		if task.IsFinished() {
+			continue
+		}
+
+		status := task.GetStatus()
+		task.Failed(fmt.Sprintf("%s while it was %s", ErrTaskInterrupted, status))
+
+		r.logger.Info(
+			"Task interrupted by the server stop set to FAILED",
+			map[string]interface{}{
+				"component": "LocalTaskRepository.Initialize",
+				"package":   "github.com/apenella/ransidble/internal/infrastructure/persistence/task",
+				"status":    status,
+				"task_id":   id,
+			},
+		)
+
+		err = r.write(id, task)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrInitializingTaskRepository, err)
+		}
+	}
+
+	r.store = store
+
+	return nil
+}
+
+// Find returns a task by id
+func (r *LocalTaskRepository) Find(id string) (*entity.Task, error) {
+
+	if r == nil || r.store == nil {
+		r.logger.Error(
+			ErrTaskNotInitializedStorage.Error(),
+			map[string]interface{}{
+				"component": "LocalTaskRepository.Find",
+				"package":   "github.com/apenella/ransidble/internal/infrastructure/persistence/task",
+				"task_id":   id,
+			},
+		)
+
+		return nil, ErrTaskNotInitializedStorage
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	task, ok := r.store[id]
+	if !ok {
+		r.logger.Error(
+			ErrTaskNotFound.Error(),
+			map[string]interface{}{
+				"component": "LocalTaskRepository.Find",
+				"package":   "github.com/apenella/ransidble/internal/infrastructure/persistence/task",
+				"task_id":   id,
+			},
+		)
+
+		return nil, ErrTaskNotFound
+	}
+
+	return task, nil
+}
+
+// FindAll returns all tasks sorted by creation time
+func (r *LocalTaskRepository) FindAll() ([]*entity.Task, error) {
+
+	if r == nil || r.store == nil {
+		r.logger.Error(
+			ErrTaskNotInitializedStorage.Error(),
+			map[string]interface{}{
+				"component": "LocalTaskRepository.FindAll",
+				"package":   "github.com/apenella/ransidble/internal/infrastructure/persistence/task",
+			},
+		)
+
+		return nil, ErrTaskNotInitializedStorage
+	}
+
+	tasks := r.list()
+
+	query := &entity.TaskQuery{
+		Order:  entity.TaskSortOrderAsc,
+		SortBy: entity.TaskSortByCreatedAt,
+	}
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return query.Less(tasks[i], tasks[j])
+	})
+
+	return tasks, nil
+}
+
+// Search returns the page of tasks that fulfills the query
+func (r *LocalTaskRepository) Search(query *entity.TaskQuery) (*entity.TaskPage, error) {
+
+	if r == nil || r.store == nil {
+		r.logger.Error(
+			ErrTaskNotInitializedStorage.Error(),
+			map[string]interface{}{
+				"component": "LocalTaskRepository.Search",
+				"package":   "github.com/apenella/ransidble/internal/infrastructure/persistence/task",
+			},
+		)
+
+		return nil, ErrTaskNotInitializedStorage
+	}
+
+	if query == nil {
+		query = entity.NewTaskQuery()
+	}
+
+	return query.Apply(r.list())
+}
+
+// Remove removes a task by id
+func (r *LocalTaskRepository) Remove(id string) error {
+
+	if r == nil || r.store == nil {
+		r.logger.Error(
+			ErrTaskNotInitializedStorage.Error(),
+			map[string]interface{}{
+				"component": "LocalTaskRepository.Remove",
+				"package":   "github.com/apenella/ransidble/internal/infrastructure/persistence/task",
+				"task_id":   id,
+			},
+		)
+
+		return ErrTaskNotInitializedStorage
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	_, ok := r.store[id]
+	if !ok {
+		r.logger.Error(
+			ErrTaskNotFound.Error(),
+			map[string]interface{}{
+				"component": "LocalTaskRepository.Remove",
+				"package":   "github.com/apenella/ransidble/internal/infrastructure/persistence/task",
+				"task_id":   id,
+			},
+		)
+
+		return ErrTaskNotFound
+	}
+
+	err := r.fs.Remove(r.recordPath(id))
+	if err != nil && !os.IsNotExist(err) {
+		r.logger.Error(
+			fmt.Sprintf("%s: %s", ErrRemovingTaskRecord, err.Error()),
+			map[string]interface{}{
+				"component": "LocalTaskRepository.Remove",
+				"package":   "github.com/apenella/ransidble/internal/infrastructure/persistence/task",
+				"task_id":   id,
+			},
+		)
+
+		return fmt.Errorf("%w: %w", ErrRemovingTaskRecord, err)
+	}
+
+	delete(r.store, id)
+
+	r.logger.Debug(
+		"Task removed",
+		map[string]interface{}{
+			"component": "LocalTaskRepository.Remove",
+			"package":   "github.com/apenella/ransidble/internal/infrastructure/persistence/task",
+			"task_id":   id,
+		},
+	)
+
+	return nil
+}
+
+// SafeStore stores a task and return an error if the task already exists
+func (r *LocalTaskRepository) SafeStore(id string, task *entity.Task) error {
+
+	if r == nil || r.store == nil {
+		r.logger.Error(
+			ErrTaskNotInitializedStorage.Error(),
+			map[string]interface{}{
+				"component": "LocalTaskRepository.SafeStore",
+				"package":   "github.com/apenella/ransidble/internal/infrastructure/persistence/task",
+				"task_id":   id,
+			},
+		)
+
+		return ErrTaskNotInitializedStorage
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	_, ok := r.store[id]
+	if ok {
+		r.logger.Error(
+			ErrTaskAlreadyExists.Error(),
+			map[string]interface{}{
+				"component": "LocalTaskRepository.SafeStore",
+				"package":   "github.com/apenella/ransidble/internal/infrastructure/persistence/task",
+				"task_id":   id,
+			},
+		)
+
+		return ErrTaskAlreadyExists
+	}
+
+	return r.save(id, task, "LocalTaskRepository.SafeStore")
+}
+
+// Store stores a task
+func (r *LocalTaskRepository) Store(id string, task *entity.Task) error {
+
+	if r == nil || r.store == nil {
+		r.logger.Error(
+			ErrTaskNotInitializedStorage.Error(),
+			map[string]interface{}{
+				"component": "LocalTaskRepository.Store",
+				"package":   "github.com/apenella/ransidble/internal/infrastructure/persistence/task",
+				"task_id":   id,
+			},
+		)
+
+		return ErrTaskNotInitializedStorage
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.save(id, task, "LocalTaskRepository.Store")
+}
+
+// Update updates a task
+func (r *LocalTaskRepository) Update(id string, task *entity.Task) error {
+
+	if r == nil || r.store == nil {
+		r.logger.Error(
+			ErrTaskNotInitializedStorage.Error(),
+			map[string]interface{}{
+				"component": "LocalTaskRepository.Update",
+				"package":   "github.com/apenella/ransidble/internal/infrastructure/persistence/task",
+				"task_id":   id,
+			},
+		)
+
+		return ErrTaskNotInitializedStorage
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	_, ok := r.store[id]
+	if !ok {
+		r.logger.Error(
+			ErrTaskNotFound.Error(),
+			map[string]interface{}{
+				"component": "LocalTaskRepository.Update",
+				"package":   "github.com/apenella/ransidble/internal/infrastructure/persistence/task",
+				"task_id":   id,
+			},
+		)
+
+		return ErrTaskNotFound
+	}
+
+	return r.save(id, task, "LocalTaskRepository.Update")
+}
+
+// save writes the task record and keeps the task in memory. The caller must hold the repository lock
+func (r *LocalTaskRepository) save(id string, task *entity.Task, component string) error {
+
+	err := r.write(id, task)
+	if err != nil {
+		r.logger.Error(
+			err.Error(),
+			map[string]interface{}{
+				"component": component,
+				"package":   "github.com/apenella/ransidble/internal/infrastructure/persistence/task",
+				"task_id":   id,
+			},
+		)
+
+		return err
+	}
+
+	r.store[id] = task
+
+	r.logger.Debug(
+		"Task stored",
+		map[string]interface{}{
+			"component": component,
+			"package":   "github.com/apenella/ransidble/internal/infrastructure/persistence/task",
+			"task_id":   id,
+		},
+	)
+
+	return nil
+}
+
+// list returns the tasks kept in memory
+func (r *LocalTaskRepository) list() []*entity.Task {
+	tasks := []*entity.Task{}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, task := range r.store {
+		tasks = append(tasks, task)
+	}
+
+	return tasks
+}
+
+// load reads the task records from the repository path. The records that can not be read are skipped
+func (r *LocalTaskRepository) load() (map[string]*entity.Task, error) {
+
+	store := make(map[string]*entity.Task)
+
+	entries, err := afero.ReadDir(r.fs, r.path)
+	if err != nil {
+		r.logger.Error(
+			fmt.Sprintf("%s: %s", ErrReadingTaskRecord, err.Error()),
+			map[string]interface{}{
+				"component": "LocalTaskRepository.load",
+				"package":   "github.com/apenella/ransidble/internal/infrastructure/persistence/task",
+				"path":      r.path,
+			},
+		)
+
+		return nil, fmt.Errorf("%w: %w", ErrReadingTaskRecord, err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != taskRecordExtension {
+			continue
+		}
+
+		id := strings.TrimSuffix(entry.Name(), taskRecordExtension)
+
+		task, err := r.read(id)
+		if err != nil {
+			r.logger.Error(
+				err.Error(),
+				map[string]interface{}{
+					"component": "LocalTaskRepository.load",
+					"package":   "github.com/apenella/ransidble/internal/infrastructure/persistence/task",
+					"task_id":   id,
+				},
+			)
+			continue
+		}
+
+		store[id] = task
+	}
+
+	return store, nil
+}
+
+// read reads a task record
+func (r *LocalTaskRepository) read(id string) (*entity.Task, error) {
+
+	data, err := afero.ReadFile(r.fs, r.recordPath(id))
+	if err != nil {
+		return nil, fmt.Errorf("%w %s: %w", ErrReadingTaskRecord, id, err)
+	}
+
+	task := &entity.Task{}
+	err = json.Unmarshal(data, task)
+	if err != nil {
+		return nil, fmt.Errorf("%w %s: %w", ErrReadingTaskRecord, id, err)
+	}
+
+	return task, nil
+}
+
+// write writes a task record. The record is written into a temporary file which then replaces the record, to not leave a partial record when the server stops while writing it
+func (r *LocalTaskRepository) write(id string, task *entity.Task) error {
+
+	if task == nil {
+		return fmt.Errorf("%w %s: task not provided", ErrWritingTaskRecord, id)
+	}
+
+	data, err := json.Marshal(task)
+	if err != nil {
+		return fmt.Errorf("%w %s: %w", ErrWritingTaskRecord, id, err)
+	}
+
+	temporaryPath := r.recordPath(id) + taskRecordTemporaryExtension
+
+	err = afero.WriteFile(r.fs, temporaryPath, data, 0644)
+	if err != nil {
+		return fmt.Errorf("%w %s: %w", ErrWritingTaskRecord, id, err)
+	}
+
+	err = r.fs.Rename(temporaryPath, r.recordPath(id))
+	if err != nil {
+		return fmt.Errorf("%w %s: %w", ErrWritingTaskRecord, id, err)
+	}
+
+	return nil
+}
+
+// recordPath returns the path of the task record
+func (r *LocalTaskRepository) recordPath(id string) string {
+	return filepath.Join(r.path, id+taskRecordExtension)
+}
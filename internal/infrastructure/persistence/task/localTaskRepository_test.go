@@ -0,0 +1,194 @@
+package persistence
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	"github.com/apenella/ransidble/internal/infrastructure/logger"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeTestingTaskRecord(t *testing.T, fs afero.Fs, path string, task *entity.Task) {
+	data, err := json.Marshal(task)
+	assert.NoError(t, err)
+	err = afero.WriteFile(fs, path+"/"+task.ID+".json", data, 0644)
+	assert.NoError(t, err)
+}
+
+func TestNewLocalTaskRepository(t *testing.T) {
+	t.Log("Testing creating a new LocalTaskRepository")
+	t.Parallel()
+
+	fs := afero.NewMemMapFs()
+	log := logger.NewFakeLogger()
+
+	repository := NewLocalTaskRepository(fs, "tasks", log)
+
+	assert.Equal(t, &LocalTaskRepository{fs: fs, path: "tasks", logger: log}, repository)
+}
+
+// TestLocalTaskRepository_Initialize tests the Initialize method
+func TestLocalTaskRepository_Initialize(t *testing.T) {
+	tests := []struct {
+		desc         string
+		repository   *LocalTaskRepository
+		arrangeFunc  func(*testing.T, *LocalTaskRepository)
+		assertFunc   func(*testing.T, *LocalTaskRepository)
+		err          error
+		errContained error
+	}{
+		{
+			desc:       "Testing initializing a local task repository creating its path",
+			repository: NewLocalTaskRepository(afero.NewMemMapFs(), "tasks", logger.NewFakeLogger()),
+			assertFunc: func(t *testing.T, r *LocalTaskRepository) {
+				exists, err := afero.DirExists(r.fs, "tasks")
+				assert.NoError(t, err)
+				assert.True(t, exists)
+				assert.Empty(t, r.store)
+			},
+		},
+		{
+			desc:       "Testing initializing a local task repository loading the persisted tasks and setting the interrupted tasks to FAILED",
+			repository: NewLocalTaskRepository(afero.NewMemMapFs(), "tasks", logger.NewFakeLogger()),
+			arrangeFunc: func(t *testing.T, r *LocalTaskRepository) {
+				writeTestingTaskRecord(t, r.fs, "tasks", &entity.Task{ID: "task-success", Command: entity.AnsiblePlaybookCommand, Status: entity.SUCCESS})
+				writeTestingTaskRecord(t, r.fs, "tasks", &entity.Task{ID: "task-running", Command: entity.AnsiblePlaybookCommand, Status: entity.RUNNING})
+				writeTestingTaskRecord(t, r.fs, "tasks", &entity.Task{ID: "task-accepted", Command: entity.AnsiblePlaybookCommand, Status: entity.ACCEPTED})
+				err := afero.WriteFile(r.fs, "tasks/invalid.json", []byte("{"), 0644)
+				assert.NoError(t, err)
+				err = afero.WriteFile(r.fs, "tasks/README", []byte("not a record"), 0644)
+				assert.NoError(t, err)
+			},
+			assertFunc: func(t *testing.T, r *LocalTaskRepository) {
+				assert.Len(t, r.store, 3)
+				assert.Equal(t, entity.SUCCESS, r.store["task-success"].Status)
+
+				for id, status := range map[string]string{"task-running": entity.RUNNING, "task-accepted": entity.ACCEPTED} {
+					assert.Equal(t, entity.FAILED, r.store[id].Status)
+					assert.Equal(t, ErrTaskInterrupted.Error()+" while it was "+status, r.store[id].ErrorMessage)
+					assert.NotEmpty(t, r.store[id].CompletedAt)
+
+					persisted, err := r.read(id)
+					assert.NoError(t, err)
+					assert.Equal(t, entity.FAILED, persisted.Status)
+				}
+			},
+		},
+		{
+			desc:       "Testing error initializing a local task repository when the path is not a directory",
+			repository: NewLocalTaskRepository(afero.NewMemMapFs(), "tasks", logger.NewFakeLogger()),
+			arrangeFunc: func(t *testing.T, r *LocalTaskRepository) {
+				err := afero.WriteFile(r.fs, "tasks", []byte{}, 0644)
+				assert.NoError(t, err)
+			},
+			errContained: ErrTaskRepositoryPathIsNotADirectory,
+		},
+		{
+			desc:       "Testing error initializing a local task repository without filesystem",
+			repository: NewLocalTaskRepository(nil, "tasks", logger.NewFakeLogger()),
+			err:        ErrTaskRepositoryFilesystemNotInitialized,
+		},
+		{
+			desc:       "Testing error initializing a local task repository without path",
+			repository: NewLocalTaskRepository(afero.NewMemMapFs(), "", logger.NewFakeLogger()),
+			err:        ErrTaskRepositoryPathNotInitialized,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			if test.arrangeFunc != nil {
+				test.arrangeFunc(t, test.repository)
+			}
+
+			err := test.repository.Initialize()
+			switch {
+			case test.err != nil:
+				assert.Equal(t, test.err, err)
+			case test.errContained != nil:
+				assert.ErrorIs(t, err, test.errContained)
+			default:
+				assert.NoError(t, err)
+				test.assertFunc(t, test.repository)
+			}
+		})
+	}
+}
+
+// TestLocalTaskRepository_Persistence tests the tasks are kept after the repository is initialized again
+func TestLocalTaskRepository_Persistence(t *testing.T) {
+	t.Log("Testing the local task repository persists the tasks")
+	t.Parallel()
+
+	fs := afero.NewMemMapFs()
+	parameters := &entity.AnsiblePlaybookParameters{Playbooks: []string{"site.yml"}, Inventory: "127.0.0.1,"}
+
+	repository := NewLocalTaskRepository(fs, "tasks", logger.NewFakeLogger())
+	err := repository.Initialize()
+	assert.NoError(t, err)
+
+	task := &entity.Task{ID: "task-1", Command: entity.AnsiblePlaybookCommand, CreatedAt: "2026-01-01T10:00:00Z", Parameters: parameters, ProjectID: "project-1", Status: entity.PENDING}
+	err = repository.SafeStore(task.ID, task)
+	assert.NoError(t, err)
+	assert.Equal(t, ErrTaskAlreadyExists, repository.SafeStore(task.ID, task))
+
+	found, err := repository.Find(task.ID)
+	assert.NoError(t, err)
+	assert.Same(t, task, found)
+
+	task.Success()
+	err = repository.Update(task.ID, task)
+	assert.NoError(t, err)
+	assert.Equal(t, ErrTaskNotFound, repository.Update("task-2", task))
+
+	other := &entity.Task{ID: "task-2", Command: entity.AnsiblePlaybookCommand, CreatedAt: "2026-01-01T11:00:00Z", Status: entity.FAILED}
+	err = repository.Store(other.ID, other)
+	assert.NoError(t, err)
+
+	restarted := NewLocalTaskRepository(fs, "tasks", logger.NewFakeLogger())
+	err = restarted.Initialize()
+	assert.NoError(t, err)
+
+	tasks, err := restarted.FindAll()
+	assert.NoError(t, err)
+	assert.Equal(t, []*entity.Task{task, other}, tasks)
+
+	page, err := restarted.Search(&entity.TaskQuery{Order: entity.TaskSortOrderAsc, SortBy: entity.TaskSortByCreatedAt, Status: []string{entity.SUCCESS}})
+	assert.NoError(t, err)
+	assert.Equal(t, []*entity.Task{task}, page.Tasks)
+
+	err = restarted.Remove(task.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, ErrTaskNotFound, restarted.Remove(task.ID))
+
+	exists, err := afero.Exists(fs, "tasks/task-1.json")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	_, err = restarted.Find(task.ID)
+	assert.Equal(t, ErrTaskNotFound, err)
+}
+
+// TestLocalTaskRepository_NotInitialized tests the methods of a repository that is not initialized
+func TestLocalTaskRepository_NotInitialized(t *testing.T) {
+	t.Log("Testing the local task repository methods when it is not initialized")
+	t.Parallel()
+
+	repository := NewLocalTaskRepository(afero.NewMemMapFs(), "tasks", logger.NewFakeLogger())
+
+	_, err := repository.Find("task-1")
+	assert.Equal(t, ErrTaskNotInitializedStorage, err)
+	_, err = repository.FindAll()
+	assert.Equal(t, ErrTaskNotInitializedStorage, err)
+	_, err = repository.Search(nil)
+	assert.Equal(t, ErrTaskNotInitializedStorage, err)
+	assert.Equal(t, ErrTaskNotInitializedStorage, repository.Remove("task-1"))
+	assert.Equal(t, ErrTaskNotInitializedStorage, repository.SafeStore("task-1", &entity.Task{}))
+	assert.Equal(t, ErrTaskNotInitializedStorage, repository.Store("task-1", &entity.Task{}))
+	assert.Equal(t, ErrTaskNotInitializedStorage, repository.Update("task-1", &entity.Task{}))
+}
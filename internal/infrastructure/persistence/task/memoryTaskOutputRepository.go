@@ -0,0 +1,153 @@
+package persistence
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/apenella/ransidble/internal/domain/ports/repository"
+)
+
+// TruncatedOutputMarker is appended to the output of a task when it exceeds the maximum output size, and the output written afterwards is discarded
+const TruncatedOutputMarker = "\n[output truncated: maximum output size exceeded]\n"
+
+var (
+	// ErrTaskOutputNotInitializedStorage is returned when the task output storage is not initialized
+	ErrTaskOutputNotInitializedStorage = fmt.Errorf("task output storage not initialized")
+)
+
+// MemoryTaskOutputRepository struct to store the tasks output in memory
+type MemoryTaskOutputRepository struct {
+	store map[string][]byte
+	// maxSize is the maximum size of the output kept for each task. Zero means no maximum
+	maxSize int64
+	mutex   sync.RWMutex
+	logger  repository.Logger
+}
+
+// NewMemoryTaskOutputRepository creates a new MemoryTaskOutputRepository
+func NewMemoryTaskOutputRepository(logger repository.Logger) *MemoryTaskOutputRepository {
+	return &MemoryTaskOutputRepository{
+		store:  make(map[string][]byte),
+		logger: logger,
+	}
+}
+
+// WithMaxSize sets the maximum size of the output kept for each task. Zero means no maximum
+func (m *MemoryTaskOutputRepository) WithMaxSize(maxSize int64) *MemoryTaskOutputRepository {
+	m.maxSize = maxSize
+	return m
+}
+
+// Append appends data to the output of a task. Once the output reaches the maximum size, the data exceeding it is discarded and the truncated output marker is appended, so the output already read by the clients is never changed
+func (m *MemoryTaskOutputRepository) Append(id string, data []byte) error {
+
+	if m == nil || m.store == nil {
+		m.logger.Error(
+			ErrTaskOutputNotInitializedStorage.Error(),
+			map[string]interface{}{
+				"component": "MemoryTaskOutputRepository.Append",
+				"package":   "github.com/apenella/ransidble/internal/infrastructure/persistence/task",
+				"task_id":   id,
+			},
+		)
+
+		return ErrTaskOutputNotInitializedStorage
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	output := m.store[id]
+	if m.maxSize <= 0 || int64(len(output))+int64(len(data)) <= m.maxSize {
+		m.store[id] = append(output, data...)
+		return nil
+	}
+
+	// the output is only longer than the maximum size once the marker has been appended
+	if int64(len(output)) > m.maxSize {
+		return nil
+	}
+
+	remaining := m.maxSize - int64(len(output))
+	output = append(output, data[:remaining]...)
+	m.store[id] = append(output, TruncatedOutputMarker...)
+
+	m.logger.Warn(
+		"Task output truncated",
+		map[string]interface{}{
+			"component": "MemoryTaskOutputRepository.Append",
+			"max_size":  m.maxSize,
+			"package":   "github.com/apenella/ransidble/internal/infrastructure/persistence/task",
+			"task_id":   id,
+		},
+	)
+
+	return nil
+}
+
+// Find returns the output of a task. A task that has not written any output yet has an empty output
+func (m *MemoryTaskOutputRepository) Find(id string) ([]byte, error) {
+
+	if m == nil || m.store == nil {
+		m.logger.Error(
+			ErrTaskOutputNotInitializedStorage.Error(),
+			map[string]interface{}{
+				"component": "MemoryTaskOutputRepository.Find",
+				"package":   "github.com/apenella/ransidble/internal/infrastructure/persistence/task",
+				"task_id":   id,
+			},
+		)
+
+		return nil, ErrTaskOutputNotInitializedStorage
+	}
+
+	m.logger.Debug(
+		"Finding task output",
+		map[string]interface{}{
+			"component": "MemoryTaskOutputRepository.Find",
+			"package":   "github.com/apenella/ransidble/internal/infrastructure/persistence/task",
+			"task_id":   id,
+		},
+	)
+
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	// a copy is returned to prevent the caller from reading the output while it is being appended
+	output := make([]byte, len(m.store[id]))
+	copy(output, m.store[id])
+
+	return output, nil
+}
+
+// Remove removes the output of a task
+func (m *MemoryTaskOutputRepository) Remove(id string) error {
+
+	if m == nil || m.store == nil {
+		m.logger.Error(
+			ErrTaskOutputNotInitializedStorage.Error(),
+			map[string]interface{}{
+				"component": "MemoryTaskOutputRepository.Remove",
+				"package":   "github.com/apenella/ransidble/internal/infrastructure/persistence/task",
+				"task_id":   id,
+			},
+		)
+
+		return ErrTaskOutputNotInitializedStorage
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	delete(m.store, id)
+
+	m.logger.Debug(
+		"Task output removed",
+		map[string]interface{}{
+			"component": "MemoryTaskOutputRepository.Remove",
+			"package":   "github.com/apenella/ransidble/internal/infrastructure/persistence/task",
+			"task_id":   id,
+		},
+	)
+
+	return nil
+}
@@ -0,0 +1,238 @@
+package persistence
+
+import (
+	"testing"
+
+	"github.com/apenella/ransidble/internal/infrastructure/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewMemoryTaskOutputRepository(t *testing.T) {
+
+	t.Run("Testing creating a new MemoryTaskOutputRepository", func(t *testing.T) {
+		t.Parallel()
+		t.Log("Testing NewMemoryTaskOutputRepository")
+
+		persistence := NewMemoryTaskOutputRepository(
+			logger.NewFakeLogger(),
+		)
+
+		assert.NotEmpty(t, persistence)
+		assert.IsType(t, &MemoryTaskOutputRepository{}, persistence)
+		assert.Equal(t, make(map[string][]byte), persistence.store)
+	})
+}
+
+// TestMemoryTaskOutputRepository_Append tests the Append method
+func TestMemoryTaskOutputRepository_Append(t *testing.T) {
+	tests := []struct {
+		desc        string
+		id          string
+		data        []byte
+		persistence *MemoryTaskOutputRepository
+		expected    map[string][]byte
+		err         error
+	}{
+		{
+			desc: "Testing appending output to a task without previous output",
+			id:   "task1",
+			data: []byte("line1\n"),
+			persistence: &MemoryTaskOutputRepository{
+				store:  map[string][]byte{},
+				logger: logger.NewFakeLogger(),
+			},
+			expected: map[string][]byte{
+				"task1": []byte("line1\n"),
+			},
+		},
+		{
+			desc: "Testing appending output to a task with previous output",
+			id:   "task1",
+			data: []byte("line2\n"),
+			persistence: &MemoryTaskOutputRepository{
+				store: map[string][]byte{
+					"task1": []byte("line1\n"),
+				},
+				logger: logger.NewFakeLogger(),
+			},
+			expected: map[string][]byte{
+				"task1": []byte("line1\nline2\n"),
+			},
+		},
+		{
+			desc: "Testing appending output to a task within the maximum output size",
+			id:   "task1",
+			data: []byte("line2\n"),
+			persistence: &MemoryTaskOutputRepository{
+				store: map[string][]byte{
+					"task1": []byte("line1\n"),
+				},
+				maxSize: 12,
+				logger:  logger.NewFakeLogger(),
+			},
+			expected: map[string][]byte{
+				"task1": []byte("line1\nline2\n"),
+			},
+		},
+		{
+			desc: "Testing appending output to a task exceeding the maximum output size truncates the output",
+			id:   "task1",
+			data: []byte("line2\n"),
+			persistence: &MemoryTaskOutputRepository{
+				store: map[string][]byte{
+					"task1": []byte("line1\n"),
+				},
+				maxSize: 9,
+				logger:  logger.NewFakeLogger(),
+			},
+			expected: map[string][]byte{
+				"task1": []byte("line1\nlin" + TruncatedOutputMarker),
+			},
+		},
+		{
+			desc: "Testing appending output to a task whose output is already truncated discards the output",
+			id:   "task1",
+			data: []byte("line3\n"),
+			persistence: &MemoryTaskOutputRepository{
+				store: map[string][]byte{
+					"task1": []byte("line1\nlin" + TruncatedOutputMarker),
+				},
+				maxSize: 9,
+				logger:  logger.NewFakeLogger(),
+			},
+			expected: map[string][]byte{
+				"task1": []byte("line1\nlin" + TruncatedOutputMarker),
+			},
+		},
+		{
+			desc: "Testing appending output error when store is not initialized",
+			id:   "task1",
+			data: []byte("line1\n"),
+			persistence: &MemoryTaskOutputRepository{
+				store:  nil,
+				logger: logger.NewFakeLogger(),
+			},
+			err: ErrTaskOutputNotInitializedStorage,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			err := test.persistence.Append(test.id, test.data)
+			if err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.Equal(t, test.expected, test.persistence.store)
+			}
+		})
+	}
+}
+
+// TestMemoryTaskOutputRepository_Find tests the Find method
+func TestMemoryTaskOutputRepository_Find(t *testing.T) {
+	tests := []struct {
+		desc        string
+		id          string
+		persistence *MemoryTaskOutputRepository
+		expected    []byte
+		err         error
+	}{
+		{
+			desc: "Testing finding the output of a task",
+			id:   "task1",
+			persistence: &MemoryTaskOutputRepository{
+				store: map[string][]byte{
+					"task1": []byte("line1\nline2\n"),
+				},
+				logger: logger.NewFakeLogger(),
+			},
+			expected: []byte("line1\nline2\n"),
+		},
+		{
+			desc: "Testing finding the output of a task without output",
+			id:   "task2",
+			persistence: &MemoryTaskOutputRepository{
+				store: map[string][]byte{
+					"task1": []byte("line1\nline2\n"),
+				},
+				logger: logger.NewFakeLogger(),
+			},
+			expected: []byte{},
+		},
+		{
+			desc: "Testing finding the output of a task error when store is not initialized",
+			id:   "task1",
+			persistence: &MemoryTaskOutputRepository{
+				store:  nil,
+				logger: logger.NewFakeLogger(),
+			},
+			err: ErrTaskOutputNotInitializedStorage,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			output, err := test.persistence.Find(test.id)
+			if err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.Equal(t, test.expected, output)
+			}
+		})
+	}
+}
+
+// TestMemoryTaskOutputRepository_Remove tests the Remove method
+func TestMemoryTaskOutputRepository_Remove(t *testing.T) {
+	tests := []struct {
+		desc        string
+		id          string
+		persistence *MemoryTaskOutputRepository
+		expected    map[string][]byte
+		err         error
+	}{
+		{
+			desc: "Testing removing the output of a task",
+			id:   "task1",
+			persistence: &MemoryTaskOutputRepository{
+				store: map[string][]byte{
+					"task1": []byte("line1\n"),
+					"task2": []byte("line1\n"),
+				},
+				logger: logger.NewFakeLogger(),
+			},
+			expected: map[string][]byte{
+				"task2": []byte("line1\n"),
+			},
+		},
+		{
+			desc: "Testing removing the output of a task error when store is not initialized",
+			id:   "task1",
+			persistence: &MemoryTaskOutputRepository{
+				store:  nil,
+				logger: logger.NewFakeLogger(),
+			},
+			err: ErrTaskOutputNotInitializedStorage,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			err := test.persistence.Remove(test.id)
+			if err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.Equal(t, test.expected, test.persistence.store)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package persistence
 
 import (
 	"fmt"
+	"sort"
 	"sync"
 
 	"github.com/apenella/ransidble/internal/domain/core/entity"
@@ -77,7 +78,7 @@ func (m *MemoryTaskRepository) Find(id string) (*entity.Task, error) {
 	return task, nil
 }
 
-// FindAll returns all tasks
+// FindAll returns all tasks sorted by creation time
 func (m *MemoryTaskRepository) FindAll() ([]*entity.Task, error) {
 	tasks := []*entity.Task{}
 
@@ -108,9 +109,54 @@ func (m *MemoryTaskRepository) FindAll() ([]*entity.Task, error) {
 		tasks = append(tasks, task)
 	}
 
+	query := &entity.TaskQuery{
+		Order:  entity.TaskSortOrderAsc,
+		SortBy: entity.TaskSortByCreatedAt,
+	}
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return query.Less(tasks[i], tasks[j])
+	})
+
 	return tasks, nil
 }
 
+// Search returns the page of tasks that fulfills the query
+func (m *MemoryTaskRepository) Search(query *entity.TaskQuery) (*entity.TaskPage, error) {
+	tasks := []*entity.Task{}
+
+	if m.store == nil || m == nil {
+		m.logger.Error(
+			ErrTaskNotInitializedStorage.Error(),
+			map[string]interface{}{
+				"component": "MemoryTaskRepository.Search",
+				"package":   "github.com/apenella/ransidble/internal/infrastructure/persistence/task",
+			},
+		)
+
+		return nil, ErrTaskNotInitializedStorage
+	}
+
+	if query == nil {
+		query = entity.NewTaskQuery()
+	}
+
+	m.logger.Debug(
+		"Searching tasks",
+		map[string]interface{}{
+			"component": "MemoryTaskRepository.Search",
+			"package":   "github.com/apenella/ransidble/internal/infrastructure/persistence/task",
+		},
+	)
+
+	m.mutex.Lock()
+	for _, task := range m.store {
+		tasks = append(tasks, task)
+	}
+	m.mutex.Unlock()
+
+	return query.Apply(tasks)
+}
+
 // Remove removes a task by id
 func (m *MemoryTaskRepository) Remove(id string) error {
 
@@ -127,6 +173,9 @@ func (m *MemoryTaskRepository) Remove(id string) error {
 		return ErrTaskNotInitializedStorage
 	}
 
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
 	_, ok := m.store[id]
 	if !ok {
 		m.logger.Error(
@@ -141,8 +190,6 @@ func (m *MemoryTaskRepository) Remove(id string) error {
 		return ErrTaskNotFound
 	}
 
-	m.mutex.Lock()
-	defer m.mutex.Unlock()
 	delete(m.store, id)
 
 	m.logger.Debug(
@@ -252,6 +299,9 @@ func (m *MemoryTaskRepository) Update(id string, task *entity.Task) error {
 		return ErrTaskNotInitializedStorage
 	}
 
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
 	_, ok := m.store[id]
 	if !ok {
 		m.logger.Error(
@@ -266,8 +316,6 @@ func (m *MemoryTaskRepository) Update(id string, task *entity.Task) error {
 		return ErrTaskNotFound
 	}
 
-	m.mutex.Lock()
-	defer m.mutex.Unlock()
 	m.store[id] = task
 
 	m.logger.Debug(
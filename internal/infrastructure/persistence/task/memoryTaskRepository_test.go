@@ -97,13 +97,18 @@ func TestMemoryTaskRepository_FindAll(t *testing.T) {
 			desc: "Testing find all tasks in memory persistence",
 			persistence: &MemoryTaskRepository{
 				store: map[string]*entity.Task{
-					"task1": {ID: "task1"},
-					"task2": {ID: "task2"},
+					"task2": {ID: "task2", CreatedAt: "2026-01-01T10:00:00Z"},
+					"task1": {ID: "task1", CreatedAt: "2026-01-01T10:00:00Z"},
+					"task3": {ID: "task3", CreatedAt: "2025-12-31T10:00:00Z"},
 				},
 				logger: logger.NewFakeLogger(),
 			},
-			expected: []*entity.Task{{ID: "task1"}, {ID: "task2"}},
-			err:      nil,
+			expected: []*entity.Task{
+				{ID: "task3", CreatedAt: "2025-12-31T10:00:00Z"},
+				{ID: "task1", CreatedAt: "2026-01-01T10:00:00Z"},
+				{ID: "task2", CreatedAt: "2026-01-01T10:00:00Z"},
+			},
+			err: nil,
 		},
 		{
 			desc: "Testing finding all tasks error when store is not initialized",
@@ -124,7 +129,65 @@ func TestMemoryTaskRepository_FindAll(t *testing.T) {
 			if err != nil {
 				assert.Equal(t, test.err, err)
 			} else {
-				assert.ElementsMatch(t, test.expected, tasks)
+				assert.Equal(t, test.expected, tasks)
+			}
+		})
+	}
+}
+
+// TestMemoryTaskRepository_Search tests the Search method
+func TestMemoryTaskRepository_Search(t *testing.T) {
+	tests := []struct {
+		desc        string
+		persistence *MemoryTaskRepository
+		query       *entity.TaskQuery
+		expected    *entity.TaskPage
+		err         error
+	}{
+		{
+			desc: "Testing searching tasks in memory persistence",
+			persistence: &MemoryTaskRepository{
+				store: map[string]*entity.Task{
+					"task1": {ID: "task1", ProjectID: "project-1", Status: entity.SUCCESS, CreatedAt: "2026-01-01T10:00:00Z"},
+					"task2": {ID: "task2", ProjectID: "project-2", Status: entity.SUCCESS, CreatedAt: "2026-01-01T10:01:00Z"},
+					"task3": {ID: "task3", ProjectID: "project-1", Status: entity.FAILED, CreatedAt: "2026-01-01T10:02:00Z"},
+				},
+				logger: logger.NewFakeLogger(),
+			},
+			query: &entity.TaskQuery{
+				Order:     entity.TaskSortOrderDesc,
+				ProjectID: "project-1",
+				SortBy:    entity.TaskSortByCreatedAt,
+			},
+			expected: &entity.TaskPage{
+				Tasks: []*entity.Task{
+					{ID: "task3", ProjectID: "project-1", Status: entity.FAILED, CreatedAt: "2026-01-01T10:02:00Z"},
+					{ID: "task1", ProjectID: "project-1", Status: entity.SUCCESS, CreatedAt: "2026-01-01T10:00:00Z"},
+				},
+			},
+			err: nil,
+		},
+		{
+			desc: "Testing searching tasks error when store is not initialized",
+			persistence: &MemoryTaskRepository{
+				store:  nil,
+				logger: logger.NewFakeLogger(),
+			},
+			query:    entity.NewTaskQuery(),
+			expected: nil,
+			err:      ErrTaskNotInitializedStorage,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+			page, err := test.persistence.Search(test.query)
+			if err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.Equal(t, test.expected, page)
 			}
 		})
 	}
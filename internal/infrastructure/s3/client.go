@@ -0,0 +1,291 @@
+package s3
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/apenella/ransidble/internal/domain/ports/repository"
+)
+
+const (
+	// DefaultRegion is the region used to sign the requests when no region is configured
+	DefaultRegion = "us-east-1"
+	// DefaultTimeout is the time limit of a request, including the transfer of the object, when no timeout is configured
+	DefaultTimeout = 10 * time.Minute
+
+	// errorCodeNoSuchBucket is the error code returned when the bucket does not exist
+	errorCodeNoSuchBucket = "NoSuchBucket"
+)
+
+var (
+	// ErrBucketNotProvided is returned when the bucket is not provided
+	ErrBucketNotProvided = fmt.Errorf("bucket not provided")
+	// ErrCreatingRequest is returned when the request to the object storage can not be created
+	ErrCreatingRequest = fmt.Errorf("error creating object storage request")
+	// ErrDeletingObject is returned when an object can not be deleted
+	ErrDeletingObject = fmt.Errorf("error deleting object")
+	// ErrGettingObject is returned when an object can not be got
+	ErrGettingObject = fmt.Errorf("error getting object")
+	// ErrInvalidEndpoint is returned when the endpoint is not a valid URL
+	ErrInvalidEndpoint = fmt.Errorf("invalid object storage endpoint")
+	// ErrObjectKeyNotProvided is returned when the object key is not provided
+	ErrObjectKeyNotProvided = fmt.Errorf("object key not provided")
+	// ErrObjectNotFound is returned when an object does not exist
+	ErrObjectNotFound = fmt.Errorf("object not found")
+	// ErrPuttingObject is returned when an object can not be put
+	ErrPuttingObject = fmt.Errorf("error putting object")
+	// ErrReaderNotProvided is returned when the reader is not provided
+	ErrReaderNotProvided = fmt.Errorf("reader not provided")
+)
+
+// Config represents the configuration to access an S3-compatible object storage
+type Config struct {
+	// AccessKeyID is the access key used to sign the requests. The requests are not signed when it is not provided
+	AccessKeyID string
+	// Bucket is the bucket where the objects are stored
+	Bucket string
+	// Endpoint is the URL of the object storage. The AWS endpoint of the region is used when it is not provided
+	Endpoint string
+	// Prefix is prepended to the keys of the objects, so several applications can share a bucket
+	Prefix string
+	// PathStyle addresses the bucket on the request path instead of on the host name, as required by most S3-compatible object storages
+	PathStyle bool
+	// Region is the region used to sign the requests. DefaultRegion is used when it is not provided
+	Region string
+	// SecretAccessKey is the secret key used to sign the requests
+	SecretAccessKey string
+	// SessionToken is the token of temporary credentials
+	SessionToken string
+	// Timeout is the time limit of a request, including the transfer of the object. DefaultTimeout is used when it is not provided. It only applies to the HTTP client created by NewClient
+	Timeout time.Duration
+}
+
+// Client is a client of an S3-compatible object storage. It supports the operations required to store the source code of the projects
+type Client struct {
+	config     Config
+	endpoint   *url.URL
+	httpClient *http.Client
+	now        func() time.Time
+}
+
+// Ensure Client implements the ObjectStorer interface
+var _ repository.ObjectStorer = (*Client)(nil)
+
+// NewClient creates a new Client. An HTTP client limited by the configured timeout is created when no HTTP client is provided, so a stalled object storage does not block the requests forever
+func NewClient(config Config, httpClient *http.Client) (*Client, error) {
+
+	if config.Bucket == "" {
+		return nil, ErrBucketNotProvided
+	}
+
+	if config.Region == "" {
+		config.Region = DefaultRegion
+	}
+
+	if config.Endpoint == "" {
+		config.Endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", config.Region)
+	}
+
+	endpoint, err := url.Parse(config.Endpoint)
+	if err != nil || endpoint.Scheme == "" || endpoint.Host == "" {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidEndpoint, config.Endpoint)
+	}
+
+	config.Prefix = strings.Trim(config.Prefix, "/")
+	if config.Prefix != "" {
+		config.Prefix = config.Prefix + "/"
+	}
+
+	if config.Timeout <= 0 {
+		config.Timeout = DefaultTimeout
+	}
+
+	if httpClient == nil {
+		httpClient = &http.Client{
+			Timeout: config.Timeout,
+		}
+	}
+
+	return &Client{
+		config:     config,
+		endpoint:   endpoint,
+		httpClient: httpClient,
+		now:        time.Now,
+	}, nil
+}
+
+// PutObject stores the content of the reader on the given key. The content is spooled to a temporary file to compute its length and checksum before sending it. The request is interrupted when the context is done
+func (c *Client) PutObject(ctx context.Context, key string, reader io.Reader) error {
+
+	if key == "" {
+		return ErrObjectKeyNotProvided
+	}
+
+	if reader == nil {
+		return ErrReaderNotProvided
+	}
+
+	spool, err := os.CreateTemp("", "ransidble-s3-*")
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrPuttingObject, err)
+	}
+	defer func() {
+		_ = spool.Close()
+		_ = os.Remove(spool.Name())
+	}()
+
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(spool, hash), reader)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrPuttingObject, err)
+	}
+
+	_, err = spool.Seek(0, io.SeekStart)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrPuttingObject, err)
+	}
+
+	req, err := c.newRequest(ctx, http.MethodPut, key, spool, hex.EncodeToString(hash.Sum(nil)))
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w %s: %w", ErrPuttingObject, key, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w %s: %w", ErrPuttingObject, key, readErrorResponse(res).error(res.StatusCode))
+	}
+
+	return nil
+}
+
+// GetObject returns a reader of the content stored on the given key, which must be closed by the caller. The request, including the transfer of the content, is interrupted when the context is done
+func (c *Client) GetObject(ctx context.Context, key string) (io.ReadCloser, error) {
+
+	if key == "" {
+		return nil, ErrObjectKeyNotProvided
+	}
+
+	req, err := c.newRequest(ctx, http.MethodGet, key, nil, emptyPayloadHash)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w %s: %w", ErrGettingObject, key, err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		defer res.Body.Close()
+
+		document := readErrorResponse(res)
+		// a missing bucket is a misconfiguration rather than a missing object
+		if res.StatusCode == http.StatusNotFound && document.Code != errorCodeNoSuchBucket {
+			return nil, fmt.Errorf("%w: %s", ErrObjectNotFound, key)
+		}
+
+		return nil, fmt.Errorf("%w %s: %w", ErrGettingObject, key, document.error(res.StatusCode))
+	}
+
+	return res.Body, nil
+}
+
+// DeleteObject removes the content stored on the given key. Removing a key that does not exist is not an error. The request is interrupted when the context is done
+func (c *Client) DeleteObject(ctx context.Context, key string) error {
+
+	if key == "" {
+		return ErrObjectKeyNotProvided
+	}
+
+	req, err := c.newRequest(ctx, http.MethodDelete, key, nil, emptyPayloadHash)
+	if err != nil {
+		return err
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w %s: %w", ErrDeletingObject, key, err)
+	}
+	defer res.Body.Close()
+
+	switch res.StatusCode {
+	case http.StatusOK, http.StatusNoContent:
+		return nil
+	default:
+		document := readErrorResponse(res)
+		if res.StatusCode == http.StatusNotFound && document.Code != errorCodeNoSuchBucket {
+			return nil
+		}
+		return fmt.Errorf("%w %s: %w", ErrDeletingObject, key, document.error(res.StatusCode))
+	}
+}
+
+// newRequest creates a signed request to the given object key
+func (c *Client) newRequest(ctx context.Context, method string, key string, body io.Reader, payloadHash string) (*http.Request, error) {
+
+	key = c.config.Prefix + key
+
+	objectURL := *c.endpoint
+	basePath := strings.TrimSuffix(objectURL.Path, "/")
+	if c.config.PathStyle {
+		objectURL.Path = basePath + "/" + c.config.Bucket + "/" + key
+	} else {
+		objectURL.Host = c.config.Bucket + "." + objectURL.Host
+		objectURL.Path = basePath + "/" + key
+	}
+	objectURL.RawPath = encodePath(objectURL.Path)
+
+	req, err := http.NewRequestWithContext(ctx, method, objectURL.String(), body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCreatingRequest, err)
+	}
+
+	if c.config.AccessKeyID != "" {
+		if c.config.SessionToken != "" {
+			req.Header.Set(headerSecurityToken, c.config.SessionToken)
+		}
+		signRequest(req, payloadHash, c.config.AccessKeyID, c.config.SecretAccessKey, c.config.Region, c.now())
+	}
+
+	return req, nil
+}
+
+// errorResponse represents the error document returned by the object storage
+type errorResponse struct {
+	Code    string `xml:"Code"`
+	Message string `xml:"Message"`
+}
+
+// readErrorResponse reads the error document of a response of the object storage
+func readErrorResponse(res *http.Response) *errorResponse {
+	document := &errorResponse{}
+
+	body, _ := io.ReadAll(io.LimitReader(res.Body, 64*1024))
+	_ = xml.Unmarshal(body, document)
+
+	return document
+}
+
+// error returns the error described by the error document
+func (e *errorResponse) error(status int) error {
+	if e.Code == "" {
+		return fmt.Errorf("unexpected status %d", status)
+	}
+
+	return fmt.Errorf("unexpected status %d: %s: %s", status, e.Code, e.Message)
+}
@@ -0,0 +1,243 @@
+package s3
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apenella/ransidble/test/s3server"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewClient(t *testing.T) {
+	tests := []struct {
+		desc         string
+		config       Config
+		endpoint     string
+		region       string
+		timeout      time.Duration
+		err          error
+		errContained error
+	}{
+		{
+			desc:     "Testing creating a client with the default region, endpoint and timeout",
+			config:   Config{Bucket: "projects"},
+			endpoint: "https://s3.us-east-1.amazonaws.com",
+			region:   DefaultRegion,
+			timeout:  DefaultTimeout,
+		},
+		{
+			desc:     "Testing creating a client with a custom endpoint and timeout",
+			config:   Config{Bucket: "projects", Endpoint: "http://minio:9000", Region: "eu-west-1", Timeout: time.Minute},
+			endpoint: "http://minio:9000",
+			region:   "eu-west-1",
+			timeout:  time.Minute,
+		},
+		{
+			desc:   "Testing error creating a client without bucket",
+			config: Config{},
+			err:    ErrBucketNotProvided,
+		},
+		{
+			desc:         "Testing error creating a client with an invalid endpoint",
+			config:       Config{Bucket: "projects", Endpoint: "minio:9000/path"},
+			errContained: ErrInvalidEndpoint,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			client, err := NewClient(test.config, nil)
+			switch {
+			case test.err != nil:
+				assert.Equal(t, test.err, err)
+			case test.errContained != nil:
+				assert.ErrorIs(t, err, test.errContained)
+			default:
+				assert.NoError(t, err)
+				assert.Equal(t, test.endpoint, client.endpoint.String())
+				assert.Equal(t, test.region, client.config.Region)
+				assert.Equal(t, test.timeout, client.httpClient.Timeout)
+			}
+		})
+	}
+}
+
+func TestClientObjects(t *testing.T) {
+	tests := []struct {
+		desc      string
+		pathStyle bool
+		endpoint  func(server *s3server.Server) string
+		prefix    string
+		key       string
+		objectKey string
+	}{
+		{
+			desc:      "Testing putting, getting and deleting an object using path-style addressing",
+			pathStyle: true,
+			endpoint:  func(server *s3server.Server) string { return server.URL },
+			key:       "project-1.tar.gz",
+			objectKey: "project-1.tar.gz",
+		},
+		{
+			desc:      "Testing putting, getting and deleting an object using virtual-hosted-style addressing and a prefix",
+			pathStyle: false,
+			endpoint:  func(server *s3server.Server) string { return strings.Replace(server.URL, "127.0.0.1", "s3.local", 1) },
+			prefix:    "/ransidble/projects/",
+			key:       "project 1.tar.gz",
+			objectKey: "ransidble/projects/project 1.tar.gz",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			server := s3server.NewServer("ransidble", "access", "eu-west-1")
+			t.Cleanup(server.Close)
+
+			client, err := NewClient(Config{
+				AccessKeyID:     "access",
+				Bucket:          "ransidble",
+				Endpoint:        test.endpoint(server),
+				PathStyle:       test.pathStyle,
+				Prefix:          test.prefix,
+				Region:          "eu-west-1",
+				SecretAccessKey: "secret",
+			}, server.Client())
+			assert.NoError(t, err)
+
+			err = client.PutObject(context.Background(), test.key, strings.NewReader("content"))
+			assert.NoError(t, err)
+
+			content, exists := server.Object(test.objectKey)
+			assert.True(t, exists)
+			assert.Equal(t, "content", string(content))
+
+			reader, err := client.GetObject(context.Background(), test.key)
+			assert.NoError(t, err)
+			data, err := io.ReadAll(reader)
+			assert.NoError(t, err)
+			assert.NoError(t, reader.Close())
+			assert.Equal(t, "content", string(data))
+
+			err = client.DeleteObject(context.Background(), test.key)
+			assert.NoError(t, err)
+
+			_, exists = server.Object(test.objectKey)
+			assert.False(t, exists)
+
+			_, err = client.GetObject(context.Background(), test.key)
+			assert.ErrorIs(t, err, ErrObjectNotFound)
+		})
+	}
+}
+
+func TestClientErrors(t *testing.T) {
+	server := s3server.NewServer("ransidble", "access", DefaultRegion)
+	t.Cleanup(server.Close)
+
+	tests := []struct {
+		desc         string
+		config       Config
+		arrangeFunc  func(client *Client) error
+		err          error
+		errContained error
+	}{
+		{
+			desc:   "Testing error putting an object without key",
+			config: Config{Bucket: "ransidble", Endpoint: server.URL, PathStyle: true, AccessKeyID: "access"},
+			arrangeFunc: func(client *Client) error {
+				return client.PutObject(context.Background(), "", strings.NewReader("content"))
+			},
+			err: ErrObjectKeyNotProvided,
+		},
+		{
+			desc:   "Testing error putting an object without reader",
+			config: Config{Bucket: "ransidble", Endpoint: server.URL, PathStyle: true, AccessKeyID: "access"},
+			arrangeFunc: func(client *Client) error {
+				return client.PutObject(context.Background(), "key", nil)
+			},
+			err: ErrReaderNotProvided,
+		},
+		{
+			desc:   "Testing error putting an object with unexpected credentials",
+			config: Config{Bucket: "ransidble", Endpoint: server.URL, PathStyle: true, AccessKeyID: "unknown"},
+			arrangeFunc: func(client *Client) error {
+				return client.PutObject(context.Background(), "key", strings.NewReader("content"))
+			},
+			errContained: ErrPuttingObject,
+		},
+		{
+			desc:   "Testing error getting an object from an unknown bucket",
+			config: Config{Bucket: "unknown", Endpoint: server.URL, PathStyle: true, AccessKeyID: "access"},
+			arrangeFunc: func(client *Client) error {
+				_, err := client.GetObject(context.Background(), "key")
+				return err
+			},
+			errContained: ErrGettingObject,
+		},
+		{
+			desc:   "Testing error getting an object when the context is done",
+			config: Config{Bucket: "ransidble", Endpoint: server.URL, PathStyle: true, AccessKeyID: "access"},
+			arrangeFunc: func(client *Client) error {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				_, err := client.GetObject(ctx, "key")
+				return err
+			},
+			errContained: context.Canceled,
+		},
+		{
+			desc:   "Testing error putting an object when the context is done",
+			config: Config{Bucket: "ransidble", Endpoint: server.URL, PathStyle: true, AccessKeyID: "access"},
+			arrangeFunc: func(client *Client) error {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				return client.PutObject(ctx, "key", strings.NewReader("content"))
+			},
+			errContained: context.Canceled,
+		},
+		{
+			desc:   "Testing error deleting an object without key",
+			config: Config{Bucket: "ransidble", Endpoint: server.URL, PathStyle: true, AccessKeyID: "access"},
+			arrangeFunc: func(client *Client) error {
+				return client.DeleteObject(context.Background(), "")
+			},
+			err: ErrObjectKeyNotProvided,
+		},
+		{
+			desc:   "Testing deleting an object that does not exist",
+			config: Config{Bucket: "ransidble", Endpoint: server.URL, PathStyle: true, AccessKeyID: "access"},
+			arrangeFunc: func(client *Client) error {
+				return client.DeleteObject(context.Background(), "unknown")
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			client, err := NewClient(test.config, server.Client())
+			assert.NoError(t, err)
+
+			err = test.arrangeFunc(client)
+			switch {
+			case test.err != nil:
+				assert.Equal(t, test.err, err)
+			case test.errContained != nil:
+				assert.ErrorIs(t, err, test.errContained)
+			default:
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package s3
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// emptyPayloadHash is the SHA-256 of an empty payload
+	emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+	headerAuthorization = "Authorization"
+	headerContentSHA256 = "X-Amz-Content-Sha256"
+	headerDate          = "X-Amz-Date"
+	headerSecurityToken = "X-Amz-Security-Token"
+
+	signatureAlgorithm = "AWS4-HMAC-SHA256"
+	signatureService   = "s3"
+	timeFormat         = "20060102T150405Z"
+	dateFormat         = "20060102"
+)
+
+// signRequest signs the request using the AWS Signature Version 4. The host and all the headers set on the request are signed
+func signRequest(req *http.Request, payloadHash string, accessKeyID string, secretAccessKey string, region string, now time.Time) {
+
+	now = now.UTC()
+	date := now.Format(dateFormat)
+
+	req.Header.Set(headerDate, now.Format(timeFormat))
+	req.Header.Set(headerContentSHA256, payloadHash)
+
+	headers := map[string]string{
+		"host": req.URL.Host,
+	}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.Join(values, ",")
+	}
+
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(headers[name]) + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := strings.Join([]string{date, region, signatureService, "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{
+		signatureAlgorithm,
+		now.Format(timeFormat),
+		scope,
+		hashHex(canonicalRequest),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+secretAccessKey), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, signatureService)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set(headerAuthorization, fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s", signatureAlgorithm, accessKeyID, scope, signedHeaders, signature))
+}
+
+// canonicalQuery returns the query string sorted by key and encoded as required by the signature
+func canonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	parts := []string{}
+	for _, key := range keys {
+		values := query[key]
+		sort.Strings(values)
+		for _, value := range values {
+			parts = append(parts, encode(key, true)+"="+encode(value, true))
+		}
+	}
+
+	return strings.Join(parts, "&")
+}
+
+// encodePath encodes the path as required by the signature, keeping the slashes
+func encodePath(path string) string {
+	return encode(path, false)
+}
+
+// encode encodes every byte but the unreserved characters. The slash is only encoded when encodeSlash is set
+func encode(value string, encodeSlash bool) string {
+	var builder strings.Builder
+
+	for _, b := range []byte(value) {
+		switch {
+		case 'A' <= b && b <= 'Z', 'a' <= b && b <= 'z', '0' <= b && b <= '9', b == '-', b == '_', b == '.', b == '~':
+			builder.WriteByte(b)
+		case b == '/' && !encodeSlash:
+			builder.WriteByte(b)
+		default:
+			fmt.Fprintf(&builder, "%%%02X", b)
+		}
+	}
+
+	return builder.String()
+}
+
+func hashHex(value string) string {
+	hash := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(hash[:])
+}
+
+func hmacSHA256(key []byte, value string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(value))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,16 @@
+package scan
+
+import "errors"
+
+var (
+	// ErrImportSourceNotProvided represents an error when the import source is not provided
+	ErrImportSourceNotProvided = errors.New("import source not provided")
+	// ErrScanningImportPath represents an error when the import path cannot be scanned
+	ErrScanningImportPath = errors.New("error scanning import path")
+	// ErrUnsupportedImportPath represents an error when the import path is neither a directory nor a project archive
+	ErrUnsupportedImportPath = errors.New("import path is neither a directory nor a project archive")
+	// ErrOpeningImportSource represents an error when the source code of an import source cannot be opened
+	ErrOpeningImportSource = errors.New("error opening import source")
+	// ErrPackingProjectTree represents an error when a project tree cannot be packed
+	ErrPackingProjectTree = errors.New("error packing project tree")
+)
@@ -0,0 +1,327 @@
+package scan
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	"github.com/apenella/ransidble/internal/domain/ports/repository"
+	"github.com/spf13/afero"
+)
+
+// packedProjectTreePrefix is the prefix of the temporary files where the project trees are packed
+const packedProjectTreePrefix = "ransidble-import"
+
+// projectVersionSeparator separates the project name from the project version in the name of the directories and archives
+const projectVersionSeparator = "@"
+
+// archiveExtensions represents the extensions of the project archives and their formats. The extensions ending with another extension are placed first, so they are matched before it
+var archiveExtensions = []struct {
+	extension string
+	format    string
+}{
+	{extension: "." + entity.ExtensionOCI, format: entity.ProjectFormatOCI},
+	{extension: "." + entity.ExtensionTarGz, format: entity.ProjectFormatTarGz},
+	{extension: "." + entity.ExtensionTarZst, format: entity.ProjectFormatTarZst},
+	{extension: "." + entity.ExtensionTarXz, format: entity.ProjectFormatTarXz},
+	{extension: "." + entity.ExtensionTar, format: entity.ProjectFormatTar},
+	{extension: "." + entity.ExtensionZip, format: entity.ProjectFormatZip},
+}
+
+// Scanner finds the projects to import in the filesystem. A project is either a directory holding the project tree or a project archive, whose name follows the <name>@<version> convention
+type Scanner struct {
+	// fs is the filesystem
+	fs afero.Fs
+	// logger is the logger
+	logger repository.Logger
+}
+
+// Ensure Scanner implements the SourceCodeScanner interface
+var _ repository.SourceCodeScanner = (*Scanner)(nil)
+
+// NewScanner method creates a new Scanner struct
+func NewScanner(fs afero.Fs, logger repository.Logger) *Scanner {
+	return &Scanner{
+		fs:     fs,
+		logger: logger,
+	}
+}
+
+// Scan method returns the projects found in the path, sorted by their path. A path to a project archive, or to a directory holding a manifest or an ansible.cfg file at its root, is a single project. Otherwise, each subdirectory and each project archive of the directory is a project, while the hidden entries, the symbolic links and the other files are ignored
+func (s *Scanner) Scan(path string) ([]*entity.ProjectImportSource, error) {
+
+	info, err := s.fs.Stat(path)
+	if err != nil {
+		s.logger.Error(
+			fmt.Sprintf("%s: %s", ErrScanningImportPath, err),
+			map[string]interface{}{
+				"component": "Scanner.Scan",
+				"package":   "github.com/apenella/ransidble/internal/infrastructure/scan",
+				"path":      path,
+			})
+		return nil, fmt.Errorf("%w: %w", ErrScanningImportPath, err)
+	}
+
+	if !info.IsDir() {
+		source := archiveSource(path)
+		if source == nil {
+			s.logger.Error(
+				ErrUnsupportedImportPath.Error(),
+				map[string]interface{}{
+					"component": "Scanner.Scan",
+					"package":   "github.com/apenella/ransidble/internal/infrastructure/scan",
+					"path":      path,
+				})
+			return nil, fmt.Errorf("%w: %s", ErrUnsupportedImportPath, path)
+		}
+
+		return []*entity.ProjectImportSource{source}, nil
+	}
+
+	if s.isProjectTree(path) {
+		return []*entity.ProjectImportSource{projectTreeSource(path)}, nil
+	}
+
+	entries, err := afero.ReadDir(s.fs, path)
+	if err != nil {
+		s.logger.Error(
+			fmt.Sprintf("%s: %s", ErrScanningImportPath, err),
+			map[string]interface{}{
+				"component": "Scanner.Scan",
+				"package":   "github.com/apenella/ransidble/internal/infrastructure/scan",
+				"path":      path,
+			})
+		return nil, fmt.Errorf("%w: %w", ErrScanningImportPath, err)
+	}
+
+	sources := []*entity.ProjectImportSource{}
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+
+		entryPath := filepath.Join(path, entry.Name())
+		switch {
+		case entry.IsDir():
+			sources = append(sources, projectTreeSource(entryPath))
+		case entry.Mode().IsRegular():
+			source := archiveSource(entryPath)
+			if source != nil {
+				sources = append(sources, source)
+			}
+		}
+	}
+
+	return sources, nil
+}
+
+// Open method returns the source code of the import source. The project trees are packed as tar.gz into a temporary file, which is removed when the source code is closed. The entries are packed in lexical order and without their ownership and modification times, so the digest of a project tree only changes when its content does
+func (s *Scanner) Open(source *entity.ProjectImportSource) (io.ReadSeekCloser, error) {
+
+	if source == nil {
+		s.logger.Error(
+			ErrImportSourceNotProvided.Error(),
+			map[string]interface{}{
+				"component": "Scanner.Open",
+				"package":   "github.com/apenella/ransidble/internal/infrastructure/scan",
+			})
+		return nil, ErrImportSourceNotProvided
+	}
+
+	info, err := s.fs.Stat(source.Path)
+	if err == nil && !info.IsDir() {
+		var file afero.File
+		file, err = s.fs.Open(source.Path)
+		if err == nil {
+			return file, nil
+		}
+	}
+	if err != nil {
+		s.logger.Error(
+			fmt.Sprintf("%s: %s", ErrOpeningImportSource, err),
+			map[string]interface{}{
+				"component": "Scanner.Open",
+				"package":   "github.com/apenella/ransidble/internal/infrastructure/scan",
+				"path":      source.Path,
+			})
+		return nil, fmt.Errorf("%w: %w", ErrOpeningImportSource, err)
+	}
+
+	packed, err := s.pack(source.Path)
+	if err != nil {
+		s.logger.Error(
+			fmt.Sprintf("%s: %s", ErrPackingProjectTree, err),
+			map[string]interface{}{
+				"component": "Scanner.Open",
+				"package":   "github.com/apenella/ransidble/internal/infrastructure/scan",
+				"path":      source.Path,
+			})
+		return nil, fmt.Errorf("%w: %w", ErrPackingProjectTree, err)
+	}
+
+	return packed, nil
+}
+
+// isProjectTree returns whether the directory is the root of a project tree, holding either a manifest or an ansible.cfg file
+func (s *Scanner) isProjectTree(path string) bool {
+	for _, name := range []string{entity.ProjectManifestFileName, entity.ProjectManifestAlternativeFileName, "ansible.cfg"} {
+		info, err := s.fs.Stat(filepath.Join(path, name))
+		if err == nil && !info.IsDir() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// pack packs the project tree into a temporary tar.gz file, which is rewound to be read from its beginning
+func (s *Scanner) pack(root string) (io.ReadSeekCloser, error) {
+
+	file, err := afero.TempFile(s.fs, "", packedProjectTreePrefix)
+	if err != nil {
+		return nil, err
+	}
+	packed := &packedProjectTree{File: file, fs: s.fs}
+
+	err = s.writeTarGz(file, root)
+	if err == nil {
+		_, err = file.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		return nil, errors.Join(err, packed.Close())
+	}
+
+	return packed, nil
+}
+
+// writeTarGz writes the entries of the project tree as a tar.gz archive. The symbolic links are only packed when the filesystem can read them, and the other special files are not packed
+func (s *Scanner) writeTarGz(writer io.Writer, root string) error {
+
+	gzipWriter := gzip.NewWriter(writer)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	err := afero.Walk(s.fs, root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if path == root {
+			return nil
+		}
+
+		link := ""
+		switch {
+		case info.Mode()&os.ModeSymlink != 0:
+			reader, ok := s.fs.(afero.LinkReader)
+			if !ok {
+				return nil
+			}
+
+			link, err = reader.ReadlinkIfPossible(path)
+			if err != nil {
+				return err
+			}
+		case !info.IsDir() && !info.Mode().IsRegular():
+			return nil
+		}
+
+		relPath, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+
+		header, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+
+		header.Name = filepath.ToSlash(relPath)
+		if info.IsDir() {
+			header.Name += "/"
+		}
+		header.Uid, header.Gid = 0, 0
+		header.Uname, header.Gname = "", ""
+		header.ModTime = time.Unix(0, 0)
+		header.AccessTime, header.ChangeTime = time.Time{}, time.Time{}
+
+		err = tarWriter.WriteHeader(header)
+		if err != nil {
+			return err
+		}
+
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		file, err := s.fs.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		_, err = io.Copy(tarWriter, file)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return errors.Join(tarWriter.Close(), gzipWriter.Close())
+}
+
+// packedProjectTree is a project tree packed into a temporary file, which is removed once the file is closed
+type packedProjectTree struct {
+	afero.File
+	fs afero.Fs
+}
+
+// Close closes the file and removes it
+func (p *packedProjectTree) Close() error {
+	return errors.Join(p.File.Close(), p.fs.Remove(p.File.Name()))
+}
+
+// projectTreeSource returns the import source of a project tree, which is packed as tar.gz when it is opened
+func projectTreeSource(path string) *entity.ProjectImportSource {
+	name, version := parseName(filepath.Base(path))
+
+	return &entity.ProjectImportSource{
+		Format:  entity.ProjectFormatTarGz,
+		Name:    name,
+		Path:    path,
+		Version: version,
+	}
+}
+
+// archiveSource returns the import source of a project archive, or nil when the file extension is not the extension of a project archive
+func archiveSource(path string) *entity.ProjectImportSource {
+	base := filepath.Base(path)
+
+	for _, archive := range archiveExtensions {
+		if strings.HasSuffix(base, archive.extension) {
+			name, version := parseName(strings.TrimSuffix(base, archive.extension))
+
+			return &entity.ProjectImportSource{
+				Format:  archive.format,
+				Name:    name,
+				Path:    path,
+				Version: version,
+			}
+		}
+	}
+
+	return nil
+}
+
+// parseName splits the name of a directory or archive, without its extension, into the project name and the project version
+func parseName(name string) (string, string) {
+	projectName, version, _ := strings.Cut(name, projectVersionSeparator)
+
+	return projectName, version
+}
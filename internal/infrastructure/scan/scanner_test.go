@@ -0,0 +1,211 @@
+package scan
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io"
+	"path/filepath"
+	"testing"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	"github.com/apenella/ransidble/internal/infrastructure/logger"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestScanner returns a scanner whose filesystem holds the files
+func newTestScanner(files map[string]string) *Scanner {
+	fs := afero.NewMemMapFs()
+	for path, content := range files {
+		_ = afero.WriteFile(fs, filepath.FromSlash(path), []byte(content), 0644)
+	}
+
+	return NewScanner(fs, logger.NewFakeLogger())
+}
+
+func TestScannerScan(t *testing.T) {
+	tests := []struct {
+		desc     string
+		scanner  *Scanner
+		path     string
+		expected []*entity.ProjectImportSource
+		err      error
+	}{
+		{
+			desc: "Testing scanning a directory holding project trees and project archives",
+			scanner: newTestScanner(map[string]string{
+				"projects/.hidden/site.yml":        "- hosts: all",
+				"projects/README.md":               "# projects",
+				"projects/project-1/site.yml":      "- hosts: all",
+				"projects/project-2@v1.tar.gz":     "archive",
+				"projects/project-3@2.0.0.oci.tar": "archive",
+			}),
+			path: "projects",
+			expected: []*entity.ProjectImportSource{
+				{Format: entity.ProjectFormatTarGz, Name: "project-1", Path: filepath.Join("projects", "project-1")},
+				{Format: entity.ProjectFormatTarGz, Name: "project-2", Path: filepath.Join("projects", "project-2@v1.tar.gz"), Version: "v1"},
+				{Format: entity.ProjectFormatOCI, Name: "project-3", Path: filepath.Join("projects", "project-3@2.0.0.oci.tar"), Version: "2.0.0"},
+			},
+		},
+		{
+			desc: "Testing scanning a directory holding a project tree",
+			scanner: newTestScanner(map[string]string{
+				"project@v1/ransidble.yaml":     "playbooks: [site.yml]",
+				"project@v1/roles/web/main.yml": "---",
+				"project@v1/site.yml":           "- hosts: all",
+			}),
+			path: "project@v1",
+			expected: []*entity.ProjectImportSource{
+				{Format: entity.ProjectFormatTarGz, Name: "project", Path: "project@v1", Version: "v1"},
+			},
+		},
+		{
+			desc: "Testing scanning a project archive",
+			scanner: newTestScanner(map[string]string{
+				"projects/project.zip": "archive",
+			}),
+			path: "projects/project.zip",
+			expected: []*entity.ProjectImportSource{
+				{Format: entity.ProjectFormatZip, Name: "project", Path: "projects/project.zip"},
+			},
+		},
+		{
+			desc: "Testing error scanning a file that is not a project archive",
+			scanner: newTestScanner(map[string]string{
+				"projects/README.md": "# projects",
+			}),
+			path: "projects/README.md",
+			err:  ErrUnsupportedImportPath,
+		},
+		{
+			desc:    "Testing error scanning a path that does not exist",
+			scanner: newTestScanner(map[string]string{}),
+			path:    "projects",
+			err:     ErrScanningImportPath,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			sources, err := test.scanner.Scan(test.path)
+			if test.err != nil {
+				assert.ErrorIs(t, err, test.err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expected, sources)
+			}
+		})
+	}
+}
+
+func TestScannerOpen(t *testing.T) {
+	files := map[string]string{
+		"projects/project-1/inventory/hosts.yml": "all: {}",
+		"projects/project-1/site.yml":            "- hosts: all",
+		"projects/project-2.tar.gz":              "archive",
+	}
+
+	tests := []struct {
+		desc     string
+		scanner  *Scanner
+		source   *entity.ProjectImportSource
+		expected []string
+		content  string
+		err      error
+		packed   bool
+	}{
+		{
+			desc:    "Testing opening a project archive",
+			scanner: newTestScanner(files),
+			source:  &entity.ProjectImportSource{Format: entity.ProjectFormatTarGz, Name: "project-2", Path: "projects/project-2.tar.gz"},
+			content: "archive",
+		},
+		{
+			desc:     "Testing opening a project tree, which is packed as tar.gz",
+			scanner:  newTestScanner(files),
+			source:   &entity.ProjectImportSource{Format: entity.ProjectFormatTarGz, Name: "project-1", Path: "projects/project-1"},
+			expected: []string{"inventory/", "inventory/hosts.yml", "site.yml"},
+			packed:   true,
+		},
+		{
+			desc:    "Testing error opening a nil import source",
+			scanner: newTestScanner(files),
+			err:     ErrImportSourceNotProvided,
+		},
+		{
+			desc:    "Testing error opening an import source that does not exist",
+			scanner: newTestScanner(files),
+			source:  &entity.ProjectImportSource{Format: entity.ProjectFormatTarGz, Name: "project-3", Path: "projects/project-3"},
+			err:     ErrOpeningImportSource,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			content, err := test.scanner.Open(test.source)
+			if test.err != nil {
+				assert.ErrorIs(t, err, test.err)
+				return
+			}
+			assert.NoError(t, err)
+
+			if !test.packed {
+				data, err := io.ReadAll(content)
+				assert.NoError(t, err)
+				assert.Equal(t, test.content, string(data))
+				assert.NoError(t, content.Close())
+				return
+			}
+
+			gzipReader, err := gzip.NewReader(content)
+			assert.NoError(t, err)
+			tarReader := tar.NewReader(gzipReader)
+
+			names := []string{}
+			for {
+				header, err := tarReader.Next()
+				if err == io.EOF {
+					break
+				}
+				assert.NoError(t, err)
+				names = append(names, header.Name)
+			}
+			assert.Equal(t, test.expected, names)
+
+			packedFile := content.(*packedProjectTree).Name()
+			assert.NoError(t, content.Close())
+			exists, _ := afero.Exists(test.scanner.fs, packedFile)
+			assert.False(t, exists)
+		})
+	}
+}
+
+func TestScannerOpenPacksProjectTreeDeterministically(t *testing.T) {
+	t.Log("Testing packing the same project tree twice produces the same content")
+
+	scanner := newTestScanner(map[string]string{
+		"project/site.yml":           "- hosts: all",
+		"project/roles/web/main.yml": "---",
+	})
+	source := &entity.ProjectImportSource{Format: entity.ProjectFormatTarGz, Name: "project", Path: "project"}
+
+	packed := [][]byte{}
+	for i := 0; i < 2; i++ {
+		content, err := scanner.Open(source)
+		assert.NoError(t, err)
+
+		data, err := io.ReadAll(content)
+		assert.NoError(t, err)
+		assert.NoError(t, content.Close())
+
+		packed = append(packed, data)
+	}
+
+	assert.Equal(t, packed[0], packed[1])
+}
@@ -0,0 +1,20 @@
+package signature
+
+import "errors"
+
+var (
+	// ErrProjectNotProvided represents an error when the project is not provided
+	ErrProjectNotProvided = errors.New("project not provided")
+	// ErrWorkingDirNotProvided represents an error when the working directory is not provided
+	ErrWorkingDirNotProvided = errors.New("working directory not provided")
+	// ErrFilesystemNotProvided represents an error when the filesystem is not provided
+	ErrFilesystemNotProvided = errors.New("filesystem not provided")
+	// ErrSourceCodeFileNotSignable represents an error when the signed source code is not a file, such as a directory
+	ErrSourceCodeFileNotSignable = errors.New("source code file cannot be signed")
+	// ErrOpeningSourceCodeFile represents an error when the source code file cannot be opened
+	ErrOpeningSourceCodeFile = errors.New("error opening source code file")
+	// ErrVerifyingSourceCodeSignature represents an error when the signature of the source code is not verified
+	ErrVerifyingSourceCodeSignature = errors.New("error verifying source code signature")
+	// ErrReadingPublicKey represents an error when a public key of the trust policy cannot be read
+	ErrReadingPublicKey = errors.New("error reading public key")
+)
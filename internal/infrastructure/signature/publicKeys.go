@@ -0,0 +1,34 @@
+package signature
+
+import (
+	"crypto"
+	"fmt"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	"github.com/spf13/afero"
+)
+
+// LoadPublicKeys returns the public keys stored as PEM files or as minisign public key files in the given paths
+func LoadPublicKeys(fs afero.Fs, paths []string) ([]crypto.PublicKey, error) {
+
+	if fs == nil {
+		return nil, ErrFilesystemNotProvided
+	}
+
+	publicKeys := make([]crypto.PublicKey, 0, len(paths))
+	for _, path := range paths {
+		data, err := afero.ReadFile(fs, path)
+		if err != nil {
+			return nil, fmt.Errorf("%w %s: %w", ErrReadingPublicKey, path, err)
+		}
+
+		publicKey, err := entity.ParseProjectPublicKey(data)
+		if err != nil {
+			return nil, fmt.Errorf("%w %s: %w", ErrReadingPublicKey, path, err)
+		}
+
+		publicKeys = append(publicKeys, publicKey)
+	}
+
+	return publicKeys, nil
+}
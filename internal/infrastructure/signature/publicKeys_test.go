@@ -0,0 +1,55 @@
+package signature
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"path/filepath"
+	"testing"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	"github.com/apenella/ransidble/test/signing"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadPublicKeys(t *testing.T) {
+
+	publicKey, _, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	publicKeyPEM := signing.EncodePublicKey(t, publicKey)
+
+	fs := afero.NewMemMapFs()
+	err = afero.WriteFile(fs, filepath.Join("keys", "ci.pub"), publicKeyPEM, 0644)
+	assert.NoError(t, err)
+	err = afero.WriteFile(fs, filepath.Join("keys", "minisign.pub"), signing.EncodeMinisignPublicKey(publicKey, []byte("keyid-01")), 0644)
+	assert.NoError(t, err)
+	err = afero.WriteFile(fs, filepath.Join("keys", "invalid.pub"), []byte("invalid"), 0644)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		desc     string
+		paths    []string
+		expected int
+		err      error
+	}{
+		{desc: "Testing loading the public keys of the trust policy", paths: []string{filepath.Join("keys", "ci.pub"), filepath.Join("keys", "minisign.pub")}, expected: 2},
+		{desc: "Testing loading the public keys of the trust policy without paths", paths: nil, expected: 0},
+		{desc: "Testing error loading a public key that does not exist", paths: []string{filepath.Join("keys", "unknown.pub")}, err: ErrReadingPublicKey},
+		{desc: "Testing error loading a public key that is not valid", paths: []string{filepath.Join("keys", "invalid.pub")}, err: entity.ErrInvalidProjectPublicKey},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			publicKeys, err := LoadPublicKeys(fs, test.paths)
+			if test.err != nil {
+				assert.ErrorIs(t, err, test.err)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, publicKeys, test.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package signature
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	"github.com/apenella/ransidble/internal/domain/ports/repository"
+	"github.com/spf13/afero"
+)
+
+// Verifier verifies the signature of the source code fetched into the working directory against the trust policy
+type Verifier struct {
+	// fs is the filesystem
+	fs afero.Fs
+	// logger is the logger
+	logger repository.Logger
+	// policy is the trust policy
+	policy *entity.ProjectTrustPolicy
+}
+
+// Ensure Verifier implements the SourceCodeSignatureVerifier interface
+var _ repository.SourceCodeSignatureVerifier = (*Verifier)(nil)
+
+// NewVerifier method creates a new Verifier struct
+func NewVerifier(fs afero.Fs, policy *entity.ProjectTrustPolicy, logger repository.Logger) *Verifier {
+	return &Verifier{
+		fs:     fs,
+		logger: logger,
+		policy: policy,
+	}
+}
+
+// Verify method verifies the signature of the source code file located in the working directory and named as the project reference. The projects are not verified when the trust policy is not enforced
+func (v *Verifier) Verify(project *entity.Project, workingDir string) error {
+
+	if !v.policy.IsEnforced() {
+		return nil
+	}
+
+	if project == nil {
+		v.logger.Error(ErrProjectNotProvided.Error(),
+			map[string]interface{}{
+				"component": "Verifier.Verify",
+				"package":   "github.com/apenella/ransidble/internal/infrastructure/signature",
+			})
+		return ErrProjectNotProvided
+	}
+
+	if workingDir == "" {
+		v.logger.Error(ErrWorkingDirNotProvided.Error(),
+			map[string]interface{}{
+				"component":  "Verifier.Verify",
+				"package":    "github.com/apenella/ransidble/internal/infrastructure/signature",
+				"project_id": project.Name,
+			})
+		return ErrWorkingDirNotProvided
+	}
+
+	if v.fs == nil {
+		v.logger.Error(ErrFilesystemNotProvided.Error(),
+			map[string]interface{}{
+				"component":  "Verifier.Verify",
+				"package":    "github.com/apenella/ransidble/internal/infrastructure/signature",
+				"project_id": project.Name,
+			})
+		return ErrFilesystemNotProvided
+	}
+
+	// the unsigned projects are only checked against the policy, since there is no signature to verify
+	if project.Signature == "" {
+		err := v.policy.Verify(project.Signature, nil)
+		if err != nil {
+			v.logger.Error(
+				fmt.Sprintf("%s: %s", ErrVerifyingSourceCodeSignature, err),
+				map[string]interface{}{
+					"component":       "Verifier.Verify",
+					"package":         "github.com/apenella/ransidble/internal/infrastructure/signature",
+					"project_id":      project.Name,
+					"project_version": project.Version,
+				})
+			return fmt.Errorf("%w: %w", ErrVerifyingSourceCodeSignature, err)
+		}
+		return nil
+	}
+
+	sourceCodeFile := filepath.Join(workingDir, project.Reference)
+	info, err := v.fs.Stat(sourceCodeFile)
+	if err != nil {
+		v.logger.Error(
+			fmt.Sprintf("%s: %s", ErrOpeningSourceCodeFile, err),
+			map[string]interface{}{
+				"component":   "Verifier.Verify",
+				"package":     "github.com/apenella/ransidble/internal/infrastructure/signature",
+				"project_id":  project.Name,
+				"source_file": sourceCodeFile,
+			})
+		return fmt.Errorf("%w: %w", ErrOpeningSourceCodeFile, err)
+	}
+
+	if info.IsDir() {
+		v.logger.Error(ErrSourceCodeFileNotSignable.Error(),
+			map[string]interface{}{
+				"component":   "Verifier.Verify",
+				"package":     "github.com/apenella/ransidble/internal/infrastructure/signature",
+				"project_id":  project.Name,
+				"source_file": sourceCodeFile,
+			})
+		return fmt.Errorf("%w: %w", ErrVerifyingSourceCodeSignature, ErrSourceCodeFileNotSignable)
+	}
+
+	file, err := v.fs.Open(sourceCodeFile)
+	if err != nil {
+		v.logger.Error(
+			fmt.Sprintf("%s: %s", ErrOpeningSourceCodeFile, err),
+			map[string]interface{}{
+				"component":   "Verifier.Verify",
+				"package":     "github.com/apenella/ransidble/internal/infrastructure/signature",
+				"project_id":  project.Name,
+				"source_file": sourceCodeFile,
+			})
+		return fmt.Errorf("%w: %w", ErrOpeningSourceCodeFile, err)
+	}
+	defer file.Close()
+
+	err = v.policy.Verify(project.Signature, file)
+	if err != nil {
+		v.logger.Error(
+			fmt.Sprintf("%s: %s", ErrVerifyingSourceCodeSignature, err),
+			map[string]interface{}{
+				"component":       "Verifier.Verify",
+				"package":         "github.com/apenella/ransidble/internal/infrastructure/signature",
+				"project_id":      project.Name,
+				"project_version": project.Version,
+				"source_file":     sourceCodeFile,
+			})
+		return fmt.Errorf("%w: %w", ErrVerifyingSourceCodeSignature, err)
+	}
+
+	return nil
+}
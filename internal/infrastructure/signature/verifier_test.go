@@ -0,0 +1,110 @@
+package signature
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"path/filepath"
+	"testing"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	"github.com/apenella/ransidble/internal/infrastructure/logger"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifierVerify(t *testing.T) {
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	publicKey := &privateKey.PublicKey
+	digest := sha256.Sum256([]byte("content for testing"))
+	signed, err := ecdsa.SignASN1(rand.Reader, privateKey, digest[:])
+	assert.NoError(t, err)
+	signature := base64.StdEncoding.EncodeToString(signed)
+	tamperedDigest := sha256.Sum256([]byte("tampered content"))
+	tamperedSigned, err := ecdsa.SignASN1(rand.Reader, privateKey, tamperedDigest[:])
+	assert.NoError(t, err)
+	tamperedSignature := base64.StdEncoding.EncodeToString(tamperedSigned)
+
+	workingDir := filepath.Join("tmp", "workspace")
+	fs := afero.NewMemMapFs()
+	err = afero.WriteFile(fs, filepath.Join(workingDir, "project.tar.gz"), []byte("content for testing"), 0644)
+	assert.NoError(t, err)
+	err = fs.MkdirAll(filepath.Join(workingDir, "project-plain"), 0755)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		desc     string
+		verifier *Verifier
+		project  *entity.Project
+		err      error
+	}{
+		{
+			desc:     "Testing verifying the signature of a project",
+			verifier: NewVerifier(fs, entity.NewProjectTrustPolicy(true, publicKey), logger.NewFakeLogger()),
+			project:  &entity.Project{Name: "project", Reference: "project.tar.gz", Signature: signature},
+		},
+		{
+			desc:     "Testing verifying a project when the trust policy is not enforced",
+			verifier: NewVerifier(fs, nil, logger.NewFakeLogger()),
+			project:  &entity.Project{Name: "project", Reference: "project.tar.gz", Signature: tamperedSignature},
+		},
+		{
+			desc:     "Testing verifying an unsigned project when signatures are not required",
+			verifier: NewVerifier(fs, entity.NewProjectTrustPolicy(false, publicKey), logger.NewFakeLogger()),
+			project:  &entity.Project{Name: "project", Reference: "project.tar.gz"},
+		},
+		{
+			desc:     "Testing error verifying an unsigned project when signatures are required",
+			verifier: NewVerifier(fs, entity.NewProjectTrustPolicy(true, publicKey), logger.NewFakeLogger()),
+			project:  &entity.Project{Name: "project", Reference: "project.tar.gz"},
+			err:      entity.ErrProjectSignatureRequired,
+		},
+		{
+			desc:     "Testing error verifying a project whose signature does not match its content",
+			verifier: NewVerifier(fs, entity.NewProjectTrustPolicy(true, publicKey), logger.NewFakeLogger()),
+			project:  &entity.Project{Name: "project", Reference: "project.tar.gz", Signature: tamperedSignature},
+			err:      entity.ErrProjectSignatureNotTrusted,
+		},
+		{
+			desc:     "Testing error verifying a signed project whose source code is a directory",
+			verifier: NewVerifier(fs, entity.NewProjectTrustPolicy(true, publicKey), logger.NewFakeLogger()),
+			project:  &entity.Project{Name: "project", Reference: "project-plain", Signature: signature},
+			err:      ErrSourceCodeFileNotSignable,
+		},
+		{
+			desc:     "Testing error verifying a signed project whose source code file does not exist",
+			verifier: NewVerifier(fs, entity.NewProjectTrustPolicy(true, publicKey), logger.NewFakeLogger()),
+			project:  &entity.Project{Name: "project", Reference: "unknown.tar.gz", Signature: signature},
+			err:      ErrOpeningSourceCodeFile,
+		},
+		{
+			desc:     "Testing error verifying a project when the project is not provided",
+			verifier: NewVerifier(fs, entity.NewProjectTrustPolicy(true, publicKey), logger.NewFakeLogger()),
+			err:      ErrProjectNotProvided,
+		},
+		{
+			desc:     "Testing error verifying a project when the filesystem is not provided",
+			verifier: NewVerifier(nil, entity.NewProjectTrustPolicy(true, publicKey), logger.NewFakeLogger()),
+			project:  &entity.Project{Name: "project", Reference: "project.tar.gz", Signature: signature},
+			err:      ErrFilesystemNotProvided,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			err := test.verifier.Verify(test.project, workingDir)
+			if test.err != nil {
+				assert.ErrorIs(t, err, test.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -6,7 +6,9 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 
+	"github.com/apenella/ransidble/internal/domain/core/entity"
 	"github.com/apenella/ransidble/internal/domain/ports/repository"
 	"github.com/spf13/afero"
 )
@@ -30,8 +32,17 @@ var (
 	ErrFilesystemNotProvided = fmt.Errorf("filesystem not provided")
 	// ErrTarFileHeaderNotProvided is returned when the tar file header is not provided
 	ErrTarFileHeaderNotProvided = fmt.Errorf("tar file header not provided")
+	// ErrUnsafeTarEntry is returned when a tar entry, or the target of a link, is placed outside the destination
+	ErrUnsafeTarEntry = fmt.Errorf("unsafe tar entry")
+	// ErrCreatingLinkFromTar is returned when a link is unable to be created from the tar file
+	ErrCreatingLinkFromTar = fmt.Errorf("unable to create link from tar")
+	// ErrSymlinkNotSupported is returned when the filesystem does not support symbolic links
+	ErrSymlinkNotSupported = fmt.Errorf("symbolic links not supported by the filesystem")
 )
 
+// maxSymlinkResolutions is the maximum number of symbolic links followed to resolve a path, as the Linux kernel does
+const maxSymlinkResolutions = 40
+
 // Tar is a struct that implements the Tar operations
 type Tar struct {
 	// fs is the filesystem
@@ -51,8 +62,8 @@ func NewTar(fs afero.Fs, logger repository.Logger) *Tar {
 	}
 }
 
-// Extract untar the io.Reader into the destination
-func (t *Tar) Extract(r io.Reader, destination string) error {
+// Extract untar the io.Reader into the destination. Each entry is accounted on the archive usage before it is extracted, so the extraction stops as soon as any archive limit is exceeded
+func (t *Tar) Extract(r io.Reader, destination string, usage *entity.ProjectArchiveUsage) error {
 
 	if r == nil {
 		t.logger.Error(
@@ -85,6 +96,8 @@ func (t *Tar) Extract(r io.Reader, destination string) error {
 	}
 
 	tr := tar.NewReader(r)
+	// directories holds the extracted directories, whose attributes are set once their content is extracted
+	directories := []*tar.Header{}
 
 	for {
 		header, err := tr.Next()
@@ -103,11 +116,25 @@ func (t *Tar) Extract(r io.Reader, destination string) error {
 			return fmt.Errorf("%s: %w", ErrTarReading, err)
 		}
 
-		target := filepath.Join(destination, header.Name)
+		// the hard links are accounted once the size of the linked file is known
+		if header.Typeflag != tar.TypeXGlobalHeader && header.Typeflag != tar.TypeLink {
+			err = usage.AddEntry(header.Name, entrySize(header))
+			if err != nil {
+				t.logger.Error(
+					err.Error(),
+					map[string]interface{}{
+						"component": "Tar.Extract",
+						"package":   "github.com/apenella/ransidble/internal/infrastructure/tar",
+						"file":      header.Name,
+					})
+				return err
+			}
+		}
 
+		// The PAX and GNU long name headers are merged into the header of the entry they describe by the tar reader
 		switch header.Typeflag {
 		case tar.TypeDir:
-			err := t.fs.MkdirAll(target, os.FileMode(header.Mode))
+			err = t.extractDirectory(header, destination)
 			if err != nil {
 				t.logger.Error(
 					fmt.Sprintf("%s: %s", ErrUnableToUntar, err),
@@ -119,8 +146,15 @@ func (t *Tar) Extract(r io.Reader, destination string) error {
 					})
 				return fmt.Errorf("%s: %w", ErrUnableToUntar, err)
 			}
-		case tar.TypeReg:
-			err := t.extractRegularFile(tr, header, target)
+			directories = append(directories, header)
+		case tar.TypeReg, tar.TypeGNUSparse:
+			target, err := t.entryTarget(header.Name, destination)
+			if err == nil {
+				err = t.extractRegularFile(tr, header, target)
+			}
+			if err == nil {
+				err = t.setAttributes(header, target)
+			}
 			if err != nil {
 				t.logger.Error(
 					fmt.Sprintf("%s: %s", ErrExtractingFileFromTar, err),
@@ -132,10 +166,38 @@ func (t *Tar) Extract(r io.Reader, destination string) error {
 					})
 				return fmt.Errorf("%s: %w", ErrExtractingFileFromTar, err)
 			}
-		// The following cases are not supported yet, it would be required when fetching files from a git source
+		case tar.TypeSymlink:
+			err = t.extractSymlink(header, destination)
+			if err != nil {
+				t.logger.Error(
+					fmt.Sprintf("%s: %s", ErrCreatingLinkFromTar, err),
+					map[string]interface{}{
+						"component": "Tar.Extract",
+						"package":   "github.com/apenella/ransidble/internal/infrastructure/tar",
+						"file":      header.Name,
+						"link":      header.Linkname,
+						"type":      "symlink",
+					})
+				return fmt.Errorf("%s: %w", ErrCreatingLinkFromTar, err)
+			}
+		case tar.TypeLink:
+			err = t.extractHardLink(header, destination, usage)
+			if err != nil {
+				t.logger.Error(
+					fmt.Sprintf("%s: %s", ErrCreatingLinkFromTar, err),
+					map[string]interface{}{
+						"component": "Tar.Extract",
+						"package":   "github.com/apenella/ransidble/internal/infrastructure/tar",
+						"file":      header.Name,
+						"link":      header.Linkname,
+						"type":      "hardlink",
+					})
+				return fmt.Errorf("%s: %w", ErrCreatingLinkFromTar, err)
+			}
 		// https://github.com/golang/build/blob/master/internal/untar/untar.go#L131C1-L132C48
-		// case tar.TypeXGlobalHeader:
-		// 	// git archive generates these. Ignore them.
+		case tar.TypeXGlobalHeader:
+			// git archive generates these. Ignore them.
+			continue
 		default:
 			t.logger.Error(
 				ErrUnableToUntar.Error(),
@@ -150,9 +212,306 @@ func (t *Tar) Extract(r io.Reader, destination string) error {
 		}
 	}
 
+	// A symbolic link is validated when it is created, but a later entry can replace a link it goes through. The links are validated again once the whole archive is extracted
+	err := t.validateSymlinks(destination)
+	if err != nil {
+		t.logger.Error(
+			fmt.Sprintf("%s: %s", ErrUnableToUntar, err),
+			map[string]interface{}{
+				"component":   "Tar.Extract",
+				"destination": destination,
+				"package":     "github.com/apenella/ransidble/internal/infrastructure/tar",
+			})
+		return fmt.Errorf("%s: %w", ErrUnableToUntar, err)
+	}
+
+	// The attributes of the directories are set in reverse order, so setting the attributes of a directory is not undone by extracting its subdirectories
+	for i := len(directories) - 1; i >= 0; i-- {
+		target, err := t.resolvePath(directories[i].Name, destination)
+		if err == nil {
+			err = t.setAttributes(directories[i], target)
+		}
+		if err != nil {
+			t.logger.Error(
+				fmt.Sprintf("%s: %s", ErrUnableToUntar, err),
+				map[string]interface{}{
+					"component": "Tar.Extract",
+					"package":   "github.com/apenella/ransidble/internal/infrastructure/tar",
+					"file":      directories[i].Name,
+					"type":      "directory",
+				})
+			return fmt.Errorf("%s: %w", ErrUnableToUntar, err)
+		}
+	}
+
 	return nil
 }
 
+// extractDirectory creates the directory of the tar entry. The directory is created writable, and its mode is set once its content is extracted
+func (t *Tar) extractDirectory(header *tar.Header, destination string) error {
+	target, err := t.resolvePath(header.Name, destination)
+	if err != nil {
+		return err
+	}
+
+	return t.fs.MkdirAll(target, 0755)
+}
+
+// extractSymlink creates the symbolic link of the tar entry. The link must be relative and point inside the destination
+func (t *Tar) extractSymlink(header *tar.Header, destination string) error {
+
+	linker, ok := t.fs.(afero.Linker)
+	if !ok {
+		return ErrSymlinkNotSupported
+	}
+
+	if header.Linkname == "" || isAbsolute(header.Linkname) {
+		return fmt.Errorf("%w: %s -> %s", ErrUnsafeTarEntry, header.Name, header.Linkname)
+	}
+
+	target, err := t.entryTarget(header.Name, destination)
+	if err != nil {
+		return err
+	}
+
+	relativeDir, err := filepath.Rel(destination, filepath.Dir(target))
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrUnsafeTarEntry, header.Name)
+	}
+
+	_, err = t.resolvePath(filepath.Join(relativeDir, filepath.FromSlash(header.Linkname)), destination)
+	if err != nil {
+		return fmt.Errorf("%w: %s -> %s", ErrUnsafeTarEntry, header.Name, header.Linkname)
+	}
+
+	return linker.SymlinkIfPossible(filepath.FromSlash(header.Linkname), target)
+}
+
+// extractHardLink creates the file of a hard link entry. The content of the linked file, which must be already extracted inside the destination, is copied because afero does not support hard links
+func (t *Tar) extractHardLink(header *tar.Header, destination string, usage *entity.ProjectArchiveUsage) (err error) {
+
+	source, err := t.resolvePath(header.Linkname, destination)
+	if err != nil {
+		return fmt.Errorf("%w: %s -> %s", ErrUnsafeTarEntry, header.Name, header.Linkname)
+	}
+
+	info, err := t.fs.Stat(source)
+	if err != nil {
+		return err
+	}
+
+	if !info.Mode().IsRegular() {
+		return fmt.Errorf("%w: %s -> %s", ErrUnsafeTarEntry, header.Name, header.Linkname)
+	}
+
+	err = usage.AddEntry(header.Name, info.Size())
+	if err != nil {
+		return err
+	}
+
+	// a hard link to itself is kept as it is, since replacing the target would remove the linked file
+	self, err := t.resolvePath(header.Name, destination)
+	if err == nil && self == source {
+		return nil
+	}
+
+	target, err := t.entryTarget(header.Name, destination)
+	if err != nil {
+		return err
+	}
+
+	sourceFile, err := t.fs.Open(source)
+	if err != nil {
+		return err
+	}
+	defer sourceFile.Close()
+
+	targetFile, err := t.fs.OpenFile(target, os.O_RDWR|os.O_CREATE|os.O_TRUNC, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(targetFile, sourceFile)
+	errClose := targetFile.Close()
+	if err != nil {
+		return err
+	}
+	if errClose != nil {
+		return errClose
+	}
+
+	return t.fs.Chtimes(target, info.ModTime(), info.ModTime())
+}
+
+// entryTarget returns the path where a file, or a link, of the tar entry is extracted. The parent directory is created when it does not exist, and an existing file or link on the path is replaced
+func (t *Tar) entryTarget(name string, destination string) (string, error) {
+
+	cleanName := filepath.Clean(filepath.FromSlash(name))
+	if cleanName == "." {
+		return "", fmt.Errorf("%w: %s", ErrUnsafeTarEntry, name)
+	}
+
+	parent, err := t.resolvePath(filepath.Dir(cleanName), destination)
+	if err != nil {
+		return "", err
+	}
+
+	err = t.fs.MkdirAll(parent, 0755)
+	if err != nil {
+		return "", err
+	}
+
+	target := filepath.Join(parent, filepath.Base(cleanName))
+	info, err := t.lstat(target)
+	if err == nil && !info.IsDir() {
+		err = t.fs.Remove(target)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return target, nil
+}
+
+// resolvePath returns the path of the tar entry name inside the destination. The symbolic links on the path are resolved as if the destination was the root directory, so the entries going outside the destination through "..", absolute paths or symbolic links are rejected
+func (t *Tar) resolvePath(name string, destination string) (string, error) {
+
+	if isAbsolute(name) {
+		return "", fmt.Errorf("%w: %s", ErrUnsafeTarEntry, name)
+	}
+
+	destination = filepath.Clean(destination)
+	current := destination
+	pending := strings.Split(filepath.ToSlash(name), "/")
+	links := 0
+
+	for len(pending) > 0 {
+		component := pending[0]
+		pending = pending[1:]
+
+		switch component {
+		case "", ".":
+			continue
+		case "..":
+			if current == destination {
+				return "", fmt.Errorf("%w: %s", ErrUnsafeTarEntry, name)
+			}
+			current = filepath.Dir(current)
+			continue
+		}
+
+		next := filepath.Join(current, component)
+		info, err := t.lstat(next)
+		if err != nil || info.Mode()&os.ModeSymlink == 0 {
+			current = next
+			continue
+		}
+
+		links++
+		if links > maxSymlinkResolutions {
+			return "", fmt.Errorf("%w: %s", ErrUnsafeTarEntry, name)
+		}
+
+		reader, ok := t.fs.(afero.LinkReader)
+		if !ok {
+			return "", ErrSymlinkNotSupported
+		}
+
+		linkname, err := reader.ReadlinkIfPossible(next)
+		if err != nil {
+			return "", err
+		}
+
+		if isAbsolute(linkname) {
+			return "", fmt.Errorf("%w: %s", ErrUnsafeTarEntry, name)
+		}
+
+		pending = append(strings.Split(filepath.ToSlash(linkname), "/"), pending...)
+	}
+
+	return current, nil
+}
+
+// validateSymlinks ensures that every symbolic link in the destination points inside it
+func (t *Tar) validateSymlinks(destination string) error {
+	if _, ok := t.fs.(afero.LinkReader); !ok {
+		return nil
+	}
+
+	return afero.Walk(t.fs, destination, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.Mode()&os.ModeSymlink == 0 {
+			return nil
+		}
+
+		relative, err := filepath.Rel(destination, path)
+		if err != nil {
+			return err
+		}
+
+		linkname, err := t.fs.(afero.LinkReader).ReadlinkIfPossible(path)
+		if err != nil {
+			return err
+		}
+
+		_, err = t.resolvePath(filepath.Join(filepath.Dir(relative), linkname), destination)
+		if err != nil {
+			// the link is removed to prevent following it outside of the destination
+			_ = t.fs.Remove(path)
+			return fmt.Errorf("%w: %s -> %s", ErrUnsafeTarEntry, relative, linkname)
+		}
+
+		return nil
+	})
+}
+
+// setAttributes sets the permissions and the modification time of the tar entry
+func (t *Tar) setAttributes(header *tar.Header, target string) error {
+
+	err := t.fs.Chmod(target, header.FileInfo().Mode().Perm())
+	if err != nil {
+		return err
+	}
+
+	if header.ModTime.IsZero() {
+		return nil
+	}
+
+	accessTime := header.AccessTime
+	if accessTime.IsZero() {
+		accessTime = header.ModTime
+	}
+
+	return t.fs.Chtimes(target, accessTime, header.ModTime)
+}
+
+// lstat returns the file info of the path without following the symbolic links, when the filesystem supports it
+func (t *Tar) lstat(path string) (os.FileInfo, error) {
+	if lstater, ok := t.fs.(afero.Lstater); ok {
+		info, _, err := lstater.LstatIfPossible(path)
+		return info, err
+	}
+
+	return t.fs.Stat(path)
+}
+
+// entrySize returns the uncompressed size of the tar entry, which is only set for the regular files
+func entrySize(header *tar.Header) int64 {
+	if header.Typeflag == tar.TypeReg || header.Typeflag == tar.TypeGNUSparse {
+		return header.Size
+	}
+
+	return 0
+}
+
+// isAbsolute returns whether the tar entry name is an absolute path, in either Unix or Windows notation
+func isAbsolute(name string) bool {
+	return filepath.IsAbs(name) || strings.HasPrefix(name, "/") || strings.HasPrefix(name, `\`)
+}
+
 // extractRegularFile extracts a regular file from the tar file
 func (t *Tar) extractRegularFile(tr *tar.Reader, header *tar.Header, destination string) (err error) {
 	var file afero.File
@@ -192,11 +551,7 @@ func (t *Tar) extractRegularFile(tr *tar.Reader, header *tar.Header, destination
 		return ErrReaderNotProvided
 	}
 
-	file, err = t.fs.OpenFile(destination, os.O_RDWR|os.O_CREATE|os.O_TRUNC, os.FileMode(header.Mode))
-	defer func() {
-		err = file.Close()
-	}()
-
+	file, err = t.fs.OpenFile(destination, os.O_RDWR|os.O_CREATE|os.O_TRUNC, header.FileInfo().Mode().Perm())
 	if err != nil {
 		t.logger.Error(
 			fmt.Sprintf("%s: %s", ErrCreatingFileFromTar, err),
@@ -209,6 +564,12 @@ func (t *Tar) extractRegularFile(tr *tar.Reader, header *tar.Header, destination
 
 		return fmt.Errorf("%s: %w", ErrCreatingFileFromTar, err)
 	}
+	defer func() {
+		errClose := file.Close()
+		if err == nil {
+			err = errClose
+		}
+	}()
 
 	if _, err = io.Copy(file, tr); err != nil {
 		t.logger.Error(
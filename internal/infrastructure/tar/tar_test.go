@@ -2,15 +2,42 @@ package tar
 
 import (
 	"archive/tar"
+	"bytes"
 	"io"
+	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/apenella/ransidble/internal/domain/core/entity"
 	"github.com/apenella/ransidble/internal/infrastructure/logger"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
 )
 
+// gitArchiveReader returns a tar file starting with the global header generated by git archive
+func gitArchiveReader(t *testing.T) io.Reader {
+	var buffer bytes.Buffer
+
+	content := []byte("- hosts: all\n")
+	writer := tar.NewWriter(&buffer)
+
+	err := writer.WriteHeader(&tar.Header{
+		Typeflag:   tar.TypeXGlobalHeader,
+		Name:       "pax_global_header",
+		PAXRecords: map[string]string{"comment": "c5b54d6d8a1e4e0f9f6b1b2fd3c4e4b5a6f7a8b9"},
+	})
+	assert.NoError(t, err)
+	err = writer.WriteHeader(&tar.Header{Typeflag: tar.TypeReg, Name: "site.yml", Mode: 0644, Size: int64(len(content))})
+	assert.NoError(t, err)
+	_, err = writer.Write(content)
+	assert.NoError(t, err)
+	assert.NoError(t, writer.Close())
+
+	return &buffer
+}
+
 func TestExtract(t *testing.T) {
 
 	/*
@@ -58,6 +85,22 @@ func TestExtract(t *testing.T) {
 
 			},
 		},
+		{
+			desc:        "Testing extracting content from a tar file generated by git archive",
+			tar:         NewTar(fs, logger.NewFakeLogger()),
+			reader:      gitArchiveReader(t),
+			destination: workingDir,
+			err:         nil,
+			arrangeFunc: func(t *testing.T, tar *Tar) {
+				fs.RemoveAll(workingDir)
+				fs.MkdirAll(workingDir, 0755)
+			},
+			assertFunc: func(t *testing.T, tar *Tar) {
+				content, err := afero.ReadFile(fs, filepath.Join(workingDir, "site.yml"))
+				assert.Nil(t, err)
+				assert.Equal(t, "- hosts: all\n", string(content))
+			},
+		},
 		{
 			desc:        "Testing error extracting content from a tar file when reader is not provided",
 			tar:         NewTar(fs, logger.NewFakeLogger()),
@@ -96,7 +139,7 @@ func TestExtract(t *testing.T) {
 				test.arrangeFunc(t, test.tar)
 			}
 
-			err := test.tar.Extract(test.reader, test.destination)
+			err := test.tar.Extract(test.reader, test.destination, nil)
 			if err != nil {
 				assert.Equal(t, test.err.Error(), err.Error())
 			} else {
@@ -149,3 +192,244 @@ func TestExtractRegularFile(t *testing.T) {
 		})
 	}
 }
+
+// tarEntry represents an entry of the tar files created by the tests
+type tarEntry struct {
+	header  *tar.Header
+	content string
+}
+
+// tarReader returns a tar file holding the entries
+func tarReader(t *testing.T, entries ...tarEntry) io.Reader {
+	var buffer bytes.Buffer
+
+	writer := tar.NewWriter(&buffer)
+	for _, entry := range entries {
+		entry.header.Size = int64(len(entry.content))
+		err := writer.WriteHeader(entry.header)
+		assert.NoError(t, err)
+		_, err = writer.Write([]byte(entry.content))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, writer.Close())
+
+	return &buffer
+}
+
+func TestExtract_LinksAndAttributes(t *testing.T) {
+	modTime := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	longName := filepath.Join("roles", strings.Repeat("long-directory-name/", 8), "main.yml")
+
+	tests := []struct {
+		desc       string
+		fs         afero.Fs
+		entries    []tarEntry
+		usage      *entity.ProjectArchiveUsage
+		err        error
+		assertFunc func(t *testing.T, destination string)
+	}{
+		{
+			desc: "Testing extracting symbolic links, hard links and PAX headers preserving the file modes and modification times",
+			fs:   afero.NewOsFs(),
+			entries: []tarEntry{
+				{header: &tar.Header{Typeflag: tar.TypeXGlobalHeader, Name: "pax_global_header", PAXRecords: map[string]string{"comment": "git archive"}}},
+				{header: &tar.Header{Typeflag: tar.TypeDir, Name: "scripts/", Mode: 0750, ModTime: modTime}},
+				{header: &tar.Header{Typeflag: tar.TypeReg, Name: "scripts/run.sh", Mode: 0755, ModTime: modTime}, content: "#!/bin/sh\n"},
+				{header: &tar.Header{Typeflag: tar.TypeSymlink, Name: "run.sh", Linkname: "scripts/run.sh", Mode: 0777}},
+				{header: &tar.Header{Typeflag: tar.TypeLink, Name: "scripts/run-copy.sh", Linkname: "scripts/run.sh", Mode: 0755, ModTime: modTime}},
+				{header: &tar.Header{Typeflag: tar.TypeReg, Name: longName, Mode: 0600, ModTime: modTime, Format: tar.FormatPAX}, content: "- hosts: all\n"},
+				{header: &tar.Header{Typeflag: tar.TypeSymlink, Name: "roles/common", Linkname: "../scripts", Mode: 0777}},
+			},
+			assertFunc: func(t *testing.T, destination string) {
+				info, err := os.Stat(filepath.Join(destination, "scripts", "run.sh"))
+				assert.NoError(t, err)
+				assert.Equal(t, os.FileMode(0755), info.Mode().Perm())
+				assert.True(t, modTime.Equal(info.ModTime()))
+
+				info, err = os.Stat(filepath.Join(destination, "scripts"))
+				assert.NoError(t, err)
+				assert.Equal(t, os.FileMode(0750), info.Mode().Perm())
+				assert.True(t, modTime.Equal(info.ModTime()))
+
+				linkname, err := os.Readlink(filepath.Join(destination, "run.sh"))
+				assert.NoError(t, err)
+				assert.Equal(t, "scripts/run.sh", linkname)
+
+				content, err := os.ReadFile(filepath.Join(destination, "scripts", "run-copy.sh"))
+				assert.NoError(t, err)
+				assert.Equal(t, "#!/bin/sh\n", string(content))
+
+				info, err = os.Stat(filepath.Join(destination, longName))
+				assert.NoError(t, err)
+				assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+				content, err = os.ReadFile(filepath.Join(destination, "roles", "common", "run.sh"))
+				assert.NoError(t, err)
+				assert.Equal(t, "#!/bin/sh\n", string(content))
+			},
+		},
+		{
+			desc: "Testing extracting a file through a symbolic link to a directory inside the destination",
+			fs:   afero.NewOsFs(),
+			entries: []tarEntry{
+				{header: &tar.Header{Typeflag: tar.TypeDir, Name: "group_vars/", Mode: 0755}},
+				{header: &tar.Header{Typeflag: tar.TypeSymlink, Name: "vars", Linkname: "group_vars", Mode: 0777}},
+				{header: &tar.Header{Typeflag: tar.TypeReg, Name: "vars/all.yml", Mode: 0644}, content: "key: value\n"},
+			},
+			assertFunc: func(t *testing.T, destination string) {
+				content, err := os.ReadFile(filepath.Join(destination, "group_vars", "all.yml"))
+				assert.NoError(t, err)
+				assert.Equal(t, "key: value\n", string(content))
+			},
+		},
+		{
+			desc: "Testing error extracting an entry with an absolute path",
+			fs:   afero.NewOsFs(),
+			entries: []tarEntry{
+				{header: &tar.Header{Typeflag: tar.TypeReg, Name: "/etc/cron.d/job", Mode: 0644}, content: "content"},
+			},
+			err: ErrUnsafeTarEntry,
+		},
+		{
+			desc: "Testing error extracting an entry going outside the destination",
+			fs:   afero.NewOsFs(),
+			entries: []tarEntry{
+				{header: &tar.Header{Typeflag: tar.TypeReg, Name: "dir/../../escape.yml", Mode: 0644}, content: "content"},
+			},
+			err: ErrUnsafeTarEntry,
+		},
+		{
+			desc: "Testing error extracting a directory going outside the destination",
+			fs:   afero.NewOsFs(),
+			entries: []tarEntry{
+				{header: &tar.Header{Typeflag: tar.TypeDir, Name: "../escape/", Mode: 0755}},
+			},
+			err: ErrUnsafeTarEntry,
+		},
+		{
+			desc: "Testing error extracting a symbolic link pointing outside the destination",
+			fs:   afero.NewOsFs(),
+			entries: []tarEntry{
+				{header: &tar.Header{Typeflag: tar.TypeSymlink, Name: "dir/escape", Linkname: "../../outside", Mode: 0777}},
+			},
+			err: ErrUnsafeTarEntry,
+		},
+		{
+			desc: "Testing error extracting a symbolic link with an absolute target",
+			fs:   afero.NewOsFs(),
+			entries: []tarEntry{
+				{header: &tar.Header{Typeflag: tar.TypeSymlink, Name: "passwd", Linkname: "/etc/passwd", Mode: 0777}},
+			},
+			err: ErrUnsafeTarEntry,
+		},
+		{
+			desc: "Testing error extracting a symbolic link that goes outside the destination once the link it goes through is replaced",
+			fs:   afero.NewOsFs(),
+			entries: []tarEntry{
+				{header: &tar.Header{Typeflag: tar.TypeDir, Name: "sub/deep/", Mode: 0755}},
+				{header: &tar.Header{Typeflag: tar.TypeSymlink, Name: "a", Linkname: "sub/deep", Mode: 0777}},
+				{header: &tar.Header{Typeflag: tar.TypeSymlink, Name: "b", Linkname: "a/../..", Mode: 0777}},
+				{header: &tar.Header{Typeflag: tar.TypeSymlink, Name: "a", Linkname: ".", Mode: 0777}},
+			},
+			err: ErrUnsafeTarEntry,
+			assertFunc: func(t *testing.T, destination string) {
+				_, err := os.Lstat(filepath.Join(destination, "b"))
+				assert.True(t, os.IsNotExist(err))
+			},
+		},
+		{
+			desc: "Testing extracting a file whose path goes up through a symbolic link keeps it inside the destination",
+			fs:   afero.NewOsFs(),
+			entries: []tarEntry{
+				{header: &tar.Header{Typeflag: tar.TypeSymlink, Name: "a", Linkname: ".", Mode: 0777}},
+				{header: &tar.Header{Typeflag: tar.TypeReg, Name: "a/../site.yml", Mode: 0644}, content: "content"},
+			},
+			assertFunc: func(t *testing.T, destination string) {
+				_, err := os.Stat(filepath.Join(destination, "site.yml"))
+				assert.NoError(t, err)
+				_, err = os.Stat(filepath.Join(filepath.Dir(destination), "site.yml"))
+				assert.True(t, os.IsNotExist(err))
+			},
+		},
+		{
+			desc: "Testing error extracting a hard link to a file outside the destination",
+			fs:   afero.NewOsFs(),
+			entries: []tarEntry{
+				{header: &tar.Header{Typeflag: tar.TypeLink, Name: "passwd", Linkname: "../../etc/passwd", Mode: 0644}},
+			},
+			err: ErrUnsafeTarEntry,
+		},
+		{
+			desc: "Testing error extracting more entries than the maximum number of entries",
+			fs:   afero.NewOsFs(),
+			entries: []tarEntry{
+				{header: &tar.Header{Typeflag: tar.TypeDir, Name: "roles/", Mode: 0755}},
+				{header: &tar.Header{Typeflag: tar.TypeReg, Name: "site.yml", Mode: 0644}, content: "- hosts: all\n"},
+			},
+			usage: (&entity.ProjectArchiveLimits{MaxEntries: 1}).NewUsage(0),
+			err:   entity.ErrProjectArchiveLimitExceeded,
+			assertFunc: func(t *testing.T, destination string) {
+				_, err := os.Stat(filepath.Join(destination, "site.yml"))
+				assert.True(t, os.IsNotExist(err))
+			},
+		},
+		{
+			desc: "Testing error extracting a file larger than the maximum file size",
+			fs:   afero.NewOsFs(),
+			entries: []tarEntry{
+				{header: &tar.Header{Typeflag: tar.TypeReg, Name: "site.yml", Mode: 0644}, content: "- hosts: all\n"},
+			},
+			usage: (&entity.ProjectArchiveLimits{MaxFileSize: 5}).NewUsage(0),
+			err:   entity.ErrProjectArchiveLimitExceeded,
+		},
+		{
+			desc: "Testing error extracting a hard link exceeding the maximum uncompressed size",
+			fs:   afero.NewOsFs(),
+			entries: []tarEntry{
+				{header: &tar.Header{Typeflag: tar.TypeReg, Name: "site.yml", Mode: 0644}, content: "- hosts: all\n"},
+				{header: &tar.Header{Typeflag: tar.TypeLink, Name: "copy.yml", Linkname: "site.yml", Mode: 0644}},
+			},
+			usage: (&entity.ProjectArchiveLimits{MaxUncompressedSize: 20}).NewUsage(0),
+			err:   entity.ErrProjectArchiveLimitExceeded,
+		},
+		{
+			desc: "Testing error extracting files exceeding the maximum compression ratio",
+			fs:   afero.NewOsFs(),
+			entries: []tarEntry{
+				{header: &tar.Header{Typeflag: tar.TypeReg, Name: "site.yml", Mode: 0644}, content: strings.Repeat("- hosts: all\n", 100)},
+			},
+			usage: (&entity.ProjectArchiveLimits{MaxCompressionRatio: 10}).NewUsage(100),
+			err:   entity.ErrProjectArchiveLimitExceeded,
+		},
+		{
+			desc: "Testing error extracting a symbolic link when the filesystem does not support them",
+			fs:   afero.NewMemMapFs(),
+			entries: []tarEntry{
+				{header: &tar.Header{Typeflag: tar.TypeSymlink, Name: "run.sh", Linkname: "scripts/run.sh", Mode: 0777}},
+			},
+			err: ErrSymlinkNotSupported,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			destination := filepath.Join(t.TempDir(), "working-dir")
+			err := test.fs.MkdirAll(destination, 0755)
+			assert.NoError(t, err)
+
+			err = NewTar(test.fs, logger.NewFakeLogger()).Extract(tarReader(t, test.entries...), destination, test.usage)
+			if test.err != nil {
+				assert.ErrorIs(t, err, test.err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if test.assertFunc != nil {
+				test.assertFunc(t, destination)
+			}
+		})
+	}
+}
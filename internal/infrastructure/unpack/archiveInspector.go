@@ -0,0 +1,192 @@
+package unpack
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	"github.com/apenella/ransidble/internal/domain/ports/repository"
+)
+
+// ArchiveInspector checks the content of the uploaded source code of the projects against the archive limits. The entries are read from the archive headers, so the content is decompressed but never written to the disk
+type ArchiveInspector struct {
+	// logger is the logger
+	logger repository.Logger
+}
+
+// Ensure ArchiveInspector implements the SourceCodeArchiveInspector interface
+var _ repository.SourceCodeArchiveInspector = (*ArchiveInspector)(nil)
+
+// NewArchiveInspector method creates a new ArchiveInspector struct
+func NewArchiveInspector(logger repository.Logger) *ArchiveInspector {
+	return &ArchiveInspector{
+		logger: logger,
+	}
+}
+
+// Inspect method checks the size of the packed source code, and the entries it holds, against the archive limits. The formats that are not packed are not inspected
+func (i *ArchiveInspector) Inspect(format string, content io.ReaderAt, size int64, limits *entity.ProjectArchiveLimits) error {
+	var err error
+
+	if content == nil {
+		i.logger.Error(ErrSourceCodeContentNotProvided.Error(),
+			map[string]interface{}{
+				"component": "ArchiveInspector.Inspect",
+				"package":   "github.com/apenella/ransidble/internal/infrastructure/unpack",
+			})
+		return ErrSourceCodeContentNotProvided
+	}
+
+	err = limits.CheckUploadSize(size)
+	if err != nil {
+		i.logger.Error(err.Error(),
+			map[string]interface{}{
+				"component": "ArchiveInspector.Inspect",
+				"format":    format,
+				"package":   "github.com/apenella/ransidble/internal/infrastructure/unpack",
+			})
+		return err
+	}
+
+	usage := limits.NewUsage(size)
+	if usage == nil {
+		return nil
+	}
+
+	reader := io.NewSectionReader(content, 0, size)
+
+	switch format {
+	case entity.ProjectFormatTar, entity.ProjectFormatOCI:
+		err = inspectTar(reader, usage)
+	case entity.ProjectFormatTarGz:
+		err = inspectTarGzip(reader, usage)
+	case entity.ProjectFormatTarZst:
+		err = inspectCommandTar(zstdCommand, reader, usage)
+	case entity.ProjectFormatTarXz:
+		err = inspectCommandTar(xzCommand, reader, usage)
+	case entity.ProjectFormatZip:
+		err = inspectZip(content, size, usage)
+	}
+	if err != nil {
+		i.logger.Error(
+			fmt.Sprintf("%s: %s", ErrInspectingSourceCodeFile, err),
+			map[string]interface{}{
+				"component": "ArchiveInspector.Inspect",
+				"format":    format,
+				"package":   "github.com/apenella/ransidble/internal/infrastructure/unpack",
+			})
+		return fmt.Errorf("%s: %w", ErrInspectingSourceCodeFile, err)
+	}
+
+	return nil
+}
+
+// inspectTar accounts the entries of a tar file
+func inspectTar(reader io.Reader, usage *entity.ProjectArchiveUsage) error {
+
+	tarReader := tar.NewReader(reader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if header.Typeflag == tar.TypeXGlobalHeader {
+			continue
+		}
+
+		size := int64(0)
+		if header.Typeflag == tar.TypeReg || header.Typeflag == tar.TypeGNUSparse {
+			size = header.Size
+		}
+
+		err = usage.AddEntry(header.Name, size)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// inspectTarGzip accounts the entries of a tar.gz file
+func inspectTarGzip(reader io.Reader, usage *entity.ProjectArchiveUsage) error {
+
+	gzipReader, err := gzip.NewReader(reader)
+	if err != nil {
+		return fmt.Errorf("%s: %w", ErrCreatingGzipReader, err)
+	}
+	defer gzipReader.Close()
+
+	return inspectTar(gzipReader, usage)
+}
+
+// inspectCommandTar accounts the entries of a tar file decompressed by an external command
+func inspectCommandTar(command string, reader io.Reader, usage *entity.ProjectArchiveUsage) error {
+	var stderr bytes.Buffer
+
+	cmd := exec.Command(command, decompressCommandArgs...)
+	cmd.Stdin = reader
+	cmd.Stderr = &stderr
+
+	decompressedReader, err := cmd.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("%s: %w", ErrDecompressingSourceCodeFile, err)
+	}
+
+	err = cmd.Start()
+	if err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			err = fmt.Errorf("%w: %s", ErrDecompressCommandNotAvailable, command)
+		}
+		return fmt.Errorf("%s: %w", ErrDecompressingSourceCodeFile, err)
+	}
+
+	err = inspectTar(decompressedReader, usage)
+	if err != nil {
+		// the command is stopped because its output is not read anymore
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+		return err
+	}
+
+	err = drainTrailingData(decompressedReader, usage)
+	if err != nil {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+		return err
+	}
+
+	err = cmd.Wait()
+	if err != nil {
+		return fmt.Errorf("%s: %w: %s", ErrDecompressingSourceCodeFile, err, strings.TrimSpace(stderr.String()))
+	}
+
+	return nil
+}
+
+// inspectZip accounts the entries of a zip file using their declared uncompressed size, which the zip reader enforces when the entries are extracted
+func inspectZip(content io.ReaderAt, size int64, usage *entity.ProjectArchiveUsage) error {
+
+	zipReader, err := zip.NewReader(content, size)
+	if err != nil {
+		return fmt.Errorf("%s: %w", ErrCreatingZipReader, err)
+	}
+
+	for _, zipFile := range zipReader.File {
+		err = usage.AddEntry(zipFile.Name, int64(zipFile.UncompressedSize64))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,124 @@
+package unpack
+
+import (
+	"bytes"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	"github.com/apenella/ransidble/internal/infrastructure/logger"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArchiveInspectorInspect(t *testing.T) {
+
+	tests := []struct {
+		desc    string
+		format  string
+		file    string
+		command string
+		limits  *entity.ProjectArchiveLimits
+		err     error
+	}{
+		{
+			desc:   "Testing inspecting a project in tar.gz format within the archive limits",
+			format: entity.ProjectFormatTarGz,
+			file:   filepath.Join("fixtures", "unpack", "project-targz", "project.tar.gz"),
+			limits: &entity.ProjectArchiveLimits{MaxCompressionRatio: 10, MaxEntries: 2, MaxFileSize: 1024, MaxUncompressedSize: 1024, MaxUploadSize: 1024},
+		},
+		{
+			desc:   "Testing inspecting a project without archive limits",
+			format: entity.ProjectFormatTarGz,
+			file:   filepath.Join("fixtures", "unpack", "project-targz", "project.tar.gz"),
+		},
+		{
+			desc:   "Testing inspecting a project in plain format, which is not inspected",
+			format: entity.ProjectFormatPlain,
+			file:   filepath.Join("fixtures", "unpack", "project-plain", "site.yaml"),
+			limits: &entity.ProjectArchiveLimits{MaxEntries: 1},
+		},
+		{
+			desc:   "Testing error inspecting a project in tar.gz format exceeding the maximum number of entries",
+			format: entity.ProjectFormatTarGz,
+			file:   filepath.Join("fixtures", "unpack", "project-targz", "project.tar.gz"),
+			limits: &entity.ProjectArchiveLimits{MaxEntries: 1},
+			err:    entity.ErrProjectArchiveLimitExceeded,
+		},
+		{
+			desc:   "Testing error inspecting a project in tar format exceeding the maximum file size",
+			format: entity.ProjectFormatTar,
+			file:   filepath.Join("fixtures", "unpack", "project-tar", "project.tar"),
+			limits: &entity.ProjectArchiveLimits{MaxFileSize: 1},
+			err:    entity.ErrProjectArchiveLimitExceeded,
+		},
+		{
+			desc:   "Testing error inspecting a project in zip format exceeding the maximum uncompressed size",
+			format: entity.ProjectFormatZip,
+			file:   filepath.Join("fixtures", "unpack", "project-zip", "project.zip"),
+			limits: &entity.ProjectArchiveLimits{MaxUncompressedSize: 1},
+			err:    entity.ErrProjectArchiveLimitExceeded,
+		},
+		{
+			desc:    "Testing error inspecting a project in tar.zst format exceeding the maximum file size",
+			format:  entity.ProjectFormatTarZst,
+			file:    filepath.Join("fixtures", "unpack", "project-tarzst", "project.tar.zst"),
+			command: zstdCommand,
+			limits:  &entity.ProjectArchiveLimits{MaxFileSize: 1},
+			err:     entity.ErrProjectArchiveLimitExceeded,
+		},
+		{
+			desc:    "Testing error inspecting a project in tar.xz format exceeding the maximum file size",
+			format:  entity.ProjectFormatTarXz,
+			file:    filepath.Join("fixtures", "unpack", "project-tarxz", "project.tar.xz"),
+			command: xzCommand,
+			limits:  &entity.ProjectArchiveLimits{MaxFileSize: 1},
+			err:     entity.ErrProjectArchiveLimitExceeded,
+		},
+		{
+			desc:   "Testing error inspecting a project exceeding the maximum upload size",
+			format: entity.ProjectFormatTarGz,
+			file:   filepath.Join("fixtures", "unpack", "project-targz", "project.tar.gz"),
+			limits: &entity.ProjectArchiveLimits{MaxUploadSize: 10},
+			err:    entity.ErrProjectUploadSizeExceeded,
+		},
+		{
+			desc:   "Testing error inspecting a project in tar.gz format whose content is not a gzip file",
+			format: entity.ProjectFormatTarGz,
+			file:   filepath.Join("fixtures", "unpack", "project-tar", "project.tar"),
+			limits: &entity.ProjectArchiveLimits{MaxEntries: 10},
+			err:    ErrInspectingSourceCodeFile,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			if test.command != "" {
+				if _, err := exec.LookPath(test.command); err != nil {
+					t.Skipf("%s command not available", test.command)
+				}
+			}
+
+			content, err := afero.ReadFile(newFixturesFs(), test.file)
+			assert.NoError(t, err)
+
+			err = NewArchiveInspector(logger.NewFakeLogger()).Inspect(test.format, bytes.NewReader(content), int64(len(content)), test.limits)
+			if test.err != nil {
+				assert.ErrorContains(t, err, test.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestArchiveInspectorInspect_ContentNotProvided(t *testing.T) {
+	t.Log("Testing error inspecting a project when the content is not provided")
+
+	err := NewArchiveInspector(logger.NewFakeLogger()).Inspect(entity.ProjectFormatTarGz, nil, 0, &entity.ProjectArchiveLimits{MaxEntries: 1})
+	assert.Equal(t, ErrSourceCodeContentNotProvided, err)
+}
@@ -0,0 +1,184 @@
+package unpack
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	"github.com/apenella/ransidble/internal/domain/ports/repository"
+	"github.com/spf13/afero"
+)
+
+const (
+	// tarRecordSize is the size of the records the tar writers pad the archives to, which bounds the padding that follows the end of a tar archive
+	tarRecordSize = 10240
+)
+
+// decompressCommandArgs are the arguments of the zstd and xz commands to decompress the standard input into the standard output
+var decompressCommandArgs = []string{"--decompress", "--stdout", "--quiet"}
+
+// commandTarFormat unpacks the tar files compressed with an algorithm that is not supported by the standard library. The file is decompressed by an external command, whose output is streamed to the tar extractor. The command must be available in the PATH, which is not the case of the distroless container image, so the formats are only registered when the command is available
+type commandTarFormat struct {
+	// component is the component name used on the log messages
+	component string
+	// command is the command that decompresses the file. It reads the compressed content from the standard input and writes the decompressed content to the standard output
+	command string
+	// args are the arguments of the command
+	args []string
+	// extractor extracts the decompressed tar content
+	extractor repository.SourceCodeTarExtractorer
+	// fs is the filesystem
+	fs afero.Fs
+	// limits are the archive limits enforced while the file is extracted
+	limits *entity.ProjectArchiveLimits
+	// logger is the logger
+	logger repository.Logger
+}
+
+// available returns an error when the command is not found in the PATH
+func (a *commandTarFormat) available() error {
+	_, err := exec.LookPath(a.command)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrDecompressCommandNotAvailable, a.command)
+	}
+
+	return nil
+}
+
+// unpack method decompresses and extracts the tar file of the project into the working directory. The command is killed when the context is done
+func (a *commandTarFormat) unpack(ctx context.Context, project *entity.Project, workingDir string) error {
+	var stderr bytes.Buffer
+
+	err := validateUnpackParameters(a.fs, a.logger, a.component, project, workingDir)
+	if err != nil {
+		return err
+	}
+
+	if a.extractor == nil {
+		a.logger.Error(ErrTarExtractorNotProvided.Error(),
+			map[string]interface{}{
+				"component": a.component,
+				"package":   "github.com/apenella/ransidble/internal/infrastructure/unpack",
+			})
+		return ErrTarExtractorNotProvided
+	}
+
+	sourceCodeFile, err := openSourceCodeFile(a.fs, a.logger, a.component, project, workingDir)
+	if err != nil {
+		return err
+	}
+	defer sourceCodeFile.Close()
+
+	usage, err := newArchiveUsage(a.logger, a.component, a.limits, sourceCodeFile)
+	if err != nil {
+		return err
+	}
+
+	cmd := exec.CommandContext(ctx, a.command, a.args...)
+	cmd.Stdin = sourceCodeFile
+	cmd.Stderr = &stderr
+
+	decompressedReader, err := cmd.StdoutPipe()
+	if err != nil {
+		a.logger.Error(
+			fmt.Sprintf("%s: %s", ErrDecompressingSourceCodeFile, err),
+			map[string]interface{}{
+				"command":     a.command,
+				"component":   a.component,
+				"package":     "github.com/apenella/ransidble/internal/infrastructure/unpack",
+				"source_file": sourceCodeFile.Name(),
+			})
+		return fmt.Errorf("%s: %w", ErrDecompressingSourceCodeFile, err)
+	}
+
+	err = cmd.Start()
+	if err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			err = fmt.Errorf("%w: %s", ErrDecompressCommandNotAvailable, a.command)
+		}
+		a.logger.Error(
+			fmt.Sprintf("%s: %s", ErrDecompressingSourceCodeFile, err),
+			map[string]interface{}{
+				"command":     a.command,
+				"component":   a.component,
+				"package":     "github.com/apenella/ransidble/internal/infrastructure/unpack",
+				"source_file": sourceCodeFile.Name(),
+			})
+		return fmt.Errorf("%s: %w", ErrDecompressingSourceCodeFile, err)
+	}
+
+	err = a.extractor.Extract(decompressedReader, workingDir, usage)
+	if err != nil {
+		// the command is stopped because its output is not read anymore
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+
+		a.logger.Error(
+			fmt.Sprintf("%s: %s", ErrExtractingSourceCodeFile, err),
+			map[string]interface{}{
+				"command":     a.command,
+				"component":   a.component,
+				"package":     "github.com/apenella/ransidble/internal/infrastructure/unpack",
+				"source_file": sourceCodeFile.Name(),
+				"stderr":      strings.TrimSpace(stderr.String()),
+				"working_dir": workingDir,
+			})
+		return fmt.Errorf("%s: %w", ErrExtractingSourceCodeFile, err)
+	}
+
+	// the tar archive may be followed by padding, which must be read to let the command finish
+	err = drainTrailingData(decompressedReader, usage)
+	if err != nil {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+
+		a.logger.Error(
+			fmt.Sprintf("%s: %s", ErrExtractingSourceCodeFile, err),
+			map[string]interface{}{
+				"command":     a.command,
+				"component":   a.component,
+				"package":     "github.com/apenella/ransidble/internal/infrastructure/unpack",
+				"source_file": sourceCodeFile.Name(),
+				"working_dir": workingDir,
+			})
+		return fmt.Errorf("%s: %w", ErrExtractingSourceCodeFile, err)
+	}
+
+	err = cmd.Wait()
+	if err != nil {
+		a.logger.Error(
+			fmt.Sprintf("%s: %s", ErrDecompressingSourceCodeFile, err),
+			map[string]interface{}{
+				"command":     a.command,
+				"component":   a.component,
+				"package":     "github.com/apenella/ransidble/internal/infrastructure/unpack",
+				"source_file": sourceCodeFile.Name(),
+				"stderr":      strings.TrimSpace(stderr.String()),
+			})
+		return fmt.Errorf("%s: %w", ErrDecompressingSourceCodeFile, err)
+	}
+
+	return nil
+}
+
+// drainTrailingData reads the data that follows the end of the tar archive, which the command must write before it finishes. When the archive limits bound the uncompressed size, the data read is bounded by the uncompressed size they still allow plus a tar record of padding, so a stream that keeps decompressing after the tar archive is rejected instead of being read until its end
+func drainTrailingData(reader io.Reader, usage *entity.ProjectArchiveUsage) error {
+	remaining, limited := usage.RemainingUncompressedSize()
+	if !limited {
+		_, _ = io.Copy(io.Discard, reader)
+		return nil
+	}
+
+	maxTrailingSize := remaining + tarRecordSize
+	read, _ := io.Copy(io.Discard, io.LimitReader(reader, maxTrailingSize+1))
+	if read > maxTrailingSize {
+		return fmt.Errorf("%w: the data trailing the tar archive exceeds the maximum of %d bytes", entity.ErrProjectArchiveLimitExceeded, maxTrailingSize)
+	}
+
+	return nil
+}
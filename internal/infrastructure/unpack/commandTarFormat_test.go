@@ -0,0 +1,198 @@
+package unpack
+
+import (
+	"bytes"
+	"context"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	"github.com/apenella/ransidble/internal/domain/ports/repository"
+	"github.com/apenella/ransidble/internal/infrastructure/logger"
+	"github.com/apenella/ransidble/internal/infrastructure/tar"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommandTarFormatUnpack(t *testing.T) {
+
+	tests := []struct {
+		desc       string
+		command    string
+		ctx        context.Context
+		unpack     func(fs afero.Fs) repository.SourceCodeUnpacker
+		project    *entity.Project
+		workingDir string
+		err        error
+	}{
+		{
+			desc:    "Testing unpack project in tar.zst format",
+			command: zstdCommand,
+			unpack: func(fs afero.Fs) repository.SourceCodeUnpacker {
+				return NewTarZstdFormat(fs, tar.NewTar(fs, logger.NewFakeLogger()), logger.NewFakeLogger())
+			},
+			project:    entity.NewProject("project-tarzst", "v1.0.0", "project.tar.zst", entity.ProjectFormatTarZst, entity.ProjectTypeLocal),
+			workingDir: filepath.Join("fixtures", "unpack", "project-tarzst"),
+		},
+		{
+			desc:    "Testing unpack project in tar.xz format",
+			command: xzCommand,
+			unpack: func(fs afero.Fs) repository.SourceCodeUnpacker {
+				return NewTarXzFormat(fs, tar.NewTar(fs, logger.NewFakeLogger()), logger.NewFakeLogger())
+			},
+			project:    entity.NewProject("project-tarxz", "v1.0.0", "project.tar.xz", entity.ProjectFormatTarXz, entity.ProjectTypeLocal),
+			workingDir: filepath.Join("fixtures", "unpack", "project-tarxz"),
+		},
+		{
+			desc:    "Testing error unpacking project in tar.zst format when the source code file is not compressed with zstd",
+			command: zstdCommand,
+			unpack: func(fs afero.Fs) repository.SourceCodeUnpacker {
+				return NewTarZstdFormat(fs, tar.NewTar(fs, logger.NewFakeLogger()), logger.NewFakeLogger())
+			},
+			project:    entity.NewProject("project-zip", "v1.0.0", "project.zip", entity.ProjectFormatTarZst, entity.ProjectTypeLocal),
+			workingDir: filepath.Join("fixtures", "unpack", "project-zip"),
+			err:        ErrDecompressingSourceCodeFile,
+		},
+		{
+			desc:    "Testing error unpacking project in tar.zst format when the context is done",
+			command: zstdCommand,
+			ctx:     cancelledContext(),
+			unpack: func(fs afero.Fs) repository.SourceCodeUnpacker {
+				return NewTarZstdFormat(fs, tar.NewTar(fs, logger.NewFakeLogger()), logger.NewFakeLogger())
+			},
+			project:    entity.NewProject("project-tarzst", "v1.0.0", "project.tar.zst", entity.ProjectFormatTarZst, entity.ProjectTypeLocal),
+			workingDir: filepath.Join("fixtures", "unpack", "project-tarzst"),
+			err:        context.Canceled,
+		},
+		{
+			desc:    "Testing error unpacking project in tar.xz format when project tar extractor is not provided",
+			command: xzCommand,
+			unpack: func(fs afero.Fs) repository.SourceCodeUnpacker {
+				return NewTarXzFormat(fs, nil, logger.NewFakeLogger())
+			},
+			project:    entity.NewProject("project-tarxz", "v1.0.0", "project.tar.xz", entity.ProjectFormatTarXz, entity.ProjectTypeLocal),
+			workingDir: filepath.Join("fixtures", "unpack", "project-tarxz"),
+			err:        ErrTarExtractorNotProvided,
+		},
+		{
+			desc:    "Testing error unpacking project in tar.xz format when the source code file does not exist",
+			command: xzCommand,
+			unpack: func(fs afero.Fs) repository.SourceCodeUnpacker {
+				return NewTarXzFormat(fs, tar.NewTar(fs, logger.NewFakeLogger()), logger.NewFakeLogger())
+			},
+			project:    entity.NewProject("project-tarxz", "v1.0.0", "unknown.tar.xz", entity.ProjectFormatTarXz, entity.ProjectTypeLocal),
+			workingDir: filepath.Join("fixtures", "unpack", "project-tarxz"),
+			err:        ErrSourceCodeFileNotExist,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			if _, err := exec.LookPath(test.command); err != nil {
+				t.Skipf("%s command not available", test.command)
+			}
+
+			ctx := test.ctx
+			if ctx == nil {
+				ctx = context.Background()
+			}
+
+			fs := newFixturesFs()
+			err := test.unpack(fs).Unpack(ctx, test.project, test.workingDir)
+			if test.err != nil {
+				assert.ErrorContains(t, err, test.err.Error())
+			} else {
+				assert.NoError(t, err)
+				_, err = fs.Stat(filepath.Join(test.workingDir, "site.yml"))
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCommandTarFormatUnpack_CommandNotAvailable(t *testing.T) {
+	t.Log("Testing error unpacking a project when the decompress command is not available")
+
+	fs := newFixturesFs()
+	unpacker := &commandTarFormat{
+		component: "TarZstdFormat.Unpack",
+		command:   "ransidble-unknown-decompress-command",
+		args:      decompressCommandArgs,
+		extractor: tar.NewTar(fs, logger.NewFakeLogger()),
+		fs:        fs,
+		logger:    logger.NewFakeLogger(),
+	}
+
+	err := unpacker.unpack(context.Background(), entity.NewProject("project-tarzst", "v1.0.0", "project.tar.zst", entity.ProjectFormatTarZst, entity.ProjectTypeLocal), filepath.Join("fixtures", "unpack", "project-tarzst"))
+	assert.ErrorIs(t, err, ErrDecompressCommandNotAvailable)
+}
+
+func TestCommandTarFormatAvailable(t *testing.T) {
+	t.Log("Testing the availability of the decompress commands")
+
+	unpacker := &commandTarFormat{command: "ransidble-unknown-decompress-command"}
+	assert.ErrorIs(t, unpacker.available(), ErrDecompressCommandNotAvailable)
+
+	if _, err := exec.LookPath(zstdCommand); err != nil {
+		t.Skipf("%s command not available", zstdCommand)
+	}
+	assert.NoError(t, NewTarZstdFormat(afero.NewMemMapFs(), nil, logger.NewFakeLogger()).Available())
+}
+
+func TestDrainTrailingData(t *testing.T) {
+
+	tests := []struct {
+		desc   string
+		limits *entity.ProjectArchiveLimits
+		data   []byte
+		err    error
+	}{
+		{
+			desc:   "Testing draining the data trailing a tar archive without limits",
+			limits: nil,
+			data:   make([]byte, 10*tarRecordSize),
+		},
+		{
+			desc:   "Testing draining the padding trailing a tar archive within the limits",
+			limits: &entity.ProjectArchiveLimits{MaxUncompressedSize: 100},
+			data:   make([]byte, tarRecordSize),
+		},
+		{
+			desc:   "Testing error draining the data trailing a tar archive when it exceeds the limits",
+			limits: &entity.ProjectArchiveLimits{MaxUncompressedSize: 100},
+			data:   make([]byte, 10*tarRecordSize),
+			err:    entity.ErrProjectArchiveLimitExceeded,
+		},
+		{
+			desc:   "Testing error draining the data trailing a tar archive when it exceeds the compression ratio",
+			limits: &entity.ProjectArchiveLimits{MaxCompressionRatio: 10},
+			data:   make([]byte, tarRecordSize+1001),
+			err:    entity.ErrProjectArchiveLimitExceeded,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			err := drainTrailingData(bytes.NewReader(test.data), test.limits.NewUsage(100))
+			if test.err != nil {
+				assert.ErrorIs(t, err, test.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+// cancelledContext returns a context that is already done
+func cancelledContext() context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	return ctx
+}
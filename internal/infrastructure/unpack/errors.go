@@ -27,8 +27,26 @@ var (
 	ErrSourceCodeFileNotExist = errors.New("source code file does not exist")
 	// ErrOpeningSourceCodeFile is returned when the source code file cannot be opened
 	ErrOpeningSourceCodeFile = errors.New("an error occurred opening source code file")
+	// ErrDescribingSourceCodeFile is returned when the size of the source code file cannot be described
+	ErrDescribingSourceCodeFile = errors.New("an error occurred describing source code file")
 	// ErrCreatingGzipReader is returned when the gzip reader cannot be created
 	ErrCreatingGzipReader = errors.New("an error occurred creating gzip reader")
+	// ErrCreatingZipReader is returned when the zip reader cannot be created
+	ErrCreatingZipReader = errors.New("an error occurred creating zip reader")
+	// ErrDecompressCommandNotAvailable is returned when the command that decompresses the source code file is not available in the PATH
+	ErrDecompressCommandNotAvailable = errors.New("decompress command not available")
+	// ErrDecompressingSourceCodeFile is returned when the source code file cannot be decompressed
+	ErrDecompressingSourceCodeFile = errors.New("an error occurred decompressing source code file")
+	// ErrStagingOCILayout is returned when the OCI image layout cannot be staged to be unpacked
+	ErrStagingOCILayout = errors.New("an error occurred staging OCI image layout")
+	// ErrUnsupportedLayerMediaType is returned when an OCI layer is neither a tar file nor a file named by its title annotation
+	ErrUnsupportedLayerMediaType = errors.New("unsupported layer media type")
+	// ErrUnsafeArchiveEntry is returned when an archive entry would be extracted outside of the working directory, or it is not a regular file or a directory
+	ErrUnsafeArchiveEntry = errors.New("unsafe archive entry")
+	// ErrInspectingSourceCodeFile is returned when the content of the source code file cannot be inspected, or it exceeds the archive limits
+	ErrInspectingSourceCodeFile = errors.New("an error occurred inspecting source code file")
+	// ErrSourceCodeContentNotProvided is returned when the content of the source code to inspect is not provided
+	ErrSourceCodeContentNotProvided = errors.New("source code content not provided")
 	// ErrExtractingSourceCodeFile is returned when the source code file cannot be extracted
 	ErrExtractingSourceCodeFile = errors.New("an error occurred extracting source code file")
 	// ErrDescribingProjectReferenece is returned when the project reference cannot be described
@@ -0,0 +1,319 @@
+package unpack
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	"github.com/apenella/ransidble/internal/domain/ports/repository"
+	"github.com/apenella/ransidble/internal/infrastructure/oci"
+	"github.com/spf13/afero"
+)
+
+const (
+	// whiteoutPrefix is the prefix of the layer entries that remove a file of the lower layers
+	whiteoutPrefix = ".wh."
+	// whiteoutOpaque is the layer entry that removes the content of its directory from the lower layers
+	whiteoutOpaque = ".wh..wh..opq"
+	// defaultOCIFileMode is the mode of the extracted files whose permissions are not set in the layer
+	defaultOCIFileMode os.FileMode = 0644
+	// defaultOCIDirMode is the mode of the extracted directories
+	defaultOCIDirMode os.FileMode = 0755
+)
+
+// OCIFormat struct used to unpack the OCI image-layout tarballs, either uploaded or fetched from a registry. The layers are flattened into the working directory, from the base to the top layer
+type OCIFormat struct {
+	// fs is the filesystem
+	fs afero.Fs
+	// limits are the archive limits enforced while the layers are applied
+	limits *entity.ProjectArchiveLimits
+	// logger is the logger
+	logger repository.Logger
+}
+
+// Ensure OCIFormat implements the SourceCodeUnpacker interface
+var _ repository.SourceCodeUnpacker = (*OCIFormat)(nil)
+
+// NewOCIFormat method creates a new OCIFormat struct
+func NewOCIFormat(fs afero.Fs, logger repository.Logger) *OCIFormat {
+	return &OCIFormat{
+		fs:     fs,
+		logger: logger,
+	}
+}
+
+// WithLimits sets the archive limits enforced while the layers are applied
+func (a *OCIFormat) WithLimits(limits *entity.ProjectArchiveLimits) *OCIFormat {
+	a.limits = limits
+	return a
+}
+
+// Unpack method flattens the layers of the OCI image layout of the project into the working directory. The manifest must match the digest the project is pinned to, and every layer is verified against its digest before it is applied
+func (a *OCIFormat) Unpack(_ context.Context, project *entity.Project, workingDir string) (err error) {
+
+	err = validateUnpackParameters(a.fs, a.logger, "OCIFormat.Unpack", project, workingDir)
+	if err != nil {
+		return err
+	}
+
+	sourceCodeFile, err := openSourceCodeFile(a.fs, a.logger, "OCIFormat.Unpack", project, workingDir)
+	if err != nil {
+		return err
+	}
+	defer sourceCodeFile.Close()
+
+	usage, err := newArchiveUsage(a.logger, "OCIFormat.Unpack", a.limits, sourceCodeFile)
+	if err != nil {
+		return err
+	}
+
+	// the blobs are staged out of the working directory, so they are never part of the project
+	stagingDir, err := afero.TempDir(a.fs, "", "ransidble-oci-")
+	if err != nil {
+		a.logger.Error(
+			fmt.Sprintf("%s: %s", ErrStagingOCILayout, err),
+			map[string]interface{}{
+				"component":   "OCIFormat.Unpack",
+				"package":     "github.com/apenella/ransidble/internal/infrastructure/unpack",
+				"source_file": sourceCodeFile.Name(),
+			})
+		return fmt.Errorf("%s: %w", ErrStagingOCILayout, err)
+	}
+	defer func() {
+		errRemove := a.fs.RemoveAll(stagingDir)
+		if err == nil {
+			err = errRemove
+		}
+	}()
+
+	layout, err := oci.StageLayout(a.fs, sourceCodeFile, stagingDir)
+	if err != nil {
+		a.logger.Error(
+			fmt.Sprintf("%s: %s", ErrStagingOCILayout, err),
+			map[string]interface{}{
+				"component":   "OCIFormat.Unpack",
+				"package":     "github.com/apenella/ransidble/internal/infrastructure/unpack",
+				"source_file": sourceCodeFile.Name(),
+			})
+		return fmt.Errorf("%s: %w", ErrStagingOCILayout, err)
+	}
+
+	digest := ""
+	if project.OCI != nil {
+		digest = project.OCI.Digest
+	}
+
+	manifest, err := layout.Manifest(digest)
+	if err != nil {
+		a.logger.Error(
+			fmt.Sprintf("%s: %s", ErrExtractingSourceCodeFile, err),
+			map[string]interface{}{
+				"component":   "OCIFormat.Unpack",
+				"digest":      digest,
+				"package":     "github.com/apenella/ransidble/internal/infrastructure/unpack",
+				"source_file": sourceCodeFile.Name(),
+			})
+		return fmt.Errorf("%s: %w", ErrExtractingSourceCodeFile, err)
+	}
+
+	for _, layer := range manifest.Layers {
+		err = a.applyLayer(layout, layer, workingDir, usage)
+		if err != nil {
+			a.logger.Error(
+				fmt.Sprintf("%s: %s", ErrExtractingSourceCodeFile, err),
+				map[string]interface{}{
+					"component":   "OCIFormat.Unpack",
+					"layer":       layer.Digest,
+					"package":     "github.com/apenella/ransidble/internal/infrastructure/unpack",
+					"source_file": sourceCodeFile.Name(),
+					"working_dir": workingDir,
+				})
+			return fmt.Errorf("%s: %w", ErrExtractingSourceCodeFile, err)
+		}
+	}
+
+	return nil
+}
+
+// applyLayer verifies a layer and applies it on the working directory. The tar layers are extracted, and the other layers are written as a file named after their title annotation, as the artifact tools do
+func (a *OCIFormat) applyLayer(layout *oci.Layout, layer oci.Descriptor, workingDir string, usage *entity.ProjectArchiveUsage) error {
+
+	err := layout.VerifyBlob(layer)
+	if err != nil {
+		return err
+	}
+
+	blob, err := layout.OpenBlob(layer)
+	if err != nil {
+		return err
+	}
+	defer blob.Close()
+
+	switch layer.MediaType {
+	case oci.MediaTypeImageLayer:
+		return a.extractLayer(blob, workingDir, usage)
+	case oci.MediaTypeImageLayerGzip, oci.MediaTypeDockerLayer:
+		gzipReader, err := gzip.NewReader(blob)
+		if err != nil {
+			return fmt.Errorf("%s: %w", ErrCreatingGzipReader, err)
+		}
+		defer gzipReader.Close()
+
+		return a.extractLayer(gzipReader, workingDir, usage)
+	}
+
+	title := layer.Annotations[oci.AnnotationTitle]
+	if title == "" {
+		return fmt.Errorf("%w: %s", ErrUnsupportedLayerMediaType, layer.MediaType)
+	}
+
+	target, err := archiveEntryTarget(workingDir, title)
+	if err != nil {
+		return err
+	}
+
+	err = usage.AddEntry(title, layer.Size)
+	if err != nil {
+		return err
+	}
+
+	return a.writeFile(target, blob, defaultOCIFileMode)
+}
+
+// extractLayer extracts a tar layer into the working directory, honouring the whiteout entries that remove the content of the lower layers
+func (a *OCIFormat) extractLayer(reader io.Reader, workingDir string, usage *entity.ProjectArchiveUsage) error {
+
+	tarReader := tar.NewReader(reader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		name := strings.TrimPrefix(header.Name, "./")
+		if name == "" || name == "." {
+			continue
+		}
+
+		target, err := archiveEntryTarget(workingDir, name)
+		if err != nil {
+			return err
+		}
+
+		size := int64(0)
+		if header.Typeflag == tar.TypeReg {
+			size = header.Size
+		}
+
+		err = usage.AddEntry(name, size)
+		if err != nil {
+			return err
+		}
+
+		base := path.Base(name)
+		switch {
+		case base == whiteoutOpaque:
+			err = a.removeDirContent(filepath.Dir(target))
+		case strings.HasPrefix(base, whiteoutPrefix):
+			err = a.removeWhiteout(workingDir, name, header.Name)
+		case header.Typeflag == tar.TypeDir:
+			err = a.fs.MkdirAll(target, defaultOCIDirMode)
+		case header.Typeflag == tar.TypeReg:
+			perm := os.FileMode(header.Mode).Perm()
+			if perm == 0 {
+				perm = defaultOCIFileMode
+			}
+			err = a.writeFile(target, tarReader, perm)
+		case header.Typeflag == tar.TypeSymlink || header.Typeflag == tar.TypeLink:
+			err = fmt.Errorf("%w: %s is a link", ErrUnsafeArchiveEntry, header.Name)
+		default:
+			err = fmt.Errorf("%w: %s is not a regular file", ErrUnsafeArchiveEntry, header.Name)
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// writeFile writes a file of a layer, replacing the file or the directory of the lower layers placed on the same path
+func (a *OCIFormat) writeFile(target string, content io.Reader, perm os.FileMode) (err error) {
+
+	info, err := a.fs.Stat(target)
+	if err == nil && info.IsDir() {
+		err = a.fs.RemoveAll(target)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = a.fs.MkdirAll(filepath.Dir(target), defaultOCIDirMode)
+	if err != nil {
+		return err
+	}
+
+	file, err := a.fs.OpenFile(target, os.O_RDWR|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		errClose := file.Close()
+		if err == nil {
+			err = errClose
+		}
+	}()
+
+	_, err = io.Copy(file, content)
+
+	return err
+}
+
+// removeWhiteout removes the file or directory hidden by a whiteout entry. The hidden path must be placed inside the working directory, and must not be the working directory itself
+func (a *OCIFormat) removeWhiteout(workingDir string, name string, entry string) error {
+
+	hidden := strings.TrimPrefix(path.Base(name), whiteoutPrefix)
+	if hidden == "" || hidden == "." || hidden == ".." || strings.ContainsAny(hidden, `/\`) {
+		return fmt.Errorf("%w: %s", ErrUnsafeArchiveEntry, entry)
+	}
+
+	target, err := archiveEntryTarget(workingDir, path.Join(path.Dir(name), hidden))
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrUnsafeArchiveEntry, entry)
+	}
+
+	if filepath.Clean(target) == filepath.Clean(workingDir) {
+		return fmt.Errorf("%w: %s", ErrUnsafeArchiveEntry, entry)
+	}
+
+	return a.fs.RemoveAll(target)
+}
+
+// removeDirContent removes the content of a directory, keeping the directory itself
+func (a *OCIFormat) removeDirContent(dir string) error {
+
+	entries, err := afero.ReadDir(a.fs, dir)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		err = a.fs.RemoveAll(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,171 @@
+package unpack
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	"github.com/apenella/ransidble/internal/infrastructure/logger"
+	"github.com/apenella/ransidble/internal/infrastructure/oci"
+	"github.com/apenella/ransidble/test/ociregistry"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+// newOCIFormatProject creates a project in oci format pinned to the given manifest digest
+func newOCIFormatProject(digest string) *entity.Project {
+	project := entity.NewProject("project-oci", "v1.0.0", "project.oci.tar", entity.ProjectFormatOCI, entity.ProjectTypeLocal)
+	project.OCI = entity.NewProjectOCISource("", digest)
+
+	return project
+}
+
+func TestOCIFormatUnpack(t *testing.T) {
+
+	workingDir := "/working-dir"
+
+	layered := ociregistry.NewArtifact(
+		map[string]string{"site.yml": "---", "roles/common/tasks/main.yml": "---", "old.yml": "---", "group_vars/all.yml": "---"},
+		map[string]string{"site.yml": "- hosts: all", ".wh.old.yml": "", "group_vars/.wh..wh..opq": "", "group_vars/web.yml": "---"},
+	)
+
+	corrupted := ociregistry.NewArtifact(map[string]string{"site.yml": "---"})
+	for digest, content := range corrupted.Blobs {
+		if content[0] == 0x1f {
+			corrupted.Blobs[digest] = bytes.Repeat([]byte("x"), len(content))
+		}
+	}
+
+	unsafe := ociregistry.NewArtifact(map[string]string{"../escape.yml": "---"})
+	unsafeWhiteout := ociregistry.NewArtifact(map[string]string{"site.yml": "---"}, map[string]string{".wh...": ""})
+	unsafeNestedWhiteout := ociregistry.NewArtifact(map[string]string{"roles/common.yml": "---"}, map[string]string{"roles/.wh...": ""})
+
+	tests := []struct {
+		desc         string
+		project      *entity.Project
+		layout       []byte
+		err          error
+		errContained error
+		assertFunc   func(*testing.T, afero.Fs)
+	}{
+		{
+			desc:    "Testing unpack project in oci format flattening its layers",
+			project: newOCIFormatProject(layered.Digest),
+			layout:  layered.Layout(),
+			assertFunc: func(t *testing.T, fs afero.Fs) {
+				content, err := afero.ReadFile(fs, filepath.Join(workingDir, "site.yml"))
+				assert.NoError(t, err)
+				assert.Equal(t, "- hosts: all", string(content))
+
+				_, err = fs.Stat(filepath.Join(workingDir, "roles", "common", "tasks", "main.yml"))
+				assert.NoError(t, err)
+				_, err = fs.Stat(filepath.Join(workingDir, "group_vars", "web.yml"))
+				assert.NoError(t, err)
+
+				_, err = fs.Stat(filepath.Join(workingDir, "old.yml"))
+				assert.Error(t, err)
+				_, err = fs.Stat(filepath.Join(workingDir, "group_vars", "all.yml"))
+				assert.Error(t, err)
+				_, err = fs.Stat(filepath.Join(workingDir, ".wh.old.yml"))
+				assert.Error(t, err)
+			},
+		},
+		{
+			desc:    "Testing unpack project in oci format without a pinned digest",
+			project: entity.NewProject("project-oci", "v1.0.0", "project.oci.tar", entity.ProjectFormatOCI, entity.ProjectTypeLocal),
+			layout:  layered.Layout(),
+			assertFunc: func(t *testing.T, fs afero.Fs) {
+				_, err := fs.Stat(filepath.Join(workingDir, "site.yml"))
+				assert.NoError(t, err)
+			},
+		},
+		{
+			desc:         "Testing error unpacking project in oci format pinned to a manifest that is not in the layout",
+			project:      newOCIFormatProject(oci.Digest([]byte("unknown"))),
+			layout:       layered.Layout(),
+			errContained: oci.ErrManifestNotFound,
+		},
+		{
+			desc:         "Testing error unpacking project in oci format with a layer that does not match its digest",
+			project:      newOCIFormatProject(corrupted.Digest),
+			layout:       corrupted.Layout(),
+			errContained: oci.ErrDigestMismatch,
+		},
+		{
+			desc:         "Testing error unpacking project in oci format with a layer entry placed outside of the working directory",
+			project:      newOCIFormatProject(unsafe.Digest),
+			layout:       unsafe.Layout(),
+			errContained: ErrUnsafeArchiveEntry,
+		},
+		{
+			desc:         "Testing error unpacking project in oci format with a whiteout entry hiding the parent of the working directory",
+			project:      newOCIFormatProject(unsafeWhiteout.Digest),
+			layout:       unsafeWhiteout.Layout(),
+			errContained: ErrUnsafeArchiveEntry,
+			assertFunc: func(t *testing.T, fs afero.Fs) {
+				_, err := fs.Stat("/sibling.yml")
+				assert.NoError(t, err)
+				_, err = fs.Stat(filepath.Join(workingDir, "site.yml"))
+				assert.NoError(t, err)
+			},
+		},
+		{
+			desc:         "Testing error unpacking project in oci format with a whiteout entry hiding the working directory",
+			project:      newOCIFormatProject(unsafeNestedWhiteout.Digest),
+			layout:       unsafeNestedWhiteout.Layout(),
+			errContained: ErrUnsafeArchiveEntry,
+			assertFunc: func(t *testing.T, fs afero.Fs) {
+				_, err := fs.Stat(filepath.Join(workingDir, "roles", "common.yml"))
+				assert.NoError(t, err)
+			},
+		},
+		{
+			desc:         "Testing error unpacking project in oci format that is not an OCI image layout",
+			project:      newOCIFormatProject(layered.Digest),
+			layout:       []byte("content"),
+			errContained: oci.ErrInvalidLayout,
+		},
+		{
+			desc:    "Testing error unpacking project in oci format when the source code file does not exist",
+			project: entity.NewProject("project-oci", "v1.0.0", "unknown.oci.tar", entity.ProjectFormatOCI, entity.ProjectTypeLocal),
+			layout:  layered.Layout(),
+			err:     ErrSourceCodeFileNotExist,
+		},
+		{
+			desc:   "Testing error unpacking project in oci format when the project is not provided",
+			layout: layered.Layout(),
+			err:    ErrProjectNotProvided,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			fs := afero.NewMemMapFs()
+			err := afero.WriteFile(fs, filepath.Join(workingDir, "project.oci.tar"), test.layout, 0644)
+			assert.NoError(t, err)
+			// a file placed next to the working directory, which must not be reached by the layers
+			err = afero.WriteFile(fs, "/sibling.yml", []byte("---"), 0644)
+			assert.NoError(t, err)
+
+			unpacker := NewOCIFormat(fs, logger.NewFakeLogger())
+			err = unpacker.Unpack(context.Background(), test.project, workingDir)
+			switch {
+			case test.err != nil:
+				assert.Equal(t, test.err, err)
+			case test.errContained != nil:
+				assert.ErrorIs(t, err, test.errContained)
+				if test.assertFunc != nil {
+					test.assertFunc(t, fs)
+				}
+			default:
+				assert.NoError(t, err)
+				test.assertFunc(t, fs)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package unpack
 
 import (
+	"context"
 	"fmt"
 	"os"
 
@@ -29,7 +30,7 @@ func NewPlainFormat(fs afero.Fs, logger repository.Logger) *PlainFormat {
 }
 
 // Unpack method prepares the project into the working directory. Unpacking a plain format project does not require any action. The project is already fetched in the working directory. It just checks if the working directory exists.
-func (p *PlainFormat) Unpack(project *entity.Project, workingDir string) error {
+func (p *PlainFormat) Unpack(_ context.Context, project *entity.Project, workingDir string) error {
 
 	var err error
 	var workingDirExist bool
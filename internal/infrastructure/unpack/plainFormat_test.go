@@ -1,6 +1,7 @@
 package unpack
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
@@ -129,7 +130,7 @@ func TestPlainFormatUnpack(t *testing.T) {
 				test.arrangeFunc(t, test.unpack)
 			}
 
-			err := test.unpack.Unpack(test.project, test.workingDir)
+			err := test.unpack.Unpack(context.Background(), test.project, test.workingDir)
 			if err != nil {
 				assert.Equal(t, test.err.Error(), err.Error())
 			} else {
@@ -0,0 +1,105 @@
+package unpack
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	"github.com/apenella/ransidble/internal/domain/ports/repository"
+	"github.com/spf13/afero"
+)
+
+// validateUnpackParameters validates the parameters required to unpack a project into the working directory
+func validateUnpackParameters(fs afero.Fs, logger repository.Logger, component string, project *entity.Project, workingDir string) error {
+
+	if project == nil {
+		logger.Error(ErrProjectNotProvided.Error(),
+			map[string]interface{}{
+				"component": component,
+				"package":   "github.com/apenella/ransidble/internal/infrastructure/unpack",
+			})
+		return ErrProjectNotProvided
+	}
+
+	if workingDir == "" {
+		logger.Error(ErrWorkingDirNotProvided.Error(),
+			map[string]interface{}{
+				"component": component,
+				"package":   "github.com/apenella/ransidble/internal/infrastructure/unpack",
+			})
+		return ErrWorkingDirNotProvided
+	}
+
+	if fs == nil {
+		logger.Error(ErrFilesystemNotProvided.Error(),
+			map[string]interface{}{
+				"component": component,
+				"package":   "github.com/apenella/ransidble/internal/infrastructure/unpack",
+			})
+		return ErrFilesystemNotProvided
+	}
+
+	return nil
+}
+
+// openSourceCodeFile opens the packed source code of the project, which is located in the working directory and named as the project reference
+func openSourceCodeFile(fs afero.Fs, logger repository.Logger, component string, project *entity.Project, workingDir string) (afero.File, error) {
+
+	if project.Reference == "" {
+		logger.Error(ErrProjectReferenceNotProvided.Error(),
+			map[string]interface{}{
+				"component": component,
+				"package":   "github.com/apenella/ransidble/internal/infrastructure/unpack",
+			})
+		return nil, ErrProjectReferenceNotProvided
+	}
+
+	sourceCodeFile := filepath.Join(workingDir, project.Reference)
+	_, err := fs.Stat(sourceCodeFile)
+	if err != nil {
+		logger.Error(
+			ErrSourceCodeFileNotExist.Error(),
+			map[string]interface{}{
+				"component":   component,
+				"package":     "github.com/apenella/ransidble/internal/infrastructure/unpack",
+				"source_file": sourceCodeFile,
+			})
+		return nil, ErrSourceCodeFileNotExist
+	}
+
+	file, err := fs.Open(sourceCodeFile)
+	if err != nil {
+		logger.Error(
+			fmt.Sprintf("%s: %s", ErrOpeningSourceCodeFile, err),
+			map[string]interface{}{
+				"component":   component,
+				"package":     "github.com/apenella/ransidble/internal/infrastructure/unpack",
+				"source_file": sourceCodeFile,
+			})
+		return nil, fmt.Errorf("%s: %w", ErrOpeningSourceCodeFile, err)
+	}
+
+	return file, nil
+}
+
+// newArchiveUsage returns the usage to account the content of the packed source code file against the archive limits. It returns nil when no limit is set
+func newArchiveUsage(logger repository.Logger, component string, limits *entity.ProjectArchiveLimits, file afero.File) (*entity.ProjectArchiveUsage, error) {
+
+	if !limits.IsEnabled() {
+		return nil, nil
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		logger.Error(
+			fmt.Sprintf("%s: %s", ErrDescribingSourceCodeFile, err),
+			map[string]interface{}{
+				"component":   component,
+				"package":     "github.com/apenella/ransidble/internal/infrastructure/unpack",
+				"source_file": file.Name(),
+			})
+		return nil, fmt.Errorf("%s: %w", ErrDescribingSourceCodeFile, err)
+	}
+
+	return limits.NewUsage(info.Size()), nil
+}
@@ -0,0 +1,80 @@
+package unpack
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	"github.com/apenella/ransidble/internal/domain/ports/repository"
+	"github.com/spf13/afero"
+)
+
+// TarFormat struct used to unpack uncompressed tar files
+type TarFormat struct {
+	fs        afero.Fs
+	logger    repository.Logger
+	extractor repository.SourceCodeTarExtractorer
+	limits    *entity.ProjectArchiveLimits
+}
+
+// Ensure TarFormat implements the SourceCodeUnpacker interface
+var _ repository.SourceCodeUnpacker = (*TarFormat)(nil)
+
+// NewTarFormat method creates a new TarFormat struct
+func NewTarFormat(fs afero.Fs, extractor repository.SourceCodeTarExtractorer, logger repository.Logger) *TarFormat {
+	return &TarFormat{
+		fs:        fs,
+		logger:    logger,
+		extractor: extractor,
+	}
+}
+
+// WithLimits sets the archive limits enforced while the tar file is extracted
+func (a *TarFormat) WithLimits(limits *entity.ProjectArchiveLimits) *TarFormat {
+	a.limits = limits
+	return a
+}
+
+// Unpack method extracts the tar file of the project into the working directory
+func (a *TarFormat) Unpack(_ context.Context, project *entity.Project, workingDir string) error {
+
+	err := validateUnpackParameters(a.fs, a.logger, "TarFormat.Unpack", project, workingDir)
+	if err != nil {
+		return err
+	}
+
+	if a.extractor == nil {
+		a.logger.Error(ErrTarExtractorNotProvided.Error(),
+			map[string]interface{}{
+				"component": "TarFormat.Unpack",
+				"package":   "github.com/apenella/ransidble/internal/infrastructure/unpack",
+			})
+		return ErrTarExtractorNotProvided
+	}
+
+	sourceCodeFile, err := openSourceCodeFile(a.fs, a.logger, "TarFormat.Unpack", project, workingDir)
+	if err != nil {
+		return err
+	}
+	defer sourceCodeFile.Close()
+
+	usage, err := newArchiveUsage(a.logger, "TarFormat.Unpack", a.limits, sourceCodeFile)
+	if err != nil {
+		return err
+	}
+
+	err = a.extractor.Extract(sourceCodeFile, workingDir, usage)
+	if err != nil {
+		a.logger.Error(
+			fmt.Sprintf("%s: %s", ErrExtractingSourceCodeFile, err),
+			map[string]interface{}{
+				"component":   "TarFormat.Unpack",
+				"package":     "github.com/apenella/ransidble/internal/infrastructure/unpack",
+				"source_file": sourceCodeFile.Name(),
+				"working_dir": workingDir,
+			})
+		return fmt.Errorf("%s: %w", ErrExtractingSourceCodeFile, err)
+	}
+
+	return nil
+}
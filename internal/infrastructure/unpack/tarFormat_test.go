@@ -0,0 +1,122 @@
+package unpack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	"github.com/apenella/ransidble/internal/domain/ports/repository"
+	"github.com/apenella/ransidble/internal/infrastructure/logger"
+	"github.com/apenella/ransidble/internal/infrastructure/tar"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// newFixturesFs returns a filesystem that reads the test fixtures and keeps the written files in memory
+func newFixturesFs() afero.Fs {
+	return afero.NewCopyOnWriteFs(
+		afero.NewReadOnlyFs(
+			afero.NewBasePathFs(afero.NewOsFs(), "../../../test"),
+		),
+		afero.NewMemMapFs(),
+	)
+}
+
+func TestTarFormatUnpack(t *testing.T) {
+
+	workingDir := filepath.Join("fixtures", "unpack", "project-tar")
+	fs := newFixturesFs()
+
+	tests := []struct {
+		desc        string
+		unpack      *TarFormat
+		project     *entity.Project
+		workingDir  string
+		err         error
+		arrangeFunc func(*testing.T, *TarFormat)
+		assertFunc  func(*testing.T, *TarFormat)
+	}{
+		{
+			desc:       "Testing unpack project in tar format",
+			unpack:     NewTarFormat(fs, tar.NewTar(fs, logger.NewFakeLogger()), logger.NewFakeLogger()),
+			project:    entity.NewProject("project-tar", "v1.0.0", "project.tar", entity.ProjectFormatTar, entity.ProjectTypeLocal),
+			workingDir: workingDir,
+			assertFunc: func(t *testing.T, unpack *TarFormat) {
+				_, err := unpack.fs.Stat(filepath.Join(workingDir, "site.yml"))
+				assert.NoError(t, err)
+			},
+		},
+		{
+			desc:       "Testing error unpacking project in tar format when project is not provided",
+			unpack:     NewTarFormat(fs, repository.NewMockProjectSourceCodeTarExtractorer(), logger.NewFakeLogger()),
+			workingDir: workingDir,
+			err:        ErrProjectNotProvided,
+		},
+		{
+			desc:    "Testing error unpacking project in tar format when working directory is not provided",
+			unpack:  NewTarFormat(fs, repository.NewMockProjectSourceCodeTarExtractorer(), logger.NewFakeLogger()),
+			project: &entity.Project{},
+			err:     ErrWorkingDirNotProvided,
+		},
+		{
+			desc:       "Testing error unpacking project in tar format when filesystem is not provided",
+			unpack:     NewTarFormat(nil, repository.NewMockProjectSourceCodeTarExtractorer(), logger.NewFakeLogger()),
+			project:    &entity.Project{},
+			workingDir: workingDir,
+			err:        ErrFilesystemNotProvided,
+		},
+		{
+			desc:       "Testing error unpacking project in tar format when project tar extractor is not provided",
+			unpack:     NewTarFormat(fs, nil, logger.NewFakeLogger()),
+			project:    &entity.Project{},
+			workingDir: workingDir,
+			err:        ErrTarExtractorNotProvided,
+		},
+		{
+			desc:       "Testing error unpacking project in tar format when project reference is not provided",
+			unpack:     NewTarFormat(fs, repository.NewMockProjectSourceCodeTarExtractorer(), logger.NewFakeLogger()),
+			project:    &entity.Project{Name: "project-tar"},
+			workingDir: workingDir,
+			err:        ErrProjectReferenceNotProvided,
+		},
+		{
+			desc:       "Testing error unpacking project in tar format when the source code file does not exist",
+			unpack:     NewTarFormat(fs, repository.NewMockProjectSourceCodeTarExtractorer(), logger.NewFakeLogger()),
+			project:    entity.NewProject("project-tar", "v1.0.0", "unknown.tar", entity.ProjectFormatTar, entity.ProjectTypeLocal),
+			workingDir: workingDir,
+			err:        ErrSourceCodeFileNotExist,
+		},
+		{
+			desc:       "Testing error unpacking project in tar format when there is an error extracting tar file",
+			unpack:     NewTarFormat(fs, repository.NewMockProjectSourceCodeTarExtractorer(), logger.NewFakeLogger()),
+			project:    entity.NewProject("project-tar", "v1.0.0", "project.tar", entity.ProjectFormatTar, entity.ProjectTypeLocal),
+			workingDir: workingDir,
+			err:        fmt.Errorf("%s: %w", ErrExtractingSourceCodeFile, errors.New("error extracting tar file")),
+			arrangeFunc: func(t *testing.T, unpack *TarFormat) {
+				unpack.extractor.(*repository.MockProjectSourceCodeTarExtractorer).On("Extract", mock.Anything, workingDir, mock.Anything).Return(errors.New("error extracting tar file"))
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.arrangeFunc != nil {
+				test.arrangeFunc(t, test.unpack)
+			}
+
+			err := test.unpack.Unpack(context.Background(), test.project, test.workingDir)
+			if test.err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+				test.assertFunc(t, test.unpack)
+			}
+		})
+	}
+}
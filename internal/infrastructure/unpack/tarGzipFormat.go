@@ -2,8 +2,8 @@ package unpack
 
 import (
 	"compress/gzip"
+	"context"
 	"fmt"
-	"io"
 	"path/filepath"
 
 	"github.com/apenella/ransidble/internal/domain/core/entity"
@@ -16,6 +16,7 @@ type TarGzipFormat struct {
 	fs        afero.Fs
 	logger    repository.Logger
 	extractor repository.SourceCodeTarExtractorer
+	limits    *entity.ProjectArchiveLimits
 }
 
 // Ensure TarGzipFormat implements the SourceCodeUnpacker interface
@@ -30,11 +31,18 @@ func NewTarGzipFormat(fs afero.Fs, extractor repository.SourceCodeTarExtractorer
 	}
 }
 
+// WithLimits sets the archive limits enforced while the tar.gz file is extracted
+func (a *TarGzipFormat) WithLimits(limits *entity.ProjectArchiveLimits) *TarGzipFormat {
+	a.limits = limits
+	return a
+}
+
 // Unpack method prepares the project into dest folder
-func (a *TarGzipFormat) Unpack(project *entity.Project, workingDir string) error {
+func (a *TarGzipFormat) Unpack(_ context.Context, project *entity.Project, workingDir string) error {
 	var err error
 	var gzipReader *gzip.Reader
-	var sourceCodeFileReader io.Reader
+	var sourceCodeFileReader afero.File
+	var usage *entity.ProjectArchiveUsage
 	var sourceCodeFile string
 	// var sourceFileInfo os.FileInfo
 
@@ -109,6 +117,12 @@ func (a *TarGzipFormat) Unpack(project *entity.Project, workingDir string) error
 			})
 		return fmt.Errorf("%s: %w", ErrOpeningSourceCodeFile, err)
 	}
+	defer sourceCodeFileReader.Close()
+
+	usage, err = newArchiveUsage(a.logger, "TarGzipFormat.Unpack", a.limits, sourceCodeFileReader)
+	if err != nil {
+		return err
+	}
 
 	gzipReader, err = gzip.NewReader(sourceCodeFileReader)
 	if err != nil {
@@ -122,7 +136,7 @@ func (a *TarGzipFormat) Unpack(project *entity.Project, workingDir string) error
 		return fmt.Errorf("%s: %w", ErrCreatingGzipReader, err)
 	}
 
-	err = a.extractor.Extract(gzipReader, workingDir)
+	err = a.extractor.Extract(gzipReader, workingDir, usage)
 	if err != nil {
 		a.logger.Error(
 			fmt.Sprintf("%s: %s", ErrExtractingSourceCodeFile, err),
@@ -1,6 +1,7 @@
 package unpack
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"path/filepath"
@@ -108,6 +109,20 @@ func TestTarGzipFormatUnpack(t *testing.T) {
 			arrangeFunc: func(t *testing.T, unpack *TarGzipFormat) {},
 			assertFunc:  func(t *testing.T, unpack *TarGzipFormat) {},
 		},
+		{
+			desc:   "Testing error unpacking project in tar.gz format when a file exceeds the maximum file size",
+			unpack: NewTarGzipFormat(fs, tar.NewTar(fs, logger.NewFakeLogger()), logger.NewFakeLogger()).WithLimits(&entity.ProjectArchiveLimits{MaxFileSize: 100}),
+			project: &entity.Project{
+				Name:      "project-targz",
+				Format:    "targz",
+				Reference: sourceProjectTargz,
+				Storage:   "local",
+			},
+			workingDir:  workingDir,
+			err:         fmt.Errorf("%s: %w", ErrExtractingSourceCodeFile, fmt.Errorf("%w: the size of ./site.yml, 217 bytes, exceeds the maximum file size of 100 bytes", entity.ErrProjectArchiveLimitExceeded)),
+			arrangeFunc: func(t *testing.T, unpack *TarGzipFormat) {},
+			assertFunc:  func(t *testing.T, unpack *TarGzipFormat) {},
+		},
 		{
 			desc:   "Testing error unpacking project in tar.gz format when there is an error extracting tar file",
 			unpack: NewTarGzipFormat(fs, repository.NewMockProjectSourceCodeTarExtractorer(), logger.NewFakeLogger()),
@@ -120,7 +135,7 @@ func TestTarGzipFormatUnpack(t *testing.T) {
 			workingDir: workingDir,
 			err:        fmt.Errorf("%s: %w", ErrExtractingSourceCodeFile, errors.New("error extracting tar file")),
 			arrangeFunc: func(t *testing.T, unpack *TarGzipFormat) {
-				unpack.extractor.(*repository.MockProjectSourceCodeTarExtractorer).On("Extract", mock.Anything, workingDir).Return(errors.New("error extracting tar file"))
+				unpack.extractor.(*repository.MockProjectSourceCodeTarExtractorer).On("Extract", mock.Anything, workingDir, mock.Anything).Return(errors.New("error extracting tar file"))
 			},
 			assertFunc: func(t *testing.T, unpack *TarGzipFormat) {},
 		},
@@ -134,7 +149,7 @@ func TestTarGzipFormatUnpack(t *testing.T) {
 				test.arrangeFunc(t, test.unpack)
 			}
 
-			err := test.unpack.Unpack(test.project, test.workingDir)
+			err := test.unpack.Unpack(context.Background(), test.project, test.workingDir)
 			if err != nil {
 				assert.Equal(t, test.err.Error(), err.Error())
 			} else {
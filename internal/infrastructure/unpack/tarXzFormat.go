@@ -0,0 +1,52 @@
+package unpack
+
+import (
+	"context"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	"github.com/apenella/ransidble/internal/domain/ports/repository"
+	"github.com/spf13/afero"
+)
+
+const (
+	// xzCommand is the command used to decompress xz files
+	xzCommand = "xz"
+)
+
+// TarXzFormat struct used to unpack tar.xz files. The files are decompressed using the xz command, which must be available in the PATH
+type TarXzFormat struct {
+	unpacker *commandTarFormat
+}
+
+// Ensure TarXzFormat implements the SourceCodeUnpacker interface
+var _ repository.SourceCodeUnpacker = (*TarXzFormat)(nil)
+
+// NewTarXzFormat method creates a new TarXzFormat struct
+func NewTarXzFormat(fs afero.Fs, extractor repository.SourceCodeTarExtractorer, logger repository.Logger) *TarXzFormat {
+	return &TarXzFormat{
+		unpacker: &commandTarFormat{
+			component: "TarXzFormat.Unpack",
+			command:   xzCommand,
+			args:      decompressCommandArgs,
+			extractor: extractor,
+			fs:        fs,
+			logger:    logger,
+		},
+	}
+}
+
+// WithLimits sets the archive limits enforced while the tar.xz file is extracted
+func (a *TarXzFormat) WithLimits(limits *entity.ProjectArchiveLimits) *TarXzFormat {
+	a.unpacker.limits = limits
+	return a
+}
+
+// Available returns an error when the xz command is not available in the PATH, in which case the tar.xz files cannot be unpacked
+func (a *TarXzFormat) Available() error {
+	return a.unpacker.available()
+}
+
+// Unpack method decompresses and extracts the tar.xz file of the project into the working directory
+func (a *TarXzFormat) Unpack(ctx context.Context, project *entity.Project, workingDir string) error {
+	return a.unpacker.unpack(ctx, project, workingDir)
+}
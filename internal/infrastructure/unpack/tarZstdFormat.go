@@ -0,0 +1,52 @@
+package unpack
+
+import (
+	"context"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	"github.com/apenella/ransidble/internal/domain/ports/repository"
+	"github.com/spf13/afero"
+)
+
+const (
+	// zstdCommand is the command used to decompress zstd files
+	zstdCommand = "zstd"
+)
+
+// TarZstdFormat struct used to unpack tar.zst files. The files are decompressed using the zstd command, which must be available in the PATH
+type TarZstdFormat struct {
+	unpacker *commandTarFormat
+}
+
+// Ensure TarZstdFormat implements the SourceCodeUnpacker interface
+var _ repository.SourceCodeUnpacker = (*TarZstdFormat)(nil)
+
+// NewTarZstdFormat method creates a new TarZstdFormat struct
+func NewTarZstdFormat(fs afero.Fs, extractor repository.SourceCodeTarExtractorer, logger repository.Logger) *TarZstdFormat {
+	return &TarZstdFormat{
+		unpacker: &commandTarFormat{
+			component: "TarZstdFormat.Unpack",
+			command:   zstdCommand,
+			args:      decompressCommandArgs,
+			extractor: extractor,
+			fs:        fs,
+			logger:    logger,
+		},
+	}
+}
+
+// WithLimits sets the archive limits enforced while the tar.zst file is extracted
+func (a *TarZstdFormat) WithLimits(limits *entity.ProjectArchiveLimits) *TarZstdFormat {
+	a.unpacker.limits = limits
+	return a
+}
+
+// Available returns an error when the zstd command is not available in the PATH, in which case the tar.zst files cannot be unpacked
+func (a *TarZstdFormat) Available() error {
+	return a.unpacker.available()
+}
+
+// Unpack method decompresses and extracts the tar.zst file of the project into the working directory
+func (a *TarZstdFormat) Unpack(ctx context.Context, project *entity.Project, workingDir string) error {
+	return a.unpacker.unpack(ctx, project, workingDir)
+}
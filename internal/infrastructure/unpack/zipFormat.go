@@ -0,0 +1,177 @@
+package unpack
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	"github.com/apenella/ransidble/internal/domain/ports/repository"
+	"github.com/spf13/afero"
+)
+
+const (
+	// defaultZipFileMode is the mode of the extracted files whose permissions are not set in the zip file
+	defaultZipFileMode os.FileMode = 0644
+	// defaultZipDirMode is the mode of the extracted directories
+	defaultZipDirMode os.FileMode = 0755
+)
+
+// ZipFormat struct used to unpack zip files
+type ZipFormat struct {
+	// fs is the filesystem
+	fs afero.Fs
+	// limits are the archive limits enforced while the zip file is extracted
+	limits *entity.ProjectArchiveLimits
+	// logger is the logger
+	logger repository.Logger
+}
+
+// Ensure ZipFormat implements the SourceCodeUnpacker interface
+var _ repository.SourceCodeUnpacker = (*ZipFormat)(nil)
+
+// NewZipFormat method creates a new ZipFormat struct
+func NewZipFormat(fs afero.Fs, logger repository.Logger) *ZipFormat {
+	return &ZipFormat{
+		fs:     fs,
+		logger: logger,
+	}
+}
+
+// WithLimits sets the archive limits enforced while the zip file is extracted
+func (a *ZipFormat) WithLimits(limits *entity.ProjectArchiveLimits) *ZipFormat {
+	a.limits = limits
+	return a
+}
+
+// Unpack method extracts the zip file of the project into the working directory. The entries placed outside the working directory and the symbolic links are rejected
+func (a *ZipFormat) Unpack(_ context.Context, project *entity.Project, workingDir string) error {
+
+	err := validateUnpackParameters(a.fs, a.logger, "ZipFormat.Unpack", project, workingDir)
+	if err != nil {
+		return err
+	}
+
+	sourceCodeFile, err := openSourceCodeFile(a.fs, a.logger, "ZipFormat.Unpack", project, workingDir)
+	if err != nil {
+		return err
+	}
+	defer sourceCodeFile.Close()
+
+	sourceCodeFileInfo, err := sourceCodeFile.Stat()
+	if err != nil {
+		a.logger.Error(
+			fmt.Sprintf("%s: %s", ErrOpeningSourceCodeFile, err),
+			map[string]interface{}{
+				"component":   "ZipFormat.Unpack",
+				"package":     "github.com/apenella/ransidble/internal/infrastructure/unpack",
+				"source_file": sourceCodeFile.Name(),
+			})
+		return fmt.Errorf("%s: %w", ErrOpeningSourceCodeFile, err)
+	}
+
+	zipReader, err := zip.NewReader(sourceCodeFile, sourceCodeFileInfo.Size())
+	if err != nil {
+		a.logger.Error(
+			fmt.Sprintf("%s: %s", ErrCreatingZipReader, err),
+			map[string]interface{}{
+				"component":   "ZipFormat.Unpack",
+				"package":     "github.com/apenella/ransidble/internal/infrastructure/unpack",
+				"source_file": sourceCodeFile.Name(),
+			})
+		return fmt.Errorf("%s: %w", ErrCreatingZipReader, err)
+	}
+
+	usage := a.limits.NewUsage(sourceCodeFileInfo.Size())
+	for _, zipFile := range zipReader.File {
+		// the zip reader fails when an entry holds more content than its declared uncompressed size
+		err = usage.AddEntry(zipFile.Name, int64(zipFile.UncompressedSize64))
+		if err == nil {
+			err = a.extractFile(zipFile, workingDir)
+		}
+		if err != nil {
+			a.logger.Error(
+				fmt.Sprintf("%s: %s", ErrExtractingSourceCodeFile, err),
+				map[string]interface{}{
+					"component":   "ZipFormat.Unpack",
+					"file":        zipFile.Name,
+					"package":     "github.com/apenella/ransidble/internal/infrastructure/unpack",
+					"source_file": sourceCodeFile.Name(),
+					"working_dir": workingDir,
+				})
+			return fmt.Errorf("%s: %w", ErrExtractingSourceCodeFile, err)
+		}
+	}
+
+	return nil
+}
+
+// extractFile extracts a zip file entry into the working directory
+func (a *ZipFormat) extractFile(zipFile *zip.File, workingDir string) (err error) {
+
+	target, err := archiveEntryTarget(workingDir, zipFile.Name)
+	if err != nil {
+		return err
+	}
+
+	mode := zipFile.Mode()
+	switch {
+	case mode.IsDir():
+		return a.fs.MkdirAll(target, defaultZipDirMode)
+	case mode&os.ModeSymlink != 0:
+		return fmt.Errorf("%w: %s is a symbolic link", ErrUnsafeArchiveEntry, zipFile.Name)
+	case !mode.IsRegular():
+		return fmt.Errorf("%w: %s is not a regular file", ErrUnsafeArchiveEntry, zipFile.Name)
+	}
+
+	err = a.fs.MkdirAll(filepath.Dir(target), defaultZipDirMode)
+	if err != nil {
+		return err
+	}
+
+	perm := mode.Perm()
+	if perm == 0 {
+		perm = defaultZipFileMode
+	}
+
+	content, err := zipFile.Open()
+	if err != nil {
+		return err
+	}
+	defer content.Close()
+
+	file, err := a.fs.OpenFile(target, os.O_RDWR|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		errClose := file.Close()
+		if err == nil {
+			err = errClose
+		}
+	}()
+
+	_, err = io.Copy(file, content)
+
+	return err
+}
+
+// archiveEntryTarget returns the path where an archive entry is extracted. It returns an error when the entry would be placed outside of the working directory
+func archiveEntryTarget(workingDir string, name string) (string, error) {
+
+	if name == "" || filepath.IsAbs(name) || strings.HasPrefix(name, `\`) {
+		return "", fmt.Errorf("%w: %s", ErrUnsafeArchiveEntry, name)
+	}
+
+	target := filepath.Join(workingDir, filepath.FromSlash(name))
+	relative, err := filepath.Rel(workingDir, target)
+	if err != nil || relative == ".." || strings.HasPrefix(relative, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%w: %s", ErrUnsafeArchiveEntry, name)
+	}
+
+	return target, nil
+}
@@ -0,0 +1,236 @@
+package unpack
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	"github.com/apenella/ransidble/internal/infrastructure/logger"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+// writeZipFile writes a zip file into the filesystem, containing the provided entries
+func writeZipFile(t *testing.T, fs afero.Fs, path string, entries map[string]os.FileMode) {
+	var buffer bytes.Buffer
+
+	zipWriter := zip.NewWriter(&buffer)
+	for name, mode := range entries {
+		header := &zip.FileHeader{Name: name, Method: zip.Deflate}
+		header.SetMode(mode)
+		writer, err := zipWriter.CreateHeader(header)
+		assert.NoError(t, err)
+		if mode.IsDir() {
+			continue
+		}
+		_, err = writer.Write([]byte("content"))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, zipWriter.Close())
+	assert.NoError(t, afero.WriteFile(fs, path, buffer.Bytes(), 0644))
+}
+
+func TestZipFormatUnpack(t *testing.T) {
+
+	workingDir := filepath.Join("fixtures", "unpack", "project-zip")
+
+	tests := []struct {
+		desc        string
+		project     *entity.Project
+		workingDir  string
+		fs          afero.Fs
+		limits      *entity.ProjectArchiveLimits
+		err         error
+		arrangeFunc func(*testing.T, afero.Fs)
+		assertFunc  func(*testing.T, afero.Fs)
+	}{
+		{
+			desc:       "Testing unpack project in zip format",
+			project:    entity.NewProject("project-zip", "v1.0.0", "project.zip", entity.ProjectFormatZip, entity.ProjectTypeLocal),
+			workingDir: workingDir,
+			fs:         newFixturesFs(),
+			assertFunc: func(t *testing.T, fs afero.Fs) {
+				_, err := fs.Stat(filepath.Join(workingDir, "site.yml"))
+				assert.NoError(t, err)
+			},
+		},
+		{
+			desc:       "Testing unpack project in zip format with nested directories",
+			project:    entity.NewProject("project-zip", "v1.0.0", "project.zip", entity.ProjectFormatZip, entity.ProjectTypeLocal),
+			workingDir: "/working-dir",
+			fs:         afero.NewMemMapFs(),
+			arrangeFunc: func(t *testing.T, fs afero.Fs) {
+				writeZipFile(t, fs, "/working-dir/project.zip", map[string]os.FileMode{
+					"roles/":                      os.ModeDir | 0755,
+					"roles/common/tasks/main.yml": 0600,
+				})
+			},
+			assertFunc: func(t *testing.T, fs afero.Fs) {
+				info, err := fs.Stat("/working-dir/roles/common/tasks/main.yml")
+				assert.NoError(t, err)
+				assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+			},
+		},
+		{
+			desc:       "Testing error unpacking project in zip format when an entry is placed outside of the working directory",
+			project:    entity.NewProject("project-zip", "v1.0.0", "project.zip", entity.ProjectFormatZip, entity.ProjectTypeLocal),
+			workingDir: "/working-dir",
+			fs:         afero.NewMemMapFs(),
+			err:        ErrUnsafeArchiveEntry,
+			arrangeFunc: func(t *testing.T, fs afero.Fs) {
+				writeZipFile(t, fs, "/working-dir/project.zip", map[string]os.FileMode{
+					"../evil.yml": 0644,
+				})
+			},
+			assertFunc: func(t *testing.T, fs afero.Fs) {
+				_, err := fs.Stat("/evil.yml")
+				assert.True(t, os.IsNotExist(err))
+			},
+		},
+		{
+			desc:       "Testing error unpacking project in zip format when an entry is a symbolic link",
+			project:    entity.NewProject("project-zip", "v1.0.0", "project.zip", entity.ProjectFormatZip, entity.ProjectTypeLocal),
+			workingDir: "/working-dir",
+			fs:         afero.NewMemMapFs(),
+			err:        ErrUnsafeArchiveEntry,
+			arrangeFunc: func(t *testing.T, fs afero.Fs) {
+				writeZipFile(t, fs, "/working-dir/project.zip", map[string]os.FileMode{
+					"link": os.ModeSymlink | 0777,
+				})
+			},
+		},
+		{
+			desc:       "Testing error unpacking project in zip format when the entries exceed the archive limits",
+			project:    entity.NewProject("project-zip", "v1.0.0", "project.zip", entity.ProjectFormatZip, entity.ProjectTypeLocal),
+			workingDir: "/working-dir",
+			fs:         afero.NewMemMapFs(),
+			limits:     &entity.ProjectArchiveLimits{MaxEntries: 1},
+			err:        entity.ErrProjectArchiveLimitExceeded,
+			arrangeFunc: func(t *testing.T, fs afero.Fs) {
+				writeZipFile(t, fs, "/working-dir/project.zip", map[string]os.FileMode{
+					"site.yml":      0644,
+					"inventory.yml": 0644,
+				})
+			},
+		},
+		{
+			desc:       "Testing error unpacking project in zip format when a file exceeds the maximum file size",
+			project:    entity.NewProject("project-zip", "v1.0.0", "project.zip", entity.ProjectFormatZip, entity.ProjectTypeLocal),
+			workingDir: "/working-dir",
+			fs:         afero.NewMemMapFs(),
+			limits:     &entity.ProjectArchiveLimits{MaxFileSize: 3},
+			err:        entity.ErrProjectArchiveLimitExceeded,
+			arrangeFunc: func(t *testing.T, fs afero.Fs) {
+				writeZipFile(t, fs, "/working-dir/project.zip", map[string]os.FileMode{
+					"site.yml": 0644,
+				})
+			},
+			assertFunc: func(t *testing.T, fs afero.Fs) {
+				_, err := fs.Stat("/working-dir/site.yml")
+				assert.True(t, os.IsNotExist(err))
+			},
+		},
+		{
+			desc:       "Testing error unpacking project in zip format when the source code file is not a zip file",
+			project:    entity.NewProject("project-zip", "v1.0.0", "project.tar", entity.ProjectFormatZip, entity.ProjectTypeLocal),
+			workingDir: filepath.Join("fixtures", "unpack", "project-tar"),
+			fs:         newFixturesFs(),
+			err:        ErrCreatingZipReader,
+		},
+		{
+			desc:       "Testing error unpacking project in zip format when project is not provided",
+			workingDir: workingDir,
+			fs:         newFixturesFs(),
+			err:        ErrProjectNotProvided,
+		},
+		{
+			desc:       "Testing error unpacking project in zip format when filesystem is not provided",
+			project:    &entity.Project{},
+			workingDir: workingDir,
+			err:        ErrFilesystemNotProvided,
+		},
+		{
+			desc:       "Testing error unpacking project in zip format when project reference is not provided",
+			project:    &entity.Project{Name: "project-zip"},
+			workingDir: workingDir,
+			fs:         newFixturesFs(),
+			err:        ErrProjectReferenceNotProvided,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			if test.arrangeFunc != nil {
+				test.arrangeFunc(t, test.fs)
+			}
+
+			err := NewZipFormat(test.fs, logger.NewFakeLogger()).WithLimits(test.limits).Unpack(context.Background(), test.project, test.workingDir)
+			if test.err != nil {
+				assert.ErrorContains(t, err, test.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if test.assertFunc != nil {
+				test.assertFunc(t, test.fs)
+			}
+		})
+	}
+}
+
+func TestArchiveEntryTarget(t *testing.T) {
+	tests := []struct {
+		desc     string
+		name     string
+		expected string
+		err      error
+	}{
+		{
+			desc:     "Testing archive entry target of a file",
+			name:     "site.yml",
+			expected: "/working-dir/site.yml",
+		},
+		{
+			desc:     "Testing archive entry target of a file whose path is cleaned inside the working directory",
+			name:     "roles/../site.yml",
+			expected: "/working-dir/site.yml",
+		},
+		{
+			desc: "Testing error archive entry target of a file placed outside of the working directory",
+			name: "../../etc/passwd",
+			err:  ErrUnsafeArchiveEntry,
+		},
+		{
+			desc: "Testing error archive entry target of a file with an absolute path",
+			name: "/etc/passwd",
+			err:  ErrUnsafeArchiveEntry,
+		},
+		{
+			desc: "Testing error archive entry target of an entry without name",
+			name: "",
+			err:  ErrUnsafeArchiveEntry,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			t.Log(test.desc)
+
+			target, err := archiveEntryTarget("/working-dir", test.name)
+			if test.err != nil {
+				assert.ErrorIs(t, err, test.err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expected, target)
+			}
+		})
+	}
+}
@@ -0,0 +1,179 @@
+package functional
+
+import (
+	"context"
+	"fmt"
+	nethttp "net/http"
+	"testing"
+	"time"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	"github.com/apenella/ransidble/internal/domain/core/service/executor"
+	taskService "github.com/apenella/ransidble/internal/domain/core/service/task"
+	"github.com/apenella/ransidble/internal/domain/ports/repository"
+	"github.com/apenella/ransidble/internal/handler/http"
+	taskHandler "github.com/apenella/ransidble/internal/handler/http/task"
+	"github.com/apenella/ransidble/internal/infrastructure/logger"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// SuiteCancelTask is the test suite for the HTTP server
+type SuiteCancelTask struct {
+	listenAddress string
+	router        *echo.Echo
+	server        *http.Server
+
+	suite.Suite
+}
+
+// SetupSuite runs once before the suite starts running
+func (suite *SuiteCancelTask) SetupSuite() {
+	suite.listenAddress = "0.0.0.0:8080"
+}
+
+// SetupTest runs before each test
+func (suite *SuiteCancelTask) SetupTest() {
+	suite.router = echo.New()
+	suite.server = http.NewServer(suite.listenAddress, suite.router, logger.NewFakeLogger())
+}
+
+// TearDownTest runs after the suite ends
+func (suite *SuiteCancelTask) TearDownTest() {
+	suite.server.Stop()
+}
+
+// TestCancelTask tests the CancelTask method
+func (suite *SuiteCancelTask) TestCancelTask() {
+	if suite.server == nil {
+		suite.T().Errorf("%s. HTTP server is not initialized", suite.T().Name())
+		suite.T().FailNow()
+		return
+	}
+
+	if suite.router == nil {
+		suite.T().Errorf("%s. HTTP router is not initialized", suite.T().Name())
+		suite.T().FailNow()
+		return
+	}
+
+	if suite.listenAddress == "" {
+		suite.T().Errorf("%s. Listen address is not initialized", suite.T().Name())
+		suite.T().FailNow()
+		return
+	}
+
+	go func() {
+		err := suite.server.Start(context.Background())
+		if err != nil {
+			suite.T().Errorf("%s. error starting HTTP server: %s", suite.T().Name(), err)
+			suite.T().FailNow()
+			return
+		}
+	}()
+
+	errConn := waitHTTPServer(suite.listenAddress, 1*time.Second, 5)
+	if errConn != nil {
+		suite.T().Errorf("%s. error waiting for HTTP server: %s", suite.T().Name(), errConn)
+		suite.T().FailNow()
+		return
+	}
+
+	tests := []struct {
+		desc               string
+		method             string
+		url                string
+		expectedStatusCode int
+		expectedBody       string
+		arrangeTest        func()
+	}{
+		{
+			desc:               "Testing a request to cancel a queued task and return a StatusAccepted",
+			method:             "POST",
+			url:                "http://" + suite.listenAddress + "/tasks/task-1/cancel",
+			expectedStatusCode: nethttp.StatusAccepted,
+			arrangeTest: func() {
+				// the task repository is mocked and returns a task waiting to be executed
+				taskRepository := repository.NewMockTaskRepository()
+				taskRepository.On("Find", "task-1").Return(&entity.Task{
+					ID:        "task-1",
+					ProjectID: "project-1",
+					Command:   entity.AnsiblePlaybookCommand,
+					Status:    entity.PENDING,
+				}, nil)
+				taskRepository.On("Update", "task-1", mock.Anything).Return(nil)
+
+				arrangeCancelTaskRouter(suite.router, http.CancelTaskPath, taskRepository)
+			},
+		},
+		{
+			desc:               "Testing a request to cancel a finished task and return a StatusConflict",
+			method:             "POST",
+			url:                "http://" + suite.listenAddress + "/tasks/task-1/cancel",
+			expectedStatusCode: nethttp.StatusConflict,
+			expectedBody:       "{\"id\":\"task-1\",\"error\":\"error cancelling task: error cancelling task task-1: task already finished\",\"status\":409}",
+			arrangeTest: func() {
+				// the task repository is mocked and returns a task that is already finished
+				taskRepository := repository.NewMockTaskRepository()
+				taskRepository.On("Find", "task-1").Return(&entity.Task{
+					ID:        "task-1",
+					ProjectID: "project-1",
+					Command:   entity.AnsiblePlaybookCommand,
+					Status:    entity.SUCCESS,
+				}, nil)
+
+				arrangeCancelTaskRouter(suite.router, http.CancelTaskPath, taskRepository)
+			},
+		},
+		{
+			desc:               "Testing a request to cancel a non-existing task and return a StatusNotFound",
+			method:             "POST",
+			url:                "http://" + suite.listenAddress + "/tasks/task-1/cancel",
+			expectedStatusCode: nethttp.StatusNotFound,
+			expectedBody:       "{\"id\":\"task-1\",\"error\":\"error cancelling task: error finding task task-1: task not found\",\"status\":404}",
+			arrangeTest: func() {
+				// the task repository is mocked and returns a task not found error
+				taskRepository := repository.NewMockTaskRepository()
+				taskRepository.On("Find", "task-1").Return(nil, fmt.Errorf("task not found"))
+
+				arrangeCancelTaskRouter(suite.router, http.CancelTaskPath, taskRepository)
+			},
+		},
+	}
+
+	for _, test := range tests {
+
+		if test.arrangeTest != nil {
+			test.arrangeTest()
+		}
+
+		input := &InputFunctionalTest{
+			desc:               test.desc,
+			method:             test.method,
+			url:                test.url,
+			expectedStatusCode: test.expectedStatusCode,
+			expectedBody:       test.expectedBody,
+		}
+
+		err := actAndAssert(suite.T(), input)
+		assert.NoError(suite.T(), err)
+	}
+}
+
+// TestSuiteCancelTask runs the test suite
+func TestSuiteCancelTask(t *testing.T) {
+	suite.Run(t, new(SuiteCancelTask))
+}
+
+func arrangeCancelTaskRouter(router *echo.Echo, path string, taskRepository *repository.MockTaskRepository) {
+
+	// the dispatcher is not started because the tasks are cancelled before they reach a worker
+	dispatcher := executor.NewDispatch(1, nil, nil, nil, logger.NewFakeLogger())
+
+	cancelTaskService := taskService.NewCancelTaskService(dispatcher, taskRepository, logger.NewFakeLogger())
+	cancelTaskHandler := taskHandler.NewCancelTaskHandler(cancelTaskService, logger.NewFakeLogger())
+
+	router.POST(path, cancelTaskHandler.Handle)
+}
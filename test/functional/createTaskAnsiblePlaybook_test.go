@@ -145,7 +145,7 @@ func (suite *SuiteCreateTaskAnsiblePlaybook) TestCreateTaskAnsiblePlaybook() {
 					BecomeUser:        "",
 				}
 
-				ansibleExecutor.On("Run", mock.Anything, mock.Anything, expectedParameters).Return(nil)
+				ansibleExecutor.On("Run", mock.Anything, mock.Anything, expectedParameters, mock.Anything).Return(nil, nil)
 
 				dispatcher, err := arrangeTaskAnsiblePlaybookRouter(suite.router, ansibleExecutor)
 				if err != nil {
@@ -289,7 +289,7 @@ func (suite *SuiteCreateTaskAnsiblePlaybook) TestCreateTaskAnsiblePlaybook() {
 					BecomeUser:        "root",
 				}
 
-				ansibleExecutor.On("Run", mock.Anything, mock.Anything, expectedParameters).Return(nil)
+				ansibleExecutor.On("Run", mock.Anything, mock.Anything, expectedParameters, mock.Anything).Return(nil, nil)
 
 				dispatcher, err := arrangeTaskAnsiblePlaybookRouter(suite.router, ansibleExecutor)
 				if err != nil {
@@ -319,7 +319,7 @@ func (suite *SuiteCreateTaskAnsiblePlaybook) TestCreateTaskAnsiblePlaybook() {
 					Connection:    "local",
 				}
 
-				ansibleExecutor.On("Run", mock.Anything, mock.Anything, expectedParameters).Return(nil)
+				ansibleExecutor.On("Run", mock.Anything, mock.Anything, expectedParameters, mock.Anything).Return(nil, nil)
 
 				dispatcher, err := arrangeTaskAnsiblePlaybookRouter(suite.router, ansibleExecutor)
 				if err != nil {
@@ -437,6 +437,7 @@ func arrangeTaskAnsiblePlaybookRouter(router *echo.Echo, ansibleExecutor executo
 		1,
 		workspaceBuilder,
 		ansibleExecutor,
+		taskpersistence.NewMemoryTaskOutputRepository(log),
 		log,
 	)
 
@@ -8,7 +8,6 @@ import (
 	"testing"
 	"time"
 
-	"github.com/apenella/ransidble/internal/domain/core/entity"
 	projectService "github.com/apenella/ransidble/internal/domain/core/service/project"
 	"github.com/apenella/ransidble/internal/domain/ports/service"
 	"github.com/apenella/ransidble/internal/handler/http"
@@ -18,6 +17,7 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -115,6 +115,48 @@ func (suite *SuiteGetProjectsList) TestGetProjectLists() {
 			expectedBody:       "[{\"format\":\"targz\",\"name\":\"project-3\",\"reference\":\"project-3.tar.gz\",\"storage\":\"local\"},{\"format\":\"targz\",\"name\":\"project-4\",\"reference\":\"project-4.tar.gz\",\"storage\":\"local\"}]",
 			expectedStatusCode: nethttp.StatusOK,
 		},
+		{
+			desc:   "Testing a request to get projects list functional behavior when the request is paginated and filtered that returns a StatusOK status code",
+			method: nethttp.MethodGet,
+			url:    "http://" + suite.listenAddress + http.GetProjectsPath + "?format=targz&limit=1&fields=format",
+			arrangeTest: func(suite *SuiteGetProjectsList) {
+				log := logger.NewFakeLogger()
+				afs := afero.NewOsFs()
+
+				projectsRepository := local.NewDatabaseDriver(
+					afs,
+					filepath.Join("..", "fixtures", "functional-get-projects"),
+					log,
+				)
+
+				getProjectService := projectService.NewGetProjectService(projectsRepository, log)
+				getProjectListHandler := projectHandler.NewGetProjectListHandler(getProjectService, log)
+				suite.router.GET(http.GetProjectsPath, getProjectListHandler.Handle)
+			},
+			expectedBody:       "[{\"format\":\"targz\",\"name\":\"project-3\"}]",
+			expectedStatusCode: nethttp.StatusOK,
+		},
+		{
+			desc:   "Testing a request to get projects list functional behavior when the query parameters are not valid that returns a StatusBadRequest status code",
+			method: nethttp.MethodGet,
+			url:    "http://" + suite.listenAddress + http.GetProjectsPath + "?format=rar",
+			arrangeTest: func(suite *SuiteGetProjectsList) {
+				log := logger.NewFakeLogger()
+				afs := afero.NewOsFs()
+
+				projectsRepository := local.NewDatabaseDriver(
+					afs,
+					filepath.Join("..", "fixtures", "functional-get-projects"),
+					log,
+				)
+
+				getProjectService := projectService.NewGetProjectService(projectsRepository, log)
+				getProjectListHandler := projectHandler.NewGetProjectListHandler(getProjectService, log)
+				suite.router.GET(http.GetProjectsPath, getProjectListHandler.Handle)
+			},
+			expectedBody:       "{\"id\":\"\",\"error\":\"invalid project query parameters: Key: 'ProjectQueryParameters.Format' Error:Field validation for 'Format' failed on the 'oneof' tag\",\"status\":400}",
+			expectedStatusCode: nethttp.StatusBadRequest,
+		},
 		{
 			desc:   "Testing a request to get projects list functional behaviour when service returns an error getting the projects list that returns a StatusInternalServerError status code",
 			method: nethttp.MethodGet,
@@ -124,8 +166,8 @@ func (suite *SuiteGetProjectsList) TestGetProjectLists() {
 
 				// This is the mock service that simulates a project not provided error returned by the service
 				getProjectService := service.NewMockGetProjectService()
-				getProjectService.On("GetProjectsList").Return(
-					[]*entity.Project{},
+				getProjectService.On("GetProjectsList", mock.Anything).Return(
+					nil,
 					errors.New("testing get project list error"),
 				)
 
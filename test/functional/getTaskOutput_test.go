@@ -0,0 +1,158 @@
+package functional
+
+import (
+	"context"
+	"fmt"
+	nethttp "net/http"
+	"testing"
+	"time"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	taskService "github.com/apenella/ransidble/internal/domain/core/service/task"
+	"github.com/apenella/ransidble/internal/domain/ports/repository"
+	"github.com/apenella/ransidble/internal/handler/http"
+	taskHandler "github.com/apenella/ransidble/internal/handler/http/task"
+	"github.com/apenella/ransidble/internal/infrastructure/logger"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+// SuiteGetTaskOutput is the test suite for the HTTP server
+type SuiteGetTaskOutput struct {
+	listenAddress string
+	router        *echo.Echo
+	server        *http.Server
+
+	suite.Suite
+}
+
+// SetupSuite runs once before the suite starts running
+func (suite *SuiteGetTaskOutput) SetupSuite() {
+	suite.listenAddress = "0.0.0.0:8080"
+}
+
+// SetupTest runs before each test
+func (suite *SuiteGetTaskOutput) SetupTest() {
+	suite.router = echo.New()
+	suite.server = http.NewServer(suite.listenAddress, suite.router, logger.NewFakeLogger())
+}
+
+// TearDownTest runs after the suite ends
+func (suite *SuiteGetTaskOutput) TearDownTest() {
+	suite.server.Stop()
+}
+
+// TestGetTaskOutput tests the GetTaskOutput method
+func (suite *SuiteGetTaskOutput) TestGetTaskOutput() {
+	if suite.server == nil {
+		suite.T().Errorf("%s. HTTP server is not initialized", suite.T().Name())
+		suite.T().FailNow()
+		return
+	}
+
+	if suite.router == nil {
+		suite.T().Errorf("%s. HTTP router is not initialized", suite.T().Name())
+		suite.T().FailNow()
+		return
+	}
+
+	if suite.listenAddress == "" {
+		suite.T().Errorf("%s. Listen address is not initialized", suite.T().Name())
+		suite.T().FailNow()
+		return
+	}
+
+	go func() {
+		err := suite.server.Start(context.Background())
+		if err != nil {
+			suite.T().Errorf("%s. error starting HTTP server: %s", suite.T().Name(), err)
+			suite.T().FailNow()
+			return
+		}
+	}()
+
+	errConn := waitHTTPServer(suite.listenAddress, 1*time.Second, 5)
+	if errConn != nil {
+		suite.T().Errorf("%s. error waiting for HTTP server: %s", suite.T().Name(), errConn)
+		suite.T().FailNow()
+		return
+	}
+
+	tests := []struct {
+		desc               string
+		method             string
+		url                string
+		expectedStatusCode int
+		expectedBody       string
+		arrangeTest        func()
+	}{
+		{
+			desc:               "Testing a request to get the output of an existing task and return a StatusOK",
+			method:             "GET",
+			url:                "http://" + suite.listenAddress + "/tasks/task-1/output",
+			expectedStatusCode: nethttp.StatusOK,
+			expectedBody:       "PLAY [all] ***",
+			arrangeTest: func() {
+				// the task repository is mocked and returns a valid task
+				taskRepository := repository.NewMockTaskRepository()
+				taskRepository.On("Find", "task-1").Return(&entity.Task{
+					ID:        "task-1",
+					ProjectID: "project-1",
+					Command:   entity.AnsiblePlaybookCommand,
+					Status:    entity.SUCCESS,
+				}, nil)
+
+				taskOutputRepository := repository.NewMockTaskOutputRepository()
+				taskOutputRepository.On("Find", "task-1").Return([]byte("PLAY [all] ***\n"), nil)
+
+				arrangeGetTaskOutputRouter(suite.router, http.GetTaskOutputPath, taskRepository, taskOutputRepository)
+			},
+		},
+		{
+			desc:               "Testing a request to get the output of a non-existing task and return a StatusNotFound",
+			method:             "GET",
+			url:                "http://" + suite.listenAddress + "/tasks/task-1/output",
+			expectedStatusCode: nethttp.StatusNotFound,
+			expectedBody:       "{\"id\":\"\",\"error\":\"error getting task output: error finding task task-1: task not found\",\"status\":404}",
+			arrangeTest: func() {
+				// the task repository is mocked and returns a task not found error
+				taskRepository := repository.NewMockTaskRepository()
+				taskRepository.On("Find", "task-1").Return(nil, fmt.Errorf("task not found"))
+
+				arrangeGetTaskOutputRouter(suite.router, http.GetTaskOutputPath, taskRepository, repository.NewMockTaskOutputRepository())
+			},
+		},
+	}
+
+	for _, test := range tests {
+
+		if test.arrangeTest != nil {
+			test.arrangeTest()
+		}
+
+		input := &InputFunctionalTest{
+			desc:               test.desc,
+			method:             test.method,
+			url:                test.url,
+			expectedStatusCode: test.expectedStatusCode,
+			expectedBody:       test.expectedBody,
+		}
+
+		err := actAndAssert(suite.T(), input)
+		assert.NoError(suite.T(), err)
+	}
+}
+
+// TestSuiteGetTaskOutput runs the test suite
+func TestSuiteGetTaskOutput(t *testing.T) {
+	suite.Run(t, new(SuiteGetTaskOutput))
+}
+
+func arrangeGetTaskOutputRouter(router *echo.Echo, path string, taskRepository *repository.MockTaskRepository, taskOutputRepository *repository.MockTaskOutputRepository) {
+
+	getTaskOutputService := taskService.NewGetTaskOutputService(taskRepository, taskOutputRepository, logger.NewFakeLogger())
+	getTaskOutputHandler := taskHandler.NewGetTaskOutputHandler(getTaskOutputService, logger.NewFakeLogger())
+
+	router.GET(path, getTaskOutputHandler.Handle)
+}
@@ -0,0 +1,147 @@
+package functional
+
+import (
+	"context"
+	nethttp "net/http"
+	"testing"
+	"time"
+
+	"github.com/apenella/ransidble/internal/domain/core/entity"
+	taskService "github.com/apenella/ransidble/internal/domain/core/service/task"
+	"github.com/apenella/ransidble/internal/handler/http"
+	taskHandler "github.com/apenella/ransidble/internal/handler/http/task"
+	"github.com/apenella/ransidble/internal/infrastructure/logger"
+	taskpersistence "github.com/apenella/ransidble/internal/infrastructure/persistence/task"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+// SuiteGetTasks is the test suite for the HTTP server
+type SuiteGetTasks struct {
+	listenAddress string
+	router        *echo.Echo
+	server        *http.Server
+
+	suite.Suite
+}
+
+// SetupSuite runs once before the suite starts running
+func (suite *SuiteGetTasks) SetupSuite() {
+	suite.listenAddress = "0.0.0.0:8080"
+}
+
+// SetupTest runs before each test
+func (suite *SuiteGetTasks) SetupTest() {
+	suite.router = echo.New()
+	suite.server = http.NewServer(suite.listenAddress, suite.router, logger.NewFakeLogger())
+}
+
+// TearDownTest runs after the suite ends
+func (suite *SuiteGetTasks) TearDownTest() {
+	suite.server.Stop()
+}
+
+// TestGetTasks tests the request to list the tasks
+func (suite *SuiteGetTasks) TestGetTasks() {
+	if suite.server == nil {
+		suite.T().Errorf("%s. HTTP server is not initialized", suite.T().Name())
+		suite.T().FailNow()
+		return
+	}
+
+	if suite.router == nil {
+		suite.T().Errorf("%s. HTTP router is not initialized", suite.T().Name())
+		suite.T().FailNow()
+		return
+	}
+
+	if suite.listenAddress == "" {
+		suite.T().Errorf("%s. Listen address is not initialized", suite.T().Name())
+		suite.T().FailNow()
+		return
+	}
+
+	// the tasks are stored into a memory task repository
+	repository := taskpersistence.NewMemoryTaskRepository(logger.NewFakeLogger())
+	for _, task := range []*entity.Task{
+		{ID: "1", ProjectID: "project-1", Command: entity.AnsiblePlaybookCommand, Parameters: &entity.AnsiblePlaybookParameters{Playbooks: []string{"site.yml"}, Inventory: "127.0.0.1,"}, CreatedAt: "2026-01-01T10:00:00Z", Status: entity.SUCCESS},
+		{ID: "2", ProjectID: "project-2", Command: entity.AnsiblePlaybookCommand, Parameters: &entity.AnsiblePlaybookParameters{Playbooks: []string{"site.yml"}, Inventory: "127.0.0.1,"}, CreatedAt: "2026-01-01T10:01:00Z", Status: entity.FAILED},
+		{ID: "3", ProjectID: "project-1", Command: entity.AnsiblePlaybookCommand, Parameters: &entity.AnsiblePlaybookParameters{Playbooks: []string{"site.yml"}, Inventory: "127.0.0.1,"}, CreatedAt: "2026-01-01T10:02:00Z", Status: entity.PENDING},
+	} {
+		err := repository.Store(task.ID, task)
+		if err != nil {
+			suite.T().Errorf("%s. error storing task: %s", suite.T().Name(), err)
+			suite.T().FailNow()
+			return
+		}
+	}
+
+	getTaskService := taskService.NewGetTaskService(repository, logger.NewFakeLogger())
+	getTasksListHandler := taskHandler.NewGetTasksListHandler(getTaskService, logger.NewFakeLogger())
+	suite.router.GET(http.GetTasksPath, getTasksListHandler.Handle)
+
+	go func() {
+		err := suite.server.Start(context.Background())
+		if err != nil {
+			suite.T().Errorf("%s. error starting HTTP server: %s", suite.T().Name(), err)
+			suite.T().FailNow()
+			return
+		}
+	}()
+
+	errConn := waitHTTPServer(suite.listenAddress, 1*time.Second, 5)
+	if errConn != nil {
+		suite.T().Errorf("%s. error waiting for HTTP server: %s", suite.T().Name(), errConn)
+		suite.T().FailNow()
+		return
+	}
+
+	tests := []struct {
+		desc               string
+		method             string
+		url                string
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			desc:               "Testing a request to list the tasks of a project sorted by creation time and return a StatusOK",
+			method:             "GET",
+			url:                "http://" + suite.listenAddress + "/tasks?project_id=project-1&order=asc",
+			expectedStatusCode: nethttp.StatusOK,
+			expectedBody:       "{\"tasks\":[{\"command\":\"ansible-playbook\",\"completed_at\":\"\",\"created_at\":\"2026-01-01T10:00:00Z\",\"executed_at\":\"\",\"id\":\"1\",\"parameters\":{\"playbooks\":[\"site.yml\"],\"inventory\":\"127.0.0.1,\"},\"project_id\":\"project-1\",\"status\":\"SUCCESS\"},{\"command\":\"ansible-playbook\",\"completed_at\":\"\",\"created_at\":\"2026-01-01T10:02:00Z\",\"executed_at\":\"\",\"id\":\"3\",\"parameters\":{\"playbooks\":[\"site.yml\"],\"inventory\":\"127.0.0.1,\"},\"project_id\":\"project-1\",\"status\":\"PENDING\"}]}",
+		},
+		{
+			desc:               "Testing a request to list the tasks filtered by status and return a StatusOK",
+			method:             "GET",
+			url:                "http://" + suite.listenAddress + "/tasks?status=FAILED",
+			expectedStatusCode: nethttp.StatusOK,
+			expectedBody:       "{\"tasks\":[{\"command\":\"ansible-playbook\",\"completed_at\":\"\",\"created_at\":\"2026-01-01T10:01:00Z\",\"executed_at\":\"\",\"id\":\"2\",\"parameters\":{\"playbooks\":[\"site.yml\"],\"inventory\":\"127.0.0.1,\"},\"project_id\":\"project-2\",\"status\":\"FAILED\"}]}",
+		},
+		{
+			desc:               "Testing a request to list the tasks with an invalid cursor and return a StatusBadRequest",
+			method:             "GET",
+			url:                "http://" + suite.listenAddress + "/tasks?cursor=invalid",
+			expectedStatusCode: nethttp.StatusBadRequest,
+			expectedBody:       "{\"id\":\"\",\"error\":\"error getting tasks list: invalid task query: invalid task query cursor\",\"status\":400}",
+		},
+	}
+
+	for _, test := range tests {
+		input := &InputFunctionalTest{
+			desc:               test.desc,
+			method:             test.method,
+			url:                test.url,
+			expectedStatusCode: test.expectedStatusCode,
+			expectedBody:       test.expectedBody,
+		}
+
+		err := actAndAssert(suite.T(), input)
+		assert.NoError(suite.T(), err)
+	}
+}
+
+// TestSuiteGetTasks runs the test suite
+func TestSuiteGetTasks(t *testing.T) {
+	suite.Run(t, new(SuiteGetTasks))
+}
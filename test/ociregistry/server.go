@@ -0,0 +1,295 @@
+package ociregistry
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	// mediaTypeManifest is the media type of the manifests served
+	mediaTypeManifest = "application/vnd.oci.image.manifest.v1+json"
+	// mediaTypeIndex is the media type of the layout indexes
+	mediaTypeIndex = "application/vnd.oci.image.index.v1+json"
+	// mediaTypeConfig is the media type of the artifact configurations
+	mediaTypeConfig = "application/vnd.oci.image.config.v1+json"
+	// mediaTypeLayer is the media type of the artifact layers
+	mediaTypeLayer = "application/vnd.oci.image.layer.v1.tar+gzip"
+
+	// token is the token issued to the authenticated clients
+	token = "ociregistry-token"
+)
+
+// Artifact represents an OCI artifact whose layers hold sets of files
+type Artifact struct {
+	// Blobs holds the content of the configuration and the layers by digest
+	Blobs map[string][]byte
+	// Digest is the digest of the manifest
+	Digest string
+	// Manifest is the content of the manifest
+	Manifest []byte
+}
+
+// NewArtifact creates an artifact with a layer for each set of files, keyed by path, from the base to the top layer
+func NewArtifact(layers ...map[string]string) *Artifact {
+
+	artifact := &Artifact{
+		Blobs: make(map[string][]byte),
+	}
+
+	descriptors := make([]interface{}, 0, len(layers))
+	for _, files := range layers {
+		layer := newLayer(files)
+		artifact.Blobs[digest(layer)] = layer
+		descriptors = append(descriptors, descriptor(mediaTypeLayer, layer))
+	}
+
+	config := []byte("{}")
+	artifact.Blobs[digest(config)] = config
+
+	artifact.Manifest, _ = json.Marshal(map[string]interface{}{
+		"schemaVersion": 2,
+		"mediaType":     mediaTypeManifest,
+		"config":        descriptor(mediaTypeConfig, config),
+		"layers":        descriptors,
+	})
+	artifact.Digest = digest(artifact.Manifest)
+
+	return artifact
+}
+
+// newLayer returns a gzip compressed tar layer holding the given files
+func newLayer(files map[string]string) []byte {
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	layer := &bytes.Buffer{}
+	gzipWriter := gzip.NewWriter(layer)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for _, name := range names {
+		_ = tarWriter.WriteHeader(&tar.Header{Typeflag: tar.TypeReg, Name: name, Mode: 0644, Size: int64(len(files[name]))})
+		_, _ = tarWriter.Write([]byte(files[name]))
+	}
+	_ = tarWriter.Close()
+	_ = gzipWriter.Close()
+
+	return layer.Bytes()
+}
+
+// Layout returns the artifact as an OCI image-layout tarball
+func (a *Artifact) Layout() []byte {
+
+	index, _ := json.Marshal(map[string]interface{}{
+		"schemaVersion": 2,
+		"mediaType":     mediaTypeIndex,
+		"manifests":     []interface{}{descriptor(mediaTypeManifest, a.Manifest)},
+	})
+
+	entries := map[string][]byte{
+		"oci-layout":       []byte(`{"imageLayoutVersion":"1.0.0"}`),
+		"index.json":       index,
+		blobPath(a.Digest): a.Manifest,
+	}
+	for blobDigest, content := range a.Blobs {
+		entries[blobPath(blobDigest)] = content
+	}
+
+	names := make([]string, 0, len(entries))
+	for name := range entries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	layout := &bytes.Buffer{}
+	tarWriter := tar.NewWriter(layout)
+	for _, name := range names {
+		_ = tarWriter.WriteHeader(&tar.Header{Typeflag: tar.TypeReg, Name: name, Mode: 0644, Size: int64(len(entries[name]))})
+		_, _ = tarWriter.Write(entries[name])
+	}
+	_ = tarWriter.Close()
+
+	return layout.Bytes()
+}
+
+// Server is an in-memory stand-in of an OCI distribution registry, to test the components that fetch artifacts from it. It serves the manifests and the blobs of the pushed artifacts and, when credentials are set, it requires a token obtained with them
+type Server struct {
+	*httptest.Server
+
+	password string
+	realm    string
+	username string
+
+	mutex     sync.Mutex
+	blobs     map[string][]byte
+	manifests map[string][]byte
+}
+
+// NewServer starts a new Server. When the username is provided, the requests must be authorized using the token flow
+func NewServer(username string, password string) *Server {
+	server := &Server{
+		blobs:     make(map[string][]byte),
+		manifests: make(map[string][]byte),
+		password:  password,
+		username:  username,
+	}
+
+	server.Server = httptest.NewServer(http.HandlerFunc(server.handle))
+
+	return server
+}
+
+// SetRealm sets the URL of the authorization service announced on the token challenges. The registry token endpoint is announced when it is not set
+func (s *Server) SetRealm(realm string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.realm = realm
+}
+
+// Host returns the host and port of the registry, to be used on the artifact references
+func (s *Server) Host() string {
+	return s.Listener.Addr().String()
+}
+
+// Push stores an artifact on a repository, tagging it with the given tag
+func (s *Server) Push(repository string, tag string, artifact *Artifact) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for blobDigest, content := range artifact.Blobs {
+		s.blobs[repository+"@"+blobDigest] = content
+	}
+	s.manifests[repository+"@"+artifact.Digest] = artifact.Manifest
+	s.manifests[repository+":"+tag] = artifact.Manifest
+}
+
+// PutBlob replaces the content of a blob of a repository, which allows serving corrupted blobs
+func (s *Server) PutBlob(repository string, blobDigest string, content []byte) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.blobs[repository+"@"+blobDigest] = content
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+
+	if r.URL.Path == "/token" {
+		username, password, ok := r.BasicAuth()
+		if !ok || username != s.username || password != s.password {
+			writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "invalid credentials")
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"token":%q}`, token)
+		return
+	}
+
+	if !strings.HasPrefix(r.URL.Path, "/v2/") {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "unknown path")
+		return
+	}
+
+	if s.username != "" && r.Header.Get("Authorization") != "Bearer "+token {
+		s.mutex.Lock()
+		realm := s.realm
+		s.mutex.Unlock()
+		if realm == "" {
+			realm = s.URL + "/token"
+		}
+
+		w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s",service="ociregistry"`, realm))
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+		return
+	}
+
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		writeError(w, http.StatusMethodNotAllowed, "UNSUPPORTED", "the specified method is not allowed")
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/v2/")
+	if index := strings.LastIndex(path, "/manifests/"); index >= 0 {
+		repository, reference := path[:index], path[index+len("/manifests/"):]
+
+		separator := ":"
+		if strings.Contains(reference, ":") {
+			separator = "@"
+		}
+
+		s.mutex.Lock()
+		content, exists := s.manifests[repository+separator+reference]
+		s.mutex.Unlock()
+
+		if !exists {
+			writeError(w, http.StatusNotFound, "MANIFEST_UNKNOWN", "manifest unknown")
+			return
+		}
+
+		w.Header().Set("Content-Type", mediaTypeManifest)
+		w.Header().Set("Docker-Content-Digest", digest(content))
+		if r.Method == http.MethodGet {
+			_, _ = w.Write(content)
+		}
+		return
+	}
+
+	if index := strings.LastIndex(path, "/blobs/"); index >= 0 {
+		repository, blobDigest := path[:index], path[index+len("/blobs/"):]
+
+		s.mutex.Lock()
+		content, exists := s.blobs[repository+"@"+blobDigest]
+		s.mutex.Unlock()
+
+		if !exists {
+			writeError(w, http.StatusNotFound, "BLOB_UNKNOWN", "blob unknown")
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/octet-stream")
+		if r.Method == http.MethodGet {
+			_, _ = w.Write(content)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func writeError(w http.ResponseWriter, status int, code string, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = fmt.Fprintf(w, `{"errors":[{"code":%q,"message":%q}]}`, code, message)
+}
+
+// descriptor returns the descriptor of a content
+func descriptor(mediaType string, content []byte) map[string]interface{} {
+	return map[string]interface{}{
+		"mediaType": mediaType,
+		"digest":    digest(content),
+		"size":      len(content),
+	}
+}
+
+// digest returns the sha256 digest of a content
+func digest(content []byte) string {
+	sum := sha256.Sum256(content)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// blobPath returns the path of a blob in an OCI image layout
+func blobPath(blobDigest string) string {
+	return "blobs/" + strings.Replace(blobDigest, ":", "/", 1)
+}
@@ -15,6 +15,11 @@ const (
 	OpenAPIDefPath = "../../api/openapi.yaml"
 )
 
+func init() {
+	// the Server-Sent Events responses are validated as plain text
+	openapi3filter.RegisterBodyDecoder("text/event-stream", openapi3filter.PlainBodyDecoder)
+}
+
 // Validator is a struct to validate the OpenAPI specification
 type Validator struct {
 	loader *openapi3.Loader
@@ -0,0 +1,170 @@
+package s3server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+)
+
+// Server is an in-memory stand-in of an S3-compatible object storage, such as MinIO, to test the components that store objects on it. It supports putting, getting and deleting objects using either path-style or virtual-hosted-style addressing
+type Server struct {
+	*httptest.Server
+
+	accessKeyID string
+	bucket      string
+	region      string
+
+	mutex   sync.Mutex
+	objects map[string][]byte
+}
+
+// NewServer starts a new Server holding the given bucket. When the access key is provided, the requests must be signed with it using the given region
+func NewServer(bucket string, accessKeyID string, region string) *Server {
+	server := &Server{
+		accessKeyID: accessKeyID,
+		bucket:      bucket,
+		region:      region,
+		objects:     make(map[string][]byte),
+	}
+
+	server.Server = httptest.NewServer(http.HandlerFunc(server.handle))
+
+	return server
+}
+
+// Object returns the content stored on a key of the bucket
+func (s *Server) Object(key string) ([]byte, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	content, exists := s.objects[key]
+	return content, exists
+}
+
+// PutObject stores a content on a key of the bucket
+func (s *Server) PutObject(key string, content []byte) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.objects[key] = content
+}
+
+// Client returns an HTTP client that sends the requests to any host to the server, so the virtual-hosted-style requests reach it
+func (s *Server) Client() *http.Client {
+	address := s.Listener.Addr().String()
+
+	return &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, network, _ string) (net.Conn, error) {
+				return (&net.Dialer{}).DialContext(ctx, network, address)
+			},
+		},
+	}
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+
+	bucket, key := s.objectLocation(r)
+	if bucket != s.bucket {
+		writeError(w, http.StatusNotFound, "NoSuchBucket", "the specified bucket does not exist")
+		return
+	}
+
+	if key == "" {
+		writeError(w, http.StatusBadRequest, "InvalidRequest", "object key not provided")
+		return
+	}
+
+	if s.accessKeyID != "" {
+		err := s.authorize(r)
+		if err != nil {
+			writeError(w, http.StatusForbidden, "AccessDenied", err.Error())
+			return
+		}
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		content, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "IncompleteBody", err.Error())
+			return
+		}
+
+		hash := sha256.Sum256(content)
+		if s.accessKeyID != "" && r.Header.Get("X-Amz-Content-Sha256") != hex.EncodeToString(hash[:]) {
+			writeError(w, http.StatusBadRequest, "XAmzContentSHA256Mismatch", "the provided content checksum does not match the content")
+			return
+		}
+
+		s.PutObject(key, content)
+		w.WriteHeader(http.StatusOK)
+	case http.MethodGet:
+		content, exists := s.Object(key)
+		if !exists {
+			writeError(w, http.StatusNotFound, "NoSuchKey", "the specified key does not exist")
+			return
+		}
+		_, _ = w.Write(content)
+	case http.MethodDelete:
+		s.mutex.Lock()
+		delete(s.objects, key)
+		s.mutex.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", "the specified method is not allowed")
+	}
+}
+
+// objectLocation returns the bucket and the key of the object requested, either on the path or on the host name
+func (s *Server) objectLocation(r *http.Request) (string, string) {
+	path := strings.TrimPrefix(r.URL.Path, "/")
+	host, _, err := net.SplitHostPort(r.Host)
+	if err != nil {
+		host = r.Host
+	}
+
+	if net.ParseIP(host) == nil && strings.Contains(host, ".") {
+		return strings.SplitN(host, ".", 2)[0], path
+	}
+
+	parts := strings.SplitN(path, "/", 2)
+	if len(parts) < 2 {
+		return parts[0], ""
+	}
+
+	return parts[0], parts[1]
+}
+
+// authorize validates that the request is signed with the expected credentials and signs the required headers
+func (s *Server) authorize(r *http.Request) error {
+	authorization := r.Header.Get("Authorization")
+	if !strings.HasPrefix(authorization, "AWS4-HMAC-SHA256 Credential="+s.accessKeyID+"/") {
+		return fmt.Errorf("request not signed with the expected access key")
+	}
+
+	if !strings.Contains(authorization, "/"+s.region+"/s3/aws4_request") {
+		return fmt.Errorf("request not signed for the expected region")
+	}
+
+	for _, header := range []string{"host", "x-amz-content-sha256", "x-amz-date"} {
+		if !strings.Contains(authorization, header) {
+			return fmt.Errorf("header %s not signed", header)
+		}
+	}
+
+	return nil
+}
+
+func writeError(w http.ResponseWriter, status int, code string, message string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	_, _ = fmt.Fprintf(w, "<?xml version=\"1.0\" encoding=\"UTF-8\"?><Error><Code>%s</Code><Message>%s</Message></Error>", code, message)
+}
@@ -0,0 +1,34 @@
+package signing
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"testing"
+)
+
+const (
+	// MinisignAlgorithm is the signature algorithm set on the minisign public keys, which is the same for the legacy and the prehashed signatures
+	MinisignAlgorithm = "Ed"
+)
+
+// EncodePublicKey returns the PEM encoded PKIX public key. The test fails when the public key cannot be encoded
+func EncodePublicKey(t testing.TB, publicKey crypto.PublicKey) []byte {
+	t.Helper()
+
+	data, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		t.Fatalf("error encoding public key: %s", err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: data})
+}
+
+// EncodeMinisignPublicKey returns a minisign public key file holding the public key and its key ID
+func EncodeMinisignPublicKey(publicKey ed25519.PublicKey, keyID []byte) []byte {
+	key := append(append([]byte(MinisignAlgorithm), keyID...), publicKey...)
+
+	return []byte("untrusted comment: minisign public key\n" + base64.StdEncoding.EncodeToString(key) + "\n")
+}